@@ -2,11 +2,20 @@ package cmd
 
 import (
 	"fmt"
+	"geo-checker/internal/webpage"
 	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/annotate"
+	"geo-checker/pkg/archive"
+	"geo-checker/pkg/baseline"
 	"geo-checker/pkg/config"
 	"geo-checker/pkg/formatter"
 	"geo-checker/pkg/llm"
+	"geo-checker/pkg/review"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/search"
 	"geo-checker/pkg/ui"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -14,16 +23,111 @@ import (
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze [URL]",
 	Short: "Analyze a single webpage for GEO optimization",
-	Long:  "Analyze a single webpage using the specified LLM provider to assess GEO optimization opportunities",
-	Args:  cobra.ExactArgs(1),
+	Long: `Analyze a single webpage using the specified LLM provider to assess GEO optimization opportunities.
+
+Instead of a URL, --text scores the given copy (- reads it from stdin) and
+--clipboard the text on the clipboard, e.g. a draft answer paragraph.
+
+--archive-dir keeps the page as it was served, and --from-archive scores
+that copy again instead of the live page.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		url := args[0]
+		text, _ := cmd.Flags().GetString("text")
+		clipboard, _ := cmd.Flags().GetBool("clipboard")
+		inputs := len(args)
+		if text != "" {
+			inputs++
+		}
+		if clipboard {
+			inputs++
+		}
+		if inputs != 1 {
+			return fmt.Errorf("give one of a URL, --text or --clipboard")
+		}
+		url, source := "", ""
+		if len(args) == 1 {
+			url = args[0]
+		} else {
+			var err error
+			if clipboard {
+				text, err = readClipboard(cmd.Context())
+				source = "(clipboard)"
+			} else {
+				text, err = readTextInput(text)
+				source = "(text)"
+			}
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(text) == "" {
+				return fmt.Errorf("no text to analyze")
+			}
+		}
 		
 		provider, _ := cmd.Flags().GetString("provider")
 		model, _ := cmd.Flags().GetString("model")
 		output, _ := cmd.Flags().GetString("output")
 		mode, _ := cmd.Flags().GetString("mode")
 		interactive, _ := cmd.Flags().GetBool("interactive")
+		crawlerParity, _ := cmd.Flags().GetBool("crawler-parity")
+		inlineFrames, _ := cmd.Flags().GetBool("inline-frames")
+		skipImageCheck, _ := cmd.Flags().GetBool("skip-image-check")
+		condense, _ := cmd.Flags().GetBool("condense")
+		metaCaptures, _ := cmd.Flags().GetStringSlice("capture-meta")
+		if _, err := webpage.ParseMetaCaptures(metaCaptures); err != nil {
+			return err
+		}
+		selector, _ := cmd.Flags().GetString("selector")
+		if selector != "" {
+			if url == "" {
+				return fmt.Errorf("--selector only works when analyzing a URL")
+			}
+			if err := webpage.ValidateSelector(selector); err != nil {
+				return err
+			}
+		}
+		deterministic, _ := cmd.Flags().GetBool("deterministic")
+		noStream, _ := cmd.Flags().GetBool("no-stream")
+		formattingDrafts, _ := cmd.Flags().GetBool("formatting-drafts")
+		answerDraft, _ := cmd.Flags().GetBool("answer-draft")
+		framing, _ := cmd.Flags().GetBool("framing")
+		originality, _ := cmd.Flags().GetString("originality")
+		originalitySamples, _ := cmd.Flags().GetInt("originality-samples")
+		if originality != "" {
+			// Fail before any page is fetched when the API is not set up
+			if _, err := search.New(originality); err != nil {
+				return err
+			}
+		}
+		profile, _ := cmd.Flags().GetString("profile")
+		staleAfterDays, _ := cmd.Flags().GetInt("stale-after-days")
+		readingLevel, _ := cmd.Flags().GetString("reading-level")
+		if _, err := scorer.ParseReadingLevel(readingLevel); err != nil {
+			return err
+		}
+		framework, _ := cmd.Flags().GetString("framework")
+		if framework != "" {
+			if _, err := scorer.LoadFramework(framework); err != nil {
+				return err
+			}
+		}
+		classifyLLM, _ := cmd.Flags().GetBool("classify-llm")
+		thresholds, err := gateThresholds(cmd)
+		if err != nil {
+			return err
+		}
+		archiveDir, archiveFormat, err := archiveSettings(cmd)
+		if err != nil {
+			return err
+		}
+		fromArchive, _ := cmd.Flags().GetString("from-archive")
+		if fromArchive != "" && url == "" {
+			return fmt.Errorf("--from-archive needs the URL of the archived page")
+		}
+		
+		if _, err := scorer.ParseProfile(profile); err != nil {
+			return err
+		}
 		
 		// Interactive model selection
 		if interactive {
@@ -62,31 +166,168 @@ var analyzeCmd = &cobra.Command{
 		}
 		
 		cfg := &config.Config{
-			LLMProvider:  provider,
-			Model:        model,
-			OutputFormat: output,
-			Mode:         mode,
-			MaxTokens:    4000,
-			Temperature:  0.7,
-			Timeout:      30,
+			LLMProvider:        provider,
+			Model:              model,
+			OutputFormat:       output,
+			Mode:               mode,
+			MaxTokens:          4000,
+			Temperature:        0.7,
+			Timeout:            requestTimeout(cmd),
+			CrawlerParity:      crawlerParity,
+			InlineFrames:       inlineFrames,
+			SkipImageCheck:     skipImageCheck,
+			Condense:           condense,
+			MetaCaptures:       metaCaptures,
+			Selector:           selector,
+			Deterministic:      deterministic,
+			Stream:             output == "text" && !noStream,
+			FormattingDrafts:   formattingDrafts,
+			AnswerDraft:        answerDraft,
+			Framing:            framing,
+			Originality:        originality,
+			OriginalitySamples: originalitySamples,
+			Profile:            profile,
+			StaleAfterDays:     staleAfterDays,
+			ReadingLevel:       readingLevel,
+			Framework:          framework,
+			ClassifyWithLLM:    classifyLLM,
+			ArchiveDir:         archiveDir,
+			ArchiveFormat:      archiveFormat,
 		}
 		
+		var result *analyzer.Result
+		var reviewState *review.State
 		analyzer := analyzer.New(cfg)
-		result, err := analyzer.AnalyzeURL(url)
-		if err != nil {
-			return fmt.Errorf("failed to analyze URL: %w", err)
+		if fromArchive != "" {
+			resp, err := archive.Load(fromArchive, url)
+			if err != nil {
+				return err
+			}
+			result, err = analyzer.AnalyzeArchived(cmd.Context(), url, resp)
+			if err != nil {
+				return fmt.Errorf("failed to analyze archived page: %w", err)
+			}
+			// The history follows the live page; an old copy scored again
+			// is not a run of it
+			if reviewState, err = applyReview(cmd, []string{url}, result); err != nil {
+				return err
+			}
+		} else if url != "" {
+			result, err = analyzer.AnalyzeURL(cmd.Context(), url)
+			if err != nil {
+				return fmt.Errorf("failed to analyze URL: %w", err)
+			}
+			recordHistory(cmd, result)
+			if reviewState, err = applyReview(cmd, []string{url}, result); err != nil {
+				return err
+			}
+		} else {
+			title, _ := cmd.Flags().GetString("title")
+			result, err = analyzer.AnalyzeContent(cmd.Context(), text, title)
+			if err != nil {
+				return fmt.Errorf("failed to analyze text: %w", err)
+			}
+			// Reports show where the text came from in place of a URL
+			result.URL = source
 		}
 		
 		formatter := formatter.New(output)
 		fmt.Print(formatter.FormatAnalysisResult(result))
-		return nil
+		if reviewState != nil && output == "text" {
+			printReviewProgress(reviewState.Progress(result.URL))
+		}
+		
+		if annotateFile, _ := cmd.Flags().GetString("annotate"); annotateFile != "" {
+			source, _ := cmd.Flags().GetString("annotate-source")
+			style, _ := cmd.Flags().GetString("annotate-style")
+			if err := writeAnnotated(cmd, url, result, annotateFile, source, style); err != nil {
+				return err
+			}
+		}
+		return enforceGate(cmd, thresholds, []baseline.Page{baseline.PageOf(result.URL, result, nil)})
 	},
 }
 
+// writeAnnotated writes a copy of the page's source with the findings as
+// comments to file: the given source file (HTML or markdown, by
+// extension) or else the HTML fetched from url.
+func writeAnnotated(cmd *cobra.Command, url string, result *analyzer.Result, file, source, style string) error {
+	if style != annotate.StyleComment && style != annotate.StyleCritic {
+		return fmt.Errorf("unknown --annotate-style %q (comment, critic)", style)
+	}
+	if source == "" && url == "" {
+		return fmt.Errorf("--annotate needs --annotate-source when analyzing text")
+	}
+	
+	var src string
+	if source != "" {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return fmt.Errorf("failed to read annotation source: %w", err)
+		}
+		src = string(data)
+	} else {
+		html, err := webpage.New().FetchHTML(cmd.Context(), url)
+		if err != nil {
+			return fmt.Errorf("failed to fetch HTML to annotate: %w", err)
+		}
+		src = html
+	}
+	
+	var annotated string
+	var placed int
+	if annotate.IsMarkdown(source) {
+		var err error
+		if annotated, placed, err = annotate.Markdown(src, result.LocalScore.Findings, style); err != nil {
+			return err
+		}
+	} else {
+		if style != annotate.StyleComment {
+			return fmt.Errorf("--annotate-style %s needs a markdown --annotate-source", style)
+		}
+		annotated, placed = annotate.HTML(src, result.LocalScore.Findings)
+	}
+	
+	if err := os.WriteFile(file, []byte(annotated), 0o644); err != nil {
+		return fmt.Errorf("failed to write annotated copy: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "📝 Annotated copy written to %s (%d of %d findings placed inline)\n", file, placed, len(result.LocalScore.Findings))
+	return nil
+}
+
 func init() {
-	analyzeCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, local)")
+	analyzeCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, gemini, local, ollama)")
 	analyzeCmd.Flags().StringP("model", "m", "", "Model to use (leave empty for recommended model)")
 	analyzeCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown)")
 	analyzeCmd.Flags().StringP("mode", "", "auto", "Analysis mode (auto, local, llm, hybrid)")
 	analyzeCmd.Flags().BoolP("interactive", "i", false, "Interactive model selection")
+	analyzeCmd.Flags().String("text", "", "Analyze this text instead of a URL (- reads it from stdin)")
+	analyzeCmd.Flags().Bool("clipboard", false, "Analyze the text on the clipboard instead of a URL")
+	analyzeCmd.Flags().String("title", "", "Title of the text given with --text or --clipboard, e.g. the question it answers")
+	analyzeCmd.Flags().Bool("crawler-parity", false, "Also fetch as a browser and as GPTBot and flag pages AI crawlers get less content from")
+	analyzeCmd.Flags().Bool("inline-frames", false, "Fetch same-origin iframes (embedded docs, schedules, calculators) and analyze their text with the page")
+	analyzeCmd.Flags().StringSlice("capture-meta", nil, "Additional metadata to record with the meta tags: meta:ATTR (e.g. meta:itemprop), link:REL (e.g. link:author) or data-NAME (e.g. data-cms-template)")
+	analyzeCmd.Flags().Bool("condense", false, "Strip repeated navigation text, compress whitespace and summarize long tables in the content sent to the LLM to use fewer tokens (llm and hybrid modes)")
+	analyzeCmd.Flags().Bool("skip-image-check", false, "Do not fetch the og:image to confirm it loads and measure it")
+	analyzeCmd.Flags().String("selector", "", "CSS selector of the region to analyze, e.g. \"#docs-content\", leaving out template chrome around it")
+	analyzeCmd.Flags().String("annotate", "", "Write a copy of the page's HTML (or of --annotate-source) with each finding as a comment where its evidence is")
+	analyzeCmd.Flags().String("annotate-source", "", "HTML or markdown file the page is built from, to annotate instead of the fetched HTML")
+	analyzeCmd.Flags().String("annotate-style", annotate.StyleComment, "Annotation style: comment (<!-- GEO: ... -->) or critic (CriticMarkup, markdown only)")
+	analyzeCmd.Flags().Bool("no-stream", false, "Show the LLM's answer when it is complete instead of as it is generated (text output)")
+	analyzeCmd.Flags().Bool("deterministic", false, "Temperature 0, cached LLM responses and no timestamps, so repeated runs on unchanged content produce identical reports")
+	analyzeCmd.Flags().String("profile", "auto", "Scoring profile (auto, general, docs, product, news, local-business, category); auto picks one per page from its detected type")
+	analyzeCmd.Flags().Int("stale-after-days", 365, "Age in days from which pages without a recent update are told to review their content")
+	analyzeCmd.Flags().String("reading-level", "", "Target audience reading level: elementary, general, college, expert or grade-N (default: grade 12 for docs, general otherwise)")
+	analyzeCmd.Flags().Bool("classify-llm", false, "Ask the LLM for the page type when --profile auto is unsure (llm and hybrid modes)")
+	analyzeCmd.Flags().Bool("formatting-drafts", false, "Ask the LLM to draft tables and lists for prose the formatting advisor flags (llm and hybrid modes)")
+	analyzeCmd.Flags().String("framework", "", "YAML file renaming pillars, overriding their weights and defining custom pillars (see examples/framework.yaml)")
+	analyzeCmd.Flags().String("originality", "", "Search distinctive sentences with this search API (brave, google) and report how much of the page appears word for word on other sites")
+	analyzeCmd.Flags().Int("originality-samples", 5, "Number of sentences --originality searches for")
+	analyzeCmd.Flags().Bool("framing", false, "Ask the LLM whether the page states the consensus and its own position, and flag unattributed strong claims (llm and hybrid modes)")
+	analyzeCmd.Flags().Bool("answer-draft", false, "Ask the LLM to draft the direct answer paragraph for pages that do not open with one (llm and hybrid modes)")
+	addHistoryFlags(analyzeCmd)
+	addGateFlags(analyzeCmd)
+	addArchiveFlags(analyzeCmd)
+	analyzeCmd.Flags().String("from-archive", "", "Score the URL as archived with --archive-dir instead of fetching it: the archive directory, one of its .html files, or a .warc.gz file")
+	addReviewFlags(analyzeCmd)
 }
\ No newline at end of file
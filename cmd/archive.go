@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+	"geo-checker/pkg/archive"
+
+	"github.com/spf13/cobra"
+)
+
+// addArchiveFlags adds the flags of commands that can keep the responses
+// of the pages they fetch.
+func addArchiveFlags(cmd *cobra.Command) {
+	cmd.Flags().String("archive-dir", "", "Keep the raw response of every page fetched in this directory, so it can be shown and rescored as it was (see --from-archive)")
+	cmd.Flags().String("archive-format", archive.FormatHTML, "Archive format: html (one file per distinct page, with an index.jsonl) or warc (WARC/1.1 records in a daily .warc.gz)")
+}
+
+// archiveSettings reads --archive-dir and --archive-format.
+func archiveSettings(cmd *cobra.Command) (dir, format string, err error) {
+	dir, _ = cmd.Flags().GetString("archive-dir")
+	format, _ = cmd.Flags().GetString("archive-format")
+	if format != archive.FormatHTML && format != archive.FormatWARC {
+		return "", "", fmt.Errorf("unknown --archive-format %q (html, warc)", format)
+	}
+	return dir, format, nil
+}
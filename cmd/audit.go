@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/pkg/audit"
+	"geo-checker/pkg/llm"
+
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit [definition file]",
+	Short: "Run a complete audit from a definition file and exit with a status code",
+	Long: `Run an end-to-end audit (analysis, thresholds, exports and notifications)
+described in a single YAML or JSON file, without prompts. Designed as the
+entrypoint of scheduled containers such as Kubernetes CronJobs.
+
+Exit codes:
+  0  all thresholds met and every export/notification delivered
+  1  the definition is invalid or the run could not start
+  2  a score threshold (min_average, min_page_score) was not met, or the
+     average fell more than max_drop points below the compared report
+  3  more URLs failed to analyze than thresholds.max_failed allows
+  4  thresholds met, but an export or notification failed
+
+Unknown keys in the definition are errors. See examples/audit.yaml for the
+full format.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+
+		def, err := audit.Load(args[0])
+		if err != nil {
+			return err
+		}
+		if def.Model == "" && def.Mode != "local" {
+			def.Model = llm.GetRecommendedModel(def.Provider)
+		}
+
+		outcome, err := audit.Run(cmd.Context(), def)
+		if err != nil {
+			return err
+		}
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(outcome, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Printf("Audit %q: %d URLs, %d failed, average score %d\n", outcome.Name, outcome.Total, outcome.Failed, outcome.Average)
+			for _, v := range outcome.Violations {
+				fmt.Printf("  threshold: %s\n", v)
+			}
+			for _, d := range outcome.Delivery {
+				fmt.Printf("  delivery: %s\n", d)
+			}
+		}
+
+		if outcome.ExitCode != audit.ExitOK {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &ExitError{Code: outcome.ExitCode, Err: fmt.Errorf("audit %q failed with exit code %d", outcome.Name, outcome.ExitCode)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	auditCmd.Flags().StringP("output", "o", "text", "Summary format (text, json)")
+	rootCmd.AddCommand(auditCmd)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/baseline"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scanner"
+	"geo-checker/pkg/scorer"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline [url-file|directory]",
+	Short: "Record the scores of a set of pages for later checks",
+	Long: `Analyze every URL of a file (one per line) or every HTML file under a
+directory and write their scores, with each pillar's, to a baseline file.
+Commit it next to the content; "check --baseline" then reports the pages
+whose score dropped since.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, _ := cmd.Flags().GetString("out")
+		mode, _ := cmd.Flags().GetString("mode")
+		profile, _ := cmd.Flags().GetString("profile")
+		if _, err := scorer.ParseProfile(profile); err != nil {
+			return err
+		}
+
+		snapshot := &baseline.Snapshot{
+			CreatedAt: time.Now().UTC(),
+			Source:    args[0],
+			Mode:      mode,
+			Profile:   profile,
+		}
+		pages, directory, err := scorePages(cmd, snapshot.Source, mode, profile)
+		if err != nil {
+			return err
+		}
+		snapshot.Pages, snapshot.Directory = pages, directory
+		if err := snapshot.Save(out); err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, p := range pages {
+			if p.Error != "" {
+				failed++
+			}
+		}
+		fmt.Printf("Wrote the scores of %d pages to %s\n", len(pages), out)
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d pages could not be analyzed and will not be checked\n", failed)
+		}
+		return nil
+	},
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check [url-file|directory]",
+	Short: "Report pages whose score dropped since a baseline",
+	Long: `Analyze the pages of a baseline again, or those of the URL file or
+directory given, and print only the pages whose score dropped by more than
+--max-regression points, with the pillars that dropped, and those that no
+longer analyze. The mode and profile the baseline was taken with are reused.
+
+Exits with status 2 when any page regressed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("baseline")
+		maxRegression, _ := cmd.Flags().GetInt("max-regression")
+		output, _ := cmd.Flags().GetString("output")
+		if maxRegression < 0 {
+			return fmt.Errorf("--max-regression must not be negative")
+		}
+
+		snapshot, err := baseline.Load(path)
+		if err != nil {
+			return err
+		}
+		source := snapshot.Source
+		if len(args) == 1 {
+			source = args[0]
+		}
+		pages, _, err := scorePages(cmd, source, snapshot.Mode, snapshot.Profile)
+		if err != nil {
+			return err
+		}
+		report := snapshot.Compare(pages, maxRegression)
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			printRegressions(report)
+		}
+
+		if !report.Passed() {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &ExitError{Code: 2, Err: fmt.Errorf("%d pages regressed", len(report.Regressions))}
+		}
+		return nil
+	},
+}
+
+// scorePages analyzes the pages of a URL file or a directory. Progress is
+// not shown, so the only output is what the command reports.
+func scorePages(cmd *cobra.Command, source, mode, profile string) ([]baseline.Page, bool, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, false, err
+	}
+	provider, _ := cmd.Flags().GetString("provider")
+	model, _ := cmd.Flags().GetString("model")
+	concurrent, _ := cmd.Flags().GetInt("concurrent")
+	extensions, _ := cmd.Flags().GetStringSlice("ext")
+
+	cfg := &config.Config{
+		LLMProvider:  provider,
+		Model:        model,
+		OutputFormat: "json",
+		Mode:         mode,
+		Concurrent:   concurrent,
+		Extensions:   extensions,
+		Profile:      profile,
+		MaxTokens:    4000,
+		Temperature:  0.7,
+		Timeout:      requestTimeout(cmd),
+		Quiet:        true,
+	}
+	ctx := cmd.Context()
+
+	var pages []baseline.Page
+	if info.IsDir() {
+		results, err := scanner.New(cfg).ScanDirectory(ctx, source)
+		if err != nil {
+			return nil, true, fmt.Errorf("failed to scan directory: %w", err)
+		}
+		for _, r := range results {
+			pages = append(pages, baseline.PageOf(r.FilePath, r.Result, r.Error))
+		}
+		return pages, true, nil
+	}
+
+	results, err := bulk.New(cfg).ProcessFile(ctx, source)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, r := range results {
+		pages = append(pages, baseline.PageOf(r.URL, r.Result, r.Error))
+	}
+	return pages, false, nil
+}
+
+func printRegressions(report *baseline.Report) {
+	if report.ScorerChanged {
+		fmt.Fprintf(os.Stderr, "Warning: the scoring rules changed since the baseline was taken (now %s); write it again once the changes are reviewed\n", scorer.Version)
+	}
+	for _, r := range report.Regressions {
+		if r.Error != "" {
+			fmt.Printf("%s: %d -> failed: %s\n", r.Key, r.Before, r.Error)
+			continue
+		}
+		fmt.Printf("%s: %d -> %d (%+d)\n", r.Key, r.Before, r.After, r.Delta)
+		var pillars []string
+		for _, p := range r.Pillars {
+			pillars = append(pillars, fmt.Sprintf("%s %d -> %d", p.Pillar, p.Before, p.After))
+		}
+		if len(pillars) > 0 {
+			fmt.Printf("    %s\n", strings.Join(pillars, ", "))
+		}
+	}
+	if len(report.Missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d baseline pages were not analyzed\n", len(report.Missing))
+	}
+	if !report.Passed() {
+		fmt.Printf("\n%d of %d pages regressed by more than %d points\n", len(report.Regressions), report.Checked, report.MaxRegression)
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{baselineCmd, checkCmd} {
+		c.Flags().StringP("provider", "p", "claude", "LLM provider (claude, gpt, gemini, local)")
+		c.Flags().StringP("model", "m", "claude-3-sonnet", "Model to use")
+		c.Flags().IntP("concurrent", "c", 5, "Number of concurrent requests, for URL files")
+		c.Flags().StringSliceP("ext", "e", []string{".html", ".htm"}, "File extensions to scan, for directories")
+		rootCmd.AddCommand(c)
+	}
+	baselineCmd.Flags().StringP("out", "o", "baseline.json", "Baseline file to write")
+	baselineCmd.Flags().String("mode", "local", "Analysis mode (local, llm, hybrid); local scores are the most stable")
+	baselineCmd.Flags().String("profile", "auto", "Scoring profile (auto, general, docs, product, news, local-business, category)")
+	checkCmd.Flags().String("baseline", "baseline.json", "Baseline file to check against")
+	checkCmd.Flags().Int("max-regression", 5, "Score drop in points tolerated before a page counts as regressed")
+	checkCmd.Flags().StringP("output", "o", "text", "Report format (text, json)")
+}
@@ -1,12 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"geo-checker/internal/bulk"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/baseline"
 	"geo-checker/pkg/config"
+	"geo-checker/pkg/export"
 	"geo-checker/pkg/formatter"
 	"geo-checker/pkg/llm"
+	"geo-checker/pkg/notify"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/search"
+	"geo-checker/pkg/tickets"
 	"geo-checker/pkg/ui"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -14,17 +25,116 @@ import (
 var bulkCmd = &cobra.Command{
 	Use:   "bulk [file]",
 	Short: "Analyze multiple URLs from a file",
-	Long:  "Analyze multiple URLs provided in a file (one URL per line) for GEO optimization",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Analyze multiple URLs provided in a file (one URL per line), the pages of a Search Console property (--gsc-site), or documents given as JSON with --json-input, for GEO optimization",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		file := args[0]
+		jsonInput, _ := cmd.Flags().GetString("json-input")
+		retryFailed, _ := cmd.Flags().GetString("retry-failed")
+		inputs := len(args)
+		for _, input := range []string{jsonInput, retryFailed} {
+			if input != "" {
+				inputs++
+			}
+		}
+		gscSite, _ := cmd.Flags().GetString("gsc-site")
+		fromSearchConsole := inputs == 0 && gscSite != ""
+		if fromSearchConsole {
+			inputs++
+		}
+		if inputs != 1 {
+			return fmt.Errorf("give either a file of URLs, --json-input, --retry-failed or --gsc-site")
+		}
+		file := jsonInput
+		if len(args) == 1 {
+			file = args[0]
+		}
 		
 		provider, _ := cmd.Flags().GetString("provider")
 		model, _ := cmd.Flags().GetString("model")
 		output, _ := cmd.Flags().GetString("output")
 		mode, _ := cmd.Flags().GetString("mode")
 		concurrent, _ := cmd.Flags().GetInt("concurrent")
+		retries, _ := cmd.Flags().GetInt("retries")
 		interactive, _ := cmd.Flags().GetBool("interactive")
+		crawlerParity, _ := cmd.Flags().GetBool("crawler-parity")
+		inlineFrames, _ := cmd.Flags().GetBool("inline-frames")
+		skipImageCheck, _ := cmd.Flags().GetBool("skip-image-check")
+		condense, _ := cmd.Flags().GetBool("condense")
+		metaCaptures, _ := cmd.Flags().GetStringSlice("capture-meta")
+		if _, err := webpage.ParseMetaCaptures(metaCaptures); err != nil {
+			return err
+		}
+		deterministic, _ := cmd.Flags().GetBool("deterministic")
+		profile, _ := cmd.Flags().GetString("profile")
+		staleAfterDays, _ := cmd.Flags().GetInt("stale-after-days")
+		readingLevel, _ := cmd.Flags().GetString("reading-level")
+		if _, err := scorer.ParseReadingLevel(readingLevel); err != nil {
+			return err
+		}
+		framework, _ := cmd.Flags().GetString("framework")
+		if framework != "" {
+			f, err := scorer.LoadFramework(framework)
+			if err != nil {
+				return err
+			}
+			// Reports only carry these per page, in JSON
+			for _, warning := range f.Warnings() {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+			}
+		}
+		classifyLLM, _ := cmd.Flags().GetBool("classify-llm")
+		framing, _ := cmd.Flags().GetBool("framing")
+		originality, _ := cmd.Flags().GetString("originality")
+		originalitySamples, _ := cmd.Flags().GetInt("originality-samples")
+		if originality != "" {
+			// Fail before any page is fetched when the API is not set up
+			if _, err := search.New(originality); err != nil {
+				return err
+			}
+		}
+		sheetsID, _ := cmd.Flags().GetString("sheets-id")
+		sheetsRange, _ := cmd.Flags().GetString("sheets-range")
+		sheetsCredentials, _ := cmd.Flags().GetString("sheets-credentials")
+		tracker, _ := cmd.Flags().GetString("tickets")
+		ticketProject, _ := cmd.Flags().GetString("ticket-project")
+		ticketLabels, _ := cmd.Flags().GetStringSlice("ticket-labels")
+		ticketAll, _ := cmd.Flags().GetBool("ticket-all")
+		emailTo, _ := cmd.Flags().GetStringSlice("email-to")
+		emailSubject, _ := cmd.Flags().GetString("email-subject")
+		project, _ := cmd.Flags().GetString("project")
+		compare, _ := cmd.Flags().GetString("compare")
+		spillFile, _ := cmd.Flags().GetString("spill")
+		resume, _ := cmd.Flags().GetBool("resume")
+		if resume && spillFile == "" {
+			return fmt.Errorf("--resume requires --spill")
+		}
+		if len(args) == 0 && spillFile != "" {
+			return fmt.Errorf("--spill only works with a file of URLs")
+		}
+		if _, err := scorer.ParseProfile(profile); err != nil {
+			return err
+		}
+		deepDiveThreshold, _ := cmd.Flags().GetInt("deep-dive-threshold")
+		deepDivePercent, _ := cmd.Flags().GetInt("deep-dive-percent")
+		if deepDiveThreshold < 0 || deepDiveThreshold > 100 || deepDivePercent < 0 || deepDivePercent > 100 {
+			return fmt.Errorf("--deep-dive-threshold and --deep-dive-percent must be between 0 and 100")
+		}
+		if (deepDiveThreshold > 0 || deepDivePercent > 0) && mode == "local" {
+			return fmt.Errorf("a deep dive analyzes pages with an LLM and cannot be used with --mode local")
+		}
+		thresholds, err := gateThresholds(cmd)
+		if err != nil {
+			return err
+		}
+		archiveDir, archiveFormat, err := archiveSettings(cmd)
+		if err != nil {
+			return err
+		}
+		// Weights are read before the run so a bad file fails it early
+		weights, err := loadWeights(cmd)
+		if err != nil {
+			return err
+		}
 		
 		// Interactive model selection
 		if interactive {
@@ -60,37 +170,252 @@ var bulkCmd = &cobra.Command{
 			fmt.Printf("Provider: %s\n", provider)
 			fmt.Printf("Model: %s\n", model)
 			fmt.Printf("Mode: %s\n", mode)
-			fmt.Printf("Concurrent requests: %d\n\n", concurrent)
+			fmt.Printf("Concurrent requests: %d\n", concurrent)
+			fmt.Printf("Retries: %d\n", retries)
+			if deepDiveThreshold > 0 || deepDivePercent > 0 {
+				fmt.Printf("Deep dive: %s\n", deepDiveLabel(deepDiveThreshold, deepDivePercent))
+			}
+			fmt.Println()
 		}
 		
 		cfg := &config.Config{
-			LLMProvider:  provider,
-			Model:        model,
-			OutputFormat: output,
-			Mode:         mode,
-			Concurrent:   concurrent,
-			MaxTokens:    4000,
-			Temperature:  0.7,
-			Timeout:      30,
+			LLMProvider:        provider,
+			Model:              model,
+			OutputFormat:       output,
+			Mode:               mode,
+			Concurrent:         concurrent,
+			Retries:            retries,
+			DeepDiveThreshold:  deepDiveThreshold,
+			DeepDivePercent:    deepDivePercent,
+			MaxTokens:          4000,
+			Temperature:        0.7,
+			Timeout:            requestTimeout(cmd),
+			CrawlerParity:      crawlerParity,
+			InlineFrames:       inlineFrames,
+			SkipImageCheck:     skipImageCheck,
+			Condense:           condense,
+			MetaCaptures:       metaCaptures,
+			Deterministic:      deterministic,
+			Profile:            profile,
+			StaleAfterDays:     staleAfterDays,
+			ReadingLevel:       readingLevel,
+			Framework:          framework,
+			ClassifyWithLLM:    classifyLLM,
+			ArchiveDir:         archiveDir,
+			ArchiveFormat:      archiveFormat,
+			Framing:            framing,
+			Originality:        originality,
+			OriginalitySamples: originalitySamples,
 		}
 		
 		processor := bulk.New(cfg)
-		results, err := processor.ProcessFile(file)
+		var results []*bulk.BulkResult
+		if jsonInput != "" {
+			var docs []bulk.Document
+			if docs, err = bulk.ReadDocumentFile(jsonInput); err == nil {
+				results, err = processor.ProcessDocuments(cmd.Context(), docs)
+			}
+		} else if retryFailed != "" {
+			results, err = retryFailedURLs(cmd.Context(), processor, retryFailed)
+		} else if fromSearchConsole {
+			results, err = processSearchConsole(cmd, processor)
+		} else if spillFile != "" {
+			results, err = processWithSpill(cmd.Context(), processor, file, spillFile, resume)
+		} else {
+			results, err = processor.ProcessFile(cmd.Context(), file)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to process bulk URLs: %w", err)
 		}
+		var analyzed []*analyzer.Result
+		for _, r := range results {
+			analyzed = append(analyzed, r.Result)
+		}
+		recordHistory(cmd, analyzed...)
+		pages := make([]baseline.Page, 0, len(results))
+		var keys []string
+		for _, r := range results {
+			key := r.URL
+			if r.ID != "" {
+				key = r.ID
+			}
+			pages = append(pages, baseline.PageOf(key, r.Result, r.Error))
+			keys = append(keys, key)
+		}
+		reviewState, err := applyReview(cmd, keys, analyzed...)
+		if err != nil {
+			return err
+		}
 		
 		formatter := formatter.New(output)
 		fmt.Print(formatter.FormatBulkResults(results))
-		return nil
+		if output == "json" {
+			// Keep stdout a single JSON document
+			fmt.Fprint(os.Stderr, formatter.FormatProviderHealth(processor.ProviderHealth()))
+		} else {
+			fmt.Print(formatter.FormatProviderHealth(processor.ProviderHealth()))
+		}
+		if weights != nil {
+			scorecard := formatter.FormatScorecard(scorecardOf(cmd, results, weights))
+			if output == "json" {
+				fmt.Fprint(os.Stderr, scorecard)
+			} else {
+				fmt.Print(scorecard)
+			}
+		}
+		if reviewState != nil && output == "text" {
+			printReviewProgress(reviewState.Progress(""))
+		}
+		
+		if sheetsID != "" {
+			exporter, err := export.NewSheetsExporter(sheetsID, sheetsRange, sheetsCredentials)
+			if err != nil {
+				return fmt.Errorf("failed to configure Google Sheets export: %w", err)
+			}
+			if err := exporter.Append(cmd.Context(), results); err != nil {
+				return fmt.Errorf("failed to export results to Google Sheets: %w", err)
+			}
+		}
+		
+		if len(emailTo) > 0 {
+			smtpCfg, err := notify.SMTPConfigFromEnv()
+			if err != nil {
+				return err
+			}
+			var previous []*bulk.BulkResult
+			if compare != "" {
+				previous, err = bulk.LoadReport(compare)
+				if err != nil {
+					return err
+				}
+			}
+			if emailSubject == "" {
+				emailSubject = "GEO audit digest"
+				if project != "" {
+					emailSubject += ": " + project
+				}
+			}
+			digest := notify.BuildDigest(project, results, previous, 5)
+			if err := notify.SendDigest(smtpCfg, emailTo, emailSubject, digest); err != nil {
+				return err
+			}
+		}
+		
+		if tracker != "" {
+			opts := tickets.Options{Project: ticketProject, Labels: ticketLabels, All: ticketAll}
+			t, err := tickets.NewTracker(tracker, opts)
+			if err != nil {
+				return fmt.Errorf("failed to configure ticket tracker: %w", err)
+			}
+			report := tickets.Sync(cmd.Context(), t, tickets.BuildIssues(results, opts))
+			if output == "text" {
+				fmt.Printf("Tickets: %d created, %d already filed, %d failed\n",
+					len(report.Created), report.Skipped, len(report.Errors))
+			}
+			if len(report.Errors) > 0 {
+				return fmt.Errorf("failed to create %d tickets: %w", len(report.Errors), report.Errors[0])
+			}
+		}
+		
+		return enforceGate(cmd, thresholds, pages)
 	},
 }
 
+// processWithSpill runs the bulk analysis streaming full results to
+// spillFile, keeping only compact summaries in memory for the reports below.
+func processWithSpill(ctx context.Context, processor *bulk.Processor, file, spillFile string, resume bool) ([]*bulk.BulkResult, error) {
+	urls, err := bulk.ReadURLFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read URLs from file: %w", err)
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no URLs found in file")
+	}
+	
+	spill, done, err := bulk.OpenSpill(spillFile, resume)
+	if err != nil {
+		return nil, err
+	}
+	defer spill.Close()
+	
+	return processor.ProcessURLsSpill(ctx, urls, spill, done)
+}
+
+// retryFailedURLs analyzes the URLs that failed in a previous report again
+// and returns the report with their new results merged in.
+func retryFailedURLs(ctx context.Context, processor *bulk.Processor, report string) ([]*bulk.BulkResult, error) {
+	previous, err := bulk.LoadReport(report)
+	if err != nil {
+		return nil, err
+	}
+	urls := bulk.FailedURLs(previous)
+	if len(urls) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: no failed URLs to retry in %s\n", report)
+		return previous, nil
+	}
+
+	retried, err := processor.ProcessURLs(ctx, urls)
+	if err != nil {
+		return nil, err
+	}
+	return bulk.MergeRetried(previous, retried), nil
+}
+
+// deepDiveLabel describes the pages a two-pass run analyzes with the LLM.
+func deepDiveLabel(threshold, percent int) string {
+	var parts []string
+	if threshold > 0 {
+		parts = append(parts, fmt.Sprintf("pages scoring below %d", threshold))
+	}
+	if percent > 0 {
+		parts = append(parts, fmt.Sprintf("the lowest-scoring %d%%", percent))
+	}
+	return strings.Join(parts, " and ")
+}
+
 func init() {
-	bulkCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, local)")
+	bulkCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, gemini, local, ollama)")
 	bulkCmd.Flags().StringP("model", "m", "", "Model to use (leave empty for recommended model)")
 	bulkCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown)")
 	bulkCmd.Flags().StringP("mode", "", "auto", "Analysis mode (auto, local, llm, hybrid)")
 	bulkCmd.Flags().IntP("concurrent", "c", 5, "Number of concurrent requests")
+	bulkCmd.Flags().Int("retries", 2, "Re-attempt URLs that failed with a retryable error (timeouts, connection errors, 429, 5xx) up to this many times")
+	bulkCmd.Flags().Int("deep-dive-threshold", 0, "Score every page locally first and analyze only pages scoring below this with the LLM (0 for no threshold)")
+	bulkCmd.Flags().Int("deep-dive-percent", 0, "Score every page locally first and analyze only the lowest-scoring N% with the LLM (0 for none)")
 	bulkCmd.Flags().BoolP("interactive", "i", false, "Interactive model selection")
+	bulkCmd.Flags().String("json-input", "", "Analyze the documents in this JSON file, an array of {id, title, content} objects (content as text or HTML), instead of fetching URLs")
+	bulkCmd.Flags().String("spill", "", "Stream full results to this NDJSON file as they complete and keep only summaries in memory")
+	bulkCmd.Flags().Bool("resume", false, "Skip URLs already recorded in the --spill file from an interrupted run")
+	bulkCmd.Flags().Int("gsc-limit", 100, "Without a file, analyze this many of the most clicked pages of the --gsc-site property (0 for all)")
+	bulkCmd.Flags().String("retry-failed", "", "Analyze again only the URLs that failed in this previous JSON report (or --spill file) and print the report with their new results merged in")
+	bulkCmd.Flags().String("profile", "auto", "Scoring profile (auto, general, docs, product, news, local-business, category); auto picks one per page from its detected type")
+	bulkCmd.Flags().Int("stale-after-days", 365, "Age in days from which pages without a recent update are told to review their content")
+	bulkCmd.Flags().String("reading-level", "", "Target audience reading level: elementary, general, college, expert or grade-N (default: grade 12 for docs, general otherwise)")
+	bulkCmd.Flags().String("framework", "", "YAML file renaming pillars, overriding their weights and defining custom pillars (see examples/framework.yaml)")
+	bulkCmd.Flags().String("originality", "", "Search distinctive sentences of each page with this search API (brave, google) and report how much appears word for word on other sites")
+	bulkCmd.Flags().Int("originality-samples", 5, "Number of sentences --originality searches for per page")
+	bulkCmd.Flags().Bool("framing", false, "Ask the LLM whether each page states the consensus and its own position, and flag unattributed strong claims (llm and hybrid modes)")
+	bulkCmd.Flags().Bool("classify-llm", false, "Ask the LLM for the page type when --profile auto is unsure (llm and hybrid modes)")
+	bulkCmd.Flags().Bool("crawler-parity", false, "Also fetch as a browser and as GPTBot and flag pages AI crawlers get less content from")
+	bulkCmd.Flags().Bool("inline-frames", false, "Fetch same-origin iframes (embedded docs, schedules, calculators) and analyze their text with the page")
+	bulkCmd.Flags().StringSlice("capture-meta", nil, "Additional metadata to record with the meta tags: meta:ATTR (e.g. meta:itemprop), link:REL (e.g. link:author) or data-NAME (e.g. data-cms-template)")
+	bulkCmd.Flags().Bool("condense", false, "Strip repeated navigation text, compress whitespace and summarize long tables in the content sent to the LLM to use fewer tokens (llm and hybrid modes)")
+	bulkCmd.Flags().Bool("skip-image-check", false, "Do not fetch the og:image to confirm it loads and measure it")
+	bulkCmd.Flags().Bool("deterministic", false, "Temperature 0, cached LLM responses and no timestamps, so repeated runs on unchanged content produce identical reports")
+	bulkCmd.Flags().String("sheets-id", "", "Google Sheets spreadsheet ID to append results to")
+	bulkCmd.Flags().String("sheets-range", "Sheet1", "Sheet name or A1 range to append rows to")
+	bulkCmd.Flags().String("sheets-credentials", "", "Service-account key file (defaults to GOOGLE_APPLICATION_CREDENTIALS)")
+	bulkCmd.Flags().StringSlice("email-to", nil, "Email a digest of the run to these addresses (SMTP_* environment variables)")
+	bulkCmd.Flags().String("email-subject", "", "Subject line for the email digest")
+	bulkCmd.Flags().String("project", "", "Project name used in digests and reports")
+	bulkCmd.Flags().String("compare", "", "Previous JSON report to compute score deltas against")
+	bulkCmd.Flags().String("tickets", "", "Create issues from findings in a tracker (jira, linear)")
+	bulkCmd.Flags().String("ticket-project", "", "Jira project key or Linear team ID for created issues")
+	bulkCmd.Flags().StringSlice("ticket-labels", nil, "Additional labels for created issues")
+	bulkCmd.Flags().Bool("ticket-all", false, "Ticket every suggestion instead of only high-severity weaknesses")
+	addHistoryFlags(bulkCmd)
+	addGateFlags(bulkCmd)
+	addArchiveFlags(bulkCmd)
+	addReviewFlags(bulkCmd)
+	addWeightFlags(bulkCmd)
 }
\ No newline at end of file
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/bundle"
+	"geo-checker/pkg/history"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var exportBundleCmd = &cobra.Command{
+	Use:   "export-bundle <report>",
+	Short: "Pack an audit into one archive to hand over or keep",
+	Long: `Pack the results of a bulk JSON report (or --spill file) into a single
+.tar.gz bundle (--file, by default geo-audit-<date>.tar.gz) with:
+
+  results.json     the results, as a bulk JSON report
+  manifests.json   the tool versions and configuration that produced them
+  history.jsonl    the recorded score history of the bundled pages
+  content/         the extracted content of every page
+  manifest.json    the pages, their snapshots and the checksum of every file
+
+Results keep no page content: the content of pages analyzed with
+--archive-dir is read from their archived response, and other pages are
+fetched again, as the analyses extracted them; pages whose content changed
+since they were scored are marked changed. --no-content leaves the
+snapshots out.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("file")
+		noContent, _ := cmd.Flags().GetBool("no-content")
+		noHistory, _ := cmd.Flags().GetBool("no-history")
+		if output == "" {
+			output = fmt.Sprintf("geo-audit-%s.tar.gz", time.Now().Format("2006-01-02"))
+		}
+
+		results, err := bulk.LoadReport(args[0])
+		if err != nil {
+			return err
+		}
+		var entries []history.Entry
+		if !noHistory {
+			path, err := historyPath(cmd)
+			if err != nil {
+				return err
+			}
+			if entries, err = history.Load(path); err != nil {
+				return err
+			}
+		}
+
+		b := bundle.New(results, entries, filepath.Base(args[0]), time.Now())
+		if !noContent {
+			b.Snapshot(cmd.Context())
+		}
+
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create bundle: %w", err)
+		}
+		if err := b.Write(f); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+
+		fmt.Printf("Wrote %s: %d pages, %d content snapshots, %d history entries\n", output, len(b.Results), len(b.Contents), len(b.History))
+		printSnapshotNotes(b.Manifest.Pages)
+		return nil
+	},
+}
+
+var importBundleCmd = &cobra.Command{
+	Use:   "import-bundle <bundle>",
+	Short: "Unpack an audit bundle after checking it",
+	Long: `Check every file of a bundle written by export-bundle against its
+checksums and unpack it into a directory (the bundle's name by default):
+results.json can then be read like any bulk JSON report, e.g. with
+bulk --compare, content/ holds the page snapshots and history.jsonl the
+runs of the pages. The bundle's score
+history is merged into the local history, skipping runs already recorded,
+unless --no-history is set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		output, _ := cmd.Flags().GetString("output")
+		noHistory, _ := cmd.Flags().GetBool("no-history")
+		if dir == "" {
+			dir = strings.TrimSuffix(strings.TrimSuffix(filepath.Base(args[0]), ".gz"), ".tar")
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open bundle: %w", err)
+		}
+		b, err := bundle.Read(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		files := map[string]any{
+			"manifest.json":  b.Manifest,
+			"results.json":   b.Results,
+			"manifests.json": b.Manifests,
+		}
+		for name, v := range files {
+			data, _ := json.MarshalIndent(v, "", "  ")
+			if err := writeBundleFile(dir, name, append(data, '\n')); err != nil {
+				return err
+			}
+		}
+		var lines []byte
+		for _, e := range b.History {
+			line, _ := json.Marshal(e)
+			lines = append(append(lines, line...), '\n')
+		}
+		if err := writeBundleFile(dir, "history.jsonl", lines); err != nil {
+			return err
+		}
+		for name, content := range b.Contents {
+			if err := writeBundleFile(dir, name, []byte(content)); err != nil {
+				return err
+			}
+		}
+
+		merged := 0
+		if !noHistory && len(b.History) > 0 {
+			path, err := historyPath(cmd)
+			if err != nil {
+				return err
+			}
+			existing, err := history.Load(path)
+			if err != nil {
+				return err
+			}
+			recorded := make(map[string]bool, len(existing))
+			for _, e := range existing {
+				recorded[e.URL+" "+e.Time.String()] = true
+			}
+			var missing []history.Entry
+			for _, e := range b.History {
+				if !recorded[e.URL+" "+e.Time.String()] {
+					missing = append(missing, e)
+				}
+			}
+			if err := history.Append(path, missing...); err != nil {
+				return err
+			}
+			merged = len(missing)
+		}
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(map[string]any{"dir": dir, "manifest": b.Manifest, "history_merged": merged}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Printf("Bundle of %s made %s by %s, checksums verified\n", b.Manifest.Source, b.Manifest.CreatedAt.Local().Format("2006-01-02 15:04"), b.Manifest.ToolVersion)
+		fmt.Printf("Unpacked %d pages and %d content snapshots into %s\n", len(b.Results), len(b.Contents), dir)
+		if !noHistory {
+			fmt.Printf("Merged %d of %d history entries into the local history\n", merged, len(b.History))
+		}
+		printSnapshotNotes(b.Manifest.Pages)
+		return nil
+	},
+}
+
+// writeBundleFile writes a file unpacked from a bundle under dir.
+func writeBundleFile(dir, name string, data []byte) error {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to unpack bundle: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to unpack bundle: %w", err)
+	}
+	return nil
+}
+
+// printSnapshotNotes lists the pages whose snapshot is missing or differs
+// from the content scored.
+func printSnapshotNotes(pages []bundle.Page) {
+	for _, p := range pages {
+		switch {
+		case p.SnapshotError != "":
+			fmt.Printf("  no snapshot of %s: %s\n", p.Key, p.SnapshotError)
+		case p.Changed != nil && *p.Changed:
+			fmt.Printf("  %s changed since it was scored\n", p.Key)
+		}
+	}
+}
+
+func init() {
+	exportBundleCmd.Flags().StringP("file", "f", "", "Bundle file to write (default geo-audit-<date>.tar.gz)")
+	exportBundleCmd.Flags().Bool("no-content", false, "Leave out the content snapshots and fetch nothing")
+	exportBundleCmd.Flags().Bool("no-history", false, "Leave out the score history")
+	exportBundleCmd.Flags().String("history-file", "", "Score history file to read instead of scores.jsonl in the history directory")
+	importBundleCmd.Flags().String("dir", "", "Directory to unpack into (default: the bundle's name)")
+	importBundleCmd.Flags().StringP("output", "o", "text", "Summary format (text, json)")
+	importBundleCmd.Flags().Bool("no-history", false, "Do not merge the bundle's score history into the local history")
+	importBundleCmd.Flags().String("history-file", "", "Score history file to merge into instead of scores.jsonl in the history directory")
+	rootCmd.AddCommand(exportBundleCmd)
+	rootCmd.AddCommand(importBundleCmd)
+}
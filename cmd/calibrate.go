@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/pkg/calibrate"
+	"geo-checker/pkg/scorer"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var calibrateCmd = &cobra.Command{
+	Use:   "calibrate [benchmark file]",
+	Short: "Check the local scorer against a labeled benchmark of pages",
+	Long: `Run the local scorer against a benchmark of pages with expected score
+ranges and report drift: how far each score (and any labeled pillar) falls
+outside its range.
+
+Without an argument the benchmark built into the binary is used. Supply your
+own as YAML:
+
+  name: docs-site
+  pages:
+    - name: getting started
+      file: pages/getting-started.html   # relative to the benchmark file
+      expected: {min: 70, max: 90}
+      pillars:
+        content_structure: {min: 60, max: 100}
+    - url: https://example.com/blog/post
+      expected: {min: 50, max: 75}
+
+Exits with status 2 when any page drifts outside its range.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+
+		var bench *calibrate.Benchmark
+		var err error
+		if len(args) == 0 {
+			bench, err = calibrate.LoadBuiltin()
+		} else {
+			bench, err = calibrate.Load(os.DirFS(filepath.Dir(args[0])), filepath.Base(args[0]))
+		}
+		if err != nil {
+			return err
+		}
+
+		report := bench.Run(cmd.Context(), scorer.NewLocalScorer())
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Printf("Calibration against %q\n\n", report.Benchmark)
+			for _, r := range report.Results {
+				status := "ok   "
+				if r.Failed() {
+					status = "DRIFT"
+				}
+				fmt.Printf("  %s %-40s %3d  expected %d-%d", status, r.Name, r.Score, r.Expected.Min, r.Expected.Max)
+				if r.Drift != 0 {
+					fmt.Printf("  (%+d)", r.Drift)
+				}
+				fmt.Println()
+				for _, p := range r.Pillars {
+					if p.Drift != 0 {
+						fmt.Printf("        %s: %d, expected %d-%d (%+d)\n", p.Pillar, p.Score, p.Expected.Min, p.Expected.Max, p.Drift)
+					}
+				}
+				if r.Error != "" {
+					fmt.Printf("        error: %s\n", r.Error)
+				}
+			}
+			fmt.Printf("\n%d passed, %d drifted, mean absolute drift %.1f points\n", report.Passed, report.Failed, report.MeanDrift)
+		}
+
+		if !report.OK() {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &ExitError{Code: 2, Err: fmt.Errorf("%d of %d benchmark pages drifted", report.Failed, len(report.Results))}
+		}
+		return nil
+	},
+}
+
+func init() {
+	calibrateCmd.Flags().StringP("output", "o", "text", "Report format (text, json)")
+	rootCmd.AddCommand(calibrateCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommands are the programs that print the clipboard, in order of
+// preference for each OS.
+var clipboardCommands = map[string][][]string{
+	"darwin":  {{"pbpaste"}},
+	"windows": {{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard -Raw"}},
+	"linux":   {{"wl-paste", "--no-newline"}, {"xclip", "-selection", "clipboard", "-o"}, {"xsel", "--clipboard", "--output"}},
+}
+
+// readClipboard returns the text on the system clipboard, using the first
+// clipboard program for this OS that is installed.
+func readClipboard(ctx context.Context) (string, error) {
+	commands := clipboardCommands[runtime.GOOS]
+	if len(commands) == 0 {
+		commands = clipboardCommands["linux"]
+	}
+	var tried []string
+	for _, command := range commands {
+		if _, err := exec.LookPath(command[0]); err != nil {
+			tried = append(tried, command[0])
+			continue
+		}
+		out, err := exec.CommandContext(ctx, command[0], command[1:]...).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to read the clipboard with %s: %w", command[0], err)
+		}
+		return string(out), nil
+	}
+	return "", fmt.Errorf("no clipboard program found (tried %s); pipe the text to --text - instead", strings.Join(tried, ", "))
+}
+
+// readTextInput returns the text given to --text, read from stdin when it
+// is "-".
+func readTextInput(text string) (string, error) {
+	if text != "-" {
+		return text, nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read text from stdin: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/cluster"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/llm"
+
+	"github.com/spf13/cobra"
+)
+
+var clusterCmd = &cobra.Command{
+	Use:   "cluster [pillar URL] [supporting URL...]",
+	Short: "Analyze a pillar page and its supporting pages as a topic cluster",
+	Long: `Analyze a topic cluster, a pillar page and the supporting posts around
+it, and report its GEO health as a whole:
+
+  interlinking     the pillar links to every supporting page and each
+                   links back; pages no other cluster page links to are
+                   orphaned
+  coverage         the pillar mentions the topic (title and H1 terms) of
+                   every supporting page
+  cannibalization  pages with near-identical titles or vocabulary that
+                   compete for the same queries
+
+The health score weighs the page scores at 40%, interlinking at 30%,
+coverage at 15% and the absence of cannibalization at 15%. Supporting URLs
+can also be read from a file with --file, one per line.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, _ := cmd.Flags().GetString("provider")
+		model, _ := cmd.Flags().GetString("model")
+		mode, _ := cmd.Flags().GetString("mode")
+		output, _ := cmd.Flags().GetString("output")
+		concurrent, _ := cmd.Flags().GetInt("concurrent")
+		file, _ := cmd.Flags().GetString("file")
+
+		supporting := args[1:]
+		if file != "" {
+			urls, err := bulk.ReadURLFile(file)
+			if err != nil {
+				return err
+			}
+			supporting = append(supporting, urls...)
+		}
+		if len(supporting) == 0 {
+			return fmt.Errorf("give at least one supporting URL after the pillar, or --file")
+		}
+		if model == "" && mode != "local" {
+			model = llm.GetRecommendedModel(provider)
+		}
+
+		cfg := &config.Config{
+			LLMProvider:  provider,
+			Model:        model,
+			OutputFormat: "json", // no per-page spinners
+			Mode:         mode,
+			Profile:      "auto",
+			Quiet:        true,
+			MaxTokens:    4000,
+			Temperature:  0.7,
+			Timeout:      requestTimeout(cmd),
+		}
+		pages := cluster.Fetch(cmd.Context(), analyzer.New(cfg), args[0], supporting, concurrent)
+		report := cluster.Evaluate(pages)
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Printf("Topic cluster of %s\n\n", report.Pillar)
+		fmt.Printf("  Health:        %d/100\n", report.Health)
+		fmt.Printf("  Average score: %d/100\n", report.AverageScore)
+		fmt.Printf("  Interlinking:  %.0f%% of pillar links present\n", report.Interlinking*100)
+		fmt.Printf("  Coverage:      %.0f%% of supporting topics covered by the pillar\n\n", report.Coverage*100)
+		for _, page := range report.Pages {
+			if page.Error != nil {
+				fmt.Printf("  %-10s %s\n             failed: %s\n", page.Role, page.URL, page.Error.Message)
+				continue
+			}
+			fmt.Printf("  %-10s %3d/100  %s\n", page.Role, page.Score, page.URL)
+			if page.Role != "pillar" {
+				fmt.Printf("             links to pillar: %s, linked from pillar: %s, inbound: %d, pillar coverage: %.0f%%\n",
+					yesNo(page.LinksToPillar), yesNo(page.LinkedFromPillar), page.InboundLinks, page.PillarCoverage*100)
+			}
+		}
+		if len(report.Overlaps) > 0 {
+			fmt.Println("\nTopic overlaps")
+			for _, o := range report.Overlaps {
+				label := "overlap"
+				if o.Cannibalization {
+					label = "cannibalization"
+				}
+				fmt.Printf("  %s: %s and %s (%.0f%% similar; shared terms: %v)\n", label, o.A, o.B, o.Similarity*100, o.SharedTerms)
+			}
+		}
+		if len(report.Issues) > 0 {
+			fmt.Println("\nIssues")
+			for _, issue := range report.Issues {
+				fmt.Printf("  - %s\n", issue)
+			}
+		}
+		return nil
+	},
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func init() {
+	clusterCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, gemini, local, ollama)")
+	clusterCmd.Flags().StringP("model", "m", "", "Model to use (leave empty for recommended model)")
+	clusterCmd.Flags().String("mode", "local", "Analysis mode of each page (auto, local, llm, hybrid)")
+	clusterCmd.Flags().StringP("output", "o", "text", "Report format (text, json)")
+	clusterCmd.Flags().IntP("concurrent", "c", 3, "Number of pages analyzed at once")
+	clusterCmd.Flags().String("file", "", "File of supporting URLs, one per line")
+	rootCmd.AddCommand(clusterCmd)
+}
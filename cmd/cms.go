@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/formatter"
+	"geo-checker/pkg/llm"
+	"geo-checker/pkg/source"
+	"geo-checker/pkg/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var cmsCmd = &cobra.Command{
+	Use:   "cms [contentful|sanity|strapi|wordpress]",
+	Short: "Analyze structured entries from a headless CMS",
+	Long: `Fetch entries from a headless CMS through its content API and score them before they are
+published. Rich text (Contentful Rich Text, Sanity Portable Text, Strapi blocks) and markdown
+fields are converted to HTML and analyzed like any other page.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, _ := cmd.Flags().GetString("provider")
+		model, _ := cmd.Flags().GetString("model")
+		output, _ := cmd.Flags().GetString("output")
+		mode, _ := cmd.Flags().GetString("mode")
+
+		srcCfg := source.Config{}
+		srcCfg.BaseURL, _ = cmd.Flags().GetString("base-url")
+		srcCfg.Project, _ = cmd.Flags().GetString("project")
+		srcCfg.Dataset, _ = cmd.Flags().GetString("dataset")
+		srcCfg.Token, _ = cmd.Flags().GetString("token")
+		srcCfg.ContentType, _ = cmd.Flags().GetString("content-type")
+		srcCfg.TitleField, _ = cmd.Flags().GetString("title-field")
+		srcCfg.BodyField, _ = cmd.Flags().GetString("body-field")
+		srcCfg.Preview, _ = cmd.Flags().GetBool("preview")
+		srcCfg.Limit, _ = cmd.Flags().GetInt("limit")
+
+		src, err := source.New(args[0], srcCfg)
+		if err != nil {
+			return err
+		}
+
+		if model != "" && provider != "" {
+			if err := llm.ValidateModelForProvider(provider, model); err != nil {
+				return fmt.Errorf("model validation failed: %w", err)
+			}
+		}
+		if model == "" {
+			model = llm.GetRecommendedModel(provider)
+		}
+
+		if output == "text" {
+			ui.New().PrintBanner()
+		}
+
+		cfg := &config.Config{
+			LLMProvider:  provider,
+			Model:        model,
+			OutputFormat: output,
+			Mode:         mode,
+			MaxTokens:    4000,
+			Temperature:  0.7,
+			Timeout:      requestTimeout(cmd),
+		}
+
+		docs, err := src.Fetch(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to fetch entries from %s: %w", src.Name(), err)
+		}
+
+		scraper := webpage.New()
+		a := analyzer.New(cfg)
+		results := make([]*bulk.BulkResult, 0, len(docs))
+
+		for _, doc := range docs {
+			result := &bulk.BulkResult{URL: doc.URL}
+			results = append(results, result)
+
+			pageData, err := scraper.ParseHTML(doc.HTML, doc.URL)
+			if err != nil {
+				result.Error = analyzer.Classify(err)
+				continue
+			}
+			analysis, err := a.AnalyzePage(cmd.Context(), pageData, doc.URL)
+			if err != nil {
+				result.Error = analyzer.Classify(err)
+				continue
+			}
+			analysis.Metadata["source"] = src.Name()
+			analysis.Metadata["source_id"] = doc.ID
+			result.Result = analysis
+		}
+
+		formatter := formatter.New(output)
+		fmt.Print(formatter.FormatBulkResults(results))
+		return nil
+	},
+}
+
+func init() {
+	cmsCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, gemini, local, ollama)")
+	cmsCmd.Flags().StringP("model", "m", "", "Model to use (leave empty for recommended model)")
+	cmsCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown)")
+	cmsCmd.Flags().StringP("mode", "", "auto", "Analysis mode (auto, local, llm, hybrid)")
+	cmsCmd.Flags().String("base-url", "", "CMS base URL (Strapi, WordPress), or a Contentful API host")
+	cmsCmd.Flags().String("project", "", "Contentful space ID or Sanity project ID")
+	cmsCmd.Flags().String("dataset", "", "Contentful environment or Sanity dataset")
+	cmsCmd.Flags().String("token", "", "API token (defaults to CONTENTFUL_ACCESS_TOKEN, CONTENTFUL_PREVIEW_TOKEN with --preview, SANITY_TOKEN or STRAPI_TOKEN)")
+	cmsCmd.Flags().String("content-type", "", "Content type, document type or collection to fetch")
+	cmsCmd.Flags().String("title-field", "title", "Field holding the entry title")
+	cmsCmd.Flags().String("body-field", "body", "Field holding the entry body")
+	cmsCmd.Flags().Bool("preview", false, "Include unpublished drafts (pre-publish scoring)")
+	cmsCmd.Flags().Int("limit", 100, "Maximum number of entries to fetch")
+	rootCmd.AddCommand(cmsCmd)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scorer"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Create and inspect the configuration file",
+	Long: `Settings such as provider, model, mode, output format, timeout and concurrency can be set once in a
+configuration file instead of repeating flags: ~/.geo-checker.yaml applies everywhere and ./geo-checker.yaml in its
+directory, overriding it (--config or GEO_CONFIG names another file). Settings are flag names; flags given on the
+command line or as GEO_<FLAG> environment variables win. Flags naming a file or directory one run reads or writes
+(out, file, dir, spill, baseline, ...) are only read from a command's section under commands.`,
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented configuration file to start from",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		global, _ := cmd.Flags().GetBool("global")
+		force, _ := cmd.Flags().GetBool("force")
+		path := config.FileName
+		if global {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return fmt.Errorf("failed to locate the home directory: %w", err)
+			}
+			path = filepath.Join(home, "."+config.FileName)
+		}
+		if _, err := os.Stat(path); err == nil && !force {
+			return fmt.Errorf("%s already exists (use --force to overwrite it)", path)
+		}
+		if err := os.WriteFile(path, []byte(config.Template), 0o644); err != nil {
+			return fmt.Errorf("failed to write config file: %w", err)
+		}
+		fmt.Printf("Wrote %s\n", path)
+		return nil
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the configuration files in effect and their settings",
+	Long:  "List the configuration files that apply, lowest precedence first, and each setting with the file it comes from. With --command, show the flag values that command would start from.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		explicit, _ := cmd.Flags().GetString("config")
+		paths := config.FilePaths(explicit)
+		file, err := config.LoadFiles(paths)
+		if err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			fmt.Println("No configuration file; run 'config init' to create one.")
+			return nil
+		}
+		fmt.Println("Configuration files (lowest precedence first):")
+		for _, path := range paths {
+			fmt.Printf("  %s\n", path)
+		}
+
+		command, _ := cmd.Flags().GetString("command")
+		if command != "" {
+			target, _, err := rootCmd.Find([]string{command})
+			if err != nil || target == rootCmd {
+				return fmt.Errorf("unknown command %q", command)
+			}
+			fmt.Printf("\nFlags of %s:\n", target.Name())
+			target.Flags().VisitAll(func(f *pflag.Flag) {
+				if value, source, ok := file.Lookup(target.Name(), f.Name); ok {
+					fmt.Printf("  %-20s %-30s (%s)\n", f.Name, value, source)
+				} else {
+					fmt.Printf("  %-20s %-30s (default)\n", f.Name, f.DefValue)
+				}
+			})
+			return nil
+		}
+
+		if keys := file.Keys(); len(keys) > 0 {
+			fmt.Println("\nSettings:")
+			for _, key := range keys {
+				value, source := config.FormatValue(file.Settings[key]), file.Source(key)
+				note := ""
+				if !anyCommandHasFlag(key) {
+					note = " — no command has this flag"
+				} else if config.CommandScoped[key] {
+					note = " — ignored: set it under commands: <command>"
+				}
+				fmt.Printf("  %-20s %-30s (%s)%s\n", key, value, source, note)
+			}
+		}
+		commands := make([]string, 0, len(file.Commands))
+		for name := range file.Commands {
+			commands = append(commands, name)
+		}
+		sort.Strings(commands)
+		for _, name := range commands {
+			fmt.Printf("\nSettings for %s:\n", name)
+			target, _, err := rootCmd.Find([]string{name})
+			known := err == nil && target != rootCmd
+			keys := make([]string, 0, len(file.Commands[name]))
+			for key := range file.Commands[name] {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				value, source, _ := file.Lookup(name, key)
+				note := ""
+				if !known {
+					note = " — unknown command"
+				} else if target.Flags().Lookup(key) == nil {
+					note = " — the command has no such flag"
+				}
+				fmt.Printf("  %-20s %-30s (%s)%s\n", key, value, source, note)
+			}
+		}
+		if len(file.Weights) > 0 {
+			if _, err := (*scorer.Framework)(nil).WithWeights(file.Weights); err != nil {
+				return fmt.Errorf("invalid weights: %w", err)
+			}
+			pillars := make([]string, 0, len(file.Weights))
+			for pillar := range file.Weights {
+				pillars = append(pillars, pillar)
+			}
+			sort.Strings(pillars)
+			fmt.Println("\nWeights:")
+			for _, pillar := range pillars {
+				fmt.Printf("  %-20s %-30g (%s)\n", pillar, file.Weights[pillar], file.Source("weights."+pillar))
+			}
+		}
+		return nil
+	},
+}
+
+// anyCommandHasFlag reports whether a command of the CLI has the flag.
+func anyCommandHasFlag(name string) bool {
+	var walk func(c *cobra.Command) bool
+	walk = func(c *cobra.Command) bool {
+		if c.Flags().Lookup(name) != nil || c.PersistentFlags().Lookup(name) != nil {
+			return true
+		}
+		for _, sub := range c.Commands() {
+			if walk(sub) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(rootCmd)
+}
+
+// applyConfigFile fills every flag still unset after the command line and
+// the environment from the configuration files, the command's own section
+// first, and loads the pillar weights they set.
+func applyConfigFile(cmd *cobra.Command) error {
+	explicit, _ := cmd.Flags().GetString("config")
+	file, err := config.LoadFiles(config.FilePaths(explicit))
+	if err != nil {
+		return err
+	}
+	if len(file.Weights) > 0 {
+		if _, err := (*scorer.Framework)(nil).WithWeights(file.Weights); err != nil {
+			return fmt.Errorf("invalid weights in config file: %w", err)
+		}
+		config.Weights = file.Weights
+	}
+	var firstErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed || firstErr != nil || f.Name == "config" {
+			return
+		}
+		value, source, ok := file.Lookup(cmd.Name(), f.Name)
+		if !ok {
+			return
+		}
+		if err := cmd.Flags().Set(f.Name, value); err != nil {
+			firstErr = fmt.Errorf("invalid value for %s in %s: %w", f.Name, source, err)
+		}
+	})
+	return firstErr
+}
+
+func init() {
+	configInitCmd.Flags().Bool("global", false, "Write ~/.geo-checker.yaml instead of ./geo-checker.yaml")
+	configInitCmd.Flags().Bool("force", false, "Overwrite an existing file")
+	configShowCmd.Flags().String("command", "", "Show the flag values this command would start from, e.g. analyze")
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"geo-checker/internal/textdiff"
 	"geo-checker/internal/webpage"
+	"os"
 	"strings"
 	"time"
 
@@ -13,19 +15,44 @@ import (
 var debugCmd = &cobra.Command{
 	Use:   "debug [URL]",
 	Short: "Debug webpage content extraction",
-	Long:  "Debug and display detailed information about webpage content extraction",
-	Args:  cobra.ExactArgs(1),
+	Long: `Debug and display detailed information about webpage content extraction.
+
+With --diff the page is fetched twice and the extracted text is diffed, to
+diagnose cloaking, JavaScript dependence and bot-specific content:
+
+  debug --diff <url>                              browser vs GPTBot user agent
+  debug --diff --ua-a default --ua-b gptbot <url> any two user agents
+  debug --diff --against snapshot.html <url>      a saved fetch vs the live page
+
+Save a snapshot for later comparison with --save. User agents accept the
+presets "default", "browser" and "gptbot" or a literal User-Agent string.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		url := args[0]
 		
+		if diff, _ := cmd.Flags().GetBool("diff"); diff {
+			return runDebugDiff(cmd, url)
+		}
+		
 		scraper := webpage.New()
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 		defer cancel()
 		
 		fmt.Printf("🔍 Debugging content extraction for: %s\n", url)
 		fmt.Println(strings.Repeat("=", 60))
 		
-		pageData, err := scraper.ScrapeURL(ctx, url)
+		html, err := scraper.FetchHTML(ctx, url)
+		if err != nil {
+			return fmt.Errorf("failed to scrape URL: %w", err)
+		}
+		if save, _ := cmd.Flags().GetString("save"); save != "" {
+			if err := os.WriteFile(save, []byte(html), 0o644); err != nil {
+				return fmt.Errorf("failed to save snapshot: %w", err)
+			}
+			fmt.Printf("💾 Saved raw HTML to %s\n", save)
+		}
+		
+		pageData, err := scraper.ParseHTML(html, url)
 		if err != nil {
 			return fmt.Errorf("failed to scrape URL: %w", err)
 		}
@@ -34,6 +61,9 @@ var debugCmd = &cobra.Command{
 		fmt.Printf("📏 Content Length: %d characters\n", len(pageData.Content))
 		fmt.Printf("🏷️  Meta Tags: %d found\n", len(pageData.MetaTags))
 		fmt.Printf("📋 Headings: %d found\n", len(pageData.Headings))
+		for _, warning := range pageData.Warnings {
+			fmt.Printf("⚠️  %s\n", warning)
+		}
 		fmt.Println()
 		
 		if len(pageData.Headings) > 0 {
@@ -78,6 +108,105 @@ var debugCmd = &cobra.Command{
 	},
 }
 
+// runDebugDiff fetches url twice (or once against a saved snapshot) and
+// prints a diff of the extracted text.
+func runDebugDiff(cmd *cobra.Command, url string) error {
+	uaA, _ := cmd.Flags().GetString("ua-a")
+	uaB, _ := cmd.Flags().GetString("ua-b")
+	against, _ := cmd.Flags().GetString("against")
+	contextLines, _ := cmd.Flags().GetInt("context")
+	
+	ctx, cancel := context.WithTimeout(cmd.Context(), 60*time.Second)
+	defer cancel()
+	
+	scraper := webpage.New()
+	var labelA, htmlA string
+	if against != "" {
+		data, err := os.ReadFile(against)
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		labelA, htmlA = "snapshot "+against, string(data)
+		// The live page is fetched with --ua-a, the client the snapshot stands in for
+		uaB = uaA
+	} else {
+		scraper.SetUserAgent(resolveUserAgent(uaA))
+		html, err := scraper.FetchHTML(ctx, url)
+		if err != nil {
+			return fmt.Errorf("fetch A (%s) failed: %w", uaA, err)
+		}
+		labelA, htmlA = "A: "+uaA, html
+	}
+	
+	scraper.SetUserAgent(resolveUserAgent(uaB))
+	htmlB, err := scraper.FetchHTML(ctx, url)
+	if err != nil {
+		return fmt.Errorf("fetch B (%s) failed: %w", uaB, err)
+	}
+	labelB := "B: " + uaB
+	if against != "" {
+		labelB = "live page (" + uaB + ")"
+	}
+	
+	pageA, err := scraper.ParseHTML(htmlA, url)
+	if err != nil {
+		return err
+	}
+	pageB, err := scraper.ParseHTML(htmlB, url)
+	if err != nil {
+		return err
+	}
+	
+	lines := textdiff.Lines(contentLines(pageA.Content), contentLines(pageB.Content))
+	deleted, inserted := textdiff.Stats(lines)
+	
+	fmt.Printf("🔍 Extraction diff for: %s\n", url)
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("- %s: %d characters, %d headings, title %q\n", labelA, len(pageA.Content), len(pageA.Headings), pageA.Title)
+	fmt.Printf("+ %s: %d characters, %d headings, title %q\n", labelB, len(pageB.Content), len(pageB.Headings), pageB.Title)
+	fmt.Println()
+	
+	if deleted == 0 && inserted == 0 {
+		fmt.Println("✅ Extracted content is identical")
+		return nil
+	}
+	
+	fmt.Printf("%d lines only in A, %d lines only in B\n", deleted, inserted)
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Print(textdiff.Unified(lines, contextLines))
+	return nil
+}
+
+// resolveUserAgent expands the user-agent presets accepted by debug --diff.
+func resolveUserAgent(name string) string {
+	switch strings.ToLower(name) {
+	case "default", "":
+		return webpage.DefaultUserAgent
+	case "browser":
+		return webpage.BrowserUserAgent
+	case "gptbot":
+		return webpage.GPTBotUserAgent
+	default:
+		return name
+	}
+}
+
+func contentLines(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 func init() {
+	debugCmd.Flags().Bool("diff", false, "Fetch the page twice and diff the extracted text")
+	debugCmd.Flags().String("ua-a", "browser", "User agent for the first fetch (default, browser, gptbot or a literal string)")
+	debugCmd.Flags().String("ua-b", "gptbot", "User agent for the second fetch")
+	debugCmd.Flags().String("against", "", "Diff a saved HTML snapshot against the live page instead")
+	debugCmd.Flags().String("save", "", "Save the fetched raw HTML to this file")
+	debugCmd.Flags().Int("context", 2, "Unchanged lines to show around each difference")
 	rootCmd.AddCommand(debugCmd)
 }
\ No newline at end of file
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/annotate"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/formatter"
+	"geo-checker/pkg/guardrails"
+	"geo-checker/pkg/llm"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/source"
+	"geo-checker/pkg/ui"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var draftCheckCmd = &cobra.Command{
+	Use:   "draft-check [file]",
+	Short: "Score an AI-written draft and check it for hallucination-prone patterns",
+	Long: `Score a draft before it ships, typically one written by an LLM, and run
+guardrails for the patterns generated text gets wrong:
+
+  unsourced superlatives   "the best", "industry-leading", "guaranteed"…
+                           with no link or citation in the sentence
+  vague attributions       "studies show", "experts agree"… naming no one
+  suspect citations        [n] markers beyond the reference list, undefined
+                           footnotes, author-year citations with nothing to
+                           check them against or from future years,
+                           malformed DOIs
+  broken reference links   links to placeholder hosts, links without a
+                           target and links answering with an error
+
+The draft is an HTML, markdown (.md) or plain text file, or - for stdin.
+Exit codes:
+  0  no high-severity guardrail issue and the score reaches --min-score
+  1  the draft could not be read or analyzed
+  2  a high-severity guardrail issue, or a score below --min-score`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		mode, _ := cmd.Flags().GetString("mode")
+		provider, _ := cmd.Flags().GetString("provider")
+		model, _ := cmd.Flags().GetString("model")
+		title, _ := cmd.Flags().GetString("title")
+		profile, _ := cmd.Flags().GetString("profile")
+		offline, _ := cmd.Flags().GetBool("offline")
+		minScore, _ := cmd.Flags().GetInt("min-score")
+		if _, err := scorer.ParseProfile(profile); err != nil {
+			return err
+		}
+		if mode != "local" && model == "" {
+			model = llm.GetRecommendedModel(provider)
+		}
+
+		text, err := readDraft(args[0])
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("the draft is empty")
+		}
+		page, err := draftPage(text, annotate.IsMarkdown(args[0]), title)
+		if err != nil {
+			return fmt.Errorf("failed to parse draft: %w", err)
+		}
+
+		cfg := &config.Config{
+			LLMProvider:  provider,
+			Model:        model,
+			OutputFormat: output,
+			Mode:         mode,
+			MaxTokens:    4000,
+			Temperature:  0.7,
+			Timeout:      requestTimeout(cmd),
+			Profile:      profile,
+		}
+		result, err := analyzer.New(cfg).AnalyzePage(cmd.Context(), page, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to analyze draft: %w", err)
+		}
+		report := guardrails.Check(cmd.Context(), page, guardrails.Options{CheckLinks: !offline})
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(struct {
+				Result     *analyzer.Result   `json:"result"`
+				Guardrails *guardrails.Report `json:"guardrails"`
+				Passed     bool               `json:"passed"`
+			}{result, report, report.Passed() && result.Score >= minScore}, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Print(formatter.New("text").FormatAnalysisResult(result))
+			printGuardrails(report)
+		}
+
+		var failures []string
+		if !report.Passed() {
+			failures = append(failures, "high-severity guardrail issues")
+		}
+		if result.Score < minScore {
+			failures = append(failures, fmt.Sprintf("score %d below %d", result.Score, minScore))
+		}
+		if len(failures) > 0 {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &ExitError{Code: 2, Err: fmt.Errorf("draft check failed: %s", strings.Join(failures, ", "))}
+		}
+		return nil
+	},
+}
+
+// readDraft returns the draft in file, or on stdin for "-".
+func readDraft(file string) (string, error) {
+	if file == "-" {
+		return readTextInput(file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read draft: %w", err)
+	}
+	return string(data), nil
+}
+
+// draftPage extracts a draft like a fetched page: markdown is rendered as
+// the page it would be published as, and plain text is taken as it is.
+func draftPage(text string, markdown bool, title string) (*webpage.PageData, error) {
+	var page *webpage.PageData
+	switch {
+	case markdown:
+		var err error
+		if page, err = webpage.New().ParseHTML(source.MarkdownPage(text), ""); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(strings.TrimSpace(text), "<"):
+		var err error
+		if page, err = webpage.New().ParseHTML(text, ""); err != nil {
+			return nil, err
+		}
+	default:
+		page = &webpage.PageData{Content: text, MetaTags: make(map[string]string), Headings: []webpage.Heading{}}
+	}
+	if title != "" {
+		page.Title = title
+	}
+	return page, nil
+}
+
+func printGuardrails(report *guardrails.Report) {
+	u := ui.New()
+	u.PrintSection("Draft Guardrails")
+	if report.LinksChecked > 0 {
+		u.PrintKeyValue("Links checked", fmt.Sprintf("%d", report.LinksChecked))
+	}
+	if len(report.Issues) == 0 {
+		u.PrintSuccess("No hallucination-prone patterns found")
+		return
+	}
+	for _, issue := range report.Issues {
+		u.PrintListItem(fmt.Sprintf("[%s] %s", issue.Severity, issue.Message), false)
+		if issue.Snippet != "" {
+			fmt.Printf("      %s\n", issue.Snippet)
+		}
+	}
+	fmt.Println()
+	if report.Passed() {
+		u.PrintWarning("Review the issues above before publishing")
+	} else {
+		u.PrintError("Fix the high-severity issues before publishing")
+	}
+}
+
+func init() {
+	draftCheckCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	draftCheckCmd.Flags().String("mode", "local", "Scoring mode (local, hybrid, llm)")
+	draftCheckCmd.Flags().StringP("provider", "p", "claude", "LLM provider for hybrid and llm modes")
+	draftCheckCmd.Flags().String("model", "", "Model to use (default: the provider's recommended model)")
+	draftCheckCmd.Flags().String("title", "", "Title of the draft (default: its own title or first heading)")
+	draftCheckCmd.Flags().String("profile", "auto", "Scoring profile (auto, general, docs, product, news, local-business, category); auto picks one from the draft's detected type")
+	draftCheckCmd.Flags().Int("min-score", 0, "Fail when the GEO score is below this")
+	rootCmd.AddCommand(draftCheckCmd)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Settings in config files and GEO_<FLAG> variables apply to every command
+// with the flag, so a name must mean the same thing on all of them: an
+// "output: json" meant for reports must not become a file name elsewhere.
+func TestOutputFlagIsAlwaysAFormat(t *testing.T) {
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if f.Name == "output" && !strings.Contains(strings.ToLower(f.Usage), "format") {
+				t.Errorf("%s: --output is not a format: %q", c.CommandPath(), f.Usage)
+			}
+		})
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+
+	t.Setenv("GEO_OUTPUT", "json")
+	if err := applyEnvFlags(exportBundleCmd); err != nil {
+		t.Fatal(err)
+	}
+	if file, _ := exportBundleCmd.Flags().GetString("file"); file != "" {
+		t.Errorf("GEO_OUTPUT set the bundle file to %q", file)
+	}
+}
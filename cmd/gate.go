@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"geo-checker/pkg/baseline"
+	"geo-checker/pkg/gate"
+	"geo-checker/pkg/scorer"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// addGateFlags adds the flags of commands that can fail a CI build on low
+// scores.
+func addGateFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("fail-under", 0, "Exit with status 2 when a page scores below this (0 to disable)")
+	cmd.Flags().StringSlice("fail-under-factor", nil, "Exit with status 2 when a page scores below a minimum on a pillar, as pillar=score (e.g. structured_data=50; repeatable)")
+}
+
+// gateThresholds reads --fail-under and --fail-under-factor. Factor
+// minimums must name a built-in pillar or one of the --framework file, and
+// need the local pillar scores --mode llm does not produce.
+func gateThresholds(cmd *cobra.Command) (gate.Thresholds, error) {
+	score, _ := cmd.Flags().GetInt("fail-under")
+	values, _ := cmd.Flags().GetStringSlice("fail-under-factor")
+	if score < 0 || score > 100 {
+		return gate.Thresholds{}, fmt.Errorf("--fail-under must be between 0 and 100")
+	}
+	factors, err := gate.ParseFactors(values)
+	if err != nil {
+		return gate.Thresholds{}, err
+	}
+	thresholds := gate.Thresholds{Score: score, Factors: factors}
+	if len(factors) == 0 {
+		return thresholds, nil
+	}
+	if mode, _ := cmd.Flags().GetString("mode"); mode == "llm" {
+		return gate.Thresholds{}, fmt.Errorf("--fail-under-factor checks local pillar scores and cannot be used with --mode llm")
+	}
+	var framework *scorer.Framework
+	if path, _ := cmd.Flags().GetString("framework"); path != "" {
+		if framework, err = scorer.LoadFramework(path); err != nil {
+			return gate.Thresholds{}, err
+		}
+	}
+	if err := thresholds.Validate(framework.PillarKeys()); err != nil {
+		return gate.Thresholds{}, err
+	}
+	return thresholds, nil
+}
+
+// enforceGate checks pages against the thresholds once the report is
+// printed, listing the pages that fall short on stderr and failing with
+// gate.ExitCode if any does.
+func enforceGate(cmd *cobra.Command, thresholds gate.Thresholds, pages []baseline.Page) error {
+	violations := thresholds.Check(pages)
+	if len(violations) == 0 {
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "Quality gate failed:")
+	for _, v := range violations {
+		fmt.Fprintf(os.Stderr, "  %s\n", v)
+	}
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return &ExitError{Code: gate.ExitCode, Err: fmt.Errorf("quality gate failed: %d violations", len(violations))}
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/history"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/ui"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [URL]",
+	Short: "Show how the scores of analyzed pages changed over time",
+	Long: `Every analysis of a URL by analyze and bulk is recorded with its score
+and pillar scores in scores.jsonl in the history directory (GEO_HISTORY_DIR).
+Without a URL, list the pages recorded with their first, latest and best
+scores; with one, list its runs with the change from each to the next and
+how every pillar moved between the first and the latest run shown.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		limit, _ := cmd.Flags().GetInt("limit")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := parseSince(sinceFlag, time.Now())
+		if err != nil {
+			return err
+		}
+		path, err := historyPath(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := history.Load(path)
+		if err != nil {
+			return err
+		}
+		var kept []history.Entry
+		for _, e := range entries {
+			if !e.Time.Before(since) {
+				kept = append(kept, e)
+			}
+		}
+
+		if len(args) == 0 {
+			trends := history.Trends(kept)
+			if output == "json" {
+				data, _ := json.MarshalIndent(trends, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+			if len(trends) == 0 {
+				fmt.Printf("No analyses recorded in %s\n", path)
+				return nil
+			}
+			printTrends(trends)
+			return nil
+		}
+
+		runs := history.Runs(kept, args[0])
+		if limit > 0 && len(runs) > limit {
+			runs = runs[len(runs)-limit:]
+		}
+		if len(runs) == 0 {
+			return fmt.Errorf("no analyses of %s recorded in %s", args[0], path)
+		}
+		changes := history.PillarChanges(runs[0].Entry, runs[len(runs)-1].Entry)
+		if output == "json" {
+			data, _ := json.MarshalIndent(map[string]any{"url": args[0], "runs": runs, "pillars": changes}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		printRuns(args[0], runs, changes)
+		return nil
+	},
+}
+
+// historyPath returns the --history-file of the command, or the default.
+func historyPath(cmd *cobra.Command) (string, error) {
+	if path, _ := cmd.Flags().GetString("history-file"); path != "" {
+		return path, nil
+	}
+	return history.DefaultPath()
+}
+
+// recordHistory adds the results of URLs to the score history unless
+// --no-history is set. Failing to do so is reported but does not fail the
+// analysis.
+func recordHistory(cmd *cobra.Command, results ...*analyzer.Result) {
+	if off, _ := cmd.Flags().GetBool("no-history"); off {
+		return
+	}
+	now := time.Now()
+	var entries []history.Entry
+	for _, r := range results {
+		if r != nil && r.URL != "" {
+			entries = append(entries, history.EntryOf(r, now))
+		}
+	}
+	if len(entries) == 0 {
+		return
+	}
+	path, err := historyPath(cmd)
+	if err == nil {
+		err = history.Append(path, entries...)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// addHistoryFlags adds the flags of commands that record their results.
+func addHistoryFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-history", false, "Do not record the scores in the score history")
+	cmd.Flags().String("history-file", "", "Score history file to use instead of scores.jsonl in the history directory")
+}
+
+// parseSince parses --since: a date (2024-05-01) or a number of days back
+// from now (30d); empty means the beginning.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q: give a date (2024-05-01) or a number of days (30d)", value)
+	}
+	return t, nil
+}
+
+func printTrends(trends []history.Trend) {
+	u := ui.New()
+	u.PrintHeader("SCORE HISTORY")
+	for _, t := range trends {
+		fmt.Printf("%-60s %3d runs  %3d → %3d (%s)  best %3d  last %s\n",
+			t.URL, t.Runs, t.First, t.Latest, signed(t.Delta), t.Best, t.Last.Local().Format("2006-01-02 15:04"))
+	}
+}
+
+func printRuns(url string, runs []history.Run, changes []history.PillarChange) {
+	u := ui.New()
+	u.PrintHeader("SCORE HISTORY")
+	u.PrintKeyValue("URL", url)
+	fmt.Println()
+	versions := make(map[string]bool)
+	for i, r := range runs {
+		delta := ""
+		if i > 0 {
+			delta = signed(r.Delta)
+		}
+		fmt.Printf("  %s  %3d/100  %4s  %s", r.Time.Local().Format("2006-01-02 15:04"), r.Score, delta, r.Mode)
+		if r.Model != "" {
+			fmt.Printf(" (%s)", r.Model)
+		}
+		fmt.Println()
+		versions[r.ScorerVersion] = true
+	}
+
+	if len(runs) > 1 && len(changes) > 0 {
+		fmt.Println()
+		u.PrintSubsection("Pillars, first to latest run")
+		for _, c := range changes {
+			fmt.Printf("  %-22s %3d → %3d (%s)\n", scorer.PillarName(c.Pillar), c.Before, c.After, signed(c.Delta))
+		}
+	}
+	if len(versions) > 1 {
+		fmt.Println()
+		u.PrintWarning("The scoring rules changed between these runs; part of the change may not come from the content")
+	}
+}
+
+// signed formats a score change with its sign, e.g. "+3", "-2", "0".
+func signed(delta int) string {
+	if delta > 0 {
+		return fmt.Sprintf("+%d", delta)
+	}
+	return strconv.Itoa(delta)
+}
+
+func init() {
+	historyCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	historyCmd.Flags().String("history-file", "", "Score history file to read instead of scores.jsonl in the history directory")
+	historyCmd.Flags().String("since", "", "Only runs from this date (2024-05-01) or this many days back (30d)")
+	historyCmd.Flags().Int("limit", 0, "Only the latest N runs of the URL (0 for all)")
+	rootCmd.AddCommand(historyCmd)
+}
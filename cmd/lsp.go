@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/lsp"
+	"geo-checker/pkg/scorer"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server giving GEO feedback in editors",
+	Long: `Run a Language Server Protocol server on stdin and stdout, for editors
+to start when HTML or markdown files are opened.
+
+Open documents are scored with the local scorer as they change:
+  diagnostics   findings placed at the text their evidence quotes
+                (page-level findings on the first line)
+  hover         what a finding means, its pillar, severity and evidence
+  code actions  insert a drafted meta description, fix skipped heading levels
+
+Markdown front matter "title" and "description" fields are treated as the
+page's title and meta description.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profile, _ := cmd.Flags().GetString("profile")
+		staleAfterDays, _ := cmd.Flags().GetInt("stale-after-days")
+		readingLevel, _ := cmd.Flags().GetString("reading-level")
+		if _, err := scorer.ParseProfile(profile); err != nil {
+			return err
+		}
+		if _, err := scorer.ParseReadingLevel(readingLevel); err != nil {
+			return err
+		}
+
+		cfg := &config.Config{
+			Mode:           "local",
+			OutputFormat:   "json",
+			Profile:        profile,
+			StaleAfterDays: staleAfterDays,
+			ReadingLevel:   readingLevel,
+			Timeout:        requestTimeout(cmd),
+		}
+		return lsp.New(cfg).Serve(cmd.Context(), os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	lspCmd.Flags().String("profile", "auto", "Scoring profile (auto, general, docs, product, news, local-business, category); auto picks one per document from its detected type")
+	lspCmd.Flags().Int("stale-after-days", 365, "Age in days from which pages without a recent update are told to review their content")
+	lspCmd.Flags().String("reading-level", "", "Target audience reading level: elementary, general, college, expert or grade-N (default: grade 12 for docs, general otherwise)")
+	rootCmd.AddCommand(lspCmd)
+}
@@ -21,12 +21,33 @@ var modelsCmd = &cobra.Command{
 			provider := args[0]
 			providerModels, exists := models[provider]
 			if !exists {
-				return fmt.Errorf("unknown provider: %s. Available providers: claude, openai, local", provider)
+				return fmt.Errorf("unknown provider: %s. Available providers: claude, openai, gemini, local, ollama", provider)
 			}
 			
 			fmt.Printf("📋 %s Models\n", strings.ToUpper(provider))
 			fmt.Println(strings.Repeat("=", 50))
 			
+			if llm.IsLocal(provider) {
+				// List what the Ollama server has installed, falling back
+				// to the common models when it cannot be reached
+				installed, err := llm.DiscoverModels(cmd.Context(), provider, "")
+				switch {
+				case err != nil:
+					fmt.Printf("⚠️  Could not list the installed models (%v); common models:\n\n", err)
+				case len(installed) == 0:
+					fmt.Printf("No models installed at %s; pull one, e.g. ollama pull llama3\n", llm.OllamaBaseURL(""))
+					return nil
+				default:
+					fmt.Printf("Installed at %s:\n\n", llm.OllamaBaseURL(""))
+					for _, model := range installed {
+						fmt.Printf("  %s\n", model.Name)
+						fmt.Printf("   %s\n", model.Description)
+						fmt.Println()
+					}
+					return nil
+				}
+			}
+			
 			for _, model := range providerModels {
 				indicator := " "
 				if model.Recommended {
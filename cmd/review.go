@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/review"
+	"geo-checker/pkg/ui"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Accept, reject or mark done the findings of analyzed pages",
+	Long: `Keep a review of the findings reported for each page in a state file
+(geo-review.json by default), meant to be committed with the content.
+
+analyze, bulk and scan given --review-state add the findings of every page
+to the file as open, carry forward the decisions already taken, leave
+rejected findings out of their reports and show how much of the review is
+complete. Findings are then marked with the subcommands below, by page and
+rule ID (the "id" of a finding in JSON output, e.g. structure.answer_first).`,
+}
+
+var reviewListCmd = &cobra.Command{
+	Use:   "list [URL]",
+	Short: "List the findings under review and the progress made",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("state")
+		output, _ := cmd.Flags().GetString("output")
+		statusFlag, _ := cmd.Flags().GetString("status")
+		var status review.Status
+		if statusFlag != "" {
+			var err error
+			if status, err = review.ParseStatus(statusFlag); err != nil {
+				return err
+			}
+		}
+		state, err := review.Load(path)
+		if err != nil {
+			return err
+		}
+		url := ""
+		if len(args) == 1 {
+			url = args[0]
+		}
+		var items []review.Item
+		for _, item := range state.Items {
+			if (url == "" || item.URL == url) && (status == "" || item.Status == status) {
+				items = append(items, item)
+			}
+		}
+		progress := state.Progress(url)
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(map[string]any{"items": items, "progress": progress}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		u := ui.New()
+		u.PrintHeader("REVIEW")
+		page := ""
+		for _, item := range items {
+			if item.URL != page {
+				page = item.URL
+				fmt.Println()
+				u.PrintSubsection(page)
+			}
+			fmt.Printf("  %-9s %-32s %s\n", item.Status, item.Finding, item.Message)
+			if detail := reviewDetail(item); detail != "" {
+				fmt.Printf("            %s\n", detail)
+			}
+		}
+		fmt.Println()
+		printReviewProgress(progress)
+		return nil
+	},
+}
+
+func reviewDetail(item review.Item) string {
+	detail := ""
+	if !item.Detected && item.Status != review.StatusRejected {
+		detail = "no longer detected since " + item.LastSeen.Local().Format("2006-01-02")
+	}
+	if item.Note != "" {
+		if detail != "" {
+			detail += "; "
+		}
+		detail += "note: " + item.Note
+	}
+	return detail
+}
+
+// newReviewMarkCmd returns the subcommand name marking findings with
+// status.
+func newReviewMarkCmd(name string, status review.Status, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   name + " <URL> <finding>...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("state")
+			note, _ := cmd.Flags().GetString("note")
+			state, err := review.Load(path)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			for _, finding := range args[1:] {
+				if err := state.Set(args[0], finding, status, note, now); err != nil {
+					return err
+				}
+			}
+			if err := state.Save(path); err != nil {
+				return err
+			}
+			fmt.Printf("Marked %d finding(s) of %s %s\n", len(args)-1, args[0], status)
+			printReviewProgress(state.Progress(""))
+			return nil
+		},
+	}
+	cmd.Flags().String("note", "", "Reason or reference recorded with the decision (e.g. a ticket)")
+	return cmd
+}
+
+// addReviewFlags adds the flag of commands that carry the review forward.
+func addReviewFlags(cmd *cobra.Command) {
+	cmd.Flags().String("review-state", "", "Review state file (see the review command): record the findings, hide rejected ones and report review progress")
+}
+
+// applyReview brings the --review-state file up to date with the results
+// of pages, their URLs or file paths, and hides the rejected findings from
+// them before they are reported. It returns nil without --review-state.
+func applyReview(cmd *cobra.Command, pages []string, results ...*analyzer.Result) (*review.State, error) {
+	path, _ := cmd.Flags().GetString("review-state")
+	if path == "" {
+		return nil, nil
+	}
+	state, err := review.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i, r := range results {
+		if r != nil {
+			state.Apply(pages[i], r, now)
+		}
+	}
+	if err := state.Save(path); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func printReviewProgress(p review.Progress) {
+	u := ui.New()
+	u.PrintKeyValue("Review", fmt.Sprintf("%d%% complete: %d done, %d fixed, %d accepted, %d open (%d rejected)",
+		p.Percent, p.Done, p.Fixed, p.Accepted, p.Open, p.Rejected))
+	for _, finding := range p.StillDetected {
+		u.PrintWarning("Marked done but still detected: " + finding)
+	}
+}
+
+func init() {
+	reviewCmd.PersistentFlags().String("state", "geo-review.json", "Review state file")
+	reviewListCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	reviewListCmd.Flags().String("status", "", "Only findings with this status (open, accepted, rejected, done)")
+	reviewCmd.AddCommand(reviewListCmd)
+	reviewCmd.AddCommand(newReviewMarkCmd("accept", review.StatusAccepted, "Accept findings: they are to be done"))
+	reviewCmd.AddCommand(newReviewMarkCmd("reject", review.StatusRejected, "Reject findings: they will not be done and are hidden from reports"))
+	reviewCmd.AddCommand(newReviewMarkCmd("done", review.StatusDone, "Mark findings done"))
+	reviewCmd.AddCommand(newReviewMarkCmd("reopen", review.StatusOpen, "Reopen findings"))
+	rootCmd.AddCommand(reviewCmd)
+}
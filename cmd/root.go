@@ -1,9 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"geo-checker/internal/netguard"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/llm"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var rootCmd = &cobra.Command{
@@ -13,18 +24,135 @@ var rootCmd = &cobra.Command{
 - Bulk URL checking and analysis
 - Local project directory scanning  
 - Webpage data analysis with multiple LLM providers
-- Support for Claude, GPT, and local LLMs`,
+- Support for Claude, GPT, Gemini, and local LLMs`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyEnvFlags(cmd); err != nil {
+			return err
+		}
+		// The config commands must work with a broken configuration file
+		if cmd.Parent() != configCmd {
+			if err := applyConfigFile(cmd); err != nil {
+				return err
+			}
+		}
+		if offline, _ := cmd.Flags().GetBool("offline"); offline {
+			netguard.Enable()
+			if originality, _ := cmd.Flags().GetString("originality"); originality != "" {
+				return fmt.Errorf("--originality searches the web and cannot be used with --offline")
+			}
+		}
+		if auditLog, _ := cmd.Flags().GetString("audit-log"); auditLog != "" {
+			// Fail before any analysis rather than on its first request
+			f, err := os.OpenFile(auditLog, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+			if err != nil {
+				return fmt.Errorf("invalid --audit-log: %w", err)
+			}
+			f.Close()
+			llm.AuditLogPath = auditLog
+		}
+		maxHTMLSize, _ := cmd.Flags().GetString("max-html-size")
+		size, err := parseByteSize(maxHTMLSize)
+		if err != nil {
+			return fmt.Errorf("invalid --max-html-size: %w", err)
+		}
+		webpage.DefaultMaxHTMLSize = size
+		consentSelectors, _ := cmd.Flags().GetStringSlice("consent-selectors")
+		webpage.ExtraConsentSelectors = consentSelectors
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("Welcome to GEO Checker! Use --help to see available commands.")
 	},
 }
 
+// Execute runs the CLI with a root context that is cancelled on SIGINT or
+// SIGTERM; commands pass cmd.Context() down so fetches, LLM calls and bulk
+// runs stop promptly.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// ExitError carries a specific process exit code out of a command.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
 }
 
 func init() {
+	rootCmd.Version = analyzer.ToolVersion()
+	rootCmd.PersistentFlags().String("max-html-size", "10MB", "Truncate fetched HTML beyond this size (e.g. 512KB, 10MB; 0 for no limit)")
+	rootCmd.PersistentFlags().String("config", "", "Configuration file to use instead of ~/.geo-checker.yaml and ./geo-checker.yaml")
+	rootCmd.PersistentFlags().Int("timeout", 30, "Seconds to wait for each LLM or search request")
+	rootCmd.PersistentFlags().Bool("offline", false, "Make no network request except to localhost: analyze local files and text with the local scorer or a local LLM, and fail on any other connection")
+	rootCmd.PersistentFlags().String("audit-log", "", "Append every LLM request and response, with timestamps, token counts and latency, to this JSON Lines file (API keys redacted)")
+	rootCmd.PersistentFlags().StringSlice("consent-selectors", nil, "Additional CSS selectors of cookie-consent dialogs to remove before extraction")
 	rootCmd.AddCommand(analyzeCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(scanCmd)
-}
\ No newline at end of file
+}
+
+// envFlagNames overrides the derived variable name where it would collide
+// with an existing variable of a different meaning.
+var envFlagNames = map[string]string{
+	"api-keys": "GEO_API_KEYS_FILE", // GEO_API_KEYS holds the keys themselves
+}
+
+// applyEnvFlags fills every flag not set on the command line from a
+// GEO_<FLAG_NAME> environment variable (e.g. --grpc-addr from GEO_GRPC_ADDR),
+// so containers can be configured through the environment alone.
+func applyEnvFlags(cmd *cobra.Command) error {
+	var firstErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed || firstErr != nil {
+			return
+		}
+		envVar, ok := envFlagNames[f.Name]
+		if !ok {
+			envVar = "GEO_" + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		}
+		value, ok := os.LookupEnv(envVar)
+		if !ok {
+			return
+		}
+		if err := cmd.Flags().Set(f.Name, value); err != nil {
+			firstErr = fmt.Errorf("invalid value for %s: %w", envVar, err)
+		}
+	})
+	return firstErr
+}
+
+// requestTimeout returns the --timeout of the command, in seconds.
+func requestTimeout(cmd *cobra.Command) int {
+	timeout, _ := cmd.Flags().GetInt("timeout")
+	return timeout
+}
+
+// parseByteSize parses sizes such as "512KB", "10MB" or a plain byte count.
+func parseByteSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, multiplier = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("expected a size such as 10MB, got %q", value)
+	}
+	return n * multiplier, nil
+}
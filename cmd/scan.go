@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/baseline"
 	"geo-checker/pkg/config"
 	"geo-checker/pkg/formatter"
 	"geo-checker/pkg/scanner"
+	"geo-checker/pkg/scorer"
 	"geo-checker/pkg/ui"
 
 	"github.com/spf13/cobra"
@@ -23,6 +26,22 @@ var scanCmd = &cobra.Command{
 		output, _ := cmd.Flags().GetString("output")
 		mode, _ := cmd.Flags().GetString("mode")
 		extensions, _ := cmd.Flags().GetStringSlice("ext")
+		profile, _ := cmd.Flags().GetString("profile")
+		staleAfterDays, _ := cmd.Flags().GetInt("stale-after-days")
+		readingLevel, _ := cmd.Flags().GetString("reading-level")
+		byOwner, _ := cmd.Flags().GetBool("by-owner")
+		condense, _ := cmd.Flags().GetBool("condense")
+		if _, err := scorer.ParseReadingLevel(readingLevel); err != nil {
+			return err
+		}
+		
+		if _, err := scorer.ParseProfile(profile); err != nil {
+			return err
+		}
+		thresholds, err := gateThresholds(cmd)
+		if err != nil {
+			return err
+		}
 		
 		// Show banner for text output
 		if output == "text" {
@@ -31,32 +50,65 @@ var scanCmd = &cobra.Command{
 		}
 		
 		cfg := &config.Config{
-			LLMProvider:  provider,
-			Model:        model,
-			OutputFormat: output,
-			Mode:         mode,
-			Extensions:   extensions,
-			MaxTokens:    4000,
-			Temperature:  0.7,
-			Timeout:      30,
+			LLMProvider:    provider,
+			Model:          model,
+			OutputFormat:   output,
+			Mode:           mode,
+			Extensions:     extensions,
+			Profile:        profile,
+			StaleAfterDays: staleAfterDays,
+			ReadingLevel:   readingLevel,
+			GroupByOwner:   byOwner,
+			Condense:       condense,
+			MaxTokens:      4000,
+			Temperature:    0.7,
+			Timeout:        requestTimeout(cmd),
 		}
 		
 		scanner := scanner.New(cfg)
-		results, err := scanner.ScanDirectory(directory)
+		results, err := scanner.ScanDirectory(cmd.Context(), directory)
 		if err != nil {
 			return fmt.Errorf("failed to scan directory: %w", err)
 		}
 		
+		pages := make([]baseline.Page, 0, len(results))
+		var keys []string
+		var analyzed []*analyzer.Result
+		for _, r := range results {
+			pages = append(pages, baseline.PageOf(r.FilePath, r.Result, r.Error))
+			keys = append(keys, r.FilePath)
+			analyzed = append(analyzed, r.Result)
+		}
+		reviewState, err := applyReview(cmd, keys, analyzed...)
+		if err != nil {
+			return err
+		}
+		
 		formatter := formatter.New(output)
-		fmt.Print(formatter.FormatScanResults(results))
-		return nil
+		if byOwner {
+			fmt.Print(formatter.FormatScanResultsByOwner(results))
+		} else {
+			fmt.Print(formatter.FormatScanResults(results))
+		}
+		if reviewState != nil && output == "text" {
+			printReviewProgress(reviewState.Progress(""))
+		}
+		
+		return enforceGate(cmd, thresholds, pages)
 	},
 }
 
 func init() {
-	scanCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, gpt, local)")
+	scanCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, gpt, gemini, local)")
 	scanCmd.Flags().StringP("model", "m", "claude-3-sonnet", "Model to use")
-	scanCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown)")
+	scanCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown, vscode)")
 	scanCmd.Flags().StringP("mode", "", "local", "Analysis mode (local, llm, hybrid)")
+	scanCmd.Flags().String("profile", "auto", "Scoring profile (auto, general, docs, product, news, local-business, category); auto picks one per page from its detected type")
+	scanCmd.Flags().Int("stale-after-days", 365, "Age in days from which pages without a recent update are told to review their content")
+	scanCmd.Flags().String("reading-level", "", "Target audience reading level: elementary, general, college, expert or grade-N (default: grade 12 for docs, general otherwise)")
+	scanCmd.Flags().Bool("condense", false, "Strip repeated navigation text, compress whitespace and summarize long tables in the content sent to the LLM to use fewer tokens (llm and hybrid modes)")
+	scanCmd.Flags().Bool("by-owner", false, "Map files to owners with CODEOWNERS (or their front matter author) and group the report by owner")
 	scanCmd.Flags().StringSliceP("ext", "e", []string{".html", ".htm"}, "File extensions to scan")
+	addGateFlags(scanCmd)
+	addReviewFlags(scanCmd)
 }
\ No newline at end of file
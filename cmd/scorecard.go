@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/baseline"
+	"geo-checker/pkg/formatter"
+	"geo-checker/pkg/importance"
+	"geo-checker/pkg/sitemap"
+
+	"github.com/spf13/cobra"
+)
+
+var scorecardCmd = &cobra.Command{
+	Use:   "scorecard <report>",
+	Short: "Score a site with its pages weighted by importance",
+	Long: `Compute the site score of a bulk JSON report (or --spill file) with every
+page weighted by its importance, so that the homepage counts for more than
+an old blog post in the headline number, next to the plain average.
+
+Weights come from a CSV export (--weights) with a URL or page path column
+and a numeric column: --weight-column, or else the first of weight,
+importance, priority, clicks, sessions, views, pageviews, users and
+impressions. GA4 and Search Console exports work as downloaded; pages they
+do not list weigh nothing. Or they come from the <priority> of the pages in
+a sitemap (--weights-sitemap), 0.5 when a page declares none and 0.1 for
+pages missing from the sitemap. Or they are the clicks or impressions of
+the pages of a Search Console property (--weights-gsc with --gsc-site).
+
+The scorecard also lists the pages costing the site score most, the points
+it would gain if each scored 100, and the findings ranked by the traffic of
+the pages reporting them, so that fixes for high-traffic pages with low
+scores come first.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		weights, err := loadWeights(cmd)
+		if err != nil {
+			return err
+		}
+		if weights == nil {
+			return fmt.Errorf("give --weights, --weights-sitemap or --weights-gsc")
+		}
+		results, err := bulk.LoadReport(args[0])
+		if err != nil {
+			return err
+		}
+		formatter := formatter.New(output)
+		fmt.Print(formatter.FormatScorecard(scorecardOf(cmd, results, weights)))
+		return nil
+	},
+}
+
+// addWeightFlags adds the flags of commands that weight pages by
+// importance.
+func addWeightFlags(cmd *cobra.Command) {
+	cmd.Flags().String("weights", "", "CSV of page importance (traffic export or URL,weight) to compute the importance-weighted site score with")
+	cmd.Flags().String("weight-column", "", "Column of --weights to weight pages by (default: the first of weight, importance, priority, clicks, sessions, views, pageviews, users, impressions)")
+	cmd.Flags().String("weights-sitemap", "", "Weight pages by their <priority> in this sitemap instead")
+	cmd.Flags().String("weights-gsc", "", "Weight pages by their clicks or impressions in the --gsc-site Search Console property instead")
+	cmd.Flags().Int("scorecard-top", 10, "Number of pages costing the site score most, and of findings by affected traffic, to list")
+	addSearchConsoleFlags(cmd)
+}
+
+// loadWeights reads the page weights the flags give; nil without any.
+func loadWeights(cmd *cobra.Command) (*importance.Weights, error) {
+	file, _ := cmd.Flags().GetString("weights")
+	column, _ := cmd.Flags().GetString("weight-column")
+	sitemapURL, _ := cmd.Flags().GetString("weights-sitemap")
+	gscMetric, _ := cmd.Flags().GetString("weights-gsc")
+	given := 0
+	for _, source := range []string{file, sitemapURL, gscMetric} {
+		if source != "" {
+			given++
+		}
+	}
+	switch {
+	case given > 1:
+		return nil, fmt.Errorf("give only one of --weights, --weights-sitemap and --weights-gsc")
+	case gscMetric != "":
+		return searchConsoleWeights(cmd, gscMetric)
+	case file != "":
+		return importance.LoadCSV(file, column)
+	case sitemapURL != "":
+		entries, err := sitemap.Fetch(cmd.Context(), sitemapURL)
+		if err != nil {
+			return nil, err
+		}
+		return importance.FromSitemap(entries, sitemapURL), nil
+	}
+	return nil, nil
+}
+
+// scorecardOf computes the scorecard of bulk results, keyed like the
+// quality gate, with their findings ranked by the traffic they affect.
+func scorecardOf(cmd *cobra.Command, results []*bulk.BulkResult, weights *importance.Weights) *importance.Scorecard {
+	top, _ := cmd.Flags().GetInt("scorecard-top")
+	pages := make([]baseline.Page, 0, len(results))
+	var findings []importance.PageFindings
+	for _, r := range results {
+		key := r.URL
+		if r.ID != "" {
+			key = r.ID
+		}
+		pages = append(pages, baseline.PageOf(key, r.Result, r.Error))
+		if r.Error == nil && r.Result != nil && r.Result.LocalScore != nil {
+			findings = append(findings, importance.PageFindings{Key: key, Findings: r.Result.LocalScore.Findings})
+		}
+	}
+	sc := importance.NewScorecard(pages, weights, top)
+	sc.Fixes = importance.RankFixes(findings, weights, top)
+	return sc
+}
+
+func init() {
+	scorecardCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown)")
+	addWeightFlags(scorecardCmd)
+	rootCmd.AddCommand(scorecardCmd)
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/importance"
+	"geo-checker/pkg/searchconsole"
+	"geo-checker/pkg/ui"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var searchConsoleCmd = &cobra.Command{
+	Use:   "search-console <property>",
+	Short: "List the pages of a Search Console property with their search traffic",
+	Long: `List the pages of a Google Search Console property (e.g.
+sc-domain:example.com or https://www.example.com/) shown in Google Search
+over the last --days days, most clicked first, with their clicks,
+impressions, click-through rate and average position, and with --queries
+their top search queries.
+
+The API signs in with a service account key (--credentials, or
+GOOGLE_APPLICATION_CREDENTIALS); add the account's email as a user of the
+property in Search Console. The API has no list of indexed pages: pages
+with impressions are the indexed pages that matter.
+
+-o csv writes URL,Clicks,Impressions for --weights, and -o urls one URL per
+line for bulk. bulk and scorecard can also read the property directly with
+--gsc-site: bulk analyzes its pages when given no file, and --weights-gsc
+weights pages by their clicks or impressions.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		credentials, _ := cmd.Flags().GetString("credentials")
+		days, _ := cmd.Flags().GetInt("days")
+		limit, _ := cmd.Flags().GetInt("limit")
+		queries, _ := cmd.Flags().GetInt("queries")
+
+		client, err := searchconsole.New(args[0], credentials)
+		if err != nil {
+			return err
+		}
+		pages, err := client.Pages(cmd.Context(), days, limit)
+		if err != nil {
+			return err
+		}
+		if queries > 0 {
+			if err := client.AddQueries(cmd.Context(), pages, days, queries); err != nil {
+				return err
+			}
+		}
+
+		switch output {
+		case "json":
+			data, _ := json.MarshalIndent(map[string]any{"property": args[0], "days": days, "pages": pages}, "", "  ")
+			fmt.Println(string(data))
+		case "csv":
+			w := csv.NewWriter(os.Stdout)
+			w.Write([]string{"URL", "Clicks", "Impressions"})
+			for _, p := range pages {
+				w.Write([]string{p.URL, strconv.FormatFloat(p.Clicks, 'f', -1, 64), strconv.FormatFloat(p.Impressions, 'f', -1, 64)})
+			}
+			w.Flush()
+			return w.Error()
+		case "urls":
+			for _, p := range pages {
+				fmt.Println(p.URL)
+			}
+		default:
+			printSearchConsolePages(args[0], days, pages)
+		}
+		return nil
+	},
+}
+
+func printSearchConsolePages(property string, days int, pages []searchconsole.Page) {
+	u := ui.New()
+	u.PrintHeader("SEARCH CONSOLE PAGES")
+	u.PrintKeyValue("Property", property)
+	u.PrintKeyValue("Period", fmt.Sprintf("last %d days", days))
+	u.PrintKeyValue("Pages", fmt.Sprintf("%d", len(pages)))
+	fmt.Println()
+	for _, p := range pages {
+		fmt.Printf("%-60s %8.0f clicks %10.0f impressions %5.1f%% CTR  position %.1f\n",
+			p.URL, p.Clicks, p.Impressions, 100*p.CTR, p.Position)
+		for _, q := range p.Queries {
+			fmt.Printf("    %-56s %8.0f clicks %10.0f impressions  position %.1f\n", q.Query, q.Clicks, q.Impressions, q.Position)
+		}
+	}
+}
+
+// addSearchConsoleFlags adds the flags of commands that read a Search
+// Console property.
+func addSearchConsoleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("gsc-site", "", "Search Console property to read pages and traffic from, e.g. sc-domain:example.com")
+	cmd.Flags().String("gsc-credentials", "", "Service-account key file for Search Console (defaults to GOOGLE_APPLICATION_CREDENTIALS)")
+	cmd.Flags().Int("gsc-days", 28, "Days of Search Console data to read, ending yesterday")
+}
+
+// searchConsolePages reads the pages of the property --gsc-site gives, at
+// most limit unless it is 0.
+func searchConsolePages(cmd *cobra.Command, limit int) ([]searchconsole.Page, error) {
+	site, _ := cmd.Flags().GetString("gsc-site")
+	credentials, _ := cmd.Flags().GetString("gsc-credentials")
+	days, _ := cmd.Flags().GetInt("gsc-days")
+	if site == "" {
+		return nil, fmt.Errorf("give the Search Console property with --gsc-site")
+	}
+	client, err := searchconsole.New(site, credentials)
+	if err != nil {
+		return nil, err
+	}
+	return client.Pages(cmd.Context(), days, limit)
+}
+
+// processSearchConsole analyzes the --gsc-limit most clicked pages of the
+// --gsc-site property.
+func processSearchConsole(cmd *cobra.Command, processor *bulk.Processor) ([]*bulk.BulkResult, error) {
+	limit, _ := cmd.Flags().GetInt("gsc-limit")
+	pages, err := searchConsolePages(cmd, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		site, _ := cmd.Flags().GetString("gsc-site")
+		return nil, fmt.Errorf("Search Console lists no pages of %s", site)
+	}
+	urls := make([]string, 0, len(pages))
+	for _, p := range pages {
+		urls = append(urls, p.URL)
+	}
+	return processor.ProcessURLs(cmd.Context(), urls)
+}
+
+// searchConsoleWeights weights the pages of the --gsc-site property by
+// their clicks or impressions.
+func searchConsoleWeights(cmd *cobra.Command, metric string) (*importance.Weights, error) {
+	if metric != "clicks" && metric != "impressions" {
+		return nil, fmt.Errorf("unknown --weights-gsc metric %q (clicks, impressions)", metric)
+	}
+	pages, err := searchConsolePages(cmd, 0)
+	if err != nil {
+		return nil, err
+	}
+	site, _ := cmd.Flags().GetString("gsc-site")
+	w := importance.New(fmt.Sprintf("Search Console %s of %s", metric, site), 0)
+	for _, p := range pages {
+		if metric == "clicks" {
+			w.Set(p.URL, p.Clicks)
+		} else {
+			w.Set(p.URL, p.Impressions)
+		}
+	}
+	if w.Len() == 0 {
+		return nil, fmt.Errorf("Search Console lists no pages of %s", site)
+	}
+	return w, nil
+}
+
+func init() {
+	searchConsoleCmd.Flags().StringP("output", "o", "text", "Output format (text, json, csv, urls)")
+	searchConsoleCmd.Flags().String("credentials", "", "Service-account key file (defaults to GOOGLE_APPLICATION_CREDENTIALS)")
+	searchConsoleCmd.Flags().Int("days", 28, "Days of data to read, ending yesterday")
+	searchConsoleCmd.Flags().Int("limit", 0, "List at most this many pages, most clicked first (0 for all)")
+	searchConsoleCmd.Flags().Int("queries", 0, "List this many top search queries of each page")
+	rootCmd.AddCommand(searchConsoleCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/pkg/selftest"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Verify extraction and local scoring against built-in fixtures",
+	Long: `Run content extraction and the local scorer over HTML fixtures embedded in
+the binary and compare the results with their recorded golden outputs. Use it
+to confirm an installed binary behaves correctly on this platform; it needs no
+network access or API keys.
+
+Exits with status 1 when any fixture differs.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+
+		results, err := selftest.Run()
+		if err != nil {
+			return fmt.Errorf("failed to run self-test: %w", err)
+		}
+
+		failed := 0
+		for _, r := range results {
+			if !r.OK {
+				failed++
+			}
+		}
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(results, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Printf("Self-test (%s/%s, %s)\n\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+			for _, r := range results {
+				if r.OK {
+					fmt.Printf("  ok    %s\n", r.Fixture)
+					continue
+				}
+				fmt.Printf("  FAIL  %s\n", r.Fixture)
+				if r.Error != "" {
+					fmt.Printf("        %s\n", r.Error)
+				}
+				if r.Diff != "" {
+					fmt.Print(r.Diff)
+				}
+			}
+			fmt.Printf("\n%d of %d fixtures match their golden output\n", len(results)-failed, len(results))
+		}
+
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &ExitError{Code: 1, Err: fmt.Errorf("%d self-test fixtures failed", failed)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	selftestCmd.Flags().StringP("output", "o", "text", "Report format (text, json)")
+	rootCmd.AddCommand(selftestCmd)
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/jsonrpc"
+	"geo-checker/pkg/llm"
+	"geo-checker/pkg/server"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the analyzer as an HTTP (and optional gRPC) service",
+	Long: `Run geo-checker as a long-lived service.
+
+REST endpoints:
+  GET  /healthz             liveness check
+  POST /v1/analyze          {"url": "..."}
+  POST /v1/analyze-content  {"title": "...", "content": "..."}
+  POST /v1/bulk             {"urls": [...], "concurrency": 5}  (streams NDJSON)
+  POST /v1/jobs             {"urls": [...], "callback_url": "..."}  (returns 202 + job ID)
+  GET  /v1/jobs             all retained jobs, newest first
+  GET  /v1/jobs/{id}        job status, progress and results
+  GET  /v1/jobs/{id}/report ?format=json|markdown|text
+  GET  /                    web dashboard
+
+Jobs run in the background; when callback_url is set the finished job is POSTed
+to it (signed with HMAC-SHA256 in X-Geo-Signature if GEO_WEBHOOK_SECRET is set).
+
+With --grpc-addr the same operations are also served over gRPC, as defined in
+api/geochecker/v1/geochecker.proto.
+
+With --stdio no port is opened: JSON-RPC 2.0 requests are read from stdin and
+answered on stdout, one JSON object per line, for GUI wrappers and editor
+plugins running geo-checker as a subprocess. Methods:
+  initialize      protocol and tool versions, the resolved mode
+  analyzeUrl      {"url": "..."}  the same result as analyze -o json
+  analyzeContent  {"content": "...", "title": "...", "format": "text|html|markdown"}
+  score           the same parameters; the local score only, without an LLM
+  shutdown, exit
+Requests run concurrently; match responses to requests by id. Logs and
+warnings go to stderr.
+
+Pages are only fetched from public addresses: URLs naming loopback, private
+or link-local addresses (such as cloud metadata services) are refused. Name
+internal hosts the server may fetch from with --allow-host. Requests list at
+most 1000 URLs and bulk runs use at most 20 workers.
+
+When API keys are configured, clients must send "Authorization: Bearer <key>"
+or "X-API-Key: <key>" (gRPC: the same names as metadata). Exceeding a key's rate
+limit or daily quota returns 429 (gRPC: RESOURCE_EXHAUSTED). Quotas count URLs:
+a bulk request or job is refused unless the quota covers all of its URLs.
+
+Every flag can also be set through a GEO_<FLAG> environment variable (e.g.
+GEO_ADDR, GEO_GRPC_ADDR, GEO_MODE; the keys file is GEO_API_KEYS_FILE). Use
+--healthcheck as a container health probe: it checks /healthz on --addr and
+exits non-zero when the server is unhealthy.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, _ := cmd.Flags().GetString("provider")
+		model, _ := cmd.Flags().GetString("model")
+		mode, _ := cmd.Flags().GetString("mode")
+		addr, _ := cmd.Flags().GetString("addr")
+		grpcAddr, _ := cmd.Flags().GetString("grpc-addr")
+		keysFile, _ := cmd.Flags().GetString("api-keys")
+		rateLimit, _ := cmd.Flags().GetInt("rate-limit")
+		quota, _ := cmd.Flags().GetInt("daily-quota")
+		healthcheck, _ := cmd.Flags().GetBool("healthcheck")
+		shutdownTimeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+		stdio, _ := cmd.Flags().GetBool("stdio")
+		allowHosts, _ := cmd.Flags().GetStringSlice("allow-host")
+
+		if healthcheck {
+			cmd.SilenceUsage = true
+			return server.Probe(addr)
+		}
+
+		if model == "" {
+			model = llm.GetRecommendedModel(provider)
+		}
+
+		cfg := &config.Config{
+			LLMProvider:  provider,
+			Model:        model,
+			Mode:         mode,
+			MaxTokens:    4000,
+			Temperature:  0.7,
+			Timeout:      requestTimeout(cmd),
+			AllowedHosts: allowHosts,
+		}
+
+		if stdio {
+			// The server is quiet: stdout carries the protocol alone
+			return jsonrpc.New(cfg).Serve(cmd.Context(), os.Stdin, os.Stdout)
+		}
+
+		// Either server failing stops the other
+		ctx, stop := context.WithCancel(cmd.Context())
+		defer stop()
+
+		keys, err := server.LoadAPIKeys(keysFile)
+		if err != nil {
+			return err
+		}
+		auth := server.NewAuthenticator(keys, rateLimit, quota)
+
+		srv := server.New(cfg)
+		srv.UseAuthenticator(auth)
+		srv.ShutdownTimeout = shutdownTimeout
+		if !auth.Enabled() {
+			fmt.Println("Warning: no API keys configured - the service is unauthenticated; only expose it on localhost")
+		}
+		errCh := make(chan error, 2)
+
+		fmt.Printf("Serving HTTP API on %s (mode: %s)\n", addr, srv.Mode())
+		go func() { errCh <- srv.ListenAndServe(ctx, addr) }()
+
+		running := 1
+		if grpcAddr != "" {
+			fmt.Printf("Serving gRPC API on %s\n", grpcAddr)
+			go func() { errCh <- srv.ServeGRPC(ctx, grpcAddr) }()
+			running++
+		}
+
+		var firstErr error
+		for i := 0; i < running; i++ {
+			if err := <-errCh; err != nil && firstErr == nil {
+				firstErr = err
+				stop()
+			}
+		}
+		return firstErr
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, gemini, local, ollama)")
+	serveCmd.Flags().StringP("model", "m", "", "Model to use (leave empty for recommended model)")
+	serveCmd.Flags().StringP("mode", "", "auto", "Analysis mode (auto, local, llm, hybrid)")
+	serveCmd.Flags().String("addr", ":8080", "HTTP listen address")
+	serveCmd.Flags().String("grpc-addr", "", "gRPC listen address (disabled when empty)")
+	serveCmd.Flags().String("api-keys", "", "JSON file of API keys with optional per-key limits (also reads GEO_API_KEYS)")
+	serveCmd.Flags().Int("rate-limit", 60, "Default requests per minute per API key (0 = unlimited)")
+	serveCmd.Flags().Int("daily-quota", 0, "Default URLs analyzed per day per API key (0 = unlimited)")
+	serveCmd.Flags().Bool("healthcheck", false, "Probe a running server's /healthz on --addr and exit (for container health checks)")
+	serveCmd.Flags().Bool("stdio", false, "Speak JSON-RPC on stdin/stdout instead of serving HTTP")
+	serveCmd.Flags().StringSlice("allow-host", nil, "Non-public host the server may fetch pages from (repeatable)")
+	serveCmd.Flags().Duration("shutdown-timeout", 25*time.Second, "How long to wait for in-flight requests on SIGTERM")
+	rootCmd.AddCommand(serveCmd)
+}
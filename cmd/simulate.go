@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/ui"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate [URL]",
+	Short: "Project the GEO score after hypothetical content changes",
+	Long: `Apply hypothetical changes to a page and report the score the local
+scoring model projects, without editing the content. Useful for deciding which
+fixes are worth the effort.
+
+  simulate --add-h1 --add-meta-description <url>
+  simulate --shorten-paragraphs --add-citations 5 <url>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		var scenario scorer.Scenario
+		scenario.AddH1, _ = cmd.Flags().GetBool("add-h1")
+		scenario.ShortenParagraphs, _ = cmd.Flags().GetBool("shorten-paragraphs")
+		scenario.AddCitations, _ = cmd.Flags().GetInt("add-citations")
+		scenario.AddMetaDescription, _ = cmd.Flags().GetBool("add-meta-description")
+
+		if scenario == (scorer.Scenario{}) {
+			return fmt.Errorf("no changes given; use --add-h1, --shorten-paragraphs, --add-citations or --add-meta-description")
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+
+		pageData, err := webpage.New().ScrapeURL(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to scrape URL: %w", err)
+		}
+
+		sim := scorer.NewLocalScorer().Simulate(pageData.Content, pageData, scenario)
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(struct {
+				URL      string          `json:"url"`
+				Scenario scorer.Scenario `json:"scenario"`
+				*scorer.Simulation
+			}{args[0], scenario, sim}, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		u := ui.New()
+		u.PrintSection("What-if Simulation")
+		u.PrintKeyValue("URL", args[0])
+		fmt.Println()
+		if len(sim.Changes) == 0 {
+			u.PrintInfo("None of the changes apply to this page")
+		}
+		for _, change := range sim.Changes {
+			u.PrintListItem(change, true)
+		}
+		fmt.Println()
+		fmt.Printf("  %-20s %6s %6s %6s\n", "", "Now", "After", "Change")
+		for _, p := range sim.Pillars {
+			fmt.Printf("  %-20s %6d %6d %+6d\n", p.Name, p.Before, p.After, p.After-p.Before)
+		}
+		fmt.Printf("  %-20s %6d %6d %+6d\n", "Overall", sim.Before.Overall, sim.After.Overall, sim.After.Overall-sim.Before.Overall)
+		return nil
+	},
+}
+
+func init() {
+	simulateCmd.Flags().Bool("add-h1", false, "Add an H1 heading (from the page title) if the page has none")
+	simulateCmd.Flags().Bool("shorten-paragraphs", false, "Split paragraphs over 150 words")
+	simulateCmd.Flags().Int("add-citations", 0, "Add this many citations")
+	simulateCmd.Flags().Bool("add-meta-description", false, "Add a meta description if missing or too short")
+	simulateCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	rootCmd.AddCommand(simulateCmd)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"geo-checker/pkg/sitemap"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var sitemapCmd = &cobra.Command{
+	Use:   "sitemap [sitemap URL]",
+	Short: "Audit the freshness metadata of a sitemap",
+	Long: `Fetch a sitemap (or sitemap index) and every page it lists, and check
+each <lastmod> against what the page itself declares:
+
+  missing  no <lastmod>
+  invalid  <lastmod> is not a W3C datetime
+  future   <lastmod> lies in the future
+  stale    the page's modified date (article:modified_time, og:updated_time
+           or the Last-Modified header) is later than <lastmod>
+  changed  the page content changed since the previous audit but <lastmod>
+           was not updated
+
+Content hashes are kept in the history directory (GEO_HISTORY_DIR) between
+runs; the "changed" check needs at least one earlier audit.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		concurrent, _ := cmd.Flags().GetInt("concurrent")
+		limit, _ := cmd.Flags().GetInt("limit")
+		tolerance, _ := cmd.Flags().GetDuration("tolerance")
+		noHistory, _ := cmd.Flags().GetBool("no-history")
+		failOnIssues, _ := cmd.Flags().GetBool("fail-on-issues")
+
+		entries, err := sitemap.Fetch(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		if limit > 0 && len(entries) > limit {
+			entries = entries[:limit]
+		}
+
+		auditor := sitemap.NewAuditor()
+		auditor.Concurrency = concurrent
+		auditor.Tolerance = tolerance
+		if !noHistory {
+			path, err := sitemap.DefaultHashStorePath()
+			if err != nil {
+				return err
+			}
+			if auditor.History, err = sitemap.OpenHashStore(path); err != nil {
+				return err
+			}
+		}
+
+		report := auditor.Audit(cmd.Context(), entries)
+		if auditor.History != nil {
+			if err := auditor.History.Save(); err != nil {
+				return err
+			}
+		}
+
+		if output == "json" {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Printf("Sitemap freshness for %s\n\n", args[0])
+			for _, c := range report.Checks {
+				fmt.Printf("  %-8s %s\n", c.Status, c.URL)
+				if c.Detail != "" {
+					fmt.Printf("           %s\n", c.Detail)
+				}
+			}
+			fmt.Printf("\n%d pages checked, %d ok, %d with freshness issues\n", report.Checked, report.Counts[sitemap.StatusOK], report.Issues())
+		}
+
+		if failOnIssues && report.Issues() > 0 {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &ExitError{Code: 2, Err: fmt.Errorf("%d of %d sitemap entries have freshness issues", report.Issues(), report.Checked)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	sitemapCmd.Flags().StringP("output", "o", "text", "Report format (text, json)")
+	sitemapCmd.Flags().IntP("concurrent", "c", 5, "Number of pages fetched at once")
+	sitemapCmd.Flags().Int("limit", 0, "Check at most this many entries (0 checks all)")
+	sitemapCmd.Flags().Duration("tolerance", 24*time.Hour, "Treat dates closer than this as equal (date-only lastmod values are midnight UTC)")
+	sitemapCmd.Flags().Bool("no-history", false, "Do not read or update stored content hashes")
+	sitemapCmd.Flags().Bool("fail-on-issues", false, "Exit with status 2 when any entry has a freshness issue")
+	rootCmd.AddCommand(sitemapCmd)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/formatter"
+	"geo-checker/pkg/llm"
+	"geo-checker/pkg/ui"
+	"geo-checker/pkg/wordpress"
+
+	"github.com/spf13/cobra"
+)
+
+var wordpressCmd = &cobra.Command{
+	Use:   "wordpress [site URL]",
+	Short: "Analyze posts and pages directly from a WordPress site",
+	Long: `Pull posts and pages through the WordPress REST API and analyze their rendered content,
+without crawling the site over HTTP. Set WP_USERNAME and WP_APP_PASSWORD (an application
+password) to include drafts or to write suggested meta descriptions back as autosave drafts.
+
+Descriptions are read from and written to the meta field of the SEO plugin a post shows
+signs of: Yoast, Rank Math or All in One SEO. Items with no plugin detected are skipped
+unless --meta-field names the post meta key to write.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		siteURL := args[0]
+
+		provider, _ := cmd.Flags().GetString("provider")
+		model, _ := cmd.Flags().GetString("model")
+		output, _ := cmd.Flags().GetString("output")
+		mode, _ := cmd.Flags().GetString("mode")
+		types, _ := cmd.Flags().GetStringSlice("type")
+		status, _ := cmd.Flags().GetString("status")
+		limit, _ := cmd.Flags().GetInt("limit")
+		writeMeta, _ := cmd.Flags().GetBool("write-meta")
+		metaField, _ := cmd.Flags().GetString("meta-field")
+
+		if model != "" && provider != "" {
+			if err := llm.ValidateModelForProvider(provider, model); err != nil {
+				return fmt.Errorf("model validation failed: %w", err)
+			}
+		}
+		if model == "" {
+			model = llm.GetRecommendedModel(provider)
+		}
+
+		if output == "text" {
+			ui.New().PrintBanner()
+		}
+
+		client, err := wordpress.NewClient(siteURL)
+		if err != nil {
+			return err
+		}
+		if writeMeta && !client.Authenticated() {
+			return fmt.Errorf("--write-meta requires WP_USERNAME and WP_APP_PASSWORD")
+		}
+
+		cfg := &config.Config{
+			LLMProvider:  provider,
+			Model:        model,
+			OutputFormat: output,
+			Mode:         mode,
+			MaxTokens:    4000,
+			Temperature:  0.7,
+			Timeout:      requestTimeout(cmd),
+		}
+
+		ctx := cmd.Context()
+		scraper := webpage.New()
+		a := analyzer.New(cfg)
+		var results []*bulk.BulkResult
+		drafts, skipped := 0, 0
+
+		for _, postType := range types {
+			posts, err := client.ListPosts(ctx, postType, status, limit)
+			if err != nil {
+				return fmt.Errorf("failed to list %s: %w", postType, err)
+			}
+
+			for i := range posts {
+				post := &posts[i]
+				result := &bulk.BulkResult{URL: post.Link}
+				results = append(results, result)
+
+				pageData, err := scraper.ParseHTML(post.HTML(), post.Link)
+				if err != nil {
+					result.Error = analyzer.Classify(err)
+					continue
+				}
+				analysis, err := a.AnalyzePage(ctx, pageData, post.Link)
+				if err != nil {
+					result.Error = analyzer.Classify(err)
+					continue
+				}
+				result.Result = analysis
+
+				if writeMeta && post.MetaDescription() == "" {
+					field := post.DescriptionField()
+					if field == "" {
+						field = metaField
+					}
+					if field == "" {
+						// Without a plugin every post looks undescribed
+						analysis.Metadata["meta_writeback_skipped"] = "no SEO plugin detected (Yoast, Rank Math, All in One SEO); give --meta-field to write anyway"
+						skipped++
+						continue
+					}
+					suggestion := wordpress.SuggestMetaDescription(pageData.Content)
+					if err := client.SaveDraftMetaDescription(ctx, post, field, suggestion); err != nil {
+						analysis.Metadata["meta_writeback_error"] = err.Error()
+					} else {
+						analysis.Metadata["suggested_meta_description"] = suggestion
+						analysis.Metadata["meta_description_field"] = field
+						drafts++
+					}
+				}
+			}
+		}
+
+		formatter := formatter.New(output)
+		fmt.Print(formatter.FormatBulkResults(results))
+
+		if writeMeta && output == "text" {
+			fmt.Printf("\nSaved %d suggested meta descriptions as drafts\n", drafts)
+			if skipped > 0 {
+				fmt.Printf("Skipped %d items with no SEO plugin detected; give --meta-field to write their descriptions\n", skipped)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	wordpressCmd.Flags().StringP("provider", "p", "claude", "LLM provider (claude, openai, gemini, local, ollama)")
+	wordpressCmd.Flags().StringP("model", "m", "", "Model to use (leave empty for recommended model)")
+	wordpressCmd.Flags().StringP("output", "o", "text", "Output format (text, json, markdown)")
+	wordpressCmd.Flags().StringP("mode", "", "auto", "Analysis mode (auto, local, llm, hybrid)")
+	wordpressCmd.Flags().StringSlice("type", []string{"posts", "pages"}, "Content types to fetch (posts, pages, or a custom post type)")
+	wordpressCmd.Flags().String("status", "publish", "Post status to fetch (publish, draft, any)")
+	wordpressCmd.Flags().Int("limit", 0, "Maximum items per content type (0 = all)")
+	wordpressCmd.Flags().Bool("write-meta", false, "Save suggested meta descriptions as autosave drafts, in the meta field of the detected SEO plugin (Yoast, Rank Math, All in One SEO), for items missing one")
+	wordpressCmd.Flags().String("meta-field", "", "Post meta key to write descriptions to for items with no SEO plugin detected (they are skipped otherwise)")
+	rootCmd.AddCommand(wordpressCmd)
+}
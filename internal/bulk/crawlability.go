@@ -0,0 +1,106 @@
+package bulk
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Crawlability issue kinds reported across a bulk run.
+const (
+	IssueRedirectChain        = "redirect_chain"
+	IssueHTTPSDowngrade       = "https_downgrade"
+	IssueMixedLinks           = "mixed_links"
+	IssueCrossDomainCanonical = "cross_domain_canonical"
+)
+
+// maxEvidence caps the evidence lines kept per issue.
+const maxEvidence = 5
+
+// CrawlIssue is one crawlability problem found on one URL.
+type CrawlIssue struct {
+	URL      string   `json:"url"`
+	Kind     string   `json:"kind"`
+	Detail   string   `json:"detail"`
+	Evidence []string `json:"evidence"`
+}
+
+// CrawlabilityIssues collects redirect chains, HTTPS downgrades, plain-HTTP
+// links on HTTPS pages and cross-domain canonicals from a bulk run.
+func CrawlabilityIssues(results []*BulkResult) []CrawlIssue {
+	var issues []CrawlIssue
+	for _, r := range results {
+		if r.Result == nil || r.Result.Crawl == nil {
+			continue
+		}
+		crawl := r.Result.Crawl
+
+		if len(crawl.Redirects) > 0 {
+			var hops []string
+			downgrade := false
+			for _, hop := range crawl.Redirects {
+				hops = append(hops, fmt.Sprintf("%d %s -> %s", hop.Status, hop.From, hop.To))
+				if strings.HasPrefix(hop.From, "https://") && strings.HasPrefix(hop.To, "http://") {
+					downgrade = true
+				}
+			}
+			if len(crawl.Redirects) > 1 {
+				issues = append(issues, CrawlIssue{
+					URL:      r.URL,
+					Kind:     IssueRedirectChain,
+					Detail:   fmt.Sprintf("%d redirects before reaching %s", len(crawl.Redirects), crawl.FinalURL),
+					Evidence: hops,
+				})
+			}
+			if downgrade {
+				issues = append(issues, CrawlIssue{
+					URL:      r.URL,
+					Kind:     IssueHTTPSDowngrade,
+					Detail:   "a redirect sends HTTPS traffic to plain HTTP",
+					Evidence: hops,
+				})
+			}
+		}
+
+		if n := len(crawl.InsecureLinks); n > 0 {
+			evidence := crawl.InsecureLinks
+			if n > maxEvidence {
+				evidence = append(append([]string{}, evidence[:maxEvidence]...), fmt.Sprintf("... and %d more", n-maxEvidence))
+			}
+			issues = append(issues, CrawlIssue{
+				URL:      r.URL,
+				Kind:     IssueMixedLinks,
+				Detail:   fmt.Sprintf("HTTPS page references %d plain-HTTP links or resources", n),
+				Evidence: evidence,
+			})
+		}
+
+		if crawl.Canonical != "" {
+			pageURL := crawl.FinalURL
+			if pageURL == "" {
+				pageURL = r.URL
+			}
+			if !sameSite(pageURL, crawl.Canonical) {
+				issues = append(issues, CrawlIssue{
+					URL:      r.URL,
+					Kind:     IssueCrossDomainCanonical,
+					Detail:   "canonical URL points to a different domain",
+					Evidence: []string{"page: " + pageURL, "canonical: " + crawl.Canonical},
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// sameSite compares hosts, ignoring a leading "www.".
+func sameSite(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return true
+	}
+	hostA := strings.TrimPrefix(strings.ToLower(ua.Hostname()), "www.")
+	hostB := strings.TrimPrefix(strings.ToLower(ub.Hostname()), "www.")
+	return hostA == hostB
+}
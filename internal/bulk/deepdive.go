@@ -0,0 +1,59 @@
+package bulk
+
+import (
+	"slices"
+	"sort"
+)
+
+// deepDive picks the triaged results to analyze again with the LLM: those
+// scoring below threshold and the lowest-scoring percent of the pages that
+// were scored, lowest first on ties by input order. Either limit may be 0.
+// Pages that failed triage are not picked; their failure is reported.
+func deepDive(triaged []*BulkResult, threshold, percent int) []int {
+	var scored []int
+	for i, r := range triaged {
+		if r != nil && r.Result != nil {
+			scored = append(scored, i)
+		}
+	}
+	sort.SliceStable(scored, func(a, b int) bool {
+		return triaged[scored[a]].Result.Score < triaged[scored[b]].Result.Score
+	})
+
+	picked := make(map[int]bool)
+	if percent > 0 {
+		n := (percent*len(scored) + 99) / 100
+		for _, i := range scored[:min(n, len(scored))] {
+			picked[i] = true
+		}
+	}
+	for _, i := range scored {
+		if triaged[i].Result.Score < threshold {
+			picked[i] = true
+		}
+	}
+
+	indices := make([]int, 0, len(picked))
+	for i := range picked {
+		indices = append(indices, i)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// withTriage records the triage score in the result of a deep dive. When
+// the deep dive failed, the page keeps its triage result, noting why.
+func withTriage(deep, triage *BulkResult) *BulkResult {
+	if deep.Error != nil {
+		if triage.Result == nil {
+			return deep
+		}
+		kept := *triage
+		kept.Result.Metadata["deep_dive_error"] = deep.Error.Message
+		return &kept
+	}
+	if deep.Result != nil && triage.Result != nil {
+		deep.Result.Metadata["triage_score"] = triage.Result.Score
+	}
+	return deep
+}
@@ -0,0 +1,72 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/config"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDeepDive(t *testing.T) {
+	scores := []int{70, 20, 55, 90, 40}
+	triaged := make([]*BulkResult, len(scores)+1)
+	for i, score := range scores {
+		triaged[i] = &BulkResult{Result: &analyzer.Result{Score: score}}
+	}
+	triaged[len(scores)] = &BulkResult{Error: &analyzer.Error{Message: "failed"}}
+
+	tests := []struct {
+		threshold, percent int
+		want               string
+	}{
+		{0, 0, "[]"},
+		{50, 0, "[1 4]"},
+		{0, 20, "[1]"},
+		{0, 50, "[1 2 4]"}, // 2.5 of 5 pages rounds up
+		{45, 20, "[1 4]"},
+		{0, 100, "[0 1 2 3 4]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(deepDive(triaged, tt.threshold, tt.percent)); got != tt.want {
+			t.Errorf("deepDive(threshold %d, percent %d) = %s, want %s", tt.threshold, tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestProcessURLsDeepDive(t *testing.T) {
+	var llmCalls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			llmCalls.Add(1)
+			w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"GEO Score: 35/100\nAdd structure."}}],"usage":{"total_tokens":10}}`))
+		case "/thin":
+			w.Write([]byte(`<html><body><p>Too short.</p></body></html>`))
+		default:
+			w.Write([]byte(`<html><head><title>Install guide</title><meta name="description" content="How to install the widget on Linux, macOS and Windows."></head><body><main>
+<h1>Install guide</h1><h2>Requirements</h2><p>The widget needs 2 GB of memory and a 64-bit system, according to the vendor documentation published in 2024.</p>
+<h2>Steps</h2><ol><li>Download the installer.</li><li>Run it.</li><li>Restart.</li></ol></main></body></html>`))
+		}
+	}))
+	defer ts.Close()
+
+	p := New(&config.Config{Mode: "llm", LLMProvider: "local", LocalLLMURL: ts.URL, Model: "llama2", OutputFormat: "json", Concurrent: 2, Timeout: 30, DeepDivePercent: 50})
+	results, err := p.ProcessURLs(context.Background(), []string{ts.URL + "/guide", ts.URL + "/thin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := llmCalls.Load(); n != 1 {
+		t.Errorf("LLM called %d times, want once for the lower-scoring page", n)
+	}
+	if r := results[0].Result; r == nil || r.Mode != "local" || r.Metadata["triage_score"] != nil {
+		t.Errorf("guide: %+v, want its local triage result", results[0])
+	}
+	if r := results[1].Result; r == nil || r.Mode != "llm" || r.Metadata["triage_score"] == nil {
+		t.Errorf("thin: %+v, want an LLM result with its triage score", results[1])
+	}
+}
@@ -0,0 +1,99 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"os"
+	"strings"
+)
+
+// Document is content to analyze as it is, without fetching it, e.g. an
+// entry exported from a CMS. Content is plain text or an HTML document or
+// fragment; URL is optional and only reported.
+type Document struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	URL     string `json:"url,omitempty"`
+}
+
+// ReadDocumentFile reads a JSON array of documents. Every document needs a
+// unique ID, which its result is reported under.
+func ReadDocumentFile(filename string) ([]Document, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	var docs []Document
+	if err := json.Unmarshal(data, &docs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s (want an array of {id, title, content}): %w", filename, err)
+	}
+
+	seen := make(map[string]bool, len(docs))
+	for i, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("document %d has no id", i+1)
+		}
+		if seen[doc.ID] {
+			return nil, fmt.Errorf("duplicate document id %q", doc.ID)
+		}
+		seen[doc.ID] = true
+	}
+	return docs, nil
+}
+
+// ProcessDocuments analyzes docs like ProcessURLs analyzes pages, and
+// returns their results, carrying the document IDs, in input order.
+func (p *Processor) ProcessDocuments(ctx context.Context, docs []Document) ([]*BulkResult, error) {
+	urls := make([]string, len(docs))
+	for i, doc := range docs {
+		urls[i] = doc.URL
+	}
+
+	scraper := webpage.New()
+	analyze := func(ctx context.Context, a *analyzer.Analyzer, index int, result *BulkResult) {
+		doc := docs[index]
+		page, err := documentPage(scraper, doc)
+		if err == nil {
+			result.Result, err = a.AnalyzePage(ctx, page, doc.URL)
+		}
+		if err != nil {
+			result.Error = analyzer.Classify(err)
+			return
+		}
+		result.Result.Metadata["source_id"] = doc.ID
+	}
+
+	results := make([]*BulkResult, len(docs))
+	p.process(ctx, urls, analyze, func(index int, result *BulkResult) {
+		result.ID = docs[index].ID
+		results[index] = result
+	})
+	return results, nil
+}
+
+// documentPage extracts a document like a fetched page when its content is
+// HTML, and takes it as the page text otherwise. The document title wins
+// over the HTML one.
+func documentPage(scraper *webpage.Scraper, doc Document) (*webpage.PageData, error) {
+	if strings.HasPrefix(strings.TrimSpace(doc.Content), "<") {
+		page, err := scraper.ParseHTML(doc.Content, doc.URL)
+		if err != nil {
+			return nil, err
+		}
+		if doc.Title != "" {
+			page.Title = doc.Title
+		}
+		return page, nil
+	}
+	return &webpage.PageData{
+		URL:      doc.URL,
+		Title:    doc.Title,
+		Content:  doc.Content,
+		MetaTags: make(map[string]string),
+		Headings: []webpage.Heading{},
+	}, nil
+}
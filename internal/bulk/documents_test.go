@@ -0,0 +1,61 @@
+package bulk
+
+import (
+	"context"
+	"geo-checker/pkg/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadDocumentFile(t *testing.T) {
+	dir := t.TempDir()
+	for name, tc := range map[string]struct {
+		json string
+		err  string
+	}{
+		"ok":        {json: `[{"id":"a","title":"A","content":"text"},{"id":"b","content":"<p>html</p>"}]`},
+		"no id":     {json: `[{"title":"A","content":"text"}]`, err: "document 1 has no id"},
+		"duplicate": {json: `[{"id":"a"},{"id":"a"}]`, err: `duplicate document id "a"`},
+		"object":    {json: `{"id":"a"}`, err: "want an array"},
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".json")
+		os.WriteFile(path, []byte(tc.json), 0o644)
+		docs, err := ReadDocumentFile(path)
+		if tc.err == "" {
+			if err != nil || len(docs) != 2 {
+				t.Errorf("%s: got %d documents, err %v", name, len(docs), err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.err)
+		}
+	}
+}
+
+func TestProcessDocuments(t *testing.T) {
+	docs := []Document{
+		{ID: "text", Title: "Widgets", Content: "Widgets are small mechanical parts. They cost 3 dollars each and last for years."},
+		{ID: "html", Title: "Gadgets", Content: "<h1>Old title</h1><p>Gadgets are bigger than widgets and last for ten years of daily use.</p>", URL: "https://example.com/gadgets"},
+		{ID: "empty"},
+	}
+	p := New(&config.Config{Mode: "local", OutputFormat: "json", Concurrent: 2, Timeout: 30})
+	results, err := p.ProcessDocuments(context.Background(), docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(docs) {
+		t.Fatalf("got %d results, want %d", len(results), len(docs))
+	}
+	for i, r := range results[:2] {
+		if r.ID != docs[i].ID || r.URL != docs[i].URL || r.Result == nil {
+			t.Fatalf("result %d = %+v, want a score for %s", i, r, docs[i].ID)
+		}
+		if r.Result.Title != docs[i].Title || r.Result.Metadata["source_id"] != docs[i].ID {
+			t.Errorf("result %d has title %q and source id %v", i, r.Result.Title, r.Result.Metadata["source_id"])
+		}
+	}
+	if r := results[2]; r.ID != "empty" || r.Error == nil {
+		t.Errorf("empty document = %+v, want an error", r)
+	}
+}
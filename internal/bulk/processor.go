@@ -2,36 +2,81 @@ package bulk
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"geo-checker/pkg/analyzer"
 	"geo-checker/pkg/config"
+	"geo-checker/pkg/llm"
 	"geo-checker/pkg/ui"
 	"os"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Processor struct {
 	config   *config.Config
 	analyzer *analyzer.Analyzer
 	ui       *ui.UI
+	
+	// triage scores every page locally first in two-pass runs, leaving
+	// analyzer to the pages picked for a deep dive; nil otherwise
+	triage   *analyzer.Analyzer
+	
+	// retryDelay is the pause before the first retry pass; it doubles for
+	// every further pass
+	retryDelay time.Duration
 }
 
 type BulkResult struct {
+	ID      string             `json:"id,omitempty"` // document ID, for documents given as JSON
 	URL     string             `json:"url"`
 	Result  *analyzer.Result   `json:"result,omitempty"`
-	Error   string             `json:"error,omitempty"`
+	Error   *analyzer.Error    `json:"error,omitempty"`
+
+	// Attempts is how many times the URL was analyzed, counting retries
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// ErrorMessage returns the failure message, or "" for successful results.
+func (r *BulkResult) ErrorMessage() string {
+	if r.Error == nil {
+		return ""
+	}
+	return r.Error.Message
 }
 
 func New(cfg *config.Config) *Processor {
-	return &Processor{
+	// One analyzer (and LLM provider) is shared by all workers
+	acfg := *cfg
+	acfg.Quiet = true
+	p := &Processor{
 		config:   cfg,
-		analyzer: analyzer.New(cfg),
+		analyzer: analyzer.New(&acfg),
 		ui:       ui.New(),
+		
+		retryDelay: 2 * time.Second,
 	}
+	if (cfg.DeepDiveThreshold > 0 || cfg.DeepDivePercent > 0) && p.analyzer.Mode() != "local" {
+		// The triage pass spends nothing: no LLM and no search API
+		tcfg := acfg
+		tcfg.Mode = "local"
+		tcfg.Originality = ""
+		p.triage = analyzer.New(&tcfg)
+	}
+	return p
 }
 
-func (p *Processor) ProcessFile(filename string) ([]*BulkResult, error) {
+// ProviderHealth summarizes the LLM requests made by the run so far, per
+// provider.
+func (p *Processor) ProviderHealth() []llm.ProviderHealth {
+	return p.analyzer.ProviderHealth()
+}
+
+func (p *Processor) ProcessFile(ctx context.Context, filename string) ([]*BulkResult, error) {
 	urls, err := p.readURLsFromFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read URLs from file: %w", err)
@@ -41,12 +86,45 @@ func (p *Processor) ProcessFile(filename string) ([]*BulkResult, error) {
 		return nil, fmt.Errorf("no URLs found in file")
 	}
 	
-	return p.ProcessURLs(urls)
+	return p.ProcessURLs(ctx, urls)
 }
 
-func (p *Processor) ProcessURLs(urls []string) ([]*BulkResult, error) {
+// ProcessURLs analyzes urls and returns their results in input order. When
+// ctx is cancelled, URLs not yet started are reported with the context error.
+func (p *Processor) ProcessURLs(ctx context.Context, urls []string) ([]*BulkResult, error) {
 	results := make([]*BulkResult, len(urls))
-	
+	p.process(ctx, urls, p.analyzeURL(urls), func(index int, result *BulkResult) {
+		results[index] = result
+	})
+	return results, nil
+}
+
+// analyzeFunc analyzes the input at index into a result with a.
+type analyzeFunc func(ctx context.Context, a *analyzer.Analyzer, index int, result *BulkResult)
+
+// analyzeURL returns an analyzeFunc fetching and analyzing urls.
+func (p *Processor) analyzeURL(urls []string) analyzeFunc {
+	return func(ctx context.Context, a *analyzer.Analyzer, index int, result *BulkResult) {
+		analysisResult, err := a.AnalyzeURL(ctx, urls[index])
+		if err != nil {
+			result.Error = analyzer.Classify(err)
+		} else {
+			result.Result = analysisResult
+		}
+	}
+}
+
+// process analyzes the inputs named by urls with analyze, with bounded
+// concurrency, and hands every result to deliver as soon as it completes. deliver is never called concurrently.
+// Once ctx is cancelled no new analyses start and the remaining URLs are
+// delivered with the context error. URLs that fail with a retryable error
+// are queued again after each pass, up to config.Retries times; deliver then
+// sees the same index again with the newer result.
+//
+// In two-pass runs every input is scored by the triage analyzer first and
+// only those picked by deepDive are analyzed again with the LLM; the others
+// are delivered with their triage result once triage is over.
+func (p *Processor) process(ctx context.Context, urls []string, analyze analyzeFunc, deliver func(index int, result *BulkResult)) {
 	// Show status messages for text output
 	showProgress := p.config.OutputFormat != "json"
 	
@@ -57,42 +135,118 @@ func (p *Processor) ProcessURLs(urls []string) ([]*BulkResult, error) {
 		progress.PrintInfo(fmt.Sprintf("Processing %d URLs with %d concurrent workers...", len(urls), p.config.Concurrent))
 	}
 	
-	// Create a semaphore to limit concurrent requests
-	semaphore := make(chan struct{}, p.config.Concurrent)
+	pending := make([]int, len(urls))
+	for i := range urls {
+		pending[i] = i
+	}
+	if p.triage == nil {
+		p.runWithRetries(ctx, urls, pending, p.analyzer, analyze, deliver, progress)
+	} else {
+		triaged := make([]*BulkResult, len(urls))
+		p.runWithRetries(ctx, urls, pending, p.triage, analyze, func(index int, result *BulkResult) {
+			triaged[index] = result
+		}, progress)
+		
+		picked := deepDive(triaged, p.config.DeepDiveThreshold, p.config.DeepDivePercent)
+		deep := make(map[int]bool, len(picked))
+		for _, i := range picked {
+			deep[i] = true
+		}
+		for i, result := range triaged {
+			if !deep[i] {
+				deliver(i, result)
+			}
+		}
+		if showProgress {
+			progress.PrintInfo(fmt.Sprintf("Deep dive on the %d lowest-scoring of %d pages...", len(picked), len(urls)))
+		}
+		if ctx.Err() == nil {
+			p.runWithRetries(ctx, urls, picked, p.analyzer, analyze, func(index int, result *BulkResult) {
+				deliver(index, withTriage(result, triaged[index]))
+			}, progress)
+		} else {
+			for _, i := range picked {
+				deliver(i, triaged[i])
+			}
+		}
+	}
+	
+	if showProgress {
+		progress.PrintSuccess(fmt.Sprintf("Completed analysis of %d URLs!", len(urls)))
+	}
+}
+
+// runWithRetries analyzes the inputs at indices with a, then again those
+// that failed with a retryable error, up to config.Retries times.
+func (p *Processor) runWithRetries(ctx context.Context, urls []string, indices []int, a *analyzer.Analyzer, analyze analyzeFunc, deliver func(index int, result *BulkResult), progress *ui.UI) {
+	pending := p.runPass(ctx, urls, indices, 1, a, analyze, deliver)
+	
+	for attempt := 2; attempt <= p.config.Retries+1 && len(pending) > 0; attempt++ {
+		// Back off before each pass so rate limits and overloaded hosts recover
+		select {
+		case <-time.After(p.retryDelay << (attempt - 2)):
+		case <-ctx.Done():
+			return
+		}
+		if progress != nil {
+			progress.PrintInfo(fmt.Sprintf("Retrying %d URLs with transient failures (attempt %d of %d)...", len(pending), attempt, p.config.Retries+1))
+		}
+		pending = p.runPass(ctx, urls, pending, attempt, a, analyze, deliver)
+	}
+}
+
+// runPass analyzes the URLs at indices and returns the indices that failed
+// with a retryable error.
+func (p *Processor) runPass(ctx context.Context, urls []string, indices []int, attempt int, a *analyzer.Analyzer, analyze analyzeFunc, deliver func(index int, result *BulkResult)) []int {
+	// Create a semaphore to limit concurrent requests; acquiring it before
+	// starting a goroutine keeps large runs from parking one goroutine per URL
+	semaphore := make(chan struct{}, max(p.config.Concurrent, 1))
 	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var retry []int
 	
-	for i, url := range urls {
+	for _, i := range indices {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			deliver(i, &BulkResult{URL: urls[i], Error: analyzer.Classify(ctx.Err()), Attempts: attempt})
+			mu.Unlock()
+			continue
+		}
 		wg.Add(1)
 		go func(index int, u string) {
 			defer wg.Done()
-			
-			// Acquire semaphore
-			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 			
-			result := &BulkResult{URL: u}
-			
-			analysisResult, err := p.analyzer.AnalyzeURL(u)
-			if err != nil {
-				result.Error = err.Error()
+			result := &BulkResult{URL: u, Attempts: attempt}
+			if attempt > 1 {
+				analyze(llm.WithRetry(ctx), a, index, result)
 			} else {
-				result.Result = analysisResult
+				analyze(ctx, a, index, result)
 			}
 			
-			results[index] = result
-		}(i, url)
+			mu.Lock()
+			deliver(index, result)
+			if result.Error != nil && result.Error.Retryable && ctx.Err() == nil {
+				retry = append(retry, index)
+			}
+			mu.Unlock()
+		}(i, urls[i])
 	}
 	
 	wg.Wait()
-	
-	if showProgress {
-		progress.PrintSuccess(fmt.Sprintf("Completed analysis of %d URLs!", len(urls)))
-	}
-	
-	return results, nil
+	slices.Sort(retry)
+	return retry
 }
 
 func (p *Processor) readURLsFromFile(filename string) ([]string, error) {
+	return ReadURLFile(filename)
+}
+
+// ReadURLFile reads http(s) URLs from a file, one per line, skipping blank
+// lines and # comments.
+func ReadURLFile(filename string) ([]string, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
@@ -117,4 +271,22 @@ func (p *Processor) readURLsFromFile(filename string) ([]string, error) {
 	}
 	
 	return urls, nil
-}
\ No newline at end of file
+}
+
+// LoadReport reads bulk results previously written with --output json, or
+// an NDJSON spill file written with --spill.
+func LoadReport(filename string) ([]*BulkResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return readSpill(bytes.NewReader(data), false)
+	}
+
+	var results []*BulkResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", filename, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,79 @@
+package bulk
+
+import (
+	"context"
+	"geo-checker/pkg/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProcessURLsCancelled(t *testing.T) {
+	var hits atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := New(&config.Config{Mode: "local", OutputFormat: "json", Concurrent: 2, Timeout: 30})
+	urls := []string{ts.URL + "/a", ts.URL + "/b", ts.URL + "/c"}
+	results, err := p.ProcessURLs(ctx, urls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(urls) {
+		t.Fatalf("got %d results, want %d", len(results), len(urls))
+	}
+	for i, r := range results {
+		if r == nil || r.URL != urls[i] || r.Error == nil || !r.Error.Retryable || !strings.Contains(r.ErrorMessage(), context.Canceled.Error()) {
+			t.Errorf("result %d = %+v, want cancellation error", i, r)
+		}
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("server received %d requests after cancellation", n)
+	}
+}
+
+func TestProcessURLsRetriesTransientFailures(t *testing.T) {
+	var flakyHits atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky":
+			if flakyHits.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "/down":
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`<html><head><title>Page</title></head><body><main><p>Enough body text here for the local scorer to extract a paragraph.</p></main></body></html>`))
+	}))
+	defer ts.Close()
+
+	p := New(&config.Config{Mode: "local", OutputFormat: "json", Concurrent: 2, Retries: 2, Timeout: 30})
+	p.retryDelay = time.Millisecond
+	results, err := p.ProcessURLs(context.Background(), []string{ts.URL + "/flaky", ts.URL + "/gone", ts.URL + "/down"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r := results[0]; r.Error != nil || r.Attempts != 2 {
+		t.Errorf("flaky: attempts %d, error %v; want success on attempt 2", r.Attempts, r.Error)
+	}
+	if r := results[1]; r.Error == nil || r.Error.Retryable || r.Attempts != 1 {
+		t.Errorf("gone: attempts %d, error %+v; want one non-retryable failure", r.Attempts, r.Error)
+	}
+	if r := results[2]; r.Error == nil || r.Error.StatusCode != http.StatusBadGateway || r.Attempts != 3 {
+		t.Errorf("down: attempts %d, error %+v; want 502 after 3 attempts", r.Attempts, r.Error)
+	}
+}
@@ -0,0 +1,36 @@
+package bulk
+
+// FailedURLs returns the URLs of a previous report that failed, in report
+// order. Documents given as JSON are left out: their content is not in the
+// report, so they cannot be analyzed again from it.
+func FailedURLs(results []*BulkResult) []string {
+	var urls []string
+	for _, r := range results {
+		if r != nil && r.Error != nil && r.ID == "" && r.URL != "" {
+			urls = append(urls, r.URL)
+		}
+	}
+	return urls
+}
+
+// MergeRetried replaces the results of the previous report with those of
+// the URLs analyzed again, keeping the report's order. Attempts add up, so
+// the merged report counts every attempt made at a URL.
+func MergeRetried(previous, retried []*BulkResult) []*BulkResult {
+	byURL := make(map[string]*BulkResult, len(retried))
+	for _, r := range retried {
+		byURL[r.URL] = r
+	}
+	merged := make([]*BulkResult, 0, len(previous))
+	for _, r := range previous {
+		if r == nil {
+			continue
+		}
+		if again, ok := byURL[r.URL]; ok && r.ID == "" {
+			again.Attempts += r.Attempts
+			r = again
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,40 @@
+package bulk
+
+import (
+	"geo-checker/pkg/analyzer"
+	"reflect"
+	"testing"
+)
+
+func TestRetryFailed(t *testing.T) {
+	failed := &analyzer.Error{Category: analyzer.CategoryFetch, Message: "connection refused", Retryable: true}
+	previous := []*BulkResult{
+		{URL: "https://example.com/a", Result: &analyzer.Result{Score: 70}, Attempts: 1},
+		{URL: "https://example.com/b", Error: failed, Attempts: 3},
+		{ID: "doc-1", Error: failed, Attempts: 1},
+		{URL: "https://example.com/c", Error: failed, Attempts: 1},
+	}
+
+	urls := FailedURLs(previous)
+	if want := []string{"https://example.com/b", "https://example.com/c"}; !reflect.DeepEqual(urls, want) {
+		t.Fatalf("FailedURLs = %v, want %v", urls, want)
+	}
+
+	retried := []*BulkResult{
+		{URL: "https://example.com/c", Error: failed, Attempts: 2},
+		{URL: "https://example.com/b", Result: &analyzer.Result{Score: 55}, Attempts: 1},
+	}
+	merged := MergeRetried(previous, retried)
+	if len(merged) != len(previous) {
+		t.Fatalf("merged %d results, want %d", len(merged), len(previous))
+	}
+	if merged[0] != previous[0] || merged[2] != previous[2] {
+		t.Error("results that were not retried changed")
+	}
+	if b := merged[1]; b.Error != nil || b.Result.Score != 55 || b.Attempts != 4 {
+		t.Errorf("merged b = %+v, want the retried result after 4 attempts", b)
+	}
+	if c := merged[3]; c.Error == nil || c.Attempts != 3 {
+		t.Errorf("merged c = %+v, want still failing after 3 attempts", c)
+	}
+}
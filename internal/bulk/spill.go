@@ -0,0 +1,185 @@
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"geo-checker/pkg/scorer"
+	"io"
+	"os"
+)
+
+// Spill streams complete results to an NDJSON file as they finish, so a run
+// only has to keep compact summaries in memory. The file doubles as a
+// checkpoint: a resumed run skips every URL already recorded in it.
+type Spill struct {
+	file *os.File
+	enc  *json.Encoder
+}
+
+// OpenSpill creates the spill file at path. With resume set an existing
+// file is kept and its results are returned, compacted, so the run can skip
+// them.
+func OpenSpill(path string, resume bool) (*Spill, []*BulkResult, error) {
+	var done []*BulkResult
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		f, err := os.Open(path)
+		switch {
+		case err == nil:
+			done, err = readSpill(f, true)
+			f.Close()
+			if err != nil {
+				return nil, nil, err
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, nil, fmt.Errorf("failed to open spill file: %w", err)
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open spill file: %w", err)
+	}
+	if resume {
+		// Terminate a line cut short by a killed run so new results start
+		// on a line of their own
+		if info, err := f.Stat(); err == nil && info.Size() > 0 {
+			last := make([]byte, 1)
+			if r, err := os.Open(path); err == nil {
+				r.ReadAt(last, info.Size()-1)
+				r.Close()
+			}
+			if last[0] != '\n' {
+				f.Write([]byte{'\n'})
+			}
+		}
+	}
+	return &Spill{file: f, enc: json.NewEncoder(f)}, done, nil
+}
+
+// Write appends one result as a line of JSON.
+func (s *Spill) Write(result *BulkResult) error {
+	if err := s.enc.Encode(result); err != nil {
+		return fmt.Errorf("failed to write spill file: %w", err)
+	}
+	return nil
+}
+
+func (s *Spill) Close() error {
+	return s.file.Close()
+}
+
+// readSpill decodes NDJSON results. A truncated last line, left by a run
+// that was killed mid-write, is ignored, and a URL retried by a resumed run
+// keeps only its latest result.
+func readSpill(r io.Reader, compact bool) ([]*BulkResult, error) {
+	var results []*BulkResult
+	index := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 64<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var result BulkResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			continue
+		}
+		if compact {
+			result.Compact()
+		}
+		if i, ok := index[result.URL]; ok {
+			results[i] = &result
+			continue
+		}
+		index[result.URL] = len(results)
+		results = append(results, &result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read spill file: %w", err)
+	}
+	return results, nil
+}
+
+// Compact drops the bulky parts of a result (LLM analysis text, metadata,
+// evidence snippets and per-pillar detail) and keeps what reports, digests
+// and tickets use.
+func (r *BulkResult) Compact() {
+	if r.Result == nil {
+		return
+	}
+	res := *r.Result
+	res.Analysis = ""
+	res.Metadata = nil
+	if res.LocalScore != nil {
+		score := *res.LocalScore
+		score.Metadata = nil
+		b := &score.Breakdown
+		b.Custom = append([]scorer.CustomPillar(nil), b.Custom...)
+		details := []*scorer.ScoreDetail{&b.ContentStructure, &b.SemanticClarity, &b.ContextRichness, &b.AuthoritySignals, &b.Accessibility, &b.StructuredData}
+		for i := range b.Custom {
+			details = append(details, &b.Custom[i].ScoreDetail)
+		}
+		for _, detail := range details {
+			detail.Issues, detail.Positives, detail.Findings = nil, nil, nil
+		}
+		findings := make([]scorer.Finding, len(score.Findings))
+		for i, f := range score.Findings {
+			f.Evidence = nil
+			findings[i] = f
+		}
+		score.Findings = findings
+		res.LocalScore = &score
+	}
+	r.Result = &res
+}
+
+// ProcessURLsSpill analyzes urls like ProcessURLs but writes every result to
+// spill as it completes and returns compacted results. URLs in done (from a
+// resumed spill file) are not analyzed again if they succeeded or failed
+// permanently; retryable failures are retried. A failure to write the spill
+// file stops the run: the results recorded until then are returned with the
+// error.
+func (p *Processor) ProcessURLsSpill(ctx context.Context, urls []string, spill *Spill, done []*BulkResult) ([]*BulkResult, error) {
+	byURL := make(map[string]*BulkResult, len(done))
+	for _, r := range done {
+		if r.Error == nil || !r.Error.Retryable {
+			byURL[r.URL] = r
+		}
+	}
+
+	var pending []string
+	for _, u := range urls {
+		if _, ok := byURL[u]; !ok {
+			pending = append(pending, u)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var writeErr error
+	p.process(ctx, pending, p.analyzeURL(pending), func(_ int, result *BulkResult) {
+		if writeErr != nil {
+			return
+		}
+		if writeErr = spill.Write(result); writeErr != nil {
+			cancel()
+			return
+		}
+		result.Compact()
+		byURL[result.URL] = result
+	})
+
+	results := make([]*BulkResult, 0, len(urls))
+	for _, u := range urls {
+		if r, ok := byURL[u]; ok {
+			results = append(results, r)
+		}
+	}
+	return results, writeErr
+}
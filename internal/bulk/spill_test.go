@@ -0,0 +1,105 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scorer"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSpillResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.ndjson")
+
+	spill, done, err := OpenSpill(path, true)
+	if err != nil || len(done) != 0 {
+		t.Fatalf("fresh spill: %v, %d done", err, len(done))
+	}
+	spill.Write(&BulkResult{URL: "https://a.test", Error: &analyzer.Error{Category: analyzer.CategoryTimeout, Message: "timeout", Retryable: true}})
+	spill.Write(&BulkResult{URL: "https://b.test", Result: &analyzer.Result{
+		Analysis:   "long LLM text",
+		Score:      70,
+		LocalScore: &scorer.GEOScore{Findings: []scorer.Finding{{ID: "x", Evidence: []scorer.Evidence{{Snippet: "s"}}}}},
+	}})
+	spill.Write(&BulkResult{URL: "https://a.test", Result: &analyzer.Result{Score: 50}})
+	spill.Close()
+
+	// A run killed mid-write leaves a partial line behind
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"url":"https://c.te`)
+	f.Close()
+
+	spill, done, err = OpenSpill(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer spill.Close()
+
+	if len(done) != 2 || done[0].URL != "https://a.test" || done[0].Error != nil || done[0].Result.Score != 50 {
+		t.Fatalf("resumed results = %+v", done)
+	}
+	b := done[1].Result
+	if b.Analysis != "" || len(b.LocalScore.Findings) != 1 || b.LocalScore.Findings[0].Evidence != nil {
+		t.Errorf("result not compacted: %+v", b)
+	}
+
+	spill.Write(&BulkResult{URL: "https://c.test", Error: &analyzer.Error{Category: analyzer.CategoryFetch, Message: "HTTP error: 404", StatusCode: 404}})
+	results, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[2].URL != "https://c.test" || results[2].Error.StatusCode != 404 {
+		t.Errorf("report after resume = %+v", results)
+	}
+}
+
+func TestLoadReportLegacyErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	os.WriteFile(path, []byte(`[{"url":"https://a.test","error":"failed to scrape URL: HTTP error: 500"}]`), 0o644)
+
+	results, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := results[0].Error; e == nil || e.Message != "failed to scrape URL: HTTP error: 500" || !e.Retryable {
+		t.Errorf("legacy error = %+v", e)
+	}
+}
+
+func TestSpillWriteErrorStopsRun(t *testing.T) {
+	var hits atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("<html><body><main><p>A page with enough words to be extracted as content.</p></main></body></html>"))
+	}))
+	defer ts.Close()
+
+	spill, _, err := OpenSpill(filepath.Join(t.TempDir(), "run.ndjson"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spill.Close() // every write fails
+
+	var urls []string
+	for i := 0; i < 20; i++ {
+		urls = append(urls, fmt.Sprintf("%s/%d", ts.URL, i))
+	}
+	done := []*BulkResult{{URL: urls[0], Result: &analyzer.Result{Score: 50}}}
+	p := New(&config.Config{Mode: "local", OutputFormat: "json", Concurrent: 1, Timeout: 30})
+	results, err := p.ProcessURLsSpill(context.Background(), urls, spill, done)
+	if err == nil {
+		t.Fatal("spill write error not returned")
+	}
+	if len(results) != 1 || results[0] != done[0] {
+		t.Errorf("results = %+v, want the one recorded before the error", results)
+	}
+	if n := hits.Load(); n > 3 {
+		t.Errorf("server received %d requests after the spill file failed", n)
+	}
+}
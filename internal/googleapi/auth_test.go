@@ -0,0 +1,138 @@
+package googleapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeCredentials writes a service-account key file with a fresh key and
+// returns its path.
+func writeCredentials(t *testing.T, fields map[string]string) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := map[string]string{
+		"client_email": "geo@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+	for k, v := range fields {
+		creds[k] = v
+	}
+	data, _ := json.Marshal(creds)
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadServiceAccount(t *testing.T) {
+	sa, err := LoadServiceAccount(writeCredentials(t, nil), "scope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sa.TokenURI != defaultTokenURI || sa.key == nil {
+		t.Errorf("service account = %+v, want the default token URI and a parsed key", sa)
+	}
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	os.WriteFile(invalid, []byte("{"), 0o600)
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"no path", "", "no service-account credentials"},
+		{"missing file", filepath.Join(t.TempDir(), "missing.json"), "failed to read"},
+		{"not JSON", invalid, "failed to parse"},
+		{"no email", writeCredentials(t, map[string]string{"client_email": ""}), "missing client_email"},
+		{"not PEM", writeCredentials(t, map[string]string{"private_key": "secret"}), "not PEM encoded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadServiceAccount(tt.path); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadServiceAccount() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenRefresh(t *testing.T) {
+	var requests atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || strings.Count(r.Form.Get("assertion"), ".") != 2 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":3600}`, n)
+	}))
+	defer ts.Close()
+
+	sa, err := LoadServiceAccount(writeCredentials(t, map[string]string{"token_uri": ts.URL}), "scope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if token, err := sa.Token(ctx); err != nil || token != "token-1" {
+			t.Fatalf("Token() = %q, %v, want the cached token-1", token, err)
+		}
+	}
+
+	// Within a minute of expiry the token is refreshed
+	sa.expiry = time.Now().Add(30 * time.Second)
+	if token, err := sa.Token(ctx); err != nil || token != "token-2" {
+		t.Errorf("Token() near expiry = %q, %v, want token-2", token, err)
+	}
+}
+
+func TestTokenErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"rejected", http.StatusUnauthorized, `{"error":"invalid_grant"}`, "status 401"},
+		{"malformed", http.StatusOK, `not json`, "failed to parse token response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			sa, err := LoadServiceAccount(writeCredentials(t, map[string]string{"token_uri": ts.URL}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := sa.Token(context.Background()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Token() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package netguard enforces offline mode: once enabled, every connection
+// the process dials through a guarded transport must go to the loopback
+// interface, so nothing leaves the machine.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrOffline is wrapped by the error of a connection refused in offline
+// mode.
+var ErrOffline = errors.New("offline mode")
+
+// DialFunc is the signature of http.Transport.DialContext.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+var (
+	enabled    atomic.Bool
+	enableOnce sync.Once
+)
+
+// Enable turns offline mode on for the rest of the process. Transports
+// cloned before it is called must have been guarded with Guard; the
+// default transport, which plain http.Client values use, is guarded here.
+func Enable() {
+	enabled.Store(true)
+	enableOnce.Do(func() {
+		if t, ok := http.DefaultTransport.(*http.Transport); ok {
+			t.DialContext = Guard(t.DialContext)
+		}
+	})
+}
+
+// Enabled reports whether offline mode is on.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Guard wraps dial so that, in offline mode, it refuses any address that
+// is not on the loopback interface before resolving or dialing it. A nil
+// dial stands for a zero net.Dialer.
+func Guard(dial DialFunc) DialFunc {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if err := Check(addr); err != nil {
+			return nil, err
+		}
+		return dial(ctx, network, addr)
+	}
+}
+
+// Check returns an error wrapping ErrOffline when offline mode is on and
+// addr, a host or host:port, is not a loopback address.
+func Check(addr string) error {
+	if !Enabled() {
+		return nil
+	}
+	if IsLoopback(addr) {
+		return nil
+	}
+	return fmt.Errorf("%w: refusing to connect to %s (only localhost is allowed)", ErrOffline, addr)
+}
+
+// IsLoopback reports whether addr, a host or host:port, names the loopback
+// interface: localhost, a name under .localhost, 127.0.0.0/8 or ::1.
+func IsLoopback(addr string) bool {
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(strings.Trim(host, "[]"), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,110 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsLoopback(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"localhost:11434":   true,
+		"LOCALHOST":         true,
+		"app.localhost:80":  true,
+		"127.0.0.1:8080":    true,
+		"127.8.0.1":         true,
+		"[::1]:443":         true,
+		"::1":               true,
+		"example.com:443":   false,
+		"10.0.0.5:80":       false,
+		"localhost.com:443": false,
+		"[2001:db8::1]:443": false,
+	} {
+		if got := IsLoopback(addr); got != want {
+			t.Errorf("IsLoopback(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dialed := 0
+	dial := Guard(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed++
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	})
+	if err := Check("example.com:443"); err != nil {
+		t.Fatalf("Check before Enable = %v", err)
+	}
+
+	Enable()
+	if _, err := dial(context.Background(), "tcp", "example.com:443"); !errors.Is(err, ErrOffline) {
+		t.Errorf("dial example.com = %v, want ErrOffline", err)
+	}
+	if dialed != 0 {
+		t.Errorf("refused address was dialed")
+	}
+
+	// Plain clients use the default transport, guarded by Enable
+	client := &http.Client{}
+	if _, err := client.Get("http://example.com/"); !errors.Is(err, ErrOffline) {
+		t.Errorf("GET example.com = %v, want ErrOffline", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET %s = %v, want loopback allowed", server.URL, err)
+	}
+	resp.Body.Close()
+}
+
+func TestIsPublic(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00:ec2::254":   false,
+		"fe80::1":         false,
+		"::ffff:10.0.0.1": false,
+	} {
+		if got := IsPublic(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("IsPublic(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	addr := server.Listener.Addr().String()
+
+	// localhost resolves to a loopback address when dialed
+	_, port, _ := net.SplitHostPort(addr)
+	for _, target := range []string{addr, "localhost:" + port} {
+		if _, err := PublicOnly(time.Second, nil)(context.Background(), "tcp", target); !errors.Is(err, ErrNotPublic) {
+			t.Errorf("dialing %s: %v", target, err)
+		}
+	}
+	conn, err := PublicOnly(time.Second, []string{"127.0.0.1"})(context.Background(), "tcp", addr)
+	if err != nil {
+		t.Fatalf("allowed host refused: %v", err)
+	}
+	conn.Close()
+
+	if err := CheckPublic(context.Background(), "169.254.169.254"); !errors.Is(err, ErrNotPublic) {
+		t.Errorf("metadata address accepted: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrNotPublic is wrapped by the error of a connection refused because the
+// address is not on the public internet.
+var ErrNotPublic = errors.New("address is not public")
+
+// notPublic are the ranges besides loopback, private, link-local,
+// multicast and unspecified addresses that a service must not be made to
+// connect to on behalf of its clients.
+var notPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64 of any IPv4 address
+}
+
+// IsPublic reports whether ip is on the public internet: not loopback,
+// private, link-local (which holds cloud metadata services such as
+// 169.254.169.254), multicast, unspecified or otherwise reserved.
+func IsPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range notPublic {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicOnly returns a dial function that refuses connections to addresses
+// that are not public, except to the hosts in allow. The address is checked
+// once resolved, as it is dialed, so that a name resolving to a public
+// address when checked and to an internal one when dialed (DNS rebinding)
+// cannot get past it.
+func PublicOnly(timeout time.Duration, allow []string) DialFunc {
+	public := &net.Dialer{Timeout: timeout, Control: func(network, address string, _ syscall.RawConn) error {
+		ap, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrNotPublic, address)
+		}
+		if !IsPublic(ap.Addr()) {
+			return fmt.Errorf("%w: refusing to connect to %s", ErrNotPublic, ap.Addr())
+		}
+		return nil
+	}}
+	direct := &net.Dialer{Timeout: timeout}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if Allowed(addr, allow) {
+			return direct.DialContext(ctx, network, addr)
+		}
+		return public.DialContext(ctx, network, addr)
+	}
+}
+
+// Allowed reports whether addr, a host or host:port, is one of the hosts
+// in allow (compared without case).
+func Allowed(addr string, allow []string) bool {
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	for _, a := range allow {
+		if a = strings.TrimSpace(a); a != "" && strings.EqualFold(a, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPublic resolves host and returns an error wrapping ErrNotPublic when
+// any of its addresses is not public, for URLs to be refused when they are
+// given rather than when they are used. Dialing through PublicOnly is still
+// needed: the name may resolve elsewhere later.
+func CheckPublic(ctx context.Context, host string) error {
+	host = strings.Trim(host, "[]")
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !IsPublic(ip) {
+			return fmt.Errorf("%w: %s", ErrNotPublic, host)
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, ip := range addrs {
+		if !IsPublic(ip) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrNotPublic, host, ip)
+		}
+	}
+	return nil
+}
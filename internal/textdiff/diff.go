@@ -0,0 +1,126 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of a diff line.
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is one line of a diff.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// maxCells bounds the LCS table; beyond it the inputs are compared as one
+// deleted and one inserted block.
+const maxCells = 4_000_000
+
+// Lines computes a line diff of a and b using a longest-common-subsequence
+// table, which is plenty for extracted page text.
+func Lines(a, b []string) []Line {
+	if len(a)*len(b) > maxCells {
+		var out []Line
+		for _, t := range a {
+			out = append(out, Line{Delete, t})
+		}
+		for _, t := range b {
+			out = append(out, Line{Insert, t})
+		}
+		return out
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []Line
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, Line{Delete, a[i]})
+			i++
+		default:
+			out = append(out, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, Line{Insert, b[j]})
+	}
+	return out
+}
+
+// Unified renders a diff with "-"/"+" prefixes, keeping context lines of
+// unchanged text around each change and eliding the rest.
+func Unified(lines []Line, context int) string {
+	var sb strings.Builder
+	lastPrinted := -1
+	for i, l := range lines {
+		if l.Op == Equal && !nearChange(lines, i, context) {
+			continue
+		}
+		if lastPrinted >= 0 && i > lastPrinted+1 {
+			fmt.Fprintf(&sb, "@@ %d unchanged lines @@\n", i-lastPrinted-1)
+		}
+		switch l.Op {
+		case Equal:
+			sb.WriteString("  " + l.Text + "\n")
+		case Delete:
+			sb.WriteString("- " + l.Text + "\n")
+		case Insert:
+			sb.WriteString("+ " + l.Text + "\n")
+		}
+		lastPrinted = i
+	}
+	return sb.String()
+}
+
+func nearChange(lines []Line, i, context int) bool {
+	for j := max(0, i-context); j <= min(len(lines)-1, i+context); j++ {
+		if lines[j].Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Stats counts deleted and inserted lines.
+func Stats(lines []Line) (deleted, inserted int) {
+	for _, l := range lines {
+		switch l.Op {
+		case Delete:
+			deleted++
+		case Insert:
+			inserted++
+		}
+	}
+	return deleted, inserted
+}
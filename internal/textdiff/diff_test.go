@@ -0,0 +1,46 @@
+package textdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []Line
+	}{
+		{"unchanged", "one\ntwo", "one\ntwo", []Line{{Equal, "one"}, {Equal, "two"}}},
+		{"insertion", "one\nthree", "one\ntwo\nthree", []Line{{Equal, "one"}, {Insert, "two"}, {Equal, "three"}}},
+		{"deletion", "one\ntwo\nthree", "one\nthree", []Line{{Equal, "one"}, {Delete, "two"}, {Equal, "three"}}},
+		{"replacement", "one\ntwo", "one\n2", []Line{{Equal, "one"}, {Delete, "two"}, {Insert, "2"}}},
+		{"from nothing", "", "one", []Line{{Delete, ""}, {Insert, "one"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Lines(strings.Split(tt.a, "\n"), strings.Split(tt.b, "\n"))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+	b := []string{"one", "2", "3", "4", "5", "6", "7", "eight"}
+	lines := Lines(a, b)
+
+	want := "- 1\n+ one\n  2\n@@ 4 unchanged lines @@\n  7\n- 8\n+ eight\n"
+	if got := Unified(lines, 1); got != want {
+		t.Errorf("Unified() = %q, want %q", got, want)
+	}
+	if deleted, inserted := Stats(lines); deleted != 2 || inserted != 2 {
+		t.Errorf("Stats() = %d, %d, want 2, 2", deleted, inserted)
+	}
+	if got := Unified(Lines(a, a), 1); got != "" {
+		t.Errorf("Unified() of unchanged input = %q, want nothing", got)
+	}
+}
@@ -0,0 +1,41 @@
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordOverlap compares two texts as bags of lowercase words. Similarity is
+// the Dice coefficient of the bags; coverage is the share of a's words that
+// also occur in b, which is what matters when b may be a stripped-down copy.
+func WordOverlap(a, b string) (similarity, coverage float64) {
+	countsA, totalA := wordCounts(a)
+	countsB, totalB := wordCounts(b)
+	if totalA == 0 && totalB == 0 {
+		return 1, 1
+	}
+
+	common := 0
+	for w, n := range countsA {
+		common += min(n, countsB[w])
+	}
+
+	similarity = 2 * float64(common) / float64(totalA+totalB)
+	coverage = 1
+	if totalA > 0 {
+		coverage = float64(common) / float64(totalA)
+	}
+	return similarity, coverage
+}
+
+func wordCounts(text string) (map[string]int, int) {
+	counts := make(map[string]int)
+	total := 0
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		counts[w]++
+		total++
+	}
+	return counts, total
+}
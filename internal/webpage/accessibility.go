@@ -0,0 +1,74 @@
+package webpage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Accessibility records the markup assistive technologies rely on to
+// present a page.
+type Accessibility struct {
+	// Lang is the language declared on <html>, by lang or xml:lang
+	Lang string `json:"lang,omitempty"`
+
+	// UnlabeledControls are the links and buttons anywhere on the page that
+	// have no accessible name: no text, image alt text, aria-label,
+	// aria-labelledby or title. Each is described by its tag and the
+	// attribute that identifies it best.
+	UnlabeledControls []string `json:"unlabeled_controls,omitempty"`
+}
+
+// extractAccessibility reads the declared language and the unlabeled
+// controls of the whole document, navigation and footers included.
+func extractAccessibility(doc *goquery.Document) *Accessibility {
+	html := doc.Find("html").First()
+	a := &Accessibility{Lang: strings.TrimSpace(html.AttrOr("lang", html.AttrOr("xml:lang", "")))}
+
+	doc.Find(`a[href], button, input[type="button"], input[type="submit"], input[type="reset"], [role="button"], [role="link"]`).Each(func(i int, s *goquery.Selection) {
+		if isHidden(s) || s.AttrOr("aria-hidden", "") == "true" || accessibleName(s) {
+			return
+		}
+		a.UnlabeledControls = append(a.UnlabeledControls, describeControl(s))
+	})
+	return a
+}
+
+// accessibleName reports whether a control has a name a screen reader can
+// announce.
+func accessibleName(s *goquery.Selection) bool {
+	for _, attr := range []string{"aria-label", "aria-labelledby", "title"} {
+		if strings.TrimSpace(s.AttrOr(attr, "")) != "" {
+			return true
+		}
+	}
+	if goquery.NodeName(s) == "input" {
+		// Submit and reset buttons have a default label
+		return strings.TrimSpace(s.AttrOr("value", "")) != "" || s.AttrOr("type", "") != "button"
+	}
+	if strings.TrimSpace(s.Text()) != "" {
+		return true
+	}
+	named := false
+	s.Find("img[alt], svg title, [aria-label]").EachWithBreak(func(i int, child *goquery.Selection) bool {
+		named = strings.TrimSpace(child.AttrOr("alt", child.AttrOr("aria-label", child.Text()))) != ""
+		return !named
+	})
+	return named
+}
+
+// describeControl identifies a control for a report, e.g.
+// `<a href="/cart">` or `<button class="menu-toggle">`.
+func describeControl(s *goquery.Selection) string {
+	tag := goquery.NodeName(s)
+	for _, attr := range []string{"href", "id", "class", "name", "type"} {
+		if v := strings.TrimSpace(s.AttrOr(attr, "")); v != "" {
+			if len(v) > 60 {
+				v = v[:60] + "…"
+			}
+			return fmt.Sprintf("<%s %s=%q>", tag, attr, v)
+		}
+	}
+	return "<" + tag + ">"
+}
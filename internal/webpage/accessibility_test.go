@@ -0,0 +1,36 @@
+package webpage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAccessibilityExtraction(t *testing.T) {
+	html := `<html lang="en-GB"><body>
+<nav><a href="/"><img src="logo.svg" alt=""></a><a href="/search" aria-label="Search"></a><button class="menu-toggle"></button></nav>
+<main><h1>Shoes</h1>
+<p>Pick <a href="/trail">trail shoes</a> for rough ground.</p>
+<a href="/cart"><img src="cart.png" alt="Cart"></a>
+<a href="/share" title="Share"><svg></svg></a>
+<input type="submit"><input type="button" id="more">
+<div hidden><a href="/old"></a></div>
+</main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/shoes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := page.Accessibility
+	if a == nil || a.Lang != "en-GB" {
+		t.Fatalf("accessibility = %+v, want lang en-GB", a)
+	}
+	want := `[<a href="/"> <button class="menu-toggle"> <input id="more">]`
+	if got := fmt.Sprint(a.UnlabeledControls); got != want {
+		t.Errorf("unlabeled controls = %s, want %s", got, want)
+	}
+
+	page, _ = New().ParseHTML(`<html><body><p>No language</p></body></html>`, "")
+	if page.Accessibility.Lang != "" || page.Accessibility.UnlabeledControls != nil {
+		t.Errorf("accessibility = %+v, want none", page.Accessibility)
+	}
+}
@@ -0,0 +1,22 @@
+package webpage
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// headingAnchor returns the id a link can jump to the heading with: its
+// own, that of an anchor inside it, or that of the section it opens.
+func headingAnchor(s *goquery.Selection) string {
+	if id := strings.TrimSpace(s.AttrOr("id", "")); id != "" {
+		return id
+	}
+	if a := s.Find("[id], a[name]").First(); a.Length() > 0 {
+		return strings.TrimSpace(a.AttrOr("id", a.AttrOr("name", "")))
+	}
+	if parent := s.Parent(); parent.Is("section") && parent.Children().First().IsSelection(s) {
+		return strings.TrimSpace(parent.AttrOr("id", ""))
+	}
+	return ""
+}
@@ -0,0 +1,28 @@
+package webpage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHeadingAnchors(t *testing.T) {
+	html := `<html><body><main>
+<h1>Guide</h1>
+<h2 id="install">Install</h2>
+<h2><a name="configure"></a>Configure</h2>
+<section id="deploy"><h2>Deploy</h2><p>Ship it.</p></section>
+<h2>Troubleshooting</h2>
+</main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/guide")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, h := range page.Headings {
+		ids = append(ids, h.ID)
+	}
+	if got, want := fmt.Sprintf("%q", ids), `["" "install" "configure" "deploy" ""]`; got != want {
+		t.Errorf("heading ids = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package webpage
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// CodeBlock is a <pre> block in the content. Language is the one declared
+// on the block (e.g. class="language-go"), empty when none is.
+type CodeBlock struct {
+	Language string `json:"language,omitempty"`
+	Code     string `json:"code"`
+}
+
+// Table is a table in the content: its header row, if it has one, and
+// the number of body rows and of cells in the first of them.
+type Table struct {
+	Headers []string `json:"headers,omitempty"`
+	Rows    int      `json:"rows"`
+	Columns int      `json:"columns"`
+}
+
+// extractCodeBlocks returns the <pre> blocks in sel with the text they
+// contribute to the extracted content.
+func extractCodeBlocks(sel *goquery.Selection) []CodeBlock {
+	var blocks []CodeBlock
+	sel.Find("pre").Each(func(i int, pre *goquery.Selection) {
+		code := strings.TrimSpace(pre.Text())
+		if code == "" {
+			return
+		}
+		language := codeLanguage(pre)
+		if language == "" {
+			language = codeLanguage(pre.Find("code").First())
+		}
+		blocks = append(blocks, CodeBlock{Language: language, Code: code})
+	})
+	return blocks
+}
+
+// codeLanguage reads the language highlighters declare on a code element:
+// data-lang, data-language or a language-*, lang-* or highlight-* class.
+func codeLanguage(sel *goquery.Selection) string {
+	if sel.Length() == 0 {
+		return ""
+	}
+	for _, attr := range []string{"data-lang", "data-language"} {
+		if lang := strings.TrimSpace(sel.AttrOr(attr, "")); lang != "" {
+			return strings.ToLower(lang)
+		}
+	}
+	for _, class := range strings.Fields(sel.AttrOr("class", "")) {
+		for _, prefix := range []string{"language-", "lang-", "highlight-"} {
+			if lang := strings.TrimPrefix(class, prefix); lang != class && lang != "" {
+				return strings.ToLower(lang)
+			}
+		}
+	}
+	return ""
+}
+
+// extractTables returns the tables in sel. The header row is the first
+// row of <thead> or else a first row made only of <th> cells.
+func extractTables(sel *goquery.Selection) []Table {
+	var tables []Table
+	sel.Find("table").Each(func(i int, table *goquery.Selection) {
+		rows := table.Find("tr")
+		header := table.Find("thead tr").First()
+		if header.Length() == 0 {
+			first := rows.First().Children()
+			if first.Length() > 0 && first.Length() == first.Filter("th").Length() {
+				header = rows.First()
+			}
+		}
+
+		var t Table
+		header.Children().Each(func(j int, cell *goquery.Selection) {
+			t.Headers = append(t.Headers, strings.Join(strings.Fields(cell.Text()), " "))
+		})
+		body := rows.Not("thead tr")
+		if header.Length() > 0 {
+			body = body.NotSelection(header)
+		}
+		t.Rows = body.Length()
+		if t.Rows > 0 {
+			t.Columns = body.First().Children().Length()
+		}
+		tables = append(tables, t)
+	})
+	return tables
+}
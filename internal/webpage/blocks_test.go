@@ -0,0 +1,30 @@
+package webpage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCodeBlockAndTableExtraction(t *testing.T) {
+	html := `<html><body><main><p>Install the client.</p>
+<pre><code class="hljs language-Go">go get example.com/client</code></pre>
+<pre data-lang="bash">$ client --help</pre>
+<pre>client.Run()</pre>
+<table><thead><tr><th>Parameter</th><th>Type</th></tr></thead>
+<tbody><tr><td>timeout</td><td>int</td></tr><tr><td>retries</td><td>int</td></tr></tbody></table>
+<table><tr><th>Weight</th><td>2 kg</td></tr><tr><th>Color</th><td>Red</td></tr></table>
+</main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCode := "[{go go get example.com/client} {bash $ client --help} { client.Run()}]"
+	if got := fmt.Sprint(page.CodeBlocks); got != wantCode {
+		t.Errorf("code blocks = %s, want %s", got, wantCode)
+	}
+	wantTables := "[{[Parameter Type] 2 2} {[] 2 2}]"
+	if got := fmt.Sprint(page.Tables); got != wantTables {
+		t.Errorf("tables = %s, want %s", got, wantTables)
+	}
+}
@@ -0,0 +1,182 @@
+package webpage
+
+import (
+	"encoding/json"
+	neturl "net/url"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Breadcrumb is one step of a breadcrumb trail.
+type Breadcrumb struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// extractJSONLD decodes every JSON-LD block in the page into its top-level
+// objects, with @graph members flattened. Invalid blocks are skipped.
+func extractJSONLD(doc *goquery.Document) []map[string]any {
+	var objects []map[string]any
+	doc.Find(`script[type="application/ld+json"]`).Each(func(i int, s *goquery.Selection) {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return
+		}
+		objects = append(objects, jsonLDObjects(data)...)
+	})
+	return objects
+}
+
+func jsonLDObjects(data any) []map[string]any {
+	switch v := data.(type) {
+	case []any:
+		var objects []map[string]any
+		for _, item := range v {
+			objects = append(objects, jsonLDObjects(item)...)
+		}
+		return objects
+	case map[string]any:
+		if graph, ok := v["@graph"]; ok {
+			return jsonLDObjects(graph)
+		}
+		return []map[string]any{v}
+	}
+	return nil
+}
+
+// hasJSONLDType reports whether a JSON-LD object's @type (a string or a list)
+// includes typ.
+func hasJSONLDType(obj map[string]any, typ string) bool {
+	switch t := obj["@type"].(type) {
+	case string:
+		return t == typ
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s == typ {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SchemaObjects returns the page's top-level schema.org objects of type typ,
+// from JSON-LD, microdata or RDFa.
+func (p *PageData) SchemaObjects(typ string) []map[string]any {
+	var objects []map[string]any
+	for _, obj := range p.StructuredData {
+		if hasJSONLDType(obj, typ) {
+			objects = append(objects, obj)
+		}
+	}
+	return objects
+}
+
+// schemaBreadcrumbs returns the trail declared as a schema.org
+// BreadcrumbList, in JSON-LD or microdata.
+func schemaBreadcrumbs(doc *goquery.Document, structured []map[string]any, base *neturl.URL) []Breadcrumb {
+	for _, obj := range structured {
+		if !hasJSONLDType(obj, "BreadcrumbList") {
+			continue
+		}
+		if trail := jsonLDBreadcrumbs(obj, base); len(trail) > 0 {
+			return trail
+		}
+	}
+
+	var trail []Breadcrumb
+	list := doc.Find(`[itemtype$="schema.org/BreadcrumbList"]`).First()
+	list.Find(`[itemprop="itemListElement"]`).Each(func(i int, s *goquery.Selection) {
+		nameSel := s.Find(`[itemprop="name"]`).First()
+		name := nameSel.AttrOr("content", nameSel.Text())
+		itemSel := s.Find(`[itemprop="item"]`).First()
+		href := itemSel.AttrOr("href", itemSel.AttrOr("itemid", ""))
+		if crumb := newBreadcrumb(name, href, base); crumb.Name != "" {
+			trail = append(trail, crumb)
+		}
+	})
+	return trail
+}
+
+func jsonLDBreadcrumbs(list map[string]any, base *neturl.URL) []Breadcrumb {
+	elements, _ := list["itemListElement"].([]any)
+	type positioned struct {
+		position float64
+		crumb    Breadcrumb
+	}
+	var items []positioned
+	for i, el := range elements {
+		item, ok := el.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := item["name"].(string)
+		var href string
+		switch target := item["item"].(type) {
+		case string:
+			href = target
+		case map[string]any:
+			if name == "" {
+				name, _ = target["name"].(string)
+			}
+			href, _ = target["@id"].(string)
+			if href == "" {
+				href, _ = target["url"].(string)
+			}
+		}
+		position, ok := item["position"].(float64)
+		if !ok {
+			position = float64(i + 1)
+		}
+		if crumb := newBreadcrumb(name, href, base); crumb.Name != "" {
+			items = append(items, positioned{position, crumb})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].position < items[j].position })
+
+	trail := make([]Breadcrumb, len(items))
+	for i, item := range items {
+		trail[i] = item.crumb
+	}
+	return trail
+}
+
+// visibleBreadcrumbs returns the breadcrumb navigation shown on the page:
+// the first element labelled or classed as breadcrumbs.
+func visibleBreadcrumbs(doc *goquery.Document, base *neturl.URL) []Breadcrumb {
+	container := doc.Find(`nav[aria-label*="readcrumb"], [class*="breadcrumb"], [id*="breadcrumb"]`).First()
+	if container.Length() == 0 {
+		return nil
+	}
+
+	var trail []Breadcrumb
+	items := container.Find("li")
+	if items.Length() == 0 {
+		// Flat trails: links separated by text such as "›"
+		items = container.Find("a")
+	}
+	items.Each(func(i int, s *goquery.Selection) {
+		href := s.AttrOr("href", s.Find("a").First().AttrOr("href", ""))
+		if crumb := newBreadcrumb(s.Text(), href, base); crumb.Name != "" {
+			trail = append(trail, crumb)
+		}
+	})
+	return trail
+}
+
+func newBreadcrumb(name, href string, base *neturl.URL) Breadcrumb {
+	crumb := Breadcrumb{Name: strings.Join(strings.Fields(name), " ")}
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return crumb
+	}
+	crumb.URL = href
+	if base != nil {
+		if ref, err := neturl.Parse(href); err == nil {
+			crumb.URL = base.ResolveReference(ref).String()
+		}
+	}
+	return crumb
+}
@@ -0,0 +1,52 @@
+package webpage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBreadcrumbExtraction(t *testing.T) {
+	html := `<html><head>
+<script type="application/ld+json">{"@context":"https://schema.org","@graph":[
+  {"@type":"WebPage","name":"Widgets"},
+  {"@type":"BreadcrumbList","itemListElement":[
+    {"@type":"ListItem","position":2,"item":{"@id":"/shop/widgets","name":"Widgets"}},
+    {"@type":"ListItem","position":1,"name":"Shop","item":"https://example.com/shop"}]}]}</script>
+<script type="application/ld+json">{not json</script>
+</head><body>
+<nav aria-label="breadcrumb"><ol><li><a href="/">Home</a></li><li><a href="../shop">Shop</a></li><li>Widgets</li></ol></nav>
+<main><p>Widget catalogue.</p></main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/shop/widgets")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page.StructuredData) != 2 {
+		t.Errorf("structured data = %v, want the two @graph objects", page.StructuredData)
+	}
+	wantSchema := "[{Shop https://example.com/shop} {Widgets https://example.com/shop/widgets}]"
+	if got := fmt.Sprint(page.SchemaBreadcrumbs); got != wantSchema {
+		t.Errorf("schema breadcrumbs = %s, want %s", got, wantSchema)
+	}
+	wantVisible := "[{Home https://example.com/} {Shop https://example.com/shop} {Widgets }]"
+	if got := fmt.Sprint(page.Breadcrumbs); got != wantVisible {
+		t.Errorf("visible breadcrumbs = %s, want %s", got, wantVisible)
+	}
+}
+
+func TestMicrodataBreadcrumbs(t *testing.T) {
+	html := `<html><body><ol itemscope itemtype="https://schema.org/BreadcrumbList">
+<li itemprop="itemListElement" itemscope itemtype="https://schema.org/ListItem"><a itemprop="item" href="/docs"><span itemprop="name">Docs</span></a></li>
+<li itemprop="itemListElement" itemscope itemtype="https://schema.org/ListItem"><span itemprop="name">Install</span></li>
+</ol><main><p>Install steps.</p></main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/docs/install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[{Docs https://example.com/docs} {Install }]"
+	if got := fmt.Sprint(page.SchemaBreadcrumbs); got != want {
+		t.Errorf("microdata breadcrumbs = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package webpage
+
+import "github.com/PuerkitoBio/goquery"
+
+// ConsentSelectors are the CSS selectors of cookie-consent banners and
+// dialogs, removed before anything is extracted so their text ("We value
+// your privacy", vendor lists) does not pass for the page's content. They
+// cover the common consent platforms (OneTrust, Cookiebot, Didomi,
+// Quantcast, Sourcepoint, Usercentrics, TrustArc, Osano, iubenda, Complianz
+// and the WordPress cookie plugins).
+var ConsentSelectors = []string{
+	"#onetrust-consent-sdk", "#onetrust-banner-sdk", "#CybotCookiebotDialog", "#CookieConsent",
+	"#didomi-host", ".qc-cmp2-container", "#qc-cmp2-ui", `[id^="sp_message_container"]`,
+	"#usercentrics-root", "#truste-consent-track", "#consent_blackbar", ".osano-cm-window",
+	"#iubenda-cs-banner", ".cmplz-cookiebanner", "#cookie-law-info-bar", "#moove_gdpr_cookie_info_bar",
+	"#cookie-notice", ".cc-window", ".fc-consent-root", "#klaro",
+	"#cookie-banner", ".cookie-banner", "#cookie-consent", ".cookie-consent", "#gdpr-consent", ".gdpr-banner",
+}
+
+// ExtraConsentSelectors are site-specific consent dialog selectors, set
+// with --consent-selectors.
+var ExtraConsentSelectors []string
+
+// removeConsentDialogs removes the elements matching ConsentSelectors and
+// ExtraConsentSelectors and returns how many it removed.
+func removeConsentDialogs(doc *goquery.Document) int {
+	removed := 0
+	for _, selector := range append(ConsentSelectors[:len(ConsentSelectors):len(ConsentSelectors)], ExtraConsentSelectors...) {
+		sel := doc.Find(selector)
+		removed += sel.Length()
+		sel.Remove()
+	}
+	return removed
+}
@@ -0,0 +1,31 @@
+package webpage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConsentDialogsRemoved(t *testing.T) {
+	html := `<html><body><main>
+<div id="onetrust-consent-sdk"><h2>We value your privacy</h2><p>We and our 842 partners store cookies.</p></div>
+<h1>Sourdough starter</h1><p>Feed the starter twice a day.</p>
+<div class="site-consent"><p>Accept all cookies?</p></div>
+</main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(page.Content, "privacy") || len(page.Headings) != 1 {
+		t.Errorf("content %q, headings %v, want the OneTrust dialog removed", page.Content, page.Headings)
+	}
+	if !strings.Contains(page.Content, "Accept all cookies?") {
+		t.Errorf("content %q, want the unlisted banner kept", page.Content)
+	}
+
+	defer func() { ExtraConsentSelectors = nil }()
+	ExtraConsentSelectors = []string{".site-consent"}
+	if page, _ = New().ParseHTML(html, "https://example.com/"); page.Content != "Sourdough starter\n\nFeed the starter twice a day." {
+		t.Errorf("content %q, want only the article with a configured selector", page.Content)
+	}
+}
@@ -0,0 +1,34 @@
+package webpage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ModifiedMetaTags are the meta tags pages declare their modification time
+// in, most specific first.
+var ModifiedMetaTags = []string{"article:modified_time", "og:updated_time", "last-modified", "dcterms.modified"}
+
+// dateLayouts are the ISO 8601 forms pages and sitemaps write dates in.
+// Offsets may be written +00:00 or +0000, and times without seconds.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ParseDate parses an ISO 8601 date or date and time, as found in meta
+// tags, JSON-LD and sitemaps. A time without an offset is taken as UTC.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
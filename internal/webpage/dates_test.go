@@ -0,0 +1,30 @@
+package webpage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	for _, value := range []string{
+		"2024-05-01T10:30:00Z",
+		"2024-05-01T12:30:00+02:00",
+		"2024-05-01T10:30:00+0000",
+		"2024-05-01T12:30:00.000+0200",
+		"2024-05-01T10:30Z",
+		"2024-05-01T12:30+0200",
+		" 2024-05-01T10:30:00 ",
+	} {
+		got, err := ParseDate(value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if got, err := ParseDate("2024-05-01"); err != nil || !got.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseDate(date) = %v, %v", got, err)
+	}
+	if _, err := ParseDate("May 1st"); err == nil {
+		t.Error("ParseDate accepted May 1st")
+	}
+}
@@ -0,0 +1,50 @@
+package webpage
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Definition is a term and its definition from a definition list.
+type Definition struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+}
+
+// extractDefinitions returns the entries of the <dl> lists in sel. Several
+// <dt> terms sharing one <dd> each get that definition.
+func extractDefinitions(sel *goquery.Selection) []Definition {
+	var definitions []Definition
+	sel.Find("dl").Each(func(i int, dl *goquery.Selection) {
+		// terms collects consecutive <dt>s; the first <dt> after a <dd> starts
+		// a new group
+		var terms []string
+		closed := false
+		dl.Children().Each(func(j int, child *goquery.Selection) {
+			// Some lists wrap each group in a <div>
+			items := child
+			if goquery.NodeName(child) == "div" {
+				items = child.Children()
+			}
+			items.Each(func(k int, item *goquery.Selection) {
+				text := strings.Join(strings.Fields(item.Text()), " ")
+				switch goquery.NodeName(item) {
+				case "dt":
+					if closed {
+						terms, closed = nil, false
+					}
+					terms = append(terms, text)
+				case "dd":
+					for _, term := range terms {
+						if term != "" && text != "" {
+							definitions = append(definitions, Definition{Term: term, Definition: text})
+						}
+					}
+					closed = true
+				}
+			})
+		})
+	})
+	return definitions
+}
@@ -0,0 +1,24 @@
+package webpage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDefinitionExtraction(t *testing.T) {
+	html := `<html><body><main><p>Key terms used in this guide.</p>
+<dl>
+  <dt>RAG</dt><dd>Retrieval-augmented generation.</dd>
+  <div><dt>LLM</dt><dt>Model</dt><dd>A large language model.</dd></div>
+  <dt>Token</dt><dd></dd>
+</dl></main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/glossary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[{RAG Retrieval-augmented generation.} {LLM A large language model.} {Model A large language model.}]"
+	if got := fmt.Sprint(page.Definitions); got != want {
+		t.Errorf("definitions = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package webpage
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// maxInlinedFrames caps the iframes fetched per page.
+const maxInlinedFrames = 5
+
+// Frame is an iframe in the main content. Same-origin frames are fetched
+// and their text appended to the content when frame inlining is on; AI
+// crawlers may or may not follow them, so that text is at risk.
+type Frame struct {
+	URL        string `json:"url"`
+	Title      string `json:"title,omitempty"`
+	SameOrigin bool   `json:"same_origin"`
+	Inlined    bool   `json:"inlined,omitempty"`
+	Words      int    `json:"words,omitempty"` // words of text inlined
+	Error      string `json:"error,omitempty"`
+}
+
+// SetInlineFrames changes whether subsequent fetches inline the content of
+// same-origin iframes.
+func (s *Scraper) SetInlineFrames(inline bool) {
+	s.inlineFrames = inline
+}
+
+// extractFrames returns the iframes in sel that load a web page, with
+// absolute URLs when the page has one to resolve them against.
+func extractFrames(sel *goquery.Selection, source string) []Frame {
+	base, err := neturl.Parse(source)
+	if err != nil || !base.IsAbs() {
+		base = nil
+	}
+
+	var frames []Frame
+	sel.Find("iframe[src]").Each(func(i int, f *goquery.Selection) {
+		ref, err := neturl.Parse(strings.TrimSpace(f.AttrOr("src", "")))
+		if err != nil || ref.String() == "" {
+			return
+		}
+		if base != nil {
+			ref = base.ResolveReference(ref)
+		}
+		if ref.Scheme != "http" && ref.Scheme != "https" {
+			return
+		}
+		frames = append(frames, Frame{
+			URL:        ref.String(),
+			Title:      strings.TrimSpace(f.AttrOr("title", "")),
+			SameOrigin: base != nil && ref.Scheme == base.Scheme && strings.EqualFold(ref.Host, base.Host),
+		})
+	})
+	return frames
+}
+
+// inlineFrameContent fetches the first maxInlinedFrames same-origin frames
+// of page and appends their text to its content, each after a note naming
+// the frame it comes from.
+func (s *Scraper) inlineFrameContent(ctx context.Context, page *PageData) {
+	fetched := 0
+	for i := range page.Frames {
+		frame := &page.Frames[i]
+		if !frame.SameOrigin || fetched == maxInlinedFrames {
+			continue
+		}
+		fetched++
+		html, crawl, err := s.fetch(ctx, frame.URL)
+		if err != nil {
+			frame.Error = err.Error()
+			continue
+		}
+		framed, err := s.parseHTML(html, frame.URL, crawl.FinalURL)
+		if err != nil {
+			frame.Error = err.Error()
+			continue
+		}
+		frame.Inlined = true
+		frame.Words = len(strings.Fields(framed.Content))
+		page.Content += fmt.Sprintf("\n\n[Embedded content from %s]\n\n%s", frame.URL, framed.Content)
+	}
+}
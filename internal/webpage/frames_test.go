@@ -0,0 +1,47 @@
+package webpage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInlineFrames(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><main><h1>Timetable</h1><p>Trains run every hour.</p>
+<iframe src="/schedule" title="Schedule"></iframe>
+<iframe src="https://maps.example.net/embed?q=station"></iframe>
+<iframe src="about:blank"></iframe>
+</main></body></html>`)
+	})
+	mux.HandleFunc("/schedule", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><main><p>First train at 05:40, last at 23:10.</p></main></body></html>`)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	s := New()
+	page, err := s.ScrapeURL(context.Background(), ts.URL+"/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Frames) != 2 || !page.Frames[0].SameOrigin || page.Frames[0].Title != "Schedule" || page.Frames[1].SameOrigin {
+		t.Fatalf("frames %+v, want the same-origin schedule and the cross-origin map", page.Frames)
+	}
+	if page.Frames[0].Inlined || strings.Contains(page.Content, "05:40") {
+		t.Errorf("frames inlined without the option: %q", page.Content)
+	}
+
+	s.SetInlineFrames(true)
+	if page, err = s.ScrapeURL(context.Background(), ts.URL+"/page"); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("Trains run every hour.\n\n[Embedded content from %s/schedule]\n\nFirst train at 05:40, last at 23:10.", ts.URL)
+	if !strings.HasSuffix(page.Content, want) || !page.Frames[0].Inlined || page.Frames[0].Words != 7 || page.Frames[1].Inlined {
+		t.Errorf("content %q, frames %+v, want the schedule inlined with its provenance", page.Content, page.Frames)
+	}
+}
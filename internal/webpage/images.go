@@ -0,0 +1,204 @@
+package webpage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF for image.DecodeConfig
+	_ "image/jpeg" // register JPEG for image.DecodeConfig
+	_ "image/png"  // register PNG for image.DecodeConfig
+	"io"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// maxImageHeader is how much of an image is read to find its dimensions;
+// they are in the first bytes of every format, after EXIF data in JPEGs.
+const maxImageHeader = 256 << 10
+
+// placeholderImagePattern matches file names sites give default and
+// placeholder images.
+var placeholderImagePattern = regexp.MustCompile(`(?i)(placeholder|no[-_]?image|image[-_]?not[-_]?found|default[-_]?(og|share|social|thumb|image)|blank|spacer|dummy|coming[-_]?soon)[^/]*$`)
+
+// PreviewImage is the og:image of a page: the image assistants and social
+// platforms show with links to it. Status, ContentType, Width and Height
+// are only known once it was fetched.
+type PreviewImage struct {
+	URL            string `json:"url"`
+	DeclaredWidth  int    `json:"declared_width,omitempty"` // og:image:width
+	DeclaredHeight int    `json:"declared_height,omitempty"`
+	Fetched        bool   `json:"fetched"`
+	Status         int    `json:"status,omitempty"`
+	ContentType    string `json:"content_type,omitempty"`
+	Width          int    `json:"width,omitempty"`
+	Height         int    `json:"height,omitempty"`
+	Placeholder    bool   `json:"placeholder,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// SetCheckImages changes whether subsequent fetches also fetch the page's
+// og:image to confirm it exists and measure it.
+func (s *Scraper) SetCheckImages(check bool) {
+	s.checkImages = check
+}
+
+// extractPreviewImage returns the og:image declared in the meta tags, with
+// its URL resolved against base when there is one, or nil.
+func extractPreviewImage(meta map[string]string, base *neturl.URL) *PreviewImage {
+	src := strings.TrimSpace(meta["og:image"])
+	if src == "" {
+		src = strings.TrimSpace(meta["og:image:url"])
+	}
+	if src == "" {
+		return nil
+	}
+	img := &PreviewImage{URL: resolveURL(src, base)}
+	img.DeclaredWidth, _ = strconv.Atoi(strings.TrimSpace(meta["og:image:width"]))
+	img.DeclaredHeight, _ = strconv.Atoi(strings.TrimSpace(meta["og:image:height"]))
+	img.Placeholder = placeholderImagePattern.MatchString(pathOf(img.URL))
+	return img
+}
+
+// extractHeroMedia returns the first image or video in sel before its
+// first section heading, the media readers and assistants see with the
+// title, or "" when the opening has none.
+func extractHeroMedia(sel *goquery.Selection, base *neturl.URL) string {
+	hero := ""
+	sel.Find("h2, img, video, picture source").EachWithBreak(func(i int, el *goquery.Selection) bool {
+		switch goquery.NodeName(el) {
+		case "h2":
+			return false
+		case "video":
+			if src := el.AttrOr("poster", el.AttrOr("src", "")); src != "" {
+				hero = resolveURL(src, base)
+			} else if src := el.Find("source[src]").AttrOr("src", ""); src != "" {
+				hero = resolveURL(src, base)
+			}
+		case "source":
+			if srcset := strings.Fields(el.AttrOr("srcset", "")); len(srcset) > 0 {
+				hero = resolveURL(srcset[0], base)
+			}
+		default:
+			// Tracking pixels and icons are not hero media
+			if w, _ := strconv.Atoi(el.AttrOr("width", "")); w > 0 && w < 100 {
+				return true
+			}
+			if src := strings.TrimSpace(el.AttrOr("src", "")); src != "" && !strings.HasPrefix(src, "data:") {
+				hero = resolveURL(src, base)
+			}
+		}
+		return hero == ""
+	})
+	return hero
+}
+
+func resolveURL(src string, base *neturl.URL) string {
+	ref, err := neturl.Parse(strings.TrimSpace(src))
+	if err != nil {
+		return src
+	}
+	if base != nil {
+		ref = base.ResolveReference(ref)
+	}
+	return ref.String()
+}
+
+func pathOf(rawURL string) string {
+	if u, err := neturl.Parse(rawURL); err == nil {
+		return u.Path
+	}
+	return rawURL
+}
+
+// checkPreviewImage fetches the start of img and records whether it is
+// served as an image and its dimensions.
+func (s *Scraper) checkPreviewImage(ctx context.Context, img *PreviewImage) {
+	if u, err := neturl.Parse(img.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		img.Error = "not an absolute http(s) URL"
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", img.URL, nil)
+	if err != nil {
+		img.Error = err.Error()
+		return
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set("Accept", "image/*")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		img.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+
+	img.Fetched = true
+	img.Status = resp.StatusCode
+	img.ContentType = resp.Header.Get("Content-Type")
+	if resp.StatusCode != http.StatusOK {
+		img.Error = fmt.Sprintf("HTTP error: %d", resp.StatusCode)
+		return
+	}
+	switch {
+	case !strings.HasPrefix(img.ContentType, "image/"):
+		img.Error = fmt.Sprintf("served as %s, not an image", img.ContentType)
+		return
+	case strings.HasPrefix(img.ContentType, "image/svg"):
+		img.Error = "SVG, which platforms do not show as a preview"
+		return
+	}
+	header, err := io.ReadAll(io.LimitReader(resp.Body, maxImageHeader))
+	if err != nil {
+		img.Error = err.Error()
+		return
+	}
+	if img.Width, img.Height, err = imageSize(header); err != nil {
+		img.Error = err.Error()
+		return
+	}
+	// Tracking pixels and spacers stand in for a missing image
+	if img.Width <= 16 && img.Height <= 16 {
+		img.Placeholder = true
+	}
+}
+
+// imageSize returns the dimensions of the image whose first bytes are
+// data: GIF, JPEG, PNG and WebP are decoded, SVG has none.
+func imageSize(data []byte) (int, int, error) {
+	if w, h, ok := webpSize(data); ok {
+		return w, h, nil
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not read the image dimensions: %w", err)
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// webpSize reads the dimensions from the header of a WebP image in any of
+// its lossy (VP8), lossless (VP8L) and extended (VP8X) forms.
+func webpSize(data []byte) (int, int, bool) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, false
+	}
+	switch string(data[12:16]) {
+	case "VP8 ":
+		w := int(binary.LittleEndian.Uint16(data[26:28]) & 0x3fff)
+		h := int(binary.LittleEndian.Uint16(data[28:30]) & 0x3fff)
+		return w, h, true
+	case "VP8L":
+		bits := binary.LittleEndian.Uint32(data[21:25])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, true
+	case "VP8X":
+		w := int(data[24]) | int(data[25])<<8 | int(data[26])<<16
+		h := int(data[27]) | int(data[28])<<8 | int(data[29])<<16
+		return w + 1, h + 1, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,74 @@
+package webpage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPreviewImage(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1200, 630))); err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head>
+<meta property="og:image" content="/cover.png"><meta property="og:image:width" content="1200"><meta property="og:image:height" content="600">
+</head><body><main><h1>Harbour walk</h1><img src="/pixel.gif" width="1"><img src="/harbour.jpg" alt="The harbour"><p>A walk along the harbour.</p><h2>Route</h2><img src="/map.png"></main></body></html>`)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><meta property="og:image" content="/images/placeholder.png"></head><body><main><h1>Harbour walk</h1><h2>Route</h2><img src="/map.png"></main></body></html>`)
+	})
+	mux.HandleFunc("/cover.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(buf.Bytes())
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	s := New()
+	page, err := s.ScrapeURL(context.Background(), ts.URL+"/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := page.PreviewImage
+	if img == nil || img.URL != ts.URL+"/cover.png" || !img.Fetched || img.Width != 1200 || img.Height != 630 || img.DeclaredHeight != 600 || img.Placeholder || img.Error != "" {
+		t.Errorf("preview image %+v, want the 1200x630 cover", img)
+	}
+	if page.HeroMedia != ts.URL+"/harbour.jpg" {
+		t.Errorf("hero media %q, want the harbour photo, not the pixel", page.HeroMedia)
+	}
+
+	page, err = s.ScrapeURL(context.Background(), ts.URL+"/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img := page.PreviewImage; img == nil || !img.Placeholder || img.Status != http.StatusNotFound || img.Error == "" {
+		t.Errorf("preview image %+v, want a missing placeholder", img)
+	}
+	if page.HeroMedia != "" {
+		t.Errorf("hero media %q, want none before the first section", page.HeroMedia)
+	}
+
+	s.SetCheckImages(false)
+	if page, err = s.ScrapeURL(context.Background(), ts.URL+"/page"); err != nil {
+		t.Fatal(err)
+	}
+	if page.PreviewImage == nil || page.PreviewImage.Fetched {
+		t.Errorf("preview image %+v fetched with image checks off", page.PreviewImage)
+	}
+}
+
+func TestWebpSize(t *testing.T) {
+	// Extended (VP8X) header of a 1200x630 image
+	header := []byte("RIFF\x00\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00\x00\x00\x00\x00\xaf\x04\x00\x75\x02\x00")
+	if w, h, err := imageSize(header); err != nil || w != 1200 || h != 630 {
+		t.Errorf("imageSize = %d, %d, %v, want 1200x630", w, h, err)
+	}
+}
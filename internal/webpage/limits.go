@@ -0,0 +1,204 @@
+package webpage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// DefaultMaxHTMLSize is the HTML size limit new scrapers start with. Larger
+// documents are truncated before parsing. Zero or less disables the limit.
+var DefaultMaxHTMLSize int64 = 10 << 20
+
+const (
+	// maxNestingDepth is the element depth beyond which tags are dropped
+	// (their text is kept); pathological nesting makes parsing superlinear.
+	maxNestingDepth = 256
+
+	// maxAttrLength caps attribute values; longer ones (inline data URIs,
+	// serialized state) are emptied.
+	maxAttrLength = 8 << 10
+)
+
+// SetMaxHTMLSize changes the size limit for subsequent fetches and parses.
+func (s *Scraper) SetMaxHTMLSize(n int64) {
+	s.maxHTMLSize = n
+}
+
+// readBody reads at most one byte more than the size limit, so oversized
+// documents are detected without being read in full, and converts the
+// declared or sniffed charset to UTF-8.
+func (s *Scraper) readBody(body io.Reader, contentType string) (string, error) {
+	if s.maxHTMLSize > 0 {
+		body = io.LimitReader(body, s.maxHTMLSize+1)
+	}
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	decoded, err := charset.NewReader(bytes.NewReader(raw), contentType)
+	if err != nil {
+		// Unknown charset: fall back to the raw bytes
+		return string(raw), nil
+	}
+	utf, err := io.ReadAll(decoded)
+	if err != nil {
+		return string(raw), nil
+	}
+	return string(utf), nil
+}
+
+// sanitizeHTML enforces the size limit and repairs input the parser handles
+// badly, returning the HTML to parse and a warning for every degradation.
+func (s *Scraper) sanitizeHTML(raw string) (string, []string) {
+	var warnings []string
+
+	if s.maxHTMLSize > 0 && int64(len(raw)) > s.maxHTMLSize {
+		// Cut on a character boundary, not inside a multi-byte one
+		cut := int(s.maxHTMLSize)
+		for cut > 0 && !utf8.RuneStart(raw[cut]) {
+			cut--
+		}
+		raw = raw[:cut]
+		warnings = append(warnings, fmt.Sprintf("HTML truncated to %d bytes", s.maxHTMLSize))
+	}
+
+	if !utf8.ValidString(raw) {
+		raw = strings.ToValidUTF8(raw, "�")
+		warnings = append(warnings, "invalid UTF-8 sequences replaced")
+	}
+
+	depth, attr := measureHTML(raw)
+	if depth > maxNestingDepth || attr > maxAttrLength {
+		raw = flattenHTML(raw)
+		if depth > maxNestingDepth {
+			warnings = append(warnings, fmt.Sprintf("elements nested deeper than %d levels flattened", maxNestingDepth))
+		}
+		if attr > maxAttrLength {
+			warnings = append(warnings, fmt.Sprintf("attribute values longer than %d bytes dropped", maxAttrLength))
+		}
+	}
+
+	return raw, warnings
+}
+
+// measureHTML returns the deepest element nesting and the longest attribute
+// value in doc, without building a tree. Elements whose end tag may be left
+// out are not counted: the parser closes them itself, so a list of a few
+// hundred unclosed <li> is not nested at all.
+func measureHTML(doc string) (maxDepth, maxAttr int) {
+	z := html.NewTokenizer(strings.NewReader(doc))
+	depth := 0
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return maxDepth, maxAttr
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			if !voidElements[string(name)] && !optionalEndTag[string(name)] {
+				depth++
+				maxDepth = max(maxDepth, depth)
+			}
+			for hasAttr {
+				var val []byte
+				_, val, hasAttr = z.TagAttr()
+				maxAttr = max(maxAttr, len(val))
+			}
+		case html.SelfClosingTagToken:
+			for _, hasAttr := z.TagName(); hasAttr; {
+				var val []byte
+				_, val, hasAttr = z.TagAttr()
+				maxAttr = max(maxAttr, len(val))
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); depth > 0 && !optionalEndTag[string(name)] {
+				depth--
+			}
+		}
+	}
+}
+
+// flattenHTML rewrites doc token by token, dropping tags nested beyond
+// maxNestingDepth (counted as measureHTML does) and emptying oversized
+// attribute values. Text is kept, as
+// are the deep tags content extraction looks for, up to a second depth limit.
+func flattenHTML(doc string) string {
+	z := html.NewTokenizer(strings.NewReader(doc))
+	var sb strings.Builder
+	var open []bool // for each open element: whether its tags are written
+	kept := 0       // written elements on the stack beyond maxNestingDepth
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return sb.String()
+		case html.TextToken:
+			// Raw text elements must not be re-escaped
+			sb.Write(z.Raw())
+			continue
+		}
+
+		tok := z.Token()
+		for i := range tok.Attr {
+			if len(tok.Attr[i].Val) > maxAttrLength {
+				tok.Attr[i].Val = ""
+			}
+		}
+
+		switch tt {
+		case html.StartTagToken:
+			if voidElements[tok.Data] || optionalEndTag[tok.Data] {
+				break
+			}
+			write := len(open) < maxNestingDepth || (contentElements[tok.Data] && kept < maxNestingDepth)
+			if write && len(open) >= maxNestingDepth {
+				kept++
+			}
+			open = append(open, write)
+			if !write {
+				continue
+			}
+		case html.EndTagToken:
+			if len(open) == 0 || optionalEndTag[tok.Data] {
+				break
+			}
+			write := open[len(open)-1]
+			open = open[:len(open)-1]
+			if write && len(open) >= maxNestingDepth {
+				kept--
+			}
+			if !write {
+				continue
+			}
+		}
+		sb.WriteString(tok.String())
+	}
+}
+
+// contentElements are the elements extraction reads text from.
+var contentElements = map[string]bool{
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"p": true, "li": true, "td": true, "th": true, "blockquote": true, "pre": true,
+	"dt": true, "dd": true,
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// optionalEndTag are the elements whose end tag may be omitted, which the
+// parser closes when the next sibling or the parent ends.
+var optionalEndTag = map[string]bool{
+	"html": true, "head": true, "body": true, "p": true, "li": true,
+	"dt": true, "dd": true, "rt": true, "rp": true, "optgroup": true,
+	"option": true, "colgroup": true, "caption": true, "thead": true,
+	"tbody": true, "tfoot": true, "tr": true, "td": true, "th": true,
+}
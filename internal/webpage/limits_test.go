@@ -0,0 +1,94 @@
+package webpage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHTMLDegradesGracefully(t *testing.T) {
+	deep := strings.Repeat("<div>", 5000) + "<p>Deep paragraph text.</p>" + strings.Repeat("</div>", 5000)
+	longAttr := `<main><img src="data:image/png;base64,` + strings.Repeat("A", 1<<20) + `"><p>After the image.</p></main>`
+	latin1 := "<main><p>Caf\xe9 cr\xe8me</p></main>"
+
+	tests := []struct {
+		name    string
+		html    string
+		max     int64
+		content string
+		warning string
+	}{
+		{"deep nesting", deep, DefaultMaxHTMLSize, "Deep paragraph text.", "flattened"},
+		{"long attribute", longAttr, DefaultMaxHTMLSize, "After the image.", "attribute values"},
+		{"invalid utf-8", latin1, DefaultMaxHTMLSize, "Caf� cr�me", "UTF-8"},
+		{"oversized", "<main><p>Kept text.</p>" + strings.Repeat("<p>filler</p>", 1000) + "</main>", 64, "Kept text.", "truncated to 64 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New()
+			s.SetMaxHTMLSize(tt.max)
+			page, err := s.ParseHTML(tt.html, "https://example.com/")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(page.Content, tt.content) {
+				t.Errorf("content %q does not contain %q", page.Content, tt.content)
+			}
+			if !strings.Contains(strings.Join(page.Warnings, "; "), tt.warning) {
+				t.Errorf("warnings %v do not mention %q", page.Warnings, tt.warning)
+			}
+		})
+	}
+}
+
+func TestParseHTMLLeavesWellFormedPagesAlone(t *testing.T) {
+	page, err := New().ParseHTML(`<main><h1>Title</h1><p>Body &amp; more.</p></main>`, "https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Warnings) != 0 || page.Content != "Title\n\nBody & more." {
+		t.Errorf("unexpected result: %q %v", page.Content, page.Warnings)
+	}
+}
+
+func TestParseHTMLUnclosedElementsAreNotDeep(t *testing.T) {
+	// Valid HTML: the end tags of <li>, <p> and <td> may be left out
+	var sb strings.Builder
+	for i := 0; i < 200; i++ {
+		sb.WriteString("<ul>")
+		for j := 0; j < 3; j++ {
+			sb.WriteString("<li>Item")
+		}
+		sb.WriteString("</ul><p>Paragraph<table><tr><td>Cell<td>Cell</table>")
+	}
+	page, err := New().ParseHTML("<main>"+sb.String()+"</main>", "https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Warnings) != 0 || len(page.Lists) != 200 {
+		t.Errorf("%d lists, warnings %v", len(page.Lists), page.Warnings)
+	}
+}
+
+func TestTruncateOnRuneBoundary(t *testing.T) {
+	s := New()
+	s.SetMaxHTMLSize(10)
+	// The limit falls inside the three bytes of "€"
+	raw, warnings := s.sanitizeHTML("<p>Price €12</p>")
+	if raw != "<p>Price " || len(warnings) != 1 {
+		t.Errorf("truncated to %q, warnings %v", raw, warnings)
+	}
+}
+
+func FuzzParseHTML(f *testing.F) {
+	f.Add("<html><body><main><p>text</p></main></body></html>")
+	f.Add("<div><div><p>unclosed")
+	f.Add("<img src=\"\xff\xfe\">")
+	f.Fuzz(func(t *testing.T, html string) {
+		s := New()
+		s.SetMaxHTMLSize(4096)
+		if _, err := s.ParseHTML(html, "https://example.com/"); err != nil {
+			t.Skip()
+		}
+	})
+}
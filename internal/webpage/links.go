@@ -0,0 +1,72 @@
+package webpage
+
+import (
+	neturl "net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Link is a link in the main content, with an absolute URL when the page
+// has one to resolve it against. Hidden links are hidden from readers by
+// the hidden attribute or inline styles on the link or its parents.
+type Link struct {
+	Text   string `json:"text"`
+	URL    string `json:"url"`
+	Hidden bool   `json:"hidden,omitempty"`
+}
+
+// hidingStyles are the inline style declarations that hide an element,
+// without spaces.
+var hidingStyles = map[string]bool{
+	"display:none": true, "visibility:hidden": true, "font-size:0": true, "font-size:0px": true,
+	"opacity:0": true, "text-indent:-9999px": true, "left:-9999px": true,
+}
+
+// isHidden reports whether sel or one of its parents is hidden by the
+// hidden attribute or an inline style.
+func isHidden(sel *goquery.Selection) bool {
+	for s := sel; s.Length() > 0 && goquery.NodeName(s) != "body"; s = s.Parent() {
+		if _, ok := s.Attr("hidden"); ok {
+			return true
+		}
+		for _, declaration := range strings.Split(s.AttrOr("style", ""), ";") {
+			declaration = strings.ToLower(strings.Join(strings.Fields(declaration), ""))
+			if hidingStyles[strings.TrimSuffix(declaration, "!important")] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// extractLinks returns the links in sel that lead to another page:
+// fragment, mailto:, tel: and javascript: links are skipped.
+func extractLinks(sel *goquery.Selection, source string) []Link {
+	base, err := neturl.Parse(source)
+	if err != nil || !base.IsAbs() {
+		base = nil
+	}
+
+	var links []Link
+	sel.Find("a[href]").Each(func(i int, a *goquery.Selection) {
+		href := strings.TrimSpace(a.AttrOr("href", ""))
+		lower := strings.ToLower(href)
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(lower, "mailto:") ||
+			strings.HasPrefix(lower, "tel:") || strings.HasPrefix(lower, "javascript:") {
+			return
+		}
+		text := strings.Join(strings.Fields(a.Text()), " ")
+		if text == "" {
+			text = strings.TrimSpace(a.Find("img").AttrOr("alt", ""))
+		}
+		link := Link{Text: text, URL: href, Hidden: isHidden(a)}
+		if base != nil {
+			if ref, err := neturl.Parse(href); err == nil {
+				link.URL = base.ResolveReference(ref).String()
+			}
+		}
+		links = append(links, link)
+	})
+	return links
+}
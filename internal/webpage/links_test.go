@@ -0,0 +1,50 @@
+package webpage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestLinkExtraction(t *testing.T) {
+	html := `<html><body><main><h1>Shoes</h1>
+<p><a href="/shoes/trail">Trail shoes</a>, <a href="https://other.example/road">road shoes</a>
+and <a href="#sizes">sizes</a>. Mail <a href="mailto:hi@example.com">us</a>.</p>
+<a href="kids"><img src="k.png" alt="Kids shoes"></a>
+<div style="opacity: 0.8"><a href="/sale">Sale</a></div>
+<div style="color: red; Display: none !important"><a href="https://casino.example/">casino</a></div>
+<p hidden><a href="https://pills.example/">pills</a></p>
+</main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/shop/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[{Trail shoes https://example.com/shoes/trail false} {road shoes https://other.example/road false} {Kids shoes https://example.com/shop/kids false} " +
+		"{Sale https://example.com/sale false} {casino https://casino.example/ true} {pills https://pills.example/ true}]"
+	if got := fmt.Sprint(page.Links); got != want {
+		t.Errorf("links = %s, want %s", got, want)
+	}
+}
+
+func TestLinksResolveAgainstFinalURL(t *testing.T) {
+	html := `<html><body><main><h1>Guide</h1><p>Read the <a href="next">next part</a>,
+see <a href="http://example.com/legacy">the old guide</a> or <iframe src="demo"></iframe></p></main></body></html>`
+
+	page, err := New().scrapeFetched(context.Background(), html, "http://example.com/guide", CrawlInfo{FinalURL: "https://example.com/docs/guide/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.URL != "http://example.com/guide" {
+		t.Errorf("URL = %s, want the requested one", page.URL)
+	}
+	if len(page.Links) == 0 || page.Links[0].URL != "https://example.com/docs/guide/next" {
+		t.Errorf("links = %+v, want next resolved against the final URL", page.Links)
+	}
+	if len(page.Frames) != 1 || page.Frames[0].URL != "https://example.com/docs/guide/demo" {
+		t.Errorf("frames = %+v, want demo resolved against the final URL", page.Frames)
+	}
+	if fmt.Sprint(page.Crawl.InsecureLinks) != "[http://example.com/legacy]" {
+		t.Errorf("insecure links = %v, want the plain-HTTP link of the HTTPS page", page.Crawl.InsecureLinks)
+	}
+}
@@ -0,0 +1,35 @@
+package webpage
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// List is a <ul> or <ol> list in the content with the text of its items.
+// Lists nested in an item are part of that item.
+type List struct {
+	Ordered bool     `json:"ordered,omitempty"`
+	Items   []string `json:"items"`
+}
+
+// extractLists returns the outermost <ul> and <ol> lists in sel that have
+// items.
+func extractLists(sel *goquery.Selection) []List {
+	var lists []List
+	sel.Find("ul, ol").Each(func(i int, list *goquery.Selection) {
+		if list.ParentsFiltered("li").Length() > 0 {
+			return
+		}
+		l := List{Ordered: goquery.NodeName(list) == "ol"}
+		list.ChildrenFiltered("li").Each(func(j int, item *goquery.Selection) {
+			if text := strings.Join(strings.Fields(item.Text()), " "); text != "" {
+				l.Items = append(l.Items, text)
+			}
+		})
+		if len(l.Items) > 0 {
+			lists = append(lists, l)
+		}
+	})
+	return lists
+}
@@ -0,0 +1,23 @@
+package webpage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestListExtraction(t *testing.T) {
+	html := `<html><body><main><p>Released 2024-05-01, a well-known tool.</p>
+<ul><li>Fast</li><li>Small <ul><li>under 1 MB</li></ul></li><li> </li></ul>
+<ol><li>Install</li><li>Run</li></ol>
+<ul></ul>
+</main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[{false [Fast Small under 1 MB]} {true [Install Run]}]"
+	if got := fmt.Sprint(page.Lists); got != want {
+		t.Errorf("lists = %s, want %s", got, want)
+	}
+}
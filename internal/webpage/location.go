@@ -0,0 +1,53 @@
+package webpage
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// mapHosts are the URL fragments of embeddable map services.
+var mapHosts = []string{
+	"google.com/maps", "maps.google.", "maps.apple.com", "openstreetmap.org",
+	"bing.com/maps", "mapbox.com", "here.com",
+}
+
+// extractLocation returns the postal addresses, phone numbers and embedded
+// maps shown anywhere on the page. It runs before boilerplate is removed:
+// contact details usually live in headers and footers.
+func extractLocation(doc *goquery.Document) (addresses, phones, maps []string) {
+	seen := make(map[string]bool)
+	add := func(list *[]string, value string) {
+		value = strings.Join(strings.Fields(value), " ")
+		if value != "" && !seen[value] {
+			seen[value] = true
+			*list = append(*list, value)
+		}
+	}
+
+	doc.Find(`address, [itemprop="address"]`).Each(func(i int, s *goquery.Selection) {
+		// Skip addresses nested in one already taken
+		if s.ParentsFiltered(`address, [itemprop="address"]`).Length() == 0 {
+			// Lines are often separated by <br> alone
+			s.Find("br").ReplaceWithHtml(" ")
+			add(&addresses, s.Text())
+		}
+	})
+	doc.Find(`a[href^="tel:"]`).Each(func(i int, s *goquery.Selection) {
+		add(&phones, strings.TrimPrefix(s.AttrOr("href", ""), "tel:"))
+	})
+	doc.Find(`[itemprop="telephone"]`).Each(func(i int, s *goquery.Selection) {
+		add(&phones, s.AttrOr("content", s.Text()))
+	})
+	// Interactive embeds and static map images
+	doc.Find("iframe[src], img[src]").Each(func(i int, s *goquery.Selection) {
+		src := s.AttrOr("src", "")
+		for _, host := range mapHosts {
+			if strings.Contains(strings.ToLower(src), host) {
+				add(&maps, src)
+				break
+			}
+		}
+	})
+	return addresses, phones, maps
+}
@@ -0,0 +1,29 @@
+package webpage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLocationExtraction(t *testing.T) {
+	html := `<html><body>
+<main><h1>Dental care in Lyon</h1><p>We treat patients of all ages.</p>
+<iframe src="https://www.google.com/maps/embed?pb=abc"></iframe></main>
+<footer><address>Cabinet Dupont<br>12 rue Victor Hugo<br>69002 Lyon</address>
+<a href="tel:+33478000000">04 78 00 00 00</a></footer>
+</body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/lyon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(page.Addresses), "[Cabinet Dupont 12 rue Victor Hugo 69002 Lyon]"; got != want {
+		t.Errorf("addresses = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprint(page.Phones), "[+33478000000]"; got != want {
+		t.Errorf("phones = %s, want %s", got, want)
+	}
+	if len(page.MapEmbeds) != 1 {
+		t.Errorf("map embeds = %v", page.MapEmbeds)
+	}
+}
@@ -0,0 +1,87 @@
+package webpage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MetaCapture is additional page metadata to record in PageData.MetaTags,
+// beyond the name and property of <meta> tags that are always recorded:
+//
+//   - "meta:ATTR" records <meta> tags keyed by another attribute, e.g.
+//     "meta:itemprop" or "meta:http-equiv", under the attribute's value
+//   - "link:REL" records the href of <link rel="REL"> under "link:REL"
+//   - "data-NAME" records the first data-NAME attribute on the page, e.g. a
+//     CMS template or content type, under "data-NAME"
+type MetaCapture struct {
+	Kind string // "meta", "link" or "data"
+	Name string // attribute, rel or data-* attribute
+}
+
+// metaCaptureName matches the attribute and rel names captures accept.
+var metaCaptureName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// ParseMetaCaptures parses capture specs as given to --capture-meta.
+func ParseMetaCaptures(specs []string) ([]MetaCapture, error) {
+	var captures []MetaCapture
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		var c MetaCapture
+		switch {
+		case strings.HasPrefix(spec, "meta:"):
+			c = MetaCapture{Kind: "meta", Name: strings.ToLower(strings.TrimPrefix(spec, "meta:"))}
+		case strings.HasPrefix(spec, "link:"):
+			c = MetaCapture{Kind: "link", Name: strings.ToLower(strings.TrimPrefix(spec, "link:"))}
+		case strings.HasPrefix(spec, "data-"):
+			c = MetaCapture{Kind: "data", Name: strings.ToLower(spec)}
+		default:
+			return nil, fmt.Errorf("invalid metadata capture %q (want meta:ATTR, link:REL or data-NAME)", spec)
+		}
+		if !metaCaptureName.MatchString(strings.TrimPrefix(c.Name, "data-")) {
+			return nil, fmt.Errorf("invalid metadata capture %q: %q is not an attribute name", spec, c.Name)
+		}
+		captures = append(captures, c)
+	}
+	return captures, nil
+}
+
+// SetMetaCaptures changes the additional metadata subsequent fetches record
+// in PageData.MetaTags.
+func (s *Scraper) SetMetaCaptures(captures []MetaCapture) {
+	s.metaCaptures = captures
+}
+
+// captureMeta records the metadata captures select from doc in meta,
+// without overwriting the tags already recorded.
+func captureMeta(doc *goquery.Document, captures []MetaCapture, meta map[string]string) {
+	set := func(key, value string) {
+		if _, exists := meta[key]; !exists && key != "" {
+			meta[key] = strings.TrimSpace(value)
+		}
+	}
+	for _, c := range captures {
+		switch c.Kind {
+		case "meta":
+			doc.Find("meta[" + c.Name + "]").Each(func(i int, m *goquery.Selection) {
+				set(strings.TrimSpace(m.AttrOr(c.Name, "")), m.AttrOr("content", ""))
+			})
+		case "link":
+			doc.Find("link[rel][href]").EachWithBreak(func(i int, l *goquery.Selection) bool {
+				for _, rel := range strings.Fields(strings.ToLower(l.AttrOr("rel", ""))) {
+					if rel == c.Name {
+						set("link:"+c.Name, l.AttrOr("href", ""))
+						return false
+					}
+				}
+				return true
+			})
+		case "data":
+			if el := doc.Find("[" + c.Name + "]").First(); el.Length() > 0 {
+				set(c.Name, el.AttrOr(c.Name, ""))
+			}
+		}
+	}
+}
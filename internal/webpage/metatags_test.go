@@ -0,0 +1,42 @@
+package webpage
+
+import (
+	"testing"
+)
+
+func TestMetaCaptures(t *testing.T) {
+	captures, err := ParseMetaCaptures([]string{"meta:itemprop", "link:author", "data-cms-template", "link:canonical"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New()
+	s.SetMetaCaptures(captures)
+	page, err := s.ParseHTML(`<html><head>
+<meta property="article:section" content="Guides">
+<meta itemprop="datePublished" content="2024-05-01">
+<meta itemprop="article:section" content="Ignored">
+<link rel="author" href="https://example.com/team/ana">
+<link rel="alternate canonical" href="https://example.com/guide">
+</head><body data-cms-template="long-form"><main><h1>Guide</h1><p>Text.</p></main></body></html>`, "https://example.com/guide")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"article:section":   "Guides",
+		"datePublished":     "2024-05-01",
+		"link:author":       "https://example.com/team/ana",
+		"link:canonical":    "https://example.com/guide",
+		"data-cms-template": "long-form",
+	}
+	for key, value := range want {
+		if page.MetaTags[key] != value {
+			t.Errorf("MetaTags[%q] = %q, want %q", key, page.MetaTags[key], value)
+		}
+	}
+
+	for _, spec := range []string{"itemprop", "meta:", "link:a b", "data-"} {
+		if _, err := ParseMetaCaptures([]string{spec}); err == nil {
+			t.Errorf("ParseMetaCaptures(%q) succeeded, want an error", spec)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package webpage
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Response is a page as the server sent it, kept so that what was scored
+// can be archived and scored again once the live page has changed.
+type Response struct {
+	URL       string // after redirects
+	Status    int
+	Proto     string
+	Header    http.Header
+	Body      []byte // as received, before charset decoding, up to the size limit
+	FetchedAt time.Time
+}
+
+// SetKeepResponse makes ScrapeURL record the response in PageData.Response.
+func (s *Scraper) SetKeepResponse(keep bool) {
+	s.keepResponse = keep
+}
+
+// ScrapeResponse extracts the page data of an archived response of url as
+// ScrapeURL would have when it was fetched. Frames and the preview image
+// are still looked into live, when the scraper is set to.
+func (s *Scraper) ScrapeResponse(ctx context.Context, url string, resp *Response) (*PageData, error) {
+	html, err := s.readBody(bytes.NewReader(resp.Body), resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	crawl := CrawlInfo{
+		FinalURL:       resp.URL,
+		LastModified:   resp.Header.Get("Last-Modified"),
+		XRobotsTag:     strings.Join(resp.Header.Values("X-Robots-Tag"), ", "),
+		TDMReservation: resp.Header.Get("TDM-Reservation"),
+		TDMPolicy:      resp.Header.Get("TDM-Policy"),
+		response:       resp,
+	}
+	return s.scrapeFetched(ctx, html, url, crawl)
+}
@@ -1,10 +1,12 @@
 package webpage
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"strings"
 	"time"
@@ -12,8 +14,34 @@ import (
 	"github.com/PuerkitoBio/goquery"
 )
 
+// User agents for comparing what different clients are served.
+const (
+	DefaultUserAgent = "GEO-Checker/1.0 (+https://github.com/your-repo/geo-checker)"
+	BrowserUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+	GPTBotUserAgent  = "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; GPTBot/1.2; +https://openai.com/gptbot)"
+)
+
 type Scraper struct {
-	client *http.Client
+	client      *http.Client
+	userAgent   string
+	maxHTMLSize int64
+
+	// inlineFrames appends the text of same-origin iframes to the content
+	inlineFrames bool
+
+	// selector restricts headings and content to the matching elements
+	selector string
+
+	// checkImages fetches the og:image to confirm it exists and measure it
+	checkImages bool
+
+	// metaCaptures is the metadata recorded in MetaTags beyond <meta> names
+	// and properties
+	metaCaptures []MetaCapture
+
+	// keepResponse records the response pages were served with in
+	// PageData.Response, for archiving
+	keepResponse bool
 }
 
 type PageData struct {
@@ -22,48 +50,230 @@ type PageData struct {
 	Content  string            `json:"content"`
 	MetaTags map[string]string `json:"meta_tags"`
 	Headings []Heading         `json:"headings"`
+	Crawl    CrawlInfo         `json:"crawl"`
+	Warnings []string          `json:"warnings,omitempty"` // degradations applied to malformed HTML
+	Response *Response         `json:"-"`                  // as served, when the scraper keeps responses
+
+	// Breadcrumbs is the visible breadcrumb trail and SchemaBreadcrumbs the
+	// one declared as a BreadcrumbList, both with absolute URLs
+	Breadcrumbs       []Breadcrumb     `json:"breadcrumbs,omitempty"`
+	SchemaBreadcrumbs []Breadcrumb     `json:"schema_breadcrumbs,omitempty"`
+	StructuredData    []map[string]any `json:"structured_data,omitempty"` // top-level schema.org objects, microdata and RDFa converted to JSON-LD
+	SchemaFormats     []string         `json:"schema_formats,omitempty"`  // json-ld, microdata, rdfa: those StructuredData came in
+
+	// Definitions are the term/definition pairs of <dl> lists in the content
+	Definitions []Definition `json:"definitions,omitempty"`
+
+	// Addresses, Phones and MapEmbeds are the contact details shown anywhere
+	// on the page: <address> blocks, tel: links and embedded maps
+	Addresses []string `json:"addresses,omitempty"`
+	Phones    []string `json:"phones,omitempty"`
+	MapEmbeds []string `json:"map_embeds,omitempty"`
+
+	// CodeBlocks, Tables and Lists are the <pre> blocks, headed tables and
+	// <ul>/<ol> lists in the content
+	CodeBlocks []CodeBlock `json:"code_blocks,omitempty"`
+	Tables     []Table     `json:"tables,omitempty"`
+	Lists      []List      `json:"lists,omitempty"`
+	Links      []Link      `json:"links,omitempty"`  // links in the content
+	Frames     []Frame     `json:"frames,omitempty"` // iframes in the content
+
+	// PreviewImage is the og:image and HeroMedia the first image or video
+	// of the content before its first section, both with absolute URLs
+	PreviewImage *PreviewImage `json:"preview_image,omitempty"`
+	HeroMedia    string        `json:"hero_media,omitempty"`
+
+	// Accessibility is nil for content that was not extracted from HTML
+	Accessibility *Accessibility `json:"accessibility,omitempty"`
+}
+
+// CrawlInfo records how the page was reached and which crawl signals it
+// declares.
+type CrawlInfo struct {
+	FinalURL      string     `json:"final_url,omitempty"`
+	Redirects     []Redirect `json:"redirects,omitempty"`
+	Canonical     string     `json:"canonical,omitempty"`
+	InsecureLinks []string   `json:"insecure_links,omitempty"`
+	LastModified  string     `json:"last_modified,omitempty"` // Last-Modified response header
+
+	// XRobotsTag, TDMReservation and TDMPolicy are the X-Robots-Tag,
+	// tdm-reservation and tdm-policy response headers, through which a
+	// site can opt out of AI training and text and data mining
+	XRobotsTag     string `json:"x_robots_tag,omitempty"`
+	TDMReservation string `json:"tdm_reservation,omitempty"`
+	TDMPolicy      string `json:"tdm_policy,omitempty"`
+
+	// response is the page as served, when the scraper keeps responses
+	response *Response
+}
+
+// HTTPError is returned when a page is served with a status other than 200.
+type HTTPError struct {
+	StatusCode int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP error: %d", e.StatusCode)
+}
+
+// Redirect is one hop of a redirect chain.
+type Redirect struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Status int    `json:"status"`
 }
 
 type Heading struct {
 	Level int    `json:"level"`
 	Text  string `json:"text"`
+	ID    string `json:"id,omitempty"` // anchor a link can jump to the heading with
 }
 
 func New() *Scraper {
 	return &Scraper{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: sharedTransport,
+			Timeout:   30 * time.Second,
 		},
+		userAgent:   DefaultUserAgent,
+		maxHTMLSize: DefaultMaxHTMLSize,
+		checkImages: true,
 	}
 }
 
+// SetPublicOnly makes the scraper refuse to fetch pages, frames and images
+// from addresses that are not on the public internet, except from the hosts
+// in allow.
+func (s *Scraper) SetPublicOnly(allow []string) {
+	client := *s.client
+	client.Transport = newPublicTransport(allow)
+	s.client = &client
+}
+
+// SetUserAgent changes the User-Agent sent with subsequent requests.
+func (s *Scraper) SetUserAgent(userAgent string) {
+	s.userAgent = userAgent
+}
+
 func (s *Scraper) ScrapeURL(ctx context.Context, url string) (*PageData, error) {
+	html, crawl, err := s.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return s.scrapeFetched(ctx, html, url, crawl)
+}
+
+// scrapeFetched extracts the page data of html fetched from url, and
+// completes it with the crawl details and the frames and preview image the
+// scraper is set to look into.
+func (s *Scraper) scrapeFetched(ctx context.Context, html, url string, crawl CrawlInfo) (*PageData, error) {
+	final := crawl.FinalURL
+	if final == "" {
+		final = url
+	}
+	pageData, err := s.parseHTML(html, url, final)
+	if err != nil {
+		return nil, err
+	}
+	pageData.Response = crawl.response
+	pageData.Crawl.FinalURL = crawl.FinalURL
+	pageData.Crawl.Redirects = crawl.Redirects
+	pageData.Crawl.LastModified = crawl.LastModified
+	pageData.Crawl.XRobotsTag = crawl.XRobotsTag
+	pageData.Crawl.TDMReservation = crawl.TDMReservation
+	pageData.Crawl.TDMPolicy = crawl.TDMPolicy
+	if s.inlineFrames {
+		s.inlineFrameContent(ctx, pageData)
+	}
+	if s.checkImages && pageData.PreviewImage != nil {
+		s.checkPreviewImage(ctx, pageData.PreviewImage)
+	}
+	return pageData, nil
+}
+
+// FetchHTML returns the raw HTML served for url without extracting it.
+func (s *Scraper) FetchHTML(ctx context.Context, url string) (string, error) {
+	html, _, err := s.fetch(ctx, url)
+	return html, err
+}
+
+func (s *Scraper) fetch(ctx context.Context, url string) (string, CrawlInfo, error) {
+	var crawl CrawlInfo
+	
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", crawl, fmt.Errorf("failed to create request: %w", err)
 	}
 	
-	req.Header.Set("User-Agent", "GEO-Checker/1.0 (+https://github.com/your-repo/geo-checker)")
+	req.Header.Set("User-Agent", s.userAgent)
 	
-	resp, err := s.client.Do(req)
+	// Record redirect hops on a per-request copy so the scraper stays safe
+	// to share between goroutines
+	client := *s.client
+	client.CheckRedirect = func(next *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		hop := Redirect{From: via[len(via)-1].URL.String(), To: next.URL.String()}
+		if next.Response != nil {
+			hop.Status = next.Response.StatusCode
+		}
+		crawl.Redirects = append(crawl.Redirects, hop)
+		return nil
+	}
+	
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+		return "", crawl, fmt.Errorf("failed to fetch URL: %w", err)
 	}
-	defer resp.Body.Close()
+	defer func() {
+		// Drain what is left of small bodies so the connection returns to the pool
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+	}()
+	crawl.FinalURL = resp.Request.URL.String()
+	crawl.LastModified = resp.Header.Get("Last-Modified")
+	crawl.XRobotsTag = strings.Join(resp.Header.Values("X-Robots-Tag"), ", ")
+	crawl.TDMReservation = resp.Header.Get("TDM-Reservation")
+	crawl.TDMPolicy = resp.Header.Get("TDM-Policy")
 	
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		return "", crawl, &HTTPError{StatusCode: resp.StatusCode}
 	}
 	
-	body, err := io.ReadAll(resp.Body)
+	var raw bytes.Buffer
+	reader := io.Reader(resp.Body)
+	if s.keepResponse {
+		reader = io.TeeReader(resp.Body, &raw)
+	}
+	body, err := s.readBody(reader, resp.Header.Get("Content-Type"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return "", crawl, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if s.keepResponse {
+		crawl.response = &Response{
+			URL:       crawl.FinalURL,
+			Status:    resp.StatusCode,
+			Proto:     resp.Proto,
+			Header:    resp.Header.Clone(),
+			Body:      raw.Bytes(),
+			FetchedAt: time.Now().UTC(),
+		}
 	}
 	
-	return s.parseHTML(string(body), url)
+	return body, crawl, nil
 }
 
-func (s *Scraper) parseHTML(html, source string) (*PageData, error) {
+// ParseHTML extracts page data from an HTML document that was obtained
+// without going through ScrapeURL, e.g. from a CMS API.
+func (s *Scraper) ParseHTML(html, source string) (*PageData, error) {
+	return s.parseHTML(html, source, source)
+}
+
+// parseHTML extracts the page data of html loaded from source. Relative
+// references resolve against final, the URL source redirected to.
+func (s *Scraper) parseHTML(html, source, final string) (*PageData, error) {
+	html, warnings := s.sanitizeHTML(html)
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
@@ -73,8 +283,15 @@ func (s *Scraper) parseHTML(html, source string) (*PageData, error) {
 		URL:      source,
 		MetaTags: make(map[string]string),
 		Headings: []Heading{},
+		Warnings: warnings,
 	}
 	
+	// Drop cookie-consent dialogs before anything is extracted from the page
+	removeConsentDialogs(doc)
+	
+	// Show web components as browsers do, with their shadow trees
+	flattenShadowRoots(doc)
+	
 	// Extract title
 	pageData.Title = doc.Find("title").Text()
 	
@@ -89,21 +306,51 @@ func (s *Scraper) parseHTML(html, source string) (*PageData, error) {
 			pageData.MetaTags[property] = content
 		}
 	})
+	captureMeta(doc, s.metaCaptures, pageData.MetaTags)
+	
+	// Restrict what is extracted from the content to the selected region,
+	// leaving the page-level metadata and markup as they are
+	var scope *goquery.Selection
+	if s.selector != "" {
+		if scope = doc.Find(s.selector); scope.Length() == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrSelectorNoMatch, s.selector)
+		}
+	}
+	headingScope := doc.Selection
+	if scope != nil {
+		headingScope = scope
+	}
 	
 	// Extract headings
-	doc.Find("h1, h2, h3, h4, h5, h6").Each(func(i int, s *goquery.Selection) {
+	headingScope.Find("h1, h2, h3, h4, h5, h6").Each(func(i int, s *goquery.Selection) {
 		level := getHeadingLevel(s.Get(0).Data)
 		text := strings.TrimSpace(s.Text())
 		if text != "" {
 			pageData.Headings = append(pageData.Headings, Heading{
 				Level: level,
 				Text:  text,
+				ID:    headingAnchor(s),
 			})
 		}
 	})
 	
+	// Extract crawl signals and breadcrumbs before boilerplate such as nav
+	// is removed
+	pageData.Crawl.Canonical, pageData.Crawl.InsecureLinks = extractCrawlSignals(doc, final)
+	base, err := neturl.Parse(final)
+	if err != nil || !base.IsAbs() {
+		base = nil
+	}
+	pageData.StructuredData, pageData.SchemaFormats = extractStructuredData(doc, base)
+	pageData.SchemaBreadcrumbs = schemaBreadcrumbs(doc, pageData.StructuredData, base)
+	pageData.Breadcrumbs = visibleBreadcrumbs(doc, base)
+	pageData.Addresses, pageData.Phones, pageData.MapEmbeds = extractLocation(doc)
+	pageData.Accessibility = extractAccessibility(doc)
+	pageData.PreviewImage = extractPreviewImage(pageData.MetaTags, base)
+	pageData.HeroMedia = extractHeroMedia(headingScope, base)
+	
 	// Extract main content
-	content := s.extractContent(doc)
+	content := s.extractContent(doc, scope, pageData, final)
 	pageData.Content = strings.TrimSpace(content)
 	
 	// Validate that we have some content
@@ -140,11 +387,18 @@ func (s *Scraper) parseHTML(html, source string) (*PageData, error) {
 	return pageData, nil
 }
 
-func (s *Scraper) extractContent(doc *goquery.Document) string {
+// extractContent returns the text of the main content and records the
+// definitions, code blocks, tables and links found in it on pageData. The
+// main content is scope when there is one, else the first main content
+// area found. Links and frames resolve against final.
+func (s *Scraper) extractContent(doc *goquery.Document, scope *goquery.Selection, pageData *PageData, final string) string {
 	// Remove script and style elements
 	doc.Find("script, style, nav, footer, header, aside").Remove()
 	
 	var content strings.Builder
+	if scope != nil {
+		return s.extractScopedContent(scope, pageData)
+	}
 	
 	// Extract main content areas
 	mainSelectors := []string{
@@ -169,8 +423,15 @@ func (s *Scraper) extractContent(doc *goquery.Document) string {
 		mainContent = doc.Find("body")
 	}
 	
+	pageData.Definitions = extractDefinitions(mainContent)
+	pageData.CodeBlocks = extractCodeBlocks(mainContent)
+	pageData.Tables = extractTables(mainContent)
+	pageData.Lists = extractLists(mainContent)
+	pageData.Links = extractLinks(mainContent, final)
+	pageData.Frames = extractFrames(mainContent, final)
+	
 	// Extract text content
-	mainContent.Find("h1, h2, h3, h4, h5, h6, p, li, td, th, blockquote, pre").Each(func(i int, s *goquery.Selection) {
+	mainContent.Find("h1, h2, h3, h4, h5, h6, p, li, td, th, blockquote, pre, dt, dd").Each(func(i int, s *goquery.Selection) {
 		text := strings.TrimSpace(s.Text())
 		if text != "" {
 			content.WriteString(text)
@@ -206,6 +467,39 @@ func (s *Scraper) extractContent(doc *goquery.Document) string {
 	return content.String()
 }
 
+// extractCrawlSignals returns the absolute canonical URL and, for pages
+// served over HTTPS, the plain-HTTP links and resources they reference.
+func extractCrawlSignals(doc *goquery.Document, source string) (string, []string) {
+	base, err := neturl.Parse(source)
+	if err != nil {
+		base = nil
+	}
+	
+	canonical := ""
+	if href, ok := doc.Find(`link[rel="canonical"]`).First().Attr("href"); ok {
+		canonical = strings.TrimSpace(href)
+		if base != nil {
+			if ref, err := neturl.Parse(canonical); err == nil {
+				canonical = base.ResolveReference(ref).String()
+			}
+		}
+	}
+	
+	var insecure []string
+	if base != nil && base.Scheme == "https" {
+		seen := make(map[string]bool)
+		doc.Find("a[href], img[src], script[src], link[href], iframe[src]").Each(func(i int, s *goquery.Selection) {
+			ref := s.AttrOr("href", s.AttrOr("src", ""))
+			if strings.HasPrefix(strings.ToLower(ref), "http://") && !seen[ref] {
+				seen[ref] = true
+				insecure = append(insecure, ref)
+			}
+		})
+	}
+	
+	return canonical, insecure
+}
+
 func getHeadingLevel(tagName string) int {
 	switch tagName {
 	case "h1":
@@ -0,0 +1,55 @@
+package webpage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+// ErrSelectorNoMatch is returned when the content selector matches nothing
+// on a page.
+var ErrSelectorNoMatch = errors.New("content selector matches nothing on the page")
+
+// ValidateSelector reports whether selector is a valid CSS selector.
+func ValidateSelector(selector string) error {
+	if _, err := cascadia.ParseGroup(selector); err != nil {
+		return fmt.Errorf("invalid selector %q: %w", selector, err)
+	}
+	return nil
+}
+
+// SetSelector restricts the headings and content extracted by subsequent
+// fetches to the elements matching the CSS selector, e.g. "#docs-content",
+// so shared template chrome does not count towards the score. Meta tags,
+// structured data and other page-level signals are still read from the
+// whole page. An empty selector detects the main content area.
+func (s *Scraper) SetSelector(selector string) {
+	s.selector = selector
+}
+
+// extractScopedContent returns the text of the selected elements, in
+// document order, and records what extractContent records from them.
+func (s *Scraper) extractScopedContent(scope *goquery.Selection, pageData *PageData) string {
+	pageData.Definitions = extractDefinitions(scope)
+	pageData.CodeBlocks = extractCodeBlocks(scope)
+	pageData.Tables = extractTables(scope)
+	pageData.Lists = extractLists(scope)
+	pageData.Links = extractLinks(scope, pageData.URL)
+	pageData.Frames = extractFrames(scope, pageData.URL)
+
+	var content strings.Builder
+	scope.Find("h1, h2, h3, h4, h5, h6, p, li, td, th, blockquote, pre, dt, dd").Each(func(i int, s *goquery.Selection) {
+		if text := strings.TrimSpace(s.Text()); text != "" {
+			content.WriteString(text)
+			content.WriteString("\n\n")
+		}
+	})
+	// A region of bare text, e.g. a <div> without paragraphs
+	if content.Len() == 0 {
+		content.WriteString(strings.Join(strings.Fields(scope.Text()), " "))
+	}
+	return content.String()
+}
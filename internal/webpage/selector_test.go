@@ -0,0 +1,40 @@
+package webpage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSelectorScope(t *testing.T) {
+	html := `<html><head><title>Widgets</title><meta name="description" content="All about widgets"></head><body><main>
+<div class="promo"><h2>Sign up for our newsletter</h2><p>Weekly deals in your inbox.</p><ul><li>Deals</li></ul></div>
+<div id="docs-content"><h1>Installing widgets</h1><p>Run the installer.</p><a href="/setup">Setup</a></div>
+</main></body></html>`
+
+	s := New()
+	s.SetSelector("#docs-content")
+	page, err := s.ParseHTML(html, "https://example.com/docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Content != "Installing widgets\n\nRun the installer." {
+		t.Errorf("content %q, want only the selected region", page.Content)
+	}
+	if len(page.Headings) != 1 || page.Headings[0].Text != "Installing widgets" {
+		t.Errorf("headings %v, want only those of the region", page.Headings)
+	}
+	if len(page.Lists) != 0 || len(page.Links) != 1 {
+		t.Errorf("lists %v, links %v, want those of the region", page.Lists, page.Links)
+	}
+	if page.MetaTags["description"] != "All about widgets" {
+		t.Errorf("meta tags %v, want the page's", page.MetaTags)
+	}
+
+	s.SetSelector("#missing")
+	if _, err := s.ParseHTML(html, "https://example.com/docs"); !errors.Is(err, ErrSelectorNoMatch) {
+		t.Errorf("err = %v, want ErrSelectorNoMatch", err)
+	}
+	if err := ValidateSelector("div[["); err == nil {
+		t.Error("invalid selector accepted")
+	}
+}
@@ -0,0 +1,107 @@
+package webpage
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// maxShadowRoots caps the shadow roots flattened per page.
+const maxShadowRoots = 1000
+
+// flattenShadowRoots replaces the content of every web component that
+// declares an open shadow root (<template shadowrootmode="open">, or the
+// older shadowroot attribute) with what a browser shows: the shadow tree,
+// with each <slot> filled by the light-DOM children assigned to it, or by
+// its fallback content. Light-DOM children no slot takes are not shown and
+// are dropped. It returns the number of shadow roots flattened.
+func flattenShadowRoots(doc *goquery.Document) int {
+	flattened := 0
+	for ; flattened < maxShadowRoots; flattened++ {
+		tmpl := doc.Find(`template[shadowrootmode="open"], template[shadowroot="open"]`).First()
+		if tmpl.Length() == 0 {
+			break
+		}
+		attachShadowRoot(tmpl.Get(0))
+	}
+	return flattened
+}
+
+// attachShadowRoot composes the shadow tree of tmpl into its host.
+func attachShadowRoot(tmpl *html.Node) {
+	host := tmpl.Parent
+	if host == nil {
+		tmpl.Attr = nil
+		return
+	}
+
+	// Assign the light-DOM children to slots by their slot attribute
+	assigned := make(map[string][]*html.Node)
+	for _, child := range detachChildren(host) {
+		if child == tmpl {
+			continue
+		}
+		name := ""
+		if child.Type == html.ElementNode {
+			name = attr(child, "slot")
+		}
+		assigned[name] = append(assigned[name], child)
+	}
+
+	for _, child := range detachChildren(tmpl) {
+		host.AppendChild(child)
+	}
+	var slots []*html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "slot":
+				slots = append(slots, c)
+			case "template":
+				// Slots of nested shadow roots belong to their own hosts
+			default:
+				walk(c)
+			}
+		}
+	}
+	walk(host)
+
+	for _, slot := range slots {
+		// A node is assigned to the first slot of its name only
+		name := attr(slot, "name")
+		fill := assigned[name]
+		delete(assigned, name)
+		if len(fill) == 0 {
+			fill = detachChildren(slot)
+		}
+		for _, n := range fill {
+			slot.Parent.InsertBefore(n, slot)
+		}
+		slot.Parent.RemoveChild(slot)
+	}
+}
+
+// detachChildren removes the children of n and returns them in order.
+func detachChildren(n *html.Node) []*html.Node {
+	var children []*html.Node
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		n.RemoveChild(c)
+		children = append(children, c)
+		c = next
+	}
+	return children
+}
+
+// attr returns the value of an attribute of n, or "".
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
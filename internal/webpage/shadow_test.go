@@ -0,0 +1,32 @@
+package webpage
+
+import "testing"
+
+func TestShadowRootsFlattened(t *testing.T) {
+	html := `<html><body><main>
+<recipe-card>
+  <template shadowrootmode="open">
+    <h2><slot name="title">Untitled</slot></h2>
+    <p>Serves <slot name="servings">four</slot>.</p>
+    <slot></slot>
+    <info-note><template shadowroot="open"><p>Note: <slot></slot></p></template>Use a cold pan.</info-note>
+  </template>
+  <span slot="title">Pancakes</span>
+  <p>Whisk the eggs into the flour.</p>
+  <span slot="unused">Dropped</span>
+</recipe-card>
+<closed-card><template shadowrootmode="closed"><p>Closed content</p></template></closed-card>
+</main></body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Pancakes\n\nServes four.\n\nWhisk the eggs into the flour.\n\nNote: Use a cold pan.\n\nClosed content"
+	if page.Content != want {
+		t.Errorf("content %q, want %q", page.Content, want)
+	}
+	if len(page.Headings) != 1 || page.Headings[0].Text != "Pancakes" {
+		t.Errorf("headings %+v, want the slotted title", page.Headings)
+	}
+}
@@ -0,0 +1,205 @@
+package webpage
+
+import (
+	neturl "net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Structured data formats, as listed in PageData.SchemaFormats.
+const (
+	FormatJSONLD    = "json-ld"
+	FormatMicrodata = "microdata"
+	FormatRDFa      = "rdfa"
+)
+
+// extractStructuredData returns the page's top-level schema.org objects
+// from JSON-LD, microdata and RDFa, in that order, and the formats they
+// came in. Microdata and RDFa items are converted to the shape JSON-LD
+// decodes to: "@type" and "@id", properties holding strings, nested
+// objects or, when repeated, lists of them, and URLs made absolute.
+func extractStructuredData(doc *goquery.Document, base *neturl.URL) ([]map[string]any, []string) {
+	var objects []map[string]any
+	var formats []string
+	for _, source := range []struct {
+		format  string
+		extract func() []map[string]any
+	}{
+		{FormatJSONLD, func() []map[string]any { return extractJSONLD(doc) }},
+		{FormatMicrodata, func() []map[string]any { return extractMicrodata(doc, base) }},
+		{FormatRDFa, func() []map[string]any { return extractRDFa(doc, base) }},
+	} {
+		if found := source.extract(); len(found) > 0 {
+			objects = append(objects, found...)
+			formats = append(formats, source.format)
+		}
+	}
+	return objects, formats
+}
+
+// extractMicrodata converts every top-level schema.org microdata item, an
+// itemscope that is not itself the property of another item.
+func extractMicrodata(doc *goquery.Document, base *neturl.URL) []map[string]any {
+	var items []map[string]any
+	doc.Find(`[itemscope][itemtype*="schema.org/"]:not([itemprop])`).Each(func(i int, s *goquery.Selection) {
+		items = append(items, microdataItem(s, base))
+	})
+	return items
+}
+
+func microdataItem(s *goquery.Selection, base *neturl.URL) map[string]any {
+	item := make(map[string]any)
+	if types := schemaTypes(strings.Fields(s.AttrOr("itemtype", ""))); types != nil {
+		item["@type"] = types
+	}
+	if id := strings.TrimSpace(s.AttrOr("itemid", "")); id != "" {
+		item["@id"] = resolveURL(id, base)
+	}
+	walkProperties(s, "itemprop", "itemscope", item, func(p *goquery.Selection) any {
+		if _, nested := p.Attr("itemscope"); nested {
+			return microdataItem(p, base)
+		}
+		return propertyValue(p, base)
+	})
+	return item
+}
+
+// extractRDFa converts every top-level RDFa item in the schema.org
+// vocabulary, a typeof that is not itself the property of another item.
+func extractRDFa(doc *goquery.Document, base *neturl.URL) []map[string]any {
+	var items []map[string]any
+	doc.Find("[typeof]:not([property])").Each(func(i int, s *goquery.Selection) {
+		if item := rdfaItem(s, base); item != nil {
+			items = append(items, item)
+		}
+	})
+	return items
+}
+
+func rdfaItem(s *goquery.Selection, base *neturl.URL) map[string]any {
+	var names []string
+	vocab := inSchemaVocab(s)
+	for _, t := range strings.Fields(s.AttrOr("typeof", "")) {
+		if name, ok := schemaTerm(t, vocab); ok {
+			names = append(names, name)
+		}
+	}
+	types := schemaTypes(names)
+	if types == nil {
+		return nil
+	}
+	item := map[string]any{"@type": types}
+	if id := strings.TrimSpace(s.AttrOr("resource", s.AttrOr("about", ""))); id != "" {
+		item["@id"] = resolveURL(id, base)
+	}
+	walkProperties(s, "property", "typeof", item, func(p *goquery.Selection) any {
+		if _, nested := p.Attr("typeof"); nested {
+			if nestedItem := rdfaItem(p, base); nestedItem != nil {
+				return nestedItem
+			}
+		}
+		return propertyValue(p, base)
+	})
+	return item
+}
+
+// walkProperties adds to item the properties, named by the propAttr
+// attribute, of the elements under s, without descending into nested
+// items marked by scopeAttr, whose properties are their own.
+func walkProperties(s *goquery.Selection, propAttr, scopeAttr string, item map[string]any, value func(*goquery.Selection) any) {
+	vocab := inSchemaVocab(s)
+	s.Children().Each(func(i int, child *goquery.Selection) {
+		if props, ok := child.Attr(propAttr); ok {
+			v := value(child)
+			for _, prop := range strings.Fields(props) {
+				if name, ok := schemaTerm(prop, vocab || propAttr == "itemprop"); ok {
+					addProperty(item, name, v)
+				}
+			}
+		}
+		if _, nested := child.Attr(scopeAttr); !nested {
+			walkProperties(child, propAttr, scopeAttr, item, value)
+		}
+	})
+}
+
+// addProperty sets a property, turning it into a list when it repeats.
+func addProperty(item map[string]any, name string, value any) {
+	switch existing := item[name].(type) {
+	case nil:
+		item[name] = value
+	case []any:
+		item[name] = append(existing, value)
+	default:
+		item[name] = []any{existing, value}
+	}
+}
+
+// propertyValue is the value of a microdata or RDFa property element: its
+// content attribute, the URL of links and media, the datetime of <time>,
+// or its text.
+func propertyValue(s *goquery.Selection, base *neturl.URL) string {
+	if content, ok := s.Attr("content"); ok {
+		return strings.TrimSpace(content)
+	}
+	var attr string
+	switch goquery.NodeName(s) {
+	case "a", "area", "link":
+		attr = "href"
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		attr = "src"
+	case "object":
+		attr = "data"
+	case "data", "meter":
+		return strings.TrimSpace(s.AttrOr("value", ""))
+	case "time":
+		if datetime, ok := s.Attr("datetime"); ok {
+			return strings.TrimSpace(datetime)
+		}
+	}
+	if attr == "" {
+		attr = "resource"
+	}
+	if href, ok := s.Attr(attr); ok {
+		return resolveURL(href, base)
+	}
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
+
+// schemaTypes returns the schema.org type names of a list, as a string
+// for one and a list for several, the way JSON-LD gives them.
+func schemaTypes(types []string) any {
+	var names []any
+	for _, t := range types {
+		names = append(names, t[strings.LastIndexAny(t, "/#")+1:])
+	}
+	switch len(names) {
+	case 0:
+		return nil
+	case 1:
+		return names[0]
+	}
+	return names
+}
+
+// schemaTerm returns the schema.org name of an RDFa or microdata term:
+// "name", "schema:name" or "https://schema.org/name". Bare terms count only
+// in the schema.org vocabulary.
+func schemaTerm(term string, vocab bool) (string, bool) {
+	switch {
+	case strings.HasPrefix(term, "schema:"):
+		return strings.TrimPrefix(term, "schema:"), true
+	case strings.Contains(term, "schema.org/"):
+		return term[strings.LastIndexAny(term, "/#")+1:], true
+	case strings.Contains(term, ":"):
+		return "", false
+	}
+	return term, vocab
+}
+
+// inSchemaVocab reports whether s is inside a vocab="https://schema.org/".
+func inSchemaVocab(s *goquery.Selection) bool {
+	vocab := s.Closest("[vocab]").AttrOr("vocab", "")
+	return strings.Contains(vocab, "schema.org")
+}
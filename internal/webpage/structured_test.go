@@ -0,0 +1,46 @@
+package webpage
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestStructuredData(t *testing.T) {
+	html := `<html><head>
+<script type="application/ld+json">{"@context":"https://schema.org","@type":"Organization","name":"Acme"}</script>
+</head><body>
+<article itemscope itemtype="https://schema.org/Article" itemid="/guide">
+  <h1 itemprop="headline">Install guide</h1>
+  <p>By <span itemprop="author" itemscope itemtype="https://schema.org/Person"><a itemprop="url" href="/ann"><span itemprop="name">Ann Lee</span></a></span>
+  on <time itemprop="datePublished" datetime="2024-03-01">March 1</time></p>
+  <img itemprop="image" src="/img/cover.png">
+  <meta itemprop="keywords" content="install"><meta itemprop="keywords" content="setup">
+</article>
+<div vocab="https://schema.org/" typeof="Product">
+  <span property="name">Widget</span>
+  <div property="offers" typeof="Offer"><span property="price" content="9.99">$9.99</span><meta property="priceCurrency" content="USD"></div>
+  <span property="og:title">ignored</span>
+</div>
+<div typeof="foaf:Person"><span property="foaf:name">Not schema.org</span></div>
+</body></html>`
+
+	page, err := New().ParseHTML(html, "https://example.com/docs/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(page.SchemaFormats), "[json-ld microdata rdfa]"; got != want {
+		t.Errorf("formats = %s, want %s", got, want)
+	}
+	got, _ := json.Marshal(page.StructuredData)
+	want := `[{"@context":"https://schema.org","@type":"Organization","name":"Acme"},` +
+		`{"@id":"https://example.com/guide","@type":"Article","author":{"@type":"Person","name":"Ann Lee","url":"https://example.com/ann"},` +
+		`"datePublished":"2024-03-01","headline":"Install guide","image":"https://example.com/img/cover.png","keywords":["install","setup"]},` +
+		`{"@type":"Product","name":"Widget","offers":{"@type":"Offer","price":"9.99","priceCurrency":"USD"}}]`
+	if string(got) != want {
+		t.Errorf("structured data =\n%s\nwant\n%s", got, want)
+	}
+	if len(page.SchemaObjects("Article")) != 1 {
+		t.Errorf("SchemaObjects(Article) does not find the microdata article")
+	}
+}
@@ -0,0 +1,109 @@
+package webpage
+
+import (
+	"context"
+	"geo-checker/internal/netguard"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Transport tuning for high-volume runs. Every scraper in the process shares
+// one transport, so a 1000-URL audit keeps a warm pool of keep-alive
+// connections per host instead of dialing (and resolving) for every page.
+const (
+	maxIdleConns        = 256
+	maxIdleConnsPerHost = 64
+	idleConnTimeout     = 90 * time.Second
+	dialTimeout         = 10 * time.Second
+	keepAlive           = 30 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+	responseTimeout     = 20 * time.Second
+	dnsCacheTTL         = 5 * time.Minute
+)
+
+var sharedTransport = newTransport()
+
+func newTransport() *http.Transport {
+	dialer := &net.Dialer{Timeout: dialTimeout, KeepAlive: keepAlive}
+	cache := &dnsCache{resolver: net.DefaultResolver, ttl: dnsCacheTTL, entries: make(map[string]dnsEntry)}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// Guarded outside the cache so offline mode refuses hosts before
+	// resolving them
+	t.DialContext = netguard.Guard(cache.dialContext(dialer))
+	t.MaxIdleConns = maxIdleConns
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	t.IdleConnTimeout = idleConnTimeout
+	t.TLSHandshakeTimeout = tlsHandshakeTimeout
+	t.ResponseHeaderTimeout = responseTimeout
+	return t
+}
+
+// dnsCache remembers resolved addresses for ttl, so pages on the same host
+// resolve once per run rather than once per connection.
+type dnsCache struct {
+	resolver *net.Resolver
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+func (c *dnsCache) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		addrs, err := c.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var lastErr error = &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+		for _, ip := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+}
+
+// newPublicTransport is newTransport for services fetching URLs on behalf of
+// their clients: connections to addresses that are not public are refused,
+// except to the hosts in allow. The check runs on the address dialed, so it
+// bypasses the DNS cache, and proxies are ignored as they would dial for us.
+func newPublicTransport(allow []string) *http.Transport {
+	t := newTransport()
+	t.Proxy = nil
+	t.DialContext = netguard.Guard(netguard.PublicOnly(dialTimeout, allow))
+	return t
+}
@@ -0,0 +1,77 @@
+package webpage
+
+import (
+	"context"
+	"errors"
+	"geo-checker/internal/netguard"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScrapersReuseConnections(t *testing.T) {
+	var conns atomic.Int64
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("<main><p>ok</p></main>"))
+	}))
+	ts.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	for i := 0; i < 10; i++ {
+		// A fresh scraper per page, as crawler-parity checks create them
+		s := New()
+		if _, err := s.ScrapeURL(context.Background(), ts.URL+"/page"); err != nil {
+			t.Fatal(err)
+		}
+		s.ScrapeURL(context.Background(), ts.URL+"/missing")
+	}
+	if got := conns.Load(); got != 1 {
+		t.Errorf("opened %d connections for sequential requests, want 1", got)
+	}
+}
+
+func TestDNSCacheExpires(t *testing.T) {
+	c := &dnsCache{resolver: net.DefaultResolver, ttl: time.Hour, entries: map[string]dnsEntry{
+		"cached.test":     {addrs: []string{"127.0.0.1"}, expires: time.Now().Add(time.Hour)},
+		"expired.invalid": {addrs: []string{"127.0.0.1"}, expires: time.Now().Add(-time.Second)},
+	}}
+
+	addrs, err := c.lookup(context.Background(), "cached.test")
+	if err != nil || len(addrs) != 1 {
+		t.Errorf("cached lookup = %v, %v", addrs, err)
+	}
+	if _, err := c.lookup(context.Background(), "expired.invalid"); err == nil {
+		t.Error("expired entry was served from the cache")
+	}
+}
+
+func TestPublicOnlyRefusesLoopback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<main><p>internal</p></main>"))
+	}))
+	defer ts.Close()
+
+	s := New()
+	s.SetPublicOnly(nil)
+	if _, err := s.ScrapeURL(context.Background(), ts.URL); !errors.Is(err, netguard.ErrNotPublic) {
+		t.Errorf("fetching %s: %v, want ErrNotPublic", ts.URL, err)
+	}
+
+	s = New()
+	s.SetPublicOnly([]string{"127.0.0.1"})
+	if _, err := s.ScrapeURL(context.Background(), ts.URL); err != nil {
+		t.Errorf("allowed host refused: %v", err)
+	}
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"errors"
 	"geo-checker/cmd"
 	"log"
+	"os"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
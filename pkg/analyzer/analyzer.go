@@ -2,11 +2,15 @@ package analyzer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"geo-checker/internal/netguard"
 	"geo-checker/internal/webpage"
+	"geo-checker/pkg/archive"
 	"geo-checker/pkg/config"
 	"geo-checker/pkg/llm"
 	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/search"
 	"geo-checker/pkg/ui"
 	"io/ioutil"
 	"os"
@@ -17,6 +21,9 @@ import (
 	"time"
 )
 
+// Analyzer is safe for concurrent use once constructed: its configuration
+// is a private copy that is never modified after New, and the scraper,
+// scorer and provider keep no per-request state.
 type Analyzer struct {
 	config        *config.Config
 	provider      llm.Provider
@@ -25,6 +32,13 @@ type Analyzer struct {
 	ui            *ui.UI
 	initError     error // Store initialization errors for LLM mode
 	originalMode  string // Store original mode before auto-detection
+	autoProfile   bool   // pick the scoring profile per page
+	searcher      search.Searcher // nil unless the originality check is on
+	searchErr     error           // why searcher is nil when it should not be
+	framework     *scorer.Framework // nil unless configured
+	stats         *llm.Stats        // latency and outcome of provider requests
+	archive       *archive.Store    // nil unless fetched pages are archived
+	archiveErr    error             // why archive is nil when it should not be
 }
 
 type Result struct {
@@ -35,13 +49,32 @@ type Result struct {
 	Score         int               `json:"score"`
 	Suggestions   []string          `json:"suggestions"`
 	Metadata      map[string]any    `json:"metadata"`
-	ProcessedAt   time.Time         `json:"processed_at"`
+	ProcessedAt   time.Time         `json:"processed_at,omitzero"` // zero in deterministic runs
 	TokensUsed    int               `json:"tokens_used"`
 	Mode          string            `json:"mode"` // "local", "llm", or "hybrid"
+	Crawl         *webpage.CrawlInfo `json:"crawl,omitempty"`
+	Frames        []webpage.Frame    `json:"frames,omitempty"` // iframes in the content
+	PreviewImage  *webpage.PreviewImage `json:"preview_image,omitempty"` // og:image
+	HeroMedia     string             `json:"hero_media,omitempty"`
+	Manifest      *Manifest          `json:"manifest,omitempty"` // what produced the result
+	LLMFindings   []LLMFinding       `json:"llm_findings,omitempty"` // hybrid recommendations cross-checked locally
+	FactorComparison []FactorComparison `json:"factor_comparison,omitempty"` // local vs LLM score per factor
+	NeedsReview   bool               `json:"needs_review,omitempty"` // local and LLM scores of a factor far apart
+	Streamed      bool               `json:"-"` // the analysis was shown as it was generated
+	Archive       *archive.Record    `json:"archive,omitempty"` // the response scored, when archived
 }
 
 // loadSystemPrompt loads the system prompt from SYSTEM_PROMPT.md file
 func loadSystemPrompt() (string, error) {
+	// An explicit path wins, e.g. a mounted ConfigMap in a read-only container
+	if promptPath := os.Getenv("GEO_SYSTEM_PROMPT"); promptPath != "" {
+		content, err := ioutil.ReadFile(promptPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read system prompt from %s: %w", promptPath, err)
+		}
+		return string(content), nil
+	}
+	
 	// Get the executable directory to find SYSTEM_PROMPT.md relative to it
 	execDir, err := os.Executable()
 	if err != nil {
@@ -108,17 +141,76 @@ Focus area for maximizing citation potential in AI responses.
 CRITICAL: Start response with "Overall Score: [number]/100" for score extraction.`
 }
 
+// newScorer returns a local scorer for profile with the configured
+// settings.
+func (a *Analyzer) newScorer(profile scorer.Profile) *scorer.LocalScorer {
+	ls := scorer.NewLocalScorer()
+	ls.SetProfile(profile)
+	if a.config.StaleAfterDays > 0 {
+		ls.SetStaleAfter(time.Duration(a.config.StaleAfterDays) * 24 * time.Hour)
+	}
+	if grade, err := scorer.ParseReadingLevel(a.config.ReadingLevel); err == nil {
+		ls.SetReadingLevel(grade)
+	}
+	ls.SetFramework(a.framework)
+	return ls
+}
+
 func New(cfg *config.Config) *Analyzer {
+	// Resolve auto mode on a copy so the caller's config is never mutated
+	// while other goroutines may read it
+	copied := *cfg
+	cfg = &copied
+	if cfg.Deterministic {
+		cfg.Temperature = 0
+	}
+	
 	analyzer := &Analyzer{
 		config:      cfg,
 		scraper:     webpage.New(),
-		localScorer: scorer.NewLocalScorer(),
 		ui:          ui.New(),
+		stats:       llm.NewStats(),
+	}
+	profile, err := scorer.ParseProfile(cfg.Profile)
+	if err != nil {
+		profile = scorer.ProfileGeneral
+	}
+	analyzer.autoProfile = profile == scorer.ProfileAuto
+	if cfg.Framework != "" {
+		analyzer.framework, _ = scorer.LoadFramework(cfg.Framework)
+	}
+	if len(config.Weights) > 0 {
+		if framework, err := analyzer.framework.WithWeights(config.Weights); err == nil {
+			analyzer.framework = framework
+		}
+	}
+	analyzer.localScorer = analyzer.newScorer(profile)
+	analyzer.scraper.SetInlineFrames(cfg.InlineFrames)
+	analyzer.scraper.SetCheckImages(!cfg.SkipImageCheck && !netguard.Enabled())
+	analyzer.scraper.SetSelector(cfg.Selector)
+	if cfg.PublicOnly {
+		analyzer.scraper.SetPublicOnly(cfg.AllowedHosts)
+	}
+	if captures, err := webpage.ParseMetaCaptures(cfg.MetaCaptures); err == nil {
+		analyzer.scraper.SetMetaCaptures(captures)
+	}
+	if cfg.ArchiveDir != "" {
+		analyzer.archive, analyzer.archiveErr = archive.New(cfg.ArchiveDir, cfg.ArchiveFormat)
+		analyzer.scraper.SetKeepResponse(true)
+	}
+	if cfg.Originality != "" {
+		analyzer.searcher, analyzer.searchErr = search.New(cfg.Originality)
 	}
 
 	// Intelligent mode selection based on available API keys
 	originalMode := cfg.Mode
-	if cfg.Mode == "auto" || cfg.Mode == "" {
+	if (cfg.Mode == "auto" || cfg.Mode == "") && netguard.Enabled() {
+		// Offline, only a model on this machine can be used
+		cfg.Mode = "local"
+		if llm.IsLocal(cfg.LLMProvider) {
+			cfg.Mode = "hybrid"
+		}
+	} else if cfg.Mode == "auto" || cfg.Mode == "" {
 		cfg.Mode = determineOptimalMode(cfg.LLMProvider)
 		
 		// Auto-select provider if the specified one doesn't have a valid API key
@@ -127,11 +219,20 @@ func New(cfg *config.Config) *Analyzer {
 				cfg.LLMProvider = "openai"
 			} else if hasValidAPIKey("claude") {
 				cfg.LLMProvider = "claude"
+			} else if hasValidAPIKey("gemini") {
+				cfg.LLMProvider = "gemini"
 			}
 		}
 	}
 	analyzer.originalMode = originalMode
 	
+	// Offline, fail every analysis rather than fall back to local scores
+	// without saying so
+	if cfg.Mode != "local" && !llm.IsLocal(cfg.LLMProvider) && netguard.Enabled() {
+		analyzer.initError = fmt.Errorf("%w: the %s provider is not on this machine; use --provider local or --mode local", netguard.ErrOffline, cfg.LLMProvider)
+		return analyzer
+	}
+
 	// Only initialize LLM provider if not in local-only mode
 	if cfg.Mode != "local" {
 		providerConfig := &llm.ProviderConfig{
@@ -149,34 +250,76 @@ func New(cfg *config.Config) *Analyzer {
 				analyzer.initError = fmt.Errorf("failed to initialize LLM provider: %w", err)
 			} else {
 				// In hybrid mode, continue without LLM if initialization fails
-				fmt.Printf("Warning: LLM provider initialization failed, falling back to local-only mode: %v\n", err)
+				if !cfg.Quiet {
+					fmt.Printf("Warning: LLM provider initialization failed, falling back to local-only mode: %v\n", err)
+				}
 				cfg.Mode = "local"
 			}
 		} else {
+			provider = llm.NewMeteredProvider(provider, analyzer.stats)
+			if llm.AuditLogPath != "" {
+				// Below the cache: answers reused from it send nothing
+				provider = llm.NewAuditedProvider(provider, llm.AuditLogPath, cfg.Model)
+			}
 			analyzer.provider = provider
+			if cfg.Deterministic {
+				// Reuse earlier answers so the same content gets the same
+				// report; without a cache directory every call goes out
+				if dir, err := config.CacheDir(); err == nil {
+					analyzer.provider = llm.NewCachedProvider(provider, filepath.Join(dir, "llm"), cfg.Model, cfg.Temperature)
+				}
+			}
 		}
 	}
 	
 	return analyzer
 }
 
-func (a *Analyzer) AnalyzeURL(url string) (*Result, error) {
-	// Don't show animations for JSON output
-	showAnimations := a.config.OutputFormat != "json"
+// now returns the time results are stamped with: none in deterministic
+// runs, so that reports of unchanged content are identical.
+func (a *Analyzer) now() time.Time {
+	if a.config.Deterministic {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// ProviderHealth summarizes the requests made to the LLM provider so far:
+// latency percentiles, errors and retries. Answers reused from the
+// deterministic cache are not requests and are not counted.
+func (a *Analyzer) ProviderHealth() []llm.ProviderHealth {
+	return a.stats.Health()
+}
+
+// Mode returns the analysis mode in effect after auto-detection.
+func (a *Analyzer) Mode() string {
+	return a.config.Mode
+}
+
+// AnalyzeURL fetches and analyzes url. Cancelling ctx aborts the fetch and
+// any LLM call; each step also gets the configured timeout.
+func (a *Analyzer) AnalyzeURL(ctx context.Context, url string) (*Result, error) {
+	// Don't show animations or the success line for JSON output, or when
+	// quiet: analyses may run concurrently or stdout may carry a protocol
+	showAnimations := a.config.OutputFormat != "json" && !a.config.Quiet
 	
 	if showAnimations {
 		a.ui.StartSpinner("Fetching webpage content...")
 	}
 	
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(a.config.Timeout)*time.Second)
+	fetchCtx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
 	defer cancel()
 	
-	pageData, err := a.scraper.ScrapeURL(ctx, url)
+	pageData, err := a.scraper.ScrapeURL(fetchCtx, url)
 	if err != nil {
 		if showAnimations {
 			a.ui.StopSpinner()
 		}
-		return nil, fmt.Errorf("failed to scrape URL: %w", err)
+		category := CategoryFetch
+		if errors.Is(err, webpage.ErrSelectorNoMatch) {
+			category = CategoryExtract
+		}
+		return nil, NewError(category, fmt.Errorf("failed to scrape URL: %w", err))
 	}
 	
 	if showAnimations {
@@ -188,41 +331,78 @@ func (a *Analyzer) AnalyzeURL(url string) (*Result, error) {
 		if showAnimations {
 			a.ui.StopSpinner()
 		}
-		return nil, fmt.Errorf("no content could be extracted from the webpage - the page may be empty, require JavaScript, or have unusual structure")
+		return nil, NewError(CategoryExtract, fmt.Errorf("no content could be extracted from the webpage - the page may be empty, require JavaScript, or have unusual structure"))
 	}
 	
-	result, err := a.analyzePageData(pageData, url)
+	result, err := a.analyzePageData(ctx, pageData, url)
+	if err == nil {
+		result.Crawl = &pageData.Crawl
+		result.Frames = pageData.Frames
+		result.PreviewImage, result.HeroMedia = pageData.PreviewImage, pageData.HeroMedia
+		a.archiveResponse(result, url, pageData.Response)
+	}
+	if err == nil && a.config.CrawlerParity {
+		if showAnimations {
+			a.ui.UpdateSpinner("Checking crawler parity...")
+		}
+		a.applyCrawlerParity(fetchCtx, result, url)
+	}
 	
 	if showAnimations {
 		a.ui.StopSpinner()
-		if err == nil {
-			successMsg := a.formatSuccessMessage(result)
-			a.ui.PrintSuccess(successMsg)
-		}
+	}
+	if err == nil && showAnimations {
+		a.ui.PrintSuccess(a.formatSuccessMessage(result))
 	}
 	
 	return result, err
 }
 
-func (a *Analyzer) analyzePageData(pageData *webpage.PageData, source string) (*Result, error) {
+func (a *Analyzer) analyzePageData(ctx context.Context, pageData *webpage.PageData, source string) (*Result, error) {
 	result := &Result{
 		URL:         source,
 		Title:       pageData.Title,
-		ProcessedAt: time.Now(),
+		ProcessedAt: a.now(),
 		Mode:        a.config.Mode,
 		Metadata: map[string]any{
 			"content_size": len(pageData.Content),
+			"content_hash": ContentHash(pageData.Content),
 			"meta_tags":    pageData.MetaTags,
 			"headings":     pageData.Headings,
 		},
+		Manifest: a.Manifest(),
+	}
+	if len(pageData.Warnings) > 0 {
+		result.Metadata["html_warnings"] = pageData.Warnings
 	}
 
-	// Always calculate local score
-	localScore := a.localScorer.AnalyzeContent(pageData.Content, pageData)
+	// Always calculate local score, with the profile of the page's type
+	// when the profile is auto
+	localScorer, classifyTokens := a.localScorer, 0
+	if a.autoProfile {
+		var class scorer.Classification
+		class, localScorer, classifyTokens = a.classifyPage(ctx, pageData)
+		result.Metadata["page_type"] = string(class.Type)
+		result.Metadata["page_type_confidence"] = class.Confidence
+		result.Metadata["page_type_source"] = class.Source
+		if len(class.Signals) > 0 {
+			result.Metadata["page_type_signals"] = class.Signals
+		}
+	}
+	localScore := localScorer.AnalyzeContent(pageData.Content, pageData)
 	result.LocalScore = localScore
 	result.Score = localScore.Overall
 	result.Suggestions = localScore.Suggestions
 
+	// The LLM reads the content condensed when asked to, the local scorer
+	// always reads all of it
+	llmContent := pageData.Content
+	if a.config.Condense && (a.config.Mode == "llm" || a.config.Mode == "hybrid") {
+		var stats CondenseStats
+		llmContent, stats = condenseContent(pageData.Content, pageData)
+		result.Metadata["condensed"] = stats
+	}
+	
 	switch a.config.Mode {
 	case "local":
 		// Local-only mode - just use local scoring
@@ -237,21 +417,25 @@ func (a *Analyzer) analyzePageData(pageData *webpage.PageData, source string) (*
 	case "llm":
 		// LLM-only mode
 		if a.initError != nil {
-			return nil, a.initError
+			return nil, NewError(CategoryLLM, a.initError)
 		}
 		if a.provider == nil {
-			return nil, fmt.Errorf("LLM provider not available")
+			return nil, NewError(CategoryLLM, fmt.Errorf("LLM provider not available"))
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(a.config.Timeout)*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
 		defer cancel()
 		
-		response, err := a.provider.Analyze(ctx, pageData.Content, getGeoPrompt())
+		result.Manifest.PromptHash = promptHash(getGeoPrompt())
+		ctx, printer := a.streamTo(ctx, "")
+		response, err := a.provider.Analyze(ctx, llmContent, getGeoPrompt())
+		result.Streamed = printer.finish()
 		if err != nil {
-			return nil, fmt.Errorf("LLM analysis failed: %w", err)
+			return nil, NewError(CategoryLLM, fmt.Errorf("LLM analysis failed: %w", err))
 		}
 		
 		// Extract LLM score and average with local score
 		llmScore := extractScoreFromLLMResponse(response.Content)
+		applyFactorComparison(result, response.Content, localScore, llmScore)
 		if llmScore > 0 {
 			// Average local and LLM scores
 			result.Score = (localScore.Overall + llmScore) / 2
@@ -271,13 +455,20 @@ func (a *Analyzer) analyzePageData(pageData *webpage.PageData, source string) (*
 	case "hybrid":
 		// Hybrid mode - combine local scoring with LLM insights
 		result.Analysis = a.formatLocalAnalysis(localScore)
+		if a.initError != nil {
+			return nil, NewError(CategoryLLM, a.initError)
+		}
 		
 		if a.provider != nil {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(a.config.Timeout)*time.Second)
+			ctx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
 			defer cancel()
 			
-			hybridPrompt := a.createHybridPrompt(localScore, pageData.Content)
-			response, err := a.provider.Analyze(ctx, pageData.Content, hybridPrompt)
+			hybridPrompt := a.createHybridPrompt(localScore, llmContent)
+			result.Manifest.PromptHash = promptHash(hybridPrompt)
+			ctx, printer := a.streamTo(ctx, result.Analysis)
+			response, err := a.provider.Analyze(ctx, llmContent, hybridPrompt)
+			// A failed answer leaves the local analysis to the report
+			result.Streamed = printer.finish() && err == nil
 			if err == nil {
 				// Parse LLM score if available and average with local score
 				llmScore := extractScoreFromLLMResponse(response.Content)
@@ -288,8 +479,19 @@ func (a *Analyzer) analyzePageData(pageData *webpage.PageData, source string) (*
 					result.Metadata["llm_score"] = llmScore
 					result.Metadata["scoring_method"] = "hybrid_averaged"
 				}
+				applyFactorComparison(result, response.Content, localScore, llmScore)
 				
-				result.Analysis += "\n\n" + response.Content
+				answer, findings := crossCheckLLMFindings(response.Content, pageData, localScore)
+				result.Analysis += "\n\n" + answer
+				result.LLMFindings = findings
+				if result.Streamed && !a.config.Quiet {
+					// The answer went out before it was cross-checked
+					for _, f := range findings {
+						if f.Confidence == ConfidenceLow {
+							a.ui.PrintWarning(fmt.Sprintf("Low confidence: %s (%s)", f.Text, f.Reason))
+						}
+					}
+				}
 				result.TokensUsed = response.TokensUsed
 				result.Metadata["model"] = response.Model
 				result.Metadata["provider"] = a.provider.Name()
@@ -303,10 +505,33 @@ func (a *Analyzer) analyzePageData(pageData *webpage.PageData, source string) (*
 		}
 	}
 	
+	if a.config.FormattingDrafts {
+		a.draftFormatting(ctx, localScore, pageData.Content, result)
+	}
+	if a.config.AnswerDraft {
+		a.draftAnswer(ctx, localScore, pageData.Content, result)
+	}
+	if a.config.Framing {
+		a.checkFraming(ctx, pageData, result)
+	}
+	if a.config.Originality != "" {
+		a.checkOriginality(ctx, pageData, source, result)
+	}
+	result.TokensUsed += classifyTokens
+	
 	return result, nil
 }
 
-func (a *Analyzer) AnalyzeContent(content, title string) (*Result, error) {
+// AnalyzePage analyzes page data that was extracted by the caller, e.g. from
+// HTML delivered by a CMS API rather than fetched over HTTP.
+func (a *Analyzer) AnalyzePage(ctx context.Context, pageData *webpage.PageData, source string) (*Result, error) {
+	if strings.TrimSpace(pageData.Content) == "" {
+		return nil, NewError(CategoryExtract, fmt.Errorf("no content could be extracted from %s", source))
+	}
+	return a.analyzePageData(ctx, pageData, source)
+}
+
+func (a *Analyzer) AnalyzeContent(ctx context.Context, content, title string) (*Result, error) {
 	// Create a minimal PageData for local scoring
 	pageData := &webpage.PageData{
 		Title:    title,
@@ -315,7 +540,7 @@ func (a *Analyzer) AnalyzeContent(content, title string) (*Result, error) {
 		Headings: []webpage.Heading{},
 	}
 	
-	return a.analyzePageData(pageData, title)
+	return a.analyzePageData(ctx, pageData, title)
 }
 
 func (a *Analyzer) formatLocalAnalysis(score *scorer.GEOScore) string {
@@ -323,16 +548,20 @@ func (a *Analyzer) formatLocalAnalysis(score *scorer.GEOScore) string {
 	analysis += fmt.Sprintf("Overall Score: %d/100\n\n", score.Overall)
 	
 	analysis += "=== Score Breakdown ===\n"
-	analysis += fmt.Sprintf("Content Structure: %d/100 (%.1f%%)\n", 
-		score.Breakdown.ContentStructure.Score, score.Breakdown.ContentStructure.Percentage)
-	analysis += fmt.Sprintf("Semantic Clarity: %d/100 (%.1f%%)\n", 
-		score.Breakdown.SemanticClarity.Score, score.Breakdown.SemanticClarity.Percentage)
-	analysis += fmt.Sprintf("Context Richness: %d/100 (%.1f%%)\n", 
-		score.Breakdown.ContextRichness.Score, score.Breakdown.ContextRichness.Percentage)
-	analysis += fmt.Sprintf("Authority Signals: %d/100 (%.1f%%)\n", 
-		score.Breakdown.AuthoritySignals.Score, score.Breakdown.AuthoritySignals.Percentage)
-	analysis += fmt.Sprintf("Accessibility: %d/100 (%.1f%%)\n\n", 
-		score.Breakdown.Accessibility.Score, score.Breakdown.Accessibility.Percentage)
+	for _, key := range score.PillarKeys() {
+		detail, _ := score.Pillar(key)
+		analysis += fmt.Sprintf("%s: %d/100 (%.1f%%)\n", score.PillarName(key), detail.Score, detail.Percentage)
+	}
+	analysis += "\n"
+	
+	if score.Paywall != nil {
+		analysis += "=== Content Access ===\n"
+		analysis += score.Paywall.Narrative() + "\n"
+		for _, signal := range score.Paywall.Signals {
+			analysis += fmt.Sprintf("- %s\n", signal)
+		}
+		analysis += "\n"
+	}
 	
 	if len(score.Strengths) > 0 {
 		analysis += "=== Strengths ===\n"
@@ -345,7 +574,7 @@ func (a *Analyzer) formatLocalAnalysis(score *scorer.GEOScore) string {
 	if len(score.Suggestions) > 0 {
 		analysis += "=== Recommendations ===\n"
 		for i, suggestion := range score.Suggestions {
-			analysis += fmt.Sprintf("%d. %s\n", i+1, suggestion)
+			analysis += fmt.Sprintf("%d. %s\n", i+1, score.WithImpact(suggestion))
 		}
 		analysis += "\n"
 	}
@@ -363,6 +592,7 @@ Local Analysis Results:
 - Context Richness: %d/100
 - Authority Signals: %d/100
 - Accessibility: %d/100
+- Structured Data: %d/100
 
 Key Issues Identified:
 `, localScore.Overall, 
@@ -370,11 +600,15 @@ Key Issues Identified:
 	localScore.Breakdown.SemanticClarity.Score,
 	localScore.Breakdown.ContextRichness.Score,
 	localScore.Breakdown.AuthoritySignals.Score,
-	localScore.Breakdown.Accessibility.Score)
+	localScore.Breakdown.Accessibility.Score,
+	localScore.Breakdown.StructuredData.Score)
 
 	for _, suggestion := range localScore.Suggestions {
 		prompt += fmt.Sprintf("- %s\n", suggestion)
 	}
+	if localScore.Paywall != nil {
+		prompt += "\nContent Access:\n" + localScore.Paywall.Narrative() + " Judge the content on what is readable and separate access problems from content problems.\n"
+	}
 
 	prompt += `
 Please provide:
@@ -382,7 +616,15 @@ Please provide:
 2. Specific, actionable recommendations for improvement
 3. Examples of how to implement the suggestions
 4. Advanced GEO strategies not covered by local analysis
+5. Your own scores, judged on the content rather than taken from the local analysis: start with **Overall Score: X/100** and include this table
 
+| Factor | Score |
+|--------|-------|
+`
+	for _, key := range localScore.PillarKeys() {
+		prompt += fmt.Sprintf("| %s | X/100 |\n", localScore.PillarName(key))
+	}
+	prompt += `
 Focus on practical advice for optimizing content for AI understanding and reference.`
 
 	return prompt
@@ -394,6 +636,8 @@ func getAPIKey(provider string) string {
 		return os.Getenv("CLAUDE_API_KEY")
 	case "gpt", "openai":
 		return os.Getenv("OPENAI_API_KEY")
+	case "gemini":
+		return os.Getenv("GEMINI_API_KEY")
 	default:
 		return ""
 	}
@@ -412,7 +656,9 @@ func hasValidAPIKey(provider string) bool {
 		return strings.HasPrefix(apiKey, "sk-ant-")
 	case "gpt", "openai":
 		return strings.HasPrefix(apiKey, "sk-")
-	case "local":
+	case "gemini":
+		return strings.HasPrefix(apiKey, "AIza")
+	case "local", "ollama":
 		return true // Local doesn't require API key
 	default:
 		return false
@@ -427,7 +673,7 @@ func determineOptimalMode(provider string) string {
 	}
 	
 	// If specified provider doesn't have a key, check for any available API keys
-	availableProviders := []string{"openai", "claude"}
+	availableProviders := []string{"openai", "claude", "gemini"}
 	for _, p := range availableProviders {
 		if hasValidAPIKey(p) {
 			return "hybrid" // Use hybrid mode with any available provider
@@ -473,6 +719,7 @@ This analysis used **local rule-based scoring only**. For more accurate GEO opti
 1. **Set up an API key**:
    - **OpenAI**: ` + "`" + `export OPENAI_API_KEY="your-key"` + "`" + `
    - **Claude**: ` + "`" + `export CLAUDE_API_KEY="your-key"` + "`" + `
+   - **Gemini**: ` + "`" + `export GEMINI_API_KEY="your-key"` + "`" + `
 
 2. **Re-run with enhanced analysis**:
    - ` + "`" + `geo-checker analyze <url> --mode hybrid` + "`" + `
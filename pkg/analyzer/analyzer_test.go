@@ -0,0 +1,478 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/llm"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/search"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+type countingProvider struct {
+	calls atomic.Int64
+}
+
+func (p *countingProvider) Analyze(ctx context.Context, content, prompt string) (*llm.Response, error) {
+	p.calls.Add(1)
+	return &llm.Response{Content: "Overall Score: 80/100", TokensUsed: 10, Model: "fake"}, nil
+}
+
+func (p *countingProvider) Name() string { return "fake" }
+
+// Run with -race: a single analyzer must be shareable between workers the
+// way bulk and the server use it.
+func TestAnalyzerConcurrentUse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<html><head><title>Page %s</title></head><body><main><h1>Heading</h1><p>Body text for %s with enough words to be scored as a paragraph by the local scorer.</p></main></body></html>`, r.URL.Path, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	cfg := &config.Config{Mode: "local", OutputFormat: "json", MaxTokens: 4000, Temperature: 0.7, Timeout: 30}
+	a := New(cfg)
+	provider := &countingProvider{}
+	a.provider = provider
+	a.config.Mode = "hybrid"
+
+	const workers = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := a.AnalyzeURL(context.Background(), fmt.Sprintf("%s/page-%d", ts.URL, i))
+			if err != nil {
+				errs <- err
+				return
+			}
+			if want := fmt.Sprintf("Page /page-%d", i); result.Title != want {
+				errs <- fmt.Errorf("result for worker %d has title %q", i, result.Title)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if got := provider.calls.Load(); got != workers {
+		t.Errorf("provider called %d times, want %d", got, workers)
+	}
+}
+
+// Quiet analyzers may share stdout with a protocol (serve --stdio), so
+// nothing may reach it even with the text output format.
+func TestQuietWritesNothing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><title>Widgets</title></head><body><main><h1>Widgets</h1><p>Widgets are small mechanical parts.</p></main></body></html>`)
+	}))
+	defer ts.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, colorOut := os.Stdout, color.Output
+	os.Stdout, color.Output = w, w
+	defer func() { os.Stdout, color.Output = stdout, colorOut }()
+	captured := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		captured <- string(data)
+	}()
+
+	// An unknown provider in hybrid mode falls back to local scores, which
+	// is normally announced with a warning
+	a := New(&config.Config{Mode: "hybrid", LLMProvider: "unknown", OutputFormat: "text", Quiet: true, Timeout: 10})
+	_, urlErr := a.AnalyzeURL(context.Background(), ts.URL)
+	_, contentErr := a.AnalyzeContent(context.Background(), "Widgets are small mechanical parts.", "Widgets")
+	w.Close()
+	if out := <-captured; out != "" {
+		t.Errorf("quiet analyzer wrote to stdout:\n%s", out)
+	}
+	if urlErr != nil || contentErr != nil {
+		t.Fatalf("AnalyzeURL: %v, AnalyzeContent: %v", urlErr, contentErr)
+	}
+}
+
+func TestNewDoesNotMutateConfig(t *testing.T) {
+	t.Setenv("CLAUDE_API_KEY", "")
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("GEMINI_API_KEY", "")
+	cfg := &config.Config{Mode: "auto", LLMProvider: "claude", OutputFormat: "json"}
+	a := New(cfg)
+	if cfg.Mode != "auto" || a.Mode() != "local" {
+		t.Errorf("cfg.Mode = %q, analyzer mode = %q", cfg.Mode, a.Mode())
+	}
+}
+
+func TestFormattingDrafts(t *testing.T) {
+	cfg := &config.Config{Mode: "local", OutputFormat: "json", Timeout: 30, FormattingDrafts: true}
+	a := New(cfg)
+	provider := &countingProvider{}
+	a.provider = provider
+
+	content := "Price: $49 per month. Storage: 2 TB. Support: Email and chat."
+	result, err := a.AnalyzeContent(context.Background(), content, "Plans")
+	if err != nil {
+		t.Fatal(err)
+	}
+	advice := result.LocalScore.Formatting
+	if len(advice) != 1 || advice[0].Draft == "" {
+		t.Fatalf("formatting advice = %+v, want one drafted table", advice)
+	}
+	if provider.calls.Load() != 1 || result.TokensUsed != 10 {
+		t.Errorf("%d LLM calls and %d tokens, want 1 and 10", provider.calls.Load(), result.TokensUsed)
+	}
+}
+
+type classifyingProvider struct{}
+
+func (classifyingProvider) Analyze(ctx context.Context, content, prompt string) (*llm.Response, error) {
+	return &llm.Response{Content: "docs.", TokensUsed: 7, Model: "fake"}, nil
+}
+
+func (classifyingProvider) Name() string { return "fake" }
+
+func TestAutoProfile(t *testing.T) {
+	cfg := &config.Config{Mode: "local", OutputFormat: "json", Timeout: 30, Profile: "auto"}
+	a := New(cfg)
+	result, err := a.AnalyzeContent(context.Background(), "A short note without much to go on.", "Note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Metadata["page_type"] != "article" || result.Metadata["page_type_source"] != "heuristics" {
+		t.Errorf("metadata = %v, want an article from heuristics", result.Metadata)
+	}
+
+	// Unsure heuristics defer to the LLM when asked to
+	a.config.ClassifyWithLLM = true
+	a.provider = classifyingProvider{}
+	result, err = a.AnalyzeContent(context.Background(), "A short note without much to go on.", "Note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Metadata["page_type"] != "docs" || result.Metadata["page_type_source"] != "llm" {
+		t.Errorf("metadata = %v, want docs from the LLM", result.Metadata)
+	}
+	if result.LocalScore.Metadata["profile"] != "docs" || result.TokensUsed != 7 {
+		t.Errorf("profile %v with %d tokens, want docs with 7", result.LocalScore.Metadata["profile"], result.TokensUsed)
+	}
+	if a.localScorer.Profile() != scorer.ProfileAuto {
+		t.Errorf("shared scorer switched to %s", a.localScorer.Profile())
+	}
+}
+
+func TestClassifyContentIsCutOnRunes(t *testing.T) {
+	a := New(&config.Config{Mode: "local", OutputFormat: "json", Timeout: 30, Profile: "auto", ClassifyWithLLM: true})
+	provider := &recordingProvider{}
+	a.provider = provider
+
+	// One byte of ASCII puts every two-byte rune across the cut
+	content := "A" + strings.Repeat("é", maxClassifyContent)
+	if _, err := a.AnalyzeContent(context.Background(), content, "Note"); err != nil {
+		t.Fatal(err)
+	}
+	if provider.content == "" || !utf8.ValidString(provider.content) {
+		t.Errorf("classification content is not valid UTF-8: ...%q", provider.content[max(0, len(provider.content)-4):])
+	}
+}
+
+type framingProvider struct{}
+
+func (framingProvider) Analyze(ctx context.Context, content, prompt string) (*llm.Response, error) {
+	return &llm.Response{Content: "CONSENSUS: no\nPOSITION: yes\nJUSTIFIED: yes\nCLAIM: \"Raw milk is always safe to drink.\" | unattributed", TokensUsed: 12, Model: "fake"}, nil
+}
+
+func (framingProvider) Name() string { return "fake" }
+
+func TestFraming(t *testing.T) {
+	cfg := &config.Config{Mode: "local", OutputFormat: "json", Timeout: 30, Framing: true}
+	a := New(cfg)
+	a.provider = framingProvider{}
+
+	content := "Raw milk\n\nRaw milk is always safe to drink. We drink it every day because our farm tests each batch."
+	result, err := a.AnalyzeContent(context.Background(), content, "Raw milk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	framing, ok := result.Metadata["framing"].(*Framing)
+	if !ok || framing.ConsensusStated || !framing.PositionStated || len(framing.Claims) != 1 {
+		t.Fatalf("framing = %+v", result.Metadata["framing"])
+	}
+	if ev := framing.Claims[0].Evidence; ev.Start != strings.Index(content, "Raw milk is always") {
+		t.Errorf("claim evidence = %+v, want it located in the content", ev)
+	}
+	found := false
+	for _, f := range result.LocalScore.Findings {
+		found = found || f.ID == "authority.framing"
+	}
+	if !found || result.TokensUsed != 12 {
+		t.Errorf("framing finding raised: %v, tokens %d", found, result.TokensUsed)
+	}
+}
+
+// copyingSearcher finds sentences mentioning widgets on another site.
+type copyingSearcher struct {
+	queries []string
+}
+
+func (s *copyingSearcher) Search(ctx context.Context, query string) ([]search.Result, error) {
+	s.queries = append(s.queries, query)
+	if strings.Contains(query, "widget") {
+		return []search.Result{{URL: "https://www.example.com/widgets"}, {URL: "https://copycat.example/widgets"}}, nil
+	}
+	return []search.Result{{URL: "https://example.com/other"}}, nil
+}
+
+func (s *copyingSearcher) Name() string { return "fake" }
+
+func TestOriginality(t *testing.T) {
+	a := New(&config.Config{Mode: "local", OutputFormat: "json", Timeout: 30, Originality: "fake", OriginalitySamples: 3})
+	searcher := &copyingSearcher{}
+	a.searcher = searcher
+
+	content := "Widgets\n\n" +
+		"Our widget factory in Leeds has produced 40,000 brass widgets for Victorian restorations since 1998. " +
+		"Every widget is machined by hand and tested against the British Standard 4391 before it leaves the workshop. " +
+		"Short sentences are skipped. " +
+		"Customers who restore old furniture often ask us how to pick the right finish for their hinges and handles."
+	pageData := &webpage.PageData{Title: "Widgets", Content: content, MetaTags: map[string]string{}}
+	result, err := a.AnalyzePage(context.Background(), pageData, "https://example.com/widgets")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(searcher.queries) != 3 || !strings.HasPrefix(searcher.queries[0], `"Our widget factory`) {
+		t.Errorf("queries = %q, want the 3 long sentences quoted", searcher.queries)
+	}
+	originality, ok := result.Metadata["originality"].(*Originality)
+	if !ok || originality.Sampled != 3 || originality.Duplicated != 2 || originality.Risk != 67 {
+		t.Fatalf("originality = %+v", result.Metadata["originality"])
+	}
+	if urls := originality.Matches[0].URLs; len(urls) != 1 || urls[0] != "https://copycat.example/widgets" {
+		t.Errorf("matched URLs = %q, want the page's own site left out", urls)
+	}
+	var finding *scorer.Finding
+	for i, f := range result.LocalScore.Findings {
+		if f.ID == "authority.originality" {
+			finding = &result.LocalScore.Findings[i]
+		}
+	}
+	if finding == nil || finding.Severity != scorer.SeverityHigh || len(finding.Evidence) != 2 {
+		t.Errorf("originality finding = %+v", finding)
+	}
+}
+
+func TestDistinctiveSentencesAreWhole(t *testing.T) {
+	content := "Brass hinges, e.g. the Hampton range, cost approx. 2.5 times as much as steel ones but last for decades. " +
+		"Dr. Okafor tested 300 hinges from the U.S. and Europe over four winters in an unheated barn near Shrewsbury."
+	want := []string{
+		"Brass hinges, e.g. the Hampton range, cost approx. 2.5 times as much as steel ones but last for decades.",
+		"Dr. Okafor tested 300 hinges from the U.S. and Europe over four winters in an unheated barn near Shrewsbury.",
+	}
+	if got := distinctiveSentences(content, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("distinctiveSentences = %q, want %q", got, want)
+	}
+}
+
+func TestManifest(t *testing.T) {
+	t.Setenv("CLAUDE_API_KEY", "sk-ant-test")
+	model := llm.GetRecommendedModel("claude")
+	cfg := &config.Config{Mode: "hybrid", LLMProvider: "claude", Model: model, OutputFormat: "json", Timeout: 30, Profile: "docs", ReadingLevel: "expert"}
+	a := New(cfg)
+	a.provider = &countingProvider{}
+
+	result, err := a.AnalyzeContent(context.Background(), "Install\n\nRun the installer and restart the shell.", "Install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := result.Manifest
+	if m == nil || m.ScorerVersion != scorer.Version || m.ToolVersion == "" || m.Provider != "claude" || m.Model != model || len(m.PromptHash) != 16 {
+		t.Fatalf("manifest = %+v", m)
+	}
+	if m.Config.Mode != "hybrid" || m.Config.Profile != "docs" || m.Config.ReadingLevel != "expert" || m.Config.Timeout != 30 {
+		t.Errorf("config = %+v", m.Config)
+	}
+
+	local, err := New(&config.Config{Mode: "local", Timeout: 30}).AnalyzeContent(context.Background(), "Install\n\nRun it.", "Install")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := local.Manifest; m.Provider != "" || m.PromptHash != "" || m.Config.Profile != "general" {
+		t.Errorf("local manifest = %+v, want no LLM settings", m)
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	t.Setenv("CLAUDE_API_KEY", "sk-ant-test")
+	dir := t.TempDir()
+	t.Setenv("GEO_CACHE_DIR", dir)
+	model := llm.GetRecommendedModel("claude")
+	inner := &countingProvider{}
+
+	run := func() string {
+		cfg := &config.Config{Mode: "hybrid", LLMProvider: "claude", Model: model, OutputFormat: "json", Temperature: 0.7, Timeout: 30, Deterministic: true}
+		a := New(cfg)
+		if a.config.Temperature != 0 {
+			t.Errorf("temperature = %g, want 0", a.config.Temperature)
+		}
+		a.provider = llm.NewCachedProvider(inner, filepath.Join(dir, "llm"), model, a.config.Temperature)
+		result, err := a.AnalyzeContent(context.Background(), "Install\n\nRun the installer and restart the shell.", "Install")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.ProcessedAt.IsZero() {
+			t.Errorf("processed_at = %v, want none", result.ProcessedAt)
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	first, second := run(), run()
+	if first != second {
+		t.Errorf("reports differ:\n%s\n%s", first, second)
+	}
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("%d LLM calls, want 1", calls)
+	}
+}
+
+func TestCrossCheckLLMFindings(t *testing.T) {
+	page := &webpage.PageData{
+		MetaTags: map[string]string{"description": "How to install the tool"},
+		Headings: make([]webpage.Heading, 12),
+	}
+	answer := "Overall Score: 60/100\n\n### Key Recommendations\n" +
+		"- **High Impact**: Add more headings to break up the content\n" +
+		"- **Quick Win**: Add a meta description\n" +
+		"- **Long-term**: Include a comparison table of plans\n" +
+		"- Write shorter sentences"
+
+	tagged, findings := crossCheckLLMFindings(answer, page, &scorer.GEOScore{})
+	want := []string{ConfidenceLow, ConfidenceLow, ConfidenceHigh, ConfidenceUnverified}
+	if len(findings) != len(want) {
+		t.Fatalf("%d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+	for i, f := range findings {
+		if f.Confidence != want[i] {
+			t.Errorf("finding %q has confidence %q, want %q", f.Text, f.Confidence, want[i])
+		}
+	}
+	if !strings.Contains(tagged, "break up the content _(low confidence: page has 12 headings)_") {
+		t.Errorf("headings advice not tagged:\n%s", tagged)
+	}
+	if strings.Contains(tagged, "plans _(") {
+		t.Errorf("corroborated advice tagged:\n%s", tagged)
+	}
+}
+
+func TestFactorComparison(t *testing.T) {
+	local := &scorer.GEOScore{Overall: 70}
+	local.Breakdown.ContentStructure = scorer.ScoreDetail{Score: 80}
+	local.Breakdown.SemanticClarity = scorer.ScoreDetail{Score: 40}
+	answer := "**Overall Score: 60/100**\n\n" +
+		"The local analysis gave Authority Signals 10, which seems fair.\n\n" +
+		"| Factor | Score |\n|--------|-------|\n" +
+		"| Content Structure | 75/100 |\n" +
+		"| **Semantic Clarity** | **85** |\n"
+
+	result := &Result{}
+	applyFactorComparison(result, answer, local, 60)
+	got := make(map[string]FactorComparison)
+	for _, c := range result.FactorComparison {
+		got[c.Factor] = c
+	}
+	if len(got) != 3 {
+		t.Fatalf("compared %d factors, want overall and the two in the table: %+v", len(got), result.FactorComparison)
+	}
+	if c := got["overall"]; c.Local != 70 || c.LLM != 60 || c.Difference != -10 || c.NeedsReview {
+		t.Errorf("overall = %+v", c)
+	}
+	if c := got["content_structure"]; c.LLM != 75 || c.NeedsReview {
+		t.Errorf("content_structure = %+v", c)
+	}
+	if c := got["semantic_clarity"]; c.LLM != 85 || c.Difference != 45 || !c.NeedsReview {
+		t.Errorf("semantic_clarity = %+v", c)
+	}
+	if !result.NeedsReview {
+		t.Error("result not flagged for review")
+	}
+
+	result = &Result{}
+	applyFactorComparison(result, "Overall Score: 30/100", local, 30)
+	if result.FactorComparison != nil || result.NeedsReview {
+		t.Errorf("answer without a factor table compared: %+v", result)
+	}
+}
+
+type recordingProvider struct {
+	content string
+}
+
+func (p *recordingProvider) Analyze(ctx context.Context, content, prompt string) (*llm.Response, error) {
+	p.content = content
+	return &llm.Response{Content: "Overall Score: 70/100", TokensUsed: 10, Model: "fake"}, nil
+}
+
+func (p *recordingProvider) Name() string { return "fake" }
+
+func TestCondense(t *testing.T) {
+	var rows strings.Builder
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(&rows, "<tr><td>Plan %d</td><td>$%d</td></tr>", i, i*5)
+	}
+	html := `<html><body><main><p>Home &gt; Pricing &gt; Plans</p><h1>Plans</h1><p>Share this   page on social</p>
+<p>Every plan includes    support and a   30-day trial.</p>
+<table><thead><tr><th>Plan</th><th>Price</th></tr></thead>` + rows.String() + `</table>
+<p>Share this page on social</p></main></body></html>`
+	page, err := webpage.New().ParseHTML(html, "https://example.com/plans")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condensed, stats := condenseContent(page.Content, page)
+	for _, want := range []string{"Every plan includes support and a 30-day trial.", "Plan | Price\nPlan 1 | $5", "Plan 5 | $25\n[25 more rows of this 30-row table omitted]"} {
+		if !strings.Contains(condensed, want) {
+			t.Errorf("condensed content lacks %q:\n%s", want, condensed)
+		}
+	}
+	if strings.Contains(condensed, "Plan 6") || strings.Contains(condensed, "Home > Pricing") || strings.Count(condensed, "Share this page") != 1 {
+		t.Errorf("condensed content kept omitted rows, the breadcrumb or the repeated share bar:\n%s", condensed)
+	}
+	if stats.TablesSummarized != 1 || stats.RepeatedBlocks != 2 || stats.ReductionPercent < 30 {
+		t.Errorf("stats %+v, want one table, two blocks and at least 30%% less", stats)
+	}
+
+	// The LLM gets the condensed content, the local scorer all of it
+	provider := &recordingProvider{}
+	a := New(&config.Config{Mode: "local", OutputFormat: "json", Timeout: 30, Condense: true, Quiet: true})
+	a.provider = provider
+	a.config.Mode = "hybrid"
+	result, err := a.AnalyzePage(context.Background(), page, page.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.content != condensed || result.Metadata["condensed"] == nil || !result.Manifest.Config.Condense {
+		t.Errorf("LLM got %d characters, metadata %v, want the %d condensed ones", len(provider.content), result.Metadata["condensed"], len(condensed))
+	}
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"strings"
+	"time"
+)
+
+// archiveResponse keeps the response result was scored from in the
+// configured archive. Archiving failures never fail the analysis; they
+// are recorded in the metadata.
+func (a *Analyzer) archiveResponse(result *Result, url string, resp *webpage.Response) {
+	if a.archive == nil {
+		if a.archiveErr != nil {
+			result.Metadata["archive_error"] = a.archiveErr.Error()
+		}
+		return
+	}
+	if resp == nil {
+		return
+	}
+	record, err := a.archive.Save(url, resp)
+	if err != nil {
+		result.Metadata["archive_error"] = err.Error()
+		return
+	}
+	result.Archive = record
+}
+
+// AnalyzeArchived analyzes url from a response archived when it was
+// fetched earlier, to score the page as it was served then, e.g. after the
+// live page changed or with a newer version of the scoring. Frames and the
+// preview image are still looked into live when the analyzer is set to.
+func (a *Analyzer) AnalyzeArchived(ctx context.Context, url string, resp *webpage.Response) (*Result, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
+	defer cancel()
+
+	pageData, err := a.scraper.ScrapeResponse(fetchCtx, url, resp)
+	if err != nil {
+		category := CategoryExtract
+		if !errors.Is(err, webpage.ErrSelectorNoMatch) {
+			err = fmt.Errorf("failed to read archived response: %w", err)
+		}
+		return nil, NewError(category, err)
+	}
+	if strings.TrimSpace(pageData.Content) == "" {
+		return nil, NewError(CategoryExtract, fmt.Errorf("no content could be extracted from the archived response"))
+	}
+
+	result, err := a.analyzePageData(ctx, pageData, url)
+	if err != nil {
+		return nil, err
+	}
+	result.Crawl = &pageData.Crawl
+	result.Frames = pageData.Frames
+	result.PreviewImage, result.HeroMedia = pageData.PreviewImage, pageData.HeroMedia
+	result.Metadata["archived_at"] = resp.FetchedAt
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"context"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// llmClassifyBelow is the heuristic confidence under which ClassifyWithLLM
+// asks the LLM for the page type.
+const llmClassifyBelow = 0.5
+
+// maxClassifyContent caps the content sent to the LLM for classification.
+const maxClassifyContent = 4000
+
+// classifyPage detects the page type for the auto profile and returns a
+// scorer for its profile. Pages the heuristics are unsure about are
+// settled by the LLM when ClassifyWithLLM is set; LLM failures keep the
+// heuristic result. The tokens the LLM used are returned.
+func (a *Analyzer) classifyPage(ctx context.Context, pageData *webpage.PageData) (scorer.Classification, *scorer.LocalScorer, int) {
+	class := scorer.ClassifyPage(pageData)
+	tokens := 0
+	if a.config.ClassifyWithLLM && a.provider != nil && class.Confidence < llmClassifyBelow {
+		content := pageData.Content
+		if len(content) > maxClassifyContent {
+			cut := maxClassifyContent
+			for cut > 0 && !utf8.RuneStart(content[cut]) {
+				cut-- // never split a multi-byte character
+			}
+			content = content[:cut]
+		}
+		classifyCtx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
+		response, err := a.provider.Analyze(classifyCtx, pageData.Title+"\n\n"+content, classifyPrompt())
+		cancel()
+		if err == nil {
+			tokens = response.TokensUsed
+			if t, err := scorer.ParsePageType(strings.Trim(strings.TrimSpace(response.Content), ".`*\"")); err == nil {
+				class = scorer.Classification{Type: t, Confidence: 1, Source: "llm", Signals: class.Signals}
+			}
+		}
+	}
+
+	// A scorer per page: the shared one must not change under other workers
+	return class, a.newScorer(class.Type.Profile()), tokens
+}
+
+func classifyPrompt() string {
+	types := make([]string, len(scorer.PageTypes))
+	for i, t := range scorer.PageTypes {
+		types[i] = string(t)
+	}
+	return `Classify the following web page by its type. Answer with exactly one of: ` + strings.Join(types, ", ") + `.
+Reply with the type only, without explanation.`
+}
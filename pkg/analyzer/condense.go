@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"regexp"
+	"strings"
+)
+
+const (
+	// condenseTableRows is the number of rows from which a table is
+	// summarized, condenseKeptRows the rows the summary keeps and
+	// maxCellChars the longest block taken for one of its cells.
+	condenseTableRows = 10
+	condenseKeptRows  = 5
+	maxCellChars      = 300
+
+	// repeatedBlockMinWords and repeatedBlockMaxWords bound the blocks
+	// that are dropped when they repeat: menus, share bars and "Skip to
+	// content" links, not short table cells or long paragraphs quoted
+	// twice on purpose.
+	repeatedBlockMinWords = 2
+	repeatedBlockMaxWords = 15
+)
+
+var (
+	horizontalSpace = regexp.MustCompile(`[ \t\f\v\x{00a0}]+`)
+
+	// breadcrumbBlock matches a breadcrumb trail written as text, e.g.
+	// "Home > Docs > Install".
+	breadcrumbBlock = regexp.MustCompile(`^[^>/›»\n]{1,40}(?:\s+[>/›»]\s+[^>/›»\n]{1,40}){2,}$`)
+)
+
+// CondenseStats is what condensing removed from the content sent to the
+// LLM.
+type CondenseStats struct {
+	OriginalChars    int `json:"original_chars"`
+	CondensedChars   int `json:"condensed_chars"`
+	ReductionPercent int `json:"reduction_percent"`
+	TokensSaved      int `json:"tokens_saved"` // estimated, at 4 characters a token
+	RepeatedBlocks   int `json:"repeated_blocks,omitempty"`
+	TablesSummarized int `json:"tables_summarized,omitempty"`
+}
+
+// condenseContent prepares content for the LLM: it compresses whitespace,
+// drops breadcrumb trails and short blocks repeated from earlier in the
+// page, and replaces the cells of tables longer than condenseTableRows
+// rows with their first rows and a note of how many were left out. The
+// local scorer still sees the full content.
+func condenseContent(content string, page *webpage.PageData) (string, CondenseStats) {
+	stats := CondenseStats{OriginalChars: len(content)}
+
+	var blocks []string
+	for _, block := range strings.Split(content, "\n\n") {
+		var lines []string
+		for _, line := range strings.Split(block, "\n") {
+			if line = strings.TrimSpace(horizontalSpace.ReplaceAllString(line, " ")); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		}
+	}
+
+	if page != nil {
+		for _, table := range page.Tables {
+			var summarized bool
+			if blocks, summarized = summarizeTable(blocks, table); summarized {
+				stats.TablesSummarized++
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	kept := blocks[:0]
+	for _, block := range blocks {
+		words := len(strings.Fields(block))
+		key := strings.ToLower(block)
+		if words >= repeatedBlockMinWords && words <= repeatedBlockMaxWords {
+			if seen[key] {
+				stats.RepeatedBlocks++
+				continue
+			}
+			seen[key] = true
+		}
+		if breadcrumbBlock.MatchString(block) {
+			stats.RepeatedBlocks++
+			continue
+		}
+		kept = append(kept, block)
+	}
+
+	condensed := strings.Join(kept, "\n\n")
+	stats.CondensedChars = len(condensed)
+	if stats.OriginalChars > 0 {
+		stats.ReductionPercent = (stats.OriginalChars - stats.CondensedChars) * 100 / stats.OriginalChars
+	}
+	stats.TokensSaved = (stats.OriginalChars - stats.CondensedChars) / 4
+	return condensed, stats
+}
+
+// summarizeTable finds the cells of a headed table with more than
+// condenseTableRows rows among blocks, where extraction lists each cell as
+// a block after the header cells, and replaces them with the header and
+// first rows as pipe-separated lines and a note of the rows left out.
+func summarizeTable(blocks []string, table webpage.Table) ([]string, bool) {
+	columns := len(table.Headers)
+	if columns == 0 || table.Rows <= condenseTableRows || table.Columns != columns {
+		return blocks, false
+	}
+	cells := columns * (table.Rows + 1)
+	for start := 0; start+cells <= len(blocks); start++ {
+		if !headerAt(blocks, start, table.Headers) || !cellsAt(blocks[start:start+cells]) {
+			continue
+		}
+		lines := []string{strings.Join(table.Headers, " | ")}
+		for row := 1; row <= condenseKeptRows; row++ {
+			lines = append(lines, strings.Join(blocks[start+row*columns:start+(row+1)*columns], " | "))
+		}
+		lines = append(lines, fmt.Sprintf("[%d more rows of this %d-row table omitted]", table.Rows-condenseKeptRows, table.Rows))
+
+		summarized := append([]string{}, blocks[:start]...)
+		summarized = append(summarized, strings.Join(lines, "\n"))
+		return append(summarized, blocks[start+cells:]...), true
+	}
+	return blocks, false
+}
+
+// cellsAt reports whether blocks look like table cells, so a table with
+// empty or multi-paragraph cells, whose blocks do not line up with its
+// rows, is left as it is.
+func cellsAt(blocks []string) bool {
+	for _, block := range blocks {
+		if strings.Contains(block, "\n") || len(block) > maxCellChars {
+			return false
+		}
+	}
+	return true
+}
+
+func headerAt(blocks []string, start int, headers []string) bool {
+	for i, header := range headers {
+		if blocks[start+i] != strings.TrimSpace(horizontalSpace.ReplaceAllString(header, " ")) {
+			return false
+		}
+	}
+	return true
+}
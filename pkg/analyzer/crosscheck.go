@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+	"regexp"
+	"strings"
+)
+
+// Confidence levels of LLM recommendations after the cross-check.
+const (
+	ConfidenceHigh       = "high"       // local measurements agree
+	ConfidenceUnverified = "unverified" // nothing measured locally to check against
+	ConfidenceLow        = "low"        // local measurements contradict it
+)
+
+// LLMFinding is a recommendation from the LLM's answer with the confidence
+// the local measurements give it.
+type LLMFinding struct {
+	Text       string `json:"text"`
+	Confidence string `json:"confidence"`
+	Check      string `json:"check,omitempty"`  // the local measurement it was checked against
+	Reason     string `json:"reason,omitempty"` // what was measured
+}
+
+// crossCheck is a local measurement an LLM recommendation can be checked
+// against. The recommendation is about the check when it matches topic;
+// present reports what the page has and whether that is enough to make
+// advice to add more of it contradictory.
+type crossCheck struct {
+	id      string
+	topic   *regexp.Regexp
+	present func(page *webpage.PageData, local *scorer.GEOScore) (string, bool)
+}
+
+// adviceVerb matches recommendations asking for something to be added,
+// the only ones a measurement of what the page has can contradict.
+var adviceVerb = regexp.MustCompile(`(?i)\b(add|adding|include|including|introduce|create|implement|use|missing|lacks?|lacking|no|without|more|consider)\b`)
+
+// recommendationLine matches the bulleted and numbered lines of an answer.
+var recommendationLine = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)])\s+(.+)$`)
+
+var crossChecks = []crossCheck{
+	{
+		id:    "headings",
+		topic: regexp.MustCompile(`(?i)\b(sub)?headings?\b|\bh[23]s?\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			n := len(page.Headings)
+			return fmt.Sprintf("page has %d headings", n), n >= 5
+		},
+	},
+	{
+		id:    "lists",
+		topic: regexp.MustCompile(`(?i)\bbullet(ed)?\b|\b(numbered|bulleted) lists?\b|\blists\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			n := len(page.Lists)
+			return fmt.Sprintf("page has %d lists", n), n >= 3
+		},
+	},
+	{
+		id:    "tables",
+		topic: regexp.MustCompile(`(?i)\b(comparison|data|summary) tables?\b|\btables\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			n := len(page.Tables)
+			return fmt.Sprintf("page has %d tables", n), n >= 1
+		},
+	},
+	{
+		id:    "code",
+		topic: regexp.MustCompile(`(?i)\bcode (examples?|samples?|snippets?|blocks?)\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			n := len(page.CodeBlocks)
+			return fmt.Sprintf("page has %d code blocks", n), n >= 1
+		},
+	},
+	{
+		id:    "meta_description",
+		topic: regexp.MustCompile(`(?i)\bmeta descriptions?\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			if strings.TrimSpace(page.MetaTags["description"]) == "" {
+				return "page has no meta description", false
+			}
+			return "page has a meta description", true
+		},
+	},
+	{
+		id:    "structured_data",
+		topic: regexp.MustCompile(`(?i)\b(schema(\.org)? markup|structured data|json-ld)\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			n := len(page.StructuredData)
+			return fmt.Sprintf("page has %d JSON-LD objects", n), n >= 1
+		},
+	},
+	{
+		id:    "faq",
+		topic: regexp.MustCompile(`(?i)\bfaqs?\b|\bfrequently asked\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			for _, h := range page.Headings {
+				if text := strings.ToLower(h.Text); strings.Contains(text, "faq") || strings.Contains(text, "frequently asked") {
+					return fmt.Sprintf("page has an FAQ section (%q)", h.Text), true
+				}
+			}
+			return "page has no FAQ heading", false
+		},
+	},
+	{
+		id:    "citations",
+		topic: regexp.MustCompile(`(?i)\b(citations?|cite|sources|references)\b`),
+		present: func(_ *webpage.PageData, local *scorer.GEOScore) (string, bool) {
+			n := len(local.CitedDomains)
+			return fmt.Sprintf("page cites %d external domains", n), n >= 3
+		},
+	},
+	{
+		id:    "key_takeaways",
+		topic: regexp.MustCompile(`(?i)\b(key takeaways|tl;?dr|summary box)\b`),
+		present: func(_ *webpage.PageData, local *scorer.GEOScore) (string, bool) {
+			if local.KeyTakeaways == nil || !local.KeyTakeaways.Found {
+				return "page has no key takeaways block", false
+			}
+			return "page has a key takeaways block", true
+		},
+	},
+	{
+		id:    "author",
+		topic: regexp.MustCompile(`(?i)\bauthor( bio| byline| name)?\b|\bbyline\b`),
+		present: func(page *webpage.PageData, _ *scorer.GEOScore) (string, bool) {
+			if author := strings.TrimSpace(page.MetaTags["author"]); author != "" {
+				return fmt.Sprintf("page names its author (%s)", author), true
+			}
+			return "page names no author in its metadata", false
+		},
+	},
+}
+
+// crossCheckLLMFindings checks the recommendations of an LLM answer against
+// what was measured on the page. A recommendation asking to add something
+// the page already has enough of is tagged low confidence in the returned
+// answer, so the report does not give contradictory advice; the findings
+// list every recommendation with its confidence.
+func crossCheckLLMFindings(answer string, page *webpage.PageData, local *scorer.GEOScore) (string, []LLMFinding) {
+	lines := strings.Split(answer, "\n")
+	var findings []LLMFinding
+	for i, line := range lines {
+		m := recommendationLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		finding := LLMFinding{Text: strings.TrimSpace(m[1]), Confidence: ConfidenceUnverified}
+		if adviceVerb.MatchString(finding.Text) {
+			for _, check := range crossChecks {
+				if !check.topic.MatchString(finding.Text) {
+					continue
+				}
+				reason, present := check.present(page, local)
+				finding.Check, finding.Reason = check.id, reason
+				if present {
+					finding.Confidence = ConfidenceLow
+					break
+				}
+				finding.Confidence = ConfidenceHigh
+			}
+		}
+		if finding.Confidence == ConfidenceLow {
+			lines[i] = line + fmt.Sprintf(" _(low confidence: %s)_", finding.Reason)
+		}
+		findings = append(findings, finding)
+	}
+	return strings.Join(lines, "\n"), findings
+}
@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"geo-checker/pkg/scorer"
+	"regexp"
+	"strconv"
+)
+
+// ReviewDisagreement is the gap in points between the local and the LLM
+// score of a factor from which it needs human review: averaging scores
+// that far apart hides that one of the two has misjudged the page.
+const ReviewDisagreement = 25
+
+// FactorComparison sets the local score of a factor against the LLM's.
+type FactorComparison struct {
+	Factor      string `json:"factor"` // pillar key, or "overall"
+	Name        string `json:"name"`
+	Local       int    `json:"local"`
+	LLM         int    `json:"llm"`
+	Difference  int    `json:"difference"` // LLM minus local
+	NeedsReview bool   `json:"needs_review,omitempty"`
+}
+
+// compareFactors compares the overall score and every pillar the LLM
+// scored in its factor table with the local scores, in pillar order. It
+// returns nil when the answer has no factor scores to compare.
+func compareFactors(answer string, local *scorer.GEOScore, llmOverall int) []FactorComparison {
+	var comparisons []FactorComparison
+	if llmOverall > 0 {
+		comparisons = append(comparisons, newComparison("overall", "Overall", local.Overall, llmOverall))
+	}
+	for _, key := range local.PillarKeys() {
+		name := local.PillarName(key)
+		llmScore, ok := factorTableScore(answer, name)
+		if !ok {
+			continue
+		}
+		detail, _ := local.Pillar(key)
+		comparisons = append(comparisons, newComparison(key, name, detail.Score, llmScore))
+	}
+	if len(comparisons) == 1 && comparisons[0].Factor == "overall" {
+		// Without factor scores the overall gap is already reported by
+		// local_score and llm_score
+		return nil
+	}
+	return comparisons
+}
+
+func newComparison(factor, name string, local, llm int) FactorComparison {
+	diff := llm - local
+	return FactorComparison{
+		Factor:      factor,
+		Name:        name,
+		Local:       local,
+		LLM:         llm,
+		Difference:  diff,
+		NeedsReview: diff >= ReviewDisagreement || -diff >= ReviewDisagreement,
+	}
+}
+
+// factorTableScore returns the score of the named factor from a row of the
+// markdown table the prompts ask for, e.g. "| Content Structure | 70/100 |".
+// Rows are the only form read: prose quoting the local scores back must
+// not pass for the LLM's own.
+func factorTableScore(answer, name string) (int, bool) {
+	row := regexp.MustCompile(`(?im)^\s*\|\s*\**\s*` + regexp.QuoteMeta(name) + `\s*\**\s*\|\s*\**\s*(\d{1,3})\s*(?:/\s*100|%)?\s*\**\s*(?:\||$)`)
+	m := row.FindStringSubmatch(answer)
+	if m == nil {
+		return 0, false
+	}
+	score, err := strconv.Atoi(m[1])
+	if err != nil || score > 100 {
+		return 0, false
+	}
+	return score, true
+}
+
+// applyFactorComparison records the comparison of the LLM's answer with the
+// local scores in result, flagging it for review on any large gap.
+func applyFactorComparison(result *Result, answer string, local *scorer.GEOScore, llmOverall int) {
+	result.FactorComparison = compareFactors(answer, local, llmOverall)
+	for _, c := range result.FactorComparison {
+		result.NeedsReview = result.NeedsReview || c.NeedsReview
+	}
+}
@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/pkg/scorer"
+	"strings"
+	"time"
+)
+
+// maxFormattingDrafts caps the LLM calls made for formatting drafts per page.
+const maxFormattingDrafts = 3
+
+// draftFormatting asks the LLM to rewrite the passages flagged by the
+// formatting advisor as Markdown tables or lists. Failures are recorded in
+// the metadata and never fail the analysis.
+func (a *Analyzer) draftFormatting(ctx context.Context, score *scorer.GEOScore, content string, result *Result) {
+	if len(score.Formatting) == 0 {
+		return
+	}
+	if a.provider == nil {
+		result.Metadata["formatting_draft_error"] = "no LLM provider available"
+		return
+	}
+
+	for i := 0; i < len(score.Formatting) && i < maxFormattingDrafts; i++ {
+		advice := &score.Formatting[i]
+		passage := advice.Evidence.Snippet
+		if ev := advice.Evidence; ev.Start >= 0 && ev.End <= len(content) {
+			passage = content[ev.Start:ev.End]
+		}
+
+		draftCtx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
+		response, err := a.provider.Analyze(draftCtx, passage, formattingDraftPrompt(advice.Kind))
+		cancel()
+		if err != nil {
+			result.Metadata["formatting_draft_error"] = err.Error()
+			return
+		}
+		advice.Draft = strings.TrimSpace(response.Content)
+		result.TokensUsed += response.TokensUsed
+	}
+}
+
+// draftAnswer asks the LLM to write the direct answer paragraph a page
+// should open with, in place of the one taken from the page's own
+// sentences. Failures keep that one.
+func (a *Analyzer) draftAnswer(ctx context.Context, score *scorer.GEOScore, content string, result *Result) {
+	advice := score.AnswerFirst
+	if advice == nil || advice.Lead || a.provider == nil {
+		return
+	}
+	if len(content) > maxClassifyContent {
+		content = content[:maxClassifyContent]
+	}
+
+	draftCtx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
+	response, err := a.provider.Analyze(draftCtx, content, answerDraftPrompt(advice.Question))
+	cancel()
+	if err != nil {
+		result.Metadata["answer_draft_error"] = err.Error()
+		return
+	}
+	advice.Draft = strings.TrimSpace(response.Content)
+	result.TokensUsed += response.TokensUsed
+}
+
+func answerDraftPrompt(question string) string {
+	return fmt.Sprintf(`Write the opening paragraph of the following web page: 40 to 60 words that directly answer %q.
+State the answer in the first sentence, then the most useful supporting fact or figure.
+Use only facts from the page. Reply with the paragraph only, without an introduction or explanation.`, question)
+}
+
+func formattingDraftPrompt(kind string) string {
+	shape := "a Markdown table with a header row"
+	if kind == scorer.FormatList {
+		shape = "a Markdown bulleted list"
+	}
+	return fmt.Sprintf(`Rewrite the following passage from a web page as %s.
+Keep every fact, figure and name exactly as written and do not add information.
+Reply with the Markdown only, without an introduction or explanation.`, shape)
+}
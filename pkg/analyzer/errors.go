@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"geo-checker/internal/netguard"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/llm"
+	"net"
+	"net/http"
+)
+
+// ErrorCategory names the stage of an analysis that failed.
+type ErrorCategory string
+
+const (
+	CategoryFetch   ErrorCategory = "fetch"
+	CategoryExtract ErrorCategory = "extract"
+	CategoryLLM     ErrorCategory = "llm"
+	CategoryTimeout ErrorCategory = "timeout"
+)
+
+// Error is a failed analysis. The analyzer returns it from every Analyze
+// method so bulk reports can group failures by cause and reruns can retry
+// only the failures worth retrying.
+type Error struct {
+	Category   ErrorCategory `json:"category"`
+	Message    string        `json:"message"`
+	Retryable  bool          `json:"retryable"`
+	StatusCode int           `json:"status_code,omitempty"`
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// UnmarshalJSON also accepts the plain error strings written by older
+// reports. Their cause is unknown, so they are treated as retryable.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*e = Error{Message: message, Retryable: true}
+		return nil
+	}
+	type plain Error
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+// Classify returns err as an *Error. Errors that are not already typed are
+// fetch failures when they come from the network, and extraction failures,
+// which a rerun would repeat, otherwise.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var typed *Error
+	if errors.As(err, &typed) {
+		if typed.Message == err.Error() {
+			return typed
+		}
+		// Keep the caller's context in the message
+		wrapped := *typed
+		wrapped.Message = err.Error()
+		wrapped.err = err
+		return &wrapped
+	}
+	if isNetworkError(err) {
+		return NewError(CategoryFetch, err)
+	}
+	return NewError(CategoryExtract, err)
+}
+
+// isNetworkError reports whether err comes from a request: a connection,
+// DNS or HTTP failure, one the offline guard refused, or an interrupted
+// wait for a response.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	var httpErr *webpage.HTTPError
+	return errors.As(err, &netErr) || errors.As(err, &httpErr) || errors.Is(err, netguard.ErrOffline) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
+
+// NewError types err as a failure of the given stage, refining category
+// for timeouts and filling in the status code and retryability from the
+// underlying HTTP or LLM error.
+func NewError(category ErrorCategory, err error) *Error {
+	e := &Error{Category: category, Message: err.Error(), err: err}
+
+	var netErr net.Error
+	var llmErr *llm.LLMError
+	var httpErr *webpage.HTTPError
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		e.Category = CategoryTimeout
+		e.Retryable = true
+	case errors.Is(err, context.Canceled):
+		// Interrupted runs should pick these up on resume
+		e.Retryable = true
+	case errors.As(err, &llmErr):
+		e.Category = CategoryLLM
+		if llmErr.Type == llm.ErrorTypeTimeout {
+			e.Category = CategoryTimeout
+		}
+		e.Retryable = llmErr.Retryable
+		e.StatusCode = llmErr.StatusCode
+	case errors.As(err, &httpErr):
+		e.StatusCode = httpErr.StatusCode
+		e.Retryable = httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	case errors.Is(err, netguard.ErrOffline):
+		// Refused before any request was made; a rerun offline is refused too
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		// Unknown hosts will not resolve on a rerun either
+	case category == CategoryFetch:
+		// Connection failures are usually transient
+		e.Retryable = true
+	}
+	return e
+}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/llm"
+	"io/fs"
+	"net"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		category  ErrorCategory
+		retryable bool
+		status    int
+	}{
+		{"not found", NewError(CategoryFetch, fmt.Errorf("failed to scrape URL: %w", &webpage.HTTPError{StatusCode: 404})), CategoryFetch, false, 404},
+		{"unavailable", NewError(CategoryFetch, &webpage.HTTPError{StatusCode: 503}), CategoryFetch, true, 503},
+		{"deadline", NewError(CategoryFetch, fmt.Errorf("failed to fetch URL: %w", context.DeadlineExceeded)), CategoryTimeout, true, 0},
+		{"rate limited", NewError(CategoryLLM, llm.ParseHTTPError(429, nil, "claude")), CategoryLLM, true, 429},
+		{"bad key", NewError(CategoryLLM, llm.ParseHTTPError(401, nil, "claude")), CategoryLLM, false, 401},
+		{"no content", NewError(CategoryExtract, errors.New("no content")), CategoryExtract, false, 0},
+		{"untyped network", fmt.Errorf("failed to scrape URL: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), CategoryFetch, true, 0},
+		{"untyped", fmt.Errorf("failed to read file: %w", fs.ErrPermission), CategoryExtract, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Classify(tt.err)
+			if e.Category != tt.category || e.Retryable != tt.retryable || e.StatusCode != tt.status {
+				t.Errorf("Classify() = %+v", e)
+			}
+		})
+	}
+}
+
+func TestClassifyKeepsWrappedMessage(t *testing.T) {
+	inner := NewError(CategoryExtract, errors.New("no content"))
+	e := Classify(fmt.Errorf("failed to analyze content: %w", inner))
+	if e.Category != CategoryExtract || e.Message != "failed to analyze content: no content" {
+		t.Errorf("Classify() = %+v", e)
+	}
+	if !errors.Is(e, inner) {
+		t.Error("classified error does not wrap the original")
+	}
+}
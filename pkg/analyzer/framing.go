@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+	"strings"
+	"time"
+)
+
+const (
+	// maxFramingContent caps the content sent to the LLM for the framing
+	// check.
+	maxFramingContent = 12000
+
+	// maxFramingCandidates caps the strong claims pointed out to the LLM.
+	maxFramingCandidates = 10
+)
+
+// Framing is the LLM's reading of how the page situates its claims: whether
+// it states the consensus view and its own position, and whether it
+// justifies that position.
+type Framing struct {
+	ConsensusStated   bool           `json:"consensus_stated"`
+	PositionStated    bool           `json:"position_stated"`
+	PositionJustified bool           `json:"position_justified"`
+	Claims            []FramingClaim `json:"claims,omitempty"`
+}
+
+// FramingClaim is a strong claim the page leaves unattributed or ambiguous.
+type FramingClaim struct {
+	Claim    string          `json:"claim"`
+	Issue    string          `json:"issue"` // "unattributed" or "ambiguous"
+	Evidence scorer.Evidence `json:"evidence"`
+}
+
+// checkFraming asks the LLM how the page frames consensus and its own
+// position, pointing it at the unattributed strong claims the heuristics
+// found. The verdict is recorded in the metadata and, when the framing
+// falls short, raised as an authority finding; failures never fail the
+// analysis.
+func (a *Analyzer) checkFraming(ctx context.Context, pageData *webpage.PageData, result *Result) {
+	if a.provider == nil {
+		result.Metadata["framing_error"] = "no LLM provider available"
+		return
+	}
+	content := pageData.Content
+	if len(content) > maxFramingContent {
+		content = content[:maxFramingContent]
+	}
+	candidates := scorer.StrongClaims(pageData.Content, pageData, maxFramingCandidates)
+
+	framingCtx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
+	response, err := a.provider.Analyze(framingCtx, content, framingPrompt(candidates))
+	cancel()
+	if err != nil {
+		result.Metadata["framing_error"] = err.Error()
+		return
+	}
+	result.TokensUsed += response.TokensUsed
+
+	framing := parseFraming(response.Content)
+	for i := range framing.Claims {
+		framing.Claims[i].Evidence = scorer.Locate(pageData.Content, pageData, framing.Claims[i].Claim)
+	}
+	result.Metadata["framing"] = framing
+	a.applyFraming(framing, result)
+}
+
+// applyFraming raises the framing finding on the local score.
+func (a *Analyzer) applyFraming(framing *Framing, result *Result) {
+	var gaps []string
+	if !framing.ConsensusStated {
+		gaps = append(gaps, "the consensus view")
+	}
+	if !framing.PositionStated {
+		gaps = append(gaps, "its own position")
+	} else if !framing.PositionJustified {
+		gaps = append(gaps, "why it takes its position")
+	}
+	if len(gaps) == 0 && len(framing.Claims) == 0 {
+		return
+	}
+
+	var issue string
+	if len(gaps) > 0 {
+		issue = fmt.Sprintf("Framing: the page does not state %s", strings.Join(gaps, " or "))
+		if len(framing.Claims) > 0 {
+			issue += fmt.Sprintf(", and makes %d unattributed or ambiguous strong claims", len(framing.Claims))
+		}
+	} else {
+		issue = fmt.Sprintf("Framing: %d strong claims are unattributed or ambiguous", len(framing.Claims))
+	}
+
+	result.Suggestions = append(result.Suggestions, "Say what the consensus is, where the page stands and why, and attribute strong claims to a source")
+	local := result.LocalScore
+	if local == nil {
+		return
+	}
+	finding := scorer.Finding{
+		ID:       "authority.framing",
+		Pillar:   "authority_signals",
+		Message:  issue,
+		Severity: scorer.SeverityMedium,
+	}
+	for i := 0; i < len(framing.Claims) && i < 3; i++ {
+		finding.Evidence = append(finding.Evidence, framing.Claims[i].Evidence)
+	}
+	local.Breakdown.AuthoritySignals.Issues = append(local.Breakdown.AuthoritySignals.Issues, issue)
+	local.Breakdown.AuthoritySignals.Findings = append(local.Breakdown.AuthoritySignals.Findings, finding)
+	local.Findings = append(local.Findings, finding)
+}
+
+// parseFraming reads the LLM's line-based verdict; lines it does not
+// recognize are ignored.
+func parseFraming(response string) *Framing {
+	framing := &Framing{}
+	for _, line := range strings.Split(response, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		yes := strings.HasPrefix(strings.ToLower(value), "yes")
+		switch strings.ToUpper(strings.Trim(key, "-* ")) {
+		case "CONSENSUS":
+			framing.ConsensusStated = yes
+		case "POSITION":
+			framing.PositionStated = yes
+		case "JUSTIFIED":
+			framing.PositionJustified = yes
+		case "CLAIM":
+			claim, issue, _ := strings.Cut(value, "|")
+			issue = strings.ToLower(strings.TrimSpace(issue))
+			if issue != "ambiguous" {
+				issue = "unattributed"
+			}
+			if claim = strings.Trim(strings.TrimSpace(claim), `"“”`); claim != "" {
+				framing.Claims = append(framing.Claims, FramingClaim{Claim: claim, Issue: issue})
+			}
+		}
+	}
+	return framing
+}
+
+func framingPrompt(candidates []scorer.Evidence) string {
+	var sb strings.Builder
+	sb.WriteString(`Read the following web page and judge how it situates its claims.
+Answer in exactly this format, one item per line:
+CONSENSUS: yes or no (does the page state what the consensus or mainstream view is?)
+POSITION: yes or no (does the page state its own position?)
+JUSTIFIED: yes or no (does it give reasons or evidence for that position?)
+CLAIM: <the sentence, quoted exactly as on the page> | unattributed or ambiguous
+Add one CLAIM line per strong claim that is stated as fact without a source (unattributed) or that could be read several ways (ambiguous); add none if there are none.`)
+	if len(candidates) > 0 {
+		sb.WriteString("\nThese sentences make absolute claims without naming a source; judge them first:\n")
+		for _, c := range candidates {
+			sb.WriteString("- " + c.Snippet + "\n")
+		}
+	}
+	return sb.String()
+}
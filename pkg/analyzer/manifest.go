@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"geo-checker/internal/netguard"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scorer"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Version is the version of the tool, set at build time with
+// -ldflags "-X geo-checker/pkg/analyzer.Version=v1.2.3". Builds without it
+// report the VCS revision the Go toolchain recorded.
+var Version = ""
+
+// ToolVersion returns Version, or the module version or VCS revision of
+// the binary, or "dev".
+func ToolVersion() string {
+	if Version != "" {
+		return Version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		return v
+	}
+	revision, modified := "", false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if revision == "" {
+		return "dev"
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	return "dev-" + revision
+}
+
+// Manifest records what produced a result, so that a report can be
+// reproduced later: the tool and scorer versions, the provider, model and
+// prompt of LLM analyses and the effective configuration.
+type Manifest struct {
+	ToolVersion   string          `json:"tool_version"`
+	ScorerVersion string          `json:"scorer_version"`
+	GoVersion     string          `json:"go_version"`
+	Provider      string          `json:"provider,omitempty"`
+	Model         string          `json:"model,omitempty"`
+	PromptHash    string          `json:"prompt_hash,omitempty"` // SHA-256 of the prompt sent, first 16 hex digits
+	Config        EffectiveConfig `json:"config"`
+}
+
+// EffectiveConfig is the configuration in effect once defaults and auto
+// mode are resolved. API keys are left out.
+type EffectiveConfig struct {
+	Mode             string             `json:"mode"`
+	RequestedMode    string             `json:"requested_mode,omitempty"`
+	Profile          string             `json:"profile"`
+	ReadingLevel     string             `json:"reading_level,omitempty"`
+	Framework        string             `json:"framework,omitempty"` // pillar framework file
+	Weights          map[string]float64 `json:"weights,omitempty"`   // from the configuration file
+	StaleAfterDays   int                `json:"stale_after_days,omitempty"`
+	CrawlerParity    bool               `json:"crawler_parity"`
+	InlineFrames     bool               `json:"inline_frames"`
+	SkipImageCheck   bool               `json:"skip_image_check,omitempty"`
+	Selector         string             `json:"selector,omitempty"` // content region
+	MetaCaptures     []string           `json:"meta_captures,omitempty"`
+	Condense         bool               `json:"condense,omitempty"`
+	FormattingDrafts bool               `json:"formatting_drafts"`
+	AnswerDraft      bool               `json:"answer_draft"`
+	Framing          bool               `json:"framing"`
+	Originality      string             `json:"originality,omitempty"` // search API
+	ClassifyWithLLM  bool               `json:"classify_llm"`
+	Deterministic    bool               `json:"deterministic"`
+	MaxTokens        int                `json:"max_tokens,omitempty"`
+	Temperature      float64            `json:"temperature,omitempty"`
+	Timeout          int                `json:"timeout_seconds"`
+	UserAgent        string             `json:"user_agent"`
+	MaxHTMLSize      int64              `json:"max_html_size"`
+	ConsentSelectors []string           `json:"consent_selectors,omitempty"` // beyond the built-in ones
+	Offline          bool               `json:"offline,omitempty"`           // no connections beyond localhost
+}
+
+// Manifest returns the manifest of the analyses the analyzer runs. The
+// prompt hash is filled in per result, as hybrid prompts embed the local
+// analysis of the page.
+func (a *Analyzer) Manifest() *Manifest {
+	cfg := a.config
+	profile := string(a.localScorer.Profile())
+	if a.autoProfile {
+		profile = string(scorer.ProfileAuto)
+	}
+	m := &Manifest{
+		ToolVersion:   ToolVersion(),
+		ScorerVersion: scorer.Version,
+		GoVersion:     runtime.Version(),
+		Config: EffectiveConfig{
+			Mode:             cfg.Mode,
+			Profile:          profile,
+			ReadingLevel:     cfg.ReadingLevel,
+			Framework:        cfg.Framework,
+			Weights:          config.Weights,
+			StaleAfterDays:   cfg.StaleAfterDays,
+			CrawlerParity:    cfg.CrawlerParity,
+			InlineFrames:     cfg.InlineFrames,
+			SkipImageCheck:   cfg.SkipImageCheck,
+			Selector:         cfg.Selector,
+			MetaCaptures:     cfg.MetaCaptures,
+			Condense:         cfg.Condense,
+			FormattingDrafts: cfg.FormattingDrafts,
+			AnswerDraft:      cfg.AnswerDraft,
+			Framing:          cfg.Framing,
+			Originality:      cfg.Originality,
+			ClassifyWithLLM:  cfg.ClassifyWithLLM,
+			Deterministic:    cfg.Deterministic,
+			Timeout:          cfg.Timeout,
+			UserAgent:        webpage.DefaultUserAgent,
+			MaxHTMLSize:      webpage.DefaultMaxHTMLSize,
+			ConsentSelectors: webpage.ExtraConsentSelectors,
+			Offline:          netguard.Enabled(),
+		},
+	}
+	if a.originalMode != cfg.Mode {
+		m.Config.RequestedMode = a.originalMode
+	}
+	if cfg.Mode != "local" {
+		m.Provider, m.Model = cfg.LLMProvider, cfg.Model
+		m.Config.MaxTokens, m.Config.Temperature = cfg.MaxTokens, cfg.Temperature
+	}
+	return m
+}
+
+// ContentHash returns the hash of extracted content that results record
+// under metadata.content_hash, in the form of the prompt hash, so the
+// content scored can later be told apart from the page's current content.
+func ContentHash(content string) string {
+	return promptHash(content)
+}
+
+// promptHash returns the first 16 hex digits of the SHA-256 of prompt.
+func promptHash(prompt string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(prompt)))
+	return hex.EncodeToString(sum[:])[:16]
+}
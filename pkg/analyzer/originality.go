@@ -0,0 +1,182 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+	"math"
+	neturl "net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// defaultOriginalitySamples is the number of sentences searched for
+	// when the configuration does not say.
+	defaultOriginalitySamples = 5
+
+	// Sentences sampled are between these lengths in words: shorter ones
+	// match by chance, longer ones are cut by search APIs.
+	minSampleWords = 12
+	maxSampleWords = 32
+)
+
+// Originality is how much of a page's distinctive text appears word for
+// word on other sites, from exact-phrase searches of sampled sentences.
+type Originality struct {
+	Provider   string             `json:"provider"`
+	Sampled    int                `json:"sampled"`
+	Duplicated int                `json:"duplicated"`
+	Risk       int                `json:"risk"` // share of sampled sentences found elsewhere, 0-100
+	Matches    []OriginalityMatch `json:"matches,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// OriginalityMatch is a sampled sentence found on other sites.
+type OriginalityMatch struct {
+	Sentence string          `json:"sentence"`
+	URLs     []string        `json:"urls"`
+	Evidence scorer.Evidence `json:"evidence"`
+}
+
+// checkOriginality searches for the page's most distinctive sentences and
+// records the duplication risk in the metadata, raising an authority
+// finding when much of the text is copied. Search failures never fail the
+// analysis.
+func (a *Analyzer) checkOriginality(ctx context.Context, pageData *webpage.PageData, source string, result *Result) {
+	if a.searcher == nil {
+		result.Metadata["originality_error"] = a.searchErr.Error()
+		return
+	}
+	samples := a.config.OriginalitySamples
+	if samples <= 0 {
+		samples = defaultOriginalitySamples
+	}
+
+	originality := &Originality{Provider: a.searcher.Name()}
+	own := siteHost(source)
+	for _, sentence := range distinctiveSentences(pageData.Content, samples) {
+		searchCtx, cancel := context.WithTimeout(ctx, time.Duration(a.config.Timeout)*time.Second)
+		results, err := a.searcher.Search(searchCtx, `"`+sentence+`"`)
+		cancel()
+		if err != nil {
+			originality.Error = err.Error()
+			break
+		}
+		originality.Sampled++
+
+		var urls []string
+		for _, r := range results {
+			if host := siteHost(r.URL); host != "" && host != own {
+				urls = append(urls, r.URL)
+			}
+		}
+		if len(urls) > 0 {
+			originality.Duplicated++
+			originality.Matches = append(originality.Matches, OriginalityMatch{
+				Sentence: sentence,
+				URLs:     urls,
+				Evidence: scorer.Locate(pageData.Content, pageData, sentence),
+			})
+		}
+	}
+	if originality.Sampled > 0 {
+		originality.Risk = int(math.Round(100 * float64(originality.Duplicated) / float64(originality.Sampled)))
+	}
+	result.Metadata["originality"] = originality
+	a.applyOriginality(originality, result)
+}
+
+// applyOriginality raises the originality finding on the local score when
+// at least two in five sampled sentences are found elsewhere.
+func (a *Analyzer) applyOriginality(originality *Originality, result *Result) {
+	if originality.Risk < 40 || originality.Duplicated < 2 {
+		return
+	}
+	severity := scorer.SeverityMedium
+	if originality.Risk >= 60 {
+		severity = scorer.SeverityHigh
+	}
+	issue := fmt.Sprintf("Originality: %d of %d distinctive sentences appear word for word on other sites (duplication risk %d%%)",
+		originality.Duplicated, originality.Sampled, originality.Risk)
+
+	result.Suggestions = append(result.Suggestions, "Rewrite copied passages in your own words and add original data, examples or analysis; AI systems rarely cite duplicated text")
+	local := result.LocalScore
+	if local == nil {
+		return
+	}
+	finding := scorer.Finding{
+		ID:       "authority.originality",
+		Pillar:   "authority_signals",
+		Message:  issue,
+		Severity: severity,
+	}
+	for i := 0; i < len(originality.Matches) && i < 3; i++ {
+		finding.Evidence = append(finding.Evidence, originality.Matches[i].Evidence)
+	}
+	local.Breakdown.AuthoritySignals.Issues = append(local.Breakdown.AuthoritySignals.Issues, issue)
+	local.Breakdown.AuthoritySignals.Findings = append(local.Breakdown.AuthoritySignals.Findings, finding)
+	local.Findings = append(local.Findings, finding)
+}
+
+// distinctiveSentences returns up to n sentences of content that are the
+// least likely to match other pages by chance, in page order: long words,
+// numbers and names count for a sentence, quotes against it as they break
+// exact-phrase searches.
+func distinctiveSentences(content string, n int) []string {
+	type candidate struct {
+		sentence string
+		index    int
+		score    int
+	}
+	var candidates []candidate
+	seen := make(map[string]bool)
+	for i, s := range scorer.Sentences(content) {
+		if !strings.ContainsAny(s[len(s)-1:], ".!?") {
+			continue // a heading or list item
+		}
+		words := strings.Fields(s)
+		s = strings.Join(words, " ")
+		if len(words) < minSampleWords || len(words) > maxSampleWords || strings.ContainsAny(s, `"“”`) || seen[s] {
+			continue
+		}
+		seen[s] = true
+		score := 0
+		for j, w := range words {
+			w = strings.TrimFunc(w, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+			switch {
+			case strings.IndexFunc(w, unicode.IsDigit) >= 0:
+				score += 2
+			case j > 0 && w != "" && unicode.IsUpper([]rune(w)[0]):
+				score += 2
+			case len(w) >= 7:
+				score++
+			}
+		}
+		candidates = append(candidates, candidate{s, i, score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].index < candidates[j].index })
+	sentences := make([]string, len(candidates))
+	for i, c := range candidates {
+		sentences[i] = c.sentence
+	}
+	return sentences
+}
+
+// siteHost returns the host of a URL without a leading "www.", or "" when
+// it has none.
+func siteHost(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
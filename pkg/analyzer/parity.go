@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/internal/textdiff"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scorer"
+)
+
+// parityThreshold is the share of the browser-visible text an AI crawler
+// must receive before the page is considered at parity.
+const parityThreshold = 0.8
+
+// CrawlerParity compares the content served to a regular browser with the
+// content served to an AI crawler (GPTBot).
+type CrawlerParity struct {
+	BrowserChars int     `json:"browser_chars"`
+	CrawlerChars int     `json:"crawler_chars"`
+	Similarity   float64 `json:"similarity"`
+	Coverage     float64 `json:"coverage"`
+	Parity       bool    `json:"parity"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// checkCrawlerParity fetches url as a browser and as GPTBot. A crawler that
+// is blocked outright counts as receiving no content. Both fetches keep to
+// public addresses when cfg does.
+func checkCrawlerParity(ctx context.Context, url string, cfg *config.Config) *CrawlerParity {
+	type fetch struct {
+		page *webpage.PageData
+		err  error
+	}
+	scrape := func(userAgent string) <-chan fetch {
+		ch := make(chan fetch, 1)
+		go func() {
+			scraper := webpage.New()
+			scraper.SetUserAgent(userAgent)
+			if cfg.PublicOnly {
+				scraper.SetPublicOnly(cfg.AllowedHosts)
+			}
+			page, err := scraper.ScrapeURL(ctx, url)
+			ch <- fetch{page, err}
+		}()
+		return ch
+	}
+	browserCh, crawlerCh := scrape(webpage.BrowserUserAgent), scrape(webpage.GPTBotUserAgent)
+	browser, crawler := <-browserCh, <-crawlerCh
+
+	if browser.err != nil {
+		return &CrawlerParity{Parity: true, Error: fmt.Sprintf("browser fetch failed: %v", browser.err)}
+	}
+
+	parity := &CrawlerParity{BrowserChars: len(browser.page.Content)}
+	if crawler.err != nil {
+		parity.Error = fmt.Sprintf("crawler fetch failed: %v", crawler.err)
+		return parity
+	}
+
+	parity.CrawlerChars = len(crawler.page.Content)
+	parity.Similarity, parity.Coverage = textdiff.WordOverlap(browser.page.Content, crawler.page.Content)
+	parity.Parity = parity.Coverage >= parityThreshold
+	return parity
+}
+
+// applyCrawlerParity records the parity check on the result and raises an
+// accessibility finding when the crawler gets materially less content.
+func (a *Analyzer) applyCrawlerParity(ctx context.Context, result *Result, url string) {
+	parity := checkCrawlerParity(ctx, url, a.config)
+	result.Metadata["crawler_parity"] = parity
+	if parity.Parity {
+		return
+	}
+
+	var issue string
+	if parity.Error != "" {
+		issue = fmt.Sprintf("Crawler parity: AI crawlers (GPTBot) could not retrieve this page (%s)", parity.Error)
+	} else {
+		issue = fmt.Sprintf("Crawler parity: AI crawlers (GPTBot) receive only %.0f%% of the content browsers see (%d vs %d characters)",
+			parity.Coverage*100, parity.CrawlerChars, parity.BrowserChars)
+	}
+	suggestion := "Serve AI crawlers the same content as browsers: check bot rules in your CDN/WAF, user-agent based rendering and JavaScript-only content"
+
+	result.Suggestions = append([]string{suggestion}, result.Suggestions...)
+	if local := result.LocalScore; local != nil {
+		finding := scorer.Finding{
+			ID:       "accessibility.crawler_parity",
+			Pillar:   "accessibility",
+			Message:  issue,
+			Severity: scorer.SeverityHigh,
+			Evidence: []scorer.Evidence{
+				{Snippet: fmt.Sprintf("browser (%s): %d characters", webpage.BrowserUserAgent, parity.BrowserChars), Start: -1, End: -1},
+				{Snippet: fmt.Sprintf("crawler (%s): %d characters", webpage.GPTBotUserAgent, parity.CrawlerChars), Start: -1, End: -1},
+			},
+		}
+		local.Breakdown.Accessibility.Issues = append(local.Breakdown.Accessibility.Issues, issue)
+		local.Breakdown.Accessibility.Findings = append(local.Breakdown.Accessibility.Findings, finding)
+		local.Findings = append([]scorer.Finding{finding}, local.Findings...)
+	}
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/pkg/llm"
+	"geo-checker/pkg/ui"
+	"strings"
+	"sync"
+)
+
+// streamPrinter shows the LLM's answer in the AI INSIGHTS section while it
+// is generated instead of leaving a spinner up, a line at a time so that
+// its markdown renders as it does in the report.
+type streamPrinter struct {
+	ui      *ui.UI
+	prefix  string // shown before the answer: the local analysis of hybrid runs
+	mu      sync.Mutex
+	started bool
+	line    strings.Builder
+}
+
+// streamTo returns ctx set to stream the LLM's answer to the terminal and
+// the printer showing it, when the analysis is configured to stream; else
+// ctx and nil.
+func (a *Analyzer) streamTo(ctx context.Context, prefix string) (context.Context, *streamPrinter) {
+	if !a.config.Stream || a.config.OutputFormat != "text" || a.config.Quiet {
+		return ctx, nil
+	}
+	p := &streamPrinter{ui: a.ui, prefix: prefix}
+	return llm.WithStream(ctx, p.write), p
+}
+
+func (p *streamPrinter) write(text string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.started {
+		p.started = true
+		p.ui.StopSpinner()
+		fmt.Println()
+		p.ui.PrintSection("AI INSIGHTS")
+		fmt.Println()
+		if p.prefix != "" {
+			p.printLine(p.prefix + "\n")
+		}
+	}
+	for {
+		i := strings.IndexByte(text, '\n')
+		if i < 0 {
+			p.line.WriteString(text)
+			return
+		}
+		p.line.WriteString(text[:i])
+		p.printLine(p.line.String())
+		p.line.Reset()
+		text = text[i+1:]
+	}
+}
+
+func (p *streamPrinter) printLine(line string) {
+	fmt.Println(strings.TrimSuffix(p.ui.FormatMarkdownContent(line), "\n"))
+}
+
+// finish shows the rest of the answer and reports whether any of it was
+// streamed; answers reused from the cache are not.
+func (p *streamPrinter) finish() bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.line.Len() > 0 {
+		p.printLine(p.line.String())
+		p.line.Reset()
+	}
+	return p.started
+}
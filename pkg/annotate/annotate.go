@@ -0,0 +1,128 @@
+// Package annotate writes findings back into the source a page was built
+// from, as comments at the places their evidence quotes, so editors can fix
+// issues in place.
+package annotate
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"geo-checker/pkg/scorer"
+)
+
+// Annotation styles: HTML comments work in HTML and markdown, CriticMarkup
+// comments only in markdown.
+const (
+	StyleComment = "comment"
+	StyleCritic  = "critic"
+)
+
+// needleWords is the number of words of a snippet searched for in the
+// source: enough to be unique, few enough to survive markup and
+// truncation.
+const needleWords = 8
+
+// headingSkip is the prefix of evidence for a skipped heading level.
+var headingSkip = regexp.MustCompile(`^H\d → H\d: `)
+
+// IsMarkdown reports whether path names a markdown file, by extension.
+func IsMarkdown(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".mdx", ".mdown":
+		return true
+	}
+	return false
+}
+
+// note is a finding to write into the source and, for each of its
+// positioned evidence, the texts to look for in order of preference.
+type note struct {
+	finding  scorer.Finding
+	evidence [][]string
+}
+
+// notes pairs every finding with the needles of its positioned evidence.
+// Findings with none are page-level.
+func notes(findings []scorer.Finding) []note {
+	out := make([]note, 0, len(findings))
+	for _, f := range findings {
+		n := note{finding: f}
+		for _, ev := range f.Evidence {
+			if ev.Start < 0 {
+				continue
+			}
+			if needles := needles(ev.Snippet); len(needles) > 0 {
+				n.evidence = append(n.evidence, needles)
+			}
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+// needles returns the opening words of a snippet, without the truncation
+// mark, the URL link evidence adds and the levels heading evidence starts
+// with, and the words after them in case
+// extraction joined the opening to a preceding heading.
+func needles(snippet string) []string {
+	snippet = headingSkip.ReplaceAllString(snippet, "")
+	snippet, _, _ = strings.Cut(snippet, " → ")
+	words := strings.Fields(strings.TrimSuffix(snippet, "…"))
+	var out []string
+	for start := 0; start < len(words) && len(out) < 2; start += needleWords {
+		out = append(out, strings.Join(words[start:min(len(words), start+needleWords)], " "))
+	}
+	return out
+}
+
+// label describes a finding in one line: severity, rule, message and the
+// points fixing it could add.
+func label(f scorer.Finding) string {
+	s := fmt.Sprintf("GEO [%s] %s: %s", f.Severity, f.ID, f.Message)
+	if f.Impact > 0 {
+		s += fmt.Sprintf(" (+%d pts)", f.Impact)
+	}
+	return s
+}
+
+// comment returns an HTML comment holding text, with the sequences that
+// would end it early broken up.
+func comment(text string) string {
+	text = strings.ReplaceAll(text, "--", "- -")
+	return "<!-- " + strings.ReplaceAll(text, "- -->", "- - >") + " -->"
+}
+
+// critic returns a CriticMarkup comment holding text.
+func critic(text string) string {
+	return "{>>" + strings.ReplaceAll(text, "<<}", "<< }") + "<<}"
+}
+
+// plain collapses whitespace so texts from the source compare with
+// snippets.
+func plain(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// insertion is text to insert into the source at a byte offset.
+type insertion struct {
+	offset int
+	text   string
+}
+
+// apply inserts every insertion into src, keeping insertions at the same
+// offset in the order given.
+func apply(src string, insertions []insertion) string {
+	sort.SliceStable(insertions, func(i, j int) bool { return insertions[i].offset < insertions[j].offset })
+	var sb strings.Builder
+	last := 0
+	for _, ins := range insertions {
+		sb.WriteString(src[last:ins.offset])
+		sb.WriteString(ins.text)
+		last = ins.offset
+	}
+	sb.WriteString(src[last:])
+	return sb.String()
+}
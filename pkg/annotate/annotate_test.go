@@ -0,0 +1,123 @@
+package annotate
+
+import (
+	"strings"
+	"testing"
+
+	"geo-checker/pkg/scorer"
+)
+
+var testFindings = []scorer.Finding{
+	{ID: "clarity.long_sentences", Severity: scorer.SeverityMedium, Message: "Shorten long sentences", Impact: 4,
+		Evidence: []scorer.Evidence{{Snippet: "This sentence goes on and on and on well past the point of being readable…", Start: 10, End: 90}}},
+	{ID: "authority.hidden_links", Severity: scorer.SeverityHigh, Message: "Remove links hidden from readers (1 found)",
+		Evidence: []scorer.Evidence{{Snippet: "cheap pills → https://spam.example/", Start: 120, End: 131}}},
+	{ID: "structure.schema", Severity: scorer.SeverityLow, Message: "Add Article markup"},
+}
+
+func TestHTML(t *testing.T) {
+	src := `<html><head><title>T</title></head>
+<body>
+<div class="content">
+  <h1>Title</h1>
+  <p>This sentence goes on <em>and on</em> and on well past the point of being readable and then some more.
+  <p>Short one. <a href="https://spam.example/" style="display:none">cheap pills</a></p>
+</div>
+</body></html>`
+
+	out, placed := HTML(src, testFindings)
+	if placed != 2 {
+		t.Errorf("placed %d findings inline, want 2", placed)
+	}
+	for _, want := range []string{
+		"<!-- GEO [medium] clarity.long_sentences: Shorten long sentences (+4 pts) --><p>This sentence",
+		"<!-- GEO [high] authority.hidden_links: Remove links hidden from readers (1 found) --><p>Short one.",
+		"<body>\n<!-- GEO page-level findings:\n  GEO [low] structure.schema: Add Article markup\n -->\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("annotated HTML lacks %q:\n%s", want, out)
+		}
+	}
+
+	// Apart from the comments the source is unchanged
+	stripped := out
+	for strings.Contains(stripped, "<!-- GEO") {
+		i := strings.Index(stripped, "<!-- GEO")
+		j := strings.Index(stripped[i:], "-->") + i + len("-->")
+		stripped = stripped[:i] + stripped[j:]
+	}
+	if stripped != strings.Replace(src, "<body>\n", "<body>\n\n\n", 1) {
+		t.Errorf("source changed:\n%s", stripped)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	src := `---
+title: Test
+---
+# Title
+
+This sentence goes on and on and on well past the point of being readable and then some more.
+
+- [cheap pills](https://spam.example/)
+`
+
+	out, placed, err := Markdown(src, testFindings, StyleComment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placed != 2 {
+		t.Errorf("placed %d findings inline, want 2", placed)
+	}
+	for _, want := range []string{
+		"---\n<!-- GEO page-level findings:",
+		"then some more. <!-- GEO [medium] clarity.long_sentences: Shorten long sentences (+4 pts) -->\n",
+		"(https://spam.example/) <!-- GEO [high] authority.hidden_links",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("annotated markdown lacks %q:\n%s", want, out)
+		}
+	}
+
+	out, _, err = Markdown(src, testFindings, StyleCritic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"{==This sentence goes on and on and on==}{>>GEO [medium] clarity.long_sentences: Shorten long sentences (+4 pts)<<} well",
+		"{>>GEO [low] structure.schema: Add Article markup<<}\n\n# Title",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("critic markup lacks %q:\n%s", want, out)
+		}
+	}
+
+	if _, _, err := Markdown(src, testFindings, "margin"); err == nil {
+		t.Error("unknown style accepted")
+	}
+}
+
+func TestComment(t *testing.T) {
+	if got := comment("a --> b -- c"); strings.Count(got, "-->") != 1 || !strings.HasSuffix(got, " -->") {
+		t.Errorf("comment = %q, closes early", got)
+	}
+}
+
+func TestLocate(t *testing.T) {
+	src := "<html><body>\n<h1>Title</h1>\n  <p>This sentence goes on and on and on well past the point of being readable.</p>\n</body></html>"
+	locations := Locate(src, false, testFindings)
+	if len(locations) != 3 {
+		t.Fatalf("%d locations, want 3", len(locations))
+	}
+	if l := locations[0]; l.Line != 3 || l.Col != 3 {
+		t.Errorf("long sentence at %d:%d, want 3:3", l.Line, l.Col)
+	}
+	if l := locations[1]; l.Line != 0 {
+		t.Errorf("missing link placed at %d:%d", l.Line, l.Col)
+	}
+
+	md := "# Title\n\nSome words. This sentence goes on and on and on well past the point.\n"
+	if l := Locate(md, true, testFindings)[0]; l.Line != 3 || l.Col != 13 {
+		t.Errorf("markdown long sentence at %d:%d, want 3:13", l.Line, l.Col)
+	}
+}
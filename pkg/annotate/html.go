@@ -0,0 +1,176 @@
+package annotate
+
+import (
+	"strings"
+
+	"geo-checker/pkg/scorer"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blockTags are the elements findings are attached to.
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.Li: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Td: true, atom.Th: true, atom.Blockquote: true,
+	atom.Pre: true, atom.Dt: true, atom.Dd: true, atom.Figcaption: true, atom.Caption: true,
+	atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true,
+}
+
+// autoClosed are the blocks a following block of the same kind closes
+// without an end tag.
+var autoClosed = map[atom.Atom]bool{atom.P: true, atom.Li: true, atom.Dt: true, atom.Dd: true, atom.Td: true, atom.Th: true}
+
+// block is an element of the source with the byte range it spans and its
+// text.
+type block struct {
+	start, end int
+	text       strings.Builder
+}
+
+// HTML returns src with an HTML comment before the innermost element
+// quoting each finding's evidence, and the findings that cannot be placed
+// that way (page-level ones, or evidence not found in src) listed in one
+// comment at the start of the body. The markup is otherwise left byte for
+// byte as it was. It also returns the number of findings placed inline.
+func HTML(src string, findings []scorer.Finding) (string, int) {
+	blocks, bodyStart := htmlBlocks(src)
+
+	var insertions []insertion
+	var unplaced []string
+	placed := 0
+	for _, n := range notes(findings) {
+		seen := make(map[int]bool)
+		for _, needles := range n.evidence {
+			if b := innermost(blocks, needles); b != nil && !seen[b.start] {
+				seen[b.start] = true
+				insertions = append(insertions, insertion{b.start, comment(label(n.finding))})
+			}
+		}
+		if len(seen) > 0 {
+			placed++
+		} else {
+			unplaced = append(unplaced, label(n.finding))
+		}
+	}
+	if len(unplaced) > 0 {
+		text := "\n" + comment("GEO page-level findings:\n  "+strings.Join(unplaced, "\n  ")+"\n") + "\n"
+		insertions = append(insertions, insertion{bodyStart, text})
+	}
+	return apply(src, insertions), placed
+}
+
+// open is a block element whose end has not been reached.
+type open struct {
+	tag atom.Atom
+	b   *block
+}
+
+// space separates the text of the open blocks at an element boundary.
+func space(stack []open) {
+	for _, o := range stack {
+		o.b.text.WriteByte(' ')
+	}
+}
+
+// htmlBlocks tokenizes src and returns its block elements with their text
+// in document order, and the offset just after the <body> start tag (0
+// when there is none).
+func htmlBlocks(src string) ([]*block, int) {
+	var blocks []*block
+	var stack []open
+	closeTo := func(i, offset int) {
+		for _, o := range stack[i:] {
+			o.b.end = offset
+		}
+		stack = stack[:i]
+	}
+
+	z := html.NewTokenizer(strings.NewReader(src))
+	offset, bodyStart, skip := 0, 0, 0
+	for {
+		tt := z.Next()
+		raw := len(z.Raw())
+		switch tt {
+		case html.ErrorToken:
+			closeTo(0, offset)
+			return blocks, bodyStart
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := atom.Lookup(name)
+			switch {
+			case tag == atom.Br:
+				space(stack)
+			case tag == atom.Body:
+				bodyStart = offset + raw
+			case tag == atom.Script || tag == atom.Style || tag == atom.Template || tag == atom.Noscript:
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case blockTags[tag] && tt == html.StartTagToken:
+				// Blocks end an open paragraph, and list items, cells and
+				// paragraphs the previous one of their kind
+				if n := len(stack); n > 0 && stack[n-1].tag == atom.P {
+					closeTo(n-1, offset)
+				}
+				if n := len(stack); n > 0 && autoClosed[tag] && stack[n-1].tag == tag {
+					closeTo(n-1, offset)
+				}
+				space(stack)
+				b := &block{start: offset}
+				blocks = append(blocks, b)
+				stack = append(stack, open{tag, b})
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := atom.Lookup(name)
+			if tag == atom.Script || tag == atom.Style || tag == atom.Template || tag == atom.Noscript {
+				skip = max(skip-1, 0)
+			}
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].tag == tag {
+					closeTo(i, offset+raw)
+					break
+				}
+			}
+			if blockTags[tag] {
+				space(stack)
+			}
+		case html.TextToken:
+			if skip == 0 {
+				text := string(z.Text())
+				for _, o := range stack {
+					o.b.text.WriteString(text)
+				}
+			}
+		}
+		offset += raw
+	}
+}
+
+// innermost returns the block quoting the first of needles any block
+// quotes that contains no other block quoting it, first in document order,
+// or nil when no block quotes any.
+func innermost(blocks []*block, needles []string) *block {
+	for _, needle := range needles {
+		var candidates []*block
+		for _, b := range blocks {
+			if strings.Contains(plain(b.text.String()), needle) {
+				candidates = append(candidates, b)
+			}
+		}
+		for _, c := range candidates {
+			inner := false
+			for _, d := range candidates {
+				if d != c && d.start >= c.start && d.end <= c.end {
+					inner = true
+					break
+				}
+			}
+			if !inner {
+				return c
+			}
+		}
+	}
+	return nil
+}
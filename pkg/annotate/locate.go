@@ -0,0 +1,66 @@
+package annotate
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"geo-checker/pkg/scorer"
+)
+
+// Location is the 1-based line and column range in a source file where a
+// finding's evidence is quoted, the end being exclusive. Line is 0 for
+// findings with no position in the source.
+type Location struct {
+	Finding scorer.Finding `json:"finding"`
+	Line    int            `json:"line,omitempty"`
+	Col     int            `json:"col,omitempty"`
+	EndLine int            `json:"end_line,omitempty"`
+	EndCol  int            `json:"end_col,omitempty"`
+}
+
+// Locate returns the location of every finding in src, an HTML or markdown
+// source: the innermost HTML element quoting its first placed evidence, or
+// the quote itself in markdown (the quoting line when markup interrupts
+// it).
+func Locate(src string, markdown bool, findings []scorer.Finding) []Location {
+	var blocks []*block
+	var lines []mdLine
+	if markdown {
+		lines, _ = mdLines(src)
+	} else {
+		blocks, _ = htmlBlocks(src)
+	}
+
+	out := make([]Location, 0, len(findings))
+	for _, n := range notes(findings) {
+		loc := Location{Finding: n.finding}
+		for _, needles := range n.evidence {
+			start, end := -1, -1
+			if markdown {
+				if line, needle := findLine(lines, needles); line != nil {
+					start, end = line.start, line.end
+					if i := strings.Index(src[line.start:line.end], needle); i >= 0 {
+						start += i
+						end = start + len(needle)
+					}
+				}
+			} else if b := innermost(blocks, needles); b != nil {
+				start, end = b.start, b.end
+			}
+			if start >= 0 {
+				loc.Line, loc.Col = lineCol(src, start)
+				loc.EndLine, loc.EndCol = lineCol(src, end)
+				break
+			}
+		}
+		out = append(out, loc)
+	}
+	return out
+}
+
+// lineCol converts a byte offset in src to a 1-based line and column in
+// characters.
+func lineCol(src string, offset int) (int, int) {
+	lineStart := strings.LastIndexByte(src[:offset], '\n') + 1
+	return strings.Count(src[:offset], "\n") + 1, utf8.RuneCountInString(src[lineStart:offset]) + 1
+}
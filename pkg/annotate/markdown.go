@@ -0,0 +1,147 @@
+package annotate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"geo-checker/pkg/scorer"
+)
+
+var (
+	mdLink   = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	mdPrefix = regexp.MustCompile(`^\s*(?:#{1,6}\s+|>\s*|[-*+]\s+|\d+[.)]\s+)*`)
+	mdMarks  = strings.NewReplacer("**", "", "__", "", "*", "", "`", "", "~~", "")
+)
+
+// mdLine is a line of a markdown source, with the offset of its end (before
+// the newline) and its text without markup.
+type mdLine struct {
+	start, end int
+	plain      string
+}
+
+// Markdown returns src with each finding written as a comment at the end
+// of the line quoting its evidence, in the given style: an HTML comment, or
+// with StyleCritic a CriticMarkup comment after the quoted text
+// highlighted where it appears verbatim. Findings that cannot be placed
+// that way are listed at the top, after any front matter. It also returns
+// the number of findings placed inline.
+func Markdown(src string, findings []scorer.Finding, style string) (string, int, error) {
+	if style != StyleComment && style != StyleCritic {
+		return "", 0, fmt.Errorf("unknown annotation style %q (comment, critic)", style)
+	}
+	lines, top := mdLines(src)
+
+	var insertions []insertion
+	var unplaced []string
+	highlighted := make(map[int]bool)
+	placed := 0
+	for _, n := range notes(findings) {
+		seen := make(map[int]bool)
+		for _, needles := range n.evidence {
+			line, needle := findLine(lines, needles)
+			if line == nil || seen[line.start] {
+				continue
+			}
+			seen[line.start] = true
+			text := label(n.finding)
+			if style == StyleComment {
+				insertions = append(insertions, insertion{line.end, " " + comment(text)})
+				continue
+			}
+			if i := strings.Index(src[line.start:line.end], needle); i >= 0 {
+				start := line.start + i
+				end := start + len(needle)
+				if !highlighted[start] {
+					highlighted[start] = true
+					insertions = append(insertions, insertion{start, "{=="}, insertion{end, "==}"})
+				}
+				insertions = append(insertions, insertion{end, critic(text)})
+			} else {
+				insertions = append(insertions, insertion{line.end, " " + critic(text)})
+			}
+		}
+		if len(seen) > 0 {
+			placed++
+		} else {
+			unplaced = append(unplaced, label(n.finding))
+		}
+	}
+
+	if len(unplaced) > 0 {
+		var text string
+		if style == StyleComment {
+			text = comment("GEO page-level findings:\n  "+strings.Join(unplaced, "\n  ")+"\n") + "\n\n"
+		} else {
+			for _, u := range unplaced {
+				text += critic(u) + "\n"
+			}
+			text += "\n"
+		}
+		insertions = append(insertions, insertion{top, text})
+	}
+	return apply(src, insertions), placed, nil
+}
+
+// mdLines splits src into lines and returns them with the offset the
+// content starts at, after any front matter.
+func mdLines(src string) ([]mdLine, int) {
+	var lines []mdLine
+	top := 0
+	for start := 0; start < len(src); {
+		end := strings.IndexByte(src[start:], '\n')
+		next := start + end + 1
+		if end < 0 {
+			end, next = len(src)-start, len(src)
+		}
+		text := strings.TrimSuffix(src[start:start+end], "\r")
+		lines = append(lines, mdLine{start: start, end: start + len(text), plain: mdPlain(text)})
+		start = next
+	}
+
+	if len(lines) > 0 && strings.TrimSpace(src[lines[0].start:lines[0].end]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(src[lines[i].start:lines[i].end]) == "---" {
+				top = min(lines[i].end+1, len(src))
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+	return lines, top
+}
+
+// mdPlain returns the text of a markdown line without block markers,
+// emphasis and link targets.
+func mdPlain(line string) string {
+	line = mdPrefix.ReplaceAllString(line, "")
+	line = mdLink.ReplaceAllString(line, "$1")
+	return plain(mdMarks.Replace(line))
+}
+
+// findLine returns the first line quoting the first of needles any line
+// quotes or, for text wrapped over several lines, the last line of the
+// first paragraph quoting it, and the needle found.
+func findLine(lines []mdLine, needles []string) (*mdLine, string) {
+	for _, needle := range needles {
+		for i := range lines {
+			if strings.Contains(lines[i].plain, needle) {
+				return &lines[i], needle
+			}
+		}
+		var paragraph []string
+		for i := range lines {
+			if lines[i].plain == "" {
+				paragraph = paragraph[:0]
+				continue
+			}
+			paragraph = append(paragraph, lines[i].plain)
+			last := i == len(lines)-1 || lines[i+1].plain == ""
+			if last && strings.Contains(strings.Join(paragraph, " "), needle) {
+				return &lines[i], needle
+			}
+		}
+	}
+	return nil, ""
+}
@@ -0,0 +1,371 @@
+// Package archive stores pages as they were served when they were scored,
+// as raw HTML files or WARC records, so that what a page looked like at
+// the time can be shown later and the page scored again after the live
+// one has changed.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"geo-checker/internal/webpage"
+)
+
+// Archive formats.
+const (
+	FormatHTML = "html" // one file per distinct body, described in index.jsonl
+	FormatWARC = "warc" // WARC/1.1 response records in a daily .warc.gz
+)
+
+// indexFile describes the responses of an html archive, one per line.
+const indexFile = "index.jsonl"
+
+// Record locates an archived response; results carry it under "archive".
+type Record struct {
+	Format    string    `json:"format"`
+	Path      string    `json:"path"`                // file holding the response
+	RecordID  string    `json:"record_id,omitempty"` // WARC-Record-ID, in warc archives
+	SHA256    string    `json:"sha256"`              // of the body
+	Status    int       `json:"status"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// indexEntry is a line of an html archive's index.
+type indexEntry struct {
+	URL       string      `json:"url"`
+	FinalURL  string      `json:"final_url,omitempty"`
+	Status    int         `json:"status"`
+	Proto     string      `json:"proto"`
+	Header    http.Header `json:"header"`
+	File      string      `json:"file"`
+	SHA256    string      `json:"sha256"`
+	FetchedAt time.Time   `json:"fetched_at"`
+}
+
+// Store writes responses to an archive directory. It is safe for
+// concurrent use.
+type Store struct {
+	dir    string
+	format string
+	mu     sync.Mutex
+}
+
+// New returns a store writing in format to dir, which is created if needed.
+func New(dir, format string) (*Store, error) {
+	if format != FormatHTML && format != FormatWARC {
+		return nil, fmt.Errorf("unknown archive format %q (html, warc)", format)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	return &Store{dir: dir, format: format}, nil
+}
+
+// Save archives the response served for url.
+func (s *Store) Save(url string, resp *webpage.Response) (*Record, error) {
+	sum := sha256.Sum256(resp.Body)
+	rec := &Record{
+		Format:    s.format,
+		SHA256:    hex.EncodeToString(sum[:]),
+		Status:    resp.Status,
+		FetchedAt: resp.FetchedAt.UTC(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.format == FormatWARC {
+		err = s.saveWARC(url, resp, rec)
+	} else {
+		err = s.saveHTML(url, resp, rec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive %s: %w", url, err)
+	}
+	return rec, nil
+}
+
+// saveHTML writes the body to a file named by its hash, so unchanged pages
+// are stored once, and describes the response in the index.
+func (s *Store) saveHTML(url string, resp *webpage.Response, rec *Record) error {
+	rec.Path = filepath.Join(s.dir, rec.SHA256[:32]+".html")
+	if _, err := os.Stat(rec.Path); os.IsNotExist(err) {
+		if err := os.WriteFile(rec.Path, resp.Body, 0o644); err != nil {
+			return err
+		}
+	}
+	line, err := json.Marshal(indexEntry{
+		URL:       url,
+		FinalURL:  resp.URL,
+		Status:    resp.Status,
+		Proto:     resp.Proto,
+		Header:    resp.Header,
+		File:      filepath.Base(rec.Path),
+		SHA256:    rec.SHA256,
+		FetchedAt: rec.FetchedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return appendFile(filepath.Join(s.dir, indexFile), append(line, '\n'))
+}
+
+// saveWARC appends a response record to the archive of the day, starting
+// the file with a warcinfo record.
+func (s *Store) saveWARC(url string, resp *webpage.Response, rec *Record) error {
+	rec.Path = filepath.Join(s.dir, "geo-checker-"+rec.FetchedAt.Format("20060102")+".warc.gz")
+	var data bytes.Buffer
+	if _, err := os.Stat(rec.Path); os.IsNotExist(err) {
+		info := "software: geo-checker\r\nformat: WARC File Format 1.1\r\n"
+		if err := writeRecord(&data, "warcinfo", "application/warc-fields", []byte(info), map[string]string{
+			"WARC-Date":     rec.FetchedAt.Format(time.RFC3339),
+			"WARC-Filename": filepath.Base(rec.Path),
+		}); err != nil {
+			return err
+		}
+	}
+
+	var block bytes.Buffer
+	proto := resp.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	fmt.Fprintf(&block, "%s %d %s\r\n", proto, resp.Status, http.StatusText(resp.Status))
+	header := resp.Header.Clone()
+	// The body is stored decoded and possibly cut at the size limit
+	header.Del("Content-Encoding")
+	header.Set("Content-Length", strconv.Itoa(len(resp.Body)))
+	header.Write(&block)
+	block.WriteString("\r\n")
+	block.Write(resp.Body)
+
+	rec.RecordID = newRecordID()
+	sum := sha256.Sum256(resp.Body)
+	if err := writeRecord(&data, "response", "application/http;msgtype=response", block.Bytes(), map[string]string{
+		"WARC-Record-ID":      rec.RecordID,
+		"WARC-Date":           rec.FetchedAt.Format(time.RFC3339Nano),
+		"WARC-Target-URI":     url,
+		"WARC-Payload-Digest": "sha256:" + hex.EncodeToString(sum[:]),
+	}); err != nil {
+		return err
+	}
+	return appendFile(rec.Path, data.Bytes())
+}
+
+// writeRecord writes a WARC record to w as a gzip member of its own, as
+// WARC readers expect of .warc.gz files.
+func writeRecord(w io.Writer, kind, contentType string, block []byte, fields map[string]string) error {
+	gz := gzip.NewWriter(w)
+	fmt.Fprintf(gz, "WARC/1.1\r\nWARC-Type: %s\r\n", kind)
+	if _, ok := fields["WARC-Record-ID"]; !ok {
+		fmt.Fprintf(gz, "WARC-Record-ID: %s\r\n", newRecordID())
+	}
+	for _, name := range []string{"WARC-Record-ID", "WARC-Date", "WARC-Target-URI", "WARC-Filename", "WARC-Payload-Digest"} {
+		if v, ok := fields[name]; ok {
+			fmt.Fprintf(gz, "%s: %s\r\n", name, v)
+		}
+	}
+	fmt.Fprintf(gz, "Content-Type: %s\r\nContent-Length: %d\r\n\r\n", contentType, len(block))
+	gz.Write(block)
+	gz.Write([]byte("\r\n\r\n"))
+	return gz.Close()
+}
+
+func newRecordID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("<urn:uuid:%x-%x-%x-%x-%x>", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func appendFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads the latest response archived for url from path: an html
+// archive's body file or directory, or a .warc or .warc.gz file.
+func Load(path, url string) (*webpage.Response, error) {
+	var resp *webpage.Response
+	var err error
+	if strings.HasSuffix(path, ".warc") || strings.HasSuffix(path, ".warc.gz") {
+		resp, err = loadWARC(path, url, "")
+	} else {
+		resp, err = loadHTML(path, url)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("no response for %s archived in %s", url, path)
+	}
+	return resp, nil
+}
+
+// Open reads the response rec locates, archived for url.
+func Open(rec *Record, url string) (*webpage.Response, error) {
+	if rec.Format != FormatWARC {
+		return Load(rec.Path, url)
+	}
+	resp, err := loadWARC(rec.Path, url, rec.RecordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", rec.Path, err)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("record %s of %s is not in %s", rec.RecordID, url, rec.Path)
+	}
+	return resp, nil
+}
+
+func loadHTML(path, url string) (*webpage.Response, error) {
+	dir, file := path, ""
+	if info, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		dir, file = filepath.Dir(path), filepath.Base(path)
+	}
+	index, err := os.ReadFile(filepath.Join(dir, indexFile))
+	if err != nil {
+		return nil, err
+	}
+	var latest *indexEntry
+	for _, line := range bytes.Split(index, []byte("\n")) {
+		var e indexEntry
+		if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &e) != nil {
+			continue
+		}
+		if e.URL == url && (file == "" || e.File == file) && (latest == nil || !e.FetchedAt.Before(latest.FetchedAt)) {
+			latest = &e
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	body, err := os.ReadFile(filepath.Join(dir, latest.File))
+	if err != nil {
+		return nil, err
+	}
+	return &webpage.Response{
+		URL:       latest.FinalURL,
+		Status:    latest.Status,
+		Proto:     latest.Proto,
+		Header:    latest.Header,
+		Body:      body,
+		FetchedAt: latest.FetchedAt,
+	}, nil
+}
+
+// loadWARC reads the latest response record of url, or the record with
+// the given ID.
+func loadWARC(path, url, id string) (*webpage.Response, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var latest *webpage.Response
+	br := bufio.NewReader(r)
+	for {
+		fields, block, err := readRecord(br)
+		if errors.Is(err, io.EOF) {
+			return latest, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if fields["warc-type"] != "response" || fields["warc-target-uri"] != url || (id != "" && fields["warc-record-id"] != id) {
+			continue
+		}
+		httpResp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(block)), nil)
+		if err != nil {
+			return nil, fmt.Errorf("malformed response record for %s: %w", url, err)
+		}
+		body, err := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		fetched, _ := time.Parse(time.RFC3339, fields["warc-date"])
+		latest = &webpage.Response{
+			URL:       url,
+			Status:    httpResp.StatusCode,
+			Proto:     httpResp.Proto,
+			Header:    httpResp.Header,
+			Body:      body,
+			FetchedAt: fetched,
+		}
+	}
+}
+
+// readRecord reads the next WARC record: its header fields, by lower-case
+// name, and its block.
+func readRecord(r *bufio.Reader) (map[string]string, []byte, error) {
+	version, err := r.ReadString('\n')
+	for err == nil && strings.TrimSpace(version) == "" {
+		version, err = r.ReadString('\n')
+	}
+	if err != nil {
+		if errors.Is(err, io.EOF) && strings.TrimSpace(version) == "" {
+			return nil, nil, io.EOF
+		}
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(version, "WARC/") {
+		return nil, nil, fmt.Errorf("malformed WARC record: %q", strings.TrimSpace(version))
+	}
+	fields := make(map[string]string)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			fields[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+		}
+	}
+	length, err := strconv.Atoi(fields["content-length"])
+	if err != nil || length < 0 {
+		return nil, nil, fmt.Errorf("malformed WARC record: bad Content-Length")
+	}
+	block := make([]byte, length)
+	if _, err := io.ReadFull(r, block); err != nil {
+		return nil, nil, err
+	}
+	return fields, block, nil
+}
@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"geo-checker/internal/webpage"
+)
+
+func response(body string, at time.Time) *webpage.Response {
+	return &webpage.Response{
+		URL:       "https://example.com/a/",
+		Status:    200,
+		Proto:     "HTTP/1.1",
+		Header:    http.Header{"Content-Type": {"text/html; charset=utf-8"}, "X-Robots-Tag": {"noai"}},
+		Body:      []byte(body),
+		FetchedAt: at,
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	url := "https://example.com/a"
+	first := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, format := range []string{FormatHTML, FormatWARC} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := New(dir, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			old, err := store.Save(url, response("<h1>Before</h1>", first))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.Save("https://example.com/b", response("<h1>Other</h1>", first)); err != nil {
+				t.Fatal(err)
+			}
+			latest, err := store.Save(url, response("<h1>After</h1>", first.Add(time.Hour)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format == FormatWARC && (old.Path != latest.Path || old.RecordID == latest.RecordID) {
+				t.Errorf("records %+v and %+v", old, latest)
+			}
+
+			// The latest response of the URL, whole
+			path := latest.Path
+			if format == FormatHTML {
+				path = dir
+			}
+			resp, err := Load(path, url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(resp.Body) != "<h1>After</h1>" || resp.Status != 200 || !resp.FetchedAt.Equal(first.Add(time.Hour)) {
+				t.Errorf("loaded %d %q fetched %v", resp.Status, resp.Body, resp.FetchedAt)
+			}
+			if resp.Header.Get("X-Robots-Tag") != "noai" || resp.Header.Get("Content-Type") != "text/html; charset=utf-8" {
+				t.Errorf("headers = %v", resp.Header)
+			}
+
+			// The response a result recorded, even once the page changed
+			resp, err = Open(old, url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(resp.Body) != "<h1>Before</h1>" {
+				t.Errorf("opened %q", resp.Body)
+			}
+
+			if _, err := Load(path, "https://example.com/missing"); err == nil {
+				t.Error("missing URL loaded")
+			}
+		})
+	}
+}
+
+func TestArchiveDeduplicatesHTML(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(dir, FormatHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if _, err := store.Save("https://example.com/a", response("<p>Same</p>", at.Add(time.Duration(i)*time.Hour))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.html"))
+	if len(files) != 1 {
+		t.Errorf("%d body files for one distinct page", len(files))
+	}
+	if _, err := os.Stat(filepath.Join(dir, indexFile)); err != nil {
+		t.Error(err)
+	}
+	if _, err := New(dir, "zip"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
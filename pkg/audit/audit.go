@@ -0,0 +1,301 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/export"
+	"geo-checker/pkg/formatter"
+	"geo-checker/pkg/notify"
+	"geo-checker/pkg/tickets"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Exit codes returned by an audit run, from most to least severe. A run that
+// breaches thresholds and also fails to deliver a notification exits with
+// ExitThreshold; delivery problems are still listed in the outcome.
+const (
+	ExitOK        = 0
+	ExitError     = 1 // invalid definition or the run could not start
+	ExitThreshold = 2 // score thresholds not met, or the average regressed
+	ExitFailures  = 3 // more URLs failed to analyze than allowed
+	ExitDelivery  = 4 // analysis passed but an export or notification failed
+)
+
+// Definition is a complete, non-interactive audit read from a YAML (or JSON)
+// file.
+type Definition struct {
+	Name        string   `yaml:"name"`
+	Mode        string   `yaml:"mode"`
+	Provider    string   `yaml:"provider"`
+	Model       string   `yaml:"model"`
+	Concurrency int      `yaml:"concurrency"`
+	URLs        []string `yaml:"urls"`
+	URLsFile    string   `yaml:"urls_file"`
+
+	// Compare is the report of a previous run, read before exports can
+	// overwrite it, that max_drop and the email digest compare with;
+	// notify.email.compare is used when it is empty
+	Compare string `yaml:"compare"`
+
+	Thresholds struct {
+		MinAverage   int `yaml:"min_average"`
+		MinPageScore int `yaml:"min_page_score"`
+		MaxFailed    int `yaml:"max_failed"`
+		// MaxDrop is how many points the average may fall below the
+		// compared run's; 0 does not check
+		MaxDrop int `yaml:"max_drop"`
+	} `yaml:"thresholds"`
+
+	Export struct {
+		JSON     string `yaml:"json"`
+		Markdown string `yaml:"markdown"`
+		Sheets   struct {
+			ID          string `yaml:"id"`
+			Range       string `yaml:"range"`
+			Credentials string `yaml:"credentials"`
+		} `yaml:"sheets"`
+	} `yaml:"export"`
+
+	Notify struct {
+		Email struct {
+			To      []string `yaml:"to"`
+			Subject string   `yaml:"subject"`
+			Compare string   `yaml:"compare"`
+		} `yaml:"email"`
+		Tickets struct {
+			Tracker string   `yaml:"tracker"`
+			Project string   `yaml:"project"`
+			Labels  []string `yaml:"labels"`
+			All     bool     `yaml:"all"`
+		} `yaml:"tickets"`
+	} `yaml:"notify"`
+}
+
+// Outcome summarizes a run and carries its exit code.
+type Outcome struct {
+	Name       string             `json:"name"`
+	ExitCode   int                `json:"exit_code"`
+	Total      int                `json:"total"`
+	Failed     int                `json:"failed"`
+	Average    int                `json:"average"`
+	Violations []string           `json:"violations,omitempty"`
+	Delivery   []string           `json:"delivery_errors,omitempty"`
+	Results    []*bulk.BulkResult `json:"-"`
+}
+
+// Load reads and validates an audit definition. Relative paths inside the
+// file are resolved against the file's directory. Unknown keys are errors,
+// so that a misspelled threshold fails the run instead of going unchecked.
+func Load(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit definition: %w", err)
+	}
+
+	def := &Definition{Mode: "local", Provider: "claude", Concurrency: 5}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(def); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse audit definition: %w", err)
+	}
+	if def.Compare == "" {
+		def.Compare = def.Notify.Email.Compare
+	}
+
+	dir := filepath.Dir(path)
+	for _, p := range []*string{&def.URLsFile, &def.Export.JSON, &def.Export.Markdown, &def.Compare, &def.Notify.Email.Compare, &def.Export.Sheets.Credentials} {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(dir, *p)
+		}
+	}
+
+	if def.URLsFile != "" {
+		urls, err := bulk.ReadURLFile(def.URLsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read urls_file: %w", err)
+		}
+		def.URLs = append(def.URLs, urls...)
+	}
+	if len(def.URLs) == 0 {
+		return nil, fmt.Errorf("audit definition has no urls")
+	}
+	for _, u := range def.URLs {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			return nil, fmt.Errorf("invalid URL in audit definition: %s", u)
+		}
+	}
+	if def.Name == "" {
+		def.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return def, nil
+}
+
+// Config builds the analyzer configuration for the definition.
+func (d *Definition) Config() *config.Config {
+	return &config.Config{
+		LLMProvider:  d.Provider,
+		Model:        d.Model,
+		OutputFormat: "json", // no spinners or progress in scheduled runs
+		Mode:         d.Mode,
+		Concurrent:   d.Concurrency,
+		MaxTokens:    4000,
+		Temperature:  0.7,
+		Timeout:      30,
+	}
+}
+
+// Run analyzes every URL, evaluates thresholds, then performs exports and
+// notifications. Delivery steps run even when thresholds fail so that the
+// people who need to act are told.
+func Run(ctx context.Context, def *Definition) (*Outcome, error) {
+	// Load the comparison report before exports possibly overwrite it
+	var previous []*bulk.BulkResult
+	if def.Compare != "" {
+		if _, err := os.Stat(def.Compare); err == nil {
+			loaded, err := bulk.LoadReport(def.Compare)
+			if err != nil {
+				return nil, err
+			}
+			previous = loaded
+		}
+	}
+
+	results, err := bulk.New(def.Config()).ProcessURLs(ctx, def.URLs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process audit URLs: %w", err)
+	}
+
+	out, scoresOK, failuresOK := evaluate(def, results, previous)
+	out.Delivery = deliver(ctx, def, results, previous)
+
+	switch {
+	case !scoresOK:
+		out.ExitCode = ExitThreshold
+	case !failuresOK:
+		out.ExitCode = ExitFailures
+	case len(out.Delivery) > 0:
+		out.ExitCode = ExitDelivery
+	}
+	return out, nil
+}
+
+// evaluate checks the results against the definition's thresholds, and
+// their average against the previous run's, and reports separately whether
+// score and failure limits were met.
+func evaluate(def *Definition, results, previous []*bulk.BulkResult) (out *Outcome, scoresOK, failuresOK bool) {
+	out = &Outcome{Name: def.Name, Total: len(results), Results: results}
+	scoresOK, failuresOK = true, true
+
+	total, scored := 0, 0
+	for _, r := range results {
+		if r.Error != nil || r.Result == nil {
+			out.Failed++
+			continue
+		}
+		total += r.Result.Score
+		scored++
+		if def.Thresholds.MinPageScore > 0 && r.Result.Score < def.Thresholds.MinPageScore {
+			out.Violations = append(out.Violations, fmt.Sprintf("%s scored %d (minimum %d)", r.URL, r.Result.Score, def.Thresholds.MinPageScore))
+			scoresOK = false
+		}
+	}
+
+	if scored > 0 {
+		out.Average = total / scored
+		if def.Thresholds.MinAverage > 0 && out.Average < def.Thresholds.MinAverage {
+			out.Violations = append(out.Violations, fmt.Sprintf("average score %d is below %d", out.Average, def.Thresholds.MinAverage))
+			scoresOK = false
+		}
+		if before, ok := average(previous); ok && def.Thresholds.MaxDrop > 0 && before-out.Average > def.Thresholds.MaxDrop {
+			out.Violations = append(out.Violations, fmt.Sprintf("average score fell from %d to %d (maximum drop %d)", before, out.Average, def.Thresholds.MaxDrop))
+			scoresOK = false
+		}
+	}
+	if out.Failed > def.Thresholds.MaxFailed {
+		out.Violations = append(out.Violations, fmt.Sprintf("%d URLs failed to analyze (maximum %d)", out.Failed, def.Thresholds.MaxFailed))
+		failuresOK = false
+	}
+	return out, scoresOK, failuresOK
+}
+
+// average returns the average score of the results analyzed, and false
+// when there are none.
+func average(results []*bulk.BulkResult) (int, bool) {
+	total, scored := 0, 0
+	for _, r := range results {
+		if r.Error == nil && r.Result != nil {
+			total += r.Result.Score
+			scored++
+		}
+	}
+	if scored == 0 {
+		return 0, false
+	}
+	return total / scored, true
+}
+
+func deliver(ctx context.Context, def *Definition, results, previous []*bulk.BulkResult) []string {
+	var errs []string
+	fail := func(step string, err error) {
+		errs = append(errs, fmt.Sprintf("%s: %v", step, err))
+	}
+
+	if def.Export.JSON != "" {
+		if err := os.WriteFile(def.Export.JSON, []byte(formatter.New("json").FormatBulkResults(results)), 0o644); err != nil {
+			fail("json export", err)
+		}
+	}
+	if def.Export.Markdown != "" {
+		if err := os.WriteFile(def.Export.Markdown, []byte(formatter.New("markdown").FormatBulkResults(results)), 0o644); err != nil {
+			fail("markdown export", err)
+		}
+	}
+
+	if sheets := def.Export.Sheets; sheets.ID != "" {
+		if sheets.Range == "" {
+			sheets.Range = "Sheet1"
+		}
+		exporter, err := export.NewSheetsExporter(sheets.ID, sheets.Range, sheets.Credentials)
+		if err == nil {
+			err = exporter.Append(ctx, results)
+		}
+		if err != nil {
+			fail("sheets export", err)
+		}
+	}
+
+	if email := def.Notify.Email; len(email.To) > 0 {
+		subject := email.Subject
+		if subject == "" {
+			subject = "GEO audit digest: " + def.Name
+		}
+		smtpCfg, err := notify.SMTPConfigFromEnv()
+		if err == nil {
+			err = notify.SendDigest(smtpCfg, email.To, subject, notify.BuildDigest(def.Name, results, previous, 5))
+		}
+		if err != nil {
+			fail("email", err)
+		}
+	}
+
+	if t := def.Notify.Tickets; t.Tracker != "" {
+		opts := tickets.Options{Project: t.Project, Labels: t.Labels, All: t.All}
+		tracker, err := tickets.NewTracker(t.Tracker, opts)
+		if err != nil {
+			fail("tickets", err)
+		} else if report := tickets.Sync(ctx, tracker, tickets.BuildIssues(results, opts)); len(report.Errors) > 0 {
+			fail("tickets", fmt.Errorf("failed to create %d tickets: %w", len(report.Errors), report.Errors[0]))
+		}
+	}
+
+	return errs
+}
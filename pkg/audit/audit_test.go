@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeDefinition(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	for name, yaml := range map[string]string{
+		"top level": "urls: [https://example.com]\ntreshold:\n  min_average: 60\n",
+		"nested":    "urls: [https://example.com]\nthresholds:\n  min_avg: 60\n",
+	} {
+		if _, err := Load(writeDefinition(t, yaml)); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("%s: err = %v, want an unknown field error", name, err)
+		}
+	}
+
+	def, err := Load(writeDefinition(t, "urls: [https://example.com]\nthresholds:\n  min_average: 60\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Thresholds.MinAverage != 60 || def.Mode != "local" {
+		t.Errorf("definition = %+v", def)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`<html><head><title>Opening hours</title></head><body><main>
+<h1>Opening hours</h1><p>The library is open from 9am to 6pm on weekdays and from 10am to 4pm on Saturdays.</p>
+</main></body></html>`))
+	}))
+	defer ts.Close()
+
+	// A previous run that scored far higher than this page can
+	previous := filepath.Join(t.TempDir(), "previous.json")
+	if err := os.WriteFile(previous, []byte(`[{"url":"`+ts.URL+`/","result":{"score":100}}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		urls       string
+		thresholds string
+		compare    string
+		want       int
+		violation  string
+	}{
+		{"pass", ts.URL + "/", "min_average: 1", "", ExitOK, ""},
+		{"threshold breach", ts.URL + "/", "min_average: 100", "", ExitThreshold, "is below 100"},
+		{"regression", ts.URL + "/", "max_drop: 5", previous, ExitThreshold, "fell from 100"},
+		{"regression within max_drop", ts.URL + "/", "max_drop: 100", previous, ExitOK, ""},
+		{"fetch error", ts.URL + "/missing", "max_failed: 0", "", ExitFailures, "1 URLs failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "urls: [" + tt.urls + "]\nconcurrency: 1\nthresholds:\n  " + tt.thresholds + "\n"
+			if tt.compare != "" {
+				yaml += "compare: " + tt.compare + "\n"
+			}
+			def, err := Load(writeDefinition(t, yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := Run(context.Background(), def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.ExitCode != tt.want {
+				t.Errorf("exit code = %d, want %d (violations %q)", out.ExitCode, tt.want, out.Violations)
+			}
+			joined := strings.Join(out.Violations, "\n")
+			if tt.violation == "" && joined != "" || !strings.Contains(joined, tt.violation) {
+				t.Errorf("violations = %q, want %q", out.Violations, tt.violation)
+			}
+		})
+	}
+}
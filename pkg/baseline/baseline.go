@@ -0,0 +1,191 @@
+// Package baseline records the scores of a set of pages and later checks
+// new scores against them, so refactors of large content sites can be
+// guarded against regressions.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/scorer"
+)
+
+// FormatVersion changes whenever the snapshot file format does.
+const FormatVersion = 1
+
+// Snapshot is a baseline file: the scores of every page of a source, a
+// file of URLs or a directory of HTML files.
+type Snapshot struct {
+	Version       int       `json:"version"`
+	ScorerVersion string    `json:"scorer_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	Source        string    `json:"source"`
+	Directory     bool      `json:"directory,omitempty"` // Source is a directory rather than a URL file
+	Mode          string    `json:"mode"`
+	Profile       string    `json:"profile,omitempty"`
+	Pages         []Page    `json:"pages"`
+}
+
+// Page is the score of one page, keyed by its URL or file path.
+type Page struct {
+	Key     string         `json:"key"`
+	Score   int            `json:"score"`
+	Pillars map[string]int `json:"pillars,omitempty"` // local pillar scores
+	Error   string         `json:"error,omitempty"`   // why the page could not be analyzed
+}
+
+// PageOf summarizes an analysis outcome.
+func PageOf(key string, result *analyzer.Result, err *analyzer.Error) Page {
+	page := Page{Key: key}
+	switch {
+	case err != nil:
+		page.Error = err.Message
+	case result == nil:
+		page.Error = "no result"
+	default:
+		page.Score = result.Score
+		if local := result.LocalScore; local != nil {
+			page.Pillars = make(map[string]int)
+			for _, key := range local.PillarKeys() {
+				detail, _ := local.Pillar(key)
+				page.Pillars[key] = detail.Score
+			}
+		}
+	}
+	return page
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if s.Version != FormatVersion {
+		return nil, fmt.Errorf("baseline %s has format version %d, want %d: write it again with the baseline command", path, s.Version, FormatVersion)
+	}
+	return &s, nil
+}
+
+// Save writes the snapshot, with its pages sorted by key so baselines
+// diff cleanly under version control.
+func (s *Snapshot) Save(path string) error {
+	s.Version = FormatVersion
+	if s.ScorerVersion == "" {
+		s.ScorerVersion = scorer.Version
+	}
+	sort.Slice(s.Pages, func(i, j int) bool { return s.Pages[i].Key < s.Pages[j].Key })
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Regression is a page that scores worse than its baseline.
+type Regression struct {
+	Key     string         `json:"key"`
+	Before  int            `json:"before"`
+	After   int            `json:"after"`
+	Delta   int            `json:"delta"`
+	Pillars []PillarChange `json:"pillars,omitempty"` // the pillars that dropped
+	Error   string         `json:"error,omitempty"`   // set when the page no longer analyzes
+}
+
+// PillarChange is the change of one pillar's score.
+type PillarChange struct {
+	Pillar string `json:"pillar"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// Report is the outcome of a check against a baseline.
+type Report struct {
+	Baseline      string       `json:"baseline"`
+	MaxRegression int          `json:"max_regression"`
+	Checked       int          `json:"checked"` // pages scored both times
+	Improved      int          `json:"improved"`
+	Regressions   []Regression `json:"regressions"`
+	Missing       []string     `json:"missing,omitempty"` // in the baseline, not analyzed now
+	Added         []string     `json:"added,omitempty"`   // analyzed now, not in the baseline
+
+	// ScorerChanged is set when the scoring rules changed since the
+	// baseline was taken, so differences may not come from the content
+	ScorerChanged bool `json:"scorer_changed,omitempty"`
+}
+
+// Passed reports whether no page regressed.
+func (r *Report) Passed() bool {
+	return len(r.Regressions) == 0
+}
+
+// Compare checks pages against the baseline. A page regresses when its
+// score drops by more than maxRegression points, or when it no longer
+// analyzes; pages that failed in the baseline are not judged.
+// Regressions are ordered from the largest drop.
+func (s *Snapshot) Compare(pages []Page, maxRegression int) *Report {
+	report := &Report{
+		Baseline:      s.Source,
+		MaxRegression: maxRegression,
+		Regressions:   []Regression{},
+		ScorerChanged: s.ScorerVersion != scorer.Version,
+	}
+	before := make(map[string]Page, len(s.Pages))
+	for _, p := range s.Pages {
+		before[p.Key] = p
+	}
+	seen := make(map[string]bool, len(pages))
+
+	for _, after := range pages {
+		seen[after.Key] = true
+		base, ok := before[after.Key]
+		switch {
+		case !ok:
+			report.Added = append(report.Added, after.Key)
+			continue
+		case base.Error != "":
+			continue
+		case after.Error != "":
+			report.Regressions = append(report.Regressions, Regression{Key: after.Key, Before: base.Score, Delta: -base.Score, Error: after.Error})
+			continue
+		}
+
+		report.Checked++
+		delta := after.Score - base.Score
+		if delta > 0 {
+			report.Improved++
+		}
+		if -delta <= maxRegression {
+			continue
+		}
+		r := Regression{Key: after.Key, Before: base.Score, After: after.Score, Delta: delta}
+		for pillar, was := range base.Pillars {
+			if now, ok := after.Pillars[pillar]; ok && now < was {
+				r.Pillars = append(r.Pillars, PillarChange{Pillar: pillar, Before: was, After: now})
+			}
+		}
+		sort.Slice(r.Pillars, func(i, j int) bool {
+			return r.Pillars[i].After-r.Pillars[i].Before < r.Pillars[j].After-r.Pillars[j].Before
+		})
+		report.Regressions = append(report.Regressions, r)
+	}
+
+	for _, p := range s.Pages {
+		if !seen[p.Key] {
+			report.Missing = append(report.Missing, p.Key)
+		}
+	}
+	sort.SliceStable(report.Regressions, func(i, j int) bool { return report.Regressions[i].Delta < report.Regressions[j].Delta })
+	return report
+}
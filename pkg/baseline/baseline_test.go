@@ -0,0 +1,82 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"geo-checker/pkg/scorer"
+)
+
+func TestCompare(t *testing.T) {
+	snapshot := &Snapshot{
+		ScorerVersion: scorer.Version,
+		Pages: []Page{
+			{Key: "a.html", Score: 80, Pillars: map[string]int{"content_structure": 90, "accessibility": 70}},
+			{Key: "b.html", Score: 70},
+			{Key: "c.html", Score: 60},
+			{Key: "d.html", Score: 50},
+			{Key: "e.html", Error: "fetch failed"},
+			{Key: "gone.html", Score: 40},
+		},
+	}
+	pages := []Page{
+		{Key: "a.html", Score: 70, Pillars: map[string]int{"content_structure": 60, "accessibility": 75}},
+		{Key: "b.html", Score: 65}, // within the tolerance
+		{Key: "c.html", Score: 68},
+		{Key: "d.html", Error: "timeout"},
+		{Key: "e.html", Score: 10}, // not judged: failed in the baseline
+		{Key: "new.html", Score: 90},
+	}
+
+	report := snapshot.Compare(pages, 5)
+	want := []Regression{
+		{Key: "d.html", Before: 50, Delta: -50, Error: "timeout"},
+		{Key: "a.html", Before: 80, After: 70, Delta: -10, Pillars: []PillarChange{{Pillar: "content_structure", Before: 90, After: 60}}},
+	}
+	if !reflect.DeepEqual(report.Regressions, want) {
+		t.Errorf("regressions = %+v, want %+v", report.Regressions, want)
+	}
+	if report.Passed() {
+		t.Error("report passed with regressions")
+	}
+	if report.Checked != 3 || report.Improved != 1 {
+		t.Errorf("checked %d and improved %d, want 3 and 1", report.Checked, report.Improved)
+	}
+	if !reflect.DeepEqual(report.Missing, []string{"gone.html"}) || !reflect.DeepEqual(report.Added, []string{"new.html"}) {
+		t.Errorf("missing %v and added %v", report.Missing, report.Added)
+	}
+	if report.ScorerChanged {
+		t.Error("scorer reported as changed")
+	}
+
+	if report := snapshot.Compare(pages[1:3], 5); !report.Passed() {
+		t.Errorf("unchanged pages regressed: %+v", report.Regressions)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	snapshot := &Snapshot{Source: "urls.txt", Mode: "local", Pages: []Page{{Key: "b", Score: 2}, {Key: "a", Score: 1}}}
+	if err := snapshot.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ScorerVersion != scorer.Version || loaded.Source != "urls.txt" {
+		t.Errorf("loaded %+v", loaded)
+	}
+	if loaded.Pages[0].Key != "a" {
+		t.Errorf("pages not sorted: %+v", loaded.Pages)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("loaded a baseline of an unknown format version")
+	}
+}
@@ -0,0 +1,326 @@
+// Package bundle packs an audit into one archive: its results, the content
+// of its pages, the manifests of the analyses and the score history of its
+// pages. Bundles hand audits between machines, archive them and go along
+// with client deliverables; reading one checks every file against the
+// checksums it was written with.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"geo-checker/internal/bulk"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/archive"
+	"geo-checker/pkg/history"
+)
+
+// FormatVersion changes whenever the bundle layout does.
+const FormatVersion = 1
+
+// Files of a bundle, besides the content snapshots under contentDir.
+const (
+	manifestFile  = "manifest.json"
+	resultsFile   = "results.json"
+	manifestsFile = "manifests.json"
+	historyFile   = "history.jsonl"
+	contentDir    = "content/"
+)
+
+// maxFileSize bounds the files read from a bundle.
+const maxFileSize = 256 << 20
+
+// Manifest describes a bundle; it is the bundle's manifest.json.
+type Manifest struct {
+	Version     int               `json:"version"`
+	CreatedAt   time.Time         `json:"created_at"`
+	ToolVersion string            `json:"tool_version"`
+	Source      string            `json:"source,omitempty"` // report the results were read from
+	Pages       []Page            `json:"pages"`
+	Files       map[string]string `json:"files"` // file name to SHA-256
+}
+
+// Page is one page of the bundle and its content snapshot.
+type Page struct {
+	Key         string    `json:"key"` // URL, or document ID
+	Score       int       `json:"score"`
+	Error       string    `json:"error,omitempty"`
+	Content     string    `json:"content,omitempty"` // snapshot file
+	ContentHash string    `json:"content_hash,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at,omitzero"`
+
+	// Changed is set when the page's content differs from the content
+	// scored, by the hash its result recorded; nil when unknown
+	Changed *bool `json:"changed,omitempty"`
+
+	// SnapshotError is why no snapshot of the page could be taken
+	SnapshotError string `json:"snapshot_error,omitempty"`
+}
+
+// Bundle is an audit and everything that goes with it.
+type Bundle struct {
+	Manifest  Manifest
+	Results   []*bulk.BulkResult
+	Manifests []*analyzer.Manifest // distinct manifests of the results
+	History   []history.Entry      // runs of the bundled pages
+	Contents  map[string]string    // snapshot file to extracted content
+}
+
+// New returns a bundle of results. Its pages, manifests and history are
+// taken from them and entries, the whole history to draw from.
+func New(results []*bulk.BulkResult, entries []history.Entry, source string, at time.Time) *Bundle {
+	b := &Bundle{
+		Manifest: Manifest{
+			Version:     FormatVersion,
+			CreatedAt:   at.UTC(),
+			ToolVersion: analyzer.ToolVersion(),
+			Source:      source,
+		},
+		Results:  results,
+		Contents: make(map[string]string),
+	}
+	urls := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, r := range results {
+		page := Page{Key: pageKey(r)}
+		switch {
+		case r.Error != nil:
+			page.Error = r.Error.Message
+		case r.Result == nil:
+			page.Error = "no result"
+		default:
+			page.Score = r.Result.Score
+			if m := r.Result.Manifest; m != nil {
+				data, _ := json.Marshal(m)
+				if !seen[string(data)] {
+					seen[string(data)] = true
+					b.Manifests = append(b.Manifests, m)
+				}
+			}
+		}
+		b.Manifest.Pages = append(b.Manifest.Pages, page)
+		urls[r.URL] = true
+	}
+	for _, e := range entries {
+		if urls[e.URL] {
+			b.History = append(b.History, e)
+		}
+	}
+	return b
+}
+
+func pageKey(r *bulk.BulkResult) string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return r.URL
+}
+
+// Snapshot adds the extracted content of the pages of the bundle, as the
+// results' analyses configured it (content selector, inlined frames).
+// Pages archived when they were scored are read from their archive;
+// others are fetched again, so their snapshot is the page as it is now
+// and pages whose content changed since they were scored are marked so. A
+// page that cannot be read or fetched keeps the reason in its entry.
+func (b *Bundle) Snapshot(ctx context.Context) {
+	for i, r := range b.Results {
+		page := &b.Manifest.Pages[i]
+		if r.Result == nil || r.ID != "" || !strings.HasPrefix(r.URL, "http") {
+			continue // documents and local files have nothing to fetch
+		}
+		scraper := webpage.New()
+		scraper.SetCheckImages(false)
+		if m := r.Result.Manifest; m != nil {
+			scraper.SetSelector(m.Config.Selector)
+			scraper.SetInlineFrames(m.Config.InlineFrames)
+		}
+		data, fetchedAt, err := snapshotPage(ctx, scraper, r)
+		if err != nil {
+			page.SnapshotError = err.Error()
+			continue
+		}
+		page.Content = fmt.Sprintf("%s%04d.txt", contentDir, i+1)
+		page.ContentHash = analyzer.ContentHash(data.Content)
+		page.FetchedAt = fetchedAt.UTC()
+		if scored, ok := r.Result.Metadata["content_hash"].(string); ok {
+			changed := scored != page.ContentHash
+			page.Changed = &changed
+		}
+		b.Contents[page.Content] = data.Content
+	}
+}
+
+// snapshotPage extracts the content of r's page from its archived response,
+// or else from the live page, and returns when it was fetched.
+func snapshotPage(ctx context.Context, scraper *webpage.Scraper, r *bulk.BulkResult) (*webpage.PageData, time.Time, error) {
+	if rec := r.Result.Archive; rec != nil {
+		resp, err := archive.Open(rec, r.URL)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		data, err := scraper.ScrapeResponse(ctx, r.URL, resp)
+		return data, resp.FetchedAt, err
+	}
+	data, err := scraper.ScrapeURL(ctx, r.URL)
+	return data, time.Now(), err
+}
+
+// Write writes the bundle as a gzipped tar archive.
+func (b *Bundle) Write(w io.Writer) error {
+	files := make(map[string][]byte)
+	var err error
+	if files[resultsFile], err = json.MarshalIndent(b.Results, "", "  "); err != nil {
+		return err
+	}
+	if files[manifestsFile], err = json.MarshalIndent(b.Manifests, "", "  "); err != nil {
+		return err
+	}
+	var lines bytes.Buffer
+	for _, e := range b.History {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		lines.Write(append(line, '\n'))
+	}
+	files[historyFile] = lines.Bytes()
+	for name, content := range b.Contents {
+		files[name] = []byte(content)
+	}
+
+	names := make([]string, 0, len(files))
+	b.Manifest.Files = make(map[string]string, len(files))
+	for name, data := range files {
+		names = append(names, name)
+		b.Manifest.Files[name] = checksum(data)
+	}
+	sort.Strings(names)
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, name := range append([]string{manifestFile}, names...) {
+		data := manifest
+		if name != manifestFile {
+			data = files[name]
+		}
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: b.Manifest.CreatedAt}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Read reads a bundle written by Write, failing when a file is missing,
+// altered or not listed in the manifest.
+func Read(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a bundle: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		name := path.Clean(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || name == ".." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+			return nil, fmt.Errorf("unexpected entry %q in bundle", hdr.Name)
+		}
+		if hdr.Size > maxFileSize {
+			return nil, fmt.Errorf("bundle entry %s is too large", name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		files[name] = data
+	}
+
+	b := &Bundle{Contents: make(map[string]string)}
+	data, ok := files[manifestFile]
+	if !ok {
+		return nil, fmt.Errorf("not a bundle: no %s", manifestFile)
+	}
+	if err := json.Unmarshal(data, &b.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	if b.Manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("bundle has format version %d, want %d", b.Manifest.Version, FormatVersion)
+	}
+	for name, data := range files {
+		if name == manifestFile {
+			continue
+		}
+		sum, ok := b.Manifest.Files[name]
+		if !ok {
+			return nil, fmt.Errorf("bundle file %s is not in its manifest", name)
+		}
+		if checksum(data) != sum {
+			return nil, fmt.Errorf("bundle file %s does not match its checksum", name)
+		}
+	}
+	for name := range b.Manifest.Files {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("bundle file %s is missing", name)
+		}
+	}
+
+	if err := json.Unmarshal(files[resultsFile], &b.Results); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle results: %w", err)
+	}
+	if err := json.Unmarshal(files[manifestsFile], &b.Manifests); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifests: %w", err)
+	}
+	for _, line := range bytes.Split(files[historyFile], []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e history.Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse bundle history: %w", err)
+		}
+		b.History = append(b.History, e)
+	}
+	for name, data := range files {
+		if strings.HasPrefix(name, contentDir) {
+			b.Contents[name] = string(data)
+		}
+	}
+	return b, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/history"
+)
+
+func TestRoundTrip(t *testing.T) {
+	body := "Install the tool with the installer and follow the prompts to finish."
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<html><head><title>Guide</title></head><body><main><h1>Install</h1><p>%s</p></main></body></html>`, body)
+	}))
+	defer srv.Close()
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	manifest := &analyzer.Manifest{ToolVersion: "v1", ScorerVersion: "s1"}
+	results := []*bulk.BulkResult{
+		{URL: srv.URL + "/same", Result: &analyzer.Result{URL: srv.URL + "/same", Score: 70, Manifest: manifest, Metadata: map[string]any{}}},
+		{URL: srv.URL + "/old", Result: &analyzer.Result{URL: srv.URL + "/old", Score: 60, Manifest: manifest, Metadata: map[string]any{"content_hash": "0000000000000000"}}},
+		{URL: "https://unreachable.invalid/", Error: &analyzer.Error{Message: "fetch failed"}},
+	}
+	entries := []history.Entry{
+		{URL: srv.URL + "/same", Time: at, Score: 65},
+		{URL: "https://other.example/", Time: at, Score: 10},
+	}
+
+	b := New(results, entries, "report.json", at)
+	b.Snapshot(t.Context())
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Results) != 3 || len(read.Manifests) != 1 || len(read.History) != 1 || read.History[0].Score != 65 {
+		t.Fatalf("read %d results, %d manifests, history %+v", len(read.Results), len(read.Manifests), read.History)
+	}
+	pages := read.Manifest.Pages
+	if pages[0].Content == "" || !strings.Contains(read.Contents[pages[0].Content], body) || pages[0].Changed != nil {
+		t.Errorf("page without a scored hash = %+v", pages[0])
+	}
+	if pages[1].Changed == nil || !*pages[1].Changed {
+		t.Errorf("page scored with other content not marked changed: %+v", pages[1])
+	}
+	if pages[2].Error != "fetch failed" || pages[2].Content != "" {
+		t.Errorf("failed page = %+v", pages[2])
+	}
+
+	// A file altered after the bundle was written fails its checksum
+	tampered := rewrite(t, buf.Bytes(), pages[0].Content, "altered")
+	if _, err := Read(bytes.NewReader(tampered)); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("altered bundle read, err = %v", err)
+	}
+}
+
+// rewrite returns the bundle data with the content of file name replaced.
+func rewrite(t *testing.T, data []byte, name, content string) []byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	gw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gw)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, _ := io.ReadAll(tr)
+		if hdr.Name == name {
+			file = []byte(content)
+		}
+		hdr.Size = int64(len(file))
+		tw.WriteHeader(hdr)
+		tw.Write(file)
+	}
+	tw.Close()
+	gw.Close()
+	return out.Bytes()
+}
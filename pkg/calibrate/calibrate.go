@@ -0,0 +1,213 @@
+// Package calibrate runs the local scorer against a labeled benchmark of
+// pages and reports where scores drift outside the expected ranges.
+package calibrate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+	"io/fs"
+	"math"
+	"path"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed corpus
+var corpus embed.FS
+
+// Builtin is the benchmark shipped with the binary.
+const Builtin = "corpus/benchmark.yaml"
+
+// Range is an inclusive expected score range.
+type Range struct {
+	Min int `yaml:"min" json:"min"`
+	Max int `yaml:"max" json:"max"`
+}
+
+// Drift is how far score falls outside the range, negative below it.
+func (r Range) Drift(score int) int {
+	switch {
+	case score < r.Min:
+		return score - r.Min
+	case score > r.Max:
+		return score - r.Max
+	}
+	return 0
+}
+
+// Benchmark is a labeled set of pages read from YAML.
+type Benchmark struct {
+	Name  string `yaml:"name"`
+	Pages []Page `yaml:"pages"`
+
+	fsys fs.FS
+	dir  string
+}
+
+// Page is one labeled benchmark page, stored as a file next to the
+// benchmark or fetched from a URL.
+type Page struct {
+	Name     string           `yaml:"name"`
+	File     string           `yaml:"file"`
+	URL      string           `yaml:"url"`
+	Expected Range            `yaml:"expected"`
+	Pillars  map[string]Range `yaml:"pillars"`
+}
+
+// Result is the outcome for one page.
+type Result struct {
+	Name     string        `json:"name"`
+	Score    int           `json:"score"`
+	Expected Range         `json:"expected"`
+	Drift    int           `json:"drift"`
+	Pillars  []PillarDrift `json:"pillars,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Failed reports whether the page could not be scored or drifted outside
+// its range, overall or on a labeled pillar.
+func (r Result) Failed() bool {
+	if r.Error != "" || r.Drift != 0 {
+		return true
+	}
+	for _, p := range r.Pillars {
+		if p.Drift != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PillarDrift is the outcome for one labeled pillar of a page.
+type PillarDrift struct {
+	Pillar   string `json:"pillar"`
+	Score    int    `json:"score"`
+	Expected Range  `json:"expected"`
+	Drift    int    `json:"drift"`
+}
+
+// Report summarizes a calibration run.
+type Report struct {
+	Benchmark string   `json:"benchmark"`
+	Results   []Result `json:"results"`
+	Passed    int      `json:"passed"`
+	Failed    int      `json:"failed"`
+	MeanDrift float64  `json:"mean_abs_drift"` // over the pages that could be scored
+}
+
+// OK reports whether every page and pillar scored within range.
+func (r *Report) OK() bool {
+	return r.Failed == 0
+}
+
+// LoadBuiltin loads the benchmark embedded in the binary.
+func LoadBuiltin() (*Benchmark, error) {
+	return Load(corpus, Builtin)
+}
+
+// Load reads a benchmark from fsys. Page files are resolved relative to the
+// benchmark file.
+func Load(fsys fs.FS, name string) (*Benchmark, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read benchmark: %w", err)
+	}
+
+	b := &Benchmark{fsys: fsys, dir: path.Dir(name)}
+	if err := yaml.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to parse benchmark: %w", err)
+	}
+	if len(b.Pages) == 0 {
+		return nil, fmt.Errorf("benchmark has no pages")
+	}
+	for i, p := range b.Pages {
+		if (p.File == "") == (p.URL == "") {
+			return nil, fmt.Errorf("benchmark page %d: set exactly one of file or url", i+1)
+		}
+		if p.Expected.Min > p.Expected.Max {
+			return nil, fmt.Errorf("benchmark page %d: expected min is above max", i+1)
+		}
+		if p.Name == "" {
+			b.Pages[i].Name = p.File + p.URL
+		}
+	}
+	if b.Name == "" {
+		b.Name = name
+	}
+	return b, nil
+}
+
+// Run scores every page with ls.
+func (b *Benchmark) Run(ctx context.Context, ls *scorer.LocalScorer) *Report {
+	report := &Report{Benchmark: b.Name}
+	scraper := webpage.New()
+	totalDrift, scored := 0, 0
+
+	for _, p := range b.Pages {
+		result := Result{Name: p.Name, Expected: p.Expected}
+
+		page, err := b.load(ctx, scraper, p)
+		if err != nil {
+			result.Error = err.Error()
+			report.Failed++
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		score := ls.AnalyzeContent(page.Content, page)
+		result.Score = score.Overall
+		result.Drift = p.Expected.Drift(score.Overall)
+		totalDrift += abs(result.Drift)
+		scored++
+
+		pillars := make([]string, 0, len(p.Pillars))
+		for name := range p.Pillars {
+			pillars = append(pillars, name)
+		}
+		sort.Strings(pillars)
+		for _, name := range pillars {
+			detail, ok := score.Pillar(name)
+			if !ok {
+				result.Error = fmt.Sprintf("unknown pillar %q", name)
+				continue
+			}
+			drift := PillarDrift{Pillar: name, Score: detail.Score, Expected: p.Pillars[name]}
+			drift.Drift = drift.Expected.Drift(detail.Score)
+			result.Pillars = append(result.Pillars, drift)
+		}
+
+		if result.Failed() {
+			report.Failed++
+		} else {
+			report.Passed++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	if scored > 0 {
+		report.MeanDrift = math.Round(float64(totalDrift)/float64(scored)*10) / 10
+	}
+	return report
+}
+
+func (b *Benchmark) load(ctx context.Context, scraper *webpage.Scraper, p Page) (*webpage.PageData, error) {
+	if p.URL != "" {
+		return scraper.ScrapeURL(ctx, p.URL)
+	}
+	html, err := fs.ReadFile(b.fsys, path.Join(b.dir, p.File))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	return scraper.ParseHTML(string(html), p.File)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,65 @@
+package calibrate
+
+import (
+	"context"
+	"geo-checker/pkg/scorer"
+	"testing"
+	"testing/fstest"
+)
+
+// The builtin benchmark doubles as a regression test for scoring changes.
+func TestBuiltinBenchmarkWithinRange(t *testing.T) {
+	bench, err := LoadBuiltin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := bench.Run(context.Background(), scorer.NewLocalScorer())
+	for _, r := range report.Results {
+		if r.Error != "" || r.Drift != 0 {
+			t.Errorf("%s: score %d, expected %d-%d, error %q", r.Name, r.Score, r.Expected.Min, r.Expected.Max, r.Error)
+		}
+		for _, p := range r.Pillars {
+			if p.Drift != 0 {
+				t.Errorf("%s: %s score %d, expected %d-%d", r.Name, p.Pillar, p.Score, p.Expected.Min, p.Expected.Max)
+			}
+		}
+	}
+}
+
+func TestRangeDrift(t *testing.T) {
+	r := Range{Min: 40, Max: 60}
+	for score, want := range map[int]int{30: -10, 40: 0, 55: 0, 60: 0, 72: 12} {
+		if got := r.Drift(score); got != want {
+			t.Errorf("Drift(%d) = %d, want %d", score, got, want)
+		}
+	}
+}
+
+func TestRunPillarDriftAndErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"bench.yaml": {Data: []byte(`pages:
+  - file: page.html
+    expected: {min: 0, max: 100}
+    pillars:
+      structured_data: {min: 100, max: 100}
+  - file: missing.html
+    expected: {min: 0, max: 100}
+  - file: page.html
+    expected: {min: 100, max: 100}
+`)},
+		"page.html": {Data: []byte("<html><body><main><h1>Title</h1><p>A page without any structured data at all.</p></main></body></html>")},
+	}
+	bench, err := Load(fsys, "bench.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := bench.Run(context.Background(), scorer.NewLocalScorer())
+	if len(report.Results) != 3 || report.Failed != 3 || report.Results[0].Drift != 0 || !report.Results[0].Failed() {
+		t.Fatalf("results = %+v, want a page failed on its pillar alone", report.Results)
+	}
+	if want := float64(-report.Results[2].Drift) / 2; report.MeanDrift != want {
+		t.Errorf("MeanDrift = %.1f, want %.1f with the unreadable page left out", report.MeanDrift, want)
+	}
+}
@@ -0,0 +1,398 @@
+// Package cluster evaluates a topic cluster: a pillar page and the
+// supporting pages around it, analyzed together for how well they link to
+// each other, how the pillar covers their topics and whether they compete
+// for the same queries.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	neturl "net/url"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// keyTerms is how many of a page's most frequent terms stand for its
+	// topic.
+	keyTerms = 30
+
+	// overlapThreshold is the similarity of two supporting pages' key terms
+	// from which their overlap is reported.
+	overlapThreshold = 0.3
+
+	// cannibalizationThreshold is the similarity of two pages' key terms,
+	// or of their titles and H1, from which they compete for the same
+	// queries.
+	cannibalizationThreshold = 0.5
+
+	// coverageThreshold is the share of a supporting page's title terms the
+	// pillar should mention to cover its topic.
+	coverageThreshold = 0.5
+)
+
+// Page is a page of the cluster as fetched and analyzed.
+type Page struct {
+	URL    string
+	Data   *webpage.PageData
+	Result *analyzer.Result
+	Error  *analyzer.Error
+}
+
+// PageReport is how a page fits in the cluster.
+type PageReport struct {
+	URL              string           `json:"url"`
+	Title            string           `json:"title,omitempty"`
+	Role             string           `json:"role"` // "pillar" or "supporting"
+	Score            int              `json:"score"`
+	Error            *analyzer.Error  `json:"error,omitempty"`
+	LinksToPillar    bool             `json:"links_to_pillar"`
+	LinkedFromPillar bool             `json:"linked_from_pillar"`
+	InboundLinks     int              `json:"inbound_links"`   // from other cluster pages
+	OutboundLinks    int              `json:"outbound_links"`  // to other cluster pages
+	PillarCoverage   float64          `json:"pillar_coverage"` // share of its title terms the pillar mentions
+	Result           *analyzer.Result `json:"result,omitempty"`
+}
+
+// Overlap is a pair of pages whose topics overlap.
+type Overlap struct {
+	A               string   `json:"a"`
+	B               string   `json:"b"`
+	Similarity      float64  `json:"similarity"`       // of their key terms
+	TitleSimilarity float64  `json:"title_similarity"` // of their titles and H1
+	SharedTerms     []string `json:"shared_terms"`
+	Cannibalization bool     `json:"cannibalization"`
+}
+
+// Report is the cluster-level GEO health of a pillar and its supporting
+// pages.
+type Report struct {
+	Pillar       string        `json:"pillar"`
+	Health       int           `json:"health"` // 0-100
+	AverageScore int           `json:"average_score"`
+	Interlinking float64       `json:"interlinking"` // share of the expected pillar links present
+	Coverage     float64       `json:"coverage"`     // share of supporting topics the pillar covers
+	Pages        []*PageReport `json:"pages"`
+	Overlaps     []Overlap     `json:"overlaps,omitempty"`
+	Issues       []string      `json:"issues,omitempty"`
+}
+
+// Fetch scrapes and analyzes the pillar and supporting URLs, concurrency
+// at a time. Pages that fail are returned with their error.
+func Fetch(ctx context.Context, a *analyzer.Analyzer, pillar string, supporting []string, concurrency int) []*Page {
+	urls := append([]string{pillar}, supporting...)
+	pages := make([]*Page, len(urls))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			page := &Page{URL: u}
+			data, err := webpage.New().ScrapeURL(ctx, u)
+			if err != nil {
+				page.Error = analyzer.Classify(fmt.Errorf("failed to scrape URL: %w", err))
+			} else if page.Result, err = a.AnalyzePage(ctx, data, u); err != nil {
+				page.Error = analyzer.Classify(err)
+			}
+			page.Data = data
+			pages[i] = page
+		}(i, u)
+	}
+	wg.Wait()
+	return pages
+}
+
+// Evaluate reports on the cluster of pages, the first of which is the
+// pillar.
+func Evaluate(pages []*Page) *Report {
+	report := &Report{Pillar: pages[0].URL}
+	ids := make(map[string]int) // normalized URL to page index
+	for i, page := range pages {
+		ids[normalizeURL(page.URL)] = i
+		if page.Data != nil && page.Data.Crawl.FinalURL != "" {
+			ids[normalizeURL(page.Data.Crawl.FinalURL)] = i
+		}
+	}
+
+	// Links between cluster pages, counted once per pair
+	linked := make([]map[int]bool, len(pages))
+	for i, page := range pages {
+		linked[i] = make(map[int]bool)
+		if page.Data == nil {
+			continue
+		}
+		for _, link := range page.Data.Links {
+			if j, ok := ids[normalizeURL(link.URL)]; ok && j != i && !link.Hidden {
+				linked[i][j] = true
+			}
+		}
+	}
+
+	terms := make([]map[string]int, len(pages))
+	titles := make([]map[string]bool, len(pages))
+	for i, page := range pages {
+		if page.Data != nil {
+			terms[i] = termCounts(page.Data.Content)
+			titles[i] = titleTerms(page.Data)
+		}
+	}
+
+	total, scored, expected, present := 0, 0, 0, 0
+	var coverage float64
+	covered := 0
+	for i, page := range pages {
+		pr := &PageReport{URL: page.URL, Role: "supporting", Result: page.Result, Error: page.Error}
+		if i == 0 {
+			pr.Role = "pillar"
+		}
+		if page.Data != nil {
+			pr.Title = page.Data.Title
+		}
+		if page.Result != nil {
+			pr.Score = page.Result.Score
+			total += page.Result.Score
+			scored++
+		}
+		pr.OutboundLinks = len(linked[i])
+		for j := range pages {
+			if linked[j][i] {
+				pr.InboundLinks++
+			}
+		}
+		if i > 0 {
+			pr.LinksToPillar = linked[i][0]
+			pr.LinkedFromPillar = linked[0][i]
+			if page.Data != nil && pages[0].Data != nil {
+				expected += 2
+				if pr.LinksToPillar {
+					present++
+				} else {
+					report.Issues = append(report.Issues, fmt.Sprintf("%s does not link to the pillar page", page.URL))
+				}
+				if pr.LinkedFromPillar {
+					present++
+				} else {
+					report.Issues = append(report.Issues, fmt.Sprintf("The pillar page does not link to %s", page.URL))
+				}
+				if pr.InboundLinks == 0 {
+					report.Issues = append(report.Issues, fmt.Sprintf("%s is orphaned: no page of the cluster links to it", page.URL))
+				}
+
+				pr.PillarCoverage = coveredShare(titles[i], terms[0])
+				coverage += pr.PillarCoverage
+				covered++
+				if pr.PillarCoverage < coverageThreshold {
+					report.Issues = append(report.Issues, fmt.Sprintf("The pillar page barely covers the topic of %s (%q)", page.URL, pr.Title))
+				}
+			}
+		}
+		report.Pages = append(report.Pages, pr)
+	}
+
+	for i := range pages {
+		for j := i + 1; j < len(pages); j++ {
+			if terms[i] == nil || terms[j] == nil {
+				continue
+			}
+			overlap := Overlap{
+				A:               pages[i].URL,
+				B:               pages[j].URL,
+				Similarity:      similarity(topTerms(terms[i]), topTerms(terms[j])),
+				TitleSimilarity: similarity(titles[i], titles[j]),
+			}
+			// The pillar is expected to share the vocabulary of every
+			// supporting page; it only competes with one on the same title
+			overlap.Cannibalization = overlap.TitleSimilarity >= cannibalizationThreshold ||
+				(i > 0 && overlap.Similarity >= cannibalizationThreshold)
+			if !overlap.Cannibalization && (i == 0 || overlap.Similarity < overlapThreshold) {
+				continue
+			}
+			overlap.SharedTerms = sharedTerms(terms[i], terms[j], 5)
+			report.Overlaps = append(report.Overlaps, overlap)
+			if overlap.Cannibalization {
+				report.Issues = append(report.Issues, fmt.Sprintf("%s and %s compete for the same queries (%.0f%% similar); merge them or differentiate their focus", overlap.A, overlap.B, overlap.Similarity*100))
+			}
+		}
+	}
+
+	if scored > 0 {
+		report.AverageScore = total / scored
+	}
+	report.Interlinking = 1
+	if expected > 0 {
+		report.Interlinking = float64(present) / float64(expected)
+	}
+	report.Coverage = 1
+	if covered > 0 {
+		report.Coverage = coverage / float64(covered)
+	}
+	report.Health = health(report, len(pages))
+	return report
+}
+
+// health weighs the page scores at 40%, interlinking at 30%, the pillar's
+// coverage of the supporting topics at 15% and the absence of
+// cannibalization at 15%.
+func health(report *Report, pages int) int {
+	pairs := pages * (pages - 1) / 2
+	clean := 1.0
+	if pairs > 0 {
+		cannibalizing := 0
+		for _, o := range report.Overlaps {
+			if o.Cannibalization {
+				cannibalizing++
+			}
+		}
+		clean = 1 - float64(cannibalizing)/float64(pairs)
+	}
+	score := 0.4*float64(report.AverageScore) + 30*report.Interlinking + 15*min(report.Coverage/coverageThreshold, 1) + 15*clean
+	return int(score + 0.5)
+}
+
+// normalizeURL identifies a page by its URL without fragment, trailing
+// slash or case differences in the scheme and host.
+func normalizeURL(raw string) string {
+	u, err := neturl.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return raw
+	}
+	u.Fragment = ""
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(strings.TrimPrefix(u.Host, "www."))
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
+
+// stopWords are left out of the terms pages are compared on.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true, "you": true, "your": true,
+	"all": true, "any": true, "can": true, "has": true, "have": true, "had": true, "was": true, "were": true,
+	"this": true, "that": true, "these": true, "those": true, "with": true, "from": true, "into": true,
+	"our": true, "out": true, "about": true, "what": true, "when": true, "where": true, "which": true,
+	"who": true, "why": true, "how": true, "will": true, "would": true, "should": true, "could": true,
+	"their": true, "them": true, "they": true, "there": true, "then": true, "than": true, "its": true,
+	"also": true, "more": true, "most": true, "some": true, "such": true, "only": true, "other": true,
+	"each": true, "been": true, "being": true, "does": true, "did": true, "just": true, "like": true,
+	"use": true, "using": true, "used": true, "one": true, "two": true, "get": true, "make": true,
+	"may": true, "many": true, "much": true, "very": true, "over": true, "here": true, "both": true,
+}
+
+// terms splits text into lowercase words of three letters or more,
+// without stop words.
+func terms(text string) []string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(w)) >= 3 && !stopWords[w] {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func termCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range terms(text) {
+		counts[w]++
+	}
+	return counts
+}
+
+// titleTerms are the terms of a page's title and H1, what it is about.
+func titleTerms(data *webpage.PageData) map[string]bool {
+	text := data.Title
+	for _, h := range data.Headings {
+		if h.Level == 1 {
+			text += " " + h.Text
+		}
+	}
+	set := make(map[string]bool)
+	for _, w := range terms(text) {
+		set[w] = true
+	}
+	return set
+}
+
+// rankedTerms returns the terms of counts by descending frequency, ties in
+// alphabetical order.
+func rankedTerms(counts map[string]int) []string {
+	ranked := make([]string, 0, len(counts))
+	for w := range counts {
+		ranked = append(ranked, w)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if counts[ranked[i]] != counts[ranked[j]] {
+			return counts[ranked[i]] > counts[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked
+}
+
+// topTerms returns the keyTerms most frequent terms of counts.
+func topTerms(counts map[string]int) map[string]bool {
+	ranked := rankedTerms(counts)
+	if len(ranked) > keyTerms {
+		ranked = ranked[:keyTerms]
+	}
+	set := make(map[string]bool, len(ranked))
+	for _, w := range ranked {
+		set[w] = true
+	}
+	return set
+}
+
+// similarity is the Jaccard index of two term sets.
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// coveredShare is the share of the topic terms that appear in counts.
+func coveredShare(topic map[string]bool, counts map[string]int) float64 {
+	if len(topic) == 0 {
+		return 1
+	}
+	found := 0
+	for w := range topic {
+		if counts[w] > 0 {
+			found++
+		}
+	}
+	return float64(found) / float64(len(topic))
+}
+
+// sharedTerms returns up to n terms frequent on both pages, most frequent
+// first.
+func sharedTerms(a, b map[string]int, n int) []string {
+	shared := make(map[string]int)
+	for w, c := range a {
+		if b[w] > 0 {
+			shared[w] = min(c, b[w])
+		}
+	}
+	ranked := rankedTerms(shared)
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"strings"
+	"testing"
+)
+
+func page(url, title, content string, score int, links ...string) *Page {
+	data := &webpage.PageData{
+		URL:      url,
+		Title:    title,
+		Content:  content,
+		Headings: []webpage.Heading{{Level: 1, Text: title}},
+	}
+	for _, l := range links {
+		data.Links = append(data.Links, webpage.Link{URL: l})
+	}
+	return &Page{URL: url, Data: data, Result: &analyzer.Result{Score: score}}
+}
+
+func TestEvaluate(t *testing.T) {
+	espresso := "Espresso extraction depends on grind size, dose, water temperature and pressure. Dial in the grind until the shot runs in thirty seconds."
+	pages := []*Page{
+		page("https://example.com/coffee/", "Coffee brewing guide",
+			"Brewing coffee at home: espresso extraction, grind size and pour over basics.", 80,
+			"https://example.com/coffee/espresso#dial-in", "https://example.com/coffee/espresso-dial-in"),
+		page("https://example.com/coffee/espresso", "Dial in espresso extraction", espresso, 70, "https://example.com/coffee"),
+		page("https://example.com/coffee/espresso-dial-in", "How to dial in espresso extraction", espresso+" Taste the shot.", 60),
+		page("https://example.com/coffee/cold-brew", "Cold brew concentrate", "Steep coarse grounds in cold water for eighteen hours, then filter.", 50, "https://example.com/coffee/"),
+	}
+
+	report := Evaluate(pages)
+	if report.AverageScore != 65 {
+		t.Errorf("average = %d, want 65", report.AverageScore)
+	}
+	espressoPage, dialIn, coldBrew := report.Pages[1], report.Pages[2], report.Pages[3]
+	if !espressoPage.LinksToPillar || !espressoPage.LinkedFromPillar {
+		t.Errorf("espresso page links = %+v, want both ways despite fragment and trailing slash", espressoPage)
+	}
+	if dialIn.LinksToPillar || !dialIn.LinkedFromPillar {
+		t.Errorf("dial-in page links = %+v", dialIn)
+	}
+	if coldBrew.InboundLinks != 0 || coldBrew.PillarCoverage >= coverageThreshold {
+		t.Errorf("cold brew page = %+v, want orphaned and not covered by the pillar", coldBrew)
+	}
+	if report.Interlinking != 4.0/6 {
+		t.Errorf("interlinking = %v, want 4 of 6 links", report.Interlinking)
+	}
+
+	var cannibalizing []Overlap
+	for _, o := range report.Overlaps {
+		if o.Cannibalization {
+			cannibalizing = append(cannibalizing, o)
+		}
+	}
+	if len(cannibalizing) != 1 || cannibalizing[0].A != espressoPage.URL || cannibalizing[0].B != dialIn.URL {
+		t.Errorf("cannibalization = %+v, want the two espresso pages", cannibalizing)
+	}
+
+	issues := strings.Join(report.Issues, "\n")
+	for _, want := range []string{"cold-brew is orphaned", "espresso-dial-in does not link to the pillar", "barely covers the topic of https://example.com/coffee/cold-brew"} {
+		if !strings.Contains(issues, want) {
+			t.Errorf("issues missing %q:\n%s", want, issues)
+		}
+	}
+	// 40% of 65, 30% of 4/6, 15% of 4/9 coverage against the 1/2 expected
+	// and 15% of the 5 in 6 pairs that do not cannibalize
+	if report.Health != 72 {
+		t.Errorf("health = %d, want 72", report.Health)
+	}
+}
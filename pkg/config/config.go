@@ -1,5 +1,11 @@
 package config
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
 type Config struct {
 	LLMProvider   string
 	Model         string
@@ -8,6 +14,112 @@ type Config struct {
 	Concurrent    int
 	Extensions    []string
 	
+	// Retries is how many more times bulk runs attempt URLs that failed
+	// with a retryable error
+	Retries       int
+	
+	// DeepDiveThreshold and DeepDivePercent make bulk runs score every
+	// page locally first and spend the LLM only on pages scoring below the
+	// threshold or among the lowest-scoring percent; 0 turns either off
+	DeepDiveThreshold int
+	DeepDivePercent   int
+	
+	// CrawlerParity re-fetches URLs as a browser and as GPTBot to detect
+	// content served differently to AI crawlers
+	CrawlerParity bool
+	
+	// InlineFrames appends the text of same-origin iframes to the content
+	// analyzed
+	InlineFrames  bool
+	
+	// SkipImageCheck leaves the og:image unfetched, judging it by its
+	// declaration alone
+	SkipImageCheck bool
+	
+	// MetaCaptures are the additional meta, link and data-* attributes to
+	// record in the page's meta tags (meta:ATTR, link:REL, data-NAME)
+	MetaCaptures []string
+	
+	// Selector is a CSS selector restricting extraction and scoring to a
+	// region of the page; empty detects the main content area
+	Selector string
+	
+	// Condense strips repeated navigation text, compresses whitespace and
+	// summarizes long tables in the content sent to the LLM
+	Condense bool
+	
+	// FormattingDrafts asks the LLM to draft tables and lists for prose
+	// flagged by the formatting advisor
+	FormattingDrafts bool
+	
+	// AnswerDraft asks the LLM to draft the direct answer paragraph for
+	// pages that do not open with one
+	AnswerDraft bool
+	
+	// Framing asks the LLM whether pages state the consensus view and
+	// their own position, and which strong claims lack attribution
+	Framing bool
+	
+	// Originality names the search API ("brave", "google") distinctive
+	// sentences are looked up with to estimate how much of a page is
+	// duplicated on other sites; empty skips the check
+	Originality string
+	
+	// OriginalitySamples is the number of sentences looked up; 0 keeps
+	// the default of 5
+	OriginalitySamples int
+	
+	// GroupByOwner maps scanned files to owners from CODEOWNERS or their
+	// front matter authors and groups the scan report by owner
+	GroupByOwner bool
+	
+	// Profile selects the local scoring profile ("general", "docs", ...);
+	// "auto" picks one per page from its detected type
+	Profile       string
+	
+	// Framework is a YAML file renaming pillars, overriding their weights
+	// and defining custom pillars; empty reports the built-in pillars
+	Framework string
+	
+	// ClassifyWithLLM asks the LLM for the page type when the auto
+	// profile's heuristics are unsure
+	ClassifyWithLLM bool
+	
+	// StaleAfterDays is the age in days from which pages without recent
+	// updates are told to review their content; 0 keeps the default
+	StaleAfterDays int
+	
+	// ReadingLevel is the audience reading level readability is scored
+	// against ("general", "expert", "grade-8", ...); empty uses the
+	// profile's default
+	ReadingLevel string
+	
+	// Deterministic makes repeated runs on unchanged content produce
+	// identical reports: temperature 0, LLM responses cached and reused,
+	// and no analysis timestamp
+	Deterministic bool
+	
+	// ArchiveDir keeps the responses of fetched pages in this directory,
+	// as ArchiveFormat ("html" or "warc"), for the page to be shown and
+	// scored again as it was; empty keeps nothing
+	ArchiveDir    string
+	ArchiveFormat string
+	
+	// Stream shows the LLM's answer as it is generated in text output,
+	// with providers that can stream
+	Stream        bool
+	
+	// PublicOnly refuses to fetch pages, frames and images from addresses
+	// that are not on the public internet, except from AllowedHosts, for
+	// services fetching URLs on behalf of their clients
+	PublicOnly    bool
+	AllowedHosts  []string
+	
+	// Quiet keeps the analyzer from writing to the terminal (spinners,
+	// success lines, streamed answers and warnings), for callers that run
+	// analyses concurrently on one analyzer or that own stdout
+	Quiet         bool
+	
 	// API Keys
 	ClaudeAPIKey  string
 	OpenAIAPIKey  string
@@ -19,3 +131,25 @@ type Config struct {
 	Timeout       int
 }
 
+// CacheDir returns the directory for cached fetches, honoring GEO_CACHE_DIR so
+// containers with a read-only root filesystem can point it at a writable volume.
+func CacheDir() (string, error) {
+	return dataDir("GEO_CACHE_DIR", "cache")
+}
+
+// HistoryDir returns the directory for stored run history, honoring
+// GEO_HISTORY_DIR.
+func HistoryDir() (string, error) {
+	return dataDir("GEO_HISTORY_DIR", "history")
+}
+
+func dataDir(envVar, name string) (string, error) {
+	if dir := os.Getenv(envVar); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate a %s directory (set %s): %w", name, envVar, err)
+	}
+	return filepath.Join(base, "geo-checker", name), nil
+}
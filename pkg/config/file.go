@@ -0,0 +1,211 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the configuration file looked for in the working directory;
+// in the home directory it is hidden, as ~/.geo-checker.yaml.
+const FileName = "geo-checker.yaml"
+
+// Weights are the pillar weights of the loaded configuration files, by
+// pillar id. Analyzers apply them unless a framework weights the pillar.
+var Weights map[string]float64
+
+// CommandScoped are the flags naming a file or directory that one run reads
+// or writes. Set at the top level, they would hand the same path to every
+// command with the flag (a bundle to overwrite, a directory to unpack into),
+// so they are only read from a command's own section.
+var CommandScoped = map[string]bool{
+	"annotate":        true,
+	"annotate-source": true,
+	"baseline":        true,
+	"dir":             true,
+	"file":            true,
+	"from-archive":    true,
+	"json-input":      true,
+	"out":             true,
+	"retry-failed":    true,
+	"save":            true,
+	"spill":           true,
+}
+
+// File is the merged content of the configuration files. Settings are
+// flag values by flag name for every command that has the flag, except the
+// CommandScoped ones; Commands override them for one command.
+type File struct {
+	Settings map[string]any            `yaml:",inline"`
+	Commands map[string]map[string]any `yaml:"commands,omitempty"`
+	Weights  map[string]float64        `yaml:"weights,omitempty"`
+
+	// Paths are the files merged, lowest precedence first
+	Paths []string `yaml:"-"`
+
+	sources map[string]string // setting key to the file it comes from
+}
+
+// FilePaths returns the configuration files that apply, lowest precedence
+// first: explicit alone when it is set, otherwise ~/.geo-checker.yaml and
+// ./geo-checker.yaml, those that exist.
+func FilePaths(explicit string) []string {
+	if explicit != "" {
+		return []string{explicit}
+	}
+	var paths []string
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, "."+FileName))
+	}
+	paths = append(paths, FileName)
+
+	var found []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
+		}
+	}
+	return found
+}
+
+// LoadFiles reads and merges the configuration files at paths, later
+// files overriding earlier ones setting by setting.
+func LoadFiles(paths []string) (*File, error) {
+	merged := &File{
+		Settings: make(map[string]any),
+		Commands: make(map[string]map[string]any),
+		Weights:  make(map[string]float64),
+		sources:  make(map[string]string),
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		var f File
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		for key, value := range f.Settings {
+			if isSection(value) {
+				return nil, fmt.Errorf("invalid config file %s: %q must be a value or a list (per-command settings go under commands)", path, key)
+			}
+			merged.Settings[key] = value
+			merged.sources[key] = path
+		}
+		for command, settings := range f.Commands {
+			if merged.Commands[command] == nil {
+				merged.Commands[command] = make(map[string]any)
+			}
+			for key, value := range settings {
+				merged.Commands[command][key] = value
+				merged.sources[command+"."+key] = path
+			}
+		}
+		for pillar, weight := range f.Weights {
+			if weight < 0 {
+				return nil, fmt.Errorf("invalid config file %s: pillar %q has a negative weight", path, pillar)
+			}
+			merged.Weights[pillar] = weight
+			merged.sources["weights."+pillar] = path
+		}
+		merged.Paths = append(merged.Paths, path)
+	}
+	return merged, nil
+}
+
+func isSection(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
+
+// Lookup returns the value of a flag for a command as text a flag can be
+// set from, and the file it comes from. Lists are joined with commas.
+// CommandScoped flags are only found in the command's section.
+func (f *File) Lookup(command, flag string) (string, string, bool) {
+	key := command + "." + flag
+	value, ok := f.Commands[command][flag]
+	if !ok {
+		key = flag
+		if value, ok = f.Settings[flag]; !ok || CommandScoped[flag] {
+			return "", "", false
+		}
+	}
+	return FormatValue(value), f.sources[key], true
+}
+
+// FormatValue writes a setting as flag text.
+func FormatValue(value any) string {
+	if list, ok := value.([]any); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// Source returns the file a setting ("flag", "command.flag" or
+// "weights.pillar") comes from.
+func (f *File) Source(key string) string {
+	return f.sources[key]
+}
+
+// Keys returns the setting names of the file in order, for checking them
+// against the flags that exist.
+func (f *File) Keys() []string {
+	keys := make([]string, 0, len(f.Settings))
+	for key := range f.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Template is the configuration file written by "config init".
+const Template = `# geo-checker configuration. Settings are flag names and apply to every
+# command that has the flag, unless given on the command line or in a
+# GEO_<FLAG> environment variable. Flags naming a file one run reads or
+# writes (out, file, dir, spill, baseline, ...) only apply under commands.
+# ~/.geo-checker.yaml applies everywhere, ./geo-checker.yaml in the
+# directory it is in, overriding it.
+
+# LLM provider (claude, openai, gemini, local, ollama) and model
+provider: claude
+# model: claude-3-5-sonnet-latest
+
+# Analysis mode (auto, local, llm, hybrid) and output format (text, json, markdown)
+# mode: hybrid
+# output: json
+
+# Seconds to wait for each LLM request
+timeout: 30
+
+# Scoring profile and audience
+# profile: auto
+# reading-level: general
+
+# Pillar weights, by pillar id; a --framework file weighting a pillar wins
+# weights:
+#   content_structure: 0.2
+#   semantic_clarity: 0.2
+#   context_richness: 0.2
+#   authority_signals: 0.15
+#   accessibility: 0.15
+#   structured_data: 0.1
+
+# Settings for one command only
+commands:
+  bulk:
+    concurrent: 5
+  scan:
+    mode: local
+`
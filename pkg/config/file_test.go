@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFiles(t *testing.T) {
+	dir := t.TempDir()
+	home := filepath.Join(dir, "home.yaml")
+	project := filepath.Join(dir, "project.yaml")
+	os.WriteFile(home, []byte("provider: openai\nmodel: gpt-4o\nconcurrent: 4\nweights:\n  accessibility: 0.3\n"), 0o644)
+	os.WriteFile(project, []byte("model: gpt-4o-mini\next: [.html, .md]\ncommands:\n  bulk:\n    concurrent: 12\nweights:\n  content_structure: 0.4\n"), 0o644)
+
+	file, err := LoadFiles([]string{home, project})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		command, flag, value, source string
+	}{
+		{"analyze", "provider", "openai", home},
+		{"analyze", "model", "gpt-4o-mini", project},
+		{"scan", "ext", ".html,.md", project},
+		{"bulk", "concurrent", "12", project},
+		{"cluster", "concurrent", "4", home},
+	} {
+		value, source, ok := file.Lookup(tc.command, tc.flag)
+		if !ok || value != tc.value || source != tc.source {
+			t.Errorf("Lookup(%q, %q) = %q, %q, %v, want %q from %s", tc.command, tc.flag, value, source, ok, tc.value, tc.source)
+		}
+	}
+	if _, _, ok := file.Lookup("analyze", "mode"); ok {
+		t.Error("Lookup found a mode no file sets")
+	}
+	if len(file.Weights) != 2 || file.Weights["accessibility"] != 0.3 || file.Weights["content_structure"] != 0.4 {
+		t.Errorf("weights %v, want both files' weights", file.Weights)
+	}
+
+	os.WriteFile(project, []byte("analyze:\n  mode: local\n"), 0o644)
+	if _, err := LoadFiles([]string{project}); err == nil {
+		t.Error("LoadFiles accepted a command section outside commands")
+	}
+}
+
+func TestTopLevelSettingsLeavePathsAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geo-checker.yaml")
+	os.WriteFile(path, []byte("output: json\nfile: everywhere.tar.gz\nspill: everywhere.ndjson\nout: baseline.json\ncommands:\n  export-bundle:\n    file: audit.tar.gz\n"), 0o644)
+	file, err := LoadFiles([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct{ command, flag string }{
+		{"cluster", "file"},
+		{"bulk", "spill"},
+		{"baseline", "out"},
+	} {
+		if value, _, ok := file.Lookup(tc.command, tc.flag); ok {
+			t.Errorf("top-level %s set %s --%s to %q", tc.flag, tc.command, tc.flag, value)
+		}
+	}
+	if value, _, ok := file.Lookup("export-bundle", "file"); !ok || value != "audit.tar.gz" {
+		t.Errorf("export-bundle --file = %q, %v, want its section's audit.tar.gz", value, ok)
+	}
+	if value, _, ok := file.Lookup("analyze", "output"); !ok || value != "json" {
+		t.Errorf("analyze --output = %q, %v, want json", value, ok)
+	}
+}
@@ -0,0 +1,116 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/internal/googleapi"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const sheetsScope = "https://www.googleapis.com/auth/spreadsheets"
+
+// SheetsExporter appends bulk results as rows to a Google Sheet using a
+// service account that has been granted edit access to the spreadsheet.
+type SheetsExporter struct {
+	spreadsheetID string
+	sheetRange    string
+	account       *googleapi.ServiceAccount
+	baseURL       string
+}
+
+func NewSheetsExporter(spreadsheetID, sheetRange, credentialsPath string) (*SheetsExporter, error) {
+	if spreadsheetID == "" {
+		return nil, fmt.Errorf("spreadsheet ID is required")
+	}
+	if sheetRange == "" {
+		sheetRange = "Sheet1"
+	}
+
+	account, err := googleapi.LoadServiceAccount(credentialsPath, sheetsScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SheetsExporter{
+		spreadsheetID: spreadsheetID,
+		sheetRange:    sheetRange,
+		account:       account,
+		baseURL:       "https://sheets.googleapis.com/v4/spreadsheets",
+	}, nil
+}
+
+// Append writes one row per result to the end of the configured range.
+func (e *SheetsExporter) Append(ctx context.Context, results []*bulk.BulkResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	rows := make([][]any, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, SheetsRow(timestamp, r))
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"majorDimension": "ROWS",
+		"values":         rows,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode rows: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/values/%s:append?valueInputOption=USER_ENTERED&insertDataOption=INSERT_ROWS",
+		e.baseURL, url.PathEscape(e.spreadsheetID), url.PathEscape(e.sheetRange))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.account.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to append to sheet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("sheets API error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// SheetsRow converts a bulk result to a sheet row with the columns:
+// Timestamp, URL, Title, Score, Mode, the first five pillar scores, Error,
+// Structured Data. The Structured Data pillar came later and goes last so
+// the columns of existing sheets stay where they are.
+func SheetsRow(timestamp string, r *bulk.BulkResult) []any {
+	row := []any{timestamp, r.URL}
+	if r.Result == nil {
+		row = append(row, "", "", "", "", "", "", "", "")
+		return append(row, r.ErrorMessage(), "")
+	}
+
+	row = append(row, r.Result.Title, r.Result.Score, r.Result.Mode)
+	if ls := r.Result.LocalScore; ls != nil {
+		row = append(row,
+			ls.Breakdown.ContentStructure.Score,
+			ls.Breakdown.SemanticClarity.Score,
+			ls.Breakdown.ContextRichness.Score,
+			ls.Breakdown.AuthoritySignals.Score,
+			ls.Breakdown.Accessibility.Score,
+		)
+		return append(row, r.ErrorMessage(), ls.Breakdown.StructuredData.Score)
+	}
+	row = append(row, "", "", "", "", "")
+	return append(row, r.ErrorMessage(), "")
+}
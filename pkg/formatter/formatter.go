@@ -4,9 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"geo-checker/internal/bulk"
+	"geo-checker/internal/webpage"
 	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/importance"
+	"geo-checker/pkg/llm"
 	"geo-checker/pkg/scanner"
+	"geo-checker/pkg/scorer"
 	"geo-checker/pkg/ui"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -45,17 +51,174 @@ func (f *Formatter) FormatBulkResults(results []*bulk.BulkResult) string {
 	}
 }
 
+// FormatProviderHealth reports the latency, error rate and retries of each
+// LLM provider used by a run; nothing when no provider was called.
+func (f *Formatter) FormatProviderHealth(health []llm.ProviderHealth) string {
+	if len(health) == 0 {
+		return ""
+	}
+	switch f.format {
+	case "json":
+		data, err := json.MarshalIndent(map[string]any{"provider_health": health}, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("Error formatting JSON: %v", err)
+		}
+		return string(data) + "\n"
+	case "markdown":
+		var sb strings.Builder
+		sb.WriteString("\n## Provider Health\n\n")
+		sb.WriteString("| Provider | Requests | p50 | p95 | Errors | Retries |\n")
+		sb.WriteString("|----------|----------|-----|-----|--------|---------|\n")
+		for _, h := range health {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %dms | %dms | %s | %d |\n", h.Provider, h.Requests, h.P50MS, h.P95MS, healthErrors(h), h.Retries))
+		}
+		return sb.String()
+	default:
+		f.ui.PrintSection("PROVIDER HEALTH")
+		for _, h := range health {
+			f.ui.PrintKeyValue(h.Provider, fmt.Sprintf("%d requests, p50 %dms, p95 %dms, errors %s, retries %d",
+				h.Requests, h.P50MS, h.P95MS, healthErrors(h), h.Retries))
+		}
+		fmt.Println()
+		return ""
+	}
+}
+
+// FormatScorecard reports the site score weighted by page importance, its
+// pillars and the pages costing it most.
+func (f *Formatter) FormatScorecard(sc *importance.Scorecard) string {
+	pillars := make([]string, 0, len(sc.Pillars))
+	for key := range sc.Pillars {
+		pillars = append(pillars, key)
+	}
+	sort.Strings(pillars)
+	switch f.format {
+	case "json":
+		data, err := json.MarshalIndent(map[string]any{"scorecard": sc}, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("Error formatting JSON: %v", err)
+		}
+		return string(data) + "\n"
+	case "markdown":
+		var sb strings.Builder
+		sb.WriteString("\n## Site Scorecard\n\n")
+		sb.WriteString(fmt.Sprintf("- **Weighted score:** %d/100 (by %s)\n", sc.Score, sc.Source))
+		sb.WriteString(fmt.Sprintf("- **Unweighted average:** %d/100\n", sc.Average))
+		sb.WriteString(fmt.Sprintf("- **Pages weighted:** %d of %d scored\n", sc.Weighted, sc.Pages))
+		if sc.Failed > 0 {
+			sb.WriteString(fmt.Sprintf("- **Not scored:** %d\n", sc.Failed))
+		}
+		if len(pillars) > 0 {
+			sb.WriteString("\n| Pillar | Weighted score |\n|--------|----------------|\n")
+			for _, key := range pillars {
+				sb.WriteString(fmt.Sprintf("| %s | %d |\n", scorer.PillarName(key), sc.Pillars[key]))
+			}
+		}
+		if len(sc.Impact) > 0 {
+			sb.WriteString("\n### Pages Costing the Most\n\n")
+			sb.WriteString("| Page | Score | Share of weight | Points lost |\n|------|-------|-----------------|-------------|\n")
+			for _, p := range sc.Impact {
+				sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% | %.1f |\n", p.Key, p.Score, p.Share, p.Points))
+			}
+		}
+		if len(sc.Fixes) > 0 {
+			sb.WriteString("\n### Fixes by Affected Traffic\n\n")
+			sb.WriteString("| Finding | Pages | Traffic | Share of weight | Points | Top pages |\n|---------|-------|---------|-----------------|--------|-----------|\n")
+			for _, fix := range sc.Fixes {
+				sb.WriteString(fmt.Sprintf("| %s (`%s`) | %d | %s | %.1f%% | +%.1f | %s |\n", fix.Message, fix.ID, fix.Pages, formatWeight(fix.Traffic), fix.Share, fix.Points, strings.Join(fix.Top, ", ")))
+			}
+		}
+		return sb.String()
+	default:
+		f.ui.PrintSection("SITE SCORECARD")
+		f.ui.PrintScore("Weighted Score", sc.Score, 100)
+		f.ui.PrintKeyValue("Weighted by", sc.Source)
+		f.ui.PrintKeyValue("Unweighted", fmt.Sprintf("%d/100", sc.Average))
+		f.ui.PrintKeyValue("Pages weighted", fmt.Sprintf("%d of %d scored", sc.Weighted, sc.Pages))
+		if sc.Failed > 0 {
+			f.ui.PrintKeyValue("Not scored", fmt.Sprintf("%d", sc.Failed))
+		}
+		for _, key := range pillars {
+			f.ui.PrintKeyValue(scorer.PillarName(key), fmt.Sprintf("%d/100", sc.Pillars[key]))
+		}
+		if len(sc.Impact) > 0 {
+			fmt.Println()
+			f.ui.PrintSubsection("Pages costing the most")
+			for _, p := range sc.Impact {
+				f.ui.PrintListItem(fmt.Sprintf("%s: %d/100, %.1f%% of the weight, %.1f points lost", p.Key, p.Score, p.Share, p.Points), false)
+			}
+		}
+		if len(sc.Fixes) > 0 {
+			fmt.Println()
+			f.ui.PrintSubsection("Fixes by affected traffic")
+			for _, fix := range sc.Fixes {
+				f.ui.PrintListItem(fmt.Sprintf("%s [%s]: %d pages, traffic %s (%.1f%% of the weight), +%.1f points; %s", fix.Message, fix.ID, fix.Pages, formatWeight(fix.Traffic), fix.Share, fix.Points, strings.Join(fix.Top, ", ")), false)
+			}
+		}
+		fmt.Println()
+		return ""
+	}
+}
+
+// formatWeight writes a page weight without trailing zeros, e.g. "12000",
+// "0.5".
+func formatWeight(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
+
+// healthErrors describes a provider's errors, e.g. "3 (7.5%: rate_limit 2,
+// timeout 1)".
+func healthErrors(h llm.ProviderHealth) string {
+	if h.Errors == 0 {
+		return "0"
+	}
+	types := make([]string, 0, len(h.ByType))
+	for t := range h.ByType {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	for i, t := range types {
+		types[i] = fmt.Sprintf("%s %d", t, h.ByType[llm.ErrorType(t)])
+	}
+	return fmt.Sprintf("%d (%.1f%%: %s)", h.Errors, h.ErrorRate*100, strings.Join(types, ", "))
+}
+
 func (f *Formatter) FormatScanResults(results []*scanner.ScanResult) string {
 	switch f.format {
 	case "json":
 		return f.formatScanJSON(results)
 	case "markdown":
 		return f.formatScanMarkdown(results)
+	case "vscode":
+		return formatScanVSCode(results)
 	default:
 		return f.formatScanText(results)
 	}
 }
 
+// FormatScanResultsByOwner formats scan results grouped by the owners of
+// the files: JSON lists the groups with their files, text and Markdown add
+// a section per owner to the report. vscode diagnostics are not grouped.
+func (f *Formatter) FormatScanResultsByOwner(results []*scanner.ScanResult) string {
+	groups := scanner.GroupByOwner(results)
+	switch f.format {
+	case "json":
+		data, err := json.MarshalIndent(groups, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("Error formatting JSON: %v", err)
+		}
+		return string(data)
+	case "markdown":
+		return f.formatScanMarkdown(results) + formatOwnersMarkdown(groups)
+	case "vscode":
+		return formatScanVSCode(results)
+	default:
+		out := f.formatScanText(results)
+		f.printOwners(groups)
+		return out
+	}
+}
+
 func (f *Formatter) formatText(result *analyzer.Result) string {
 	var sb strings.Builder
 	
@@ -75,7 +238,15 @@ func (f *Formatter) formatText(result *analyzer.Result) string {
 		f.ui.PrintKeyValue("Title", result.Title)
 	}
 	f.ui.PrintKeyValue("Mode", strings.ToTitle(result.Mode))
-	f.ui.PrintKeyValue("Analyzed", result.ProcessedAt.Format("2006-01-02 15:04:05"))
+	if pageType := pageTypeLabel(result); pageType != "" {
+		f.ui.PrintKeyValue("Page Type", pageType)
+	}
+	if !result.ProcessedAt.IsZero() {
+		f.ui.PrintKeyValue("Analyzed", result.ProcessedAt.Format("2006-01-02 15:04:05"))
+	}
+	if note := archiveNote(result); note != "" {
+		f.ui.PrintKeyValue("Archive", note)
+	}
 	if result.TokensUsed > 0 {
 		f.ui.PrintKeyValue("Tokens", fmt.Sprintf("%d", result.TokensUsed))
 	}
@@ -102,21 +273,24 @@ func (f *Formatter) formatText(result *analyzer.Result) string {
 			fmt.Printf("    🤖 LLM-Based Scoring\n")
 		}
 	}
+	if result.LocalScore != nil {
+		f.printAccessWarnings(result.LocalScore)
+	}
+	if len(result.Frames) > 0 {
+		f.printFrames(result.Frames)
+	}
 	
 	// Detailed breakdown
 	if result.LocalScore != nil {
 		fmt.Println()
 		f.ui.PrintSection("DETAILED BREAKDOWN")
-		f.ui.PrintScore("Content Structure", 
-			result.LocalScore.Breakdown.ContentStructure.Score, 100)
-		f.ui.PrintScore("Semantic Clarity", 
-			result.LocalScore.Breakdown.SemanticClarity.Score, 100)
-		f.ui.PrintScore("Context Richness", 
-			result.LocalScore.Breakdown.ContextRichness.Score, 100)
-		f.ui.PrintScore("Authority Signals", 
-			result.LocalScore.Breakdown.AuthoritySignals.Score, 100)
-		f.ui.PrintScore("Accessibility", 
-			result.LocalScore.Breakdown.Accessibility.Score, 100)
+		for _, key := range result.LocalScore.PillarKeys() {
+			detail, _ := result.LocalScore.Pillar(key)
+			f.ui.PrintScore(result.LocalScore.PillarName(key), detail.Score, 100)
+		}
+		for _, warning := range result.LocalScore.Warnings {
+			f.ui.PrintWarning(warning)
+		}
 		
 		// Strengths
 		if len(result.LocalScore.Strengths) > 0 {
@@ -132,7 +306,129 @@ func (f *Formatter) formatText(result *analyzer.Result) string {
 			fmt.Println()
 			f.ui.PrintSubsection("Recommendations")
 			for i, suggestion := range result.Suggestions {
-				fmt.Printf("    %2d. %s\n", i+1, suggestion)
+				fmt.Printf("    %2d. %s\n", i+1, result.LocalScore.WithImpact(suggestion))
+			}
+		}
+
+		
+		// Evidence behind the findings that can be traced to page text
+		if hasEvidence(result.LocalScore.Findings) {
+			fmt.Println()
+			f.ui.PrintSubsection("Evidence")
+			for _, finding := range result.LocalScore.Findings {
+				if len(finding.Evidence) == 0 {
+					continue
+				}
+				f.ui.PrintListItem(fmt.Sprintf("[%s] %s", finding.Severity, finding.Message), false)
+				for _, ev := range finding.Evidence {
+					fmt.Printf("        > %s\n", ev.Snippet)
+					if where := evidenceLocation(ev); where != "" {
+						fmt.Printf("          (%s)\n", where)
+					}
+				}
+			}
+		}
+		
+		// A direct answer to open the page with
+		if advice := result.LocalScore.AnswerFirst; advice != nil && advice.Suggestion != "" {
+			fmt.Println()
+			f.ui.PrintSubsection("Answer First")
+			f.ui.PrintListItem(advice.Suggestion, false)
+			fmt.Printf("        > %s\n", advice.Evidence.Snippet)
+			if advice.Draft != "" {
+				fmt.Printf("          Suggested opening: %s\n", advice.Draft)
+			}
+		}
+
+		// A key-takeaways block to open the article with
+		if advice := result.LocalScore.KeyTakeaways; advice != nil && advice.Suggestion != "" {
+			fmt.Println()
+			f.ui.PrintSubsection("Key Takeaways")
+			f.ui.PrintListItem(advice.Suggestion, false)
+			if advice.Evidence != nil {
+				fmt.Printf("        > %s\n", advice.Evidence.Snippet)
+			}
+			if advice.Draft != "" {
+				for _, line := range strings.Split(advice.Draft, "\n") {
+					fmt.Printf("          %s\n", line)
+				}
+			}
+		}
+
+		// An introduction stating the scope and a conclusion to end with
+		for _, section := range []struct {
+			name   string
+			advice *scorer.SectionAdvice
+		}{{"Introduction", result.LocalScore.Intro}, {"Conclusion", result.LocalScore.Conclusion}} {
+			if section.advice == nil || section.advice.Suggestion == "" {
+				continue
+			}
+			fmt.Println()
+			f.ui.PrintSubsection(section.name)
+			f.ui.PrintListItem(section.advice.Suggestion, false)
+			if section.advice.Evidence != nil {
+				fmt.Printf("        > %s\n", section.advice.Evidence.Snippet)
+			}
+			if section.advice.Draft != "" {
+				for _, line := range strings.Split(section.advice.Draft, "\n") {
+					fmt.Printf("          %s\n", line)
+				}
+			}
+		}
+
+		// Prose that would read better as a table or list
+		if len(result.LocalScore.Formatting) > 0 {
+			fmt.Println()
+			f.ui.PrintSubsection("Formatting Advice")
+			for _, advice := range result.LocalScore.Formatting {
+				f.ui.PrintListItem(fmt.Sprintf("[%s] %s", advice.Kind, advice.Suggestion), false)
+				fmt.Printf("        > %s\n", advice.Evidence.Snippet)
+				if advice.Draft != "" {
+					for _, line := range strings.Split(advice.Draft, "\n") {
+						fmt.Printf("          %s\n", line)
+					}
+				}
+			}
+		}
+
+		// The sites the content cites, by kind of source
+		if len(result.LocalScore.CitedDomains) > 0 {
+			fmt.Println()
+			f.ui.PrintSubsection("Cited Domains")
+			for _, d := range result.LocalScore.CitedDomains {
+				fmt.Printf("    %-40s %-8s %d link(s)\n", d.Domain, d.Tier, d.Links)
+			}
+		}
+
+		// How much of the text sits under descriptive headings
+		if coverage := result.LocalScore.Coverage; coverage != nil && coverage.Words > 0 {
+			fmt.Println()
+			f.ui.PrintSubsection("Heading Coverage")
+			f.ui.PrintListItem(coverageSummary(coverage), coverage.Percent >= 50)
+			for _, ev := range coverage.Floating {
+				fmt.Printf("        > %s\n", ev.Snippet)
+			}
+		}
+
+		// Where the long sentences and paragraphs are
+		if lengths := result.LocalScore.Lengths; lengths != nil && lengths.Sentences.Count > 0 {
+			fmt.Println()
+			f.ui.PrintSubsection("Sentence & Paragraph Lengths")
+			for _, dist := range []struct {
+				name string
+				scorer.LengthDistribution
+			}{{"Sentences", lengths.Sentences}, {"Paragraphs", lengths.Paragraphs}} {
+				f.ui.PrintListItem(fmt.Sprintf("%s: %d, mean %.1f words, median %d, longest %d",
+					dist.name, dist.Count, dist.Mean, dist.Median, dist.Longest), false)
+				for _, bucket := range dist.Buckets {
+					fmt.Printf("        %-8s %-30s %d\n", bucket.Label(), lengthBar(bucket.Count, dist.Count), bucket.Count)
+				}
+				for _, outlier := range dist.Outliers {
+					fmt.Printf("        > (%d words) %s\n", outlier.Words, outlier.Evidence.Snippet)
+					if where := evidenceLocation(outlier.Evidence); where != "" {
+						fmt.Printf("          (%s)\n", where)
+					}
+				}
 			}
 		}
 	}
@@ -147,8 +443,9 @@ func (f *Formatter) formatText(result *analyzer.Result) string {
 				fmt.Println()
 				f.ui.PrintMarkdownContent("## 🤖 Enhanced Analysis Recommendation" + parts[1])
 			}
-		} else if result.Mode != "local" {
+		} else if result.Mode != "local" && !result.Streamed {
 			// This is LLM analysis content - format it beautifully
+			// (unless it was shown as it was generated)
 			fmt.Println()
 			f.ui.PrintSection("AI INSIGHTS")
 			fmt.Println()
@@ -158,6 +455,30 @@ func (f *Formatter) formatText(result *analyzer.Result) string {
 		}
 	}
 	
+	if len(result.FactorComparison) > 0 {
+		fmt.Println()
+		f.ui.PrintSection("LOCAL VS LLM")
+		for _, c := range result.FactorComparison {
+			line := fmt.Sprintf("%-22s local %3d  LLM %3d  (%s)", c.Name, c.Local, c.LLM, signed(c.Difference))
+			if c.NeedsReview {
+				line += "  needs human review"
+			}
+			f.ui.PrintListItem(line, !c.NeedsReview)
+		}
+		if result.NeedsReview {
+			fmt.Println()
+			f.ui.PrintWarning(fmt.Sprintf("Local and LLM scores differ by %d points or more; check the page before relying on the score", analyzer.ReviewDisagreement))
+		}
+	}
+	
+	if m := result.Manifest; m != nil {
+		fmt.Println()
+		f.ui.PrintSection("REPRODUCIBILITY")
+		for _, row := range manifestRows(m) {
+			f.ui.PrintKeyValue(row[0], row[1])
+		}
+	}
+	
 	fmt.Println()
 	
 	return sb.String()
@@ -181,13 +502,38 @@ func (f *Formatter) formatMarkdown(result *analyzer.Result) string {
 	if result.Title != "" {
 		sb.WriteString(fmt.Sprintf("**Title:** %s\n", result.Title))
 	}
-	sb.WriteString(fmt.Sprintf("**Analyzed:** %s\n", result.ProcessedAt.Format(time.RFC3339)))
+	if !result.ProcessedAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("**Analyzed:** %s\n", result.ProcessedAt.Format(time.RFC3339)))
+	}
+	if note := archiveNote(result); note != "" {
+		sb.WriteString(fmt.Sprintf("**Archive:** %s\n", note))
+	}
+	if pageType := pageTypeLabel(result); pageType != "" {
+		sb.WriteString(fmt.Sprintf("**Page Type:** %s\n", pageType))
+	}
 	if result.TokensUsed > 0 {
 		sb.WriteString(fmt.Sprintf("**Tokens Used:** %d\n", result.TokensUsed))
 	}
+	if result.LocalScore != nil {
+		sb.WriteString(formatAccessMarkdown(result.LocalScore, ""))
+	}
 	sb.WriteString("\n## Analysis\n\n")
 	sb.WriteString(result.Analysis)
 	sb.WriteString("\n")
+	if result.LocalScore != nil {
+		sb.WriteString(formatFindingsMarkdown(result.LocalScore.Findings, "##"))
+		sb.WriteString(formatAnswerMarkdown(result.LocalScore.AnswerFirst, "##"))
+		sb.WriteString(formatTakeawaysMarkdown(result.LocalScore.KeyTakeaways, "##"))
+		sb.WriteString(formatSectionMarkdown("Introduction", result.LocalScore.Intro, "##"))
+		sb.WriteString(formatSectionMarkdown("Conclusion", result.LocalScore.Conclusion, "##"))
+		sb.WriteString(formatAdviceMarkdown(result.LocalScore.Formatting, "##"))
+		sb.WriteString(formatCitedDomainsMarkdown(result.LocalScore.CitedDomains, "##"))
+		sb.WriteString(formatCoverageMarkdown(result.LocalScore.Coverage, "##"))
+		sb.WriteString(formatLengthsMarkdown(result.LocalScore.Lengths, "##"))
+	}
+	sb.WriteString(formatFramesMarkdown(result.Frames, "##"))
+	sb.WriteString(formatComparisonMarkdown(result.FactorComparison, "##"))
+	sb.WriteString(formatManifestMarkdown(result.Manifest, "##"))
 	
 	return sb.String()
 }
@@ -198,31 +544,53 @@ func (f *Formatter) formatBulkText(results []*bulk.BulkResult) string {
 	f.ui.PrintHeader("GEO BULK ANALYSIS REPORT")
 	
 	successCount := 0
-	errorCount := 0
+	reviewCount := 0
+	var failures []*analyzer.Error
 	totalScore := 0
 	
 	for i, result := range results {
 		f.ui.PrintSection(fmt.Sprintf("RESULT %d", i+1))
-		f.ui.PrintKeyValue("URL", result.URL)
+		if result.ID != "" {
+			f.ui.PrintKeyValue("ID", result.ID)
+		}
+		if result.URL != "" || result.ID == "" {
+			f.ui.PrintKeyValue("URL", result.URL)
+		}
+		if result.Attempts > 1 {
+			f.ui.PrintKeyValue("Attempts", fmt.Sprintf("%d", result.Attempts))
+		}
 		
-		if result.Error != "" {
+		if result.Error != nil {
 			fmt.Println()
-			f.ui.PrintError(fmt.Sprintf("Analysis failed: %s", result.Error))
-			errorCount++
+			f.ui.PrintError(fmt.Sprintf("Analysis failed (%s): %s", describeError(result.Error), result.Error.Message))
+			failures = append(failures, result.Error)
 		} else if result.Result != nil {
 			f.ui.PrintKeyValue("Title", result.Result.Title)
+			if pageType := pageTypeLabel(result.Result); pageType != "" {
+				f.ui.PrintKeyValue("Page Type", pageType)
+			}
 			if result.Result.TokensUsed > 0 {
 				f.ui.PrintKeyValue("Tokens", fmt.Sprintf("%d", result.Result.TokensUsed))
 			}
+			if triage := deepDiveNote(result.Result); triage != "" {
+				f.ui.PrintKeyValue("Deep Dive", triage)
+			}
+			if result.Result.NeedsReview {
+				f.ui.PrintKeyValue("Review", reviewNote(result.Result))
+				reviewCount++
+			}
 			fmt.Println()
 			f.ui.PrintScore("GEO Score", result.Result.Score, 100)
+			if result.Result.LocalScore != nil {
+				f.printAccessWarnings(result.Result.LocalScore)
+			}
 			
 			// Show all recommendations
 			if len(result.Result.Suggestions) > 0 {
 				fmt.Println()
 				f.ui.PrintSubsection("Recommendations")
 				for _, suggestion := range result.Result.Suggestions {
-					f.ui.PrintListItem(suggestion, false)
+					f.ui.PrintListItem(result.Result.LocalScore.WithImpact(suggestion), false)
 				}
 			}
 			
@@ -232,11 +600,28 @@ func (f *Formatter) formatBulkText(results []*bulk.BulkResult) string {
 		fmt.Println()
 	}
 	
+	if issues := bulk.CrawlabilityIssues(results); len(issues) > 0 {
+		f.ui.PrintSection("CRAWLABILITY ISSUES")
+		for _, issue := range issues {
+			f.ui.PrintListItem(fmt.Sprintf("%s: %s", issue.URL, issue.Detail), false)
+			for _, evidence := range issue.Evidence {
+				fmt.Printf("      %s\n", evidence)
+			}
+		}
+		fmt.Println()
+	}
+	
 	// Summary
 	f.ui.PrintSection("SUMMARY")
 	f.ui.PrintKeyValue("Total URLs", fmt.Sprintf("%d", len(results)))
 	f.ui.PrintKeyValue("Successful", fmt.Sprintf("%d", successCount))
-	f.ui.PrintKeyValue("Errors", fmt.Sprintf("%d", errorCount))
+	f.ui.PrintKeyValue("Errors", fmt.Sprintf("%d", len(failures)))
+	if len(failures) > 0 {
+		f.ui.PrintKeyValue("By cause", failureBreakdown(failures))
+	}
+	if reviewCount > 0 {
+		f.ui.PrintKeyValue("Needs review", fmt.Sprintf("%d", reviewCount))
+	}
 	
 	if successCount > 0 {
 		avgScore := totalScore / successCount
@@ -269,29 +654,77 @@ func (f *Formatter) formatBulkMarkdown(results []*bulk.BulkResult) string {
 	sb.WriteString("# GEO Bulk Analysis Report\n\n")
 	
 	successCount := 0
-	errorCount := 0
+	reviewCount := 0
+	var failures []*analyzer.Error
 	
 	for i, result := range results {
 		sb.WriteString(fmt.Sprintf("## Result %d\n\n", i+1))
-		sb.WriteString(fmt.Sprintf("**URL:** %s\n\n", result.URL))
+		if result.ID != "" {
+			sb.WriteString(fmt.Sprintf("**ID:** %s\n\n", result.ID))
+		}
+		if result.URL != "" || result.ID == "" {
+			sb.WriteString(fmt.Sprintf("**URL:** %s\n\n", result.URL))
+		}
+		if result.Attempts > 1 {
+			sb.WriteString(fmt.Sprintf("**Attempts:** %d\n\n", result.Attempts))
+		}
 		
-		if result.Error != "" {
-			sb.WriteString(fmt.Sprintf("**ERROR:** %s\n\n", result.Error))
-			errorCount++
+		if result.Error != nil {
+			sb.WriteString(fmt.Sprintf("**ERROR (%s):** %s\n\n", describeError(result.Error), result.Error.Message))
+			failures = append(failures, result.Error)
 		} else if result.Result != nil {
 			sb.WriteString(fmt.Sprintf("**Title:** %s\n", result.Result.Title))
+			if pageType := pageTypeLabel(result.Result); pageType != "" {
+				sb.WriteString(fmt.Sprintf("**Page Type:** %s\n", pageType))
+			}
+			if triage := deepDiveNote(result.Result); triage != "" {
+				sb.WriteString(fmt.Sprintf("**Deep Dive:** %s\n", triage))
+			}
+			if result.Result.NeedsReview {
+				sb.WriteString(fmt.Sprintf("**Review:** %s\n", reviewNote(result.Result)))
+				reviewCount++
+			}
 			sb.WriteString(fmt.Sprintf("**Tokens Used:** %d\n\n", result.Result.TokensUsed))
+			if result.Result.LocalScore != nil {
+				sb.WriteString(formatAccessMarkdown(result.Result.LocalScore, "\n"))
+			}
 			sb.WriteString("### Analysis\n\n")
 			sb.WriteString(result.Result.Analysis)
 			sb.WriteString("\n\n")
+			if result.Result.LocalScore != nil {
+				sb.WriteString(formatFindingsMarkdown(result.Result.LocalScore.Findings, "###"))
+				sb.WriteString(formatAnswerMarkdown(result.Result.LocalScore.AnswerFirst, "###"))
+				sb.WriteString(formatTakeawaysMarkdown(result.Result.LocalScore.KeyTakeaways, "###"))
+				sb.WriteString(formatSectionMarkdown("Introduction", result.Result.LocalScore.Intro, "###"))
+				sb.WriteString(formatSectionMarkdown("Conclusion", result.Result.LocalScore.Conclusion, "###"))
+				sb.WriteString(formatAdviceMarkdown(result.Result.LocalScore.Formatting, "###"))
+			}
+			sb.WriteString(formatComparisonMarkdown(result.Result.FactorComparison, "###"))
 			successCount++
 		}
 	}
 	
+	if issues := bulk.CrawlabilityIssues(results); len(issues) > 0 {
+		sb.WriteString("## Crawlability Issues\n\n")
+		for _, issue := range issues {
+			sb.WriteString(fmt.Sprintf("- **%s** — %s\n", issue.URL, issue.Detail))
+			for _, evidence := range issue.Evidence {
+				sb.WriteString(fmt.Sprintf("  - `%s`\n", evidence))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	
 	sb.WriteString("## Summary\n\n")
 	sb.WriteString(fmt.Sprintf("- **Total URLs:** %d\n", len(results)))
 	sb.WriteString(fmt.Sprintf("- **Successful:** %d\n", successCount))
-	sb.WriteString(fmt.Sprintf("- **Errors:** %d\n", errorCount))
+	sb.WriteString(fmt.Sprintf("- **Errors:** %d\n", len(failures)))
+	if len(failures) > 0 {
+		sb.WriteString(fmt.Sprintf("- **By cause:** %s\n", failureBreakdown(failures)))
+	}
+	if reviewCount > 0 {
+		sb.WriteString(fmt.Sprintf("- **Needs review:** %d\n", reviewCount))
+	}
 	
 	return sb.String()
 }
@@ -303,31 +736,37 @@ func (f *Formatter) formatScanText(results []*scanner.ScanResult) string {
 	fmt.Println()
 	
 	successCount := 0
-	errorCount := 0
+	var failures []*analyzer.Error
 	totalScore := 0
 	
 	for i, result := range results {
 		f.ui.PrintSection(fmt.Sprintf("FILE %d", i+1))
 		f.ui.PrintKeyValue("Path", result.FilePath)
 		
-		if result.Error != "" {
+		if result.Error != nil {
 			fmt.Println()
-			f.ui.PrintError(fmt.Sprintf("Analysis failed: %s", result.Error))
-			errorCount++
+			f.ui.PrintError(fmt.Sprintf("Analysis failed (%s): %s", describeError(result.Error), result.Error.Message))
+			failures = append(failures, result.Error)
 		} else if result.Result != nil {
 			f.ui.PrintKeyValue("Title", result.Result.Title)
+			if pageType := pageTypeLabel(result.Result); pageType != "" {
+				f.ui.PrintKeyValue("Page Type", pageType)
+			}
 			if result.Result.TokensUsed > 0 {
 				f.ui.PrintKeyValue("Tokens", fmt.Sprintf("%d", result.Result.TokensUsed))
 			}
 			fmt.Println()
 			f.ui.PrintScore("GEO Score", result.Result.Score, 100)
+			if result.Result.LocalScore != nil {
+				f.printAccessWarnings(result.Result.LocalScore)
+			}
 			
 			// Show all recommendations if available
 			if len(result.Result.Suggestions) > 0 {
 				fmt.Println()
 				f.ui.PrintSubsection("Recommendations")
 				for _, suggestion := range result.Result.Suggestions {
-					f.ui.PrintListItem(suggestion, false)
+					f.ui.PrintListItem(result.Result.LocalScore.WithImpact(suggestion), false)
 				}
 			}
 			
@@ -341,7 +780,10 @@ func (f *Formatter) formatScanText(results []*scanner.ScanResult) string {
 	f.ui.PrintSection("SUMMARY")
 	f.ui.PrintKeyValue("Total Files", fmt.Sprintf("%d", len(results)))
 	f.ui.PrintKeyValue("Successful", fmt.Sprintf("%d", successCount))
-	f.ui.PrintKeyValue("Errors", fmt.Sprintf("%d", errorCount))
+	f.ui.PrintKeyValue("Errors", fmt.Sprintf("%d", len(failures)))
+	if len(failures) > 0 {
+		f.ui.PrintKeyValue("By cause", failureBreakdown(failures))
+	}
 	
 	if successCount > 0 {
 		avgScore := totalScore / successCount
@@ -368,27 +810,77 @@ func (f *Formatter) formatScanJSON(results []*scanner.ScanResult) string {
 	return string(data)
 }
 
+// formatScanVSCode writes one "file:line:col: severity: message" line per
+// finding, the format editor problem matchers parse compiler diagnostics
+// from. Findings with no position in the file are reported on its first
+// line; high severity maps to error, medium to warning and low to info.
+func formatScanVSCode(results []*scanner.ScanResult) string {
+	var sb strings.Builder
+	for _, result := range results {
+		if result.Error != nil {
+			sb.WriteString(fmt.Sprintf("%s:1:1: error: analysis failed (%s): %s\n", result.FilePath, describeError(result.Error), result.Error.Message))
+			continue
+		}
+		for _, loc := range result.Locations {
+			line, col := max(loc.Line, 1), max(loc.Col, 1)
+			message := fmt.Sprintf("%s [%s]", loc.Finding.Message, loc.Finding.ID)
+			if loc.Finding.Impact > 0 {
+				message += fmt.Sprintf(" (+%d pts)", loc.Finding.Impact)
+			}
+			sb.WriteString(fmt.Sprintf("%s:%d:%d: %s: %s\n", result.FilePath, line, col, diagnosticSeverity(loc.Finding.Severity), message))
+		}
+	}
+	return sb.String()
+}
+
+// diagnosticSeverity maps a finding severity to a compiler diagnostic
+// level.
+func diagnosticSeverity(severity string) string {
+	switch severity {
+	case scorer.SeverityHigh:
+		return "error"
+	case scorer.SeverityMedium:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
 func (f *Formatter) formatScanMarkdown(results []*scanner.ScanResult) string {
 	var sb strings.Builder
 	
 	sb.WriteString("# GEO Directory Scan Report\n\n")
 	
 	successCount := 0
-	errorCount := 0
+	var failures []*analyzer.Error
 	
 	for i, result := range results {
 		sb.WriteString(fmt.Sprintf("## File %d\n\n", i+1))
 		sb.WriteString(fmt.Sprintf("**Path:** `%s`\n\n", result.FilePath))
 		
-		if result.Error != "" {
-			sb.WriteString(fmt.Sprintf("**ERROR:** %s\n\n", result.Error))
-			errorCount++
+		if result.Error != nil {
+			sb.WriteString(fmt.Sprintf("**ERROR (%s):** %s\n\n", describeError(result.Error), result.Error.Message))
+			failures = append(failures, result.Error)
 		} else if result.Result != nil {
 			sb.WriteString(fmt.Sprintf("**Title:** %s\n", result.Result.Title))
+			if pageType := pageTypeLabel(result.Result); pageType != "" {
+				sb.WriteString(fmt.Sprintf("**Page Type:** %s\n", pageType))
+			}
 			sb.WriteString(fmt.Sprintf("**Tokens Used:** %d\n\n", result.Result.TokensUsed))
+			if result.Result.LocalScore != nil {
+				sb.WriteString(formatAccessMarkdown(result.Result.LocalScore, "\n"))
+			}
 			sb.WriteString("### Analysis\n\n")
 			sb.WriteString(result.Result.Analysis)
 			sb.WriteString("\n\n")
+			if result.Result.LocalScore != nil {
+				sb.WriteString(formatFindingsMarkdown(result.Result.LocalScore.Findings, "###"))
+				sb.WriteString(formatAnswerMarkdown(result.Result.LocalScore.AnswerFirst, "###"))
+				sb.WriteString(formatTakeawaysMarkdown(result.Result.LocalScore.KeyTakeaways, "###"))
+				sb.WriteString(formatSectionMarkdown("Introduction", result.Result.LocalScore.Intro, "###"))
+				sb.WriteString(formatSectionMarkdown("Conclusion", result.Result.LocalScore.Conclusion, "###"))
+				sb.WriteString(formatAdviceMarkdown(result.Result.LocalScore.Formatting, "###"))
+			}
 			successCount++
 		}
 	}
@@ -396,7 +888,518 @@ func (f *Formatter) formatScanMarkdown(results []*scanner.ScanResult) string {
 	sb.WriteString("## Summary\n\n")
 	sb.WriteString(fmt.Sprintf("- **Total Files:** %d\n", len(results)))
 	sb.WriteString(fmt.Sprintf("- **Successful:** %d\n", successCount))
-	sb.WriteString(fmt.Sprintf("- **Errors:** %d\n", errorCount))
+	sb.WriteString(fmt.Sprintf("- **Errors:** %d\n", len(failures)))
+	if len(failures) > 0 {
+		sb.WriteString(fmt.Sprintf("- **By cause:** %s\n", failureBreakdown(failures)))
+	}
 	
 	return sb.String()
-}
\ No newline at end of file
+}
+
+// pageTypeLabel describes the page type detected for the auto profile,
+// e.g. "product (heuristics, 83% confident)", or "" when none was.
+func pageTypeLabel(result *analyzer.Result) string {
+	pageType, ok := result.Metadata["page_type"]
+	if !ok {
+		return ""
+	}
+	confidence, _ := result.Metadata["page_type_confidence"].(float64)
+	return fmt.Sprintf("%v (%v, %.0f%% confident)", pageType, result.Metadata["page_type_source"], confidence*100)
+}
+
+func hasEvidence(findings []scorer.Finding) bool {
+	for _, finding := range findings {
+		if len(finding.Evidence) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// evidenceLocation describes where a piece of evidence sits in the page.
+func evidenceLocation(ev scorer.Evidence) string {
+	var parts []string
+	if len(ev.HeadingPath) > 0 {
+		parts = append(parts, "under "+strings.Join(ev.HeadingPath, " › "))
+	}
+	if ev.Start >= 0 {
+		parts = append(parts, fmt.Sprintf("chars %d–%d", ev.Start, ev.End))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatFindingsMarkdown renders findings that carry evidence as quoted
+// snippets under a heading of the given level.
+func formatFindingsMarkdown(findings []scorer.Finding, level string) string {
+	if !hasEvidence(findings) {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Evidence\n\n", level))
+	for _, finding := range findings {
+		if len(finding.Evidence) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("**%s** (`%s`, %s severity)\n\n", finding.Message, finding.ID, finding.Severity))
+		for _, ev := range finding.Evidence {
+			sb.WriteString(fmt.Sprintf("> %s\n", ev.Snippet))
+			if where := evidenceLocation(ev); where != "" {
+				sb.WriteString(fmt.Sprintf(">\n> — %s\n", where))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// deepDiveNote describes the part of a two-pass run a result had: the
+// local triage score of pages analyzed again with the LLM, or why that
+// analysis failed; "" outside two-pass runs and for pages left at triage.
+func deepDiveNote(result *analyzer.Result) string {
+	if msg, ok := result.Metadata["deep_dive_error"].(string); ok {
+		return "failed, local triage score kept: " + msg
+	}
+	// Reports read back from JSON hold numbers as float64
+	switch score := result.Metadata["triage_score"].(type) {
+	case int:
+		return fmt.Sprintf("analyzed with the LLM after a local triage score of %d/100", score)
+	case float64:
+		return fmt.Sprintf("analyzed with the LLM after a local triage score of %d/100", int(score))
+	}
+	return ""
+}
+
+// describeError summarizes an analysis error's category, status code and
+// whether a rerun may succeed, e.g. "fetch, HTTP 503, retryable".
+func describeError(e *analyzer.Error) string {
+	parts := []string{string(e.Category)}
+	if e.Category == "" {
+		parts[0] = "unknown"
+	}
+	if e.StatusCode != 0 {
+		parts = append(parts, fmt.Sprintf("HTTP %d", e.StatusCode))
+	}
+	if e.Retryable {
+		parts = append(parts, "retryable")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// failureBreakdown counts failures per category, in a fixed category order,
+// e.g. "fetch 3 (2 retryable), timeout 1 (1 retryable)".
+func failureBreakdown(failures []*analyzer.Error) string {
+	categories := []analyzer.ErrorCategory{
+		analyzer.CategoryFetch, analyzer.CategoryExtract, analyzer.CategoryLLM, analyzer.CategoryTimeout, "",
+	}
+	total := make(map[analyzer.ErrorCategory]int)
+	retryable := make(map[analyzer.ErrorCategory]int)
+	for _, e := range failures {
+		total[e.Category]++
+		if e.Retryable {
+			retryable[e.Category]++
+		}
+	}
+
+	var parts []string
+	for _, category := range categories {
+		if total[category] == 0 {
+			continue
+		}
+		name := string(category)
+		if name == "" {
+			name = "unknown"
+		}
+		part := fmt.Sprintf("%s %d", name, total[category])
+		if retryable[category] > 0 {
+			part += fmt.Sprintf(" (%d retryable)", retryable[category])
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatAnswerMarkdown renders the answer-first advice, with the drafted
+// opening, under a heading of the given level.
+func formatAnswerMarkdown(advice *scorer.AnswerAdvice, level string) string {
+	if advice == nil || advice.Suggestion == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Answer First\n\n", level))
+	sb.WriteString(fmt.Sprintf("**%s**\n\n", advice.Suggestion))
+	sb.WriteString(fmt.Sprintf("> %s\n\n", advice.Evidence.Snippet))
+	if advice.Draft != "" {
+		sb.WriteString("Suggested opening:\n\n")
+		sb.WriteString(advice.Draft)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// formatTakeawaysMarkdown renders the key-takeaways advice, with the
+// drafted block, under a heading of the given level.
+func formatTakeawaysMarkdown(advice *scorer.SummaryAdvice, level string) string {
+	if advice == nil || advice.Suggestion == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Key Takeaways\n\n", level))
+	sb.WriteString(fmt.Sprintf("**%s**\n\n", advice.Suggestion))
+	if advice.Evidence != nil {
+		sb.WriteString(fmt.Sprintf("> %s\n\n", advice.Evidence.Snippet))
+	}
+	if advice.Draft != "" {
+		sb.WriteString("Suggested block:\n\n")
+		sb.WriteString(advice.Draft)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// formatSectionMarkdown renders the introduction or conclusion advice,
+// with the drafted text, under a heading of the given level.
+func formatSectionMarkdown(name string, advice *scorer.SectionAdvice, level string) string {
+	if advice == nil || advice.Suggestion == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s %s\n\n", level, name))
+	sb.WriteString(fmt.Sprintf("**%s**\n\n", advice.Suggestion))
+	if advice.Evidence != nil {
+		sb.WriteString(fmt.Sprintf("> %s\n\n", advice.Evidence.Snippet))
+	}
+	if advice.Draft != "" {
+		sb.WriteString("Suggested text:\n\n")
+		sb.WriteString(advice.Draft)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// formatAdviceMarkdown renders formatting advice, with any LLM drafts,
+// under a heading of the given level.
+func formatAdviceMarkdown(advice []scorer.FormatAdvice, level string) string {
+	if len(advice) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Formatting Advice\n\n", level))
+	for _, a := range advice {
+		sb.WriteString(fmt.Sprintf("**%s** (%s)\n\n", a.Suggestion, a.Kind))
+		sb.WriteString(fmt.Sprintf("> %s\n\n", a.Evidence.Snippet))
+		if a.Draft != "" {
+			sb.WriteString("Suggested rewrite:\n\n")
+			sb.WriteString(a.Draft)
+			sb.WriteString("\n\n")
+		}
+	}
+	return sb.String()
+}
+
+// aiOptOutNote explains what an AI opt-out means for the score.
+const aiOptOutNote = "AI systems that honour these signals may not train on, use or cite it, however well it scores."
+
+// printAccessWarnings warns that the page opts out of AI use, with the
+// tags and headers declaring it, or that its content sits behind a
+// paywall or login wall.
+func (f *Formatter) printAccessWarnings(score *scorer.GEOScore) {
+	if optOut := score.AIOptOut; optOut != nil {
+		fmt.Println()
+		f.ui.PrintWarning(optOut.Summary() + ". " + aiOptOutNote)
+		for _, source := range optOut.Sources {
+			fmt.Printf("    %s\n", source)
+		}
+		if optOut.TDMPolicy != "" {
+			fmt.Printf("    TDM policy: %s\n", optOut.TDMPolicy)
+		}
+	}
+	if wall := score.Paywall; wall != nil {
+		fmt.Println()
+		f.ui.PrintWarning(wall.Narrative())
+		for _, signal := range wall.Signals {
+			fmt.Printf("    %s\n", signal)
+		}
+	}
+}
+
+// formatAccessMarkdown renders the AI opt-out and paywall warnings as
+// blockquotes, each followed by sep when there is one.
+func formatAccessMarkdown(score *scorer.GEOScore, sep string) string {
+	var sb strings.Builder
+	if optOut := score.AIOptOut; optOut != nil {
+		sb.WriteString(fmt.Sprintf("\n> ⚠️ **AI opt-out:** %s. %s\n>\n", optOut.Summary(), aiOptOutNote))
+		for _, source := range optOut.Sources {
+			sb.WriteString(fmt.Sprintf("> - `%s`\n", source))
+		}
+		if optOut.TDMPolicy != "" {
+			sb.WriteString(fmt.Sprintf("> - TDM policy: %s\n", optOut.TDMPolicy))
+		}
+		sb.WriteString(sep)
+	}
+	if wall := score.Paywall; wall != nil {
+		sb.WriteString(fmt.Sprintf("\n> 🔒 **Content access:** %s\n>\n", wall.Narrative()))
+		for _, signal := range wall.Signals {
+			sb.WriteString(fmt.Sprintf("> - %s\n", signal))
+		}
+		sb.WriteString(sep)
+	}
+	return sb.String()
+}
+
+// framesNote explains why iframe content is at risk.
+const framesNote = "AI crawlers may not follow iframes, so content that only exists in them is at risk of being missed."
+
+// frameStatus describes whether a frame's content was analyzed.
+func frameStatus(frame webpage.Frame) string {
+	switch {
+	case frame.Inlined:
+		return fmt.Sprintf("inlined, %d words", frame.Words)
+	case frame.Error != "":
+		return "fetch failed: " + frame.Error
+	case !frame.SameOrigin:
+		return "other origin, not analyzed"
+	}
+	return "not analyzed (use --inline-frames)"
+}
+
+// printFrames lists the iframes in the content and whether their content
+// was analyzed.
+func (f *Formatter) printFrames(frames []webpage.Frame) {
+	fmt.Println()
+	f.ui.PrintSubsection("Embedded Frames")
+	for _, frame := range frames {
+		label := frame.URL
+		if frame.Title != "" {
+			label = fmt.Sprintf("%s (%s)", frame.Title, frame.URL)
+		}
+		f.ui.PrintListItem(fmt.Sprintf("%s: %s", label, frameStatus(frame)), frame.Inlined)
+	}
+	f.ui.PrintInfo(framesNote)
+}
+
+// formatFramesMarkdown renders the iframes in the content as a table under
+// a heading of the given level.
+func formatFramesMarkdown(frames []webpage.Frame, level string) string {
+	if len(frames) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Embedded Frames\n\n%s\n\n", level, framesNote))
+	sb.WriteString("| Frame | Title | Content |\n|---|---|---|\n")
+	for _, frame := range frames {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", frame.URL, frame.Title, frameStatus(frame)))
+	}
+	return sb.String()
+}
+
+// archiveNote says where the response scored was archived, or when the
+// archived response scored again was fetched.
+func archiveNote(result *analyzer.Result) string {
+	// Reports read back from JSON hold the time as a string
+	switch at := result.Metadata["archived_at"].(type) {
+	case time.Time:
+		return "scored from a copy fetched " + at.Local().Format("2006-01-02 15:04:05")
+	case string:
+		return "scored from a copy fetched " + at
+	}
+	if result.Archive != nil {
+		return "kept in " + result.Archive.Path
+	}
+	if msg, ok := result.Metadata["archive_error"].(string); ok {
+		return "not kept: " + msg
+	}
+	return ""
+}
+
+// reviewNote names the factors whose local and LLM scores are too far
+// apart to be trusted without a look at the page.
+func reviewNote(result *analyzer.Result) string {
+	var factors []string
+	for _, c := range result.FactorComparison {
+		if c.NeedsReview {
+			factors = append(factors, fmt.Sprintf("%s (local %d, LLM %d)", c.Name, c.Local, c.LLM))
+		}
+	}
+	return "local and LLM scores disagree on " + strings.Join(factors, ", ")
+}
+
+// signed formats a score difference with its sign, e.g. "+3", "-2", "0".
+func signed(diff int) string {
+	if diff > 0 {
+		return fmt.Sprintf("+%d", diff)
+	}
+	return fmt.Sprintf("%d", diff)
+}
+
+// formatComparisonMarkdown renders the local and LLM score of each factor
+// as a table under a heading of the given level.
+func formatComparisonMarkdown(comparisons []analyzer.FactorComparison, level string) string {
+	if len(comparisons) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Local vs LLM\n\n", level))
+	sb.WriteString("| Factor | Local | LLM | Difference | |\n|--------|-------|-----|------------|-|\n")
+	for _, c := range comparisons {
+		flag := ""
+		if c.NeedsReview {
+			flag = "⚠️ needs human review"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s | %s |\n", c.Name, c.Local, c.LLM, signed(c.Difference), flag))
+	}
+	return sb.String()
+}
+
+// manifestRows returns the manifest as label/value pairs, the effective
+// configuration as compact JSON.
+func manifestRows(m *analyzer.Manifest) [][2]string {
+	rows := [][2]string{{"Tool", m.ToolVersion}, {"Scorer", m.ScorerVersion}, {"Go", m.GoVersion}}
+	if m.Provider != "" {
+		rows = append(rows, [2]string{"Provider", m.Provider}, [2]string{"Model", m.Model})
+	}
+	if m.PromptHash != "" {
+		rows = append(rows, [2]string{"Prompt", m.PromptHash})
+	}
+	config, _ := json.Marshal(m.Config)
+	return append(rows, [2]string{"Config", string(config)})
+}
+
+// formatManifestMarkdown renders what produced the result, so the report
+// can be reproduced, under a heading of the given level.
+func formatManifestMarkdown(m *analyzer.Manifest, level string) string {
+	if m == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Reproducibility\n\n", level))
+	for _, row := range manifestRows(m) {
+		if row[0] == "Config" {
+			sb.WriteString(fmt.Sprintf("- **Config:** `%s`\n", row[1]))
+		} else {
+			sb.WriteString(fmt.Sprintf("- **%s:** %s\n", row[0], row[1]))
+		}
+	}
+	return sb.String()
+}
+
+// formatCitedDomainsMarkdown renders the sites the content cites as a
+// table under a heading of the given level.
+func formatCitedDomainsMarkdown(domains []scorer.CitedDomain, level string) string {
+	if len(domains) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Cited Domains\n\n", level))
+	sb.WriteString("| Domain | Tier | Links |\n|---|---|---|\n")
+	for _, d := range domains {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d |\n", d.Domain, d.Tier, d.Links))
+	}
+	return sb.String()
+}
+
+// coverageSummary describes where the words of a page sit.
+func coverageSummary(coverage *scorer.HeadingCoverage) string {
+	return fmt.Sprintf("%d%% of %d words under descriptive headings (%d before the first section, %d under generic headings)",
+		coverage.Percent, coverage.Words, coverage.Preamble, coverage.Generic)
+}
+
+// formatCoverageMarkdown renders the heading coverage, with the largest
+// floating blocks, under a heading of the given level.
+func formatCoverageMarkdown(coverage *scorer.HeadingCoverage, level string) string {
+	if coverage == nil || coverage.Words == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Heading Coverage\n\n", level))
+	sb.WriteString(coverageSummary(coverage) + ".\n\n")
+	for _, ev := range coverage.Floating {
+		sb.WriteString(fmt.Sprintf("- %s\n", ev.Snippet))
+	}
+	return sb.String()
+}
+
+// formatLengthsMarkdown renders the sentence and paragraph length
+// histograms, with the outliers to shorten, under a heading of the given
+// level.
+func formatLengthsMarkdown(lengths *scorer.LengthReport, level string) string {
+	if lengths == nil || lengths.Sentences.Count == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n%s Sentence & Paragraph Lengths\n\n", level))
+	sb.WriteString("| Words | Sentences | Words | Paragraphs |\n|---|---|---|---|\n")
+	for i, bucket := range lengths.Sentences.Buckets {
+		paragraphs := lengths.Paragraphs.Buckets[i]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s | %d |\n", bucket.Label(), bucket.Count, paragraphs.Label(), paragraphs.Count))
+	}
+	sb.WriteString(fmt.Sprintf("\nSentences: mean %.1f words, median %d, longest %d. Paragraphs: mean %.1f words, median %d, longest %d.\n\n",
+		lengths.Sentences.Mean, lengths.Sentences.Median, lengths.Sentences.Longest,
+		lengths.Paragraphs.Mean, lengths.Paragraphs.Median, lengths.Paragraphs.Longest))
+	for _, outlier := range append(lengths.Sentences.Outliers, lengths.Paragraphs.Outliers...) {
+		sb.WriteString(fmt.Sprintf("- **%d words:** %s", outlier.Words, outlier.Evidence.Snippet))
+		if where := evidenceLocation(outlier.Evidence); where != "" {
+			sb.WriteString(fmt.Sprintf(" _(%s)_", where))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// lengthBar draws count out of total as a bar of up to 30 blocks.
+func lengthBar(count, total int) string {
+	if total == 0 {
+		return ""
+	}
+	return strings.Repeat("█", (count*30+total-1)/total)
+}
+
+// ownerFileSummary describes a file of an owner's group: its score and
+// number of recommendations, or why it failed.
+func ownerFileSummary(result *scanner.ScanResult) string {
+	if result.Error != nil || result.Result == nil {
+		return fmt.Sprintf("%s: analysis failed", result.FilePath)
+	}
+	return fmt.Sprintf("%s: %d/100, %d recommendations", result.FilePath, result.Result.Score, len(result.Result.Suggestions))
+}
+
+// printOwners lists the files of each owner, worst scores first.
+func (f *Formatter) printOwners(groups []*scanner.OwnerGroup) {
+	f.ui.PrintSection("BY OWNER")
+	for _, group := range groups {
+		fmt.Println()
+		f.ui.PrintSubsection(fmt.Sprintf("%s (%d files, average %d/100, %d recommendations)", group.Owner, len(group.Files), group.AverageScore, group.Suggestions))
+		for _, file := range group.Files {
+			f.ui.PrintListItem(ownerFileSummary(file), file.Error == nil && file.Result != nil && file.Result.Score >= 80)
+		}
+	}
+}
+
+// formatOwnersMarkdown renders the files of each owner, worst scores
+// first.
+func formatOwnersMarkdown(groups []*scanner.OwnerGroup) string {
+	var sb strings.Builder
+	sb.WriteString("\n## By Owner\n\n")
+	sb.WriteString("| Owner | Files | Average | Recommendations | Errors |\n")
+	sb.WriteString("|-------|-------|---------|-----------------|--------|\n")
+	for _, group := range groups {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d/100 | %d | %d |\n", group.Owner, len(group.Files), group.AverageScore, group.Suggestions, group.Errors))
+	}
+	for _, group := range groups {
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", group.Owner))
+		for _, file := range group.Files {
+			sb.WriteString("- " + ownerFileSummary(file) + "\n")
+		}
+	}
+	return sb.String()
+}
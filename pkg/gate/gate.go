@@ -0,0 +1,108 @@
+// Package gate checks scores against minimums, so that analyze, bulk and
+// scan can fail a CI build when documentation falls below a quality bar.
+package gate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"geo-checker/pkg/baseline"
+	"geo-checker/pkg/scorer"
+)
+
+// ExitCode is the exit status of a run that did not pass the gate, the one
+// draft-check, check and sitemap --fail-on-issues use for failed checks.
+const ExitCode = 2
+
+// Thresholds are the minimum scores a page must reach.
+type Thresholds struct {
+	Score   int            // overall score; 0 checks nothing
+	Factors map[string]int // pillar key to minimum pillar score
+}
+
+// Enabled reports whether any minimum is set.
+func (t Thresholds) Enabled() bool {
+	return t.Score > 0 || len(t.Factors) > 0
+}
+
+// ParseFactors parses per-factor minimums given as pillar=score, e.g.
+// "structured_data=50". Pillar display names are accepted for the built-in
+// pillars, in any case and with spaces or hyphens for underscores.
+func ParseFactors(values []string) (map[string]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	factors := make(map[string]int, len(values))
+	for _, v := range values {
+		name, min, ok := strings.Cut(v, "=")
+		score, err := strconv.Atoi(strings.TrimSpace(min))
+		if !ok || err != nil || score < 0 || score > 100 {
+			return nil, fmt.Errorf("invalid factor threshold %q: give pillar=score with a score from 0 to 100, e.g. structured_data=50", v)
+		}
+		key := strings.ToLower(strings.TrimSpace(name))
+		key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid factor threshold %q: no pillar named", v)
+		}
+		factors[key] = score
+	}
+	return factors, nil
+}
+
+// Validate checks that every factor with a minimum names one of the pillars
+// in keys, so a misspelled pillar fails the run before any page is fetched.
+func (t Thresholds) Validate(keys []string) error {
+	known := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		known[key] = true
+	}
+	var unknown []string
+	for key := range t.Factors {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown pillar %s in --fail-under-factor (want one of %s)", strings.Join(unknown, ", "), strings.Join(keys, ", "))
+}
+
+// Check returns why each page falls short of the thresholds, in page order.
+// A page that could not be analyzed fails, as nothing shows it passes, and
+// so does one without a score for a factor with a minimum, which catches
+// misspelled pillar names.
+func (t Thresholds) Check(pages []baseline.Page) []string {
+	if !t.Enabled() {
+		return nil
+	}
+	factors := make([]string, 0, len(t.Factors))
+	for key := range t.Factors {
+		factors = append(factors, key)
+	}
+	sort.Strings(factors)
+
+	var violations []string
+	for _, page := range pages {
+		if page.Error != "" {
+			violations = append(violations, fmt.Sprintf("%s could not be analyzed: %s", page.Key, page.Error))
+			continue
+		}
+		if t.Score > 0 && page.Score < t.Score {
+			violations = append(violations, fmt.Sprintf("%s scored %d (minimum %d)", page.Key, page.Score, t.Score))
+		}
+		for _, key := range factors {
+			score, ok := page.Pillars[key]
+			switch {
+			case !ok:
+				violations = append(violations, fmt.Sprintf("%s has no %s score", page.Key, key))
+			case score < t.Factors[key]:
+				violations = append(violations, fmt.Sprintf("%s scored %d on %s (minimum %d)", page.Key, score, scorer.PillarName(key), t.Factors[key]))
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,58 @@
+package gate
+
+import (
+	"reflect"
+	"testing"
+
+	"geo-checker/pkg/baseline"
+)
+
+func TestParseFactors(t *testing.T) {
+	factors, err := ParseFactors([]string{"structured_data=50", "Content Structure=60", "semantic-clarity = 40"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"structured_data": 50, "content_structure": 60, "semantic_clarity": 40}
+	if !reflect.DeepEqual(factors, want) {
+		t.Errorf("factors = %v, want %v", factors, want)
+	}
+
+	for _, bad := range []string{"structured_data", "structured_data=high", "structured_data=101", "=50"} {
+		if _, err := ParseFactors([]string{bad}); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	keys := []string{"structured_data", "faq_coverage"}
+	if err := (Thresholds{Factors: map[string]int{"structured_data": 50, "faq_coverage": 20}}).Validate(keys); err != nil {
+		t.Errorf("known pillars rejected: %v", err)
+	}
+	if err := (Thresholds{Factors: map[string]int{"structured_date": 50}}).Validate(keys); err == nil {
+		t.Error("misspelled pillar accepted")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	pages := []baseline.Page{
+		{Key: "a.html", Score: 80, Pillars: map[string]int{"structured_data": 90}},
+		{Key: "b.html", Score: 55, Pillars: map[string]int{"structured_data": 30}},
+		{Key: "c.html", Error: "timeout"},
+		{Key: "d.html", Score: 70},
+	}
+	thresholds := Thresholds{Score: 60, Factors: map[string]int{"structured_data": 50}}
+	want := []string{
+		"b.html scored 55 (minimum 60)",
+		"b.html scored 30 on Structured Data (minimum 50)",
+		"c.html could not be analyzed: timeout",
+		"d.html has no structured_data score",
+	}
+	if got := thresholds.Check(pages); !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %q, want %q", got, want)
+	}
+
+	if got := (Thresholds{}).Check(pages); got != nil {
+		t.Errorf("no thresholds, violations = %q", got)
+	}
+}
@@ -0,0 +1,352 @@
+// Package guardrails checks drafts, typically written by an LLM, for the
+// patterns that make generated text unsafe to publish as it is: claims
+// nobody sourced, citations that look made up and reference links that
+// lead nowhere. The checks complement the GEO score; they do not change it.
+package guardrails
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+)
+
+// Checks an issue can come from.
+const (
+	CheckSuperlative = "unsourced_superlative"
+	CheckAttribution = "vague_attribution"
+	CheckCitation    = "suspect_citation"
+	CheckLink        = "broken_link"
+)
+
+// Issue is one problem found in a draft.
+type Issue struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Snippet  string `json:"snippet,omitempty"`
+}
+
+// Report is the outcome of checking a draft.
+type Report struct {
+	Issues       []Issue `json:"issues"`
+	LinksChecked int     `json:"links_checked"`
+}
+
+// Passed reports whether the draft has no high-severity issue. Medium
+// issues are worth a look but do not stop a draft from shipping.
+func (r *Report) Passed() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == scorer.SeverityHigh {
+			return false
+		}
+	}
+	return true
+}
+
+// Options controls how a draft is checked.
+type Options struct {
+	// CheckLinks requests every absolute http(s) link to find broken ones
+	CheckLinks bool
+	Client     *http.Client // nil for a client with a 10 second timeout
+	Now        time.Time    // the zero time for the current time
+}
+
+var (
+	superlative = regexp.MustCompile(`(?i)\b(the (best|worst|most|fastest|largest|biggest|cheapest|safest|only|first)|world'?s (best|leading|first|largest|most)|number one|#1|(industry|market)[- ]leading|unmatched|unparalleled|unrivall?ed|revolutionary|groundbreaking|guaranteed|100%|never fails|always works|proven to)\b`)
+	attribution = regexp.MustCompile(`(?i)\b((studies|research|experts|scientists|doctors|surveys|reports) (show|shows|suggest|suggests|say|says|agree|found|prove|proves|confirm|confirms)|(a|one) (recent |new )?(study|survey|report|paper) (found|shows|showed|suggests)|according to (a |one )?(recent |new )?(study|survey|report|research|experts))\b`)
+	sourceMark  = regexp.MustCompile(`(?i)\[\d{1,3}\]|\[\^[^\]\s]+\]|\]\([^)\s]+\)|https?://|\baccording to [A-Z]|\bsource:`)
+	authorYear  = regexp.MustCompile(`\(([A-Z][\p{L}'-]+(?: et al\.?|,? (?:and|&) [A-Z][\p{L}'-]+)?),? (\d{4})[a-z]?\)`)
+	numbered    = regexp.MustCompile(`\[(\d{1,3})\]`)
+	footnote    = regexp.MustCompile(`\[\^([^\]\s]+)\](:?)`)
+	doi         = regexp.MustCompile(`(?i)\bdoi:\s*(\S+)`)
+	validDOI    = regexp.MustCompile(`^10\.\d{4,9}/[-._;()/:A-Za-z0-9]+$`)
+	mdLink      = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)\)`)
+	references  = regexp.MustCompile(`(?i)^(references|sources|citations|bibliography|notes|footnotes|works cited|further reading)$`)
+)
+
+// placeholderHosts are hosts reserved for examples, which a generated link
+// to is never real.
+var placeholderHosts = []string{"example.com", "example.org", "example.net", "localhost"}
+var placeholderTLDs = []string{".example", ".test", ".invalid", ".localhost"}
+
+// Check runs the guardrails on an extracted draft. Markdown drafts are
+// expected to be rendered with source.MarkdownPage, which leaves their
+// links and footnotes in the content as written.
+func Check(ctx context.Context, page *webpage.PageData, opts Options) *Report {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	report := &Report{Issues: []Issue{}}
+	links := draftLinks(page)
+
+	for _, s := range sentences(page.Content) {
+		if sourced(s, page.Links) {
+			continue
+		}
+		if m := superlative.FindString(s); m != "" {
+			report.add(CheckSuperlative, scorer.SeverityMedium, fmt.Sprintf("%q is claimed without a source", m), s)
+		} else if m := attribution.FindString(s); m != "" {
+			report.add(CheckAttribution, scorer.SeverityMedium, fmt.Sprintf("%q does not say which study or who", m), s)
+		}
+	}
+
+	checkCitations(report, page, len(links), opts.Now)
+	checkLinks(ctx, report, links, opts)
+	return report
+}
+
+func (r *Report) add(check, severity, message, snippet string) {
+	r.Issues = append(r.Issues, Issue{Check: check, Severity: severity, Message: message, Snippet: clip(snippet)})
+}
+
+// checkCitations flags citations that point at nothing: numbered markers
+// and footnotes without an entry to resolve to, author-year citations in a
+// draft with no references at all or from a year to come, and malformed
+// DOIs.
+func checkCitations(report *Report, page *webpage.PageData, links int, now time.Time) {
+	entries := referenceEntries(page)
+
+	reported := make(map[string]bool)
+	for _, m := range numbered.FindAllStringSubmatch(page.Content, -1) {
+		n, _ := strconv.Atoi(m[1])
+		if reported[m[0]] || (n >= 1 && n <= entries) {
+			continue
+		}
+		reported[m[0]] = true
+		if entries == 0 {
+			report.add(CheckCitation, scorer.SeverityHigh, fmt.Sprintf("Citation %s has no reference list to point to", m[0]), around(page.Content, m[0]))
+		} else {
+			report.add(CheckCitation, scorer.SeverityHigh, fmt.Sprintf("Citation %s is beyond the %d references listed", m[0], entries), around(page.Content, m[0]))
+		}
+	}
+
+	defined := make(map[string]bool)
+	for _, m := range footnote.FindAllStringSubmatch(page.Content, -1) {
+		if m[2] == ":" {
+			defined[m[1]] = true
+		}
+	}
+	for _, m := range footnote.FindAllStringSubmatch(page.Content, -1) {
+		if m[2] == "" && !defined[m[1]] && !reported[m[0]] {
+			reported[m[0]] = true
+			report.add(CheckCitation, scorer.SeverityHigh, fmt.Sprintf("Footnote %s is never defined", m[0]), around(page.Content, m[0]))
+		}
+	}
+
+	for _, m := range authorYear.FindAllStringSubmatch(page.Content, -1) {
+		year, _ := strconv.Atoi(m[2])
+		switch {
+		case year > now.Year():
+			report.add(CheckCitation, scorer.SeverityHigh, fmt.Sprintf("%s cites a year that has not happened yet", m[0]), around(page.Content, m[0]))
+		case entries == 0 && links == 0:
+			report.add(CheckCitation, scorer.SeverityHigh, fmt.Sprintf("%s has no reference list or link to check it against", m[0]), around(page.Content, m[0]))
+		}
+	}
+
+	for _, m := range doi.FindAllStringSubmatch(page.Content, -1) {
+		if id := strings.TrimRight(m[1], ".,;:)]"); !validDOI.MatchString(id) {
+			report.add(CheckCitation, scorer.SeverityHigh, fmt.Sprintf("DOI %q is malformed", id), around(page.Content, m[0]))
+		}
+	}
+}
+
+// referenceEntries returns the number of entries in the draft's reference
+// list: the list after a References (or Sources, Notes…) heading.
+func referenceEntries(page *webpage.PageData) int {
+	at := -1
+	for _, h := range page.Headings {
+		if references.MatchString(strings.TrimSpace(h.Text)) {
+			at = strings.LastIndex(page.Content, h.Text)
+		}
+	}
+	if at < 0 {
+		return 0
+	}
+	for _, list := range page.Lists {
+		if len(list.Items) > 0 && strings.Contains(page.Content[at:], list.Items[0]) {
+			return len(list.Items)
+		}
+	}
+	return 0
+}
+
+// link is a reference link in a draft.
+type link struct {
+	text, url string
+}
+
+// draftLinks returns the links of the draft: the extracted links of HTML
+// and the inline links left in the content of markdown.
+func draftLinks(page *webpage.PageData) []link {
+	var links []link
+	for _, l := range page.Links {
+		links = append(links, link{l.Text, l.URL})
+	}
+	for _, m := range mdLink.FindAllStringSubmatch(page.Content, -1) {
+		links = append(links, link{m[1], m[2]})
+	}
+	return links
+}
+
+// checkLinks flags links that cannot be real, and with opts.CheckLinks the
+// absolute ones that do not answer or answer with an error.
+func checkLinks(ctx context.Context, report *Report, links []link, opts Options) {
+	var live []link
+	seen := make(map[string]bool)
+	for _, l := range links {
+		target := strings.TrimSpace(l.url)
+		if target == "" || target == "#" {
+			report.add(CheckLink, scorer.SeverityHigh, fmt.Sprintf("Link %q has no target", l.text), l.text)
+			continue
+		}
+		u, err := neturl.Parse(target)
+		if err != nil {
+			report.add(CheckLink, scorer.SeverityHigh, fmt.Sprintf("Link %q has an invalid URL: %s", l.text, target), l.text)
+			continue
+		}
+		if placeholder(u.Hostname()) {
+			report.add(CheckLink, scorer.SeverityHigh, fmt.Sprintf("Link %q points to the placeholder host %s", l.text, u.Hostname()), target)
+			continue
+		}
+		if (u.Scheme == "http" || u.Scheme == "https") && !seen[target] {
+			seen[target] = true
+			live = append(live, link{l.text, target})
+		}
+	}
+	if !opts.CheckLinks {
+		return
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	problems := make([]*Issue, len(live))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4)
+	for i, l := range live {
+		wg.Add(1)
+		go func(i int, l link) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			problems[i] = probe(ctx, client, l)
+		}(i, l)
+	}
+	wg.Wait()
+
+	report.LinksChecked = len(live)
+	for _, p := range problems {
+		if p != nil {
+			report.Issues = append(report.Issues, *p)
+		}
+	}
+}
+
+// probe requests a link with HEAD, falling back to GET for servers that
+// refuse HEAD, and returns the issue with it, if any.
+func probe(ctx context.Context, client *http.Client, l link) *Issue {
+	var status int
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, l.url, nil)
+		if err != nil {
+			return &Issue{Check: CheckLink, Severity: scorer.SeverityHigh, Message: fmt.Sprintf("Link %q has an invalid URL: %v", l.text, err), Snippet: l.url}
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return &Issue{Check: CheckLink, Severity: scorer.SeverityMedium, Message: fmt.Sprintf("Link %q could not be reached: %v", l.text, err), Snippet: l.url}
+		}
+		resp.Body.Close()
+		status = resp.StatusCode
+		if status != http.StatusMethodNotAllowed && status != http.StatusForbidden && status != http.StatusNotImplemented {
+			break
+		}
+	}
+	if status >= 400 {
+		return &Issue{Check: CheckLink, Severity: scorer.SeverityHigh, Message: fmt.Sprintf("Link %q is broken (HTTP %d)", l.text, status), Snippet: l.url}
+	}
+	return nil
+}
+
+func placeholder(host string) bool {
+	host = strings.ToLower(host)
+	for _, h := range placeholderHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	for _, tld := range placeholderTLDs {
+		if strings.HasSuffix(host, tld) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourced reports whether a sentence carries a source: a link, a citation
+// or a named attribution. Whether the citation holds up is checked apart.
+func sourced(sentence string, links []webpage.Link) bool {
+	if sourceMark.MatchString(sentence) || authorYear.MatchString(sentence) {
+		return true
+	}
+	for _, l := range links {
+		if len(l.Text) >= 3 && strings.Contains(sentence, l.Text) {
+			return true
+		}
+	}
+	return false
+}
+
+// sentences splits content into sentences with the scorer's segmenter,
+// line by line so list items and headings stand alone.
+func sentences(content string) []string {
+	var out []string
+	for _, line := range strings.Split(content, "\n") {
+		out = append(out, scorer.Sentences(line)...)
+	}
+	return out
+}
+
+// around returns the words of content around match, within its
+// paragraph.
+func around(content, match string) string {
+	i := strings.Index(content, match)
+	if i < 0 {
+		return match
+	}
+	start := strings.LastIndexByte(content[:i], '\n') + 1
+	if i-start > 80 {
+		start = i - 80 + strings.IndexByte(content[i-80:i], ' ') + 1
+	}
+	end := len(content)
+	if n := strings.IndexByte(content[i:], '\n'); n >= 0 {
+		end = i + n
+	}
+	if after := i + len(match); end-after > 80 {
+		end = after + strings.LastIndexByte(content[after:after+80], ' ')
+	}
+	return strings.TrimSpace(content[start:end])
+}
+
+// clip shortens a snippet to a readable length.
+func clip(s string) string {
+	const max = 160
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) <= max {
+		return s
+	}
+	cut := strings.LastIndex(s[:max], " ")
+	if cut < max/2 {
+		cut = max
+	}
+	return s[:cut] + "…"
+}
@@ -0,0 +1,121 @@
+package guardrails
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/source"
+)
+
+func parse(t *testing.T, html string) *webpage.PageData {
+	t.Helper()
+	page, err := webpage.New().ParseHTML(html, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return page
+}
+
+// messages returns the messages of the issues from check.
+func messages(report *Report, check string) []string {
+	var out []string
+	for _, issue := range report.Issues {
+		if issue.Check == check {
+			out = append(out, issue.Message)
+		}
+	}
+	return out
+}
+
+func TestClaims(t *testing.T) {
+	page := parse(t, `<html><body><main><h1>Widgets</h1>
+<p>Widgets are the best way to fix a loose door. Ours are the best, according to <a href="https://widget-institute.org">the Widget Institute</a>.</p>
+<p>Studies show most homes own three widgets. Our range is industry-leading (Smith et al., 2019). A steel widget weighs 2.5 kg.</p>
+</main></body></html>`)
+	report := Check(context.Background(), page, Options{})
+
+	if got := messages(report, CheckSuperlative); len(got) != 1 || !strings.Contains(got[0], `"the best"`) {
+		t.Errorf("superlatives = %q, want the unsourced one only", got)
+	}
+	if got := messages(report, CheckAttribution); len(got) != 1 || !strings.Contains(got[0], `"Studies show"`) {
+		t.Errorf("attributions = %q", got)
+	}
+	if !report.Passed() {
+		t.Errorf("draft with only medium issues failed: %+v", report.Issues)
+	}
+}
+
+// A source later in the sentence covers the claim, however many
+// abbreviations come before it.
+func TestClaimSentencesKeepTheirSource(t *testing.T) {
+	page := parse(t, `<html><body><main><p>Ours are the best hinges, as Dr. A. Okafor found in U.S. trials, e.g. <a href="https://hinge-lab.org/2023">the 2023 hinge report</a>.</p></main></body></html>`)
+	report := Check(context.Background(), page, Options{})
+	if got := messages(report, CheckSuperlative); len(got) != 0 {
+		t.Errorf("superlatives = %q, want the sourced claim left alone", got)
+	}
+}
+
+func TestCitations(t *testing.T) {
+	md := `# Widgets
+
+Steel lasts longest [1]. Brass is cheaper [3] and lighter[^note].
+Our tests agree (Jones and Lee, 2031). See doi: 10.12/abc and doi:10.1000/xyz123.
+
+## References
+
+1. Widget Institute, Durability report, 2022.
+`
+	page := parse(t, source.MarkdownPage(md))
+	report := Check(context.Background(), page, Options{Now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	got := strings.Join(messages(report, CheckCitation), "\n")
+	for _, want := range []string{
+		"Citation [3] is beyond the 1 references listed",
+		"Footnote [^note] is never defined",
+		"(Jones and Lee, 2031) cites a year that has not happened yet",
+		`DOI "10.12/abc" is malformed`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("citation issues lack %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "[1]") || strings.Contains(got, "10.1000/xyz123") {
+		t.Errorf("valid citations flagged:\n%s", got)
+	}
+	if report.Passed() {
+		t.Error("draft with suspect citations passed")
+	}
+}
+
+func TestLinks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}
+	}))
+	defer ts.Close()
+
+	md := "# Widgets\n\nSee [the report](" + ts.URL + "/report), [the study](" + ts.URL + "/gone), [the FAQ](" + ts.URL + "/no-head), [our guide](https://docs.example.com/guide) and [the list]().\n"
+	page := parse(t, source.MarkdownPage(md))
+
+	report := Check(context.Background(), page, Options{})
+	if got := messages(report, CheckLink); len(got) != 2 || report.LinksChecked != 0 {
+		t.Errorf("offline link issues = %q, %d checked", got, report.LinksChecked)
+	}
+
+	report = Check(context.Background(), page, Options{CheckLinks: true})
+	got := messages(report, CheckLink)
+	if len(got) != 3 || report.LinksChecked != 3 || !strings.Contains(got[2], `"the study" is broken (HTTP 404)`) {
+		t.Errorf("link issues = %q, %d checked", got, report.LinksChecked)
+	}
+}
@@ -0,0 +1,223 @@
+// Package history keeps the score of every analysis by URL and time, so
+// that the effect of content changes can be followed across runs.
+//
+// The history is a JSON Lines file rather than a SQLite database: release
+// builds are static (CGO_ENABLED=0), which rules out the cgo SQLite driver,
+// and an append-only log read whole does not need a query engine. Each
+// line is one self-contained Entry, so the file can be loaded into SQLite
+// or any other store as it is.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/baseline"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scorer"
+)
+
+// Entry is the outcome of one analysis of a URL.
+type Entry struct {
+	URL           string         `json:"url"`
+	Time          time.Time      `json:"time"`
+	Title         string         `json:"title,omitempty"`
+	Score         int            `json:"score"`
+	Pillars       map[string]int `json:"pillars,omitempty"` // local pillar scores
+	Mode          string         `json:"mode"`
+	Provider      string         `json:"provider,omitempty"`
+	Model         string         `json:"model,omitempty"`
+	ScorerVersion string         `json:"scorer_version"`
+}
+
+// EntryOf records a result at the given time.
+func EntryOf(result *analyzer.Result, at time.Time) Entry {
+	e := Entry{
+		URL:           result.URL,
+		Time:          at.UTC(),
+		Title:         result.Title,
+		Score:         result.Score,
+		Pillars:       baseline.PageOf(result.URL, result, nil).Pillars,
+		Mode:          result.Mode,
+		ScorerVersion: scorer.Version,
+	}
+	if m := result.Manifest; m != nil {
+		e.Provider, e.Model = m.Provider, m.Model
+	}
+	return e
+}
+
+// DefaultPath returns the score history file in the history directory.
+func DefaultPath() (string, error) {
+	dir, err := config.HistoryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scores.jsonl"), nil
+}
+
+// appendMu keeps the lines of concurrent writers of one process apart.
+var appendMu sync.Mutex
+
+// Append adds entries to the history file at path, one JSON object per
+// line, creating the file and its directory when needed.
+func Append(path string, entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var data []byte
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	appendMu.Lock()
+	defer appendMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return f.Close()
+}
+
+// Load reads the history file at path, oldest entry first. A missing file
+// is an empty history.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to parse history %s, line %d: %w", path, line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, nil
+}
+
+// Trend summarizes the runs of one URL.
+type Trend struct {
+	URL    string    `json:"url"`
+	Runs   int       `json:"runs"`
+	First  int       `json:"first"`
+	Latest int       `json:"latest"`
+	Delta  int       `json:"delta"` // Latest - First
+	Best   int       `json:"best"`
+	Since  time.Time `json:"since"` // time of the first run
+	Last   time.Time `json:"last"`  // time of the latest run
+}
+
+// Trends summarizes entries per URL, by URL.
+func Trends(entries []Entry) []Trend {
+	byURL := make(map[string]*Trend)
+	var urls []string
+	for _, e := range entries {
+		t, ok := byURL[e.URL]
+		if !ok {
+			t = &Trend{URL: e.URL, First: e.Score, Best: e.Score, Since: e.Time}
+			byURL[e.URL] = t
+			urls = append(urls, e.URL)
+		}
+		t.Runs++
+		t.Latest, t.Last = e.Score, e.Time
+		t.Delta = t.Latest - t.First
+		t.Best = max(t.Best, e.Score)
+	}
+	sort.Strings(urls)
+	trends := make([]Trend, 0, len(urls))
+	for _, url := range urls {
+		trends = append(trends, *byURL[url])
+	}
+	return trends
+}
+
+// Run is one analysis of a URL and its change from the one before.
+type Run struct {
+	Entry
+	Delta int `json:"delta"`
+}
+
+// Runs returns the entries of url, oldest first, with the change of each
+// from the one before.
+func Runs(entries []Entry, url string) []Run {
+	var runs []Run
+	for _, e := range entries {
+		if e.URL != url {
+			continue
+		}
+		run := Run{Entry: e}
+		if len(runs) > 0 {
+			run.Delta = e.Score - runs[len(runs)-1].Score
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// PillarChange is the change of one pillar's score between two runs.
+type PillarChange struct {
+	Pillar string `json:"pillar"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Delta  int    `json:"delta"`
+}
+
+// PillarChanges compares the pillar scores of two runs, largest change
+// first. Pillars scored in only one of them are left out.
+func PillarChanges(before, after Entry) []PillarChange {
+	var changes []PillarChange
+	for pillar, score := range after.Pillars {
+		if prev, ok := before.Pillars[pillar]; ok {
+			changes = append(changes, PillarChange{Pillar: pillar, Before: prev, After: score, Delta: score - prev})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		di, dj := abs(changes[i].Delta), abs(changes[j].Delta)
+		if di != dj {
+			return di > dj
+		}
+		return changes[i].Pillar < changes[j].Pillar
+	})
+	return changes
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
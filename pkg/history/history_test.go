@@ -0,0 +1,49 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendLoadTrends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.jsonl")
+	day := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if entries, err := Load(path); err != nil || entries != nil {
+		t.Fatalf("missing history: %v, %v", entries, err)
+	}
+	// Appended out of order, as concurrent runs may
+	err := Append(path,
+		Entry{URL: "https://a.test/", Time: day.AddDate(0, 0, 2), Score: 70, Pillars: map[string]int{"content_structure": 80, "authority": 50}},
+		Entry{URL: "https://a.test/", Time: day, Score: 60, Pillars: map[string]int{"content_structure": 60, "authority": 55}},
+		Entry{URL: "https://b.test/", Time: day, Score: 40},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Append(path, Entry{URL: "https://a.test/", Time: day.AddDate(0, 0, 1), Score: 65}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trends := Trends(entries)
+	if len(trends) != 2 {
+		t.Fatalf("trends = %+v, want 2", trends)
+	}
+	if a := trends[0]; a.URL != "https://a.test/" || a.Runs != 3 || a.First != 60 || a.Latest != 70 || a.Delta != 10 || a.Best != 70 {
+		t.Errorf("trend of a = %+v", a)
+	}
+
+	runs := Runs(entries, "https://a.test/")
+	if len(runs) != 3 || runs[1].Delta != 5 || runs[2].Delta != 5 {
+		t.Fatalf("runs = %+v", runs)
+	}
+	changes := PillarChanges(runs[0].Entry, runs[2].Entry)
+	if len(changes) != 2 || changes[0].Pillar != "content_structure" || changes[0].Delta != 20 || changes[1].Delta != -5 {
+		t.Errorf("pillar changes = %+v", changes)
+	}
+}
@@ -0,0 +1,100 @@
+package importance
+
+import (
+	"sort"
+
+	"geo-checker/pkg/scorer"
+)
+
+// maxFixPages is the number of pages named for each fix.
+const maxFixPages = 3
+
+// PageFindings are the findings reported for a page.
+type PageFindings struct {
+	Key      string
+	Findings []scorer.Finding
+}
+
+// Fix is a finding across the pages reporting it.
+type Fix struct {
+	ID      string   `json:"id"`      // rule ID, e.g. structure.answer_first
+	Message string   `json:"message"` // as reported for the page weighing most
+	Pages   int      `json:"pages"`
+	Traffic float64  `json:"traffic"` // weight of the pages reporting it, e.g. their clicks
+	Share   float64  `json:"share"`   // percent of the total weight
+	Points  float64  `json:"points"`  // site score points fixing it on every page could add
+	Top     []string `json:"top_pages"`
+}
+
+// RankFixes groups the findings of pages by rule and ranks them by the
+// traffic of the pages reporting them, then by the site score points at
+// stake, so that what hurts the pages people visit comes first. Pages are
+// weighted as in NewScorecard. It keeps the top fixes.
+func RankFixes(pages []PageFindings, w *Weights, top int) []Fix {
+	weights := make([]float64, len(pages))
+	total := 0.0
+	for i, p := range pages {
+		weights[i], _ = w.Of(p.Key)
+		total += weights[i]
+	}
+	if total == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(pages))
+	}
+	if total == 0 {
+		return nil
+	}
+
+	type pageWeight struct {
+		key    string
+		weight float64
+	}
+	byID := make(map[string]*Fix)
+	affected := make(map[string][]pageWeight)
+	heaviest := make(map[string]float64)
+	var ids []string
+	for i, p := range pages {
+		for _, f := range p.Findings {
+			fix, ok := byID[f.ID]
+			if !ok {
+				fix = &Fix{ID: f.ID}
+				byID[f.ID] = fix
+				ids = append(ids, f.ID)
+			}
+			if _, seen := heaviest[f.ID]; !seen || weights[i] > heaviest[f.ID] {
+				heaviest[f.ID] = weights[i]
+				fix.Message = f.Message
+			}
+			fix.Pages++
+			fix.Traffic += weights[i]
+			fix.Points += weights[i] * float64(f.Impact) / total
+			affected[f.ID] = append(affected[f.ID], pageWeight{p.Key, weights[i]})
+		}
+	}
+
+	fixes := make([]Fix, 0, len(ids))
+	for _, id := range ids {
+		fix := byID[id]
+		fix.Share = round1(100 * fix.Traffic / total)
+		fix.Traffic = round1(fix.Traffic)
+		fix.Points = round1(fix.Points)
+		on := affected[id]
+		sort.SliceStable(on, func(i, j int) bool { return on[i].weight > on[j].weight })
+		for i := 0; i < len(on) && i < maxFixPages; i++ {
+			fix.Top = append(fix.Top, on[i].key)
+		}
+		fixes = append(fixes, *fix)
+	}
+	sort.SliceStable(fixes, func(i, j int) bool {
+		if fixes[i].Traffic != fixes[j].Traffic {
+			return fixes[i].Traffic > fixes[j].Traffic
+		}
+		return fixes[i].Points > fixes[j].Points
+	})
+	if top > 0 && len(fixes) > top {
+		fixes = fixes[:top]
+	}
+	return fixes
+}
@@ -0,0 +1,305 @@
+// Package importance weights pages by how much they matter to a site, from
+// traffic exports or sitemap priorities, and computes the site score with
+// those weights, so that the homepage counts for more than an old blog
+// post in the headline number.
+package importance
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"geo-checker/pkg/baseline"
+	"geo-checker/pkg/sitemap"
+)
+
+// defaultPriority is the sitemap priority of pages that declare none.
+const defaultPriority = 0.5
+
+// urlColumns are the headers a URL column goes by in exports, lower-cased.
+var urlColumns = []string{"url", "page", "address", "landing page", "page path", "page path and screen class", "top pages", "pages", "page location", "loc"}
+
+// weightColumns are the headers taken as the weight, in order of
+// preference, when no column is named.
+var weightColumns = []string{"weight", "importance", "priority", "clicks", "sessions", "views", "pageviews", "screen page views", "users", "impressions"}
+
+// Weights are the importance of pages, by URL or by path for exports that
+// list paths only (e.g. GA4).
+type Weights struct {
+	Source  string  // where the weights come from, for reports
+	Default float64 // weight of pages not listed
+
+	byURL  map[string]float64
+	byPath map[string]float64
+}
+
+// New returns empty weights from source.
+func New(source string, defaultWeight float64) *Weights {
+	return &Weights{Source: source, Default: defaultWeight, byURL: make(map[string]float64), byPath: make(map[string]float64)}
+}
+
+// Set sets the weight of a page, given by URL or by path from "/". Weights
+// set twice add up, as traffic rows of the same page do.
+func (w *Weights) Set(page string, weight float64) {
+	if strings.HasPrefix(page, "/") {
+		w.byPath[normalizePath(page)] += weight
+		return
+	}
+	w.byURL[Normalize(page)] += weight
+}
+
+// Len returns the number of pages weighted.
+func (w *Weights) Len() int {
+	return len(w.byURL) + len(w.byPath)
+}
+
+// Of returns the weight of the page at rawURL and whether it is listed.
+func (w *Weights) Of(rawURL string) (float64, bool) {
+	if weight, ok := w.byURL[Normalize(rawURL)]; ok {
+		return weight, true
+	}
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		path := u.EscapedPath()
+		if u.RawQuery != "" {
+			path += "?" + u.RawQuery
+		}
+		if weight, ok := w.byPath[normalizePath(path)]; ok {
+			return weight, true
+		}
+	}
+	return w.Default, false
+}
+
+// Normalize returns the form URLs are matched in: lower-case scheme and
+// host, no fragment and no trailing slash.
+func Normalize(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(rawURL)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	return u.String()
+}
+
+func normalizePath(path string) string {
+	path = strings.TrimSpace(path)
+	if i := strings.Index(path, "#"); i >= 0 {
+		path = path[:i]
+	}
+	p, query, hasQuery := strings.Cut(path, "?")
+	p = strings.TrimSuffix(p, "/")
+	if hasQuery {
+		return p + "?" + query
+	}
+	return p
+}
+
+// FromCSV reads weights from a CSV export with a URL (or page path) column
+// and the numeric column named column, or else the first of weight,
+// importance, priority, clicks, sessions, views, pageviews, users and
+// impressions it has. Lines starting with # are skipped, as in GA4
+// exports. Pages not listed weigh nothing.
+func FromCSV(r io.Reader, source, column string) (*Weights, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	urlCol := findColumn(header, urlColumns...)
+	if urlCol < 0 {
+		return nil, fmt.Errorf("%s has no URL column (url, page, address, landing page, page path...)", source)
+	}
+	var weightCol int
+	if column != "" {
+		weightCol = findColumn(header, strings.ToLower(column))
+		if weightCol < 0 {
+			return nil, fmt.Errorf("%s has no column %q", source, column)
+		}
+	} else if weightCol = findColumn(header, weightColumns...); weightCol < 0 {
+		return nil, fmt.Errorf("%s has no weight column; name one of its columns with --weight-column", source)
+	}
+
+	w := New(source, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", source, err)
+		}
+		if urlCol >= len(record) || weightCol >= len(record) || strings.TrimSpace(record[urlCol]) == "" {
+			continue
+		}
+		weight, err := ParseNumber(record[weightCol])
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", source, line, err)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("%s line %d: negative weight %v", source, line, weight)
+		}
+		w.Set(strings.TrimSpace(record[urlCol]), weight)
+	}
+	if w.Len() == 0 {
+		return nil, fmt.Errorf("%s lists no pages", source)
+	}
+	return w, nil
+}
+
+// LoadCSV reads weights from the CSV file at path; see FromCSV.
+func LoadCSV(path, column string) (*Weights, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open weights: %w", err)
+	}
+	defer f.Close()
+	return FromCSV(f, path, column)
+}
+
+// FromSitemap weights pages by their sitemap priority, 0.5 for pages that
+// declare none as the protocol has it. Pages missing from the sitemap get
+// the lowest priority, 0.1.
+func FromSitemap(entries []sitemap.Entry, source string) *Weights {
+	w := New(source, 0.1)
+	for _, e := range entries {
+		priority := e.Priority
+		if priority <= 0 {
+			priority = defaultPriority
+		}
+		w.byURL[Normalize(e.Loc)] = priority
+	}
+	return w
+}
+
+// ParseNumber parses a number as exports write it, e.g. "1,234", "12.5%".
+func ParseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return n, nil
+}
+
+// findColumn returns the index of the first of names found in header, or
+// -1.
+func findColumn(header []string, names ...string) int {
+	for _, name := range names {
+		for i, h := range header {
+			if strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\uFEFF"))) == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Scorecard is the headline view of a site: its score weighted by page
+// importance next to the plain average.
+type Scorecard struct {
+	Source   string         `json:"source"` // of the weights
+	Pages    int            `json:"pages"`  // pages scored
+	Failed   int            `json:"failed,omitempty"`
+	Weighted int            `json:"weighted"`          // pages scored that the source lists
+	Score    int            `json:"score"`             // importance-weighted
+	Average  int            `json:"average"`           // unweighted
+	Pillars  map[string]int `json:"pillars,omitempty"` // importance-weighted pillar scores
+	Impact   []PageImpact   `json:"impact,omitempty"`  // pages costing the site score most, most first
+	Fixes    []Fix          `json:"fixes,omitempty"`   // findings by the traffic they affect, most first
+}
+
+// PageImpact is what a page weighs in the site score.
+type PageImpact struct {
+	Key    string  `json:"key"`
+	Score  int     `json:"score"`
+	Share  float64 `json:"share"`  // percent of the total weight
+	Points float64 `json:"points"` // points of the site score lost to the page falling short of 100
+}
+
+// NewScorecard computes the scorecard of pages, keeping the top pages
+// costing the site score most in Impact.
+func NewScorecard(pages []baseline.Page, w *Weights, top int) *Scorecard {
+	sc := &Scorecard{Source: w.Source}
+	type weighted struct {
+		page   baseline.Page
+		weight float64
+	}
+	var scored []weighted
+	total, sum, plain := 0.0, 0.0, 0
+	for _, p := range pages {
+		if p.Error != "" {
+			sc.Failed++
+			continue
+		}
+		weight, listed := w.Of(p.Key)
+		if listed {
+			sc.Weighted++
+		}
+		scored = append(scored, weighted{p, weight})
+		total += weight
+		sum += weight * float64(p.Score)
+		plain += p.Score
+	}
+	sc.Pages = len(scored)
+	if sc.Pages == 0 {
+		return sc
+	}
+	sc.Average = plain / sc.Pages
+	if total == 0 {
+		// Nothing scored carries weight: every page counts the same
+		for i := range scored {
+			scored[i].weight = 1
+		}
+		total, sum = float64(len(scored)), float64(plain)
+	}
+	sc.Score = int(sum/total + 0.5)
+
+	pillarSum := make(map[string]float64)
+	pillarWeight := make(map[string]float64)
+	for _, s := range scored {
+		for key, score := range s.page.Pillars {
+			pillarSum[key] += s.weight * float64(score)
+			pillarWeight[key] += s.weight
+		}
+		sc.Impact = append(sc.Impact, PageImpact{
+			Key:    s.page.Key,
+			Score:  s.page.Score,
+			Share:  round1(100 * s.weight / total),
+			Points: round1(s.weight * float64(100-s.page.Score) / total),
+		})
+	}
+	if len(pillarSum) > 0 {
+		sc.Pillars = make(map[string]int, len(pillarSum))
+		for key, sum := range pillarSum {
+			if pillarWeight[key] > 0 {
+				sc.Pillars[key] = int(sum/pillarWeight[key] + 0.5)
+			}
+		}
+	}
+	sort.SliceStable(sc.Impact, func(i, j int) bool { return sc.Impact[i].Points > sc.Impact[j].Points })
+	if top > 0 && len(sc.Impact) > top {
+		sc.Impact = sc.Impact[:top]
+	}
+	return sc
+}
+
+func round1(v float64) float64 {
+	return float64(int(v*10+0.5)) / 10
+}
@@ -0,0 +1,136 @@
+package importance
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"geo-checker/pkg/baseline"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/sitemap"
+)
+
+func TestFromCSV(t *testing.T) {
+	// A GA4 export: comment lines, paths only, thousands separators
+	export := `# ----------------------------------------
+# Pages and screens
+# ----------------------------------------
+Page path and screen class,Views,Users
+/,"12,000",8000
+/pricing/,3000,2100
+/blog/old-post,40,31
+`
+	w, err := FromCSV(strings.NewReader(export), "ga4.csv", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for url, want := range map[string]float64{
+		"https://example.com/":                  12000,
+		"https://Example.com/pricing":           3000,
+		"https://example.com/blog/old-post#top": 40,
+		"https://example.com/about":             0,
+	} {
+		if got, _ := w.Of(url); got != want {
+			t.Errorf("weight of %s = %v, want %v", url, got, want)
+		}
+	}
+
+	w, err = FromCSV(strings.NewReader("URL,Clicks,Impressions\nhttps://example.com/a,10,900\n"), "gsc.csv", "impressions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, listed := w.Of("https://example.com/a/"); got != 900 || !listed {
+		t.Errorf("weight by named column = %v, %v", got, listed)
+	}
+
+	for name, data := range map[string]string{
+		"no URL column":    "Name,Views\nhome,10\n",
+		"no weight column": "URL,Title\nhttps://example.com/,Home\n",
+		"bad number":       "URL,Weight\nhttps://example.com/,lots\n",
+	} {
+		if _, err := FromCSV(strings.NewReader(data), name, ""); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+func TestScorecard(t *testing.T) {
+	w := New("traffic.csv", 0)
+	w.Set("https://example.com/", 900)
+	w.Set("https://example.com/blog/old", 100)
+
+	pages := []baseline.Page{
+		{Key: "https://example.com/", Score: 80, Pillars: map[string]int{"structure": 90}},
+		{Key: "https://example.com/blog/old", Score: 30, Pillars: map[string]int{"structure": 20}},
+		{Key: "https://example.com/unlisted", Score: 10},
+		{Key: "https://example.com/broken", Error: "timeout"},
+	}
+	sc := NewScorecard(pages, w, 2)
+	if sc.Score != 75 || sc.Average != 40 || sc.Pages != 3 || sc.Weighted != 2 || sc.Failed != 1 {
+		t.Errorf("scorecard = %+v", sc)
+	}
+	if sc.Pillars["structure"] != 83 {
+		t.Errorf("pillars = %v", sc.Pillars)
+	}
+	want := []PageImpact{
+		{Key: "https://example.com/", Score: 80, Share: 90, Points: 18},
+		{Key: "https://example.com/blog/old", Score: 30, Share: 10, Points: 7},
+	}
+	if !reflect.DeepEqual(sc.Impact, want) {
+		t.Errorf("impact = %+v", sc.Impact)
+	}
+
+	// Weights matching no page scored leave the plain average
+	if sc := NewScorecard(pages[2:], w, 0); sc.Score != 10 {
+		t.Errorf("unweighted fallback score = %d", sc.Score)
+	}
+}
+
+func TestFromSitemap(t *testing.T) {
+	w := FromSitemap([]sitemap.Entry{
+		{Loc: "https://example.com/", Priority: 1},
+		{Loc: "https://example.com/blog/"},
+	}, "sitemap.xml")
+	for url, want := range map[string]float64{
+		"https://example.com":       1,
+		"https://example.com/blog":  0.5,
+		"https://example.com/other": 0.1,
+	} {
+		if got, _ := w.Of(url); got != want {
+			t.Errorf("weight of %s = %v, want %v", url, got, want)
+		}
+	}
+}
+
+func TestRankFixes(t *testing.T) {
+	w := New("gsc.csv", 0)
+	w.Set("https://example.com/", 900)
+	w.Set("https://example.com/pricing", 90)
+	w.Set("https://example.com/blog/old", 10)
+
+	pages := []PageFindings{
+		{Key: "https://example.com/blog/old", Findings: []scorer.Finding{
+			{ID: "structure.answer_first", Message: "Open with the answer", Impact: 20},
+			{ID: "trust.author", Message: "Name the author", Impact: 10},
+		}},
+		{Key: "https://example.com/", Findings: []scorer.Finding{
+			{ID: "trust.author", Message: "Name the author of the page", Impact: 5},
+		}},
+		{Key: "https://example.com/pricing", Findings: []scorer.Finding{
+			{ID: "structure.answer_first", Message: "Open with the answer", Impact: 10},
+		}},
+	}
+	fixes := RankFixes(pages, w, 0)
+	want := []Fix{
+		{ID: "trust.author", Message: "Name the author of the page", Pages: 2, Traffic: 910, Share: 91, Points: 4.6,
+			Top: []string{"https://example.com/", "https://example.com/blog/old"}},
+		{ID: "structure.answer_first", Message: "Open with the answer", Pages: 2, Traffic: 100, Share: 10, Points: 1.1,
+			Top: []string{"https://example.com/pricing", "https://example.com/blog/old"}},
+	}
+	if !reflect.DeepEqual(fixes, want) {
+		t.Errorf("fixes = %+v", fixes)
+	}
+	if fixes := RankFixes(pages, w, 1); len(fixes) != 1 || fixes[0].ID != "trust.author" {
+		t.Errorf("top 1 = %+v", fixes)
+	}
+}
@@ -0,0 +1,274 @@
+// Package jsonrpc drives the analyzer over JSON-RPC 2.0 on a byte stream,
+// typically a subprocess's stdin and stdout, so GUI wrappers and editor
+// plugins can use geo-checker without parsing its human-formatted output.
+//
+// Messages are newline-delimited: each request and each response is one
+// JSON object on its own line. Requests are handled concurrently and
+// answered as they complete, so clients match responses by id.
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/source"
+)
+
+// ProtocolVersion changes whenever a method, its parameters or its result
+// change incompatibly.
+const ProtocolVersion = 1
+
+// maxMessage caps the size of one request line.
+const maxMessage = 32 << 20
+
+// JSON-RPC error codes. Analysis failures carry the analyzer's error
+// classification as data.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeAnalysisFailed = -32000
+)
+
+// request is a JSON-RPC request or notification.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// AnalyzeURLParams are the parameters of analyzeUrl.
+type AnalyzeURLParams struct {
+	URL string `json:"url"`
+}
+
+// ContentParams are the parameters of analyzeContent and score. Format is
+// "text" (the default), "html" or "markdown"; URL is only reported, and
+// resolves relative links in HTML.
+type ContentParams struct {
+	Content string `json:"content"`
+	Title   string `json:"title,omitempty"`
+	Format  string `json:"format,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// Info is the result of initialize.
+type Info struct {
+	Protocol      int      `json:"protocol"`
+	ToolVersion   string   `json:"tool_version"`
+	ScorerVersion string   `json:"scorer_version"`
+	Mode          string   `json:"mode"`
+	Methods       []string `json:"methods"`
+}
+
+// methods are the requests the server answers.
+var methods = []string{"initialize", "analyzeUrl", "analyzeContent", "score", "shutdown"}
+
+// Server answers requests on one connection.
+type Server struct {
+	analyzer *analyzer.Analyzer // configured mode, for analyzeUrl and analyzeContent
+	local    *analyzer.Analyzer // local mode, for score
+	scraper  *webpage.Scraper
+
+	mu  sync.Mutex // serializes writes
+	out io.Writer
+}
+
+// New returns a server analyzing with cfg. Analyses run concurrently, so
+// spinners are always off.
+func New(cfg *config.Config) *Server {
+	quiet := *cfg
+	quiet.Quiet = true
+	local := quiet
+	local.Mode = "local"
+	return &Server{
+		analyzer: analyzer.New(&quiet),
+		local:    analyzer.New(&local),
+		scraper:  webpage.New(),
+	}
+}
+
+// Serve reads requests from r and writes responses to w until the client
+// sends exit or r ends. Requests still running are answered before Serve
+// returns at the end of r, and cancelled on exit or when ctx ends.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	s.out = w
+
+	in := bufio.NewScanner(r)
+	in.Buffer(make([]byte, 64*1024), maxMessage)
+	for in.Scan() {
+		line := strings.TrimSpace(in.Text())
+		if line == "" {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			s.reply(nil, nil, &Error{Code: CodeParseError, Message: err.Error()})
+			continue
+		}
+		if req.JSONRPC != "2.0" || req.Method == "" {
+			s.reply(req.ID, nil, &Error{Code: CodeInvalidRequest, Message: `want "jsonrpc": "2.0" and a method`})
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, rpcErr := s.handle(ctx, &req)
+			if req.ID != nil {
+				s.reply(req.ID, result, rpcErr)
+			}
+		}()
+	}
+	if err := in.Err(); err != nil {
+		return err
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (s *Server) handle(ctx context.Context, req *request) (any, *Error) {
+	switch req.Method {
+	case "initialize":
+		return &Info{
+			Protocol:      ProtocolVersion,
+			ToolVersion:   analyzer.ToolVersion(),
+			ScorerVersion: scorer.Version,
+			Mode:          s.analyzer.Mode(),
+			Methods:       methods,
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "analyzeUrl":
+		var p AnalyzeURLParams
+		if err := decode(req.Params, &p); err != nil || p.URL == "" {
+			return nil, invalidParams(err, `"url" is required`)
+		}
+		return analysis(s.analyzer.AnalyzeURL(ctx, p.URL))
+	case "analyzeContent", "score":
+		var p ContentParams
+		if err := decode(req.Params, &p); err != nil || strings.TrimSpace(p.Content) == "" {
+			return nil, invalidParams(err, `"content" is required`)
+		}
+		page, err := s.page(p)
+		if err != nil {
+			return nil, invalidParams(err, "")
+		}
+		if req.Method == "score" {
+			result, rpcErr := analysis(s.local.AnalyzePage(ctx, page, p.URL))
+			if rpcErr != nil {
+				return nil, rpcErr
+			}
+			return result.LocalScore, nil
+		}
+		return analysis(s.analyzer.AnalyzePage(ctx, page, p.URL))
+	}
+	return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", req.Method)}
+}
+
+// page extracts the content of a request like the CLI extracts a page.
+func (s *Server) page(p ContentParams) (*webpage.PageData, error) {
+	var page *webpage.PageData
+	switch p.Format {
+	case "", "text":
+		page = &webpage.PageData{URL: p.URL, Content: p.Content, MetaTags: make(map[string]string), Headings: []webpage.Heading{}}
+	case "html", "markdown":
+		src := p.Content
+		if p.Format == "markdown" {
+			src = source.MarkdownPage(src)
+		}
+		var err error
+		if page, err = s.scraper.ParseHTML(src, p.URL); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q (want text, html or markdown)", p.Format)
+	}
+	if p.Title != "" {
+		page.Title = p.Title
+	}
+	return page, nil
+}
+
+func decode(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	return json.Unmarshal(params, v)
+}
+
+func invalidParams(err error, missing string) *Error {
+	if err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return &Error{Code: CodeInvalidParams, Message: missing}
+}
+
+// analysis turns an analyzer outcome into a result or an error carrying the
+// classified failure.
+func analysis(result *analyzer.Result, err error) (*analyzer.Result, *Error) {
+	if err != nil {
+		classified := analyzer.Classify(err)
+		return nil, &Error{Code: CodeAnalysisFailed, Message: classified.Message, Data: classified}
+	}
+	return result, nil
+}
+
+// reply writes the response to the request with id; a nil id answers a
+// request that could not be read.
+func (s *Server) reply(id json.RawMessage, result any, rpcErr *Error) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	switch {
+	case rpcErr != nil:
+		result = nil // may hold a typed nil
+	case result == nil:
+		result = json.RawMessage("null")
+	}
+	data, err := json.Marshal(&response{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+	if err != nil {
+		data, _ = json.Marshal(&response{JSONRPC: "2.0", ID: id, Error: &Error{Code: CodeAnalysisFailed, Message: err.Error()}})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out.Write(append(data, '\n'))
+}
@@ -0,0 +1,142 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"geo-checker/pkg/config"
+
+	"github.com/fatih/color"
+)
+
+// session sends request lines to a server and returns its responses by id.
+func session(t *testing.T, lines ...string) map[string]map[string]any {
+	t.Helper()
+	var out strings.Builder
+	if err := New(&config.Config{Mode: "local"}).Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &out); err != nil {
+		t.Fatal(err)
+	}
+	responses := make(map[string]map[string]any)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("bad response line %q: %v", line, err)
+		}
+		id, _ := json.Marshal(resp["id"])
+		responses[string(id)] = resp
+	}
+	return responses
+}
+
+func TestServe(t *testing.T) {
+	page := `<html><head><title>Widgets</title></head><body><main><h1>Widgets</h1><p>Widgets are small mechanical parts.</p></main></body></html>`
+	params, _ := json.Marshal(ContentParams{Content: page, Format: "html"})
+	responses := session(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"score","params":`+string(params)+`}`,
+		`{"jsonrpc":"2.0","id":3,"method":"analyzeContent","params":{"content":"Widgets are small parts.","title":"Widgets"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"analyzeContent","params":{"content":" "}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"score","params":{"content":"x","format":"pdf"}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"analyzeUrl","params":{"url":"ftp://example.com/"}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"analyze"}`,
+		`{"jsonrpc":"2.0","method":"score","params":{"content":"a notification"}}`,
+		`not json`,
+		`{"id":8,"method":"initialize"}`,
+	)
+	if len(responses) != 9 {
+		t.Fatalf("got %d responses, want 9 (no answer to the notification): %v", len(responses), responses)
+	}
+
+	info := responses["1"]["result"].(map[string]any)
+	if info["protocol"].(float64) != ProtocolVersion || info["mode"] != "local" {
+		t.Errorf("initialize = %v", info)
+	}
+	score := responses["2"]["result"].(map[string]any)
+	if _, ok := score["overall_score"]; !ok || score["breakdown"] == nil {
+		t.Errorf("score = %v", score)
+	}
+	if result := responses["3"]["result"].(map[string]any); result["title"] != "Widgets" || result["local_score"] == nil {
+		t.Errorf("analyzeContent = %v", result)
+	}
+
+	for id, code := range map[string]int{"4": CodeInvalidParams, "5": CodeInvalidParams, "6": CodeAnalysisFailed, "7": CodeMethodNotFound, "null": CodeParseError, "8": CodeInvalidRequest} {
+		resp := responses[id]
+		rpcErr, ok := resp["error"].(map[string]any)
+		if !ok || int(rpcErr["code"].(float64)) != code {
+			t.Errorf("response %s = %v, want error %d", id, resp, code)
+			continue
+		}
+		if _, ok := resp["result"]; ok {
+			t.Errorf("response %s has both a result and an error", id)
+		}
+	}
+	if data, ok := responses["6"]["error"].(map[string]any)["data"].(map[string]any); !ok || data["category"] == nil {
+		t.Errorf("analysis error lacks its classification: %v", responses["6"])
+	}
+}
+
+func TestExit(t *testing.T) {
+	responses := session(t,
+		`{"jsonrpc":"2.0","id":1,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"initialize"}`,
+	)
+	if _, ok := responses["2"]; ok || len(responses) != 1 {
+		t.Errorf("responses after exit: %v", responses)
+	}
+}
+
+// TestStdoutCarriesOnlyResponses serves on stdout itself, as serve --stdio
+// does, with the text output format a terminal would get.
+func TestStdoutCarriesOnlyResponses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><head><title>Widgets</title></head><body><main><h1>Widgets</h1><p>Widgets are small mechanical parts.</p></main></body></html>`))
+	}))
+	defer ts.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, colorOut := os.Stdout, color.Output
+	os.Stdout, color.Output = w, w
+	defer func() { os.Stdout, color.Output = stdout, colorOut }()
+
+	captured := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		captured <- string(data)
+	}()
+	requests := `{"jsonrpc":"2.0","id":1,"method":"analyzeUrl","params":{"url":"` + ts.URL + `"}}` + "\n" +
+		`{"jsonrpc":"2.0","id":2,"method":"analyzeContent","params":{"content":"Widgets are small parts.","title":"Widgets"}}`
+	err = New(&config.Config{Mode: "local", OutputFormat: "text", Timeout: 10}).Serve(context.Background(), strings.NewReader(requests), os.Stdout)
+	w.Close()
+	out := <-captured
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := 0
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var resp map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil || resp["jsonrpc"] != "2.0" {
+			t.Fatalf("stdout holds a line that is not a response: %q", scanner.Text())
+		}
+		if resp["error"] != nil {
+			t.Errorf("response %v failed: %v", resp["id"], resp["error"])
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("got %d responses on stdout, want 2:\n%s", lines, out)
+	}
+}
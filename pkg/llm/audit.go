@@ -0,0 +1,137 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditLogPath is the file every request sent to a provider is recorded in,
+// set from --audit-log; empty records nothing.
+var AuditLogPath string
+
+// auditMu serializes the entries of concurrent analyses, so that lines of
+// one process never interleave.
+var auditMu sync.Mutex
+
+// apiKeyPattern matches the API key formats of the supported providers,
+// which pages under analysis and provider error messages may contain.
+var apiKeyPattern = regexp.MustCompile(`\b(sk-ant-[A-Za-z0-9_-]{8,}|sk-(?:proj-)?[A-Za-z0-9_-]{16,}|AIza[A-Za-z0-9_-]{20,})`)
+
+// auditEntry is one line of the audit log: what was sent to the provider,
+// what came back and what it cost.
+type auditEntry struct {
+	Time             time.Time `json:"time"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model"`
+	LatencyMS        int64     `json:"latency_ms"`
+	PromptTokens     int       `json:"prompt_tokens,omitempty"`
+	CompletionTokens int       `json:"completion_tokens,omitempty"`
+	TokensUsed       int       `json:"tokens_used,omitempty"`
+	Prompt           string    `json:"prompt"`
+	Content          string    `json:"content"`
+	Response         string    `json:"response,omitempty"`
+	Error            string    `json:"error,omitempty"`
+	ErrorType        ErrorType `json:"error_type,omitempty"`
+}
+
+// auditedProvider records every request to the wrapped provider in a JSON
+// Lines file.
+type auditedProvider struct {
+	Provider
+	path  string
+	model string
+}
+
+// NewAuditedProvider returns a provider that appends each request to p and
+// its outcome to the JSON Lines file at path: the time it was sent, the
+// prompt and content, the response or error, the token counts and the
+// latency. API keys in any of them are redacted. A request whose entry
+// cannot be written fails, so that no answer is used unrecorded.
+func NewAuditedProvider(p Provider, path, model string) Provider {
+	return &auditedProvider{Provider: p, path: path, model: model}
+}
+
+func (a *auditedProvider) Analyze(ctx context.Context, content string, prompt string) (*Response, error) {
+	start := time.Now()
+	resp, err := a.Provider.Analyze(ctx, content, prompt)
+
+	entry := auditEntry{
+		Time:      start.UTC(),
+		Provider:  a.Name(),
+		Model:     a.model,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Prompt:    redactKeys(prompt),
+		Content:   redactKeys(content),
+	}
+	if err != nil {
+		entry.Error = redactKeys(err.Error())
+		if llmErr, ok := err.(*LLMError); ok {
+			entry.ErrorType = llmErr.Type
+		}
+	} else {
+		if resp.Model != "" {
+			entry.Model = resp.Model
+		}
+		entry.Response = redactKeys(resp.Content)
+		entry.TokensUsed = resp.TokensUsed
+		entry.PromptTokens = metadataInt(resp.Metadata, "prompt_tokens", "input_tokens")
+		entry.CompletionTokens = metadataInt(resp.Metadata, "completion_tokens", "output_tokens")
+		if entry.TokensUsed == 0 {
+			entry.TokensUsed = entry.PromptTokens + entry.CompletionTokens
+		}
+	}
+
+	if logErr := appendAuditEntry(a.path, entry); logErr != nil {
+		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to write audit log: %v", logErr), a.Name())
+	}
+	return resp, err
+}
+
+func appendAuditEntry(path string, entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// redactKeys replaces anything shaped like a provider API key, and the keys
+// configured in the environment, with [REDACTED].
+func redactKeys(s string) string {
+	for _, env := range []string{"CLAUDE_API_KEY", "OPENAI_API_KEY", "GEMINI_API_KEY"} {
+		if key := os.Getenv(env); len(key) >= 8 {
+			s = strings.ReplaceAll(s, key, "[REDACTED]")
+		}
+	}
+	return apiKeyPattern.ReplaceAllString(s, "[REDACTED]")
+}
+
+// metadataInt returns the first of keys present in a response's metadata
+// as an integer; providers name their token counts differently.
+func metadataInt(metadata map[string]any, keys ...string) int {
+	for _, key := range keys {
+		switch v := metadata[key].(type) {
+		case int:
+			return v
+		case float64:
+			return int(v)
+		}
+	}
+	return 0
+}
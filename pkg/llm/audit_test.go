@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failingProvider fails every request with a rate limit error.
+type failingProvider struct{}
+
+func (failingProvider) Analyze(ctx context.Context, content string, prompt string) (*Response, error) {
+	return nil, NewLLMError(ErrorTypeRateLimit, "Rate limit exceeded", "failing")
+}
+
+func (failingProvider) Name() string { return "failing" }
+
+func TestAuditedProvider(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "custom-key-value-123")
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	inner := &countingProvider{}
+	provider := NewAuditedProvider(inner, path, "test-model")
+	content := "page with sk-ant-REDACTED and custom-key-value-123"
+	if _, err := provider.Analyze(context.Background(), content, "prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewAuditedProvider(failingProvider{}, path, "test-model").Analyze(context.Background(), "content", "prompt"); err == nil {
+		t.Fatal("audited provider hid the error of the provider it wraps")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log has %d lines, want 2:\n%s", len(lines), data)
+	}
+	var ok, failed auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &ok); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &failed); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok.Provider != "counting" || ok.Model != "test" || ok.Response != "answer 1" || ok.Time.IsZero() {
+		t.Errorf("entry = %+v", ok)
+	}
+	if want := "page with [REDACTED] and [REDACTED]"; ok.Content != want {
+		t.Errorf("content = %q, want %q", ok.Content, want)
+	}
+	if failed.ErrorType != ErrorTypeRateLimit || failed.Response != "" || failed.Model != "test-model" {
+		t.Errorf("failed entry = %+v", failed)
+	}
+}
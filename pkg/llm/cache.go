@@ -0,0 +1,55 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// cachedProvider answers requests it has answered before from responses
+// stored in dir, and stores the responses to new ones.
+type cachedProvider struct {
+	Provider
+	dir         string
+	model       string
+	temperature float64
+}
+
+// NewCachedProvider returns a provider that looks responses up in dir
+// before calling p, keyed by the provider, model, temperature, prompt and
+// content, so that repeated runs on unchanged content get the same
+// answers. Failed calls are not cached.
+func NewCachedProvider(p Provider, dir, model string, temperature float64) Provider {
+	return &cachedProvider{Provider: p, dir: dir, model: model, temperature: temperature}
+}
+
+func (c *cachedProvider) Analyze(ctx context.Context, content string, prompt string) (*Response, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%g\x00%s\x00%s", c.Name(), c.model, c.temperature, prompt, content)
+	path := filepath.Join(c.dir, hex.EncodeToString(h.Sum(nil))+".json")
+
+	if data, err := os.ReadFile(path); err == nil {
+		var cached Response
+		if json.Unmarshal(data, &cached) == nil {
+			return &cached, nil
+		}
+	}
+
+	resp, err := c.Provider.Analyze(ctx, content, prompt)
+	if err != nil {
+		return nil, err
+	}
+	// The cache is best effort: a response that cannot be stored is still
+	// returned
+	if data, err := json.Marshal(resp); err == nil && os.MkdirAll(c.dir, 0o755) == nil {
+		tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+		if os.WriteFile(tmp, data, 0o644) == nil {
+			os.Rename(tmp, path)
+		}
+	}
+	return resp, nil
+}
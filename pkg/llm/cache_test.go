@@ -0,0 +1,53 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// countingProvider answers every request with the number of calls made.
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Analyze(ctx context.Context, content string, prompt string) (*Response, error) {
+	p.calls++
+	return &Response{Content: fmt.Sprintf("answer %d", p.calls), Model: "test"}, nil
+}
+
+func (p *countingProvider) Name() string { return "counting" }
+
+func TestCachedProvider(t *testing.T) {
+	inner := &countingProvider{}
+	dir := t.TempDir()
+	provider := NewCachedProvider(inner, dir, "test", 0)
+
+	first, err := provider.Analyze(context.Background(), "content", "prompt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := provider.Analyze(context.Background(), "content", "prompt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 1 || second.Content != first.Content {
+		t.Errorf("repeated request: %d calls, %q then %q, want one call and the same answer", inner.calls, first.Content, second.Content)
+	}
+
+	if _, err := provider.Analyze(context.Background(), "changed content", "prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("changed content answered from the cache")
+	}
+
+	// Another model must not reuse the answers
+	other := NewCachedProvider(inner, dir, "other", 0)
+	if _, err := other.Analyze(context.Background(), "content", "prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 3 {
+		t.Errorf("answer cached for another model reused")
+	}
+}
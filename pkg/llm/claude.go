@@ -12,9 +12,13 @@ import (
 	"time"
 )
 
+// claudeEndpoint is the Messages API.
+const claudeEndpoint = "https://api.anthropic.com/v1/messages"
+
 type ClaudeProvider struct {
-	config *ProviderConfig
-	client *http.Client
+	config   *ProviderConfig
+	client   *http.Client
+	endpoint string
 }
 
 type claudeRequest struct {
@@ -22,6 +26,7 @@ type claudeRequest struct {
 	MaxTokens   int       `json:"max_tokens"`
 	Temperature float64   `json:"temperature"`
 	Messages    []message `json:"messages"`
+	Stream      bool      `json:"stream,omitempty"`
 }
 
 type message struct {
@@ -91,8 +96,9 @@ func NewClaudeProvider(config *ProviderConfig) (*ClaudeProvider, error) {
 	}
 	
 	return &ClaudeProvider{
-		config: config,
-		client: &http.Client{Timeout: 60 * time.Second},
+		config:   config,
+		client:   newHTTPClient(60 * time.Second),
+		endpoint: claudeEndpoint,
 	}, nil
 }
 
@@ -128,12 +134,15 @@ func (c *ClaudeProvider) Analyze(ctx context.Context, content string, prompt str
 		},
 	}
 	
+	onText := streamFrom(ctx)
+	reqBody.Stream = onText != nil
+	
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to prepare request: %v", err), "claude")
 	}
 	
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to create HTTP request: %v", err), "claude")
 	}
@@ -154,6 +163,10 @@ func (c *ClaudeProvider) Analyze(ctx context.Context, content string, prompt str
 	}
 	defer resp.Body.Close()
 	
+	if resp.StatusCode == http.StatusOK && onText != nil {
+		return readClaudeStream(resp.Body, onText)
+	}
+	
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, WrapNetworkError(fmt.Errorf("failed to read response body: %w", err), "claude")
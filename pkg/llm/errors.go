@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -164,6 +165,61 @@ func ParseHTTPError(statusCode int, body []byte, provider string) *LLMError {
 	}
 }
 
+// ParseGeminiError converts a Gemini API error to an LLM error. Google
+// reports an invalid key as a 400 and both quotas and rate limits as a
+// 429, so the status and reason in the body decide the type; errors it
+// cannot read are mapped by HTTP status.
+func ParseGeminiError(statusCode int, body []byte) *LLMError {
+	var parsed struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Details []struct {
+				Reason string `json:"reason"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil || parsed.Error.Status == "" {
+		return ParseHTTPError(statusCode, body, "gemini")
+	}
+	reason := ""
+	for _, detail := range parsed.Error.Details {
+		if detail.Reason != "" {
+			reason = detail.Reason
+			break
+		}
+	}
+
+	e := &LLMError{
+		Message:    parsed.Error.Message,
+		Provider:   "gemini",
+		StatusCode: statusCode,
+	}
+	switch {
+	case reason == "API_KEY_INVALID" || parsed.Error.Status == "UNAUTHENTICATED":
+		e.Type, e.Message = ErrorTypeAuth, "Invalid API key or authentication failed"
+	case parsed.Error.Status == "PERMISSION_DENIED":
+		e.Type = ErrorTypeAuth
+	case parsed.Error.Status == "RESOURCE_EXHAUSTED" && strings.Contains(strings.ToLower(parsed.Error.Message), "per day"):
+		// Per-minute limits refill soon, daily quotas not before tomorrow
+		e.Type = ErrorTypeQuota
+	case parsed.Error.Status == "RESOURCE_EXHAUSTED":
+		e.Type = ErrorTypeRateLimit
+	case parsed.Error.Status == "NOT_FOUND":
+		e.Type = ErrorTypeModel
+	case parsed.Error.Status == "INVALID_ARGUMENT" || parsed.Error.Status == "FAILED_PRECONDITION":
+		e.Type = ErrorTypeRequest
+	case parsed.Error.Status == "UNAVAILABLE" || parsed.Error.Status == "INTERNAL":
+		e.Type = ErrorTypeService
+	case parsed.Error.Status == "DEADLINE_EXCEEDED":
+		e.Type = ErrorTypeTimeout
+	default:
+		return ParseHTTPError(statusCode, body, "gemini")
+	}
+	e.Retryable = isRetryable(e.Type)
+	return e
+}
+
 // isRetryable determines if an error type is retryable
 func isRetryable(errorType ErrorType) bool {
 	switch errorType {
@@ -0,0 +1,213 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// geminiEndpoint is the Generative Language API that serves Gemini models
+// to API keys from Google AI Studio.
+const geminiEndpoint = "https://generativelanguage.googleapis.com/v1beta"
+
+type GeminiProvider struct {
+	config   *ProviderConfig
+	client   *http.Client
+	endpoint string
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiRequest struct {
+	Contents         []geminiContent `json:"contents"`
+	GenerationConfig struct {
+		MaxOutputTokens int     `json:"maxOutputTokens"`
+		Temperature     float64 `json:"temperature"`
+	} `json:"generationConfig"`
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+		TotalTokenCount      int `json:"totalTokenCount"`
+	} `json:"usageMetadata"`
+	ModelVersion string `json:"modelVersion"`
+}
+
+func NewGeminiProvider(config *ProviderConfig) (*GeminiProvider, error) {
+	if config == nil {
+		return nil, NewLLMError(ErrorTypeRequest, "Provider configuration is required", "gemini")
+	}
+
+	if config.APIKey == "" {
+		return nil, NewLLMError(ErrorTypeAuth, "Gemini API key is required (set GEMINI_API_KEY environment variable)", "gemini")
+	}
+
+	// Validate API key format (Google API keys start with 'AIza')
+	if !strings.HasPrefix(config.APIKey, "AIza") {
+		return nil, NewLLMError(ErrorTypeAuth, "Invalid Gemini API key format (should start with 'AIza')", "gemini")
+	}
+
+	if config.Model == "" {
+		config.Model = "gemini-1.5-pro"
+	}
+
+	// Validate model name
+	validModels := []string{
+		"gemini-1.5-pro", "gemini-1.5-flash", "gemini-1.5-flash-8b",
+		"gemini-2.0-flash",
+	}
+	isValidModel := false
+	for _, validModel := range validModels {
+		if config.Model == validModel {
+			isValidModel = true
+			break
+		}
+	}
+	if !isValidModel {
+		return nil, NewLLMError(ErrorTypeModel, fmt.Sprintf("Unsupported Gemini model: %s", config.Model), "gemini")
+	}
+
+	if config.MaxTokens == 0 {
+		config.MaxTokens = 4000
+	}
+
+	// Validate token limits
+	if config.MaxTokens < 1 || config.MaxTokens > 8192 {
+		return nil, NewLLMError(ErrorTypeRequest, "MaxTokens must be between 1 and 8192 for Gemini", "gemini")
+	}
+
+	// Validate temperature
+	if config.Temperature < 0 || config.Temperature > 2 {
+		return nil, NewLLMError(ErrorTypeRequest, "Temperature must be between 0 and 2 for Gemini", "gemini")
+	}
+
+	return &GeminiProvider{
+		config:   config,
+		client:   newHTTPClient(60 * time.Second),
+		endpoint: geminiEndpoint,
+	}, nil
+}
+
+func (g *GeminiProvider) Name() string {
+	return "gemini"
+}
+
+func (g *GeminiProvider) Analyze(ctx context.Context, content string, prompt string) (*Response, error) {
+	// Validate inputs
+	if strings.TrimSpace(content) == "" {
+		return nil, NewLLMError(ErrorTypeRequest, "Content cannot be empty - webpage scraping may have failed or returned no extractable content", "gemini")
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return nil, NewLLMError(ErrorTypeRequest, "Prompt cannot be empty", "gemini")
+	}
+
+	fullPrompt := fmt.Sprintf("%s\n\nContent to analyze:\n%s", prompt, content)
+
+	// Check content length
+	if len(fullPrompt) > 1000000 { // well within the 1M-token context of Gemini 1.5
+		return nil, NewLLMError(ErrorTypeRequest, "Content too long for Gemini model", "gemini")
+	}
+
+	var reqBody geminiRequest
+	reqBody.Contents = []geminiContent{{Role: "user", Parts: []geminiPart{{Text: fullPrompt}}}}
+	reqBody.GenerationConfig.MaxOutputTokens = g.config.MaxTokens
+	reqBody.GenerationConfig.Temperature = g.config.Temperature
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to prepare request: %v", err), "gemini")
+	}
+
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent", g.endpoint, url.PathEscape(g.config.Model))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to create HTTP request: %v", err), "gemini")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", g.config.APIKey)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		// Check for specific error types
+		if urlErr, ok := err.(*url.Error); ok {
+			if urlErr.Timeout() {
+				return nil, WrapTimeoutError(err, "gemini")
+			}
+		}
+		return nil, WrapNetworkError(err, "gemini")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapNetworkError(fmt.Errorf("failed to read response body: %w", err), "gemini")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ParseGeminiError(resp.StatusCode, body)
+	}
+
+	var geminiResp geminiResponse
+	if err := json.Unmarshal(body, &geminiResp); err != nil {
+		return nil, WrapResponseError(fmt.Errorf("failed to parse response JSON: %w", err), "gemini")
+	}
+
+	// Gemini answers 200 with no candidate when it blocks the prompt, and
+	// with a candidate cut short when it blocks the answer
+	if reason := geminiResp.PromptFeedback.BlockReason; reason != "" {
+		return nil, NewLLMError(ErrorTypeContent, fmt.Sprintf("Prompt blocked by Gemini (%s)", reason), "gemini")
+	}
+	if len(geminiResp.Candidates) == 0 {
+		return nil, NewLLMError(ErrorTypeResponse, "No candidates in Gemini response", "gemini")
+	}
+	candidate := geminiResp.Candidates[0]
+	var text strings.Builder
+	for _, part := range candidate.Content.Parts {
+		text.WriteString(part.Text)
+	}
+	if text.Len() == 0 {
+		switch candidate.FinishReason {
+		case "SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT":
+			return nil, NewLLMError(ErrorTypeContent, fmt.Sprintf("Response blocked by Gemini (%s)", candidate.FinishReason), "gemini")
+		}
+		return nil, NewLLMError(ErrorTypeResponse, "Empty text content in Gemini response", "gemini")
+	}
+
+	model := geminiResp.ModelVersion
+	if model == "" {
+		model = g.config.Model
+	}
+	return &Response{
+		Content:    text.String(),
+		TokensUsed: geminiResp.UsageMetadata.TotalTokenCount,
+		Model:      model,
+		Metadata: map[string]any{
+			"prompt_tokens":     geminiResp.UsageMetadata.PromptTokenCount,
+			"completion_tokens": geminiResp.UsageMetadata.CandidatesTokenCount,
+			"finish_reason":     candidate.FinishReason,
+		},
+	}, nil
+}
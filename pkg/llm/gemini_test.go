@@ -0,0 +1,109 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewGeminiProvider_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *ProviderConfig
+		wantErr string
+	}{
+		{"empty API key", &ProviderConfig{}, "Gemini API key is required"},
+		{"invalid API key format", &ProviderConfig{APIKey: "sk-not-google"}, "Invalid Gemini API key format"},
+		{"invalid model", &ProviderConfig{APIKey: "AIza-test", Model: "gpt-4o"}, "Unsupported Gemini model"},
+		{"invalid max tokens", &ProviderConfig{APIKey: "AIza-test", MaxTokens: 10000}, "MaxTokens must be between 1 and 8192"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGeminiProvider(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewGeminiProvider() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeminiAnalyze(t *testing.T) {
+	var got geminiRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/gemini-1.5-flash:generateContent" {
+			t.Errorf("request to %s", r.URL.Path)
+		}
+		if r.Header.Get("x-goog-api-key") != "AIza-test" {
+			t.Errorf("API key header = %q", r.Header.Get("x-goog-api-key"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"candidates":[{"content":{"role":"model","parts":[{"text":"{\"score\":"},{"text":"80}"}]},"finishReason":"STOP"}],
+"usageMetadata":{"promptTokenCount":120,"candidatesTokenCount":8,"totalTokenCount":128},"modelVersion":"gemini-1.5-flash-002"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewGeminiProvider(&ProviderConfig{APIKey: "AIza-test", Model: "gemini-1.5-flash", Temperature: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.endpoint = server.URL
+
+	resp, err := provider.Analyze(context.Background(), "page text", "rate this page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Content != `{"score":80}` || resp.TokensUsed != 128 || resp.Model != "gemini-1.5-flash-002" {
+		t.Errorf("response = %+v", resp)
+	}
+	if len(got.Contents) != 1 || !strings.Contains(got.Contents[0].Parts[0].Text, "page text") ||
+		got.GenerationConfig.MaxOutputTokens != 4000 || got.GenerationConfig.Temperature != 0.2 {
+		t.Errorf("request = %+v", got)
+	}
+}
+
+func TestGeminiAnalyze_Blocked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"promptFeedback":{"blockReason":"SAFETY"}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewGeminiProvider(&ProviderConfig{APIKey: "AIza-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.endpoint = server.URL
+
+	_, err = provider.Analyze(context.Background(), "page text", "rate this page")
+	if llmErr, ok := err.(*LLMError); !ok || llmErr.Type != ErrorTypeContent {
+		t.Errorf("blocked prompt: error = %v, want a content error", err)
+	}
+}
+
+func TestParseGeminiError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   ErrorType
+	}{
+		{"invalid key", 400, `{"error":{"code":400,"message":"API key not valid.","status":"INVALID_ARGUMENT","details":[{"reason":"API_KEY_INVALID"}]}}`, ErrorTypeAuth},
+		{"bad request", 400, `{"error":{"code":400,"message":"Invalid JSON payload","status":"INVALID_ARGUMENT"}}`, ErrorTypeRequest},
+		{"rate limit", 429, `{"error":{"code":429,"message":"Resource has been exhausted","status":"RESOURCE_EXHAUSTED"}}`, ErrorTypeRateLimit},
+		{"daily quota", 429, `{"error":{"code":429,"message":"Quota exceeded for requests per day","status":"RESOURCE_EXHAUSTED"}}`, ErrorTypeQuota},
+		{"unknown model", 404, `{"error":{"code":404,"message":"models/gemini-9 is not found","status":"NOT_FOUND"}}`, ErrorTypeModel},
+		{"overloaded", 503, `{"error":{"code":503,"message":"The model is overloaded","status":"UNAVAILABLE"}}`, ErrorTypeService},
+		{"not JSON", 502, `<html>Bad Gateway</html>`, ErrorTypeService},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseGeminiError(tt.status, []byte(tt.body)); got.Type != tt.want {
+				t.Errorf("ParseGeminiError() type = %v, want %v (%s)", got.Type, tt.want, got.Message)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -17,9 +18,11 @@ type ModelInfo struct {
 	Recommended bool
 }
 
-// GetAvailableModels returns a list of available models for each provider
+// GetAvailableModels returns a list of available models for each provider.
+// The models of the local and ollama providers are the common ones; see
+// DiscoverModels for those actually installed.
 func GetAvailableModels() map[string][]ModelInfo {
-	return map[string][]ModelInfo{
+	models := map[string][]ModelInfo{
 		"claude": {
 			{
 				Name:        "claude-3-5-sonnet-20241022",
@@ -87,6 +90,36 @@ func GetAvailableModels() map[string][]ModelInfo {
 				Recommended: false,
 			},
 		},
+		"gemini": {
+			{
+				Name:        "gemini-1.5-pro",
+				Provider:    "gemini",
+				Description: "Gemini 1.5 Pro - Strong reasoning over long pages",
+				MaxTokens:   8192,
+				Recommended: true,
+			},
+			{
+				Name:        "gemini-1.5-flash",
+				Provider:    "gemini",
+				Description: "Gemini 1.5 Flash - Fast and economical",
+				MaxTokens:   8192,
+				Recommended: false,
+			},
+			{
+				Name:        "gemini-1.5-flash-8b",
+				Provider:    "gemini",
+				Description: "Gemini 1.5 Flash-8B - Lowest cost for high-volume audits",
+				MaxTokens:   8192,
+				Recommended: false,
+			},
+			{
+				Name:        "gemini-2.0-flash",
+				Provider:    "gemini",
+				Description: "Gemini 2.0 Flash - Latest fast model",
+				MaxTokens:   8192,
+				Recommended: false,
+			},
+		},
 		"local": {
 			{
 				Name:        "llama2",
@@ -118,6 +151,11 @@ func GetAvailableModels() map[string][]ModelInfo {
 			},
 		},
 	}
+	for _, m := range models["local"] {
+		m.Provider = "ollama"
+		models["ollama"] = append(models["ollama"], m)
+	}
+	return models
 }
 
 // InteractiveModelSelection provides an interactive CLI for model selection
@@ -132,9 +170,11 @@ func InteractiveModelSelection(currentProvider string) (string, string, error) {
 		fmt.Println("========================")
 		fmt.Println("1. claude   - Anthropic Claude models (requires CLAUDE_API_KEY)")
 		fmt.Println("2. openai   - OpenAI GPT models (requires OPENAI_API_KEY)")
-		fmt.Println("3. local    - Local LLM server (requires local server running)")
+		fmt.Println("3. gemini   - Google Gemini models (requires GEMINI_API_KEY)")
+		fmt.Println("4. local    - Local LLM server, OpenAI-compatible API (requires local server running)")
+		fmt.Println("5. ollama   - Ollama native API (requires ollama serve running)")
 		fmt.Println()
-		fmt.Print("Select provider (1-3): ")
+		fmt.Print("Select provider (1-5): ")
 
 		input, err := reader.ReadString('\n')
 		if err != nil {
@@ -148,7 +188,11 @@ func InteractiveModelSelection(currentProvider string) (string, string, error) {
 		case "2":
 			selectedProvider = "openai"
 		case "3":
+			selectedProvider = "gemini"
+		case "4":
 			selectedProvider = "local"
+		case "5":
+			selectedProvider = "ollama"
 		default:
 			return "", "", fmt.Errorf("invalid choice: %s", choice)
 		}
@@ -162,6 +206,12 @@ func InteractiveModelSelection(currentProvider string) (string, string, error) {
 	if !exists {
 		return "", "", fmt.Errorf("no models available for provider: %s", selectedProvider)
 	}
+	if selectedProvider == "local" || selectedProvider == "ollama" {
+		// Offer what is installed when the Ollama server answers
+		if installed, err := DiscoverModels(context.Background(), selectedProvider, ""); err == nil && len(installed) > 0 {
+			providerModels = installed
+		}
+	}
 
 	fmt.Printf("\n📋 Available %s models:\n", strings.ToUpper(selectedProvider))
 	fmt.Println(strings.Repeat("=", 50))
@@ -210,8 +260,8 @@ func ValidateModelForProvider(provider, model string) error {
 		}
 	}
 
-	// For local provider, be more permissive as users might have custom models
-	if provider == "local" {
+	// For local providers, be more permissive as users might have custom models
+	if provider == "local" || provider == "ollama" {
 		return nil
 	}
 
@@ -173,6 +173,11 @@ func TestGetRecommendedModel(t *testing.T) {
 			provider: "openai",
 			want:     "gpt-4o",
 		},
+		{
+			name:     "gemini",
+			provider: "gemini",
+			want:     "gemini-1.5-pro",
+		},
 		{
 			name:     "local",
 			provider: "local",
@@ -220,6 +225,12 @@ func TestValidateModelForProvider(t *testing.T) {
 			model:    "gpt-4",
 			wantErr:  false,
 		},
+		{
+			name:     "valid gemini model",
+			provider: "gemini",
+			model:    "gemini-1.5-flash",
+			wantErr:  false,
+		},
 		{
 			name:     "local provider with any model",
 			provider: "local",
@@ -259,4 +270,4 @@ func containsSubstring(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
@@ -44,9 +44,7 @@ func NewLocalProvider(config *ProviderConfig) (*LocalProvider, error) {
 		return nil, NewLLMError(ErrorTypeRequest, "Provider configuration is required", "local")
 	}
 	
-	if config.BaseURL == "" {
-		config.BaseURL = "http://localhost:11434"
-	}
+	config.BaseURL = OllamaBaseURL(config.BaseURL)
 	
 	// Validate URL format
 	if _, err := url.Parse(config.BaseURL); err != nil {
@@ -69,7 +67,7 @@ func NewLocalProvider(config *ProviderConfig) (*LocalProvider, error) {
 	
 	return &LocalProvider{
 		config: config,
-		client: &http.Client{Timeout: 120 * time.Second},
+		client: newHTTPClient(120 * time.Second),
 	}, nil
 }
 
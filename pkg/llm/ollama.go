@@ -0,0 +1,242 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultOllamaURL is where Ollama listens unless OLLAMA_BASE_URL says
+// otherwise.
+const defaultOllamaURL = "http://localhost:11434"
+
+// OllamaBaseURL returns the Ollama server to talk to: configured if set,
+// else OLLAMA_BASE_URL, else the default local server.
+func OllamaBaseURL(configured string) string {
+	if configured != "" {
+		return strings.TrimRight(configured, "/")
+	}
+	if env := os.Getenv("OLLAMA_BASE_URL"); env != "" {
+		return strings.TrimRight(env, "/")
+	}
+	return defaultOllamaURL
+}
+
+// OllamaProvider talks to Ollama's native API (/api/chat), which unlike
+// the OpenAI-compatible endpoint of the local provider takes Ollama's own
+// options and reports token counts for every model.
+type OllamaProvider struct {
+	config *ProviderConfig
+	client *http.Client
+}
+
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []message     `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  ollamaOptions `json:"options"`
+}
+
+type ollamaResponse struct {
+	Model   string `json:"model"`
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	DoneReason      string `json:"done_reason"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	TotalDuration   int64  `json:"total_duration"` // nanoseconds
+}
+
+// ollamaTags is the answer of /api/tags, the models installed.
+type ollamaTags struct {
+	Models []struct {
+		Name    string `json:"name"`
+		Size    int64  `json:"size"`
+		Details struct {
+			Family            string `json:"family"`
+			ParameterSize     string `json:"parameter_size"`
+			QuantizationLevel string `json:"quantization_level"`
+		} `json:"details"`
+	} `json:"models"`
+}
+
+func NewOllamaProvider(config *ProviderConfig) (*OllamaProvider, error) {
+	if config == nil {
+		return nil, NewLLMError(ErrorTypeRequest, "Provider configuration is required", "ollama")
+	}
+
+	config.BaseURL = OllamaBaseURL(config.BaseURL)
+	if _, err := url.Parse(config.BaseURL); err != nil {
+		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Invalid base URL: %v", err), "ollama")
+	}
+
+	if strings.TrimSpace(config.Model) == "" {
+		return nil, NewLLMError(ErrorTypeModel, "Model name cannot be empty (see `models ollama` for the models installed)", "ollama")
+	}
+
+	if config.Temperature < 0 || config.Temperature > 2 {
+		return nil, NewLLMError(ErrorTypeRequest, "Temperature must be between 0 and 2", "ollama")
+	}
+
+	return &OllamaProvider{
+		config: config,
+		client: newHTTPClient(120 * time.Second),
+	}, nil
+}
+
+func (o *OllamaProvider) Name() string {
+	return "ollama"
+}
+
+func (o *OllamaProvider) Analyze(ctx context.Context, content string, prompt string) (*Response, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, NewLLMError(ErrorTypeRequest, "Content cannot be empty - webpage scraping may have failed or returned no extractable content", "ollama")
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return nil, NewLLMError(ErrorTypeRequest, "Prompt cannot be empty", "ollama")
+	}
+
+	reqBody := ollamaRequest{
+		Model:  o.config.Model,
+		Stream: false,
+		Options: ollamaOptions{
+			Temperature: o.config.Temperature,
+			NumPredict:  o.config.MaxTokens,
+		},
+		Messages: []message{
+			{
+				Role:    "user",
+				Content: fmt.Sprintf("%s\n\nContent to analyze:\n%s", prompt, content),
+			},
+		},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to prepare request: %v", err), "ollama")
+	}
+
+	body, err := o.do(ctx, "POST", "/api/chat", jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var ollamaResp ollamaResponse
+	if err := json.Unmarshal(body, &ollamaResp); err != nil {
+		return nil, WrapResponseError(fmt.Errorf("failed to parse response JSON: %w", err), "ollama")
+	}
+	if ollamaResp.Message.Content == "" {
+		return nil, NewLLMError(ErrorTypeResponse, "Empty message content in Ollama response", "ollama")
+	}
+
+	return &Response{
+		Content:    ollamaResp.Message.Content,
+		TokensUsed: ollamaResp.PromptEvalCount + ollamaResp.EvalCount,
+		Model:      ollamaResp.Model,
+		Metadata: map[string]any{
+			"prompt_tokens":     ollamaResp.PromptEvalCount,
+			"completion_tokens": ollamaResp.EvalCount,
+			"done_reason":       ollamaResp.DoneReason,
+			"duration_ms":       ollamaResp.TotalDuration / int64(time.Millisecond),
+		},
+	}, nil
+}
+
+// do sends a request to the Ollama API and returns the body of a
+// successful answer.
+func (o *OllamaProvider) do(ctx context.Context, method, path string, data []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, o.config.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to create HTTP request: %v", err), "ollama")
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			if urlErr.Timeout() {
+				return nil, WrapTimeoutError(err, "ollama")
+			}
+			if strings.Contains(err.Error(), "connection refused") {
+				return nil, NewLLMError(ErrorTypeService, fmt.Sprintf("Ollama not available at %s (is `ollama serve` running?)", o.config.BaseURL), "ollama")
+			}
+		}
+		return nil, WrapNetworkError(err, "ollama")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, WrapNetworkError(fmt.Errorf("failed to read response body: %w", err), "ollama")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, ParseHTTPError(resp.StatusCode, body, "ollama")
+	}
+	return body, nil
+}
+
+// InstalledModels lists the models installed on the Ollama server, from
+// /api/tags.
+func (o *OllamaProvider) InstalledModels(ctx context.Context) ([]ModelInfo, error) {
+	body, err := o.do(ctx, "GET", "/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	var tags ollamaTags
+	if err := json.Unmarshal(body, &tags); err != nil {
+		return nil, WrapResponseError(fmt.Errorf("failed to parse model list: %w", err), "ollama")
+	}
+
+	models := make([]ModelInfo, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		var details []string
+		for _, d := range []string{m.Details.Family, m.Details.ParameterSize, m.Details.QuantizationLevel} {
+			if d != "" {
+				details = append(details, d)
+			}
+		}
+		if m.Size > 0 {
+			details = append(details, fmt.Sprintf("%.1f GB", float64(m.Size)/1e9))
+		}
+		models = append(models, ModelInfo{
+			Name:        m.Name,
+			Provider:    "ollama",
+			Description: "Installed - " + strings.Join(details, ", "),
+		})
+	}
+	return models, nil
+}
+
+// DiscoverModels lists the models installed on the Ollama server at
+// baseURL (empty for the default), for the local and ollama providers.
+func DiscoverModels(ctx context.Context, provider, baseURL string) ([]ModelInfo, error) {
+	o, err := NewOllamaProvider(&ProviderConfig{BaseURL: baseURL, Model: "-"})
+	if err != nil {
+		return nil, err
+	}
+	o.client = newHTTPClient(5 * time.Second)
+	models, err := o.InstalledModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range models {
+		models[i].Provider = provider
+	}
+	return models, nil
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOllamaAnalyze(t *testing.T) {
+	var got ollamaRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("request to %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"model":"llama3.1:8b","message":{"role":"assistant","content":"Score: 72/100"},"done":true,"done_reason":"stop",
+"prompt_eval_count":410,"eval_count":35,"total_duration":2500000000}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOllamaProvider(&ProviderConfig{BaseURL: server.URL + "/", Model: "llama3.1:8b", MaxTokens: 512, Temperature: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := provider.Analyze(context.Background(), "page text", "rate this page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Content != "Score: 72/100" || resp.TokensUsed != 445 || resp.Model != "llama3.1:8b" {
+		t.Errorf("response = %+v", resp)
+	}
+	if got.Stream || got.Model != "llama3.1:8b" || got.Options.NumPredict != 512 || got.Options.Temperature != 0.2 {
+		t.Errorf("request = %+v", got)
+	}
+}
+
+func TestOllamaModelNotInstalled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"model \"mistral\" not found, try pulling it first"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOllamaProvider(&ProviderConfig{BaseURL: server.URL, Model: "mistral"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Analyze(context.Background(), "page text", "rate this page"); err == nil {
+		t.Error("missing model not reported")
+	}
+}
+
+func TestDiscoverModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Errorf("request to %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"models":[
+{"name":"llama3.1:8b","size":4920753328,"details":{"family":"llama","parameter_size":"8.0B","quantization_level":"Q4_K_M"}},
+{"name":"qwen2.5:14b","size":0,"details":{}}]}`))
+	}))
+	defer server.Close()
+
+	models, err := DiscoverModels(context.Background(), "local", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 2 || models[0].Name != "llama3.1:8b" || models[1].Name != "qwen2.5:14b" {
+		t.Fatalf("models = %+v", models)
+	}
+	if models[0].Provider != "local" || models[0].Description != "Installed - llama, 8.0B, Q4_K_M, 4.9 GB" {
+		t.Errorf("model = %+v", models[0])
+	}
+}
@@ -12,16 +12,26 @@ import (
 	"time"
 )
 
+// openAIEndpoint is the Chat Completions API.
+const openAIEndpoint = "https://api.openai.com/v1/chat/completions"
+
 type OpenAIProvider struct {
-	config *ProviderConfig
-	client *http.Client
+	config   *ProviderConfig
+	client   *http.Client
+	endpoint string
 }
 
 type openAIRequest struct {
-	Model       string        `json:"model"`
-	Messages    []message     `json:"messages"`
-	MaxTokens   int           `json:"max_tokens"`
-	Temperature float64       `json:"temperature"`
+	Model         string               `json:"model"`
+	Messages      []message            `json:"messages"`
+	MaxTokens     int                  `json:"max_tokens"`
+	Temperature   float64              `json:"temperature"`
+	Stream        bool                 `json:"stream,omitempty"`
+	StreamOptions *openAIStreamOptions `json:"stream_options,omitempty"`
+}
+
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
 }
 
 type openAIResponse struct {
@@ -95,8 +105,9 @@ func NewOpenAIProvider(config *ProviderConfig) (*OpenAIProvider, error) {
 	}
 	
 	return &OpenAIProvider{
-		config: config,
-		client: &http.Client{Timeout: 60 * time.Second},
+		config:   config,
+		client:   newHTTPClient(60 * time.Second),
+		endpoint: openAIEndpoint,
 	}, nil
 }
 
@@ -132,12 +143,18 @@ func (o *OpenAIProvider) Analyze(ctx context.Context, content string, prompt str
 		},
 	}
 	
+	onText := streamFrom(ctx)
+	if onText != nil {
+		reqBody.Stream = true
+		reqBody.StreamOptions = &openAIStreamOptions{IncludeUsage: true}
+	}
+	
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to prepare request: %v", err), "openai")
 	}
 	
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", o.endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, NewLLMError(ErrorTypeRequest, fmt.Sprintf("Failed to create HTTP request: %v", err), "openai")
 	}
@@ -157,6 +174,10 @@ func (o *OpenAIProvider) Analyze(ctx context.Context, content string, prompt str
 	}
 	defer resp.Body.Close()
 	
+	if resp.StatusCode == http.StatusOK && onText != nil {
+		return readOpenAIStream(resp.Body, onText)
+	}
+	
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, WrapNetworkError(fmt.Errorf("failed to read response body: %w", err), "openai")
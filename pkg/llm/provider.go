@@ -31,9 +31,18 @@ func NewProvider(providerType string, config *ProviderConfig) (Provider, error)
 		return NewClaudeProvider(config)
 	case "gpt", "openai":
 		return NewOpenAIProvider(config)
+	case "gemini":
+		return NewGeminiProvider(config)
 	case "local":
 		return NewLocalProvider(config)
+	case "ollama":
+		return NewOllamaProvider(config)
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", providerType)
 	}
-}
\ No newline at end of file
+}
+// IsLocal reports whether providerType runs on this machine and needs no
+// API key.
+func IsLocal(providerType string) bool {
+	return providerType == "local" || providerType == "ollama"
+}
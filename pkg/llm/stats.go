@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats collects the latency and outcome of the requests made to providers
+// over a run. It is safe for concurrent use.
+type Stats struct {
+	mu    sync.Mutex
+	calls map[string][]call
+}
+
+type call struct {
+	latency time.Duration
+	err     ErrorType // empty on success
+	retry   bool
+}
+
+// ProviderHealth summarizes the requests made to one provider.
+type ProviderHealth struct {
+	Provider  string            `json:"provider"`
+	Requests  int               `json:"requests"`
+	Errors    int               `json:"errors"`
+	ErrorRate float64           `json:"error_rate"`
+	Retries   int               `json:"retries"`
+	P50MS     int64             `json:"p50_ms"`
+	P95MS     int64             `json:"p95_ms"`
+	ByType    map[ErrorType]int `json:"errors_by_type,omitempty"`
+}
+
+// NewStats returns an empty collection.
+func NewStats() *Stats {
+	return &Stats{calls: make(map[string][]call)}
+}
+
+type retryKey struct{}
+
+// WithRetry marks the requests made with ctx as retries of earlier failed
+// ones, so Stats counts them apart.
+func WithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+type meteredProvider struct {
+	Provider
+	stats *Stats
+}
+
+// NewMeteredProvider returns a provider that records the latency and
+// outcome of every request to p in stats.
+func NewMeteredProvider(p Provider, stats *Stats) Provider {
+	return &meteredProvider{Provider: p, stats: stats}
+}
+
+func (m *meteredProvider) Analyze(ctx context.Context, content string, prompt string) (*Response, error) {
+	start := time.Now()
+	resp, err := m.Provider.Analyze(ctx, content, prompt)
+	c := call{latency: time.Since(start), retry: ctx.Value(retryKey{}) != nil}
+	if err != nil {
+		c.err = ErrorTypeUnknown
+		if llmErr, ok := err.(*LLMError); ok {
+			c.err = llmErr.Type
+		}
+	}
+	m.stats.mu.Lock()
+	m.stats.calls[m.Name()] = append(m.stats.calls[m.Name()], c)
+	m.stats.mu.Unlock()
+	return resp, err
+}
+
+// Health summarizes the requests recorded so far per provider, by name.
+func (s *Stats) Health() []ProviderHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var health []ProviderHealth
+	for provider, calls := range s.calls {
+		h := ProviderHealth{Provider: provider, Requests: len(calls)}
+		latencies := make([]time.Duration, 0, len(calls))
+		for _, c := range calls {
+			latencies = append(latencies, c.latency)
+			if c.retry {
+				h.Retries++
+			}
+			if c.err != "" {
+				h.Errors++
+				if h.ByType == nil {
+					h.ByType = make(map[ErrorType]int)
+				}
+				h.ByType[c.err]++
+			}
+		}
+		slices.Sort(latencies)
+		h.ErrorRate = float64(h.Errors) / float64(h.Requests)
+		h.P50MS = percentile(latencies, 50).Milliseconds()
+		h.P95MS = percentile(latencies, 95).Milliseconds()
+		health = append(health, h)
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Provider < health[j].Provider })
+	return health
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
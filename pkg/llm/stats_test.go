@@ -0,0 +1,46 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStatsHealth(t *testing.T) {
+	stats := NewStats()
+	ok := NewMeteredProvider(&countingProvider{}, stats)
+	failing := NewMeteredProvider(failingProvider{}, stats)
+
+	for i := 0; i < 3; i++ {
+		ok.Analyze(context.Background(), "content", "prompt")
+	}
+	failing.Analyze(context.Background(), "content", "prompt")
+	failing.Analyze(WithRetry(context.Background()), "content", "prompt")
+
+	health := stats.Health()
+	if len(health) != 2 || health[0].Provider != "counting" || health[1].Provider != "failing" {
+		t.Fatalf("health = %+v, want counting then failing", health)
+	}
+	if h := health[0]; h.Requests != 3 || h.Errors != 0 || h.Retries != 0 {
+		t.Errorf("counting = %+v", h)
+	}
+	if h := health[1]; h.Requests != 2 || h.Errors != 2 || h.ErrorRate != 1 || h.Retries != 1 || h.ByType[ErrorTypeRateLimit] != 2 {
+		t.Errorf("failing = %+v", h)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 20; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	if got := percentile(latencies, 50); got != 10*time.Millisecond {
+		t.Errorf("p50 = %v, want 10ms", got)
+	}
+	if got := percentile(latencies, 95); got != 19*time.Millisecond {
+		t.Errorf("p95 = %v, want 19ms", got)
+	}
+	if got := percentile(latencies[:1], 95); got != time.Millisecond {
+		t.Errorf("p95 of one = %v, want 1ms", got)
+	}
+}
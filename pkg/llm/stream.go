@@ -0,0 +1,196 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type streamKey struct{}
+
+// WithStream asks the providers that can (Claude and OpenAI, over
+// server-sent events) to deliver the answers to requests made with ctx as
+// they are generated, passing each new piece of text to onText. The
+// response returned is complete as usual; providers that cannot stream,
+// and answers reused from the cache, pass nothing. It goes by context so
+// that the metered, audited and cached providers wrapping a provider pass
+// it through unchanged.
+func WithStream(ctx context.Context, onText func(string)) context.Context {
+	return context.WithValue(ctx, streamKey{}, onText)
+}
+
+// streamFrom returns the function streamed text goes to; nil when the
+// request is not streamed.
+func streamFrom(ctx context.Context) func(string) {
+	onText, _ := ctx.Value(streamKey{}).(func(string))
+	return onText
+}
+
+// readSSE calls onEvent with the name and data of each server-sent event
+// read from r, stopping at the first error it returns.
+func readSSE(r io.Reader, onEvent func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	event, data := "", ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data != "" {
+				if err := onEvent(event, data); err != nil {
+					return err
+				}
+			}
+			event, data = "", ""
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data != "" {
+				data += "\n"
+			}
+			data += strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if data != "" {
+		return onEvent(event, data)
+	}
+	return nil
+}
+
+// readOpenAIStream reads a streamed chat completion, passing its text to
+// onText, and returns the whole response.
+func readOpenAIStream(r io.Reader, onText func(string)) (*Response, error) {
+	var content strings.Builder
+	result := &Response{}
+	var usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	}
+	err := readSSE(r, func(_, data string) error {
+		if data == "[DONE]" {
+			return nil
+		}
+		var chunk struct {
+			Model   string `json:"model"`
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+			} `json:"choices"`
+			Usage *struct {
+				PromptTokens     int `json:"prompt_tokens"`
+				CompletionTokens int `json:"completion_tokens"`
+				TotalTokens      int `json:"total_tokens"`
+			} `json:"usage"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to parse stream event: %w", err)
+		}
+		if chunk.Model != "" {
+			result.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			usage = *chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if text := choice.Delta.Content; text != "" {
+				content.WriteString(text)
+				onText(text)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, WrapResponseError(err, "openai")
+	}
+	if content.Len() == 0 {
+		return nil, NewLLMError(ErrorTypeResponse, "Empty message content in OpenAI response", "openai")
+	}
+	result.Content = content.String()
+	result.TokensUsed = usage.TotalTokens
+	result.Metadata = map[string]any{
+		"prompt_tokens":     usage.PromptTokens,
+		"completion_tokens": usage.CompletionTokens,
+		"streamed":          true,
+	}
+	return result, nil
+}
+
+// readClaudeStream reads a streamed message, passing its text to onText,
+// and returns the whole response.
+func readClaudeStream(r io.Reader, onText func(string)) (*Response, error) {
+	var content strings.Builder
+	result := &Response{}
+	inputTokens, outputTokens := 0, 0
+	var streamErr *LLMError
+	err := readSSE(r, func(event, data string) error {
+		var e struct {
+			Type    string `json:"type"`
+			Message struct {
+				Model string `json:"model"`
+				Usage struct {
+					InputTokens int `json:"input_tokens"`
+				} `json:"usage"`
+			} `json:"message"`
+			Delta struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"delta"`
+			Usage struct {
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+			Error struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			return fmt.Errorf("failed to parse stream event: %w", err)
+		}
+		switch e.Type {
+		case "message_start":
+			result.Model = e.Message.Model
+			inputTokens = e.Message.Usage.InputTokens
+		case "content_block_delta":
+			if e.Delta.Type == "text_delta" && e.Delta.Text != "" {
+				content.WriteString(e.Delta.Text)
+				onText(e.Delta.Text)
+			}
+		case "message_delta":
+			outputTokens = e.Usage.OutputTokens
+		case "error":
+			// Errors after the response started come as events
+			errorType := ErrorTypeService
+			if e.Error.Type == "rate_limit_error" {
+				errorType = ErrorTypeRateLimit
+			}
+			streamErr = NewLLMError(errorType, e.Error.Message, "claude")
+			return streamErr
+		}
+		return nil
+	})
+	if streamErr != nil {
+		return nil, streamErr
+	}
+	if err != nil {
+		return nil, WrapResponseError(err, "claude")
+	}
+	if content.Len() == 0 {
+		return nil, NewLLMError(ErrorTypeResponse, "Empty text content in Claude response", "claude")
+	}
+	result.Content = content.String()
+	result.TokensUsed = inputTokens + outputTokens
+	result.Metadata = map[string]any{
+		"input_tokens":  inputTokens,
+		"output_tokens": outputTokens,
+		"streamed":      true,
+	}
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIStream(t *testing.T) {
+	var got openAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`data: {"model":"gpt-4o","choices":[{"delta":{"role":"assistant","content":""}}]}
+
+data: {"model":"gpt-4o","choices":[{"delta":{"content":"Score: "}}]}
+
+data: {"model":"gpt-4o","choices":[{"delta":{"content":"72/100"}}]}
+
+data: {"model":"gpt-4o","choices":[],"usage":{"prompt_tokens":400,"completion_tokens":5,"total_tokens":405}}
+
+data: [DONE]
+
+`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(&ProviderConfig{APIKey: "sk-test", Model: "gpt-4o"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.endpoint = server.URL
+	var streamed []string
+	ctx := WithStream(context.Background(), func(text string) { streamed = append(streamed, text) })
+	resp, err := provider.Analyze(ctx, "page text", "rate this page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Stream || got.StreamOptions == nil || !got.StreamOptions.IncludeUsage {
+		t.Errorf("request = %+v", got)
+	}
+	if strings.Join(streamed, "|") != "Score: |72/100" {
+		t.Errorf("streamed %q", streamed)
+	}
+	if resp.Content != "Score: 72/100" || resp.TokensUsed != 405 || resp.Model != "gpt-4o" {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestClaudeStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got claudeRequest
+		json.NewDecoder(r.Body).Decode(&got)
+		if !got.Stream {
+			t.Error("request not streamed")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`event: message_start
+data: {"type":"message_start","message":{"model":"claude-3-5-sonnet-20241022","usage":{"input_tokens":400,"output_tokens":1}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+
+event: ping
+data: {"type":"ping"}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Score: "}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"72/100"}}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":5}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`))
+	}))
+	defer server.Close()
+
+	provider, err := NewClaudeProvider(&ProviderConfig{APIKey: "sk-ant-test", Model: "claude-3-5-sonnet-20241022"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.endpoint = server.URL
+	var streamed strings.Builder
+	ctx := WithStream(context.Background(), func(text string) { streamed.WriteString(text) })
+	resp, err := provider.Analyze(ctx, "page text", "rate this page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if streamed.String() != "Score: 72/100" || resp.Content != "Score: 72/100" || resp.TokensUsed != 405 {
+		t.Errorf("streamed %q, response = %+v", streamed.String(), resp)
+	}
+}
+
+func TestClaudeStreamError(t *testing.T) {
+	stream := `event: error
+data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}
+
+`
+	_, err := readClaudeStream(strings.NewReader(stream), func(string) {})
+	if llmErr, ok := err.(*LLMError); !ok || llmErr.Type != ErrorTypeService {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package llm
+
+import (
+	"geo-checker/internal/netguard"
+	"net/http"
+	"time"
+)
+
+// sharedTransport pools connections for every provider in the process, so
+// concurrent analyses reuse keep-alive connections to the same API host
+// instead of each provider dialing its own. In offline mode it connects to
+// localhost only, so local models keep working.
+var sharedTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = netguard.Guard(t.DialContext)
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = 32
+	t.IdleConnTimeout = 90 * time.Second
+	return t
+}()
+
+// newHTTPClient returns a client on the shared transport. http.Client is
+// safe for concurrent use, so providers hold one each.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: sharedTransport, Timeout: timeout}
+}
@@ -0,0 +1,218 @@
+package lsp
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"geo-checker/pkg/annotate"
+	"geo-checker/pkg/scorer"
+)
+
+// pillarWhy says why each pillar matters to generative engines, for
+// hovers.
+var pillarWhy = map[string]string{
+	"content_structure": "AI systems lift answers out of pages section by section; clear headings, lists and short paragraphs make the right passage easy to find and quote.",
+	"semantic_clarity":  "Short, unambiguous sentences with defined terms are summarized and quoted accurately instead of paraphrased or skipped.",
+	"context_richness":  "Specific examples, figures and background give an AI system something concrete to cite rather than generic text it can write itself.",
+	"authority_signals": "Sources, authorship and freshness are how AI systems decide which of several similar pages to trust and cite.",
+	"accessibility":     "Meta data and crawlable markup decide whether AI crawlers can read the page and understand what it is about.",
+	"structured_data":   "Schema.org markup tells AI systems what the page is, who wrote it and when, in a form they can trust when it matches the page.",
+}
+
+// skippedHeading matches the evidence of a skipped heading level.
+var skippedHeading = regexp.MustCompile(`^H(\d) → H(\d): `)
+
+// maxDescription is the length meta descriptions are drafted to.
+const maxDescription = 155
+
+// hover explains the findings at p, or returns nil when there are none.
+func (doc *document) hover(p Position) *Hover {
+	var parts []string
+	var at *Range
+	for _, prob := range doc.problems {
+		if !prob.diagnostic.Range.contains(p) {
+			continue
+		}
+		f := prob.finding
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "**%s**", diagnosticMessage(f))
+		fmt.Fprintf(&sb, "\n\n%s · %s severity · `%s`", doc.score.PillarName(f.Pillar), f.Severity, f.ID)
+		if len(f.Merged) > 0 {
+			fmt.Fprintf(&sb, " (also %s)", strings.Join(f.Merged, ", "))
+		}
+		if detail, ok := doc.score.Pillar(f.Pillar); ok {
+			fmt.Fprintf(&sb, " · pillar %d/%d", detail.Score, detail.MaxScore)
+		}
+		if why := pillarWhy[f.Pillar]; why != "" {
+			sb.WriteString("\n\n" + why)
+		}
+		for _, ev := range f.Evidence {
+			sb.WriteString("\n\n> " + ev.Snippet)
+		}
+		parts = append(parts, sb.String())
+		r := prob.diagnostic.Range
+		at = &r
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	if len(parts) > 1 {
+		at = nil
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: strings.Join(parts, "\n\n---\n\n")}, Range: at}
+}
+
+// codeActions returns the fixes for the findings overlapping r: inserting
+// a drafted meta description and correcting skipped heading levels.
+func (doc *document) codeActions(r Range) []CodeAction {
+	var actions []CodeAction
+	for _, prob := range doc.problems {
+		if !prob.diagnostic.Range.overlaps(r) {
+			continue
+		}
+		switch prob.finding.ID {
+		case "accessibility.meta":
+			if edit, ok := doc.descriptionEdit(); ok {
+				actions = append(actions, doc.action("Insert meta description", prob.diagnostic, edit))
+			}
+		case "structure.headings":
+			for _, ev := range prob.finding.Evidence {
+				if title, edit, ok := doc.headingEdit(ev); ok {
+					actions = append(actions, doc.action(title, prob.diagnostic, edit))
+				}
+			}
+		}
+	}
+	return actions
+}
+
+func (doc *document) action(title string, d Diagnostic, edit TextEdit) CodeAction {
+	return CodeAction{
+		Title:       title,
+		Kind:        "quickfix",
+		Diagnostics: []Diagnostic{d},
+		Edit:        WorkspaceEdit{Changes: map[string][]TextEdit{doc.uri: {edit}}},
+	}
+}
+
+// descriptionEdit inserts a meta description drafted from the opening
+// paragraph: before </head> in HTML, in the front matter in markdown.
+func (doc *document) descriptionEdit() (TextEdit, bool) {
+	if doc.page.MetaTags["description"] != "" {
+		return TextEdit{}, false
+	}
+	draft := draftDescription(doc.page.Content)
+	if draft == "" {
+		return TextEdit{}, false
+	}
+
+	if doc.markdown {
+		line := "description: " + strconv.Quote(draft) + "\n"
+		lines := strings.SplitAfter(doc.text, "\n")
+		if strings.TrimSpace(lines[0]) == "---" {
+			for i := 1; i < len(lines); i++ {
+				if strings.TrimSpace(lines[i]) == "---" {
+					return insertAt(Position{Line: i}, line), true
+				}
+			}
+		}
+		return insertAt(Position{}, "---\n"+line+"---\n"), true
+	}
+
+	i := strings.Index(strings.ToLower(doc.text), "</head>")
+	if i < 0 {
+		return TextEdit{}, false
+	}
+	tag := `<meta name="description" content="` + html.EscapeString(draft) + `">` + "\n"
+	return insertAt(position(doc.text, i), tag), true
+}
+
+// headingEdit changes a heading that skips a level to the level below the
+// previous heading.
+func (doc *document) headingEdit(ev scorer.Evidence) (string, TextEdit, bool) {
+	m := skippedHeading.FindStringSubmatch(ev.Snippet)
+	if m == nil {
+		return "", TextEdit{}, false
+	}
+	prev, _ := strconv.Atoi(m[1])
+	from, to := m[2], strconv.Itoa(prev+1)
+	title := fmt.Sprintf("Change H%s to H%s", from, to)
+
+	loc := annotate.Locate(doc.text, doc.markdown, []scorer.Finding{{Evidence: []scorer.Evidence{ev}}})[0]
+	if loc.Line == 0 {
+		return "", TextEdit{}, false
+	}
+	if doc.markdown {
+		line := strings.SplitAfter(doc.text, "\n")[loc.Line-1]
+		hashes := len(line) - len(strings.TrimLeft(line, "#"))
+		if strconv.Itoa(hashes) != from {
+			return "", TextEdit{}, false
+		}
+		r := Range{Start: Position{Line: loc.Line - 1}, End: Position{Line: loc.Line - 1, Character: hashes}}
+		return title, TextEdit{Range: r, NewText: strings.Repeat("#", prev+1)}, true
+	}
+
+	start, end := offset(doc.text, loc.Line, loc.Col), offset(doc.text, loc.EndLine, loc.EndCol)
+	element := doc.text[start:end]
+	open := regexp.MustCompile(`(?i)^<h` + from + `\b`)
+	closing := regexp.MustCompile(`(?i)</h` + from + `>$`)
+	if !open.MatchString(element) {
+		return "", TextEdit{}, false
+	}
+	element = open.ReplaceAllString(element, "<h"+to)
+	element = closing.ReplaceAllString(element, "</h"+to+">")
+	r := Range{Start: Position{loc.Line - 1, loc.Col - 1}, End: Position{loc.EndLine - 1, loc.EndCol - 1}}
+	return title, TextEdit{Range: r, NewText: element}, true
+}
+
+// draftDescription returns the first paragraph of content with a full
+// sentence, cut at a word boundary to fit a meta description.
+func draftDescription(content string) string {
+	for _, para := range strings.Split(content, "\n\n") {
+		para = strings.Join(strings.Fields(para), " ")
+		if len(strings.Fields(para)) < 8 || !strings.ContainsAny(para, ".!?") {
+			continue
+		}
+		if len(para) <= maxDescription {
+			return para
+		}
+		cut := strings.LastIndex(para[:maxDescription], " ")
+		if end := strings.LastIndexAny(para[:maxDescription], ".!?"); end > maxDescription/2 {
+			return para[:end+1]
+		}
+		return strings.TrimRight(para[:cut], ",;:") + "…"
+	}
+	return ""
+}
+
+func insertAt(p Position, text string) TextEdit {
+	return TextEdit{Range: Range{Start: p, End: p}, NewText: text}
+}
+
+// position converts a byte offset in text to a position.
+func position(text string, offset int) Position {
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	return Position{Line: strings.Count(text[:offset], "\n"), Character: utf8.RuneCountInString(text[lineStart:offset])}
+}
+
+// offset converts a 1-based line and character column to a byte offset in
+// text.
+func offset(text string, line, col int) int {
+	i := 0
+	for l := 1; l < line; l++ {
+		next := strings.IndexByte(text[i:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		i += next + 1
+	}
+	for c := 1; c < col && i < len(text); c++ {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return i
+}
@@ -0,0 +1,115 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"geo-checker/pkg/config"
+)
+
+const testPage = `<html><head><title>Widgets</title></head><body><main>
+<h1>Widgets</h1>
+<p>Widgets are small mechanical parts used in many household devices, and they come in many shapes and sizes.</p>
+<h4 id="sizes">Sizes</h4>
+<p>Pick the size by measuring the slot first.</p>
+</main></body></html>`
+
+// session sends requests to a server and returns everything it wrote.
+func session(t *testing.T, requests ...map[string]any) []message {
+	t.Helper()
+	var in bytes.Buffer
+	for _, req := range requests {
+		req["jsonrpc"] = "2.0"
+		body, _ := json.Marshal(req)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	var out bytes.Buffer
+	if err := New(&config.Config{Profile: "general"}).Serve(context.Background(), &in, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	var msgs []message
+	r := bufio.NewReader(&out)
+	for {
+		msg, err := readMessage(r)
+		if err == io.EOF {
+			return msgs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, *msg)
+	}
+}
+
+func TestServer(t *testing.T) {
+	uri := "file:///site/widgets.html"
+	doc := map[string]any{"uri": uri}
+	msgs := session(t,
+		map[string]any{"id": 1, "method": "initialize", "params": map[string]any{}},
+		map[string]any{"method": "textDocument/didOpen", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri, "languageId": "html", "version": 1, "text": testPage}}},
+		map[string]any{"id": 2, "method": "textDocument/hover", "params": map[string]any{
+			"textDocument": doc, "position": map[string]any{"line": 3, "character": 5}}},
+		map[string]any{"id": 3, "method": "textDocument/codeAction", "params": map[string]any{
+			"textDocument": doc, "range": map[string]any{"start": map[string]any{"line": 3, "character": 0}, "end": map[string]any{"line": 3, "character": 0}}}},
+		map[string]any{"id": 4, "method": "textDocument/codeAction", "params": map[string]any{
+			"textDocument": doc, "range": map[string]any{"start": map[string]any{"line": 0, "character": 0}, "end": map[string]any{"line": 0, "character": 0}}}},
+		map[string]any{"id": 5, "method": "workspace/symbol", "params": map[string]any{}},
+		map[string]any{"method": "exit"},
+	)
+	if len(msgs) != 6 {
+		t.Fatalf("got %d messages, want 6", len(msgs))
+	}
+
+	var diagnostics publishDiagnosticsParams
+	json.Unmarshal(msgs[1].Params, &diagnostics)
+	var heading *Diagnostic
+	for i, d := range diagnostics.Diagnostics {
+		if d.Code == "structure.headings" {
+			heading = &diagnostics.Diagnostics[i]
+		}
+	}
+	if msgs[1].Method != "textDocument/publishDiagnostics" || heading == nil {
+		t.Fatalf("no heading diagnostic in %s", msgs[1].Params)
+	}
+	if want := (Range{Start: Position{3, 0}, End: Position{3, 25}}); heading.Range != want {
+		t.Errorf("heading diagnostic at %+v, want %+v", heading.Range, want)
+	}
+
+	result := func(msg message) string {
+		var sb strings.Builder
+		enc := json.NewEncoder(&sb)
+		enc.SetEscapeHTML(false)
+		enc.Encode(msg.Result)
+		return sb.String()
+	}
+	if hover := result(msgs[2]); !strings.Contains(hover, "structure.headings") || !strings.Contains(hover, "H1 → H4: Sizes") {
+		t.Errorf("hover = %s", hover)
+	}
+	if actions := result(msgs[3]); !strings.Contains(actions, `"Change H4 to H2"`) || !strings.Contains(actions, `<h2 id=\"sizes\">Sizes</h2>`) {
+		t.Errorf("heading actions = %s", actions)
+	}
+	if actions := result(msgs[4]); !strings.Contains(actions, `"Insert meta description"`) || !strings.Contains(actions, "Widgets are small mechanical parts") {
+		t.Errorf("page actions = %s", actions)
+	}
+	if msgs[5].Error == nil || msgs[5].Error.Code != codeMethodNotFound {
+		t.Errorf("unsupported method answered with %+v", msgs[5])
+	}
+}
+
+func TestDraftDescription(t *testing.T) {
+	long := strings.Repeat("word ", 40) + "end."
+	if got := draftDescription("Title\n\n" + long); len(got) > maxDescription+len("…") || !strings.HasSuffix(got, "…") {
+		t.Errorf("draft = %q, want a cut paragraph", got)
+	}
+	if got := draftDescription("Short heading\n\nThe first real paragraph has a full sentence in it."); got != "The first real paragraph has a full sentence in it." {
+		t.Errorf("draft = %q", got)
+	}
+}
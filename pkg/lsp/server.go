@@ -0,0 +1,250 @@
+// Package lsp serves local-scorer findings to editors over the Language
+// Server Protocol: diagnostics for open HTML and markdown documents, hovers
+// explaining them and code actions fixing the mechanical ones.
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/annotate"
+	"geo-checker/pkg/config"
+	"geo-checker/pkg/scorer"
+	"geo-checker/pkg/source"
+)
+
+// Server is a language server for one client connection. Documents are
+// analyzed with the local scorer whenever they are opened or changed.
+type Server struct {
+	analyzer *analyzer.Analyzer
+	scraper  *webpage.Scraper
+	docs     map[string]*document
+	out      io.Writer
+}
+
+// document is an open document and its latest analysis.
+type document struct {
+	uri      string
+	text     string
+	markdown bool
+	page     *webpage.PageData
+	score    *scorer.GEOScore
+	problems []problem
+}
+
+// problem is a finding placed in the document.
+type problem struct {
+	finding    scorer.Finding
+	diagnostic Diagnostic
+}
+
+// New returns a server scoring documents with cfg, which is forced to
+// local mode: an editor cannot wait for an LLM on every keystroke.
+func New(cfg *config.Config) *Server {
+	local := *cfg
+	local.Mode = "local"
+	return &Server{
+		analyzer: analyzer.New(&local),
+		scraper:  webpage.New(),
+		docs:     make(map[string]*document),
+	}
+}
+
+// Serve reads requests from r and writes responses and notifications to w
+// until the client sends exit, r ends or ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = w
+	in := bufio.NewReader(r)
+	for ctx.Err() == nil {
+		msg, err := readMessage(in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if msg == nil {
+				return err
+			}
+			// A malformed body leaves the stream in sync
+			s.reply(nil, nil, &rpcError{Code: codeParseError, Message: err.Error()})
+			continue
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	var result any
+	var rpcErr *rpcError
+	decode := func(v any) bool {
+		if err := json.Unmarshal(msg.Params, v); err != nil {
+			rpcErr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+			return false
+		}
+		return true
+	}
+
+	switch msg.Method {
+	case "initialize":
+		result = map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   map[string]any{"openClose": true, "change": 1},
+				"hoverProvider":      true,
+				"codeActionProvider": map[string]any{"codeActionKinds": []string{"quickfix"}},
+			},
+			"serverInfo": map[string]string{"name": "mux-geo", "version": analyzer.ToolVersion()},
+		}
+	case "shutdown":
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if decode(&p) {
+			markdown := p.TextDocument.LanguageID == "markdown" || annotate.IsMarkdown(path.Base(p.TextDocument.URI))
+			return s.update(ctx, p.TextDocument.URI, p.TextDocument.Text, markdown)
+		}
+	case "textDocument/didChange":
+		var p didChangeParams
+		if decode(&p) && len(p.ContentChanges) > 0 {
+			doc, ok := s.docs[p.TextDocument.URI]
+			markdown := annotate.IsMarkdown(path.Base(p.TextDocument.URI))
+			if ok {
+				markdown = doc.markdown
+			}
+			// Full sync: the last change holds the whole text
+			return s.update(ctx, p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text, markdown)
+		}
+	case "textDocument/didClose":
+		var p didCloseParams
+		if decode(&p) {
+			delete(s.docs, p.TextDocument.URI)
+			return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		}
+	case "textDocument/hover":
+		var p hoverParams
+		if decode(&p) {
+			if doc, ok := s.docs[p.TextDocument.URI]; ok {
+				if hover := doc.hover(p.Position); hover != nil {
+					result = hover
+				}
+			}
+		}
+	case "textDocument/codeAction":
+		var p codeActionParams
+		if decode(&p) {
+			actions := []CodeAction{}
+			if doc, ok := s.docs[p.TextDocument.URI]; ok {
+				actions = append(actions, doc.codeActions(p.Range)...)
+			}
+			result = actions
+		}
+	default:
+		if msg.ID == nil {
+			return nil // notifications the server does not handle
+		}
+		rpcErr = &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", msg.Method)}
+	}
+
+	if msg.ID == nil {
+		return nil
+	}
+	return s.reply(msg.ID, result, rpcErr)
+}
+
+// reply answers the request with id. A nil result is sent as null.
+func (s *Server) reply(id json.RawMessage, result any, rpcErr *rpcError) error {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	if result == nil && rpcErr == nil {
+		result = json.RawMessage("null")
+	}
+	return writeMessage(s.out, &message{ID: id, Result: result, Error: rpcErr})
+}
+
+func (s *Server) notify(method string, params any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.out, &message{Method: method, Params: data})
+}
+
+// update analyzes the text of a document and publishes its diagnostics.
+func (s *Server) update(ctx context.Context, uri, text string, markdown bool) error {
+	doc := &document{uri: uri, text: text, markdown: markdown}
+	s.docs[uri] = doc
+	if err := s.analyze(ctx, doc); err != nil {
+		// Nothing to score yet, e.g. a new empty file
+		doc.problems = nil
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(doc.problems))
+	for _, p := range doc.problems {
+		diagnostics = append(diagnostics, p.diagnostic)
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+// analyze scores the document and places its findings.
+func (s *Server) analyze(ctx context.Context, doc *document) error {
+	src := doc.text
+	if doc.markdown {
+		src = source.MarkdownPage(doc.text)
+	}
+	page, err := s.scraper.ParseHTML(src, doc.uri)
+	if err != nil {
+		return err
+	}
+	result, err := s.analyzer.AnalyzePage(ctx, page, doc.uri)
+	if err != nil {
+		return err
+	}
+	doc.page, doc.score = page, result.LocalScore
+
+	for _, loc := range annotate.Locate(doc.text, doc.markdown, doc.score.Findings) {
+		r := Range{End: Position{Line: 1}} // the first line for the whole page
+		if loc.Line > 0 {
+			r = Range{Start: Position{loc.Line - 1, loc.Col - 1}, End: Position{loc.EndLine - 1, loc.EndCol - 1}}
+		}
+		f := loc.Finding
+		doc.problems = append(doc.problems, problem{finding: f, diagnostic: Diagnostic{
+			Range:    r,
+			Severity: severity(f.Severity),
+			Code:     f.ID,
+			Source:   "geo",
+			Message:  diagnosticMessage(f),
+		}})
+	}
+	return nil
+}
+
+// severity maps a finding severity to a diagnostic severity.
+func severity(s string) int {
+	switch s {
+	case scorer.SeverityHigh:
+		return severityError
+	case scorer.SeverityMedium:
+		return severityWarning
+	default:
+		return severityInformation
+	}
+}
+
+// diagnosticMessage is the diagnostic text of a finding.
+func diagnosticMessage(f scorer.Finding) string {
+	if f.Impact > 0 {
+		return fmt.Sprintf("%s (+%d pts)", f.Message, f.Impact)
+	}
+	return f.Message
+}
@@ -0,0 +1,224 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/internal/netguard"
+	"html/template"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SMTPConfig holds mail server settings, normally read from the SMTP_*
+// environment variables.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func SMTPConfigFromEnv() (*SMTPConfig, error) {
+	cfg := &SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, fmt.Errorf("email delivery requires SMTP_HOST and SMTP_FROM (or SMTP_USERNAME)")
+	}
+	return cfg, nil
+}
+
+// PageDelta is the score change of a single URL between two runs.
+type PageDelta struct {
+	URL      string
+	Title    string
+	Score    int
+	Previous int
+	Delta    int
+	HasPrev  bool
+	Error    string
+}
+
+// Digest summarizes a run, optionally compared with a previous run.
+type Digest struct {
+	Project      string
+	GeneratedAt  time.Time
+	Total        int
+	Errors       int
+	AverageScore int
+	AverageDelta int
+	HasPrevious  bool
+	Pages        []PageDelta
+	Regressions  []PageDelta
+}
+
+// BuildDigest compares current results with previous ones (which may be nil)
+// and collects the top regressions.
+func BuildDigest(project string, current, previous []*bulk.BulkResult, topN int) *Digest {
+	prevScores := make(map[string]int)
+	for _, r := range previous {
+		if r.Result != nil {
+			prevScores[r.URL] = r.Result.Score
+		}
+	}
+
+	d := &Digest{
+		Project:     project,
+		GeneratedAt: time.Now(),
+		Total:       len(current),
+		HasPrevious: len(previous) > 0,
+	}
+
+	total, scored, compared, deltaTotal := 0, 0, 0, 0
+	for _, r := range current {
+		page := PageDelta{URL: r.URL, Error: r.ErrorMessage()}
+		if r.Result == nil {
+			d.Errors++
+			d.Pages = append(d.Pages, page)
+			continue
+		}
+
+		page.Title = r.Result.Title
+		page.Score = r.Result.Score
+		total += page.Score
+		scored++
+
+		if prev, ok := prevScores[r.URL]; ok {
+			page.Previous = prev
+			page.Delta = page.Score - prev
+			page.HasPrev = true
+			deltaTotal += page.Delta
+			compared++
+			if page.Delta < 0 {
+				d.Regressions = append(d.Regressions, page)
+			}
+		}
+		d.Pages = append(d.Pages, page)
+	}
+
+	if scored > 0 {
+		d.AverageScore = total / scored
+	}
+	if compared > 0 {
+		// Only pages present in both runs contribute to the delta
+		d.AverageDelta = deltaTotal / compared
+	}
+
+	sort.Slice(d.Regressions, func(i, j int) bool {
+		return d.Regressions[i].Delta < d.Regressions[j].Delta
+	})
+	if topN > 0 && len(d.Regressions) > topN {
+		d.Regressions = d.Regressions[:topN]
+	}
+
+	return d
+}
+
+var digestTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"signed": func(n int) string {
+		if n > 0 {
+			return fmt.Sprintf("+%d", n)
+		}
+		return fmt.Sprintf("%d", n)
+	},
+}).Parse(`<html><body style="font-family: sans-serif">
+<h2>GEO audit digest{{if .Project}}: {{.Project}}{{end}}</h2>
+<p>{{.GeneratedAt.Format "2006-01-02 15:04 MST"}} &middot; {{.Total}} URLs &middot; {{.Errors}} errors</p>
+<p><strong>Average score: {{.AverageScore}}/100</strong>{{if .HasPrevious}} ({{signed .AverageDelta}} vs previous run){{end}}</p>
+{{if .Regressions}}
+<h3>Top regressions</h3>
+<ul>
+{{range .Regressions}}<li><a href="{{.URL}}">{{if .Title}}{{.Title}}{{else}}{{.URL}}{{end}}</a>: {{.Previous}} &rarr; {{.Score}} ({{signed .Delta}})</li>
+{{end}}</ul>
+{{end}}
+<h3>All pages</h3>
+<table cellpadding="4" style="border-collapse: collapse">
+<tr><th align="left">URL</th><th>Score</th>{{if .HasPrevious}}<th>Change</th>{{end}}</tr>
+{{range .Pages}}<tr><td>{{.URL}}</td>{{if .Error}}<td colspan="2">error: {{.Error}}</td>{{else}}<td align="center">{{.Score}}</td>{{if $.HasPrevious}}<td align="center">{{if .HasPrev}}{{signed .Delta}}{{else}}new{{end}}</td>{{end}}{{end}}</tr>
+{{end}}</table>
+</body></html>`))
+
+// HTML renders the digest as an HTML email body.
+func (d *Digest) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := digestTemplate.Execute(&buf, d); err != nil {
+		return "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// SendDigest emails the digest to the given recipients.
+func SendDigest(cfg *SMTPConfig, to []string, subject string, d *Digest) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+
+	body, err := d.HTML()
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	// smtp dials on its own, outside the guarded HTTP transports
+	if err := netguard.Check(cfg.Host + ":" + cfg.Port); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := smtp.SendMail(cfg.Host+":"+cfg.Port, auth, cfg.From, to, message(cfg.From, to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// message builds an HTML email. Header values lose any line breaks, so a
+// subject or address cannot add headers, and non-ASCII text is encoded.
+func message(from string, to []string, subject, body string) []byte {
+	recipients := make([]string, len(to))
+	for i, addr := range to {
+		recipients[i] = address(addr)
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + address(from) + "\r\n")
+	msg.WriteString("To: " + strings.Join(recipients, ", ") + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("UTF-8", headerValue(subject)) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body)
+	return []byte(msg.String())
+}
+
+// address formats an address for a header, encoding a non-ASCII display
+// name.
+func address(addr string) string {
+	addr = headerValue(addr)
+	if parsed, err := mail.ParseAddress(addr); err == nil {
+		return parsed.String()
+	}
+	return addr
+}
+
+// headerValue replaces the line breaks in s with spaces.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(s)
+}
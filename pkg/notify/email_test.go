@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageHeaders(t *testing.T) {
+	msg := string(message("GEO Bot <geo@example.com>", []string{"José <jose@example.com>", "ops@example.com\r\nBcc: evil@example.com"}, "Scores for café\r\nBcc: evil@example.com", "<p>body</p>"))
+	headers, body, ok := strings.Cut(msg, "\r\n\r\n")
+	if !ok || body != "<p>body</p>" {
+		t.Fatalf("message = %q", msg)
+	}
+
+	lines := strings.Split(headers, "\r\n")
+	want := []string{"From:", "To:", "Subject:", "MIME-Version:", "Content-Type:"}
+	if len(lines) != len(want) {
+		t.Fatalf("headers = %q, want %d lines", lines, len(want))
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i]) {
+			t.Errorf("header %d = %q, want %s", i, line, want[i])
+		}
+	}
+	if subject := lines[2]; !strings.Contains(subject, "=?UTF-8?q?") || strings.Contains(subject, "é") {
+		t.Errorf("subject not encoded: %q", subject)
+	}
+	if to := lines[1]; !strings.Contains(to, "<jose@example.com>") || strings.Contains(to, "é") {
+		t.Errorf("recipient not encoded: %q", to)
+	}
+}
@@ -0,0 +1,241 @@
+// Package review keeps what people decided about the findings reported
+// for each page: accepted, rejected or done. Runs given the state file
+// carry those decisions forward, hide rejected findings and report how far
+// the work has come, for audits spread over many runs.
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/scorer"
+)
+
+// FormatVersion changes whenever the state file format does.
+const FormatVersion = 1
+
+// Status is the decision taken on a finding.
+type Status string
+
+const (
+	StatusOpen     Status = "open"     // not decided yet
+	StatusAccepted Status = "accepted" // to be done
+	StatusRejected Status = "rejected" // will not be done; hidden from reports
+	StatusDone     Status = "done"     // reported done
+)
+
+// ParseStatus parses a status name.
+func ParseStatus(s string) (Status, error) {
+	switch status := Status(strings.ToLower(s)); status {
+	case StatusOpen, StatusAccepted, StatusRejected, StatusDone:
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown review status %q (open, accepted, rejected, done)", s)
+}
+
+// Item is a finding of one page and the decision on it.
+type Item struct {
+	URL       string     `json:"url"`
+	Finding   string     `json:"finding"` // rule ID, e.g. structure.answer_first
+	Message   string     `json:"message"` // as last reported
+	Status    Status     `json:"status"`
+	Note      string     `json:"note,omitempty"`
+	Detected  bool       `json:"detected"` // reported by the latest analysis of the page
+	FirstSeen time.Time  `json:"first_seen"`
+	LastSeen  time.Time  `json:"last_seen"`
+	Updated   *time.Time `json:"updated,omitempty"` // when the status last changed
+}
+
+// State is a review state file.
+type State struct {
+	Version int    `json:"version"`
+	Items   []Item `json:"items"`
+}
+
+// Load reads the state file at path. A missing file is an empty state.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &State{Version: FormatVersion}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review state: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse review state %s: %w", path, err)
+	}
+	if s.Version != FormatVersion {
+		return nil, fmt.Errorf("review state %s has format version %d, want %d", path, s.Version, FormatVersion)
+	}
+	return &s, nil
+}
+
+// Save writes the state to path, its items sorted by URL and finding so
+// the file diffs cleanly under version control.
+func (s *State) Save(path string) error {
+	s.Version = FormatVersion
+	sort.Slice(s.Items, func(i, j int) bool {
+		if s.Items[i].URL != s.Items[j].URL {
+			return s.Items[i].URL < s.Items[j].URL
+		}
+		return s.Items[i].Finding < s.Items[j].Finding
+	})
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write review state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write review state: %w", err)
+	}
+	return nil
+}
+
+func (s *State) find(url, finding string) *Item {
+	for i := range s.Items {
+		if s.Items[i].URL == url && s.Items[i].Finding == finding {
+			return &s.Items[i]
+		}
+	}
+	return nil
+}
+
+// Set records a decision on a finding of url already in the state.
+func (s *State) Set(url, finding string, status Status, note string, at time.Time) error {
+	item := s.find(url, finding)
+	if item == nil {
+		return fmt.Errorf("no finding %s recorded for %s: analyze the page with --review-state first", finding, url)
+	}
+	item.Status = status
+	if note != "" {
+		item.Note = note
+	}
+	at = at.UTC()
+	item.Updated = &at
+	return nil
+}
+
+// Apply brings the state up to date with a new analysis of page, its URL
+// or file path: its
+// findings are added as open the first time they are reported, and
+// findings no longer reported are kept with detected unset. Rejected
+// findings are then removed from the result's findings and suggestions;
+// the score is left as computed. The progress of the page is recorded
+// under metadata.review and returned.
+func (s *State) Apply(page string, result *analyzer.Result, at time.Time) Progress {
+	at = at.UTC()
+	if result.LocalScore != nil {
+		reported := make(map[string]bool)
+		for _, f := range result.LocalScore.Findings {
+			reported[f.ID] = true
+			item := s.find(page, f.ID)
+			if item == nil {
+				s.Items = append(s.Items, Item{URL: page, Finding: f.ID, Status: StatusOpen, FirstSeen: at})
+				item = &s.Items[len(s.Items)-1]
+			}
+			item.Message, item.Detected, item.LastSeen = f.Message, true, at
+		}
+		for i := range s.Items {
+			if s.Items[i].URL == page && !reported[s.Items[i].Finding] {
+				s.Items[i].Detected = false
+			}
+		}
+		hideRejected(page, result, s)
+	}
+
+	progress := s.Progress(page)
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]any)
+	}
+	result.Metadata["review"] = progress
+	return progress
+}
+
+// hideRejected drops the findings of the result of page rejected in s,
+// and the suggestions made for them.
+func hideRejected(page string, result *analyzer.Result, s *State) {
+	rejected := make(map[string]bool)
+	var kept []scorer.Finding
+	for _, f := range result.LocalScore.Findings {
+		if item := s.find(page, f.ID); item != nil && item.Status == StatusRejected {
+			rejected[f.Message] = true
+			continue
+		}
+		kept = append(kept, f)
+	}
+	if len(rejected) == 0 {
+		return
+	}
+	score := *result.LocalScore
+	score.Findings = kept
+	score.Suggestions = withoutRejected(score.Suggestions, rejected)
+	result.LocalScore = &score
+	result.Suggestions = withoutRejected(result.Suggestions, rejected)
+}
+
+func withoutRejected(suggestions []string, rejected map[string]bool) []string {
+	var kept []string
+	for _, s := range suggestions {
+		if !rejected[s] {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// Progress counts the findings of a page, or of every page, by status.
+// A finding the latest analysis no longer reported, without being marked
+// done or rejected, counts as fixed.
+type Progress struct {
+	Total    int `json:"total"` // findings not rejected
+	Open     int `json:"open"`
+	Accepted int `json:"accepted"`
+	Done     int `json:"done"`
+	Fixed    int `json:"fixed"`
+	Rejected int `json:"rejected"`
+	Percent  int `json:"percent_complete"` // done and fixed, of Total
+
+	// StillDetected lists the findings marked done that the latest
+	// analysis reported again, as url: finding
+	StillDetected []string `json:"still_detected,omitempty"`
+}
+
+// Progress counts the findings of url, or of every page for "".
+func (s *State) Progress(url string) Progress {
+	var p Progress
+	for _, item := range s.Items {
+		if url != "" && item.URL != url {
+			continue
+		}
+		switch {
+		case item.Status == StatusRejected:
+			p.Rejected++
+			continue
+		case item.Status == StatusDone:
+			p.Done++
+			if item.Detected {
+				p.StillDetected = append(p.StillDetected, item.URL+": "+item.Finding)
+			}
+		case !item.Detected:
+			p.Fixed++
+		case item.Status == StatusAccepted:
+			p.Accepted++
+		default:
+			p.Open++
+		}
+		p.Total++
+	}
+	if p.Total > 0 {
+		p.Percent = (p.Done + p.Fixed) * 100 / p.Total
+	}
+	return p
+}
@@ -0,0 +1,93 @@
+package review
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/scorer"
+)
+
+func resultWith(url string, ids ...string) *analyzer.Result {
+	score := &scorer.GEOScore{}
+	var suggestions []string
+	for _, id := range ids {
+		score.Findings = append(score.Findings, scorer.Finding{ID: id, Message: "Fix " + id})
+		suggestions = append(suggestions, "Fix "+id)
+	}
+	score.Suggestions = suggestions
+	return &analyzer.Result{URL: url, LocalScore: score, Suggestions: suggestions}
+}
+
+func TestReviewAcrossRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.json")
+	state, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	url := "https://example.com/a"
+
+	progress := state.Apply(url, resultWith(url, "structure.headings", "schema.missing", "clarity.sentences", "authority.author"), now)
+	if progress.Total != 4 || progress.Open != 4 || progress.Percent != 0 {
+		t.Fatalf("first run progress = %+v", progress)
+	}
+	for finding, status := range map[string]Status{
+		"structure.headings": StatusRejected,
+		"schema.missing":     StatusDone,
+		"clarity.sentences":  StatusAccepted,
+		"authority.author":   StatusDone,
+	} {
+		if err := state.Set(url, finding, status, "", now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := state.Set(url, "unknown.rule", StatusDone, "", now); err == nil {
+		t.Error("decision on an unrecorded finding accepted")
+	}
+	if err := state.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next run no longer reports the schema finding, still reports the
+	// author one although it was marked done, and has a new finding
+	state, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := resultWith(url, "structure.headings", "clarity.sentences", "authority.author", "access.paywall")
+	progress = state.Apply(url, result, now.Add(24*time.Hour))
+
+	want := Progress{Total: 4, Open: 1, Accepted: 1, Done: 2, Rejected: 1, Percent: 50, StillDetected: []string{url + ": authority.author"}}
+	if !reflect.DeepEqual(progress, want) {
+		t.Errorf("progress = %+v, want %+v", progress, want)
+	}
+	for _, f := range result.LocalScore.Findings {
+		if f.ID == "structure.headings" {
+			t.Error("rejected finding still reported")
+		}
+	}
+	if !reflect.DeepEqual(result.Suggestions, []string{"Fix clarity.sentences", "Fix authority.author", "Fix access.paywall"}) {
+		t.Errorf("suggestions = %q", result.Suggestions)
+	}
+	if result.Metadata["review"] == nil {
+		t.Error("progress not recorded in metadata")
+	}
+
+	// A finding no longer reported without a decision counts as fixed
+	state.Apply(url, resultWith(url, "structure.headings", "authority.author"), now.Add(48*time.Hour))
+	if p := state.Progress(""); p.Fixed != 2 || p.Open != 0 || p.Accepted != 0 {
+		t.Errorf("progress after fixes = %+v", p)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	if s, err := ParseStatus("Rejected"); err != nil || s != StatusRejected {
+		t.Errorf("ParseStatus(Rejected) = %q, %v", s, err)
+	}
+	if _, err := ParseStatus("maybe"); err == nil {
+		t.Error("unknown status accepted")
+	}
+}
@@ -0,0 +1,257 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unowned is the owner files nobody owns are grouped under.
+const Unowned = "(unowned)"
+
+// codeownersLocations are where GitHub and GitLab look for a CODEOWNERS
+// file, relative to the repository root, in their order of precedence.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Codeowners maps repository paths to their owners with the rules of a
+// CODEOWNERS file.
+type Codeowners struct {
+	root  string // directory the patterns are relative to
+	rules []ownerRule
+}
+
+type ownerRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// FindCodeowners looks for a CODEOWNERS file in dir and its parents, up to
+// the root of the git repository dir is in. It returns nil when there is
+// none.
+func FindCodeowners(dir string) (*Codeowners, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		for _, location := range codeownersLocations {
+			path := filepath.Join(dir, filepath.FromSlash(location))
+			if _, err := os.Stat(path); err == nil {
+				return LoadCodeowners(path, dir)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadCodeowners reads the CODEOWNERS file at path, whose patterns are
+// relative to root.
+func LoadCodeowners(path, root string) (*Codeowners, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c := &Codeowners{root: root}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		// GitLab section headers, e.g. "[Docs]"
+		if strings.HasPrefix(fields[0], "[") || strings.HasPrefix(fields[0], "^[") {
+			continue
+		}
+		// A pattern without owners leaves the files it matches unowned
+		rule := ownerRule{pattern: codeownersPattern(fields[0])}
+		if len(fields) > 1 {
+			rule.owners = fields[1:]
+		}
+		c.rules = append(c.rules, rule)
+	}
+	return c, scanner.Err()
+}
+
+// codeownersPattern compiles a CODEOWNERS pattern, which follows gitignore
+// rules: patterns with a slash before their end are anchored at the root,
+// others match at any depth, and a pattern naming a directory matches
+// everything under it.
+func codeownersPattern(pattern string) *regexp.Regexp {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var re strings.Builder
+	re.WriteString("^")
+	if !anchored {
+		re.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			re.WriteString("[^/]*")
+		case pattern[i] == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	if dirOnly {
+		re.WriteString("/.*$")
+	} else {
+		re.WriteString("(?:/.*)?$")
+	}
+	return regexp.MustCompile(re.String())
+}
+
+// Owners returns the owners of the file at path: those of the last rule
+// matching it, as in CODEOWNERS. Files outside the root have none.
+func (c *Codeowners) Owners(path string) []string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	rel, err := filepath.Rel(c.root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(rel) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// frontMatterAuthors returns the authors named in the "author" or
+// "authors" field of a YAML front matter block, as a string or a list.
+func frontMatterAuthors(data string) []string {
+	lines := strings.SplitAfter(data, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil
+	}
+	var fields map[string]any
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "")), &fields); err != nil {
+		return nil
+	}
+	var authors []string
+	for _, key := range []string{"author", "authors"} {
+		switch v := fields[key].(type) {
+		case string:
+			authors = append(authors, v)
+		case []any:
+			for _, a := range v {
+				if s, ok := a.(string); ok {
+					authors = append(authors, s)
+				}
+			}
+		}
+	}
+	return authors
+}
+
+// OwnerGroup is the scanned files of one owner, worst scores first.
+type OwnerGroup struct {
+	Owner        string        `json:"owner"`
+	Files        []*ScanResult `json:"files"`
+	AverageScore int           `json:"average_score"`
+	Suggestions  int           `json:"suggestions"` // across the owner's files
+	Errors       int           `json:"errors"`
+}
+
+// GroupByOwner groups results by owner, so fixes can be routed to whoever
+// owns the files. A file with several owners is in each of their groups;
+// files without owners are grouped under Unowned, which comes last.
+// Owners are sorted by name.
+func GroupByOwner(results []*ScanResult) []*OwnerGroup {
+	groups := make(map[string]*OwnerGroup)
+	var names []string
+	for _, result := range results {
+		owners := result.Owners
+		if len(owners) == 0 {
+			owners = []string{Unowned}
+		}
+		for _, owner := range owners {
+			group, ok := groups[owner]
+			if !ok {
+				group = &OwnerGroup{Owner: owner}
+				groups[owner] = group
+				if owner != Unowned {
+					names = append(names, owner)
+				}
+			}
+			group.Files = append(group.Files, result)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[Unowned]; ok {
+		names = append(names, Unowned)
+	}
+
+	ordered := make([]*OwnerGroup, 0, len(names))
+	for _, name := range names {
+		group := groups[name]
+		total, scored := 0, 0
+		for _, file := range group.Files {
+			if file.Error != nil || file.Result == nil {
+				group.Errors++
+				continue
+			}
+			total += file.Result.Score
+			scored++
+			group.Suggestions += len(file.Result.Suggestions)
+		}
+		if scored > 0 {
+			group.AverageScore = total / scored
+		}
+		sort.SliceStable(group.Files, func(i, j int) bool {
+			return fileScore(group.Files[i]) < fileScore(group.Files[j])
+		})
+		ordered = append(ordered, group)
+	}
+	return ordered
+}
+
+// fileScore orders failed files before every scored one.
+func fileScore(result *ScanResult) int {
+	if result.Error != nil || result.Result == nil {
+		return -1
+	}
+	return result.Result.Score
+}
@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"geo-checker/pkg/analyzer"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCodeowners(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	codeowners := `# Default owners
+*            @org/web
+*.md         @org/writers
+/docs/api/   @org/api-team @alice  # API reference
+guides/**/setup.html @bob
+/docs/legacy/
+`
+	if err := os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte(codeowners), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := FindCodeowners(filepath.Join(root, "docs", "api"))
+	if err != nil || c == nil {
+		t.Fatalf("FindCodeowners = %v, %v", c, err)
+	}
+	tests := map[string][]string{
+		"index.html":                  {"@org/web"},
+		"blog/post.md":                {"@org/writers"},
+		"docs/api/v2/auth.html":       {"@org/api-team", "@alice"},
+		"other/docs/api/auth.html":    {"@org/web"},
+		"guides/linux/arm/setup.html": {"@bob"},
+		"docs/legacy/old.html":        nil,
+	}
+	for path, want := range tests {
+		if got := c.Owners(filepath.Join(root, filepath.FromSlash(path))); !reflect.DeepEqual(got, want) {
+			t.Errorf("Owners(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestFrontMatterAuthors(t *testing.T) {
+	if got := frontMatterAuthors("---\ntitle: Setup\nauthors:\n  - alice\n  - bob\n---\n# Setup\n"); !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+		t.Errorf("authors list = %v", got)
+	}
+	if got := frontMatterAuthors("---\nauthor: carol\n---\n"); !reflect.DeepEqual(got, []string{"carol"}) {
+		t.Errorf("author = %v", got)
+	}
+	if got := frontMatterAuthors("<html><body>author: dave</body></html>"); got != nil {
+		t.Errorf("authors without front matter = %v", got)
+	}
+}
+
+func TestGroupByOwner(t *testing.T) {
+	scored := func(path string, score, suggestions int, owners ...string) *ScanResult {
+		return &ScanResult{FilePath: path, Owners: owners, Result: &analyzer.Result{Score: score, Suggestions: make([]string, suggestions)}}
+	}
+	results := []*ScanResult{
+		scored("a.html", 80, 1, "@web"),
+		scored("b.html", 40, 3, "@web", "@api"),
+		scored("c.html", 60, 2),
+		{FilePath: "d.html", Owners: []string{"@api"}, Error: &analyzer.Error{Message: "unreadable"}},
+	}
+
+	groups := GroupByOwner(results)
+	var owners []string
+	for _, g := range groups {
+		owners = append(owners, g.Owner)
+	}
+	if want := []string{"@api", "@web", Unowned}; !reflect.DeepEqual(owners, want) {
+		t.Fatalf("owners = %v, want %v", owners, want)
+	}
+	api, web := groups[0], groups[1]
+	if api.Files[0].FilePath != "d.html" || api.Errors != 1 || api.AverageScore != 40 {
+		t.Errorf("api group = %+v", api)
+	}
+	if web.Files[0].FilePath != "b.html" || web.AverageScore != 60 || web.Suggestions != 4 {
+		t.Errorf("web group = %+v", web)
+	}
+}
@@ -1,9 +1,13 @@
 package scanner
 
 import (
+	"context"
 	"fmt"
+	"geo-checker/internal/webpage"
 	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/annotate"
 	"geo-checker/pkg/config"
+	"geo-checker/pkg/source"
 	"geo-checker/pkg/ui"
 	"io/fs"
 	"os"
@@ -12,30 +16,44 @@ import (
 )
 
 type Scanner struct {
-	config   *config.Config
-	analyzer *analyzer.Analyzer
-	ui       *ui.UI
+	config     *config.Config
+	analyzer   *analyzer.Analyzer
+	scraper    *webpage.Scraper
+	ui         *ui.UI
+	codeowners *Codeowners // nil without a CODEOWNERS file
 }
 
 type ScanResult struct {
 	FilePath string           `json:"file_path"`
 	Result   *analyzer.Result `json:"result,omitempty"`
-	Error    string           `json:"error,omitempty"`
+	Error    *analyzer.Error  `json:"error,omitempty"`
+	
+	// Locations places the findings in the file, for editor diagnostics;
+	// only computed for vscode output
+	Locations []annotate.Location `json:"locations,omitempty"`
+	
+	// Owners are who the file is routed to: its CODEOWNERS owners, or the
+	// authors in its front matter; only looked up when grouping by owner
+	Owners []string `json:"owners,omitempty"`
 }
 
 func New(cfg *config.Config) *Scanner {
 	return &Scanner{
 		config:   cfg,
 		analyzer: analyzer.New(cfg),
+		scraper:  webpage.New(),
 		ui:       ui.New(),
 	}
 }
 
-func (s *Scanner) ScanDirectory(dirPath string) ([]*ScanResult, error) {
+// ScanDirectory analyzes every matching file under dirPath. Cancelling ctx
+// stops the scan between files.
+func (s *Scanner) ScanDirectory(ctx context.Context, dirPath string) ([]*ScanResult, error) {
 	var results []*ScanResult
 	var filesToScan []string
 	
-	showProgress := s.config.OutputFormat != "json"
+	// Progress would corrupt machine-readable output
+	showProgress := s.config.OutputFormat != "json" && s.config.OutputFormat != "vscode"
 	
 	if showProgress {
 		s.ui.StartSpinner("Discovering files...")
@@ -46,6 +64,9 @@ func (s *Scanner) ScanDirectory(dirPath string) ([]*ScanResult, error) {
 		if err != nil {
 			return err
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		
 		if d.IsDir() {
 			return nil
@@ -77,9 +98,21 @@ func (s *Scanner) ScanDirectory(dirPath string) ([]*ScanResult, error) {
 		return results, nil
 	}
 	
+	if s.config.GroupByOwner {
+		if s.codeowners, err = FindCodeowners(dirPath); err != nil {
+			return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+		}
+		if s.codeowners == nil && showProgress {
+			s.ui.PrintWarning("No CODEOWNERS file found; files are grouped by their front matter authors")
+		}
+	}
+	
 	// Second pass: analyze files
 	for _, path := range filesToScan {
-		result := s.scanFile(path)
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result := s.scanFile(ctx, path)
 		results = append(results, result)
 	}
 	
@@ -89,7 +122,7 @@ func (s *Scanner) ScanDirectory(dirPath string) ([]*ScanResult, error) {
 		totalScore := 0
 		
 		for _, result := range results {
-			if result.Error != "" {
+			if result.Error != nil {
 				errorCount++
 			} else if result.Result != nil {
 				successCount++
@@ -112,23 +145,48 @@ func (s *Scanner) ScanDirectory(dirPath string) ([]*ScanResult, error) {
 	return results, nil
 }
 
-func (s *Scanner) scanFile(filePath string) *ScanResult {
+func (s *Scanner) scanFile(ctx context.Context, filePath string) *ScanResult {
 	result := &ScanResult{FilePath: filePath}
 	
-	content, err := s.readHTMLFile(filePath)
+	data, err := os.ReadFile(filePath)
 	if err != nil {
-		result.Error = fmt.Sprintf("failed to read file: %v", err)
+		result.Error = analyzer.NewError(analyzer.CategoryExtract, fmt.Errorf("failed to read file: %w", err))
 		return result
 	}
+	if s.config.GroupByOwner {
+		if s.codeowners != nil {
+			result.Owners = s.codeowners.Owners(filePath)
+		}
+		if len(result.Owners) == 0 {
+			result.Owners = frontMatterAuthors(string(data))
+		}
+	}
 	
-	title := s.extractTitleFromPath(filePath)
-	analysisResult, err := s.analyzer.AnalyzeContent(content, title)
+	// Files are extracted and scored the way the pages they are published
+	// as would be, headings, links and structured data included
+	markdown := annotate.IsMarkdown(filePath)
+	html := string(data)
+	if markdown {
+		html = source.MarkdownPage(html)
+	}
+	page, err := s.scraper.ParseHTML(html, "")
+	if err != nil {
+		result.Error = analyzer.NewError(analyzer.CategoryExtract, err)
+		return result
+	}
+	if page.Title == "" {
+		page.Title = s.extractTitleFromPath(filePath)
+	}
+	analysisResult, err := s.analyzer.AnalyzePage(ctx, page, filePath)
 	if err != nil {
-		result.Error = fmt.Sprintf("failed to analyze content: %v", err)
+		result.Error = analyzer.Classify(fmt.Errorf("failed to analyze content: %w", err))
 		return result
 	}
 	
 	result.Result = analysisResult
+	if s.config.OutputFormat == "vscode" && analysisResult.LocalScore != nil {
+		result.Locations = annotate.Locate(string(data), markdown, analysisResult.LocalScore.Findings)
+	}
 	return result
 }
 
@@ -144,95 +202,8 @@ func (s *Scanner) shouldScanFile(filePath string) bool {
 	return false
 }
 
-func (s *Scanner) readHTMLFile(filePath string) (string, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", err
-	}
-	
-	// For HTML files, we might want to extract just the text content
-	content := string(data)
-	
-	// Basic HTML content extraction (could be enhanced)
-	content = s.extractTextFromHTML(content)
-	
-	return content, nil
-}
-
-func (s *Scanner) extractTextFromHTML(html string) string {
-	// Simple text extraction - remove common HTML tags
-	// This is a basic implementation; for better results, we could use goquery
-	
-	// Remove script and style content
-	html = removeTagContent(html, "script")
-	html = removeTagContent(html, "style")
-	
-	// Remove HTML tags but keep content
-	html = removeTags(html)
-	
-	// Clean up whitespace
-	lines := strings.Split(html, "\n")
-	var cleanLines []string
-	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			cleanLines = append(cleanLines, line)
-		}
-	}
-	
-	return strings.Join(cleanLines, "\n")
-}
-
 func (s *Scanner) extractTitleFromPath(filePath string) string {
 	base := filepath.Base(filePath)
 	ext := filepath.Ext(base)
 	return strings.TrimSuffix(base, ext)
 }
-
-func removeTagContent(html, tag string) string {
-	startTag := fmt.Sprintf("<%s", tag)
-	endTag := fmt.Sprintf("</%s>", tag)
-	
-	for {
-		start := strings.Index(strings.ToLower(html), strings.ToLower(startTag))
-		if start == -1 {
-			break
-		}
-		
-		// Find the end of the opening tag
-		tagEnd := strings.Index(html[start:], ">")
-		if tagEnd == -1 {
-			break
-		}
-		tagEnd += start + 1
-		
-		// Find the closing tag
-		end := strings.Index(strings.ToLower(html[tagEnd:]), strings.ToLower(endTag))
-		if end == -1 {
-			break
-		}
-		end += tagEnd + len(endTag)
-		
-		html = html[:start] + html[end:]
-	}
-	
-	return html
-}
-
-func removeTags(html string) string {
-	inTag := false
-	var result strings.Builder
-	
-	for _, char := range html {
-		if char == '<' {
-			inTag = true
-		} else if char == '>' {
-			inTag = false
-		} else if !inTag {
-			result.WriteRune(char)
-		}
-	}
-	
-	return result.String()
-}
\ No newline at end of file
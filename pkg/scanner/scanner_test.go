@@ -0,0 +1,57 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"geo-checker/pkg/config"
+)
+
+func TestScanFileParsesThePage(t *testing.T) {
+	long := strings.Repeat("Widgets are small mechanical parts that hold larger assemblies together. ", 25)
+	page := `<html>
+<head><title>Widget guide</title><script>var tracking = "do not score this";</script></head>
+<body>
+<h1>Widgets</h1>
+<h2>What widgets are</h2>
+<p>` + long + `</p>
+<h2>Choosing a widget</h2>
+<p>Pick the widget that fits the load.</p>
+</body>
+</html>
+`
+	path := filepath.Join(t.TempDir(), "guide.html")
+	if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(&config.Config{Mode: "local", OutputFormat: "vscode", Extensions: []string{".html"}})
+	result := s.scanFile(context.Background(), path)
+	if result.Error != nil {
+		t.Fatal(result.Error)
+	}
+	if result.Result.Title != "Widget guide" {
+		t.Errorf("title = %q, want the page's <title>", result.Result.Title)
+	}
+	for _, f := range result.Result.LocalScore.Findings {
+		if f.ID == "structure.headings" {
+			t.Errorf("the page's H1 and H2 headings were not seen: %s", f.Message)
+		}
+	}
+
+	placed := false
+	for _, loc := range result.Locations {
+		if loc.Finding.ID == "structure.paragraphs" {
+			placed = true
+			if loc.Line != 6 {
+				t.Errorf("long paragraph placed on line %d, want 6", loc.Line)
+			}
+		}
+	}
+	if !placed {
+		t.Errorf("the long paragraph finding was not placed: %+v", result.Locations)
+	}
+}
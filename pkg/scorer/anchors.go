@@ -0,0 +1,121 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// AnchorSuggestion is a section heading without a stable anchor, with the
+// slug to give it.
+type AnchorSuggestion struct {
+	Heading   string `json:"heading"`
+	Level     int    `json:"level"`
+	ID        string `json:"id,omitempty"` // the unstable or duplicate id it has
+	Suggested string `json:"suggested"`
+}
+
+const (
+	// anchorsMaxPoints is what the anchor rule contributes to the
+	// Accessibility pillar.
+	anchorsMaxPoints = 10
+
+	// minAnchoredSections is the number of H2 and H3 headings from which a
+	// page is long enough to deep-link into.
+	minAnchoredSections = 2
+
+	// maxSlugLength caps suggested slugs.
+	maxSlugLength = 60
+)
+
+// generatedID matches ids a CMS or framework generates per render, which
+// break links as soon as the page is rebuilt: bare numbers, numbered
+// "h-3" or "section-12" ids, hashes and React ids.
+var generatedID = regexp.MustCompile(`(?i)^(\d+|(h|heading|section|title|toc|ember|id|anchor)[-_]?\d+|.*[0-9a-f]{8,}.*|:r[0-9a-z]+:)$`)
+
+// slugify turns a heading into a URL fragment: lower case letters and
+// digits joined by hyphens.
+func slugify(text string) string {
+	var sb strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	slug := sb.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if cut := strings.LastIndex(slug, "-"); cut > 0 {
+			slug = slug[:cut]
+		}
+	}
+	return slug
+}
+
+// anchorSuggestions returns the H2 and H3 headings of the page without a
+// stable, unique id, with slugs that clash neither with the ids the page
+// has nor with each other, and the number of such headings checked.
+func anchorSuggestions(headings []webpage.Heading) ([]AnchorSuggestion, int) {
+	used := make(map[string]int)
+	for _, h := range headings {
+		if h.ID != "" {
+			used[h.ID]++
+		}
+	}
+
+	var suggestions []AnchorSuggestion
+	sections := 0
+	for _, h := range headings {
+		if h.Level != 2 && h.Level != 3 {
+			continue
+		}
+		sections++
+		if h.ID != "" && used[h.ID] == 1 && !generatedID.MatchString(h.ID) {
+			continue
+		}
+		slug := slugify(h.Text)
+		if slug == "" {
+			slug = "section"
+		}
+		base := slug
+		for n := 2; used[slug] > 0; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[slug]++
+		suggestions = append(suggestions, AnchorSuggestion{Heading: h.Text, Level: h.Level, ID: h.ID, Suggested: slug})
+	}
+	return suggestions, sections
+}
+
+// evaluateHeadingAnchors scores deep-linkable sections (10 points, in
+// proportion to the H2 and H3 headings with a stable, unique id). Pages
+// with fewer than minAnchoredSections sections get full points. The
+// headings lacking one are returned as evidence with their suggested id.
+func evaluateHeadingAnchors(doc *document, suggestions []AnchorSuggestion, sections int) (int, string, []Evidence) {
+	if sections < minAnchoredSections || len(suggestions) == 0 {
+		return anchorsMaxPoints, "", nil
+	}
+
+	var evidence []Evidence
+	for _, s := range suggestions[:min(len(suggestions), maxEvidence)] {
+		ev := missingEvidence("%s → id=%q", s.Heading, s.Suggested)
+		for _, h := range doc.headings {
+			if h.text == s.Heading {
+				ev.Start, ev.End, ev.HeadingPath = h.offset, h.offset+len(h.text), doc.headingPath(h.offset)
+				break
+			}
+		}
+		evidence = append(evidence, ev)
+	}
+	score := anchorsMaxPoints * (sections - len(suggestions)) / sections
+	return score, fmt.Sprintf("Give section headings stable id anchors so answers can link to them (%d of %d lack one)", len(suggestions), sections), evidence
+}
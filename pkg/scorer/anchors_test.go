@@ -0,0 +1,48 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	for in, want := range map[string]string{
+		"Getting Started":            "getting-started",
+		"What's new in v2.0?":        "what-s-new-in-v2-0",
+		"  Café & crème brûlée  ":    "café-crème-brûlée",
+		"C++ / Rust: a comparison ✓": "c-rust-a-comparison",
+	} {
+		if got := slugify(in); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestHeadingAnchors(t *testing.T) {
+	headings := []webpage.Heading{
+		{Level: 1, Text: "Guide"},
+		{Level: 2, Text: "Install", ID: "install"},
+		{Level: 2, Text: "Configure", ID: "h-2"},
+		{Level: 3, Text: "Install"},
+		{Level: 2, Text: "FAQ", ID: "faq"},
+		{Level: 2, Text: "More FAQ", ID: "faq"},
+	}
+	suggestions, sections := anchorSuggestions(headings)
+	if sections != 5 {
+		t.Errorf("sections = %d, want 5", sections)
+	}
+	want := []AnchorSuggestion{
+		{Heading: "Configure", Level: 2, ID: "h-2", Suggested: "configure"},
+		{Heading: "Install", Level: 3, Suggested: "install-2"},
+		{Heading: "FAQ", Level: 2, ID: "faq", Suggested: "faq-2"},
+		{Heading: "More FAQ", Level: 2, ID: "faq", Suggested: "more-faq"},
+	}
+	if len(suggestions) != len(want) {
+		t.Fatalf("suggestions = %+v, want %+v", suggestions, want)
+	}
+	for i := range want {
+		if suggestions[i] != want[i] {
+			t.Errorf("suggestion %d = %+v, want %+v", i, suggestions[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AnswerAdvice is the answer-first check: whether the opening after the H1
+// answers the page's question directly, as answer engines quote openings
+// far more often than anything below them.
+type AnswerAdvice struct {
+	Question   string   `json:"question"` // the H1, or the title without one
+	Answered   bool     `json:"answered"` // within the first answerWindow words
+	Lead       bool     `json:"lead"`     // in the first paragraph
+	Suggestion string   `json:"suggestion,omitempty"`
+	Evidence   Evidence `json:"evidence"`        // the direct answer, or the opening lacking one
+	Draft      string   `json:"draft,omitempty"` // answer paragraph to open with
+}
+
+const (
+	// answerFirstMaxPoints is what the answer-first rule contributes to the
+	// Content Structure pillar.
+	answerFirstMaxPoints = 10
+
+	// answerWindow is the number of words after the H1 the answer must
+	// appear within.
+	answerWindow = 100
+)
+
+var (
+	// answerVerbPattern matches the verbs that make a sentence state
+	// something about its subject.
+	answerVerbPattern = regexp.MustCompile(`(?i)\b(is|are|was|were|means|refers to|describes|lets|allows|helps|costs|takes|requires|depends on|works by|uses|consists of|should|can|must)\b`)
+
+	// hookPattern matches openings that announce the page instead of
+	// answering.
+	hookPattern = regexp.MustCompile(`(?i)^(in this (article|post|guide|tutorial)|this (article|post|guide)|welcome|have you ever|ever wondered|are you|if you('ve| have) ever|imagine|picture this|let'?s|let us|we('ll| will)|today,? we|read on)\b`)
+)
+
+// questionWords are left out of the topic of a question.
+var questionWords = map[string]bool{
+	"what": true, "which": true, "when": true, "where": true, "does": true,
+	"your": true, "with": true, "from": true, "that": true, "this": true,
+	"guide": true, "best": true, "complete": true, "ultimate": true, "about": true,
+}
+
+// topicTerms returns the words of question a direct answer should mention.
+func topicTerms(question string) []string {
+	var terms []string
+	for _, w := range strings.Fields(strings.ToLower(question)) {
+		w = strings.Trim(w, ".,:;?!()\"'")
+		if len(w) >= 4 && !questionWords[w] {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// isDirectAnswer reports whether sentence states something about the
+// topic: a declarative sentence of 6 to 45 words with a verb of statement
+// or a figure, mentioning one of the terms, that is not a hook.
+func isDirectAnswer(sentence string, terms []string) bool {
+	words := depthWords(sentence)
+	if words < 6 || words > 45 || strings.HasSuffix(sentence, "?") || hookPattern.MatchString(sentence) {
+		return false
+	}
+	if !answerVerbPattern.MatchString(sentence) && !hasFigure.MatchString(sentence) {
+		return false
+	}
+	if len(terms) == 0 {
+		return true
+	}
+	lower := strings.ToLower(sentence)
+	for _, term := range terms {
+		if strings.Contains(lower, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// answerFirst checks whether a direct answer opens the page. When none
+// appears within answerWindow words of the H1, the first direct answer
+// further down, with the sentence following it, is drafted as the opening.
+func (d *document) answerFirst() *AnswerAdvice {
+	question, start := d.page.Title, 0
+	for _, h := range d.headings {
+		if h.level == 1 {
+			question, start = h.text, h.offset+len(h.text)
+			break
+		}
+	}
+	question = strings.TrimSpace(question)
+	terms := topicTerms(question)
+
+	var sentences []span
+	for _, s := range d.sentences() {
+		if s.start >= start && !d.isHeading(s) {
+			sentences = append(sentences, s)
+		}
+	}
+	if len(sentences) == 0 {
+		return nil
+	}
+
+	words, opening := 0, sentences[0]
+	firstParagraph := strings.Index(d.content[sentences[0].start:], "\n\n")
+	for i, s := range sentences {
+		if words >= answerWindow {
+			return d.draftAnswer(question, terms, sentences[i:], opening)
+		}
+		if isDirectAnswer(d.content[s.start:s.end], terms) {
+			advice := &AnswerAdvice{Question: question, Answered: true, Lead: true, Evidence: d.evidence(s)}
+			if firstParagraph >= 0 && s.start > sentences[0].start+firstParagraph {
+				advice.Lead = false
+				advice.Suggestion = "Move the direct answer up into the first paragraph after the H1"
+				advice.Draft = d.content[s.start:s.end]
+			}
+			return advice
+		}
+		words += wordCount(d.content, s)
+		opening.end = s.end
+	}
+	return d.draftAnswer(question, terms, nil, opening)
+}
+
+// draftAnswer reports a missing answer and drafts one from the first
+// direct answer among rest.
+func (d *document) draftAnswer(question string, terms []string, rest []span, opening span) *AnswerAdvice {
+	advice := &AnswerAdvice{
+		Question:   question,
+		Suggestion: fmt.Sprintf("Answer %q directly within the first %d words after the H1", question, answerWindow),
+		Evidence:   d.evidence(opening),
+	}
+	for i, s := range rest {
+		if !isDirectAnswer(d.content[s.start:s.end], terms) {
+			continue
+		}
+		draft := d.content[s.start:s.end]
+		// Keep the next sentence when it continues the same paragraph
+		if i+1 < len(rest) && !strings.Contains(d.content[s.end:rest[i+1].start], "\n\n") && wordCount(d.content, rest[i+1]) <= 40 {
+			draft += " " + d.content[rest[i+1].start:rest[i+1].end]
+		}
+		advice.Draft = strings.Join(strings.Fields(draft), " ")
+		break
+	}
+	return advice
+}
+
+// evaluateAnswerFirst scores the answer-first check (10 points): 10 for a
+// direct answer in the first paragraph after the H1, 6 for one further
+// down the first 100 words and 2 without one.
+func evaluateAnswerFirst(advice *AnswerAdvice) int {
+	switch {
+	case advice == nil || advice.Lead:
+		return answerFirstMaxPoints
+	case advice.Answered:
+		return 6
+	}
+	return 2
+}
@@ -0,0 +1,45 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestAnswerFirst(t *testing.T) {
+	page := func(content string) *webpage.PageData {
+		return &webpage.PageData{
+			Content:  content,
+			MetaTags: map[string]string{},
+			Headings: []webpage.Heading{{Level: 1, Text: "What is a sourdough starter?"}},
+		}
+	}
+
+	direct := "What is a sourdough starter?\n\nA sourdough starter is a fermented mix of flour and water that leavens bread.\n\nIt takes about a week to establish."
+	score := NewLocalScorer().AnalyzeContent(direct, page(direct))
+	if a := score.AnswerFirst; a == nil || !a.Lead || !strings.HasPrefix(a.Evidence.Snippet, "A sourdough starter is") {
+		t.Fatalf("answer = %+v, want the opening sentence", a)
+	}
+
+	filler := strings.Repeat("Baking is a joy we have shared with readers for years and years now. ", 8)
+	buried := "What is a sourdough starter?\n\nIn this article we look at bread.\n\n" + filler +
+		"\n\nA sourdough starter is a fermented mix of flour and water. It keeps for years when fed weekly."
+	score = NewLocalScorer().AnalyzeContent(buried, page(buried))
+	a := score.AnswerFirst
+	if a == nil || a.Answered {
+		t.Fatalf("answer = %+v, want none in the opening", a)
+	}
+	if want := "A sourdough starter is a fermented mix of flour and water. It keeps for years when fed weekly."; a.Draft != want {
+		t.Errorf("draft = %q, want %q", a.Draft, want)
+	}
+	if !strings.HasPrefix(a.Evidence.Snippet, "In this article") {
+		t.Errorf("evidence = %q, want the opening", a.Evidence.Snippet)
+	}
+	found := false
+	for _, f := range score.Findings {
+		found = found || f.ID == "structure.answer_first"
+	}
+	if !found {
+		t.Error("missing structure.answer_first finding")
+	}
+}
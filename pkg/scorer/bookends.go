@@ -0,0 +1,222 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SectionAdvice is the check of an introduction or a conclusion: whether
+// the page has one, and a draft when it does not. An introduction should
+// also say what the page covers, so readers and answer engines know its
+// scope before the first section.
+type SectionAdvice struct {
+	Found      bool      `json:"found"`
+	Scoped     bool      `json:"scoped,omitempty"` // the introduction states what the page covers
+	Suggestion string    `json:"suggestion,omitempty"`
+	Evidence   *Evidence `json:"evidence,omitempty"` // the section found
+	Draft      string    `json:"draft,omitempty"`    // Markdown ready to paste
+}
+
+const (
+	// introMaxPoints and conclusionMaxPoints are what the introduction and
+	// conclusion rules contribute to the Context Richness pillar.
+	introMaxPoints      = 5
+	conclusionMaxPoints = 5
+
+	// minIntroWords is the length of prose before the first section that
+	// makes an introduction.
+	minIntroWords = 25
+)
+
+var (
+	// scopePattern matches sentences announcing what a page covers.
+	scopePattern = regexp.MustCompile(`(?i)\b(this (article|guide|post|page|tutorial)|here,? (we|you)|below,? (we|you)|you('ll| will) (learn|find|see)|we('ll| will) (cover|explain|look at|walk)|covers|explains|walks you through|compares|looks at|we cover|we explain)\b`)
+
+	// conclusionHeadingPattern matches the headings of closing sections.
+	conclusionHeadingPattern = regexp.MustCompile(`(?i)^(conclusions?|in conclusion|summary|in summary|final (thoughts|words|verdict)|closing thoughts|wrapping up|wrap-up|recap|the verdict|verdict|(the )?bottom line|next steps|what's next)\b`)
+
+	// closingPattern matches paragraphs that open a conclusion without a
+	// heading.
+	closingPattern = regexp.MustCompile(`(?i)^(in (conclusion|summary|short|closing)|to (sum up|summarize|conclude|wrap up)|overall,|all in all|ultimately,|the bottom line)`)
+)
+
+// sectionHeadings returns the H2 headings of the page.
+func (d *document) sectionHeadings() []headingPos {
+	var sections []headingPos
+	for _, h := range d.headings {
+		if h.level == 2 {
+			sections = append(sections, h)
+		}
+	}
+	return sections
+}
+
+// introduction checks the prose between the H1 and the first H2. Pages
+// without sections are all introduction and get nil.
+func (d *document) introduction() *SectionAdvice {
+	sections := d.sectionHeadings()
+	if len(sections) == 0 {
+		return nil
+	}
+	start := 0
+	for _, h := range d.headings {
+		if h.level == 1 && h.offset < sections[0].offset {
+			start = h.offset + len(h.text)
+			break
+		}
+	}
+
+	advice := &SectionAdvice{}
+	var intro []span
+	words := 0
+	for _, p := range d.paragraphs() {
+		if p.start >= start && p.end <= sections[0].offset && !d.isHeading(p) {
+			intro = append(intro, p)
+			words += wordCount(d.content, p)
+		}
+	}
+	if words >= minIntroWords {
+		advice.Found = true
+		ev := d.evidence(span{intro[0].start, intro[len(intro)-1].end})
+		advice.Evidence = &ev
+		text := d.content[intro[0].start:intro[len(intro)-1].end]
+		advice.Scoped = scopePattern.MatchString(text) || previewed(text, sections) >= 2
+		if advice.Scoped {
+			return advice
+		}
+		advice.Suggestion = "Say in the introduction what the page covers"
+	} else {
+		advice.Suggestion = "Open with an introduction that says what the page covers before the first section"
+	}
+	advice.Draft = scopeSentence(sections)
+	return advice
+}
+
+// previewed returns the number of sections text mentions the topic of.
+func previewed(text string, sections []headingPos) int {
+	lower := strings.ToLower(text)
+	count := 0
+	for _, h := range sections {
+		for _, term := range topicTerms(h.text) {
+			if strings.Contains(lower, term) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// scopeSentence drafts a sentence announcing the sections of the page.
+func scopeSentence(sections []headingPos) string {
+	var topics []string
+	for _, h := range sections {
+		if conclusionHeadingPattern.MatchString(h.text) || summaryPattern.MatchString(h.text) {
+			continue
+		}
+		topics = append(topics, lowerFirst(strings.TrimRight(h.text, ".:?!")))
+	}
+	switch len(topics) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("This guide covers %s.", topics[0])
+	}
+	return fmt.Sprintf("This guide covers %s and %s.", strings.Join(topics[:len(topics)-1], ", "), topics[len(topics)-1])
+}
+
+// lowerFirst lower-cases the first letter of a heading unless it starts
+// an acronym or a name in capitals ("API", "GEO").
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	next, _ := utf8.DecodeRuneInString(s[size:])
+	if unicode.IsUpper(next) {
+		return s
+	}
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+// conclusion looks for a closing section: a heading such as "Conclusion"
+// or "Next steps" in the last two thirds of the page, or a closing
+// paragraph ("In summary, …") in its last section. Pages shorter than
+// minSummaryWords or with fewer than two sections need none and get nil.
+func (d *document) conclusion() *SectionAdvice {
+	sections := d.sectionHeadings()
+	if len(sections) < 2 || depthWords(d.content) < minSummaryWords {
+		return nil
+	}
+
+	advice := &SectionAdvice{}
+	for _, h := range d.headings {
+		if h.level >= 2 && h.offset > len(d.content)/3 && conclusionHeadingPattern.MatchString(h.text) {
+			ev := d.evidence(span{h.offset, h.offset + len(h.text)})
+			advice.Found, advice.Evidence = true, &ev
+			return advice
+		}
+	}
+	last := d.headings[len(d.headings)-1]
+	for _, p := range d.paragraphs() {
+		if p.start > last.offset && closingPattern.MatchString(d.content[p.start:p.end]) {
+			ev := d.evidence(p)
+			advice.Found, advice.Evidence = true, &ev
+			return advice
+		}
+	}
+
+	advice.Suggestion = "End with a conclusion that sums up the page and says what to do next"
+	// Draft it from the sentences opening the first sections
+	var topics []string
+	prose := d.proseSentences()
+	for i, h := range sections {
+		end := len(d.content)
+		if i+1 < len(sections) {
+			end = sections[i+1].offset
+		}
+		for _, s := range prose {
+			if s.start > h.offset && s.end <= end {
+				topics = append(topics, strings.Join(strings.Fields(d.content[s.start:s.end]), " "))
+				break
+			}
+		}
+		if len(topics) == 3 {
+			break
+		}
+	}
+	if len(topics) > 0 {
+		advice.Draft = "## Conclusion\n\n" + strings.Join(topics, " ")
+	}
+	return advice
+}
+
+// evaluateIntroduction scores the introduction (5 points): 5 for one
+// stating the page's scope or a page without sections, 3 for one that
+// does not and 1 without one.
+func evaluateIntroduction(advice *SectionAdvice) int {
+	switch {
+	case advice == nil || advice.Scoped:
+		return introMaxPoints
+	case advice.Found:
+		return 3
+	}
+	return 1
+}
+
+// evaluateConclusion scores the conclusion (5 points): 5 for one or a
+// page too short to need one and 1 without one.
+func evaluateConclusion(advice *SectionAdvice) int {
+	if advice == nil || advice.Found {
+		return conclusionMaxPoints
+	}
+	return 1
+}
+
+// sectionEvidence returns the evidence of a section check, if any.
+func sectionEvidence(advice *SectionAdvice) []Evidence {
+	if advice.Evidence == nil {
+		return nil
+	}
+	return []Evidence{*advice.Evidence}
+}
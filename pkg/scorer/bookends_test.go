@@ -0,0 +1,66 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func bookendPage(intro string, sections []string, body string) (string, *webpage.PageData) {
+	content := "Sourdough basics\n\n" + intro
+	headings := []webpage.Heading{{Level: 1, Text: "Sourdough basics"}}
+	for _, section := range sections {
+		content += section + "\n\n" + body + "\n\n"
+		headings = append(headings, webpage.Heading{Level: 2, Text: section})
+	}
+	return content, &webpage.PageData{Content: content, MetaTags: map[string]string{}, Headings: headings}
+}
+
+func TestIntroductionAndConclusion(t *testing.T) {
+	body := strings.Repeat("Good bread rewards patience more than any single trick or tool. ", 10)
+	sections := []string{"Feeding the starter", "Shaping the dough", "Baking"}
+
+	// Straight into the first section, and no conclusion
+	content, page := bookendPage("", sections, body)
+	score := NewLocalScorer().AnalyzeContent(content, page)
+	if score.Intro == nil || score.Intro.Found || score.Intro.Draft != "This guide covers feeding the starter, shaping the dough and baking." {
+		t.Errorf("intro = %+v, want a missing introduction with a scope draft", score.Intro)
+	}
+	if score.Conclusion == nil || score.Conclusion.Found || !strings.HasPrefix(score.Conclusion.Draft, "## Conclusion\n\nGood bread rewards patience") {
+		t.Errorf("conclusion = %+v, want a missing conclusion with a draft", score.Conclusion)
+	}
+	if !hasFinding(score, "richness.intro") || !hasFinding(score, "richness.conclusion") {
+		t.Errorf("findings = %v, want richness.intro and richness.conclusion", score.Findings)
+	}
+
+	// An introduction without its scope
+	intro := "Sourdough is bread leavened by wild yeast and lactic bacteria instead of commercial yeast, which gives it a mild sour taste and a longer shelf life.\n\n"
+	content, page = bookendPage(intro, append(sections, "Conclusion"), body)
+	score = NewLocalScorer().AnalyzeContent(content, page)
+	if !score.Intro.Found || score.Intro.Scoped || evaluateIntroduction(score.Intro) != 3 {
+		t.Errorf("intro = %+v, want an unscoped introduction", score.Intro)
+	}
+	if !score.Conclusion.Found || hasFinding(score, "richness.conclusion") {
+		t.Errorf("conclusion = %+v, want the Conclusion section found", score.Conclusion)
+	}
+
+	// Previewing the sections states the scope
+	intro = "Sourdough is bread leavened by wild yeast. Here is how to keep the starter alive, how to handle shaping and what matters when baking it.\n\n"
+	content, page = bookendPage(intro, sections, body+"\n\nIn summary, give the dough time.")
+	score = NewLocalScorer().AnalyzeContent(content, page)
+	if !score.Intro.Scoped || hasFinding(score, "richness.intro") {
+		t.Errorf("intro = %+v, want a scoped introduction", score.Intro)
+	}
+	if !score.Conclusion.Found || !strings.HasPrefix(score.Conclusion.Evidence.Snippet, "In summary") {
+		t.Errorf("conclusion = %+v, want the closing paragraph found", score.Conclusion)
+	}
+}
+
+func hasFinding(score *GEOScore, id string) bool {
+	for _, f := range score.Findings {
+		if f.ID == id {
+			return true
+		}
+	}
+	return false
+}
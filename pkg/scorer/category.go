@@ -0,0 +1,180 @@
+package scorer
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// genericAnchors are link texts that say nothing about the target.
+var genericAnchors = []string{
+	"click here", "here", "read more", "learn more", "more", "see more",
+	"view", "view all", "view more", "details", "shop now", "link", "this page",
+}
+
+// facetParams are query parameters that filter or re-sort a listing
+// without changing what it is about. Pagination is not faceting.
+var facetParams = []string{
+	"sort", "sortby", "sort_by", "order", "orderby", "dir", "filter", "color",
+	"colour", "size", "price", "min_price", "max_price", "brand", "view", "limit", "per_page",
+}
+
+// maxCuratedLinks is the number of distinct links past which a listing
+// reads as a dump rather than a selection.
+const maxCuratedLinks = 100
+
+// introParagraphWords is the length from which a paragraph counts as
+// prose rather than a link tile or a label.
+const introParagraphWords = 10
+
+// intro returns the paragraphs of prose opening the page, after its
+// headings and before the listing starts.
+func (d *document) intro() []span {
+	var intro []span
+	for _, p := range d.paragraphs() {
+		if d.isHeading(p) {
+			if len(intro) > 0 {
+				break
+			}
+			continue
+		}
+		if wordCount(d.content, p) < introParagraphWords {
+			break
+		}
+		intro = append(intro, p)
+	}
+	return intro
+}
+
+// evaluateCategoryIntro scores the descriptive block opening a category or
+// hub page (40 points): 40 for 40 to 250 words saying what the listing
+// covers, 30 when it runs longer and pushes the listing down, 15 when it
+// is shorter and 5 without one.
+func evaluateCategoryIntro(doc *document) (int, string, []Evidence) {
+	intro := doc.intro()
+	words := 0
+	for _, p := range intro {
+		words += wordCount(doc.content, p)
+	}
+
+	switch {
+	case len(intro) == 0:
+		return 5, "Open the listing with an intro paragraph describing what it covers and who it is for", nil
+	case words < 40:
+		return 15, fmt.Sprintf("Expand the intro to 40-250 words (%d now) describing what the listing covers", words), []Evidence{doc.evidence(intro[0])}
+	case words > 250:
+		return 30, fmt.Sprintf("Shorten the intro to under 250 words (%d now) so the listing stays near the top", words), []Evidence{doc.evidence(intro[0])}
+	}
+	return 40, "", nil
+}
+
+// isGenericAnchor reports whether a link text is empty or generic.
+func isGenericAnchor(text string) bool {
+	text = strings.Trim(strings.ToLower(strings.TrimSpace(text)), ".:›»→ ")
+	return text == "" || containsWord(genericAnchors, text)
+}
+
+// evaluateLinkCuration scores the links of a category or hub page (35
+// points): a listing of 5 to 100 distinct pages (10, 5 for fewer or more)
+// whose link texts describe their target (25 when 90% do, 15 at 70%, 5
+// below). Generic link texts are returned as evidence.
+func evaluateLinkCuration(doc *document) (int, string, []Evidence) {
+	seen := make(map[string]bool)
+	generic := make(map[string]bool)
+	var evidence []Evidence
+	for _, link := range doc.page.Links {
+		if isGenericAnchor(link.Text) && !generic[link.URL] {
+			generic[link.URL] = true
+			if len(evidence) < maxEvidence {
+				evidence = append(evidence, missingEvidence("link to %s reads %q", link.URL, link.Text))
+			}
+		}
+		seen[link.URL] = true
+	}
+	if len(seen) == 0 {
+		return 0, "List the pages this hub covers as links with descriptive text", nil
+	}
+
+	score := 10
+	if len(seen) < 5 || len(seen) > maxCuratedLinks {
+		score = 5
+	}
+	descriptive := float64(len(seen)-len(generic)) / float64(len(seen))
+	switch {
+	case descriptive >= 0.9:
+		score += 25
+	case descriptive >= 0.7:
+		score += 15
+	default:
+		score += 5
+	}
+
+	switch {
+	case descriptive < 0.9:
+		return score, fmt.Sprintf("Replace generic link texts such as \"Read more\" with the name of the page linked (%d links)", len(generic)), evidence
+	case len(seen) > maxCuratedLinks:
+		return score, fmt.Sprintf("Curate the listing: %d distinct links are more than readers or AI systems can weigh", len(seen)), nil
+	case len(seen) < 5:
+		return score, fmt.Sprintf("Link the main pages this hub covers (%d now)", len(seen)), nil
+	}
+	return score, "", nil
+}
+
+// facetQuery reports whether u filters or sorts a listing and returns it
+// without its facet parameters.
+func facetQuery(u *url.URL) (bool, string) {
+	query := u.Query()
+	faceted := false
+	for name := range query {
+		lower := strings.ToLower(name)
+		if containsWord(facetParams, lower) || strings.HasPrefix(lower, "filter") {
+			faceted = true
+			query.Del(name)
+		}
+	}
+	clean := *u
+	clean.RawQuery = query.Encode()
+	clean.Fragment = ""
+	return faceted, clean.String()
+}
+
+// evaluateFacetedDuplication scores how the page keeps filtered and sorted
+// variants of the listing from passing for separate pages (25 points). A
+// faceted URL must declare the unfiltered listing canonical (15), and
+// facet links must not make up more than a quarter of the listing (10).
+func evaluateFacetedDuplication(doc *document) (int, string, []Evidence) {
+	page := doc.page
+	score := 25
+	var evidence []Evidence
+
+	if u, err := url.Parse(page.URL); err == nil {
+		if faceted, clean := facetQuery(u); faceted && page.Crawl.Canonical != clean {
+			score -= 15
+			if page.Crawl.Canonical == "" {
+				evidence = append(evidence, missingEvidence("faceted URL %s declares no canonical", page.URL))
+			} else {
+				evidence = append(evidence, missingEvidence("faceted URL %s declares %s canonical instead of %s", page.URL, page.Crawl.Canonical, clean))
+			}
+		}
+	}
+
+	var facetLinks []string
+	for _, link := range page.Links {
+		if u, err := url.Parse(link.URL); err == nil {
+			if faceted, _ := facetQuery(u); faceted {
+				facetLinks = append(facetLinks, link.URL)
+			}
+		}
+	}
+	if len(page.Links) > 0 && len(facetLinks)*4 > len(page.Links) {
+		score -= 10
+		for _, link := range facetLinks[:min(len(facetLinks), maxEvidence)] {
+			evidence = append(evidence, missingEvidence("filter or sort link %s", link))
+		}
+	}
+
+	if len(evidence) > 0 {
+		return score, "Canonicalize filtered and sorted variants to the unfiltered listing and keep facet links out of the main listing", evidence
+	}
+	return score, "", nil
+}
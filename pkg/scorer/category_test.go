@@ -0,0 +1,60 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestCategoryProfile(t *testing.T) {
+	intro := "Trail running shoes for rocky, muddy and mixed terrain, chosen by our testers for grip, " +
+		"protection and comfort over long distances. Every pair below was run for at least 200 km " +
+		"before it made the list, and each page compares fit, drop and weight."
+	var items []string
+	var links []webpage.Link
+	for i := 1; i <= 8; i++ {
+		items = append(items, fmt.Sprintf("Trail Runner %d", i))
+		links = append(links, webpage.Link{Text: fmt.Sprintf("Trail Runner %d", i), URL: fmt.Sprintf("https://shop.example/p/trail-runner-%d", i)})
+	}
+	content := "Trail running shoes\n\n" + intro + "\n\n" + strings.Join(items, "\n\n")
+	page := &webpage.PageData{
+		URL:      "https://shop.example/category/trail?sort=price",
+		Content:  content,
+		MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 1, Text: "Trail running shoes"}},
+		Crawl:    webpage.CrawlInfo{Canonical: "https://shop.example/category/trail"},
+		Links:    links,
+	}
+
+	ls := NewLocalScorer()
+	ls.SetProfile(ProfileCategory)
+	score := ls.AnalyzeContent(content, page)
+	for _, f := range score.Findings {
+		switch f.ID {
+		case "richness.depth", "category.intro", "category.links", "category.facets":
+			t.Errorf("unexpected finding %s: %s", f.ID, f.Message)
+		}
+	}
+
+	// Generic link texts, no intro and a faceted URL without canonical
+	page.Crawl.Canonical = ""
+	for i := range page.Links {
+		page.Links[i].Text = "Read more"
+	}
+	content = "Trail running shoes\n\n" + strings.Join(items, "\n\n")
+	page.Content = content
+	score = ls.AnalyzeContent(content, page)
+	found := map[string]Finding{}
+	for _, f := range score.Findings {
+		found[f.ID] = f
+	}
+	for _, id := range []string{"category.intro", "category.links", "category.facets"} {
+		if _, ok := found[id]; !ok {
+			t.Errorf("missing finding %s", id)
+		}
+	}
+	if ev := found["category.facets"].Evidence; len(ev) != 1 || !strings.Contains(ev[0].Snippet, "no canonical") {
+		t.Errorf("facet evidence = %+v", ev)
+	}
+}
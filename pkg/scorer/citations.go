@@ -0,0 +1,261 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CitedDomain is a site the page links to from its content, with the
+// kind of source it is and the number of links to it.
+type CitedDomain struct {
+	Domain string `json:"domain"`
+	Tier   string `json:"tier"`
+	Links  int    `json:"links"`
+}
+
+const (
+	// citationsMaxPoints and balanceMaxPoints are what the citation quality
+	// and citation balance rules contribute to the Authority Signals pillar.
+	citationsMaxPoints = 35
+	balanceMaxPoints   = 5
+
+	// minBalanceLinks is the number of content links from which the share
+	// of links to other sites is checked.
+	minBalanceLinks = 5
+)
+
+// Citation tiers, most authoritative first.
+const (
+	TierEdu     = "edu"
+	TierGov     = "gov"
+	TierJournal = "journal"
+	TierNews    = "news"
+	TierOther   = "other"
+	TierBlog    = "blog"
+	TierSocial  = "social"
+)
+
+// tierWeights are the quality of a citation to each tier, from 0 to 1.
+var tierWeights = map[string]float64{
+	TierEdu:     1,
+	TierGov:     1,
+	TierJournal: 1,
+	TierNews:    0.7,
+	TierOther:   0.5,
+	TierBlog:    0.25,
+	TierSocial:  0.1,
+}
+
+// Well-known domains of each tier; subdomains belong to the same tier.
+var (
+	journalDomains = []string{
+		"doi.org", "arxiv.org", "nature.com", "science.org", "sciencedirect.com", "springer.com",
+		"link.springer.com", "wiley.com", "tandfonline.com", "jstor.org", "plos.org", "thelancet.com",
+		"nejm.org", "bmj.com", "cell.com", "jamanetwork.com", "ieee.org", "acm.org", "ssrn.com",
+		"researchgate.net", "semanticscholar.org", "biorxiv.org", "medrxiv.org",
+	}
+	newsDomains = []string{
+		"reuters.com", "apnews.com", "bbc.com", "bbc.co.uk", "nytimes.com", "theguardian.com",
+		"washingtonpost.com", "wsj.com", "ft.com", "bloomberg.com", "economist.com", "cnn.com",
+		"npr.org", "aljazeera.com", "theverge.com", "techcrunch.com", "wired.com", "arstechnica.com",
+		"forbes.com", "cnbc.com", "axios.com", "politico.com", "lemonde.fr", "spiegel.de",
+	}
+	blogDomains = []string{
+		"medium.com", "substack.com", "wordpress.com", "blogspot.com", "blogger.com", "dev.to",
+		"hashnode.dev", "tumblr.com", "ghost.io", "wixsite.com", "weebly.com",
+	}
+	socialDomains = []string{
+		"twitter.com", "x.com", "facebook.com", "instagram.com", "linkedin.com", "reddit.com",
+		"youtube.com", "youtu.be", "tiktok.com", "pinterest.com", "quora.com", "threads.net",
+		"mastodon.social", "bsky.app", "t.me", "discord.com",
+	}
+)
+
+// citationTier returns the tier of a cited host and path.
+func citationTier(host, path string) string {
+	labels := strings.Split(host, ".")
+	tld, second := labels[len(labels)-1], ""
+	if len(labels) > 2 && len(tld) == 2 {
+		// Country second-level domains: example.ac.uk, example.gov.au
+		second = labels[len(labels)-2]
+	}
+	switch {
+	case tld == "edu" || second == "edu" || second == "ac":
+		return TierEdu
+	case tld == "gov" || tld == "mil" || second == "gov" || second == "gouv" || second == "gob" ||
+		hasDomain(host, []string{"europa.eu", "who.int", "un.org", "oecd.org"}):
+		return TierGov
+	case hasDomain(host, journalDomains):
+		return TierJournal
+	case hasDomain(host, newsDomains):
+		return TierNews
+	case hasDomain(host, socialDomains):
+		return TierSocial
+	case hasDomain(host, blogDomains) || strings.HasPrefix(host, "blog.") || strings.HasPrefix(path, "/blog/"):
+		return TierBlog
+	}
+	return TierOther
+}
+
+// hasDomain reports whether host is one of domains or a subdomain of one.
+func hasDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSite reports whether two hosts belong to the same site, one being
+// the other or a subdomain of it.
+func sameSite(a, b string) bool {
+	return a == b || strings.HasSuffix(a, "."+b) || strings.HasSuffix(b, "."+a)
+}
+
+// linkHost returns the host of a web URL without "www.", or "" for other
+// URLs.
+func linkHost(raw string) (string, *url.URL) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", nil
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."), u
+}
+
+// citedDomains returns the other sites the content links to, most linked
+// first, with the first link to each.
+func (d *document) citedDomains() ([]CitedDomain, map[string]string) {
+	own, _ := linkHost(d.page.URL)
+
+	counts := make(map[string]*CitedDomain)
+	first := make(map[string]string)
+	var order []string
+	for _, link := range d.page.Links {
+		host, u := linkHost(link.URL)
+		if host == "" || (own != "" && sameSite(host, own)) {
+			continue
+		}
+		if counts[host] == nil {
+			counts[host] = &CitedDomain{Domain: host, Tier: citationTier(host, u.Path)}
+			first[host] = link.Text
+			order = append(order, host)
+		}
+		counts[host].Links++
+	}
+
+	domains := make([]CitedDomain, len(order))
+	for i, host := range order {
+		domains[i] = *counts[host]
+	}
+	sort.SliceStable(domains, func(i, j int) bool { return domains[i].Links > domains[j].Links })
+	return domains, first
+}
+
+// evaluateCitationQuality scores citations by the sites they point to (35
+// points): up to 15 for the number of distinct domains cited (8 for one,
+// 12 for two or three, 15 for four or more) and up to 20 for their
+// average quality, from journals, academic and government sites down to
+// blogs and social media. Pages without outbound links fall back to
+// citation phrases in the text. It returns the issue to report, if any,
+// links to the weakest sources as evidence and the cited domains.
+func (ls *LocalScorer) evaluateCitationQuality(doc *document) (int, string, []Evidence, []CitedDomain) {
+	domains, first := doc.citedDomains()
+	if len(domains) == 0 {
+		score := int(math.Round(float64(ls.evaluateCitations(doc.content)) * citationsMaxPoints / 40))
+		if score >= 26 {
+			return score, "", nil, nil
+		}
+		return score, "Add more citations and credible references", nil, nil
+	}
+
+	quality := 0.0
+	for _, d := range domains {
+		quality += tierWeights[d.Tier]
+	}
+	quality /= float64(len(domains))
+
+	score := 15
+	switch {
+	case len(domains) == 1:
+		score = 8
+	case len(domains) <= 3:
+		score = 12
+	}
+	score += int(math.Round(20 * quality))
+	if score >= 26 {
+		return score, "", nil, domains
+	}
+
+	if quality >= 0.5 {
+		return score, fmt.Sprintf("Cite more independent sources: the content links to %d other site(s)", len(domains)), nil, domains
+	}
+	var evidence []Evidence
+	weak := 0
+	for _, d := range domains {
+		if tierWeights[d.Tier] < tierWeights[TierOther] {
+			weak++
+			if len(evidence) < maxEvidence {
+				ev := missingEvidence("%s (%s)", d.Domain, d.Tier)
+				if text := first[d.Domain]; text != "" {
+					ev = Locate(doc.content, doc.page, text)
+					ev.Snippet = fmt.Sprintf("%s (%s): %s", d.Domain, d.Tier, ev.Snippet)
+				}
+				evidence = append(evidence, ev)
+			}
+		}
+	}
+	return score, fmt.Sprintf("Cite primary sources such as research, academic, government or news sites: %d of %d cited sites are blogs or social media", weak, len(domains)), evidence, domains
+}
+
+// evaluateCitationBalance scores the balance of links to the page's own
+// site and to independent sources (5 points): 5 when at least a fifth of
+// the content links lead to other sites, 3 for a tenth and 0 below, when
+// the page cites little but itself. Pages with fewer than minBalanceLinks
+// links or without a URL get full points. It returns the issue to report,
+// if any, and links back to the site as evidence.
+func evaluateCitationBalance(doc *document) (int, string, []Evidence) {
+	own, _ := linkHost(doc.page.URL)
+	if own == "" {
+		return balanceMaxPoints, "", nil
+	}
+
+	var self []string
+	total := 0
+	for _, link := range doc.page.Links {
+		host, _ := linkHost(link.URL)
+		if host == "" {
+			continue
+		}
+		total++
+		if sameSite(host, own) {
+			self = append(self, link.Text)
+		}
+	}
+	if total < minBalanceLinks {
+		return balanceMaxPoints, "", nil
+	}
+
+	external := total - len(self)
+	score := 0
+	switch {
+	case external*5 >= total:
+		return balanceMaxPoints, "", nil
+	case external*10 >= total:
+		score = 3
+	}
+
+	var evidence []Evidence
+	for _, text := range self {
+		if text = strings.TrimSpace(text); text != "" && strings.Contains(doc.content, text) {
+			evidence = append(evidence, Locate(doc.content, doc.page, text))
+			if len(evidence) == maxEvidence {
+				break
+			}
+		}
+	}
+	return score, fmt.Sprintf("Add independent sources: %d of %d links in the content point back to %s", len(self), total, own), evidence
+}
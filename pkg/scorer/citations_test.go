@@ -0,0 +1,88 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestCitationTier(t *testing.T) {
+	for host, want := range map[string]string{
+		"cs.stanford.edu":         TierEdu,
+		"ox.ac.uk":                TierEdu,
+		"cdc.gov":                 TierGov,
+		"gov.uk":                  TierOther,
+		"data.gov.uk":             TierGov,
+		"ec.europa.eu":            TierGov,
+		"doi.org":                 TierJournal,
+		"pubmed.ncbi.nlm.nih.gov": TierGov,
+		"reuters.com":             TierNews,
+		"someone.medium.com":      TierBlog,
+		"blog.example.com":        TierBlog,
+		"x.com":                   TierSocial,
+		"example.com":             TierOther,
+	} {
+		if got := citationTier(host, "/"); got != want {
+			t.Errorf("citationTier(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestCitationQuality(t *testing.T) {
+	content := "Sleep matters.\n\nA thread on sleep and a blog post and a video say so."
+	page := &webpage.PageData{URL: "https://www.example.com/sleep", Content: content, Links: []webpage.Link{
+		{Text: "A thread on sleep", URL: "https://twitter.com/someone/status/1"},
+		{Text: "a blog post", URL: "https://someone.substack.com/p/sleep"},
+		{Text: "a video", URL: "https://www.youtube.com/watch?v=1"},
+		{Text: "our guide", URL: "https://example.com/guide"},
+		{Text: "our shop", URL: "https://shop.example.com/"},
+	}}
+	ls := NewLocalScorer()
+	score, issue, evidence, domains := ls.evaluateCitationQuality(newDocument(content, page))
+	if len(domains) != 3 {
+		t.Fatalf("domains = %+v, want the three other sites", domains)
+	}
+	if score >= 30 || issue == "" || len(evidence) != 3 || evidence[0].Snippet != "twitter.com (social): A thread on sleep" || evidence[0].Start < 0 {
+		t.Errorf("score %d, issue %q, evidence %+v, want weak sources flagged", score, issue, evidence)
+	}
+
+	page.Links = []webpage.Link{
+		{Text: "a trial", URL: "https://doi.org/10.1000/1"},
+		{Text: "the guideline", URL: "https://www.cdc.gov/sleep"},
+		{Text: "a review", URL: "https://www.nature.com/articles/1"},
+		{Text: "the report", URL: "https://www.reuters.com/health/1"},
+		{Text: "the report again", URL: "https://www.reuters.com/health/1"},
+	}
+	score, issue, _, domains = ls.evaluateCitationQuality(newDocument(content, page))
+	if score < 33 || issue != "" || domains[0].Domain != "reuters.com" || domains[0].Links != 2 {
+		t.Errorf("score %d, issue %q, domains %+v, want primary sources rewarded", score, issue, domains)
+	}
+}
+
+func TestCitationBalance(t *testing.T) {
+	content := "Sleep matters.\n\nSee our sleep guide, our mattress review, our pillow test and our shop."
+	page := &webpage.PageData{URL: "https://www.example.com/sleep", Content: content}
+	for _, text := range []string{"our sleep guide", "our mattress review", "our pillow test", "our shop"} {
+		page.Links = append(page.Links, webpage.Link{Text: text, URL: "https://example.com/" + text[4:]})
+	}
+	page.Links = append(page.Links, webpage.Link{Text: "mailto", URL: "mailto:hi@example.com"})
+
+	// Too few links to judge
+	if score, issue, _ := evaluateCitationBalance(newDocument(content, page)); score != balanceMaxPoints || issue != "" {
+		t.Errorf("4 links: score %d, issue %q, want full points", score, issue)
+	}
+
+	page.Links = append(page.Links, webpage.Link{Text: "help", URL: "https://help.example.com/"})
+	score, issue, evidence := evaluateCitationBalance(newDocument(content, page))
+	if score != 0 || issue != "Add independent sources: 5 of 5 links in the content point back to example.com" || len(evidence) != 3 || evidence[0].Snippet != "our sleep guide" {
+		t.Errorf("self-referential: score %d, issue %q, evidence %+v", score, issue, evidence)
+	}
+
+	page.Links = append(page.Links, webpage.Link{Text: "a trial", URL: "https://doi.org/10.1000/1"})
+	if score, _, _ := evaluateCitationBalance(newDocument(content, page)); score != 3 {
+		t.Errorf("1 of 6 external: score %d, want 3", score)
+	}
+	page.Links = append(page.Links, webpage.Link{Text: "a review", URL: "https://www.nature.com/articles/1"})
+	if score, _, _ := evaluateCitationBalance(newDocument(content, page)); score != balanceMaxPoints {
+		t.Errorf("2 of 7 external: score %d, want full points", score)
+	}
+}
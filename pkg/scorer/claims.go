@@ -0,0 +1,46 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"regexp"
+	"strings"
+)
+
+var (
+	// absolutePattern matches the language of strong, unqualified claims.
+	absolutePattern = regexp.MustCompile(`(?i)\b(always|never|undeniabl[ey]|undoubtedly|obviously|definitely|certainly|guarantee[sd]?|proven|impossible|nobody|no one|everyone knows|without (a )?doubt|the (best|worst|only|most effective))\b|\b100%`)
+
+	// attributionPattern matches a claim's attribution to a source.
+	attributionPattern = regexp.MustCompile(`(?i)\b(according to|said|says|study|studies|research|survey|report(ed|s)?|found that|data from|source|cited)\b|\[\d+\]|\(\d{4}\)`)
+)
+
+// StrongClaims returns up to n sentences that make absolute claims without
+// attributing them to anyone, the claims answer engines are wary of
+// repeating.
+func StrongClaims(content string, pageData *webpage.PageData, n int) []Evidence {
+	doc := newDocument(content, pageData)
+	var claims []Evidence
+	for _, s := range doc.sentences() {
+		text := content[s.start:s.end]
+		if len(claims) == n {
+			break
+		}
+		if doc.isHeading(s) || strings.HasSuffix(text, "?") {
+			continue
+		}
+		if absolutePattern.MatchString(text) && !attributionPattern.MatchString(text) {
+			claims = append(claims, doc.evidence(s))
+		}
+	}
+	return claims
+}
+
+// Locate returns evidence quoting text where it occurs in content, or
+// evidence with no position when it does not.
+func Locate(content string, pageData *webpage.PageData, text string) Evidence {
+	text = strings.TrimSpace(text)
+	if i := strings.Index(content, text); text != "" && i >= 0 {
+		return newDocument(content, pageData).evidence(span{i, i + len(text)})
+	}
+	return missingEvidence("%s", text)
+}
@@ -0,0 +1,14 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestStrongClaims(t *testing.T) {
+	content := "Diets\n\nThis diet is the best way to lose weight. According to a 2024 study, it never fails. Is it always safe? Most people lose 2 kg."
+	claims := StrongClaims(content, &webpage.PageData{Content: content}, 5)
+	if len(claims) != 1 || claims[0].Snippet != "This diet is the best way to lose weight." {
+		t.Errorf("claims = %+v, want only the unattributed claim", claims)
+	}
+}
@@ -0,0 +1,219 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// PageType is the kind of page the classifier detected.
+type PageType string
+
+const (
+	PageArticle       PageType = "article"
+	PageNews          PageType = "news"
+	PageProduct       PageType = "product"
+	PageDocs          PageType = "docs"
+	PageLocalBusiness PageType = "local-business"
+	PageLanding       PageType = "landing"
+	PageCategory      PageType = "category"
+)
+
+// PageTypes lists the page types, most specific first; ties between
+// heuristic scores go to the earlier type.
+var PageTypes = []PageType{PageProduct, PageNews, PageDocs, PageLocalBusiness, PageCategory, PageLanding, PageArticle}
+
+// Classification is the detected type of a page, how sure the classifier
+// is of it (0-1) and the signals it was based on.
+type Classification struct {
+	Type       PageType `json:"type"`
+	Confidence float64  `json:"confidence"`
+	Signals    []string `json:"signals,omitempty"`
+	Source     string   `json:"source"` // "heuristics" or "llm"
+}
+
+// Profile returns the scoring profile for pages of type t. Articles use
+// the general profile and landing pages the category one.
+func (t PageType) Profile() Profile {
+	switch t {
+	case PageNews:
+		return ProfileNews
+	case PageProduct:
+		return ProfileProduct
+	case PageDocs:
+		return ProfileDocs
+	case PageLocalBusiness:
+		return ProfileLocalBusiness
+	case PageCategory, PageLanding:
+		return ProfileCategory
+	}
+	return ProfileGeneral
+}
+
+// ParsePageType returns the page type called name.
+func ParsePageType(name string) (PageType, error) {
+	for _, t := range PageTypes {
+		if strings.EqualFold(strings.TrimSpace(name), string(t)) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown page type %q", name)
+}
+
+var (
+	// cartPattern matches purchase buttons.
+	cartPattern = regexp.MustCompile(`(?i)\b(add to (cart|basket|bag)|buy now|in stock|out of stock)\b`)
+
+	// pricePattern matches prices written in text.
+	pricePattern = regexp.MustCompile(`[$€£¥]\s?\d|\d\s?[€£]|\b\d+[.,]\d{2} ?(USD|EUR|GBP)\b`)
+
+	// ctaPattern matches the calls to action of landing pages.
+	ctaPattern = regexp.MustCompile(`(?i)\b(get started|sign up|start (your )?free|free trial|request a demo|book a demo|contact sales|try it free|join now)\b`)
+)
+
+// Path segments that hint at a page type.
+var (
+	docsSegments     = []string{"docs", "documentation", "api", "reference", "guide", "guides", "sdk", "manual"}
+	categorySegments = []string{"category", "categories", "collections", "collection", "tag", "tags", "topics", "shop"}
+)
+
+// pageSignals accumulates heuristic evidence for each page type.
+type pageSignals struct {
+	points  map[PageType]int
+	signals map[PageType][]string
+}
+
+func (s *pageSignals) add(t PageType, points int, signal string) {
+	s.points[t] += points
+	s.signals[t] = append(s.signals[t], signal)
+}
+
+// ClassifyPage detects the type of a page from its markup, URL and content.
+// Confidence grows with the evidence for the winning type and with its lead
+// over the runner-up; pages without any signal are articles with low
+// confidence.
+func ClassifyPage(pageData *webpage.PageData) Classification {
+	s := &pageSignals{points: make(map[PageType]int), signals: make(map[PageType][]string)}
+	doc := newDocument(pageData.Content, pageData)
+	words := depthWords(pageData.Content)
+
+	// Markup is the strongest signal
+	if p := product(pageData); p != nil {
+		s.add(PageProduct, 3, "Product markup")
+		if p["offers"] != nil {
+			s.add(PageProduct, 1, "Offer markup")
+		}
+	}
+	if newsArticle(pageData) != nil {
+		s.add(PageNews, 3, "NewsArticle markup")
+	}
+	if localBusiness(pageData) != nil {
+		s.add(PageLocalBusiness, 3, "LocalBusiness markup")
+	}
+	for _, typ := range []string{"TechArticle", "APIReference"} {
+		if len(pageData.SchemaObjects(typ)) > 0 {
+			s.add(PageDocs, 3, typ+" markup")
+		}
+	}
+	for _, typ := range []string{"CollectionPage", "ItemList"} {
+		if len(pageData.SchemaObjects(typ)) > 0 {
+			s.add(PageCategory, 3, typ+" markup")
+		}
+	}
+	for _, typ := range []string{"Article", "BlogPosting"} {
+		if len(pageData.SchemaObjects(typ)) > 0 {
+			s.add(PageArticle, 3, typ+" markup")
+		}
+	}
+
+	// URL path
+	if page, err := url.Parse(pageData.URL); err == nil {
+		segments := pathSegments(page.Path)
+		for _, segment := range segments {
+			if containsWord(docsSegments, strings.ToLower(segment)) {
+				s.add(PageDocs, 2, "/"+segment+"/ in the URL")
+				break
+			}
+		}
+		for _, segment := range segments {
+			if containsWord(categorySegments, strings.ToLower(segment)) {
+				s.add(PageCategory, 1, "/"+segment+"/ in the URL")
+				break
+			}
+		}
+		if page.IsAbs() && len(segments) == 0 {
+			s.add(PageLanding, 1, "home page")
+		}
+	}
+
+	// Content
+	if cartPattern.MatchString(pageData.Content) {
+		s.add(PageProduct, 2, "purchase buttons")
+	}
+	if pricePattern.MatchString(pageData.Content) {
+		s.add(PageProduct, 1, "prices")
+	}
+	if blocks := doc.codeBlocks(); len(blocks) >= 2 {
+		s.add(PageDocs, 2, fmt.Sprintf("%d code blocks", len(blocks)))
+	} else if len(blocks) == 1 {
+		s.add(PageDocs, 1, "a code block")
+	}
+	if doc.parameterTables() > 0 {
+		s.add(PageDocs, 1, "parameter tables")
+	}
+	if pageData.MetaTags["article:published_time"] != "" {
+		s.add(PageNews, 1, "publication date")
+		s.add(PageArticle, 1, "publication date")
+	}
+	if bylinePattern.MatchString(pageData.Content) {
+		s.add(PageNews, 1, "byline")
+		s.add(PageArticle, 1, "byline")
+	}
+	if score, _, _ := evaluateDateline(doc); score >= 15 {
+		s.add(PageNews, 2, "dateline")
+	}
+	if len(pageData.Addresses) > 0 && len(pageData.Phones) > 0 {
+		s.add(PageLocalBusiness, 1, "address and phone")
+	}
+	if len(pageData.MapEmbeds) > 0 {
+		s.add(PageLocalBusiness, 1, "embedded map")
+	}
+	if links := len(pageData.Links); links >= 20 && words/links < 15 {
+		s.add(PageCategory, 2, fmt.Sprintf("%d links for %d words", links, words))
+	}
+	if ctas := len(ctaPattern.FindAllString(pageData.Content, -1)); ctas >= 2 {
+		s.add(PageLanding, 2, fmt.Sprintf("%d calls to action", ctas))
+	} else if ctas == 1 {
+		s.add(PageLanding, 1, "a call to action")
+	}
+	if words >= 600 {
+		s.add(PageArticle, 1, fmt.Sprintf("%d words of prose", words))
+	}
+
+	best, second := PageTypes[0], 0
+	for _, t := range PageTypes[1:] {
+		if s.points[t] > s.points[best] {
+			best = t
+		}
+	}
+	for _, t := range PageTypes {
+		if t != best && s.points[t] > second {
+			second = s.points[t]
+		}
+	}
+
+	top := s.points[best]
+	if top == 0 {
+		return Classification{Type: PageArticle, Confidence: 0.2, Source: "heuristics"}
+	}
+	confidence := float64(min(top, 4)) / 4 * float64(top-second) / float64(top)
+	return Classification{
+		Type:       best,
+		Confidence: math.Round(confidence*100) / 100,
+		Signals:    s.signals[best],
+		Source:     "heuristics",
+	}
+}
@@ -0,0 +1,66 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestClassifyPage(t *testing.T) {
+	manyLinks := make([]webpage.Link, 24)
+	for i := range manyLinks {
+		manyLinks[i] = webpage.Link{Text: fmt.Sprintf("Item %d", i), URL: fmt.Sprintf("https://shop.example/item-%d", i)}
+	}
+
+	tests := []struct {
+		name string
+		page *webpage.PageData
+		want PageType
+	}{
+		{"product markup", &webpage.PageData{
+			URL:            "https://shop.example/p/trail-runner",
+			Content:        "Trail Runner 2\n\n€129.00\n\nAdd to cart",
+			StructuredData: []map[string]any{{"@type": "Product", "name": "Trail Runner 2", "offers": map[string]any{"price": 129.0}}},
+		}, PageProduct},
+		{"docs URL and code", &webpage.PageData{
+			URL:     "https://example.com/docs/install",
+			Content: "Install\n\n```bash\nnpm install acme\n```\n\nThen:\n\n```js\nrequire('acme')\n```",
+		}, PageDocs},
+		{"category links", &webpage.PageData{
+			URL:     "https://shop.example/category/shoes",
+			Content: "Shoes\n\nBrowse our shoes.",
+			Links:   manyLinks,
+		}, PageCategory},
+		{"news markup", &webpage.PageData{
+			URL:            "https://news.example/2026/03/vote",
+			Content:        "LONDON — Parliament voted on Tuesday.\n\nBy Jane Doe",
+			StructuredData: []map[string]any{{"@type": "NewsArticle", "headline": "Vote"}},
+		}, PageNews},
+		{"no signals", &webpage.PageData{Content: "A short note."}, PageArticle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := ClassifyPage(tt.page)
+			if class.Type != tt.want {
+				t.Errorf("type = %s (signals %v), want %s", class.Type, class.Signals, tt.want)
+			}
+			if class.Source != "heuristics" || class.Confidence <= 0 || class.Confidence > 1 {
+				t.Errorf("classification = %+v", class)
+			}
+		})
+	}
+
+	if class := ClassifyPage(&webpage.PageData{Content: "A short note."}); class.Confidence >= 0.5 {
+		t.Errorf("confidence without signals = %.2f, want it low", class.Confidence)
+	}
+}
+
+func TestPageTypeProfile(t *testing.T) {
+	for pageType, want := range map[PageType]Profile{
+		PageProduct: ProfileProduct, PageDocs: ProfileDocs, PageLanding: ProfileCategory, PageCategory: ProfileCategory, PageArticle: ProfileGeneral,
+	} {
+		if got := pageType.Profile(); got != want {
+			t.Errorf("%s.Profile() = %s, want %s", pageType, got, want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// visibleDatePattern matches dates as pages write them: ISO dates and
+	// day, month and year in either order.
+	visibleDatePattern = regexp.MustCompile(`(?i)\b(\d{4}-\d{2}-\d{2}|\d{1,2} (?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.? \d{4}|(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.? \d{1,2}, \d{4})\b`)
+
+	// visibleRatingPattern matches ratings such as "4.5 out of 5", "4.5/5",
+	// "4.5 stars" or "Rated 4.5".
+	visibleRatingPattern = regexp.MustCompile(`(?i)\b(\d(?:[.,]\d{1,2})?)\s*(?:out of \d+|/\s*\d+\b|stars?\b)|\brated:?\s+(\d(?:[.,]\d{1,2})?)\b`)
+
+	// visiblePricePattern matches prices with a currency symbol or code
+	// before or after the amount, e.g. "$19.99", "19,99 €" or "19.99 USD".
+	visiblePricePattern = regexp.MustCompile(`(?:[$€£¥]|\b(?:USD|EUR|GBP|CAD|AUD|JPY|CHF)\s?)\s?(\d[\d,.]*)|(\d[\d,.]*)\s?(?:[€£]|\b(?:USD|EUR|GBP|CAD|AUD|JPY|CHF)\b)`)
+)
+
+// schemaDatesShown are the date properties compared with the dates the
+// page shows.
+var schemaDatesShown = []string{"datePublished", "dateModified"}
+
+// visibleMismatches compares the authors, dates, ratings and prices obj
+// declares with the content. Authors, ratings and prices must be shown as
+// declared; dates only contradict the page when it shows dates and none of
+// them is the declared one, as many pages show no date at all.
+func (d *document) visibleMismatches(obj map[string]any) []string {
+	var mismatched []string
+	for _, name := range authorNames(obj["author"]) {
+		if !d.shows(name) {
+			mismatched = append(mismatched, fmt.Sprintf("author: %q is not named on the page", name))
+		}
+	}
+
+	shownDates := d.visibleDates()
+	for _, prop := range schemaDatesShown {
+		declared, ok := parseSchemaDate(schemaText(obj[prop]))
+		if !ok || len(shownDates) == 0 || dateShown(declared, shownDates) {
+			continue
+		}
+		mismatched = append(mismatched, fmt.Sprintf("%s: %s is none of the dates the page shows (%s)", prop, declared.Format("2006-01-02"), formatDates(shownDates)))
+	}
+
+	for _, rating := range append(schemaObjects(obj["aggregateRating"]), schemaObjects(obj["reviewRating"])...) {
+		value, ok := schemaNumber(rating["ratingValue"])
+		if !ok {
+			continue
+		}
+		shown := d.visibleNumbers(visibleRatingPattern)
+		if !numberShown(value, shown, 0.05) {
+			mismatched = append(mismatched, fmt.Sprintf("ratingValue: %s is %s", formatNumber(value), shownDescription("rating", shown)))
+		}
+	}
+
+	for _, offer := range schemaObjects(obj["offers"]) {
+		price, ok := schemaNumber(offer["price"])
+		if !ok {
+			price, ok = schemaNumber(offer["lowPrice"])
+		}
+		if !ok {
+			continue
+		}
+		shown := d.visibleNumbers(visiblePricePattern)
+		if !numberShown(price, shown, 0.005) {
+			mismatched = append(mismatched, fmt.Sprintf("offers.price: %s is %s", formatNumber(price), shownDescription("price", shown)))
+		}
+	}
+	return mismatched
+}
+
+// authorNames returns every name in a JSON-LD author value: a string, a
+// Person or Organization, or a list of them.
+func authorNames(v any) []string {
+	var names []string
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			names = append(names, authorNames(item)...)
+		}
+	default:
+		if name := schemaText(v); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// visibleDates returns the dates written in the content.
+func (d *document) visibleDates() []time.Time {
+	var dates []time.Time
+	for _, m := range visibleDatePattern.FindAllStringSubmatch(d.content, -1) {
+		if t := parseVisibleDate(m[1]); !t.IsZero() {
+			dates = append(dates, t)
+		}
+	}
+	return dates
+}
+
+// dateShown reports whether the day of declared is among the shown dates.
+func dateShown(declared time.Time, shown []time.Time) bool {
+	y, m, day := declared.Date()
+	for _, t := range shown {
+		if ty, tm, td := t.Date(); ty == y && tm == m && td == day {
+			return true
+		}
+	}
+	return false
+}
+
+func formatDates(dates []time.Time) string {
+	var parts []string
+	for i, t := range dates {
+		if i == maxEvidence {
+			parts = append(parts, "...")
+			break
+		}
+		parts = append(parts, t.Format("2006-01-02"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// visibleNumbers returns the amounts pattern captures in the content.
+func (d *document) visibleNumbers(pattern *regexp.Regexp) []float64 {
+	var numbers []float64
+	for _, m := range pattern.FindAllStringSubmatch(d.content, -1) {
+		for _, group := range m[1:] {
+			if n, ok := parseAmount(group); ok {
+				numbers = append(numbers, n)
+				break
+			}
+		}
+	}
+	return numbers
+}
+
+// parseAmount parses an amount as written on a page: a comma followed by
+// one or two final digits is a decimal comma, other commas separate
+// thousands.
+func parseAmount(s string) (float64, bool) {
+	s = strings.TrimRight(s, ".,")
+	if s == "" {
+		return 0, false
+	}
+	if i := strings.LastIndex(s, ","); i >= 0 && len(s)-i <= 3 && !strings.Contains(s[i:], ".") {
+		s = strings.ReplaceAll(s[:i], ".", "") + "." + s[i+1:]
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	return n, err == nil
+}
+
+// schemaNumber returns a JSON-LD number, which may be written as a string.
+func schemaNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		return parseAmount(strings.TrimSpace(v))
+	}
+	return 0, false
+}
+
+// numberShown reports whether value is among the shown numbers, within
+// tolerance for the rounding pages apply.
+func numberShown(value float64, shown []float64, tolerance float64) bool {
+	for _, n := range shown {
+		if math.Abs(n-value) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// shownDescription describes the values of a kind the page shows, for a
+// declared value that is not among them.
+func shownDescription(kind string, shown []float64) string {
+	if len(shown) == 0 {
+		return fmt.Sprintf("not shown on the page, which shows no %s", kind)
+	}
+	var parts []string
+	for i, n := range shown {
+		if i == maxEvidence {
+			parts = append(parts, "...")
+			break
+		}
+		parts = append(parts, formatNumber(n))
+	}
+	return fmt.Sprintf("not the %s the page shows (%s)", kind, strings.Join(parts, ", "))
+}
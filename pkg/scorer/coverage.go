@@ -0,0 +1,124 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// coverageMaxPoints is what heading coverage contributes to the
+	// Content Structure pillar.
+	coverageMaxPoints = 10
+
+	// minCoverageWords is the body length from which text is expected to
+	// be split into sections; shorter pages get full points.
+	minCoverageWords = 300
+
+	// fullCoverage and floatingCoverage are the anchored shares from which
+	// a page gets full points, and below which most of it is floating.
+	fullCoverage     = 70
+	floatingCoverage = 50
+)
+
+// genericHeadings are section headings that say nothing about the text
+// under them.
+var genericHeadings = map[string]bool{
+	"overview": true, "introduction": true, "intro": true, "details": true, "more": true,
+	"more info": true, "more information": true, "information": true, "info": true,
+	"misc": true, "miscellaneous": true, "other": true, "others": true, "general": true,
+	"content": true, "contents": true, "section": true, "notes": true, "untitled": true,
+	"read more": true, "learn more": true, "click here": true,
+}
+
+// numberedHeading matches headings that only number their section.
+var numberedHeading = regexp.MustCompile(`(?i)^(section|part|chapter|step)\s+\d+$`)
+
+// HeadingCoverage is how much of the body text sits under descriptive
+// section headings, where AI systems can find it by topic, rather than
+// floating in a preamble before the first section or under generic
+// headings such as "Overview" or "More".
+type HeadingCoverage struct {
+	Words    int        `json:"words"`              // body words, headings excluded
+	Anchored int        `json:"anchored"`           // under descriptive section headings
+	Preamble int        `json:"preamble"`           // before the first section heading
+	Generic  int        `json:"generic"`            // under generic section headings
+	Percent  int        `json:"percent"`            // anchored share of the words, 0-100
+	Floating []Evidence `json:"floating,omitempty"` // the largest unanchored blocks
+}
+
+// headingCoverage splits the body at its headings and counts the words
+// each part holds. The first H1 titles the page and anchors nothing.
+func (d *document) headingCoverage() *HeadingCoverage {
+	type region struct {
+		span
+		words   int
+		generic bool
+	}
+	coverage := &HeadingCoverage{}
+	var floating []region
+	start, anchored, generic, titled := 0, false, false, false
+	add := func(end int) {
+		words := wordCount(d.content, span{start, end})
+		coverage.Words += words
+		switch {
+		case words == 0:
+			return
+		case !anchored:
+			coverage.Preamble += words
+		case generic:
+			coverage.Generic += words
+		default:
+			coverage.Anchored += words
+			return
+		}
+		floating = append(floating, region{trimSpan(d.content, span{start, end}), words, generic})
+	}
+
+	for _, h := range d.headings {
+		add(h.offset)
+		start = h.offset + len(h.text)
+		if h.level == 1 && !titled {
+			titled = true
+			continue
+		}
+		anchored, generic = true, isGenericHeading(h.text)
+	}
+	add(len(d.content))
+
+	if coverage.Words > 0 {
+		coverage.Percent = int(math.Round(100 * float64(coverage.Anchored) / float64(coverage.Words)))
+	}
+	sort.SliceStable(floating, func(i, j int) bool { return floating[i].words > floating[j].words })
+	for i := 0; i < len(floating) && i < maxEvidence; i++ {
+		ev := d.evidence(floating[i].span)
+		where := "before the first section heading"
+		if floating[i].generic {
+			where = "under a generic heading"
+		}
+		ev.Snippet = fmt.Sprintf("%d words %s: %s", floating[i].words, where, ev.Snippet)
+		coverage.Floating = append(coverage.Floating, ev)
+	}
+	return coverage
+}
+
+func isGenericHeading(text string) bool {
+	text = strings.ToLower(strings.Trim(strings.TrimSpace(text), ":.?!"))
+	return genericHeadings[text] || numberedHeading.MatchString(text) || len(text) < 3
+}
+
+// evaluateHeadingCoverage scores the anchored share of the body: full
+// points from 70%, proportionally less below. It returns the issue to
+// report when most of a page long enough to need sections floats.
+func evaluateHeadingCoverage(coverage *HeadingCoverage) (int, string) {
+	if coverage.Words < minCoverageWords {
+		return coverageMaxPoints, ""
+	}
+	score := min(coverageMaxPoints, int(math.Round(float64(coverageMaxPoints*coverage.Percent)/fullCoverage)))
+	if coverage.Percent >= floatingCoverage {
+		return score, ""
+	}
+	return score, fmt.Sprintf("Only %d%% of the text sits under descriptive headings: split the rest into sections whose H2/H3 headings say what each part covers", coverage.Percent)
+}
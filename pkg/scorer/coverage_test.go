@@ -0,0 +1,62 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestHeadingCoverage(t *testing.T) {
+	para := strings.Repeat("Proof the dough overnight in the fridge before baking. ", 10) // 90 words
+	content := "Bread\n\n" + para + "\n\n" + para + "\n\nOverview\n\n" + para + "\n\nShaping the loaf\n\n" + para
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{}, Headings: []webpage.Heading{
+		{Level: 1, Text: "Bread"}, {Level: 2, Text: "Overview"}, {Level: 2, Text: "Shaping the loaf"},
+	}}
+
+	score := NewLocalScorer().AnalyzeContent(content, page)
+	coverage := score.Coverage
+	if coverage == nil {
+		t.Fatal("no heading coverage")
+	}
+	if coverage.Words != 360 || coverage.Preamble != 180 || coverage.Generic != 90 || coverage.Anchored != 90 || coverage.Percent != 25 {
+		t.Errorf("coverage = %+v, want 180 preamble, 90 generic and 90 anchored of 360 words", coverage)
+	}
+	if len(coverage.Floating) != 2 || !strings.HasPrefix(coverage.Floating[0].Snippet, "180 words before the first section heading: Proof") ||
+		!strings.HasPrefix(coverage.Floating[1].Snippet, "90 words under a generic heading") {
+		t.Errorf("floating = %+v", coverage.Floating)
+	}
+
+	var finding *Finding
+	for i, f := range score.Findings {
+		if f.ID == "structure.coverage" {
+			finding = &score.Findings[i]
+		}
+	}
+	if finding == nil || !strings.HasPrefix(finding.Message, "Only 25% of the text") {
+		t.Fatalf("no coverage finding in %+v", score.Findings)
+	}
+
+	for _, tc := range []struct {
+		coverage HeadingCoverage
+		points   int
+		issue    bool
+	}{
+		{HeadingCoverage{Words: 120, Percent: 0}, coverageMaxPoints, false},
+		{HeadingCoverage{Words: 800, Percent: 85}, coverageMaxPoints, false},
+		{HeadingCoverage{Words: 800, Percent: 56}, 8, false},
+		{HeadingCoverage{Words: 800, Percent: 21}, 3, true},
+	} {
+		points, issue := evaluateHeadingCoverage(&tc.coverage)
+		if points != tc.points || (issue != "") != tc.issue {
+			t.Errorf("%+v scores %d (issue %q), want %d", tc.coverage, points, issue, tc.points)
+		}
+	}
+}
+
+func TestGenericHeading(t *testing.T) {
+	for text, want := range map[string]bool{"Overview": true, "More info:": true, "Step 2": true, "FAQ": false, "Shaping the loaf": false, "Q": true} {
+		if got := isGenericHeading(text); got != want {
+			t.Errorf("isGenericHeading(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// codeBlock is a code block located in the content.
+type codeBlock struct {
+	span
+	language string
+}
+
+var (
+	// fencePattern matches Markdown code fences; the group is the info
+	// string's language.
+	fencePattern = regexp.MustCompile("(?ms)^```[ \\t]*([\\w+#.-]*)[^\\n]*\\n.*?^```[ \\t]*$")
+
+	// runnablePattern matches lines that make a code block something a
+	// reader can run as is: a shell command, a shebang, a program entry
+	// point or its imports.
+	runnablePattern = regexp.MustCompile(`(?m)^\s*(\$ \S|#!|package main\b|func main\(|if __name__ ==|import \S|from \S+ import |const \w+ = require\(|(curl|npm|npx|yarn|pnpm|pip|python3?|go|node|docker|kubectl|git|brew|cargo) \S)`)
+
+	// placeholderPattern matches elided lines that leave a block incomplete.
+	placeholderPattern = regexp.MustCompile(`(?m)^\s*((//|#)\s*)?(\.\.\.|…)\s*$`)
+
+	// markdownTableHeader matches the header row of a Markdown table.
+	markdownTableHeader = regexp.MustCompile(`(?m)^\|(.+)\|[ \t]*\n\|[ \t:|-]+\|[ \t]*$`)
+)
+
+// Header words of a parameter table: one naming the parameter and one
+// describing it.
+var (
+	parameterNameHeaders = []string{"parameter", "param", "name", "field", "option", "argument", "flag", "property", "key", "attribute"}
+	parameterInfoHeaders = []string{"type", "description", "default", "required", "values"}
+)
+
+// codeBlocks returns the code blocks in the content, in order: the <pre>
+// blocks of scraped pages and Markdown fences of raw content.
+func (d *document) codeBlocks() []codeBlock {
+	var blocks []codeBlock
+	from := 0
+	for _, b := range d.page.CodeBlocks {
+		idx := strings.Index(d.content[from:], b.Code)
+		if idx < 0 {
+			continue
+		}
+		start := from + idx
+		blocks = append(blocks, codeBlock{span: span{start, start + len(b.Code)}, language: b.Language})
+		from = start + len(b.Code)
+	}
+	for _, m := range fencePattern.FindAllStringSubmatchIndex(d.content, -1) {
+		blocks = append(blocks, codeBlock{span: span{m[0], m[1]}, language: strings.ToLower(d.content[m[2]:m[3]])})
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].start < blocks[j].start })
+	return blocks
+}
+
+// prose returns the document with its code blocks blanked out so sentence
+// metrics only see the text around them. Offsets stay valid for evidence.
+func (d *document) prose() *document {
+	blocks := d.codeBlocks()
+	if len(blocks) == 0 {
+		return d
+	}
+	masked := []byte(d.content)
+	for _, b := range blocks {
+		for i := b.start; i < b.end; i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+	prose := *d
+	prose.content = string(masked)
+	return &prose
+}
+
+// isRunnable reports whether a code block can be run as is.
+func (d *document) isRunnable(b codeBlock) bool {
+	code := d.content[b.start:b.end]
+	return runnablePattern.MatchString(code) && !placeholderPattern.MatchString(code)
+}
+
+// parameterTables counts the tables, HTML or Markdown, whose headers name
+// parameters and describe them.
+func (d *document) parameterTables() int {
+	count := 0
+	for _, t := range d.page.Tables {
+		if isParameterTable(t.Headers) {
+			count++
+		}
+	}
+	for _, m := range markdownTableHeader.FindAllStringSubmatch(d.content, -1) {
+		if isParameterTable(strings.Split(m[1], "|")) {
+			count++
+		}
+	}
+	return count
+}
+
+func isParameterTable(headers []string) bool {
+	named, described := false, false
+	for _, h := range headers {
+		h = strings.ToLower(strings.TrimSpace(h))
+		named = named || containsWord(parameterNameHeaders, h)
+		described = described || containsWord(parameterInfoHeaders, h)
+	}
+	return named && described
+}
+
+func containsWord(words []string, header string) bool {
+	for _, w := range strings.Fields(header) {
+		for _, want := range words {
+			if w == want || w == want+"s" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// codeSnippet quotes the first line of code in a block for evidence.
+func (d *document) codeSnippet(b codeBlock) Evidence {
+	ev := d.evidence(b.span)
+	for _, line := range strings.Split(d.content[b.start:b.end], "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "```") {
+			ev.Snippet = line
+			break
+		}
+	}
+	return ev
+}
+
+// evaluateCodeLanguages scores the share of code blocks that declare their
+// language (25 points). It returns the issue to report, if any, with the
+// unannotated blocks as evidence. Pages without code fall back to the
+// general parsing rule.
+func (ls *LocalScorer) evaluateCodeLanguages(doc *document) (int, string, []Evidence) {
+	blocks := doc.codeBlocks()
+	if len(blocks) == 0 {
+		score := ls.evaluateParsingFriendliness(doc.content)
+		if score >= 15 {
+			return score, "", nil
+		}
+		return score, "Structure content for better machine readability", nil
+	}
+
+	var evidence []Evidence
+	missing := 0
+	for _, b := range blocks {
+		if b.language == "" {
+			missing++
+			if len(evidence) < maxEvidence {
+				evidence = append(evidence, doc.codeSnippet(b))
+			}
+		}
+	}
+	if missing == 0 {
+		return 25, "", nil
+	}
+	issue := fmt.Sprintf("Annotate code blocks with their language (e.g. ```python): %d of %d have none", missing, len(blocks))
+	return 25 * (len(blocks) - missing) / len(blocks), issue, evidence
+}
+
+// evaluateRunnableExamples scores complete code examples (35 points): 5
+// without code, 15 for fragments only, 25 for one or two runnable examples
+// and 35 for three or more. It returns incomplete blocks as evidence.
+func (ls *LocalScorer) evaluateRunnableExamples(doc *document) (int, []Evidence) {
+	blocks := doc.codeBlocks()
+	if len(blocks) == 0 {
+		return 5, nil
+	}
+
+	runnable := 0
+	var evidence []Evidence
+	for _, b := range blocks {
+		if doc.isRunnable(b) {
+			runnable++
+		} else if len(evidence) < maxEvidence {
+			evidence = append(evidence, doc.codeSnippet(b))
+		}
+	}
+	switch {
+	case runnable >= 3:
+		return 35, nil
+	case runnable > 0:
+		return 25, evidence
+	}
+	return 15, evidence
+}
+
+// evaluateParameterTables scores reference tables (20 points). Without a
+// parameter table, list usage earns at most 15.
+func (ls *LocalScorer) evaluateParameterTables(doc *document) int {
+	if doc.parameterTables() > 0 {
+		return 20
+	}
+	return min(ls.evaluateListUsage(doc), 15)
+}
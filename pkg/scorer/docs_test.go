@@ -0,0 +1,64 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestDocsProfile(t *testing.T) {
+	content := "Quickstart\n\n" +
+		"Install the client and call it from your program.\n\n" +
+		"```bash\n$ go get example.com/client\n```\n\n" +
+		"```\nclient.Run(ctx, options, handler, logger, metrics, tracer, retries, timeout, backoff, limiter)\n```\n\n" +
+		"| Parameter | Type | Description |\n|---|---|---|\n| timeout | int | Seconds to wait |"
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 1, Text: "Quickstart"}}}
+
+	doc := newDocument(content, page)
+	blocks := doc.codeBlocks()
+	if len(blocks) != 2 || blocks[0].language != "bash" || blocks[1].language != "" {
+		t.Fatalf("code blocks = %+v", blocks)
+	}
+	if !doc.isRunnable(blocks[0]) || doc.isRunnable(blocks[1]) {
+		t.Errorf("runnable = %v, %v; want true, false", doc.isRunnable(blocks[0]), doc.isRunnable(blocks[1]))
+	}
+	if strings.Contains(doc.prose().content, "client.Run") {
+		t.Error("prose still contains code")
+	}
+
+	ls := NewLocalScorer()
+	ls.SetProfile(ProfileDocs)
+	score := ls.AnalyzeContent(content, page)
+	found := map[string]Finding{}
+	for _, pillar := range []ScoreDetail{score.Breakdown.ContentStructure, score.Breakdown.ContextRichness, score.Breakdown.Accessibility} {
+		for _, f := range pillar.Findings {
+			found[f.ID] = f
+		}
+	}
+	if _, ok := found["docs.parameters"]; ok {
+		t.Error("parameter table not recognized")
+	}
+	if _, ok := found["docs.examples"]; ok {
+		t.Error("runnable example not recognized")
+	}
+	langs, ok := found["docs.code_languages"]
+	if !ok || len(langs.Evidence) != 1 || !strings.HasPrefix(langs.Evidence[0].Snippet, "client.Run") {
+		t.Errorf("code language finding = %+v", langs)
+	}
+	if score.Metadata["profile"] != "docs" {
+		t.Errorf("profile metadata = %v", score.Metadata["profile"])
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	if p, err := ParseProfile(""); err != nil || p != ProfileGeneral {
+		t.Errorf("empty profile = %q, %v", p, err)
+	}
+	if p, err := ParseProfile("Docs"); err != nil || p != ProfileDocs {
+		t.Errorf("Docs = %q, %v", p, err)
+	}
+	if _, err := ParseProfile("recipes"); err == nil {
+		t.Error("unknown profile accepted")
+	}
+}
@@ -0,0 +1,50 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// experienceMaxPoints is what the first-hand experience rule contributes
+// to the Authority Signals pillar.
+const experienceMaxPoints = 10
+
+var (
+	// experiencePattern matches first-hand experience: what the authors
+	// tested, measured or used themselves ("we tested", "in our benchmark",
+	// "I've used").
+	experiencePattern = regexp.MustCompile(`(?i)\b((we|i|our team)( have|'ve| had| personally)? (tested|tried|measured|benchmarked|ran|surveyed|interviewed|analy[sz]ed|reviewed|compared|used|built|deployed|audited|observed|spent)|in (our|my) (own )?(tests?|testing|benchmarks?|experiments?|study|survey|analysis|experience|review|lab)|hands-on|first-?hand)\b`)
+
+	// originalDataPattern matches references to data the authors produced.
+	originalDataPattern = regexp.MustCompile(`(?i)\b((our|my) (own )?(data|dataset|research|benchmarks?|survey|findings|results|measurements)|original (data|research)|proprietary data|we collected|(we|i) recorded)\b`)
+)
+
+// evaluateExperience scores first-hand experience, the first E of E-E-A-T
+// and distinct from claims of expertise (10 points): 10 for two or more
+// experience statements backed by the authors' own data or figures, 6 for
+// experience without data and 2 without any. It returns the issue to
+// report, if any, and the statements as evidence.
+func evaluateExperience(doc *document) (int, string, []Evidence, int) {
+	var markers []span
+	data := false
+	for _, s := range doc.proseSentences() {
+		text := doc.content[s.start:s.end]
+		experience, original := experiencePattern.MatchString(text), originalDataPattern.MatchString(text)
+		if experience || original {
+			markers = append(markers, s)
+			data = data || original || (experience && hasFigure.MatchString(text))
+		}
+	}
+
+	var evidence []Evidence
+	for i := 0; i < len(markers) && i < maxEvidence; i++ {
+		evidence = append(evidence, doc.evidence(markers[i]))
+	}
+	switch {
+	case len(markers) >= 2 && data:
+		return experienceMaxPoints, "", nil, len(markers)
+	case len(markers) > 0:
+		return 6, fmt.Sprintf("Back your first-hand experience with your own results, measurements or benchmark figures (%d statements)", len(markers)), evidence, len(markers)
+	}
+	return 2, "Show first-hand experience: say what you tested, measured or used yourself and what you found", nil, 0
+}
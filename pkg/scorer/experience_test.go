@@ -0,0 +1,30 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestExperience(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"expertise claims only", "Our certified experts are experienced professionals. They follow an evidence-based methodology.", 2},
+		{"experience without data", "We tested every router in a small apartment. In our experience the mesh models were easier to set up.", 6},
+		{"experience with figures", "We tested every router in a small apartment. In our benchmark the mesh models reached 480 Mbps in the bedroom.", 10},
+		{"experience with original data", "I've used the app daily since launch. Our own survey of readers points the same way.", 10},
+	}
+	for _, tt := range tests {
+		doc := newDocument(tt.content, &webpage.PageData{Content: tt.content})
+		score, issue, evidence, _ := evaluateExperience(doc)
+		if score != tt.want {
+			t.Errorf("%s: score %d, want %d (%q)", tt.name, score, tt.want, issue)
+		}
+		if score == 6 && (len(evidence) != 2 || !strings.HasPrefix(evidence[0].Snippet, "We tested")) {
+			t.Errorf("%s: evidence = %+v, want the experience statements", tt.name, evidence)
+		}
+	}
+}
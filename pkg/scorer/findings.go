@@ -0,0 +1,298 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Finding is an issue raised by a scoring rule, with the text that
+// triggered it.
+type Finding struct {
+	ID       string     `json:"id"`
+	Pillar   string     `json:"pillar"`
+	Message  string     `json:"message"`
+	Severity string     `json:"severity"`
+	Impact   int        `json:"impact"` // overall points gained if the rule passed
+	Merged   []string   `json:"merged,omitempty"`
+	Evidence []Evidence `json:"evidence,omitempty"`
+
+	// Points scored and available on the rule; the shortfall weighted by the
+	// pillar is what fixing the finding could add to the overall score.
+	points    int
+	maxPoints int
+}
+
+// Finding severities, most severe first.
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+// Evidence points at the part of the page behind a finding. Start and End
+// are byte offsets into the extracted content, or -1 when the evidence comes
+// from outside it (e.g. meta tags).
+type Evidence struct {
+	Snippet     string   `json:"snippet"`
+	Start       int      `json:"start"`
+	End         int      `json:"end"`
+	HeadingPath []string `json:"heading_path,omitempty"`
+}
+
+// maxEvidence caps the evidence attached to one finding.
+const maxEvidence = 3
+
+// maxSnippet caps the length of a quoted snippet.
+const maxSnippet = 160
+
+// document indexes the extracted content once per analysis so rules can
+// point at paragraphs, sentences and headings.
+type document struct {
+	content  string
+	page     *webpage.PageData
+	headings []headingPos
+	advice   []FormatAdvice
+	answer   *AnswerAdvice
+	summary  *SummaryAdvice
+	opening  *SectionAdvice
+	closing  *SectionAdvice
+	anchors  []AnchorSuggestion
+	cited    []CitedDomain
+	wall     *Paywall
+	schema   []SchemaCheck
+	sections int       // H2 and H3 headings
+	updated  time.Time // latest date the page shows or declares
+	coverage *HeadingCoverage
+
+	readingGrade float64 // Flesch-Kincaid grade of the prose
+	experience   int     // first-hand experience statements
+}
+
+type headingPos struct {
+	offset int
+	level  int
+	text   string
+}
+
+type span struct {
+	start, end int
+}
+
+func newDocument(content string, pageData *webpage.PageData) *document {
+	doc := &document{content: content, page: pageData}
+
+	// Extracted content lists headings as their own blocks, in page order
+	from := 0
+	for _, h := range pageData.Headings {
+		idx := strings.Index(content[from:], h.Text)
+		if idx < 0 {
+			continue
+		}
+		doc.headings = append(doc.headings, headingPos{offset: from + idx, level: h.Level, text: h.Text})
+		from += idx + len(h.Text)
+	}
+	return doc
+}
+
+// headingPath returns the chain of headings enclosing offset.
+func (d *document) headingPath(offset int) []string {
+	var stack []headingPos
+	for _, h := range d.headings {
+		if h.offset > offset {
+			break
+		}
+		for len(stack) > 0 && stack[len(stack)-1].level >= h.level {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, h)
+	}
+
+	path := make([]string, len(stack))
+	for i, h := range stack {
+		path[i] = h.text
+	}
+	return path
+}
+
+func (d *document) isHeading(s span) bool {
+	text := strings.TrimSpace(d.content[s.start:s.end])
+	for _, h := range d.headings {
+		if h.offset >= s.start && h.offset < s.end && text == h.text {
+			return true
+		}
+	}
+	return false
+}
+
+// evidence quotes the content between start and end.
+func (d *document) evidence(s span) Evidence {
+	snippet := strings.Join(strings.Fields(d.content[s.start:s.end]), " ")
+	if len(snippet) > maxSnippet {
+		cut := strings.LastIndex(snippet[:maxSnippet], " ")
+		if cut <= 0 {
+			cut = maxSnippet
+		}
+		snippet = snippet[:cut] + "…"
+	}
+	return Evidence{Snippet: snippet, Start: s.start, End: s.end, HeadingPath: d.headingPath(s.start)}
+}
+
+// paragraphs returns the spans of the blank-line separated blocks the
+// scorer treats as paragraphs.
+func (d *document) paragraphs() []span {
+	var spans []span
+	start := 0
+	for start < len(d.content) {
+		end := strings.Index(d.content[start:], "\n\n")
+		if end < 0 {
+			end = len(d.content)
+		} else {
+			end += start
+		}
+		if strings.TrimSpace(d.content[start:end]) != "" {
+			spans = append(spans, trimSpan(d.content, span{start, end}))
+		}
+		start = end + 2
+	}
+	return spans
+}
+
+func (d *document) sentences() []span {
+	return sentenceSpans(d.content)
+}
+
+func trimSpan(content string, s span) span {
+	for s.start < s.end && isSpace(content[s.start]) {
+		s.start++
+	}
+	for s.end > s.start && isSpace(content[s.end-1]) {
+		s.end--
+	}
+	return s
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\n' || b == '\t' || b == '\r'
+}
+
+// wordCount returns the length of a span in English-word equivalents, the
+// unit of every length threshold.
+func wordCount(content string, s span) int {
+	return depthWords(content[s.start:s.end])
+}
+
+// longest returns up to n spans with the most words, above minWords.
+func (d *document) longest(spans []span, minWords, n int) []Evidence {
+	var candidates []span
+	for _, s := range spans {
+		if wordCount(d.content, s) > minWords {
+			candidates = append(candidates, s)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return wordCount(d.content, candidates[i]) > wordCount(d.content, candidates[j])
+	})
+
+	var out []Evidence
+	for i := 0; i < len(candidates) && i < n; i++ {
+		out = append(out, d.evidence(candidates[i]))
+	}
+	return out
+}
+
+// containing returns up to n sentences that contain any of the phrases.
+func (d *document) containing(phrases []string, n int) []Evidence {
+	quoted := make([]string, len(phrases))
+	for i, phrase := range phrases {
+		quoted[i] = regexp.QuoteMeta(phrase)
+	}
+	pattern := regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)\b`)
+
+	var out []Evidence
+	for _, s := range d.sentences() {
+		if pattern.MatchString(strings.ToLower(d.content[s.start:s.end])) {
+			out = append(out, d.evidence(s))
+			if len(out) == n {
+				break
+			}
+		}
+	}
+	return out
+}
+
+// addIssue records an issue on the pillar both as plain text and as a
+// finding with evidence.
+func (detail *ScoreDetail) addIssue(pillar, id, message string, points, maxPoints int, evidence ...Evidence) {
+	detail.Issues = append(detail.Issues, message)
+	detail.Findings = append(detail.Findings, Finding{
+		ID:        id,
+		Pillar:    pillar,
+		Message:   message,
+		Evidence:  evidence,
+		points:    points,
+		maxPoints: maxPoints,
+	})
+}
+
+// Evidence collectors for the rules whose failure can be traced to
+// specific text. Rules about missing content (no lists, no examples) have
+// nothing to point at and carry no evidence.
+
+func (d *document) headingEvidence() []Evidence {
+	var out []Evidence
+	hasH1 := false
+	for _, h := range d.page.Headings {
+		hasH1 = hasH1 || h.Level == 1
+	}
+	if !hasH1 {
+		out = append(out, Evidence{Snippet: "no H1 heading", Start: -1, End: -1})
+	}
+	for i := 1; i < len(d.headings) && len(out) < maxEvidence; i++ {
+		prev, h := d.headings[i-1], d.headings[i]
+		if h.level > prev.level+1 {
+			ev := d.evidence(span{h.offset, h.offset + len(h.text)})
+			ev.Snippet = fmt.Sprintf("H%d → H%d: %s", prev.level, h.level, ev.Snippet)
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func (d *document) paragraphEvidence() []Evidence {
+	if long := d.longest(d.paragraphs(), 150, maxEvidence); len(long) > 0 {
+		return long
+	}
+
+	// Otherwise point at the short fragments that drag the ratio down
+	var out []Evidence
+	for _, p := range d.paragraphs() {
+		if wordCount(d.content, p) < 20 && !d.isHeading(p) {
+			out = append(out, d.evidence(p))
+			if len(out) == maxEvidence {
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (d *document) longSentenceEvidence() []Evidence {
+	return d.longest(d.sentences(), 25, maxEvidence)
+}
+
+func (d *document) metaEvidence() []Evidence {
+	var out []Evidence
+	if d.page.Title == "" {
+		out = append(out, Evidence{Snippet: "<title> is missing", Start: -1, End: -1})
+	}
+	if desc := d.page.MetaTags["description"]; desc == "" {
+		out = append(out, Evidence{Snippet: "meta description is missing", Start: -1, End: -1})
+	} else if len(desc) <= 50 {
+		out = append(out, Evidence{Snippet: "meta description: " + desc, Start: -1, End: -1})
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFindingEvidenceOffsets(t *testing.T) {
+	long := strings.Repeat("This sentence keeps going without a break and ", 20) + "ends here."
+	content := "Guide\n\nSetup\n\n" + long + "\n\nShort note."
+	page := &webpage.PageData{
+		Title:    "Guide",
+		MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 1, Text: "Guide"}, {Level: 3, Text: "Setup"}},
+	}
+
+	score := NewLocalScorer().AnalyzeContent(content, page)
+
+	found := map[string]Finding{}
+	for _, f := range score.Findings {
+		found[f.ID] = f
+	}
+
+	headings, ok := found["structure.headings"]
+	if !ok || len(headings.Evidence) == 0 {
+		t.Fatalf("expected heading finding with evidence, got %+v", score.Findings)
+	}
+	if got := headings.Evidence[0].Snippet; got != "H1 → H3: Setup" {
+		t.Errorf("heading evidence = %q", got)
+	}
+
+	paragraphs, ok := found["structure.paragraphs"]
+	if !ok || len(paragraphs.Evidence) == 0 {
+		t.Fatalf("expected paragraph finding with evidence, got %+v", score.Findings)
+	}
+	ev := paragraphs.Evidence[0]
+	if content[ev.Start:ev.End] != long {
+		t.Errorf("offsets %d-%d do not cover the long paragraph", ev.Start, ev.End)
+	}
+	if strings.Join(ev.HeadingPath, "/") != "Guide/Setup" {
+		t.Errorf("heading path = %v", ev.HeadingPath)
+	}
+
+	meta, ok := found["accessibility.meta"]
+	if !ok || len(meta.Evidence) == 0 || meta.Evidence[0].Start != -1 {
+		t.Errorf("expected meta evidence outside the content, got %+v", meta)
+	}
+}
+
+func TestGenerateInsightsMergesAndRanks(t *testing.T) {
+	ls := NewLocalScorer()
+	score := &GEOScore{Overall: 90}
+	score.Breakdown.SemanticClarity = ScoreDetail{Score: 40, MaxScore: 100, Percentage: 40}
+	score.Breakdown.SemanticClarity.addIssue("semantic_clarity", "clarity.readability", "Use shorter sentences", 0, 40,
+		Evidence{Snippet: "a long sentence", Start: 0, End: 15})
+	score.Breakdown.Accessibility = ScoreDetail{Score: 80, MaxScore: 100, Percentage: 80}
+	score.Breakdown.Accessibility.addIssue("accessibility", "accessibility.density", "Use shorter sentences.", 20, 35,
+		Evidence{Snippet: "a long sentence", Start: 0, End: 15})
+	score.Breakdown.ContentStructure = ScoreDetail{Score: 70, MaxScore: 100, Percentage: 70}
+	score.Breakdown.ContentStructure.addIssue("content_structure", "structure.lists", "Consider using lists", 5, 20)
+	score.Breakdown.ContentStructure.addIssue("content_structure", "structure.paragraphs", "consider using lists.", 20, 25)
+	score.Breakdown.ContextRichness = ScoreDetail{Score: 100, MaxScore: 100, Percentage: 100}
+	score.Breakdown.AuthoritySignals = ScoreDetail{Score: 100, MaxScore: 100, Percentage: 100}
+	score.Breakdown.StructuredData = ScoreDetail{Score: 100, MaxScore: 100, Percentage: 100}
+
+	ls.generateInsights(score)
+
+	if len(score.Findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", score.Findings)
+	}
+	first := score.Findings[0]
+	if first.ID != "sentences.length" || first.Severity != SeverityHigh || len(first.Merged) != 2 || len(first.Evidence) != 1 {
+		t.Errorf("merged finding = %+v", first)
+	}
+	if score.Findings[1].ID != "structure.lists" || score.Findings[1].Severity != SeverityMedium {
+		t.Errorf("second finding = %+v", score.Findings[1])
+	}
+	want := int(math.Round(40*ls.weights.SemanticClarity)) + int(math.Round(15*ls.weights.Accessibility))
+	if first.Impact != want {
+		t.Errorf("merged impact = %d, want %d", first.Impact, want)
+	}
+	if got := score.WithImpact("Use Shorter Sentences"); got != "Use Shorter Sentences" {
+		t.Errorf("unmatched suggestion annotated: %q", got)
+	}
+	if got := score.WithImpact(first.Message); !strings.HasSuffix(got, fmt.Sprintf("(+%d pts)", want)) {
+		t.Errorf("WithImpact = %q", got)
+	}
+	if len(score.Weaknesses) != 1 || score.Weaknesses[0] != "Semantic Clarity is weak (40/100)" {
+		t.Errorf("weaknesses = %v", score.Weaknesses)
+	}
+}
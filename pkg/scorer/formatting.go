@@ -0,0 +1,164 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of formatting advice.
+const (
+	FormatTable = "table"
+	FormatList  = "list"
+)
+
+// FormatAdvice points at prose that carries tabular or list data. Answer
+// engines lift tables and lists far more reliably than the same facts spread
+// over sentences.
+type FormatAdvice struct {
+	Kind       string   `json:"kind"`
+	Suggestion string   `json:"suggestion"`
+	Items      []string `json:"items,omitempty"` // labels or entries detected in the passage
+	Evidence   Evidence `json:"evidence"`
+	Draft      string   `json:"draft,omitempty"` // Markdown rewrite drafted by an LLM, on request
+}
+
+const (
+	// minKeyValueRun is the number of consecutive "Label: value" sentences
+	// that make a table.
+	minKeyValueRun = 3
+
+	// minNumericItems is the number of enumerated items with figures that
+	// make a sentence a comparison.
+	minNumericItems = 3
+
+	// minInlineItems is the number of short enumerated items that make a
+	// sentence a list.
+	minInlineItems = 4
+
+	// maxFormatAdvice caps the advice given for one page.
+	maxFormatAdvice = 5
+)
+
+var (
+	keyValuePattern = regexp.MustCompile(`^([A-Z][^:.!?\n]{0,38}[^\s:]):\s+\S`)
+	itemSeparator   = regexp.MustCompile(`\s*(?:,|;)\s*(?:and\s+|or\s+)?|\s+(?:and|or|vs\.?|versus)\s+`)
+	hasFigure       = regexp.MustCompile(`\d`)
+)
+
+// formattingAdvice finds runs of "Label: value" sentences and enumerations
+// inside sentences that would read better as a table or list.
+func (d *document) formattingAdvice() []FormatAdvice {
+	var advice []FormatAdvice
+	sentences := d.sentences()
+
+	for i := 0; i < len(sentences) && len(advice) < maxFormatAdvice; {
+		run, labels := d.keyValueRun(sentences[i:])
+		if run >= minKeyValueRun {
+			passage := span{sentences[i].start, sentences[i+run-1].end}
+			advice = append(advice, FormatAdvice{
+				Kind:       FormatTable,
+				Suggestion: fmt.Sprintf("Present these %d \"label: value\" statements as a two-column table", run),
+				Items:      labels,
+				Evidence:   d.evidence(passage),
+			})
+			i += run
+			continue
+		}
+
+		if a, ok := d.enumerationAdvice(sentences[i]); ok {
+			advice = append(advice, a)
+		}
+		i++
+	}
+	return advice
+}
+
+// keyValueRun returns how many of the leading sentences are "Label: value"
+// statements, and their labels.
+func (d *document) keyValueRun(sentences []span) (int, []string) {
+	var labels []string
+	for _, s := range sentences {
+		m := keyValuePattern.FindStringSubmatch(d.content[s.start:s.end])
+		if m == nil || len(strings.Fields(m[1])) > 5 || d.isHeading(s) {
+			break
+		}
+		labels = append(labels, m[1])
+	}
+	return len(labels), labels
+}
+
+// enumerationAdvice recognizes a sentence that compares several items by
+// figures (a table) or enumerates many short items (a list).
+func (d *document) enumerationAdvice(s span) (FormatAdvice, bool) {
+	text := strings.TrimRight(d.content[s.start:s.end], ".!?")
+	// Plain lists are only recognized after an introducing colon; without
+	// one, ordinary sentences with a few commas would qualify
+	introduced := false
+	if i := strings.Index(text, ": "); i >= 0 {
+		text, introduced = text[i+2:], true
+	}
+
+	var items []string
+	for _, part := range itemSeparator.Split(text, -1) {
+		if part = strings.TrimSpace(part); part != "" {
+			items = append(items, part)
+		}
+	}
+
+	numeric := 0
+	short := 0
+	for _, item := range items {
+		if hasFigure.MatchString(item) {
+			numeric++
+		}
+		if len(strings.Fields(item)) <= 4 {
+			short++
+		}
+	}
+
+	switch {
+	case numeric >= minNumericItems:
+		return FormatAdvice{
+			Kind:       FormatTable,
+			Suggestion: fmt.Sprintf("Turn this comparison of %d items into a table with one row per item", numeric),
+			Items:      truncateItems(items),
+			Evidence:   d.evidence(s),
+		}, true
+	case introduced && short >= minInlineItems && short == len(items):
+		return FormatAdvice{
+			Kind:       FormatList,
+			Suggestion: fmt.Sprintf("Break this enumeration of %d items into a bulleted list", len(items)),
+			Items:      truncateItems(items),
+			Evidence:   d.evidence(s),
+		}, true
+	}
+	return FormatAdvice{}, false
+}
+
+func truncateItems(items []string) []string {
+	const maxItem = 40
+	out := make([]string, len(items))
+	for i, item := range items {
+		if len(item) > maxItem {
+			cut := maxItem
+			for cut > 0 && !utf8.RuneStart(item[cut]) {
+				cut--
+			}
+			item = item[:cut] + "…"
+		}
+		out[i] = item
+	}
+	return out
+}
+
+// formatEvidence returns the passages behind the formatting advice, as
+// evidence for the list-usage rule.
+func formatEvidence(advice []FormatAdvice) []Evidence {
+	var out []Evidence
+	for i := 0; i < len(advice) && i < maxEvidence; i++ {
+		out = append(out, advice[i].Evidence)
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestFormattingAdvice(t *testing.T) {
+	content := "Product details\n\n" +
+		"Price: $49 per month. Storage: 2 TB. Support: Email and chat. Contract: None.\n\n" +
+		"The Basic plan costs $10, the Pro plan costs $25 and the Team plan costs $60 per seat.\n\n" +
+		"Supported formats include: PDF, DOCX, Markdown, HTML and plain text.\n\n" +
+		"We started in a garage, moved to an office, and never looked back."
+	page := &webpage.PageData{Title: "Plans", Content: content, MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 1, Text: "Product details"}}}
+
+	score := NewLocalScorer().AnalyzeContent(content, page)
+	advice := score.Formatting
+	if len(advice) != 3 {
+		t.Fatalf("got %d pieces of advice, want 3: %+v", len(advice), advice)
+	}
+
+	if a := advice[0]; a.Kind != FormatTable || len(a.Items) != 4 || a.Items[0] != "Price" {
+		t.Errorf("key-value advice = %+v", a)
+	}
+	if a := advice[1]; a.Kind != FormatTable || len(a.Items) != 3 {
+		t.Errorf("comparison advice = %+v", a)
+	}
+	if a := advice[2]; a.Kind != FormatList || len(a.Items) != 5 || a.Items[4] != "plain text" {
+		t.Errorf("enumeration advice = %+v", a)
+	}
+	for _, a := range advice {
+		if got := content[a.Evidence.Start:a.Evidence.End]; got == "" {
+			t.Errorf("advice %q has no evidence span", a.Suggestion)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package scorer
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Framework maps reports onto an organization's own content-quality
+// framework: it renames built-in pillars, overrides their weights and adds
+// pillars scored by rules of its own. It is read from a YAML (or JSON)
+// file; see examples/framework.yaml.
+type Framework struct {
+	Pillars []PillarDefinition `yaml:"pillars"`
+}
+
+// PillarDefinition customizes a built-in pillar, named by its JSON key
+// (e.g. "content_structure"), or defines a new one scored by Rules.
+type PillarDefinition struct {
+	ID     string       `yaml:"id"`
+	Name   string       `yaml:"name"`   // display name; empty keeps the built-in one
+	Weight *float64     `yaml:"weight"` // nil keeps the profile's weight
+	Rules  []CustomRule `yaml:"rules"`  // custom pillars only
+}
+
+// CustomRule awards its points when Pattern matches the part of the page
+// named by In at least Min and at most Max times. Without Min or Max the
+// pattern must match at least once; with only Max it may not match more
+// often, so Max 0 forbids the pattern.
+type CustomRule struct {
+	ID      string `yaml:"id"`
+	Message string `yaml:"message"` // the recommendation when the rule fails
+	Points  int    `yaml:"points"`
+	Pattern string `yaml:"pattern"` // regular expression
+	In      string `yaml:"in"`      // content (default), title, headings or meta:<name>
+	Min     *int   `yaml:"min"`
+	Max     *int   `yaml:"max"`
+
+	re *regexp.Regexp
+}
+
+// builtinPillars are the JSON keys of the built-in pillars, in report order.
+var builtinPillars = []string{"content_structure", "semantic_clarity", "context_richness", "authority_signals", "accessibility", "structured_data"}
+
+// LoadFramework reads and validates a framework file.
+func LoadFramework(path string) (*Framework, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read framework: %w", err)
+	}
+	f := &Framework{}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse framework: %w", err)
+	}
+	if err := f.compile(); err != nil {
+		return nil, fmt.Errorf("invalid framework %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// WithWeights returns a copy of f that also weights the pillars in weights,
+// by pillar id, that f does not weight itself. f may be nil.
+func (f *Framework) WithWeights(weights map[string]float64) (*Framework, error) {
+	merged := &Framework{}
+	if f != nil {
+		merged.Pillars = append(merged.Pillars, f.Pillars...)
+	}
+	ids := make([]string, 0, len(weights))
+	for id := range weights {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		weight := weights[id]
+		if weight < 0 {
+			return nil, fmt.Errorf("pillar %q has a negative weight", id)
+		}
+		found := false
+		for i := range merged.Pillars {
+			if p := &merged.Pillars[i]; p.ID == id {
+				if p.Weight == nil {
+					p.Weight = &weight
+				}
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+		if !isBuiltinPillar(id) {
+			return nil, fmt.Errorf("unknown pillar %q (want one of %s)", id, strings.Join(builtinPillars, ", "))
+		}
+		merged.Pillars = append(merged.Pillars, PillarDefinition{ID: id, Weight: &weight})
+	}
+	return merged, nil
+}
+
+// compile checks the definitions and compiles the rule patterns.
+func (f *Framework) compile() error {
+	seen := make(map[string]bool)
+	rules := make(map[string]bool)
+	for i := range f.Pillars {
+		p := &f.Pillars[i]
+		switch {
+		case p.ID == "":
+			return fmt.Errorf("pillar %d has no id", i+1)
+		case seen[p.ID]:
+			return fmt.Errorf("duplicate pillar %q", p.ID)
+		case p.Weight != nil && *p.Weight < 0:
+			return fmt.Errorf("pillar %q has a negative weight", p.ID)
+		}
+		seen[p.ID] = true
+
+		if isBuiltinPillar(p.ID) {
+			if len(p.Rules) > 0 {
+				return fmt.Errorf("built-in pillar %q cannot take custom rules", p.ID)
+			}
+			continue
+		}
+		if len(p.Rules) == 0 {
+			return fmt.Errorf("pillar %q is not built in and has no rules", p.ID)
+		}
+		if p.Name == "" {
+			p.Name = p.ID
+		}
+		for j := range p.Rules {
+			r := &p.Rules[j]
+			switch {
+			case r.ID == "":
+				return fmt.Errorf("rule %d of pillar %q has no id", j+1, p.ID)
+			case rules[r.ID]:
+				return fmt.Errorf("duplicate rule %q", r.ID)
+			case r.Points <= 0:
+				return fmt.Errorf("rule %q needs positive points", r.ID)
+			case r.Message == "":
+				return fmt.Errorf("rule %q has no message", r.ID)
+			case r.Pattern == "":
+				return fmt.Errorf("rule %q has no pattern", r.ID)
+			case r.In != "" && r.In != "content" && r.In != "title" && r.In != "headings" && !strings.HasPrefix(r.In, "meta:"):
+				return fmt.Errorf("rule %q checks unknown part %q (want content, title, headings or meta:<name>)", r.ID, r.In)
+			}
+			rules[r.ID] = true
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return fmt.Errorf("rule %q: %w", r.ID, err)
+			}
+			r.re = re
+		}
+	}
+	return nil
+}
+
+// PillarKeys returns the keys of the pillars a report scores under the
+// framework: the built-in ones, then its custom ones. f may be nil.
+func (f *Framework) PillarKeys() []string {
+	keys := append([]string(nil), builtinPillars...)
+	if f != nil {
+		for _, p := range f.Pillars {
+			if !isBuiltinPillar(p.ID) {
+				keys = append(keys, p.ID)
+			}
+		}
+	}
+	return keys
+}
+
+func isBuiltinPillar(key string) bool {
+	for _, b := range builtinPillars {
+		if key == b {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFramework applies a framework's names and weights, and scores its
+// custom pillars from now on. A nil framework restores the built-in report.
+func (ls *LocalScorer) SetFramework(f *Framework) {
+	ls.framework = f
+	ls.applyFramework()
+}
+
+// applyFramework overrides the profile's weights with the framework's and
+// normalizes them, recording what it had to fix in ls.warnings.
+func (ls *LocalScorer) applyFramework() {
+	ls.customWeights, ls.warnings = nil, nil
+	if ls.framework == nil {
+		return
+	}
+	for _, p := range ls.framework.Pillars {
+		if p.Weight == nil {
+			continue
+		}
+		switch p.ID {
+		case "content_structure":
+			ls.weights.ContentStructure = *p.Weight
+		case "semantic_clarity":
+			ls.weights.SemanticClarity = *p.Weight
+		case "context_richness":
+			ls.weights.ContextRichness = *p.Weight
+		case "authority_signals":
+			ls.weights.AuthoritySignals = *p.Weight
+		case "accessibility":
+			ls.weights.Accessibility = *p.Weight
+		case "structured_data":
+			ls.weights.StructuredData = *p.Weight
+		}
+	}
+	ls.normalizeWeights()
+}
+
+// Warnings returns the problems the framework's weights and rule points
+// have under the general profile; scores correct them but they are
+// likely mistakes.
+func (f *Framework) Warnings() []string {
+	ls := NewLocalScorer()
+	ls.SetFramework(f)
+	return ls.warnings
+}
+
+// definition returns the framework's definition of a pillar, if any.
+func (ls *LocalScorer) definition(key string) *PillarDefinition {
+	if ls.framework == nil {
+		return nil
+	}
+	for i, p := range ls.framework.Pillars {
+		if p.ID == key {
+			return &ls.framework.Pillars[i]
+		}
+	}
+	return nil
+}
+
+// pillarName is the display name of a pillar under the framework.
+func (ls *LocalScorer) pillarName(key string) string {
+	if p := ls.definition(key); p != nil && p.Name != "" {
+		return p.Name
+	}
+	return PillarName(key)
+}
+
+// analyzeCustomPillars scores the framework's own pillars. Each scores the
+// points of the rules it passes, scaled to 100.
+func (ls *LocalScorer) analyzeCustomPillars(doc *document) []CustomPillar {
+	if ls.framework == nil {
+		return nil
+	}
+	var pillars []CustomPillar
+	for _, p := range ls.framework.Pillars {
+		if isBuiltinPillar(p.ID) {
+			continue
+		}
+		detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}, Findings: []Finding{}}
+		total := p.points()
+		for _, r := range p.Rules {
+			points, evidence := r.evaluate(doc)
+			// Rules whose points do not add up to 100 are scaled to 100
+			detail.Score += scalePoints(points, total, detail.MaxScore)
+			if points == r.Points {
+				continue
+			}
+			detail.addIssue(p.ID, r.ID, r.Message, scalePoints(points, total, detail.MaxScore), scalePoints(r.Points, total, detail.MaxScore), evidence...)
+		}
+		detail.Score = min(detail.Score, detail.MaxScore)
+		detail.Percentage = float64(detail.Score) / float64(detail.MaxScore) * 100
+		pillars = append(pillars, CustomPillar{ID: p.ID, ScoreDetail: detail})
+	}
+	return pillars
+}
+
+// evaluate returns the rule's points, or 0 and evidence when it fails.
+func (r CustomRule) evaluate(doc *document) (int, []Evidence) {
+	var texts []string
+	switch {
+	case r.In == "title":
+		texts = []string{doc.page.Title}
+	case r.In == "headings":
+		for _, h := range doc.page.Headings {
+			texts = append(texts, h.Text)
+		}
+	case strings.HasPrefix(r.In, "meta:"):
+		texts = []string{doc.page.MetaTags[strings.TrimPrefix(r.In, "meta:")]}
+	default:
+		texts = []string{doc.content}
+	}
+	count := 0
+	for _, text := range texts {
+		count += len(r.re.FindAllStringIndex(text, -1))
+	}
+
+	lo := 1
+	if r.Min != nil {
+		lo = *r.Min
+	} else if r.Max != nil {
+		lo = 0
+	}
+	if count >= lo && (r.Max == nil || count <= *r.Max) {
+		return r.Points, nil
+	}
+
+	// Point at the sentences holding matches that should not be there
+	var evidence []Evidence
+	if r.Max != nil && count > *r.Max && (r.In == "" || r.In == "content") {
+		for _, s := range doc.sentences() {
+			if r.re.MatchString(doc.content[s.start:s.end]) {
+				evidence = append(evidence, doc.evidence(s))
+				if len(evidence) == maxEvidence {
+					break
+				}
+			}
+		}
+	}
+	return 0, evidence
+}
@@ -0,0 +1,122 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFramework(t *testing.T) {
+	f, err := LoadFramework(filepath.Join("..", "..", "examples", "framework.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "Acme Widgets\n\nAcme Widgets are cheap to run. They fit any standard slot."
+	page := &webpage.PageData{Title: "Widgets", Content: content, MetaTags: map[string]string{}, Headings: []webpage.Heading{{Level: 1, Text: "Acme Widgets"}}}
+
+	ls := NewLocalScorer()
+	plain := ls.AnalyzeContent(content, page)
+	ls.SetFramework(f)
+	score := ls.AnalyzeContent(content, page)
+
+	if len(score.Breakdown.Custom) != 1 {
+		t.Fatalf("custom pillars = %+v", score.Breakdown.Custom)
+	}
+	brand := score.Breakdown.Custom[0]
+	if brand.ID != "brand_voice" || brand.Name != "Brand Voice" || brand.Score != 40 {
+		t.Errorf("brand pillar = %+v, want Brand Voice at 40", brand)
+	}
+	ids := make(map[string]Finding)
+	for _, finding := range score.Findings {
+		ids[finding.ID] = finding
+	}
+	if banned, ok := ids["brand.banned"]; !ok || len(banned.Evidence) != 1 || !strings.Contains(banned.Evidence[0].Snippet, "cheap") {
+		t.Errorf("banned finding = %+v", banned)
+	}
+	if _, ok := ids["brand.title"]; !ok {
+		t.Error("no brand.title finding")
+	}
+	if _, ok := ids["brand.name"]; ok {
+		t.Error("brand.name passed but was reported")
+	}
+
+	if got := score.PillarName("content_structure"); got != "Findability" {
+		t.Errorf("renamed pillar = %q", got)
+	}
+	if got := score.PillarName("semantic_clarity"); got != "Semantic Clarity" {
+		t.Errorf("unchanged pillar = %q", got)
+	}
+	if keys := score.PillarKeys(); len(keys) != 7 || keys[6] != "brand_voice" {
+		t.Errorf("pillar keys = %v", keys)
+	}
+
+	b := plain.Breakdown
+	want := 0.22*float64(b.ContentStructure.Score) + 0.22*float64(b.SemanticClarity.Score) + 0.18*float64(b.ContextRichness.Score) +
+		0.10*float64(b.AuthoritySignals.Score) + 0.10*float64(b.Accessibility.Score) + 0.08*float64(b.StructuredData.Score) + 0.10*40
+	if diff := float64(score.Overall) - want; diff < -0.5 || diff > 0.5 {
+		t.Errorf("overall = %d, want %.1f", score.Overall, want)
+	}
+
+	if len(score.Warnings) != 0 {
+		t.Errorf("warnings = %v", score.Warnings)
+	}
+
+	// Profiles keep the framework's weights, normalized with theirs
+	ls.SetProfile(ProfileNews)
+	w := ls.weights
+	if total := w.ContentStructure + w.SemanticClarity + w.ContextRichness + w.AuthoritySignals + w.Accessibility + w.StructuredData + ls.customWeights["brand_voice"]; total < 0.999 || total > 1.001 {
+		t.Errorf("news weights %+v sum to %f", w, total)
+	}
+	if w.AuthoritySignals != w.Accessibility || len(ls.warnings) != 1 {
+		t.Errorf("news weights = %+v, warnings %v", w, ls.warnings)
+	}
+}
+
+func TestLoadFrameworkErrors(t *testing.T) {
+	for _, tc := range []struct {
+		yaml string
+		want string
+	}{
+		{"pillars:\n  - name: X\n", "has no id"},
+		{"pillars:\n  - id: accessibility\n    weight: -1\n", "negative weight"},
+		{"pillars:\n  - id: accessibility\n    rules:\n      - {id: a, message: m, points: 5, pattern: x}\n", "cannot take custom rules"},
+		{"pillars:\n  - id: voice\n", "has no rules"},
+		{"pillars:\n  - id: voice\n    rules:\n      - {id: a, message: m, points: 5, pattern: \"(\"}\n", "rule \"a\""},
+		{"pillars:\n  - id: voice\n    rules:\n      - {id: a, message: m, points: 5, pattern: x, in: footer}\n", "unknown part"},
+		{"pillars:\n  - id: voice\n    rules:\n      - {id: a, message: m, pattern: x}\n", "positive points"},
+	} {
+		path := filepath.Join(t.TempDir(), "framework.yaml")
+		os.WriteFile(path, []byte(tc.yaml), 0o644)
+		if _, err := LoadFramework(path); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%q: error %v, want %q", tc.yaml, err, tc.want)
+		}
+	}
+}
+
+func TestFrameworkWithWeights(t *testing.T) {
+	weight := 0.5
+	f := &Framework{Pillars: []PillarDefinition{{ID: "accessibility", Weight: &weight}, {ID: "semantic_clarity", Name: "Clarity"}}}
+	merged, err := f.WithWeights(map[string]float64{"accessibility": 0.1, "semantic_clarity": 0.3, "context_richness": 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, p := range merged.Pillars {
+		if p.Weight != nil {
+			got[p.ID] = *p.Weight
+		}
+	}
+	want := map[string]float64{"accessibility": 0.5, "semantic_clarity": 0.3, "context_richness": 0.2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("weights %v, want %v (the framework's own weight kept)", got, want)
+	}
+	if f.Pillars[1].Weight != nil {
+		t.Error("WithWeights changed the framework it was called on")
+	}
+	if _, err := (*Framework)(nil).WithWeights(map[string]float64{"speed": 1}); err == nil {
+		t.Error("WithWeights accepted an unknown pillar")
+	}
+}
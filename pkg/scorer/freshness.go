@@ -0,0 +1,114 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// freshnessMaxPoints is what the update-signal rule contributes to the
+	// Authority Signals pillar.
+	freshnessMaxPoints = 10
+
+	// DefaultStaleAfter is the age from which a page should be reviewed.
+	DefaultStaleAfter = 365 * 24 * time.Hour
+)
+
+var (
+	// lastUpdatedPattern matches a visible update notice with its date,
+	// e.g. "Last updated: March 3, 2026" or "Reviewed on 2026-03-03".
+	lastUpdatedPattern = regexp.MustCompile(`(?i)\b(?:last (?:updated|modified|reviewed)|updated|reviewed|revised)(?: on)?:?\s+(\d{4}-\d{2}-\d{2}|\d{1,2} (?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.? \d{4}|(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.? (?:\d{1,2}, )?\d{4})`)
+
+	// changelogPattern matches the heading of a visible changelog.
+	changelogPattern = regexp.MustCompile(`(?i)^(change ?log|revision history|version history|update history|updates|what's new|release notes)$`)
+)
+
+// visibleDateLayouts are the layouts of the dates lastUpdatedPattern
+// captures, once lower-cased abbreviations are expanded by time.Parse.
+var visibleDateLayouts = []string{
+	"2006-01-02", "January 2, 2006", "Jan 2, 2006", "2 January 2006", "2 Jan 2006", "January 2006", "Jan 2006",
+}
+
+// parseVisibleDate parses a date as written in an update notice.
+func parseVisibleDate(s string) time.Time {
+	s = strings.TrimSpace(strings.ReplaceAll(s, ".", ""))
+	s = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+	for _, layout := range visibleDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// declaredUpdate returns the latest date the page declares it was modified
+// or published and where that was read from: JSON-LD, meta tags, or the
+// Last-Modified header as a last resort.
+func declaredUpdate(pageData *webpage.PageData) (time.Time, string) {
+	var latest time.Time
+	source := ""
+	consider := func(value, from string) {
+		if t, err := webpage.ParseDate(value); err == nil && t.After(latest) {
+			latest, source = t, from
+		}
+	}
+	for _, obj := range pageData.StructuredData {
+		consider(schemaText(obj["dateModified"]), "dateModified")
+		consider(schemaText(obj["datePublished"]), "datePublished")
+	}
+	for _, name := range webpage.ModifiedMetaTags {
+		consider(pageData.MetaTags[name], name)
+	}
+	consider(pageData.MetaTags["article:published_time"], "article:published_time")
+	if latest.IsZero() && pageData.Crawl.LastModified != "" {
+		if t, err := http.ParseTime(pageData.Crawl.LastModified); err == nil {
+			latest, source = t, "Last-Modified header"
+		}
+	}
+	return latest, source
+}
+
+// updateNotice returns the first visible "Last updated" notice or
+// changelog heading, and the date of the notice when it has one.
+func (d *document) updateNotice() (span, time.Time, bool) {
+	for _, p := range d.paragraphs() {
+		text := d.content[p.start:p.end]
+		if d.isHeading(p) && changelogPattern.MatchString(strings.TrimSpace(text)) {
+			return p, time.Time{}, true
+		}
+		if m := lastUpdatedPattern.FindStringSubmatchIndex(text); m != nil {
+			s := trimSpan(d.content, span{p.start + m[0], p.start + m[1]})
+			return s, parseVisibleDate(text[m[2]:m[3]]), true
+		}
+	}
+	return span{}, time.Time{}, false
+}
+
+// evaluateFreshness scores update signals (10 points): a visible "Last
+// updated" notice or changelog (10), without one 7, and 6 or 2 when the
+// latest date the page shows or declares is older than staleAfter. It also
+// returns that date.
+func evaluateFreshness(doc *document, staleAfter time.Duration, now time.Time) (int, string, []Evidence, time.Time) {
+	notice, shown, found := doc.updateNotice()
+	updated, source := declaredUpdate(doc.page)
+	if shown.After(updated) {
+		updated, source = shown, "update notice"
+	}
+
+	stale := !updated.IsZero() && now.Sub(updated) > staleAfter
+	age := int(now.Sub(updated).Hours() / 24)
+	switch {
+	case found && !stale:
+		return freshnessMaxPoints, "", nil, updated
+	case found:
+		return 6, fmt.Sprintf("Review the page: it was last updated %d days ago", age), []Evidence{doc.evidence(notice)}, updated
+	case stale:
+		ev := missingEvidence("last updated %s (%s), with no visible update notice", updated.Format("2006-01-02"), source)
+		return 2, fmt.Sprintf("Review the page and show a \"Last updated\" date: it was last updated %d days ago", age), []Evidence{ev}, updated
+	}
+	return 7, "Show a visible \"Last updated\" date or a changelog", nil, updated
+}
@@ -0,0 +1,36 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+	"time"
+)
+
+func TestFreshness(t *testing.T) {
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		content string
+		meta    map[string]string
+		score   int
+		updated string
+	}{
+		{"recent notice", "Guide\n\nLast updated: March 3, 2026\n\nBody text.", nil, 10, "2026-03-03"},
+		{"changelog", "Guide\n\nBody text.\n\nChangelog\n\nAdded a section.", nil, 10, ""},
+		{"old notice", "Guide\n\nUpdated on 2 Jan 2024.\n\nBody text.", nil, 6, "2024-01-02"},
+		{"old meta date", "Guide\n\nBody text.", map[string]string{"article:modified_time": "2023-05-01T10:00:00Z"}, 2, "2023-05-01"},
+		{"no dates", "Guide\n\nBody text.", nil, 7, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &webpage.PageData{Content: tt.content, MetaTags: tt.meta, Headings: []webpage.Heading{{Level: 1, Text: "Guide"}, {Level: 2, Text: "Changelog"}}}
+			score, _, _, updated := evaluateFreshness(newDocument(tt.content, page), DefaultStaleAfter, now)
+			if score != tt.score {
+				t.Errorf("score = %d, want %d", score, tt.score)
+			}
+			if got := updated.Format("2006-01-02"); tt.updated != "" && got != tt.updated {
+				t.Errorf("updated = %s, want %s", got, tt.updated)
+			}
+		})
+	}
+}
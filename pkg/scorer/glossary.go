@@ -0,0 +1,94 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// minGlossaryEntries is the number of definitions that make a glossary.
+	minGlossaryEntries = 3
+
+	// maxUndefinedTerms is the number of undefined technical terms above
+	// which a page is told to add a glossary.
+	maxUndefinedTerms = 5
+)
+
+var (
+	// dashDefinition matches "Term — definition" blocks.
+	dashDefinition = regexp.MustCompile(`^([A-Z][\w/().' -]{0,40}?)\s+[—–-]\s+\S`)
+
+	// acronymPattern matches acronyms and initialisms such as API or LLMs.
+	acronymPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,5}s?\b`)
+)
+
+// commonAcronyms are understood without a definition.
+var commonAcronyms = map[string]bool{
+	"OK": true, "AM": true, "PM": true, "TV": true, "US": true, "USA": true,
+	"UK": true, "EU": true, "CEO": true, "FAQ": true, "ID": true, "PDF": true,
+}
+
+// glossary summarizes how a page defines its terms.
+type glossary struct {
+	entries   int             // <dl> entries and "Term — definition" blocks
+	defined   map[string]bool // upper-cased terms with a definition
+	undefined []Evidence      // first use of technical terms never defined
+	terms     []string        // the undefined terms, in order of first use
+}
+
+// glossary finds the definitions a page gives and the acronyms it uses
+// without defining them. An acronym counts as defined when a definition
+// list or dash block defines it, when it is spelled out in parentheses
+// next to its expansion, or when a sentence starts by explaining it.
+func (d *document) glossary() *glossary {
+	g := &glossary{defined: make(map[string]bool)}
+	for _, def := range d.page.Definitions {
+		g.entries++
+		g.defined[strings.ToUpper(def.Term)] = true
+	}
+	for _, p := range d.paragraphs() {
+		if m := dashDefinition.FindStringSubmatch(d.content[p.start:p.end]); m != nil && !d.isHeading(p) {
+			g.entries++
+			g.defined[strings.ToUpper(strings.TrimSpace(m[1]))] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range d.sentences() {
+		for _, term := range acronymPattern.FindAllString(d.content[s.start:s.end], -1) {
+			term = strings.TrimSuffix(term, "s")
+			if seen[term] || commonAcronyms[term] || len(term) < 2 {
+				continue
+			}
+			seen[term] = true
+			if g.defined[term] || d.definesInline(term) {
+				continue
+			}
+			g.terms = append(g.terms, term)
+			if len(g.undefined) < maxEvidence {
+				ev := d.evidence(s)
+				ev.Snippet = fmt.Sprintf("%s: %s", term, ev.Snippet)
+				g.undefined = append(g.undefined, ev)
+			}
+		}
+	}
+	return g
+}
+
+// definesInline reports whether the content explains term where it is used:
+// "Large Language Model (LLM)", "LLM (large language model)" or "LLM is".
+func (d *document) definesInline(term string) bool {
+	quoted := regexp.QuoteMeta(term)
+	pattern := regexp.MustCompile(`\(` + quoted + `s?\)|\b` + quoted + `s?\s+\([a-zA-Z][^)]{3,}\)|\b` + quoted + `s?\s+(is|are|means|refers to|stands for)\b`)
+	return pattern.MatchString(d.content)
+}
+
+// undefinedTermList names up to three undefined terms for an issue message.
+func (g *glossary) undefinedTermList() string {
+	terms := g.terms
+	if len(terms) > 3 {
+		terms = terms[:3]
+	}
+	return strings.Join(terms, ", ")
+}
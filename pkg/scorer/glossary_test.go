@@ -0,0 +1,42 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestGlossaryDetection(t *testing.T) {
+	content := "Glossary\n\n" +
+		"Embedding — a vector that represents text.\n\n" +
+		"A large language model (LLM) reads the prompt. The API returns JSON.\n\n" +
+		"Send the FAQ to the team."
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{},
+		Definitions: []webpage.Definition{{Term: "JSON", Definition: "A data format."}}}
+
+	g := newDocument(content, page).glossary()
+	if g.entries != 2 {
+		t.Errorf("entries = %d, want 2", g.entries)
+	}
+	if got := strings.Join(g.terms, ","); got != "API" {
+		t.Errorf("undefined terms = %s, want API", got)
+	}
+	if len(g.undefined) != 1 || !strings.HasPrefix(g.undefined[0].Snippet, "API: ") {
+		t.Errorf("undefined evidence = %+v", g.undefined)
+	}
+}
+
+func TestGlossarySuggestion(t *testing.T) {
+	content := "Setup\n\n" +
+		"Configure the SDK and the CLI before calling the API. " +
+		"The CDN caches each JWT, and the ORM writes to the DB over TLS."
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{}}
+
+	score := NewLocalScorer().AnalyzeContent(content, page)
+	for _, issue := range score.Breakdown.SemanticClarity.Issues {
+		if strings.HasPrefix(issue, "Add a glossary") {
+			return
+		}
+	}
+	t.Errorf("no glossary suggestion in %v", score.Breakdown.SemanticClarity.Issues)
+}
@@ -0,0 +1,117 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"net/url"
+	"strings"
+)
+
+// hierarchyMaxPoints is what the site-hierarchy rule contributes to the
+// Accessibility pillar.
+const hierarchyMaxPoints = 10
+
+// evaluateSiteHierarchy scores the breadcrumb signals that place a page in
+// its site: a BreadcrumbList (4 points), visible breadcrumbs (3) and trails
+// consistent with the URL path and with each other (3). Pages without a
+// hierarchy to show (home and top-level pages, local files) get full points.
+// It returns the issue to report, if any, and evidence of inconsistencies.
+func evaluateSiteHierarchy(pageData *webpage.PageData) (int, string, []Evidence) {
+	page, err := url.Parse(pageData.URL)
+	if err != nil || (page.Scheme != "http" && page.Scheme != "https") || len(pathSegments(page.Path)) < 2 {
+		return hierarchyMaxPoints, "", nil
+	}
+
+	schema, visible := pageData.SchemaBreadcrumbs, pageData.Breadcrumbs
+	if len(schema) == 0 && len(visible) == 0 {
+		return 0, "Add breadcrumb navigation and BreadcrumbList markup to place the page in the site hierarchy", nil
+	}
+
+	score := 0
+	if len(schema) > 0 {
+		score += 4
+	}
+	if len(visible) > 0 {
+		score += 3
+	}
+
+	var evidence []Evidence
+	for _, trail := range [][]webpage.Breadcrumb{schema, visible} {
+		for _, crumb := range trail {
+			if !withinPath(crumb.URL, page) {
+				evidence = append(evidence, Evidence{
+					Snippet: fmt.Sprintf("%q links to %s, outside %s", crumb.Name, crumb.URL, page.Path),
+					Start:   -1,
+					End:     -1,
+				})
+				break
+			}
+		}
+	}
+	if len(schema) > 0 && len(visible) > 0 && !sameTrail(schema, visible) {
+		evidence = append(evidence, Evidence{
+			Snippet: fmt.Sprintf("visible breadcrumbs %q differ from BreadcrumbList %q", trailText(visible), trailText(schema)),
+			Start:   -1,
+			End:     -1,
+		})
+	}
+	if len(evidence) == 0 {
+		score += 3
+	}
+
+	switch {
+	case len(evidence) > 0:
+		return score, "Align breadcrumbs with the URL path and keep visible and schema trails identical", evidence
+	case len(schema) == 0:
+		return score, "Add BreadcrumbList structured data matching the visible breadcrumbs", nil
+	case len(visible) == 0:
+		return score, "Show the BreadcrumbList trail as visible breadcrumb navigation", nil
+	}
+	return score, "", nil
+}
+
+// withinPath reports whether a crumb links to the site root or into the
+// top-level section of page, which takes in category and tag pages such as
+// /blog/category/x for /blog/post. Crumbs without a link, such as the
+// current page, and links to other hosts are not judged.
+func withinPath(link string, page *url.URL) bool {
+	if link == "" {
+		return true
+	}
+	u, err := url.Parse(link)
+	if err != nil || !strings.EqualFold(u.Host, page.Host) {
+		return true
+	}
+	crumb, target := pathSegments(u.Path), pathSegments(page.Path)
+	return len(crumb) == 0 || strings.EqualFold(crumb[0], target[0])
+}
+
+func pathSegments(path string) []string {
+	var segments []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+func sameTrail(a, b []webpage.Breadcrumb) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i].Name, b[i].Name) {
+			return false
+		}
+	}
+	return true
+}
+
+func trailText(trail []webpage.Breadcrumb) string {
+	names := make([]string, len(trail))
+	for i, crumb := range trail {
+		names[i] = crumb.Name
+	}
+	return strings.Join(names, " › ")
+}
@@ -0,0 +1,45 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestEvaluateSiteHierarchy(t *testing.T) {
+	home := webpage.Breadcrumb{Name: "Home", URL: "https://example.com/"}
+	blog := webpage.Breadcrumb{Name: "Blog", URL: "https://example.com/blog"}
+	docs := webpage.Breadcrumb{Name: "Docs", URL: "https://example.com/docs"}
+	category := webpage.Breadcrumb{Name: "Guides", URL: "https://example.com/blog/category/guides"}
+	post := webpage.Breadcrumb{Name: "Post"}
+
+	tests := []struct {
+		name     string
+		url      string
+		schema   []webpage.Breadcrumb
+		visible  []webpage.Breadcrumb
+		want     int
+		evidence int
+	}{
+		{"home page", "https://example.com/", nil, nil, 10, 0},
+		{"local file", "pages/post.html", nil, nil, 10, 0},
+		{"top-level page", "https://example.com/about", nil, nil, 10, 0},
+		{"none", "https://example.com/blog/post", nil, nil, 0, 0},
+		{"both consistent", "https://example.com/blog/post", []webpage.Breadcrumb{home, blog, post}, []webpage.Breadcrumb{home, blog, post}, 10, 0},
+		{"schema only", "https://example.com/blog/post", []webpage.Breadcrumb{home, blog, post}, nil, 7, 0},
+		{"category crumb", "https://example.com/blog/post", []webpage.Breadcrumb{home, blog, category, post}, nil, 7, 0},
+		{"outside path", "https://example.com/blog/post", []webpage.Breadcrumb{home, docs, post}, nil, 4, 1},
+		{"trails differ", "https://example.com/blog/post", []webpage.Breadcrumb{home, blog, post}, []webpage.Breadcrumb{home, post}, 7, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &webpage.PageData{URL: tt.url, SchemaBreadcrumbs: tt.schema, Breadcrumbs: tt.visible}
+			score, issue, evidence := evaluateSiteHierarchy(page)
+			if score != tt.want || len(evidence) != tt.evidence {
+				t.Errorf("score %d with %d evidence (%q), want %d with %d", score, len(evidence), issue, tt.want, tt.evidence)
+			}
+			if (score < hierarchyMaxPoints) != (issue != "") {
+				t.Errorf("issue %q does not match score %d", issue, score)
+			}
+		})
+	}
+}
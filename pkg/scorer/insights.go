@@ -0,0 +1,301 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// relatedFindings lists rules that fire on the same underlying problem and
+// read as one recommendation when they fire together.
+var relatedFindings = []struct {
+	id      string
+	rules   []string
+	message string
+}{
+	{
+		id:      "sentences.length",
+		rules:   []string{"clarity.readability", "accessibility.density"},
+		message: "Shorten long sentences to improve readability and information density",
+	},
+	{
+		id:      "richness.detail",
+		rules:   []string{"richness.depth", "richness.examples"},
+		message: "Add more detailed explanations with concrete examples and specifics",
+	},
+	{
+		id:      "authority.sources",
+		rules:   []string{"authority.citations", "authority.factual"},
+		message: "Back claims with citations and credible sources",
+	},
+}
+
+var pillarNames = map[string]string{
+	"content_structure": "Content Structure",
+	"semantic_clarity":  "Semantic Clarity",
+	"context_richness":  "Context Richness",
+	"authority_signals": "Authority Signals",
+	"accessibility":     "Accessibility",
+	"structured_data":   "Structured Data",
+}
+
+// PillarName returns the display name of a pillar by its JSON key, e.g.
+// "Content Structure" for "content_structure".
+func PillarName(key string) string {
+	if name, ok := pillarNames[key]; ok {
+		return name
+	}
+	return key
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityHigh:
+		return 0
+	case SeverityMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (ls *LocalScorer) pillarWeight(pillar string) float64 {
+	switch pillar {
+	case "content_structure":
+		return ls.weights.ContentStructure
+	case "semantic_clarity":
+		return ls.weights.SemanticClarity
+	case "context_richness":
+		return ls.weights.ContextRichness
+	case "authority_signals":
+		return ls.weights.AuthoritySignals
+	case "accessibility":
+		return ls.weights.Accessibility
+	case "structured_data":
+		return ls.weights.StructuredData
+	}
+	return ls.customWeights[pillar]
+}
+
+// potential is the weighted score the page loses to the finding.
+func (ls *LocalScorer) potential(f Finding) float64 {
+	return float64(f.maxPoints-f.points) * ls.pillarWeight(f.Pillar)
+}
+
+// generateInsights turns per-pillar results into the page-level view:
+// strengths, one line per weak pillar, and a deduplicated list of findings
+// ordered by severity and by how much fixing them could add to the score.
+func (ls *LocalScorer) generateInsights(score *GEOScore) {
+	pillars := []struct {
+		name   string
+		detail *ScoreDetail
+	}{
+		{"content_structure", &score.Breakdown.ContentStructure},
+		{"semantic_clarity", &score.Breakdown.SemanticClarity},
+		{"context_richness", &score.Breakdown.ContextRichness},
+		{"authority_signals", &score.Breakdown.AuthoritySignals},
+		{"accessibility", &score.Breakdown.Accessibility},
+		{"structured_data", &score.Breakdown.StructuredData},
+	}
+	for i := range score.Breakdown.Custom {
+		custom := &score.Breakdown.Custom[i]
+		pillars = append(pillars, struct {
+			name   string
+			detail *ScoreDetail
+		}{custom.ID, &custom.ScoreDetail})
+	}
+
+	var findings []Finding
+	for _, p := range pillars {
+		p.detail.Name = ls.pillarName(p.name)
+		score.Strengths = append(score.Strengths, p.detail.Positives...)
+		if p.detail.Percentage < 50 {
+			score.Weaknesses = append(score.Weaknesses,
+				fmt.Sprintf("%s is weak (%d/%d)", p.detail.Name, p.detail.Score, p.detail.MaxScore))
+		}
+
+		for i := range p.detail.Findings {
+			f := &p.detail.Findings[i]
+			switch {
+			case p.detail.Percentage < 50:
+				f.Severity = SeverityHigh
+			case f.maxPoints > 0 && f.points*2 < f.maxPoints:
+				f.Severity = SeverityMedium
+			default:
+				f.Severity = SeverityLow
+			}
+			f.Impact = int(math.Round(ls.potential(*f)))
+			findings = append(findings, *f)
+		}
+	}
+
+	findings = mergeRelatedFindings(findings)
+	sort.SliceStable(findings, func(i, j int) bool {
+		if ri, rj := severityRank(findings[i].Severity), severityRank(findings[j].Severity); ri != rj {
+			return ri < rj
+		}
+		return findings[i].Impact > findings[j].Impact
+	})
+
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		key := normalizeMessage(f.Message)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		score.Findings = append(score.Findings, f)
+		score.Suggestions = append(score.Suggestions, f.Message)
+	}
+
+	// Add overall suggestions based on score
+	if score.Overall < 60 {
+		score.Suggestions = append(score.Suggestions, "Consider comprehensive content restructuring for better GEO optimization")
+	} else if score.Overall < 80 && len(score.Findings) > 0 {
+		score.Suggestions = append(score.Suggestions, "Focus on improving the lowest-scoring areas identified above")
+	}
+}
+
+// mergeRelatedFindings folds findings from the same relatedFindings group
+// into one, keeping the most severe rating and the combined evidence.
+func mergeRelatedFindings(findings []Finding) []Finding {
+	for _, group := range relatedFindings {
+		var members []int
+		for i, f := range findings {
+			for _, rule := range group.rules {
+				if f.ID == rule {
+					members = append(members, i)
+				}
+			}
+		}
+		if len(members) < 2 {
+			continue
+		}
+
+		merged := Finding{ID: group.id, Message: group.message, Severity: SeverityLow}
+		seenEvidence := make(map[string]bool)
+		for _, i := range members {
+			f := findings[i]
+			if merged.Pillar == "" {
+				merged.Pillar = f.Pillar
+			}
+			if severityRank(f.Severity) < severityRank(merged.Severity) {
+				merged.Severity = f.Severity
+			}
+			merged.Merged = append(merged.Merged, f.ID)
+			merged.points += f.points
+			merged.maxPoints += f.maxPoints
+			merged.Impact += f.Impact
+			for _, ev := range f.Evidence {
+				if !seenEvidence[ev.Snippet] && len(merged.Evidence) < maxEvidence {
+					seenEvidence[ev.Snippet] = true
+					merged.Evidence = append(merged.Evidence, ev)
+				}
+			}
+		}
+
+		// Replace the first member with the merged finding, drop the rest
+		out := make([]Finding, 0, len(findings)-len(members)+1)
+		for i, f := range findings {
+			switch {
+			case i == members[0]:
+				out = append(out, merged)
+			case contains(members, i):
+			default:
+				out = append(out, f)
+			}
+		}
+		findings = out
+	}
+	return findings
+}
+
+func contains(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMessage reduces a message to lowercase letters and digits so
+// near-identical wording compares equal.
+func normalizeMessage(message string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, message)
+}
+
+// Impact returns the estimated overall points a suggestion is worth, or 0
+// when it does not come from a scored finding.
+func (score *GEOScore) Impact(suggestion string) int {
+	if score == nil {
+		return 0
+	}
+	key := normalizeMessage(suggestion)
+	for _, f := range score.Findings {
+		if normalizeMessage(f.Message) == key {
+			return f.Impact
+		}
+	}
+	return 0
+}
+
+// WithImpact appends the estimated gain to a suggestion, e.g.
+// "Add more citations and credible references (+6 pts)".
+func (score *GEOScore) WithImpact(suggestion string) string {
+	if impact := score.Impact(suggestion); impact > 0 {
+		return fmt.Sprintf("%s (+%d pts)", suggestion, impact)
+	}
+	return suggestion
+}
+
+// Pillar returns the breakdown of a pillar by its JSON key, e.g.
+// "content_structure".
+func (score *GEOScore) Pillar(name string) (ScoreDetail, bool) {
+	switch name {
+	case "content_structure":
+		return score.Breakdown.ContentStructure, true
+	case "semantic_clarity":
+		return score.Breakdown.SemanticClarity, true
+	case "context_richness":
+		return score.Breakdown.ContextRichness, true
+	case "authority_signals":
+		return score.Breakdown.AuthoritySignals, true
+	case "accessibility":
+		return score.Breakdown.Accessibility, true
+	case "structured_data":
+		return score.Breakdown.StructuredData, true
+	}
+	for _, p := range score.Breakdown.Custom {
+		if p.ID == name {
+			return p.ScoreDetail, true
+		}
+	}
+	return ScoreDetail{}, false
+}
+
+// PillarKeys returns the JSON keys of the scored pillars in report order:
+// the built-in pillars, then those a framework adds.
+func (score *GEOScore) PillarKeys() []string {
+	keys := append([]string(nil), builtinPillars...)
+	for _, p := range score.Breakdown.Custom {
+		keys = append(keys, p.ID)
+	}
+	return keys
+}
+
+// PillarName returns the display name of a pillar in this report, which a
+// framework may have changed.
+func (score *GEOScore) PillarName(key string) string {
+	if detail, ok := score.Pillar(key); ok && detail.Name != "" {
+		return detail.Name
+	}
+	return PillarName(key)
+}
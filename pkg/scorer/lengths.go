@@ -0,0 +1,118 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// LengthReport is the distribution of sentence and paragraph lengths, so
+// writers can find the few sentences dragging readability down rather than
+// act on a generic "simplify sentences" note.
+type LengthReport struct {
+	Sentences  LengthDistribution `json:"sentences"`
+	Paragraphs LengthDistribution `json:"paragraphs"`
+}
+
+// LengthDistribution is a histogram of lengths in words, with the longest
+// items past the outlier threshold, longest first.
+type LengthDistribution struct {
+	Count    int             `json:"count"`
+	Mean     float64         `json:"mean"`
+	Median   int             `json:"median"`
+	Longest  int             `json:"longest"`
+	Buckets  []LengthBucket  `json:"buckets"`
+	Outliers []LengthOutlier `json:"outliers,omitempty"`
+}
+
+// LengthBucket counts the items of Min to Max words; Max is 0 for the
+// open-ended last bucket.
+type LengthBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max,omitempty"`
+	Count int `json:"count"`
+}
+
+// Label returns the bucket's range, e.g. "11-20" or "41+".
+func (b LengthBucket) Label() string {
+	if b.Max == 0 {
+		return fmt.Sprintf("%d+", b.Min)
+	}
+	return fmt.Sprintf("%d-%d", b.Min, b.Max)
+}
+
+// LengthOutlier is a sentence or paragraph past the outlier threshold.
+type LengthOutlier struct {
+	Words    int      `json:"words"`
+	Evidence Evidence `json:"evidence"`
+}
+
+const (
+	// Sentences and paragraphs longer than these are reported as outliers.
+	longSentenceWords  = 40
+	longParagraphWords = 150
+
+	// maxOutliers caps the outliers reported per distribution.
+	maxOutliers = 5
+)
+
+// Bucket upper bounds in words; the last bucket takes everything longer.
+var (
+	sentenceBuckets  = []int{10, 20, 30, longSentenceWords}
+	paragraphBuckets = []int{25, 50, 100, longParagraphWords}
+)
+
+// lengthReport measures the sentences and paragraphs of the page, leaving
+// out headings.
+func (d *document) lengthReport() *LengthReport {
+	return &LengthReport{
+		Sentences:  d.distribution(d.sentences(), sentenceBuckets),
+		Paragraphs: d.distribution(d.paragraphs(), paragraphBuckets),
+	}
+}
+
+// distribution buckets spans by word count using the given upper bounds,
+// with the spans longer than the last bound as outliers.
+func (d *document) distribution(spans []span, bounds []int) LengthDistribution {
+	dist := LengthDistribution{}
+	low := 1
+	for _, high := range bounds {
+		dist.Buckets = append(dist.Buckets, LengthBucket{Min: low, Max: high})
+		low = high + 1
+	}
+	dist.Buckets = append(dist.Buckets, LengthBucket{Min: low})
+
+	var lengths []int
+	var long []span
+	total := 0
+	for _, s := range spans {
+		words := wordCount(d.content, s)
+		if words == 0 || d.isHeading(s) {
+			continue
+		}
+		lengths = append(lengths, words)
+		total += words
+		i := sort.SearchInts(bounds, words)
+		dist.Buckets[i].Count++
+		if i == len(bounds) {
+			long = append(long, s)
+		}
+	}
+	if len(lengths) == 0 {
+		return dist
+	}
+
+	sort.Ints(lengths)
+	dist.Count = len(lengths)
+	dist.Mean = math.Round(float64(total)/float64(len(lengths))*10) / 10
+	dist.Median = lengths[len(lengths)/2]
+	dist.Longest = lengths[len(lengths)-1]
+
+	sort.SliceStable(long, func(i, j int) bool {
+		return wordCount(d.content, long[i]) > wordCount(d.content, long[j])
+	})
+	for i := 0; i < len(long) && i < maxOutliers; i++ {
+		dist.Outliers = append(dist.Outliers, LengthOutlier{Words: wordCount(d.content, long[i]), Evidence: d.evidence(long[i])})
+	}
+	return dist
+}
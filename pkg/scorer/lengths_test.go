@@ -0,0 +1,38 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestLengthReport(t *testing.T) {
+	short := "Proof the dough overnight in the fridge. "
+	long := "The starter " + strings.Repeat("and the flour ", 30) + "all matter. "
+	content := "Bread\n\n" + strings.Repeat(short, 4) + "\n\n" + short + long + "\n\n" + strings.Repeat("word ", 160) + "end."
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{}, Headings: []webpage.Heading{{Level: 1, Text: "Bread"}}}
+
+	lengths := NewLocalScorer().AnalyzeContent(content, page).Lengths
+	if lengths == nil {
+		t.Fatal("no length report")
+	}
+
+	sentences := lengths.Sentences
+	if sentences.Count != 7 || sentences.Buckets[0].Count != 5 || sentences.Buckets[0].Label() != "1-10" {
+		t.Errorf("sentences = %+v, want 5 of 7 in the 1-10 bucket (heading left out)", sentences)
+	}
+	if len(sentences.Outliers) != 2 || sentences.Outliers[0].Words != 161 || sentences.Outliers[1].Words != 94 {
+		t.Errorf("outliers = %+v, want the 161- and 94-word sentences, longest first", sentences.Outliers)
+	}
+	if got := sentences.Outliers[1].Evidence; !strings.HasPrefix(got.Snippet, "The starter and the flour") || got.HeadingPath[0] != "Bread" {
+		t.Errorf("outlier evidence = %+v", got)
+	}
+
+	paragraphs := lengths.Paragraphs
+	if paragraphs.Count != 3 || paragraphs.Longest != 161 || len(paragraphs.Outliers) != 1 {
+		t.Errorf("paragraphs = %+v, want 3 with the 161-word one as the outlier", paragraphs)
+	}
+	if last := paragraphs.Buckets[len(paragraphs.Buckets)-1]; last.Label() != "151+" || last.Count != 1 {
+		t.Errorf("last paragraph bucket = %+v", last)
+	}
+}
@@ -0,0 +1,160 @@
+package scorer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Deductions from the Authority Signals pillar for link patterns that make
+// AI systems distrust a page.
+const (
+	affiliateDeduction = 5
+	anchorDeduction    = 5
+	hiddenDeduction    = 10
+
+	// minAffiliateLinks is the number of affiliate links from which their
+	// density is checked.
+	minAffiliateLinks = 3
+
+	// maxAnchorRepeats is the number of links a descriptive anchor text
+	// can be used for before it reads as keyword stuffing.
+	maxAnchorRepeats = 2
+)
+
+// affiliateParams are query parameters affiliate networks track referrals
+// with.
+var affiliateParams = []string{
+	"aff", "affid", "aff_id", "aff_sub", "affiliate", "affiliate_id", "partnerid",
+	"irclickid", "clickid", "subid", "sub_id",
+}
+
+// affiliateHosts are the redirect and short-link domains of affiliate
+// networks.
+var affiliateHosts = []string{
+	"amzn.to", "go.skimresources.com", "go.redirectingat.com", "shareasale.com", "awin1.com",
+	"click.linksynergy.com", "anrdoezrs.net", "dpbolvw.net", "jdoqocy.com", "kqzyfj.com",
+	"tkqlhce.com", "prf.hn", "rstyle.me", "shopstyle.it", "pntra.com", "avantlink.com", "sjv.io",
+	"clk.tradedoubler.com",
+}
+
+// linkSpam is a link pattern found on the page and what it costs.
+type linkSpam struct {
+	id        string
+	message   string
+	deduction int
+	evidence  []Evidence
+}
+
+// isAffiliateLink reports whether a link carries affiliate tracking: an
+// affiliate network domain, an affiliate parameter, an Amazon tag or
+// utm_medium=affiliate.
+func isAffiliateLink(raw string) bool {
+	host, u := linkHost(raw)
+	if host == "" {
+		return false
+	}
+	if hasDomain(host, affiliateHosts) {
+		return true
+	}
+	query := u.Query()
+	for _, param := range affiliateParams {
+		if query.Has(param) {
+			return true
+		}
+	}
+	return (query.Has("tag") && (host == "amazon.com" || strings.HasPrefix(host, "amazon."))) ||
+		strings.EqualFold(query.Get("utm_medium"), "affiliate")
+}
+
+// detectLinkSpam looks for affiliate links dense enough to outweigh the
+// content (at least minAffiliateLinks, and one per 100 words or half the
+// links), descriptive anchor texts repeated on more than maxAnchorRepeats
+// links, and links hidden from readers.
+func detectLinkSpam(doc *document) []linkSpam {
+	var found []linkSpam
+	links := doc.page.Links
+
+	var affiliate []Evidence
+	count := 0
+	for _, link := range links {
+		if isAffiliateLink(link.URL) {
+			count++
+			if len(affiliate) < maxEvidence {
+				affiliate = append(affiliate, linkEvidence(doc, link.Text, link.URL))
+			}
+		}
+	}
+	if count >= minAffiliateLinks && (count*100 >= depthWords(doc.content) || count*2 >= len(links)) {
+		found = append(found, linkSpam{
+			id:        "authority.affiliate_links",
+			message:   fmt.Sprintf("Reduce affiliate links: %d of %d links carry affiliate tracking", count, len(links)),
+			deduction: affiliateDeduction,
+			evidence:  affiliate,
+		})
+	}
+
+	anchors := make(map[string]int)
+	texts := make(map[string]string)
+	for _, link := range links {
+		text := strings.ToLower(strings.TrimSpace(link.Text))
+		if len(strings.Fields(text)) >= 2 && !isGenericAnchor(text) {
+			anchors[text]++
+			texts[text] = link.Text
+		}
+	}
+	var repeated []string
+	for text, n := range anchors {
+		if n > maxAnchorRepeats {
+			repeated = append(repeated, text)
+		}
+	}
+	if len(repeated) > 0 {
+		sort.Slice(repeated, func(i, j int) bool {
+			return anchors[repeated[i]] > anchors[repeated[j]] || (anchors[repeated[i]] == anchors[repeated[j]] && repeated[i] < repeated[j])
+		})
+		var evidence []Evidence
+		for _, text := range repeated[:min(len(repeated), maxEvidence)] {
+			ev := Locate(doc.content, doc.page, texts[text])
+			ev.Snippet = fmt.Sprintf("%q on %d links", texts[text], anchors[text])
+			evidence = append(evidence, ev)
+		}
+		found = append(found, linkSpam{
+			id:        "authority.exact_anchors",
+			message:   fmt.Sprintf("Vary link texts: %q is used on %d links", texts[repeated[0]], anchors[repeated[0]]),
+			deduction: anchorDeduction,
+			evidence:  evidence,
+		})
+	}
+
+	var hidden []Evidence
+	count = 0
+	for _, link := range links {
+		if link.Hidden {
+			count++
+			if len(hidden) < maxEvidence {
+				hidden = append(hidden, linkEvidence(doc, link.Text, link.URL))
+			}
+		}
+	}
+	if count > 0 {
+		found = append(found, linkSpam{
+			id:        "authority.hidden_links",
+			message:   fmt.Sprintf("Remove links hidden from readers (%d found)", count),
+			deduction: hiddenDeduction,
+			evidence:  hidden,
+		})
+	}
+	return found
+}
+
+// linkEvidence points at a link's text in the content, followed by its
+// URL.
+func linkEvidence(doc *document, text, url string) Evidence {
+	if text == "" {
+		return missingEvidence("%s", url)
+	}
+	ev := Locate(doc.content, doc.page, text)
+	ev.Snippet = fmt.Sprintf("%s → %s", ev.Snippet, url)
+	return ev
+}
@@ -0,0 +1,59 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestIsAffiliateLink(t *testing.T) {
+	for raw, want := range map[string]bool{
+		"https://amzn.to/3xYz":                            true,
+		"https://www.amazon.com/dp/B01?tag=site-20":       true,
+		"https://shop.example.com/item?aff_id=42":         true,
+		"https://example.com/?utm_medium=Affiliate":       true,
+		"https://www.anrdoezrs.net/click-1-2":             true,
+		"https://example.com/item?tag=sleep":              false,
+		"https://example.com/guide?utm_medium=newsletter": false,
+		"mailto:hi@example.com":                           false,
+	} {
+		if got := isAffiliateLink(raw); got != want {
+			t.Errorf("isAffiliateLink(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestDetectLinkSpam(t *testing.T) {
+	content := "Best mattresses.\n\nBuy the Comfy, the Dreamy or the Cloud. Read more about cheap mattresses online, cheap mattresses online and cheap mattresses online."
+	page := &webpage.PageData{URL: "https://example.com/best", Content: content, Links: []webpage.Link{
+		{Text: "the Comfy", URL: "https://www.amazon.com/dp/1?tag=site-20"},
+		{Text: "the Dreamy", URL: "https://amzn.to/2"},
+		{Text: "the Cloud", URL: "https://shop.example.org/cloud?affid=7"},
+		{Text: "Read more", URL: "https://example.com/a"},
+		{Text: "Read more", URL: "https://example.com/b"},
+		{Text: "Read more", URL: "https://example.com/c"},
+	}}
+	for range 3 {
+		page.Links = append(page.Links, webpage.Link{Text: "cheap mattresses online", URL: "https://example.com/cheap"})
+	}
+	page.Links = append(page.Links, webpage.Link{Text: "casino", URL: "https://casino.example.net/", Hidden: true})
+
+	found := detectLinkSpam(newDocument(content, page))
+	if len(found) != 3 {
+		t.Fatalf("found %+v, want affiliate, anchor and hidden findings", found)
+	}
+	if found[0].id != "authority.affiliate_links" || len(found[0].evidence) != 3 || found[0].evidence[0].Snippet != "the Comfy → https://www.amazon.com/dp/1?tag=site-20" {
+		t.Errorf("affiliate finding %+v", found[0])
+	}
+	if found[1].id != "authority.exact_anchors" || found[1].message != `Vary link texts: "cheap mattresses online" is used on 3 links` {
+		t.Errorf("anchor finding %+v, want only the descriptive anchor flagged", found[1])
+	}
+	if found[2].id != "authority.hidden_links" || found[2].evidence[0].Start >= 0 {
+		t.Errorf("hidden finding %+v, want the link outside the visible text", found[2])
+	}
+
+	// A clean page has no findings and keeps its points
+	page.Links = page.Links[3:6]
+	if found := detectLinkSpam(newDocument(content, page)); len(found) != 0 {
+		t.Errorf("found %+v on a clean page", found)
+	}
+}
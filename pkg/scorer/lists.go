@@ -0,0 +1,78 @@
+package scorer
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// markdownListItem matches a Markdown list item line: a bullet (-, *, +,
+// •) or a number followed by "." or ")", then a space.
+var markdownListItem = regexp.MustCompile(`^[ \t]*(?:[-*+•]|\d{1,3}[.)])[ \t]+\S`)
+
+// listBlock is a list found in the page and its number of items.
+type listBlock struct {
+	ordered bool
+	items   int
+}
+
+// listBlocks returns the lists of the page with at least two items: the
+// <ul> and <ol> lists of the DOM, or for content without markup, such as
+// Markdown files, runs of Markdown list items separated by nothing but
+// blank lines.
+func (d *document) listBlocks() []listBlock {
+	var blocks []listBlock
+	if len(d.page.Lists) > 0 {
+		for _, l := range d.page.Lists {
+			if len(l.Items) >= 2 {
+				blocks = append(blocks, listBlock{ordered: l.Ordered, items: len(l.Items)})
+			}
+		}
+		return blocks
+	}
+
+	var current listBlock
+	flush := func() {
+		if current.items >= 2 {
+			blocks = append(blocks, current)
+		}
+		current = listBlock{}
+	}
+	for _, line := range strings.Split(d.content, "\n") {
+		switch {
+		case markdownListItem.MatchString(line):
+			marker, _ := utf8.DecodeRuneInString(strings.TrimSpace(line))
+			ordered := !strings.ContainsRune("-*+•", marker)
+			if current.items > 0 && ordered != current.ordered {
+				flush()
+			}
+			current.ordered = ordered
+			current.items++
+		case strings.TrimSpace(line) == "":
+		case strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t"):
+			// Continuation of the previous item
+		default:
+			flush()
+		}
+	}
+	flush()
+	return blocks
+}
+
+// evaluateListUsage scores the use of lists (20 points): 5 without any,
+// 10 for lists of fewer than 4 items in all and 20 otherwise.
+func (ls *LocalScorer) evaluateListUsage(doc *document) int {
+	blocks := doc.listBlocks()
+	items := 0
+	for _, b := range blocks {
+		items += b.items
+	}
+
+	switch {
+	case len(blocks) == 0:
+		return 5
+	case items < 4:
+		return 10
+	}
+	return 20
+}
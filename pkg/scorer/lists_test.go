@@ -0,0 +1,38 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"reflect"
+	"testing"
+)
+
+func TestListUsage(t *testing.T) {
+	ls := NewLocalScorer()
+	tests := []struct {
+		name    string
+		content string
+		lists   []webpage.List
+		want    int
+	}{
+		{"dates and hyphens", "Released 2024-05-01 as a well-known, long-awaited tool.\n\nVersion 1.2 - the latest - ships today.", nil, 5},
+		{"DOM list", "Features\n\nFast\n\nSmall", []webpage.List{{Items: []string{"Fast", "Small", "Safe", "Free"}}}, 20},
+		{"one-item DOM list", "Fast", []webpage.List{{Items: []string{"Fast"}}}, 5},
+		{"Markdown bullets", "Features:\n\n- Fast\n- Small\n  and light\n\n- Safe\n- Free", nil, 20},
+		{"short Markdown list", "Steps:\n1. Install\n2) Run\n\nThat is all.", nil, 10},
+	}
+	for _, tt := range tests {
+		doc := newDocument(tt.content, &webpage.PageData{Content: tt.content, Lists: tt.lists})
+		if got := ls.evaluateListUsage(doc); got != tt.want {
+			t.Errorf("%s: evaluateListUsage = %d, want %d (%+v)", tt.name, got, tt.want, doc.listBlocks())
+		}
+	}
+}
+
+func TestBulletListsAreUnordered(t *testing.T) {
+	content := "• Fast\n• Small\n1. Install\n2. Run"
+	doc := newDocument(content, &webpage.PageData{Content: content})
+	want := []listBlock{{ordered: false, items: 2}, {ordered: true, items: 2}}
+	if got := doc.listBlocks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("listBlocks = %+v, want %+v", got, want)
+	}
+}
@@ -1,14 +1,36 @@
 package scorer
 
 import (
+	"fmt"
 	"geo-checker/internal/webpage"
 	"math"
-	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// Version identifies the scoring rules. It changes whenever a rule or its
+// points change, together with the selftest golden files, so reports name
+// the rules that produced them.
+const Version = "3.0.0"
+
 type LocalScorer struct {
-	weights GEOWeights
+	weights    GEOWeights
+	profile    Profile
+	staleAfter time.Duration // age from which pages should be reviewed
+
+	// readingLevel is the target reading grade; 0 uses the profile's
+	readingLevel float64
+
+	// framework renames pillars, overrides weights and adds custom
+	// pillars; nil reports the built-in pillars
+	framework *Framework
+
+	// customWeights are the normalized weights of the custom pillars
+	customWeights map[string]float64
+
+	// warnings are the framework problems normalization corrected
+	warnings []string
 }
 
 type GEOWeights struct {
@@ -17,6 +39,7 @@ type GEOWeights struct {
 	ContextRichness  float64
 	AuthoritySignals float64
 	Accessibility    float64
+	StructuredData   float64
 }
 
 type GEOScore struct {
@@ -25,6 +48,20 @@ type GEOScore struct {
 	Suggestions      []string               `json:"suggestions"`
 	Strengths        []string               `json:"strengths"`
 	Weaknesses       []string               `json:"weaknesses"`
+	Findings         []Finding              `json:"findings"`
+	Formatting       []FormatAdvice         `json:"formatting,omitempty"`
+	AnswerFirst      *AnswerAdvice          `json:"answer_first,omitempty"`
+	KeyTakeaways     *SummaryAdvice         `json:"key_takeaways,omitempty"`
+	Intro            *SectionAdvice         `json:"intro,omitempty"`
+	Conclusion       *SectionAdvice         `json:"conclusion,omitempty"`
+	Anchors          []AnchorSuggestion     `json:"anchors,omitempty"` // headings to give an id
+	CitedDomains     []CitedDomain          `json:"cited_domains,omitempty"`
+	Lengths          *LengthReport          `json:"lengths,omitempty"`
+	Coverage         *HeadingCoverage       `json:"heading_coverage,omitempty"`
+	AIOptOut         *AIOptOut              `json:"ai_opt_out,omitempty"`
+	Paywall          *Paywall               `json:"paywall,omitempty"`
+	Schema           []SchemaCheck          `json:"schema,omitempty"` // structured data validated against its type's required properties
+	Warnings         []string               `json:"warnings,omitempty"` // weights and scores that had to be corrected
 	Metadata         map[string]interface{} `json:"metadata"`
 }
 
@@ -34,43 +71,103 @@ type ScoreBreakdown struct {
 	ContextRichness  ScoreDetail `json:"context_richness"`
 	AuthoritySignals ScoreDetail `json:"authority_signals"`
 	Accessibility    ScoreDetail `json:"accessibility"`
+	StructuredData   ScoreDetail `json:"structured_data"`
+
+	// Custom holds the pillars a framework adds, in its order
+	Custom []CustomPillar `json:"custom,omitempty"`
+}
+
+// CustomPillar is the breakdown of a pillar defined by a framework.
+type CustomPillar struct {
+	ID string `json:"id"`
+	ScoreDetail
 }
 
 type ScoreDetail struct {
-	Score       int      `json:"score"`
-	MaxScore    int      `json:"max_score"`
-	Percentage  float64  `json:"percentage"`
-	Issues      []string `json:"issues"`
-	Positives   []string `json:"positives"`
+	Name        string    `json:"name,omitempty"` // display name
+	Score       int       `json:"score"`
+	MaxScore    int       `json:"max_score"`
+	Percentage  float64   `json:"percentage"`
+	Issues      []string  `json:"issues"`
+	Positives   []string  `json:"positives"`
+	Findings    []Finding `json:"findings"`
+}
+
+// defaultWeights are the pillar weights of every profile but news.
+var defaultWeights = GEOWeights{
+	ContentStructure: 0.22,
+	SemanticClarity:  0.22,
+	ContextRichness:  0.18,
+	AuthoritySignals: 0.15,
+	Accessibility:    0.13,
+	StructuredData:   0.10,
 }
 
 func NewLocalScorer() *LocalScorer {
 	return &LocalScorer{
-		weights: GEOWeights{
-			ContentStructure: 0.25,
-			SemanticClarity:  0.25,
-			ContextRichness:  0.20,
-			AuthoritySignals: 0.15,
-			Accessibility:    0.15,
-		},
+		weights:    defaultWeights,
+		profile:    ProfileGeneral,
+		staleAfter: DefaultStaleAfter,
 	}
 }
 
+// SetStaleAfter sets the age from which pages without recent updates are
+// told to review their content.
+func (ls *LocalScorer) SetStaleAfter(d time.Duration) {
+	ls.staleAfter = d
+}
+
+// StaleAfter returns the age from which pages should be reviewed.
+func (ls *LocalScorer) StaleAfter() time.Duration {
+	return ls.staleAfter
+}
+
 func (ls *LocalScorer) AnalyzeContent(content string, pageData *webpage.PageData) *GEOScore {
 	score := &GEOScore{
 		Breakdown:   ScoreBreakdown{},
 		Suggestions: []string{},
 		Strengths:   []string{},
 		Weaknesses:  []string{},
+		Findings:    []Finding{},
 		Metadata:    make(map[string]interface{}),
 	}
 
+	doc := newDocument(content, pageData)
+	doc.advice = doc.formattingAdvice()
+	score.Formatting = doc.advice
+	doc.answer = doc.answerFirst()
+	score.AnswerFirst = doc.answer
+	// Product, category and local business pages are not read as articles
+	switch ls.profile {
+	case ProfileProduct, ProfileCategory, ProfileLocalBusiness:
+	default:
+		doc.summary = doc.keyTakeaways()
+		score.KeyTakeaways = doc.summary
+		doc.opening, doc.closing = doc.introduction(), doc.conclusion()
+		score.Intro, score.Conclusion = doc.opening, doc.closing
+	}
+	doc.anchors, doc.sections = anchorSuggestions(pageData.Headings)
+	score.Anchors = doc.anchors
+	score.Lengths = ls.sentenceDocument(doc).lengthReport()
+	doc.coverage = doc.headingCoverage()
+	score.Coverage = doc.coverage
+	score.AIOptOut = detectAIOptOut(pageData)
+	doc.wall = detectPaywall(doc)
+	score.Paywall = doc.wall
+	doc.schema = validateSchema(doc)
+	score.Schema = doc.schema
+
 	// Analyze each component
-	score.Breakdown.ContentStructure = ls.analyzeContentStructure(content, pageData)
-	score.Breakdown.SemanticClarity = ls.analyzeSemanticClarity(content)
-	score.Breakdown.ContextRichness = ls.analyzeContextRichness(content, pageData)
-	score.Breakdown.AuthoritySignals = ls.analyzeAuthoritySignals(content, pageData)
-	score.Breakdown.Accessibility = ls.analyzeAccessibility(content, pageData)
+	score.Breakdown.ContentStructure = ls.analyzeContentStructure(doc)
+	score.Breakdown.SemanticClarity = ls.analyzeSemanticClarity(doc)
+	score.Breakdown.ContextRichness = ls.analyzeContextRichness(doc)
+	score.Breakdown.AuthoritySignals = ls.analyzeAuthoritySignals(doc)
+	score.Breakdown.Accessibility = ls.analyzeAccessibility(doc)
+	score.Breakdown.StructuredData = ls.analyzeStructuredData(doc)
+	score.Breakdown.Custom = ls.analyzeCustomPillars(doc)
+	score.Warnings = append(append([]string(nil), ls.warnings...), ls.checkPillarScores(score)...)
+
+	score.CitedDomains = doc.cited
 
 	// Calculate overall score
 	score.Overall = ls.calculateOverallScore(score.Breakdown)
@@ -79,16 +176,26 @@ func (ls *LocalScorer) AnalyzeContent(content string, pageData *webpage.PageData
 	ls.generateInsights(score)
 
 	// Add metadata
-	score.Metadata["content_length"] = len(content)
-	score.Metadata["word_count"] = len(strings.Fields(content))
+	score.Metadata["content_length"] = utf8.RuneCountInString(content)
+	score.Metadata["word_count"] = countWords(content)
 	score.Metadata["heading_count"] = len(pageData.Headings)
 	score.Metadata["meta_tags_count"] = len(pageData.MetaTags)
+	score.Metadata["profile"] = string(ls.profile)
+	score.Metadata["reading_grade"] = math.Round(doc.readingGrade*10) / 10
+	score.Metadata["reading_target"] = ls.ReadingLevel()
+	if ls.profile != ProfileNews {
+		score.Metadata["experience_markers"] = doc.experience
+	}
+	if !doc.updated.IsZero() {
+		score.Metadata["last_updated"] = doc.updated.Format("2006-01-02")
+	}
 
 	return score
 }
 
-func (ls *LocalScorer) analyzeContentStructure(content string, pageData *webpage.PageData) ScoreDetail {
-	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}}
+func (ls *LocalScorer) analyzeContentStructure(doc *document) ScoreDetail {
+	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}, Findings: []Finding{}}
+	content, pageData := doc.content, doc.page
 	score := 0
 
 	// Check heading hierarchy (30 points)
@@ -97,34 +204,107 @@ func (ls *LocalScorer) analyzeContentStructure(content string, pageData *webpage
 	if headingScore >= 25 {
 		detail.Positives = append(detail.Positives, "Good heading hierarchy structure")
 	} else {
-		detail.Issues = append(detail.Issues, "Improve heading hierarchy (H1 → H2 → H3)")
+		detail.addIssue("content_structure", "structure.headings", "Improve heading hierarchy (H1 → H2 → H3)", headingScore, 30, doc.headingEvidence()...)
 	}
 
-	// Check content organization (25 points)
+	// Check content organization (5 points)
 	orgScore := ls.evaluateContentOrganization(content)
 	score += orgScore
-	if orgScore >= 20 {
+	if orgScore >= 5 {
 		detail.Positives = append(detail.Positives, "Well-organized content structure")
 	} else {
-		detail.Issues = append(detail.Issues, "Content could be better organized with clear sections")
+		detail.addIssue("content_structure", "structure.organization", "Content could be better organized with clear sections", orgScore, 5)
+	}
+
+	// Check how much of the text sits under descriptive headings (10 points)
+	coverageScore, coverageIssue := evaluateHeadingCoverage(doc.coverage)
+	score += coverageScore
+	if coverageIssue == "" {
+		if doc.coverage.Words >= minCoverageWords && coverageScore == coverageMaxPoints {
+			detail.Positives = append(detail.Positives, "Text is organized under descriptive headings")
+		}
+	} else {
+		detail.addIssue("content_structure", "structure.coverage", coverageIssue, coverageScore, coverageMaxPoints, doc.coverage.Floating...)
+	}
+
+	// Check that the page opens with a direct answer (10 points)
+	answerScore := evaluateAnswerFirst(doc.answer)
+	score += answerScore
+	if answerScore == answerFirstMaxPoints {
+		detail.Positives = append(detail.Positives, "Opens with a direct answer")
+	} else {
+		evidence := []Evidence{doc.answer.Evidence}
+		detail.addIssue("content_structure", "structure.answer_first", doc.answer.Suggestion, answerScore, answerFirstMaxPoints, evidence...)
 	}
 
-	// Check paragraph structure (25 points)
+	// Check paragraph structure (15 points)
 	paraScore := ls.evaluateParagraphStructure(content)
 	score += paraScore
-	if paraScore >= 20 {
+	if paraScore >= 12 {
 		detail.Positives = append(detail.Positives, "Good paragraph structure")
 	} else {
-		detail.Issues = append(detail.Issues, "Use shorter, more focused paragraphs")
+		detail.addIssue("content_structure", "structure.paragraphs", "Use shorter, more focused paragraphs", paraScore, 15, doc.paragraphEvidence()...)
 	}
 
-	// Check list usage (20 points)
-	listScore := ls.evaluateListUsage(content)
-	score += listScore
-	if listScore >= 15 {
-		detail.Positives = append(detail.Positives, "Effective use of lists for organization")
+	// Check for a TL;DR or key-takeaways block (10 points)
+	summaryScore := evaluateKeyTakeaways(doc.summary)
+	score += summaryScore
+	if summaryScore == summaryMaxPoints {
+		if doc.summary != nil {
+			detail.Positives = append(detail.Positives, "Summarizes the key takeaways up front")
+		}
 	} else {
-		detail.Issues = append(detail.Issues, "Consider using lists to organize key points")
+		var evidence []Evidence
+		if doc.summary.Evidence != nil {
+			evidence = append(evidence, *doc.summary.Evidence)
+		}
+		detail.addIssue("content_structure", "structure.takeaways", doc.summary.Suggestion, summaryScore, summaryMaxPoints, evidence...)
+	}
+
+	// Check list usage (20 points); developer docs are rewarded for
+	// parameter tables, product pages for specification tables, news for
+	// update notes and local businesses for showing where they are instead
+	switch ls.profile {
+	case ProfileLocalBusiness:
+		locationScore := evaluateLocationDetails(pageData)
+		score += locationScore
+		if locationScore >= 20 {
+			detail.Positives = append(detail.Positives, "Address and map show where the business is")
+		} else {
+			detail.addIssue("content_structure", "local.location", "Show the postal address in an <address> block and embed a map", locationScore, 20)
+		}
+	case ProfileNews:
+		updateScore, updateIssue, updateEvidence := evaluateUpdateNotes(doc)
+		score += updateScore
+		if updateIssue == "" {
+			detail.Positives = append(detail.Positives, "Changes since publication are noted")
+		} else {
+			detail.addIssue("content_structure", "news.updates", updateIssue, updateScore, 20, updateEvidence...)
+		}
+	case ProfileDocs:
+		tableScore := ls.evaluateParameterTables(doc)
+		score += tableScore
+		if tableScore >= 20 {
+			detail.Positives = append(detail.Positives, "Parameters documented in reference tables")
+		} else {
+			detail.addIssue("content_structure", "docs.parameters", "Document parameters and options in a table with name, type and description columns", tableScore, 20)
+		}
+	case ProfileProduct:
+		specScore := evaluateSpecTables(pageData)
+		score += specScore
+		if specScore >= 20 {
+			detail.Positives = append(detail.Positives, "Specifications presented in a table")
+		} else {
+			detail.addIssue("content_structure", "product.specs", "Present product specifications in a two-column table (attribute, value)", specScore, 20, formatEvidence(doc.advice)...)
+		}
+	default:
+		listScore := ls.evaluateListUsage(doc)
+		score += listScore
+		if listScore >= 15 {
+			detail.Positives = append(detail.Positives, "Effective use of lists for organization")
+		} else {
+			detail.addIssue("content_structure", "structure.lists", "Consider using lists to organize key points", listScore, 20, formatEvidence(doc.advice)...)
+		}
 	}
 
 	detail.Score = score
@@ -132,17 +312,20 @@ func (ls *LocalScorer) analyzeContentStructure(content string, pageData *webpage
 	return detail
 }
 
-func (ls *LocalScorer) analyzeSemanticClarity(content string) ScoreDetail {
-	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}}
+func (ls *LocalScorer) analyzeSemanticClarity(doc *document) ScoreDetail {
+	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}, Findings: []Finding{}}
+	content := doc.content
 	score := 0
 
-	// Check readability (40 points)
-	readScore := ls.evaluateReadability(content)
+	// Check readability against the target reading level (40 points)
+	prose := ls.sentenceDocument(doc)
+	readScore, readIssue, grade := ls.evaluateReadability(prose)
 	score += readScore
+	doc.readingGrade = grade
 	if readScore >= 30 {
 		detail.Positives = append(detail.Positives, "Content is clear and readable")
 	} else {
-		detail.Issues = append(detail.Issues, "Simplify sentence structure for better readability")
+		detail.addIssue("semantic_clarity", "clarity.readability", readIssue, readScore, 40, prose.longSentenceEvidence()...)
 	}
 
 	// Check terminology consistency (30 points)
@@ -151,16 +334,41 @@ func (ls *LocalScorer) analyzeSemanticClarity(content string) ScoreDetail {
 	if termScore >= 25 {
 		detail.Positives = append(detail.Positives, "Consistent terminology usage")
 	} else {
-		detail.Issues = append(detail.Issues, "Use consistent terminology throughout")
-	}
-
-	// Check definition clarity (30 points)
-	defScore := ls.evaluateDefinitionClarity(content)
-	score += defScore
-	if defScore >= 25 {
-		detail.Positives = append(detail.Positives, "Clear definitions and explanations")
+		detail.addIssue("semantic_clarity", "clarity.terminology", "Use consistent terminology throughout", termScore, 30)
+	}
+
+	// Check definition clarity (30 points); a glossary earns a bonus, many
+	// undefined technical terms cap the score. Product pages are checked
+	// for unambiguous product naming instead
+	if ls.profile == ProfileProduct {
+		nameScore, nameIssue, nameEvidence := evaluateProductNaming(doc.page)
+		score += nameScore
+		if nameIssue == "" {
+			detail.Positives = append(detail.Positives, "Product is named unambiguously")
+		} else {
+			detail.addIssue("semantic_clarity", "product.naming", nameIssue, nameScore, 30, nameEvidence...)
+		}
 	} else {
-		detail.Issues = append(detail.Issues, "Define technical terms and concepts clearly")
+		defScore := ls.evaluateDefinitionClarity(content)
+		glossary := prose.glossary()
+		if glossary.entries >= minGlossaryEntries {
+			defScore = min(defScore+10, 30)
+		}
+		if len(glossary.terms) > maxUndefinedTerms {
+			defScore = min(defScore, 20)
+		}
+		score += defScore
+		switch {
+		case defScore >= 25 && glossary.entries >= minGlossaryEntries:
+			detail.Positives = append(detail.Positives, "Glossary defines key terms")
+		case defScore >= 25:
+			detail.Positives = append(detail.Positives, "Clear definitions and explanations")
+		case len(glossary.terms) > maxUndefinedTerms:
+			message := fmt.Sprintf("Add a glossary: %d technical terms (e.g. %s) are used without a definition", len(glossary.terms), glossary.undefinedTermList())
+			detail.addIssue("semantic_clarity", "clarity.definitions", message, defScore, 30, glossary.undefined...)
+		default:
+			detail.addIssue("semantic_clarity", "clarity.definitions", "Define technical terms and concepts clearly", defScore, 30)
+		}
 	}
 
 	detail.Score = score
@@ -168,35 +376,99 @@ func (ls *LocalScorer) analyzeSemanticClarity(content string) ScoreDetail {
 	return detail
 }
 
-func (ls *LocalScorer) analyzeContextRichness(content string, pageData *webpage.PageData) ScoreDetail {
-	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}}
+func (ls *LocalScorer) analyzeContextRichness(doc *document) ScoreDetail {
+	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}, Findings: []Finding{}}
+	content := doc.content
 	score := 0
 
-	// Check content depth (40 points)
-	depthScore := ls.evaluateContentDepth(content)
-	score += depthScore
-	if depthScore >= 30 {
-		detail.Positives = append(detail.Positives, "Rich, detailed content")
+	// Check content depth (40 points); a category or hub page is not
+	// meant to be long, but to say what its listing covers
+	if ls.profile == ProfileCategory {
+		introScore, introIssue, introEvidence := evaluateCategoryIntro(doc)
+		score += introScore
+		if introIssue == "" {
+			detail.Positives = append(detail.Positives, "The listing opens with a descriptive intro")
+		} else {
+			detail.addIssue("context_richness", "category.intro", introIssue, introScore, 40, introEvidence...)
+		}
 	} else {
-		detail.Issues = append(detail.Issues, "Add more detailed explanations and examples")
+		depthScore := ls.evaluateContentDepth(content)
+		score += depthScore
+		if depthScore >= 30 {
+			detail.Positives = append(detail.Positives, "Rich, detailed content")
+		} else {
+			detail.addIssue("context_richness", "richness.depth", "Add more detailed explanations and examples", depthScore, 40)
+		}
 	}
 
-	// Check examples and specifics (35 points)
-	exampleScore := ls.evaluateExamplesAndSpecifics(content)
-	score += exampleScore
-	if exampleScore >= 25 {
-		detail.Positives = append(detail.Positives, "Good use of examples and specific details")
-	} else {
-		detail.Issues = append(detail.Issues, "Include more concrete examples and specific details")
+	// Check examples and specifics (35 points); developer docs need
+	// runnable code examples and hub pages well-chosen links
+	switch ls.profile {
+	case ProfileCategory:
+		linkScore, linkIssue, linkEvidence := evaluateLinkCuration(doc)
+		score += linkScore
+		if linkIssue == "" {
+			detail.Positives = append(detail.Positives, "A curated listing with descriptive link texts")
+		} else {
+			detail.addIssue("context_richness", "category.links", linkIssue, linkScore, 35, linkEvidence...)
+		}
+	case ProfileDocs:
+		exampleScore, exampleEvidence := ls.evaluateRunnableExamples(doc)
+		score += exampleScore
+		if exampleScore >= 25 {
+			detail.Positives = append(detail.Positives, "Complete, runnable code examples")
+		} else {
+			detail.addIssue("context_richness", "docs.examples", "Add complete code examples readers can run as is", exampleScore, 35, exampleEvidence...)
+		}
+	default:
+		exampleScore := ls.evaluateExamplesAndSpecifics(content)
+		score += exampleScore
+		if exampleScore >= 25 {
+			detail.Positives = append(detail.Positives, "Good use of examples and specific details")
+		} else {
+			detail.addIssue("context_richness", "richness.examples", "Include more concrete examples and specific details", exampleScore, 35)
+		}
 	}
 
-	// Check background information (25 points)
-	backgroundScore := ls.evaluateBackgroundInfo(content)
-	score += backgroundScore
-	if backgroundScore >= 20 {
-		detail.Positives = append(detail.Positives, "Adequate background information provided")
-	} else {
-		detail.Issues = append(detail.Issues, "Provide more context and background information")
+	// Check background information (25 points); news is placed in time
+	// and space by its dateline and local businesses give opening hours
+	// instead
+	switch ls.profile {
+	case ProfileLocalBusiness:
+		hoursScore, hoursIssue, hoursEvidence := evaluateOpeningHours(doc)
+		score += hoursScore
+		if hoursIssue == "" {
+			detail.Positives = append(detail.Positives, "Opening hours are given and marked up")
+		} else {
+			detail.addIssue("context_richness", "local.hours", hoursIssue, hoursScore, 25, hoursEvidence...)
+		}
+	case ProfileNews:
+		datelineScore, datelineIssue, datelineEvidence := evaluateDateline(doc)
+		score += datelineScore
+		if datelineIssue == "" {
+			detail.Positives = append(detail.Positives, "Dateline and publication date are given")
+		} else {
+			detail.addIssue("context_richness", "news.dateline", datelineIssue, datelineScore, 25, datelineEvidence...)
+		}
+	default:
+		backgroundScore := ls.evaluateBackgroundInfo(content)
+		score += backgroundScore
+		if backgroundScore >= 12 {
+			detail.Positives = append(detail.Positives, "Adequate background information provided")
+		} else {
+			detail.addIssue("context_richness", "richness.background", "Provide more context and background information", backgroundScore, 15)
+		}
+
+		introScore := evaluateIntroduction(doc.opening)
+		score += introScore
+		if doc.opening != nil && doc.opening.Suggestion != "" {
+			detail.addIssue("context_richness", "richness.intro", doc.opening.Suggestion, introScore, introMaxPoints, sectionEvidence(doc.opening)...)
+		}
+		conclusionScore := evaluateConclusion(doc.closing)
+		score += conclusionScore
+		if doc.closing != nil && doc.closing.Suggestion != "" {
+			detail.addIssue("context_richness", "richness.conclusion", doc.closing.Suggestion, conclusionScore, conclusionMaxPoints, sectionEvidence(doc.closing)...)
+		}
 	}
 
 	detail.Score = score
@@ -204,44 +476,122 @@ func (ls *LocalScorer) analyzeContextRichness(content string, pageData *webpage.
 	return detail
 }
 
-func (ls *LocalScorer) analyzeAuthoritySignals(content string, pageData *webpage.PageData) ScoreDetail {
-	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}}
+func (ls *LocalScorer) analyzeAuthoritySignals(doc *document) ScoreDetail {
+	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}, Findings: []Finding{}}
+	content := doc.content
 	score := 0
 
-	// Check citations and references (40 points)
-	citationScore := ls.evaluateCitations(content)
-	score += citationScore
-	if citationScore >= 30 {
-		detail.Positives = append(detail.Positives, "Good use of citations and references")
-	} else {
-		detail.Issues = append(detail.Issues, "Add more citations and credible references")
+	// Check citations and references (40 points); product pages get their
+	// authority from review markup, news from named sources and local
+	// businesses from consistent name, address and phone instead
+	switch ls.profile {
+	case ProfileLocalBusiness:
+		napScore, napIssue, napEvidence := evaluateNAP(doc)
+		score += napScore
+		if napIssue == "" {
+			detail.Positives = append(detail.Positives, "Name, address and phone are consistent")
+		} else {
+			detail.addIssue("authority_signals", "local.nap", napIssue, napScore, 40, napEvidence...)
+		}
+	case ProfileNews:
+		sourceScore, sourceEvidence := evaluateNewsSources(doc)
+		score += sourceScore
+		if sourceScore >= 30 {
+			detail.Positives = append(detail.Positives, "Quotes are attributed to named sources")
+		} else {
+			detail.addIssue("authority_signals", "news.sources", "Quote named sources and attribute each quote", sourceScore, 40, sourceEvidence...)
+		}
+	case ProfileProduct:
+		reviewScore := evaluateProductReviews(doc.page)
+		score += reviewScore
+		if reviewScore >= 25 {
+			detail.Positives = append(detail.Positives, "Ratings and reviews are marked up")
+		} else {
+			detail.addIssue("authority_signals", "product.reviews", "Mark up ratings and reviews with AggregateRating (rating value and count) and Review", reviewScore, 40)
+		}
+	default:
+		citationScore, citationIssue, citationEvidence, cited := ls.evaluateCitationQuality(doc)
+		score += citationScore
+		doc.cited = cited
+		if citationIssue == "" {
+			detail.Positives = append(detail.Positives, "Good use of citations and references")
+		} else {
+			detail.addIssue("authority_signals", "authority.citations", citationIssue, citationScore, citationsMaxPoints, citationEvidence...)
+		}
+
+		// Links to independent sources rather than only the site itself
+		// (5 points)
+		balanceScore, balanceIssue, balanceEvidence := evaluateCitationBalance(doc)
+		score += balanceScore
+		if balanceIssue != "" {
+			detail.addIssue("authority_signals", "authority.balance", balanceIssue, balanceScore, balanceMaxPoints, balanceEvidence...)
+		}
 	}
 
-	// Check expertise indicators (35 points)
-	expertiseScore := ls.evaluateExpertiseIndicators(content)
-	score += expertiseScore
-	if expertiseScore >= 25 {
-		detail.Positives = append(detail.Positives, "Clear expertise and authority indicators")
+	// Check expertise indicators (35 points); news credits its reporter
+	// with a byline instead
+	if ls.profile == ProfileNews {
+		bylineScore, bylineIssue, bylineEvidence := evaluateByline(doc)
+		score += bylineScore
+		if bylineIssue == "" {
+			detail.Positives = append(detail.Positives, "Byline credits the reporter")
+		} else {
+			detail.addIssue("authority_signals", "news.byline", bylineIssue, bylineScore, 35, bylineEvidence...)
+		}
 	} else {
-		detail.Issues = append(detail.Issues, "Include more expertise and credibility signals")
+		expertiseScore := ls.evaluateExpertiseIndicators(content)
+		score += expertiseScore
+		if expertiseScore >= 18 {
+			detail.Positives = append(detail.Positives, "Clear expertise and authority indicators")
+		} else {
+			detail.addIssue("authority_signals", "authority.expertise", "Include more expertise and credibility signals", expertiseScore, 25)
+		}
+
+		// First-hand experience, apart from claims of expertise (10 points)
+		experienceScore, experienceIssue, experienceEvidence, markers := evaluateExperience(doc)
+		score += experienceScore
+		doc.experience = markers
+		if experienceIssue == "" {
+			detail.Positives = append(detail.Positives, "Shows first-hand experience backed by original data")
+		} else {
+			detail.addIssue("authority_signals", "authority.experience", experienceIssue, experienceScore, experienceMaxPoints, experienceEvidence...)
+		}
 	}
 
-	// Check factual accuracy indicators (25 points)
+	// Check factual accuracy indicators (15 points)
 	factScore := ls.evaluateFactualAccuracy(content)
 	score += factScore
-	if factScore >= 20 {
+	if factScore >= 10 {
 		detail.Positives = append(detail.Positives, "Content appears factual and well-researched")
 	} else {
-		detail.Issues = append(detail.Issues, "Ensure factual accuracy and provide sources")
+		detail.addIssue("authority_signals", "authority.factual", "Ensure factual accuracy and provide sources", factScore, 15, doc.containing(uncertaintyPatterns, maxEvidence)...)
+	}
+
+	// Check update notices and the page's age (10 points)
+	freshScore, freshIssue, freshEvidence, updated := evaluateFreshness(doc, ls.staleAfter, time.Now())
+	score += freshScore
+	if freshIssue == "" {
+		detail.Positives = append(detail.Positives, "Shows when the content was last updated")
+	} else {
+		detail.addIssue("authority_signals", "authority.freshness", freshIssue, freshScore, freshnessMaxPoints, freshEvidence...)
 	}
+	doc.updated = updated
+
+	// Deductions for affiliate, keyword-stuffed and hidden links
+	for _, spam := range detectLinkSpam(doc) {
+		score -= spam.deduction
+		detail.addIssue("authority_signals", spam.id, spam.message, 0, spam.deduction, spam.evidence...)
+	}
+	score = max(score, 0)
 
 	detail.Score = score
 	detail.Percentage = float64(score) / float64(detail.MaxScore) * 100
 	return detail
 }
 
-func (ls *LocalScorer) analyzeAccessibility(content string, pageData *webpage.PageData) ScoreDetail {
-	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}}
+func (ls *LocalScorer) analyzeAccessibility(doc *document) ScoreDetail {
+	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}, Findings: []Finding{}}
+	content, pageData := doc.content, doc.page
 	score := 0
 
 	// Check meta information (30 points)
@@ -250,25 +600,115 @@ func (ls *LocalScorer) analyzeAccessibility(content string, pageData *webpage.Pa
 	if metaScore >= 25 {
 		detail.Positives = append(detail.Positives, "Good meta information for AI understanding")
 	} else {
-		detail.Issues = append(detail.Issues, "Add comprehensive meta descriptions and keywords")
+		detail.addIssue("accessibility", "accessibility.meta", "Add comprehensive meta descriptions and keywords", metaScore, 30, doc.metaEvidence()...)
+	}
+
+	// Check content parsing friendliness (25 points); in developer docs,
+	// whether code blocks declare their language, on product pages whether
+	// the offer is machine readable, for news and local businesses their
+	// markup, for category pages how filtered variants are canonicalized
+	switch ls.profile {
+	case ProfileCategory:
+		facetScore, facetIssue, facetEvidence := evaluateFacetedDuplication(doc)
+		score += facetScore
+		if facetIssue == "" {
+			detail.Positives = append(detail.Positives, "Filtered and sorted variants do not duplicate the listing")
+		} else {
+			detail.addIssue("accessibility", "category.facets", facetIssue, facetScore, 25, facetEvidence...)
+		}
+	case ProfileLocalBusiness:
+		schemaScore, schemaIssue, schemaEvidence := evaluateLocalSchema(pageData)
+		score += schemaScore
+		if schemaIssue == "" {
+			detail.Positives = append(detail.Positives, "LocalBusiness markup is complete")
+		} else {
+			detail.addIssue("accessibility", "local.schema", schemaIssue, schemaScore, 25, schemaEvidence...)
+		}
+	case ProfileNews:
+		schemaScore, schemaIssue, schemaEvidence := evaluateNewsSchema(pageData)
+		score += schemaScore
+		if schemaIssue == "" {
+			detail.Positives = append(detail.Positives, "NewsArticle markup is complete")
+		} else {
+			detail.addIssue("accessibility", "news.schema", schemaIssue, schemaScore, 25, schemaEvidence...)
+		}
+	case ProfileDocs:
+		langScore, langIssue, langEvidence := ls.evaluateCodeLanguages(doc)
+		score += langScore
+		if langIssue == "" {
+			detail.Positives = append(detail.Positives, "Code blocks declare their language")
+		} else {
+			detail.addIssue("accessibility", "docs.code_languages", langIssue, langScore, 25, langEvidence...)
+		}
+	case ProfileProduct:
+		offerScore, offerIssue, offerEvidence := evaluateProductOffer(pageData)
+		score += offerScore
+		if offerIssue == "" {
+			detail.Positives = append(detail.Positives, "Price and availability are marked up")
+		} else {
+			detail.addIssue("accessibility", "product.offer", offerIssue, offerScore, 25, offerEvidence...)
+		}
+	default:
+		parseScore := ls.evaluateParsingFriendliness(content)
+		score += parseScore
+		if parseScore >= 15 {
+			detail.Positives = append(detail.Positives, "Content is easy to parse and understand")
+		} else {
+			detail.addIssue("accessibility", "accessibility.parsing", "Structure content for better machine readability", parseScore, 25)
+		}
 	}
 
-	// Check content parsing friendliness (35 points)
-	parseScore := ls.evaluateParsingFriendliness(content)
-	score += parseScore
-	if parseScore >= 25 {
-		detail.Positives = append(detail.Positives, "Content is easy to parse and understand")
+	// Check information density (15 points)
+	prose := ls.sentenceDocument(doc)
+	densityScore := ls.evaluateInformationDensity(prose)
+	score += densityScore
+	if densityScore >= 11 {
+		detail.Positives = append(detail.Positives, "Good information density")
 	} else {
-		detail.Issues = append(detail.Issues, "Structure content for better machine readability")
+		detail.addIssue("accessibility", "accessibility.density", "Balance information density - avoid being too sparse or dense", densityScore, 15, prose.longSentenceEvidence()...)
 	}
 
-	// Check information density (35 points)
-	densityScore := ls.evaluateInformationDensity(content)
-	score += densityScore
-	if densityScore >= 25 {
-		detail.Positives = append(detail.Positives, "Good information density")
+	// Check page language, title, heading order and control names (10 points)
+	wcagScore, wcagIssue, wcagEvidence := evaluateWCAG(doc)
+	score += wcagScore
+	if wcagIssue == "" {
+		detail.Positives = append(detail.Positives, "Page language, title, heading order and control names meet basic WCAG checks")
 	} else {
-		detail.Issues = append(detail.Issues, "Balance information density - avoid being too sparse or dense")
+		detail.addIssue("accessibility", "accessibility.wcag", wcagIssue, wcagScore, wcagMaxPoints, wcagEvidence...)
+	}
+
+	// Check that sections can be linked to (10 points)
+	anchorScore, anchorIssue, anchorEvidence := evaluateHeadingAnchors(doc, doc.anchors, doc.sections)
+	score += anchorScore
+	if anchorIssue == "" {
+		if doc.sections >= minAnchoredSections {
+			detail.Positives = append(detail.Positives, "Section headings have stable anchors")
+		}
+	} else {
+		detail.addIssue("accessibility", "accessibility.anchors", anchorIssue, anchorScore, anchorsMaxPoints, anchorEvidence...)
+	}
+
+	// Check breadcrumbs and site hierarchy (10 points)
+	hierarchyScore, hierarchyIssue, hierarchyEvidence := evaluateSiteHierarchy(pageData)
+	score += hierarchyScore
+	if hierarchyIssue == "" {
+		detail.Positives = append(detail.Positives, "Breadcrumbs place the page in the site hierarchy")
+	} else {
+		detail.addIssue("accessibility", "accessibility.hierarchy", hierarchyIssue, hierarchyScore, hierarchyMaxPoints, hierarchyEvidence...)
+	}
+
+	// Deduction for a missing or broken preview image
+	if issue, evidence := evaluatePreviewImage(pageData); issue != "" {
+		score = max(score-previewDeduction, 0)
+		detail.addIssue("accessibility", "accessibility.preview_image", issue, 0, previewDeduction, evidence...)
+	} else {
+		detail.Positives = append(detail.Positives, "The og:image gives assistants a preview of the page")
+	}
+
+	// Deduction for content behind a paywall or login wall
+	if doc.wall != nil {
+		score = max(score-paywallDeduction, 0)
+		detail.addIssue("accessibility", "accessibility.paywall", paywallIssue(doc.wall), 0, paywallDeduction, doc.wall.Evidence...)
 	}
 
 	detail.Score = score
@@ -276,6 +716,21 @@ func (ls *LocalScorer) analyzeAccessibility(content string, pageData *webpage.Pa
 	return detail
 }
 
+// sentenceDocument returns the document sentence metrics run on: the prose
+// without code blocks for developer docs, the whole content otherwise.
+func (ls *LocalScorer) sentenceDocument(doc *document) *document {
+	if ls.profile == ProfileDocs {
+		return doc.prose()
+	}
+	return doc
+}
+
+// uncertaintyPatterns is hedging language that might indicate uncertainty
+var uncertaintyPatterns = []string{
+	"might", "could", "possibly", "perhaps", "maybe", "seems",
+	"appears", "likely", "probably", "allegedly", "reportedly",
+}
+
 // Helper functions for evaluation
 func (ls *LocalScorer) evaluateHeadingHierarchy(headings []webpage.Heading) int {
 	if len(headings) == 0 {
@@ -315,19 +770,10 @@ func (ls *LocalScorer) evaluateHeadingHierarchy(headings []webpage.Heading) int
 
 func (ls *LocalScorer) evaluateContentOrganization(content string) int {
 	sections := strings.Split(content, "\n\n")
-	if len(sections) < 2 {
-		return 5
-	}
-
-	score := 10
-	if len(sections) >= 3 {
-		score += 10
-	}
-	if len(sections) >= 5 {
-		score += 5
+	if len(sections) < 3 {
+		return 0
 	}
-
-	return min(score, 25)
+	return 5
 }
 
 func (ls *LocalScorer) evaluateParagraphStructure(content string) int {
@@ -336,7 +782,7 @@ func (ls *LocalScorer) evaluateParagraphStructure(content string) int {
 	
 	goodParagraphs := 0
 	for _, para := range paragraphs {
-		words := len(strings.Fields(para))
+		words := depthWords(para)
 		if words >= 20 && words <= 150 {
 			goodParagraphs++
 		}
@@ -344,63 +790,19 @@ func (ls *LocalScorer) evaluateParagraphStructure(content string) int {
 
 	if len(paragraphs) > 0 {
 		ratio := float64(goodParagraphs) / float64(len(paragraphs))
-		score = int(ratio * 25)
+		score = int(ratio * 15)
 	}
 
 	return score
 }
 
-func (ls *LocalScorer) evaluateListUsage(content string) int {
-	// Simple check for list indicators
-	listIndicators := []string{"•", "-", "*", "1.", "2.", "3.", "①", "②", "③"}
-	listCount := 0
-	
-	for _, indicator := range listIndicators {
-		listCount += strings.Count(content, indicator)
-	}
-
-	if listCount == 0 {
-		return 5
-	} else if listCount <= 3 {
-		return 10
-	} else if listCount <= 8 {
-		return 20
-	}
-	return 20
-}
-
-func (ls *LocalScorer) evaluateReadability(content string) int {
-	words := strings.Fields(content)
-	if len(words) == 0 {
-		return 0
-	}
-
-	// Simple readability metrics
-	avgWordsPerSentence := ls.calculateAvgWordsPerSentence(content)
-	avgSyllablesPerWord := ls.calculateAvgSyllablesPerWord(words)
-
-	score := 20 // Base score
-
-	// Prefer 15-20 words per sentence
-	if avgWordsPerSentence >= 10 && avgWordsPerSentence <= 25 {
-		score += 10
-	}
-
-	// Prefer 1-3 syllables per word average
-	if avgSyllablesPerWord >= 1.0 && avgSyllablesPerWord <= 2.5 {
-		score += 10
-	}
-
-	return min(score, 40)
-}
-
 func (ls *LocalScorer) evaluateTerminologyConsistency(content string) int {
 	// Simple consistency check - could be enhanced
-	words := strings.Fields(strings.ToLower(content))
+	words := splitWords(strings.ToLower(content))
 	wordCount := make(map[string]int)
 	
 	for _, word := range words {
-		if len(word) > 4 { // Focus on longer words
+		if utf8.RuneCountInString(word) > 4 { // Focus on longer words
 			wordCount[word]++
 		}
 	}
@@ -449,7 +851,7 @@ func (ls *LocalScorer) evaluateDefinitionClarity(content string) int {
 }
 
 func (ls *LocalScorer) evaluateContentDepth(content string) int {
-	wordCount := len(strings.Fields(content))
+	wordCount := depthWords(content)
 	
 	if wordCount < 100 {
 		return 5
@@ -488,7 +890,7 @@ func (ls *LocalScorer) evaluateExamplesAndSpecifics(content string) int {
 
 func (ls *LocalScorer) evaluateBackgroundInfo(content string) int {
 	backgroundPatterns := []string{
-		"background", "context", "history", "overview", "introduction",
+		"background", "context", "history", "overview",
 		"originally", "previously", "traditionally", "historically",
 	}
 
@@ -498,13 +900,11 @@ func (ls *LocalScorer) evaluateBackgroundInfo(content string) int {
 	}
 
 	if backgroundCount == 0 {
-		return 5
+		return 3
 	} else if backgroundCount <= 3 {
-		return 15
-	} else if backgroundCount <= 6 {
-		return 25
+		return 9
 	}
-	return 25
+	return 15
 }
 
 func (ls *LocalScorer) evaluateCitations(content string) int {
@@ -544,22 +944,14 @@ func (ls *LocalScorer) evaluateExpertiseIndicators(content string) int {
 	}
 
 	if expertiseCount == 0 {
-		return 10
+		return 7
 	} else if expertiseCount <= 3 {
-		return 20
-	} else if expertiseCount <= 8 {
-		return 35
+		return 14
 	}
-	return 35
+	return 25
 }
 
 func (ls *LocalScorer) evaluateFactualAccuracy(content string) int {
-	// Look for hedging language that might indicate uncertainty
-	uncertaintyPatterns := []string{
-		"might", "could", "possibly", "perhaps", "maybe", "seems",
-		"appears", "likely", "probably", "allegedly", "reportedly",
-	}
-
 	factualPatterns := []string{
 		"fact", "proven", "demonstrated", "confirmed", "verified",
 		"established", "documented", "evidence", "data", "statistics",
@@ -577,15 +969,15 @@ func (ls *LocalScorer) evaluateFactualAccuracy(content string) int {
 	}
 
 	// Prefer more factual language, less uncertainty
-	score := 15 // Base score
+	score := 5 // Base score
 	if factualCount > uncertaintyCount {
-		score += 10
+		score += 5
 	}
 	if factualCount >= 3 {
 		score += 5
 	}
 
-	return min(score, 25)
+	return min(score, 15)
 }
 
 func (ls *LocalScorer) evaluateMetaInformation(pageData *webpage.PageData) int {
@@ -611,11 +1003,10 @@ func (ls *LocalScorer) evaluateMetaInformation(pageData *webpage.PageData) int {
 }
 
 func (ls *LocalScorer) evaluateParsingFriendliness(content string) int {
-	score := 15 // Base score
+	score := 5 // Base score
 
 	// Check for clear sentence structure
-	sentences := strings.Split(content, ".")
-	if len(sentences) > 3 {
+	if countSentences(content) > 3 {
 		score += 10
 	}
 
@@ -624,40 +1015,40 @@ func (ls *LocalScorer) evaluateParsingFriendliness(content string) int {
 		score += 10
 	}
 
-	return min(score, 35)
+	return min(score, 25)
 }
 
-func (ls *LocalScorer) evaluateInformationDensity(content string) int {
-	words := strings.Fields(content)
-	sentences := strings.Split(content, ".")
-	
-	if len(sentences) == 0 {
+func (ls *LocalScorer) evaluateInformationDensity(doc *document) int {
+	if len(doc.proseSentences()) == 0 {
 		return 0
 	}
 
-	avgWordsPerSentence := float64(len(words)) / float64(len(sentences))
+	avgWordsPerSentence := ls.calculateAvgWordsPerSentence(doc)
 
 	// Optimal range: 12-20 words per sentence
 	if avgWordsPerSentence >= 10 && avgWordsPerSentence <= 25 {
-		return 35
+		return 15
 	} else if avgWordsPerSentence >= 8 && avgWordsPerSentence <= 30 {
-		return 25
+		return 11
 	} else if avgWordsPerSentence >= 5 && avgWordsPerSentence <= 35 {
-		return 15
+		return 6
 	}
-	return 10
+	return 3
 }
 
 // Utility functions
-func (ls *LocalScorer) calculateAvgWordsPerSentence(content string) float64 {
-	sentences := regexp.MustCompile(`[.!?]+`).Split(content, -1)
-	words := strings.Fields(content)
+func (ls *LocalScorer) calculateAvgWordsPerSentence(doc *document) float64 {
+	sentences := doc.proseSentences()
+	words := 0
+	for _, s := range sentences {
+		words += wordCount(doc.content, s)
+	}
 	
 	if len(sentences) == 0 {
 		return 0
 	}
 	
-	return float64(len(words)) / float64(len(sentences))
+	return float64(words) / float64(len(sentences))
 }
 
 func (ls *LocalScorer) calculateAvgSyllablesPerWord(words []string) float64 {
@@ -675,7 +1066,6 @@ func (ls *LocalScorer) calculateAvgSyllablesPerWord(words []string) float64 {
 
 func (ls *LocalScorer) countSyllables(word string) int {
 	word = strings.ToLower(word)
-	vowels := "aeiouy"
 	syllables := 0
 	prevWasVowel := false
 	
@@ -707,38 +1097,14 @@ func (ls *LocalScorer) calculateOverallScore(breakdown ScoreBreakdown) int {
 	weightedScore += float64(breakdown.ContextRichness.Score) * ls.weights.ContextRichness
 	weightedScore += float64(breakdown.AuthoritySignals.Score) * ls.weights.AuthoritySignals
 	weightedScore += float64(breakdown.Accessibility.Score) * ls.weights.Accessibility
+	weightedScore += float64(breakdown.StructuredData.Score) * ls.weights.StructuredData
+	for _, p := range breakdown.Custom {
+		weightedScore += float64(p.Score) * ls.pillarWeight(p.ID)
+	}
 	
 	return int(math.Round(weightedScore))
 }
 
-func (ls *LocalScorer) generateInsights(score *GEOScore) {
-	// Collect all strengths and weaknesses
-	allDetails := []ScoreDetail{
-		score.Breakdown.ContentStructure,
-		score.Breakdown.SemanticClarity,
-		score.Breakdown.ContextRichness,
-		score.Breakdown.AuthoritySignals,
-		score.Breakdown.Accessibility,
-	}
-
-	for _, detail := range allDetails {
-		score.Strengths = append(score.Strengths, detail.Positives...)
-		for _, issue := range detail.Issues {
-			score.Suggestions = append(score.Suggestions, issue)
-		}
-		if detail.Percentage < 50 {
-			score.Weaknesses = append(score.Weaknesses, detail.Issues...)
-		}
-	}
-
-	// Add overall suggestions based on score
-	if score.Overall < 60 {
-		score.Suggestions = append(score.Suggestions, "Consider comprehensive content restructuring for better GEO optimization")
-	} else if score.Overall < 80 {
-		score.Suggestions = append(score.Suggestions, "Focus on improving the lowest-scoring areas identified above")
-	}
-}
-
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -0,0 +1,188 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"regexp"
+	"strings"
+)
+
+// localBusinessTypes are LocalBusiness and the subtypes sites use most.
+var localBusinessTypes = []string{
+	"LocalBusiness", "Store", "Restaurant", "FoodEstablishment", "CafeOrCoffeeShop",
+	"ProfessionalService", "LegalService", "Attorney", "FinancialService",
+	"MedicalBusiness", "Dentist", "Physician", "HealthAndBeautyBusiness",
+	"HomeAndConstructionBusiness", "Plumber", "Electrician", "AutoRepair",
+	"LodgingBusiness", "Hotel", "RealEstateAgent",
+}
+
+var (
+	// phonePattern matches phone numbers written in text.
+	phonePattern = regexp.MustCompile(`\+?\d[\d ().-]{6,}\d`)
+
+	// hoursPattern matches opening hours written in text, e.g.
+	// "Mon–Fri 9:00–18:00" or "Opening hours".
+	hoursPattern = regexp.MustCompile(`(?i)\b(mon|tue|wed|thu|fri|sat|sun)[a-z]*\.?\b[^\n]{0,40}?\d{1,2}([:.]\d{2})?\s*(am|pm|h)?\s*(-|–|—|to)\s*\d{1,2}|\b(opening|business|office) hours\b|\bopen 24 hours\b`)
+)
+
+// localBusiness returns the page's LocalBusiness markup, or nil.
+func localBusiness(pageData *webpage.PageData) map[string]any {
+	for _, typ := range localBusinessTypes {
+		if businesses := pageData.SchemaObjects(typ); len(businesses) > 0 {
+			return businesses[0]
+		}
+	}
+	return nil
+}
+
+// streetAddress returns the street of a JSON-LD address: a PostalAddress
+// or plain text.
+func streetAddress(v any) string {
+	if obj, ok := v.(map[string]any); ok {
+		return schemaText(obj["streetAddress"])
+	}
+	return schemaText(v)
+}
+
+// phoneDigits reduces a phone number to its last nine digits, enough to
+// compare numbers written with and without a country code.
+func phoneDigits(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) > 9 {
+		digits = digits[len(digits)-9:]
+	}
+	return digits
+}
+
+// visibleLocation is the page text contact details can appear in: the
+// content and the addresses found outside it.
+func visibleLocation(doc *document) string {
+	return doc.content + "\n" + strings.Join(doc.page.Addresses, "\n")
+}
+
+func normalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// evaluateNAP scores name, address and phone consistency (40 points):
+// the business name (10), its telephone (15) and street address (15) as
+// declared in LocalBusiness markup must also be shown on the page. Without
+// markup only the visible address and phone count (10 each). It returns
+// the issue to report, if any, and the mismatches as evidence.
+func evaluateNAP(doc *document) (int, string, []Evidence) {
+	page := doc.page
+	business := localBusiness(page)
+	if business == nil {
+		score := 5
+		if len(page.Addresses) > 0 {
+			score += 10
+		}
+		if len(page.Phones) > 0 {
+			score += 10
+		}
+		return score, "Declare the business name, address and phone in LocalBusiness markup matching what the page shows", nil
+	}
+
+	visible := normalizeText(page.Title + "\n" + visibleLocation(doc))
+	score := 0
+	var evidence []Evidence
+
+	if name := schemaText(business["name"]); name != "" && strings.Contains(visible, normalizeText(name)) {
+		score += 10
+	} else if name != "" {
+		evidence = append(evidence, missingEvidence("business name %q in markup is not shown on the page", name))
+	} else {
+		evidence = append(evidence, missingEvidence("LocalBusiness markup has no name"))
+	}
+
+	if phone := schemaText(business["telephone"]); phone != "" {
+		shown := append(append([]string{}, page.Phones...), phonePattern.FindAllString(visibleLocation(doc), -1)...)
+		matched := false
+		for _, p := range shown {
+			matched = matched || phoneDigits(p) == phoneDigits(phone)
+		}
+		if matched {
+			score += 15
+		} else {
+			evidence = append(evidence, missingEvidence("telephone %s in markup is not shown on the page", phone))
+		}
+	} else {
+		evidence = append(evidence, missingEvidence("LocalBusiness markup has no telephone"))
+	}
+
+	if street := streetAddress(business["address"]); street != "" && strings.Contains(visible, normalizeText(street)) {
+		score += 15
+	} else if street != "" {
+		evidence = append(evidence, missingEvidence("address %q in markup is not shown on the page", street))
+	} else {
+		evidence = append(evidence, missingEvidence("LocalBusiness markup has no street address"))
+	}
+
+	if len(evidence) > 0 {
+		return score, "Show the same business name, address and phone on the page as in the LocalBusiness markup", evidence
+	}
+	return score, "", nil
+}
+
+// evaluateLocalSchema scores LocalBusiness markup (25 points): the type
+// (10) with a name, address and telephone (5 each).
+func evaluateLocalSchema(pageData *webpage.PageData) (int, string, []Evidence) {
+	business := localBusiness(pageData)
+	if business == nil {
+		return 0, "Add LocalBusiness markup (or a subtype such as Restaurant or Dentist) with name, address and telephone", nil
+	}
+
+	score := 10
+	var evidence []Evidence
+	for _, prop := range []string{"name", "address", "telephone"} {
+		if schemaText(business[prop]) != "" || streetAddress(business[prop]) != "" {
+			score += 5
+		} else {
+			evidence = append(evidence, missingEvidence("LocalBusiness markup has no %s", prop))
+		}
+	}
+	if len(evidence) > 0 {
+		return score, fmt.Sprintf("Complete the LocalBusiness markup (%d properties missing)", len(evidence)), evidence
+	}
+	return score, "", nil
+}
+
+// evaluateOpeningHours scores opening hours (25 points): declared in
+// markup (15) and written on the page (10).
+func evaluateOpeningHours(doc *document) (int, string, []Evidence) {
+	score := 0
+	var evidence []Evidence
+	if business := localBusiness(doc.page); business != nil && (business["openingHours"] != nil || business["openingHoursSpecification"] != nil) {
+		score += 15
+	} else {
+		evidence = append(evidence, missingEvidence("no openingHours or openingHoursSpecification in markup"))
+	}
+	if hoursPattern.MatchString(visibleLocation(doc)) {
+		score += 10
+	} else {
+		evidence = append(evidence, missingEvidence("no opening hours on the page"))
+	}
+
+	if len(evidence) > 0 {
+		return score, "Give opening hours on the page and in openingHoursSpecification markup", evidence
+	}
+	return score, "", nil
+}
+
+// evaluateLocationDetails scores how the location is shown (20 points): a
+// postal address as text (10) and an embedded map (10).
+func evaluateLocationDetails(pageData *webpage.PageData) int {
+	score := 0
+	if len(pageData.Addresses) > 0 {
+		score += 10
+	}
+	if len(pageData.MapEmbeds) > 0 {
+		score += 10
+	}
+	return score
+}
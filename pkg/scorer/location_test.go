@@ -0,0 +1,77 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestEvaluateNAP(t *testing.T) {
+	business := map[string]any{
+		"@type":     "Dentist",
+		"name":      "Cabinet Dupont",
+		"telephone": "+33 4 78 00 00 00",
+		"address":   map[string]any{"@type": "PostalAddress", "streetAddress": "12 rue Victor Hugo"},
+	}
+	tests := []struct {
+		name     string
+		phones   []string
+		address  string
+		want     int
+		evidence int
+	}{
+		{"consistent", []string{"04 78 00 00 00"}, "Cabinet Dupont 12 rue Victor Hugo 69002 Lyon", 40, 0},
+		{"other phone", []string{"04 78 11 11 11"}, "Cabinet Dupont 12 rue Victor Hugo 69002 Lyon", 25, 1},
+		{"address missing", []string{"+33478000000"}, "", 10 + 15, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &webpage.PageData{
+				Title:          "Cabinet Dupont, dentist in Lyon",
+				Content:        "Dental care for the whole family.",
+				Phones:         tt.phones,
+				StructuredData: []map[string]any{business},
+			}
+			if tt.address != "" {
+				page.Addresses = []string{tt.address}
+			}
+			score, _, evidence := evaluateNAP(newDocument(page.Content, page))
+			if score != tt.want || len(evidence) != tt.evidence {
+				t.Errorf("NAP = %d with %+v, want %d with %d evidence", score, evidence, tt.want, tt.evidence)
+			}
+		})
+	}
+}
+
+func TestLocalBusinessProfile(t *testing.T) {
+	content := "Cabinet Dupont\n\nOpening hours: Mon–Fri 9:00–18:00."
+	page := &webpage.PageData{
+		Content:   content,
+		MetaTags:  map[string]string{},
+		Headings:  []webpage.Heading{{Level: 1, Text: "Cabinet Dupont"}},
+		Addresses: []string{"12 rue Victor Hugo 69002 Lyon"},
+		MapEmbeds: []string{"https://www.google.com/maps/embed?pb=abc"},
+		StructuredData: []map[string]any{{
+			"@type":        "Dentist",
+			"name":         "Cabinet Dupont",
+			"address":      "12 rue Victor Hugo",
+			"openingHours": "Mo-Fr 09:00-18:00",
+		}},
+	}
+
+	ls := NewLocalScorer()
+	ls.SetProfile(ProfileLocalBusiness)
+	score := ls.AnalyzeContent(content, page)
+
+	ids := map[string]bool{}
+	for _, pillar := range []ScoreDetail{score.Breakdown.ContentStructure, score.Breakdown.ContextRichness, score.Breakdown.AuthoritySignals, score.Breakdown.Accessibility} {
+		for _, f := range pillar.Findings {
+			ids[f.ID] = true
+		}
+	}
+	if ids["local.location"] || ids["local.hours"] {
+		t.Errorf("address, map and hours not recognized: %v", ids)
+	}
+	if !ids["local.nap"] || !ids["local.schema"] {
+		t.Errorf("missing telephone not flagged: %v", ids)
+	}
+}
@@ -0,0 +1,193 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// newsWeights favor authority: for news, who reported it and whom they
+// spoke to matter more than structure.
+var newsWeights = GEOWeights{
+	ContentStructure: 0.13,
+	SemanticClarity:  0.18,
+	ContextRichness:  0.18,
+	AuthoritySignals: 0.28,
+	Accessibility:    0.13,
+	StructuredData:   0.10,
+}
+
+var (
+	// datelinePattern matches a dateline opening a paragraph, e.g.
+	// "LONDON —" or "NEW YORK, March 3 (Reuters) -".
+	datelinePattern = regexp.MustCompile(`^[A-Z][A-Z .'-]{2,}(, [A-Z][a-z]+\.?( \d{1,2})?)?( \([A-Za-z .]+\))? ?(—|–|--?) `)
+
+	// bylinePattern matches a visible byline such as "By Jane Doe".
+	bylinePattern = regexp.MustCompile(`(?m)^(By|Written by|Reporting by) [A-Z][\w'.-]+( [A-Z][\w'.-]+)+`)
+
+	// attributedQuotePattern matches a quote attributed to a named person:
+	// "…," said Jane Doe / Jane Doe said: "…" / according to Jane Doe.
+	attributedQuotePattern = regexp.MustCompile(`["“][^"”]{10,}["”],? (said|says|told|added|explained|wrote) [A-Z][\w'.-]+ [A-Z][\w'.-]+|[A-Z][\w'.-]+ [A-Z][\w'.-]+ (said|says|told \w+|added|explained|wrote)[,:]? ["“][^"”]{10,}["”]|according to [A-Z][\w'.-]+ [A-Z][\w'.-]+`)
+
+	// updateNotePattern matches a visible update or correction note.
+	updateNotePattern = regexp.MustCompile(`(?i)\b(updated|correction|clarification|editor's note)\b[^.\n]{0,40}(:|\d)`)
+)
+
+// anonymousSources is sourcing that names no one.
+var anonymousSources = []string{
+	"sources said", "a source said", "people familiar with", "officials said",
+	"a spokesperson said", "insiders", "according to sources", "critics say",
+}
+
+// newsArticle returns the page's NewsArticle markup, or nil.
+func newsArticle(pageData *webpage.PageData) map[string]any {
+	for _, typ := range []string{"NewsArticle", "ReportageNewsArticle", "AnalysisNewsArticle"} {
+		if articles := pageData.SchemaObjects(typ); len(articles) > 0 {
+			return articles[0]
+		}
+	}
+	return nil
+}
+
+// authorName returns the first name in a JSON-LD author value: a string,
+// a Person or a list of them.
+func authorName(v any) string {
+	if name := schemaText(v); name != "" {
+		return name
+	}
+	for _, a := range schemaObjects(v) {
+		if name := schemaText(a); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// articleDate returns the date the page declares in the NewsArticle
+// property or, failing that, the article meta tag.
+func articleDate(pageData *webpage.PageData, property, metaTag string) time.Time {
+	value := pageData.MetaTags[metaTag]
+	if article := newsArticle(pageData); article != nil && schemaText(article[property]) != "" {
+		value = schemaText(article[property])
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// evaluateNewsSources scores named sourcing (40 points): 5 without quotes
+// attributed to a named person, 20 for one, 30 for two and 40 for three or
+// more. Anonymous sourcing is returned as evidence.
+func evaluateNewsSources(doc *document) (int, []Evidence) {
+	named := len(attributedQuotePattern.FindAllString(doc.content, -1))
+	evidence := doc.containing(anonymousSources, maxEvidence)
+	switch {
+	case named >= 3:
+		return 40, evidence
+	case named == 2:
+		return 30, evidence
+	case named == 1:
+		return 20, evidence
+	}
+	return 5, evidence
+}
+
+// evaluateByline scores authorship (35 points): a visible byline (20) and
+// an author in NewsArticle markup or the author meta tag (15).
+func evaluateByline(doc *document) (int, string, []Evidence) {
+	score := 0
+	var evidence []Evidence
+	if bylinePattern.MatchString(doc.content) {
+		score += 20
+	} else {
+		evidence = append(evidence, missingEvidence("no visible byline (\"By Jane Doe\")"))
+	}
+
+	author := strings.TrimSpace(doc.page.MetaTags["author"])
+	if article := newsArticle(doc.page); article != nil && authorName(article["author"]) != "" {
+		author = authorName(article["author"])
+	}
+	if author != "" {
+		score += 15
+	} else {
+		evidence = append(evidence, missingEvidence("no author in NewsArticle markup or meta tags"))
+	}
+
+	if len(evidence) > 0 {
+		return score, "Credit the reporter with a visible byline and an author in the NewsArticle markup", evidence
+	}
+	return score, "", nil
+}
+
+// evaluateDateline scores when and where the story was filed (25 points):
+// a dateline opening the story (15) and a declared publication date (10).
+func evaluateDateline(doc *document) (int, string, []Evidence) {
+	score := 0
+	var evidence []Evidence
+	dateline := false
+	for i, p := range doc.paragraphs() {
+		if i >= 5 {
+			break
+		}
+		if !doc.isHeading(p) && datelinePattern.MatchString(doc.content[p.start:p.end]) {
+			dateline = true
+			break
+		}
+	}
+	if dateline {
+		score += 15
+	} else {
+		evidence = append(evidence, missingEvidence("no dateline (\"LONDON, March 3 —\") opening the story"))
+	}
+	if !articleDate(doc.page, "datePublished", "article:published_time").IsZero() {
+		score += 10
+	} else {
+		evidence = append(evidence, missingEvidence("no datePublished or article:published_time"))
+	}
+
+	if len(evidence) > 0 {
+		return score, "Open with a dateline and declare the publication date", evidence
+	}
+	return score, "", nil
+}
+
+// evaluateUpdateNotes scores transparency about changes (20 points): a
+// story modified after publication needs a visible update or correction
+// note. Unmodified stories get full points.
+func evaluateUpdateNotes(doc *document) (int, string, []Evidence) {
+	published := articleDate(doc.page, "datePublished", "article:published_time")
+	modified := articleDate(doc.page, "dateModified", "article:modified_time")
+	if published.IsZero() || !modified.After(published.Add(time.Hour)) || updateNotePattern.MatchString(doc.content) {
+		return 20, "", nil
+	}
+	ev := missingEvidence("modified %s, published %s, with no update note", modified.Format("2006-01-02"), published.Format("2006-01-02"))
+	return 5, "Add an update note saying what changed since publication", []Evidence{ev}
+}
+
+// evaluateNewsSchema scores NewsArticle markup (25 points): the type (10)
+// with a headline, datePublished and author (5 each).
+func evaluateNewsSchema(pageData *webpage.PageData) (int, string, []Evidence) {
+	article := newsArticle(pageData)
+	if article == nil {
+		return 0, "Add NewsArticle markup with headline, datePublished and author", nil
+	}
+
+	score := 10
+	var evidence []Evidence
+	for _, prop := range []string{"headline", "datePublished", "author"} {
+		if schemaText(article[prop]) != "" || (prop == "author" && authorName(article[prop]) != "") {
+			score += 5
+		} else {
+			evidence = append(evidence, missingEvidence("NewsArticle markup has no %s", prop))
+		}
+	}
+	if len(evidence) > 0 {
+		return score, fmt.Sprintf("Complete the NewsArticle markup (%d properties missing)", len(evidence)), evidence
+	}
+	return score, "", nil
+}
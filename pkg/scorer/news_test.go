@@ -0,0 +1,57 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestNewsProfile(t *testing.T) {
+	content := "Council approves new tram line\n\n" +
+		"By Maria Lopez\n\n" +
+		"LYON, March 3 (AFP) — The city council approved a 12 km tram line on Monday.\n\n" +
+		"\"This line connects the suburbs to the centre for the first time,\" said Paul Martin, the transport deputy.\n\n" +
+		"Officials said construction could start next year."
+	page := &webpage.PageData{
+		Content:  content,
+		MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 1, Text: "Council approves new tram line"}},
+		StructuredData: []map[string]any{{
+			"@type":         "NewsArticle",
+			"headline":      "Council approves new tram line",
+			"datePublished": "2026-03-03T09:00:00Z",
+			"dateModified":  "2026-03-04T15:00:00Z",
+			"author":        []any{map[string]any{"@type": "Person", "name": "Maria Lopez"}},
+		}},
+	}
+
+	ls := NewLocalScorer()
+	ls.SetProfile(ProfileNews)
+	if ls.weights != newsWeights {
+		t.Fatalf("weights = %+v, want news weights", ls.weights)
+	}
+	score := ls.AnalyzeContent(content, page)
+
+	found := map[string]Finding{}
+	for _, pillar := range []ScoreDetail{score.Breakdown.ContentStructure, score.Breakdown.ContextRichness, score.Breakdown.AuthoritySignals, score.Breakdown.Accessibility} {
+		for _, f := range pillar.Findings {
+			found[f.ID] = f
+		}
+	}
+	for _, id := range []string{"news.byline", "news.dateline", "news.schema", "authority.citations", "richness.background"} {
+		if f, ok := found[id]; ok {
+			t.Errorf("unexpected finding %s: %+v", id, f)
+		}
+	}
+	if f, ok := found["news.updates"]; !ok || f.points != 5 {
+		t.Errorf("update note finding = %+v", f)
+	}
+	sources, ok := found["news.sources"]
+	if !ok || sources.points != 20 || len(sources.Evidence) != 1 {
+		t.Errorf("sources finding = %+v, want one named quote and the anonymous one as evidence", sources)
+	}
+
+	ls.SetProfile(ProfileGeneral)
+	if ls.weights != defaultWeights {
+		t.Errorf("weights not restored: %+v", ls.weights)
+	}
+}
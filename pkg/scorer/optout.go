@@ -0,0 +1,93 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"geo-checker/internal/webpage"
+)
+
+// AIOptOut records that a page opts out of AI training or text and data
+// mining: noai and noimageai robots directives, or a TDM reservation
+// (W3C TDMRep) in a meta tag or response header. AI systems that honour
+// these signals may not use or cite the page however well it scores.
+type AIOptOut struct {
+	NoAI        bool     `json:"noai,omitempty"`
+	NoImageAI   bool     `json:"noimageai,omitempty"`
+	TDMReserved bool     `json:"tdm_reserved,omitempty"`
+	TDMPolicy   string   `json:"tdm_policy,omitempty"` // URL of the licensing policy
+	Sources     []string `json:"sources"`              // the tags and headers declaring it
+}
+
+var (
+	noAIPattern      = regexp.MustCompile(`(?i)\bnoai\b`)
+	noImageAIPattern = regexp.MustCompile(`(?i)\bnoimageai\b`)
+)
+
+// Summary describes what the page opts out of.
+func (o *AIOptOut) Summary() string {
+	var what []string
+	if o.NoAI {
+		what = append(what, "AI use of its content (noai)")
+	} else if o.NoImageAI {
+		what = append(what, "AI use of its images (noimageai)")
+	}
+	if o.TDMReserved {
+		what = append(what, "text and data mining (TDM reservation)")
+	}
+	return "This page opts out of " + strings.Join(what, " and ")
+}
+
+// detectAIOptOut looks for opt-out signals in the robots meta tags, meta
+// tags addressed to a single bot (googlebot, gptbot), the X-Robots-Tag
+// header and the tdm-reservation meta tag or header. It returns nil when
+// the page does not opt out.
+func detectAIOptOut(page *webpage.PageData) *AIOptOut {
+	optOut := &AIOptOut{}
+	robots := func(source, value string) {
+		noAI, noImageAI := noAIPattern.MatchString(value), noImageAIPattern.MatchString(value)
+		if noAI || noImageAI {
+			optOut.NoAI = optOut.NoAI || noAI
+			optOut.NoImageAI = optOut.NoImageAI || noImageAI
+			optOut.Sources = append(optOut.Sources, fmt.Sprintf("%s: %s", source, value))
+		}
+	}
+
+	// Meta tags are a map; sort their names for a stable report
+	names := make([]string, 0, len(page.MetaTags))
+	for name := range page.MetaTags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, lower := page.MetaTags[name], strings.ToLower(name)
+		switch {
+		case lower == "robots" || strings.HasSuffix(lower, "bot"):
+			robots("meta "+name, value)
+		case lower == "tdm-reservation" && strings.TrimSpace(value) == "1":
+			optOut.TDMReserved = true
+			optOut.Sources = append(optOut.Sources, fmt.Sprintf("meta %s: %s", name, value))
+		case lower == "tdm-policy" && optOut.TDMPolicy == "":
+			optOut.TDMPolicy = strings.TrimSpace(value)
+		}
+	}
+
+	crawl := page.Crawl
+	if crawl.XRobotsTag != "" {
+		robots("X-Robots-Tag header", crawl.XRobotsTag)
+	}
+	if strings.TrimSpace(crawl.TDMReservation) == "1" {
+		optOut.TDMReserved = true
+		optOut.Sources = append(optOut.Sources, "tdm-reservation header: "+crawl.TDMReservation)
+	}
+	if crawl.TDMPolicy != "" && optOut.TDMPolicy == "" {
+		optOut.TDMPolicy = strings.TrimSpace(crawl.TDMPolicy)
+	}
+
+	if len(optOut.Sources) == 0 {
+		return nil
+	}
+	return optOut
+}
@@ -0,0 +1,36 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestDetectAIOptOut(t *testing.T) {
+	page := &webpage.PageData{MetaTags: map[string]string{"robots": "index, follow", "description": "noai"}}
+	if optOut := detectAIOptOut(page); optOut != nil {
+		t.Errorf("detectAIOptOut = %+v, want nil without opt-out signals", optOut)
+	}
+
+	page.MetaTags["robots"] = "index, follow, noimageai"
+	optOut := detectAIOptOut(page)
+	if optOut == nil || optOut.NoAI || !optOut.NoImageAI || optOut.Summary() != "This page opts out of AI use of its images (noimageai)" {
+		t.Fatalf("detectAIOptOut = %+v, want noimageai only", optOut)
+	}
+
+	page.MetaTags["GPTBot"] = "noai"
+	page.MetaTags["tdm-policy"] = "https://example.com/policy.json"
+	page.Crawl = webpage.CrawlInfo{XRobotsTag: "noarchive", TDMReservation: "1"}
+	optOut = detectAIOptOut(page)
+	want := []string{"meta GPTBot: noai", "meta robots: index, follow, noimageai", "tdm-reservation header: 1"}
+	if optOut == nil || !optOut.NoAI || !optOut.TDMReserved || optOut.TDMPolicy != "https://example.com/policy.json" || len(optOut.Sources) != len(want) {
+		t.Fatalf("detectAIOptOut = %+v", optOut)
+	}
+	for i, source := range want {
+		if optOut.Sources[i] != source {
+			t.Errorf("source %d = %q, want %q", i, optOut.Sources[i], source)
+		}
+	}
+	if got := optOut.Summary(); got != "This page opts out of AI use of its content (noai) and text and data mining (TDM reservation)" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
@@ -0,0 +1,129 @@
+package scorer
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// paywallDeduction is what a paywall or login wall costs the Accessibility
+// pillar: AI crawlers only read the part of the page shown without an
+// account.
+const paywallDeduction = 15
+
+// Kinds of wall in front of the content.
+const (
+	WallPaywall = "paywall"
+	WallLogin   = "login"
+)
+
+// Paywall records the signs that the full content sits behind a paywall
+// or a login wall. The content scores then measure the preview, not the
+// article.
+type Paywall struct {
+	Kind     string     `json:"kind"`
+	Marked   bool       `json:"marked"` // declared with isAccessibleForFree markup
+	Signals  []string   `json:"signals"`
+	Evidence []Evidence `json:"evidence,omitempty"`
+	Words    int        `json:"words"` // words readable without an account
+}
+
+var (
+	// paywallPattern matches the notices that cut an article short.
+	paywallPattern = regexp.MustCompile(`(?i)\b(to (continue|keep) reading|to read (the|this) (full|whole|rest|entire|article|story)|read the (full|rest of the) (article|story)|for (full|unlimited|unrestricted) access|(this|the) (article|story|content|post) is (only )?(for|available to|reserved for) (paid |paying )?(subscribers|members)|subscribers[ -]only|members[ -]only|already (a subscriber|a member|have an account)|reached your (free )?(article|story) limit|free articles? (left|remaining)|unlock (this|the full) (article|story))\b`)
+
+	// loginPattern matches notices asking for an account rather than a
+	// subscription.
+	loginPattern = regexp.MustCompile(`(?i)\b(log ?in|sign ?in|sign up|register|create (a|an|your) (free )?account)\b`)
+
+	// loginPathPattern matches the paths of login and subscription pages
+	// readers are redirected to.
+	loginPathPattern = regexp.MustCompile(`(?i)/(login|log-in|signin|sign-in|sign_in|auth|sso|account/login|register|subscribe|subscription|paywall)(/|$|\.)`)
+)
+
+// accessibleForFree returns the isAccessibleForFree value of a JSON-LD
+// object or one of its parts, and whether one is declared.
+func accessibleForFree(obj map[string]any) (free, declared bool) {
+	switch v := obj["isAccessibleForFree"].(type) {
+	case bool:
+		return v, true
+	case string:
+		return !strings.EqualFold(strings.TrimSpace(v), "false"), true
+	}
+	for _, part := range schemaObjects(obj["hasPart"]) {
+		if free, declared := accessibleForFree(part); declared {
+			return free, declared
+		}
+	}
+	return true, false
+}
+
+// detectPaywall looks for isAccessibleForFree: false markup, notices such
+// as "Subscribe to continue reading" and redirects to a login or
+// subscription page. It returns nil when the content is freely readable.
+func detectPaywall(doc *document) *Paywall {
+	wall := &Paywall{Kind: WallPaywall}
+	login := false
+
+	for _, obj := range doc.page.StructuredData {
+		if free, declared := accessibleForFree(obj); declared && !free {
+			wall.Marked = true
+			wall.Signals = append(wall.Signals, fmt.Sprintf("%s markup declares isAccessibleForFree: false", schemaText(obj["@type"])))
+			break
+		}
+	}
+
+	for _, p := range doc.paragraphs() {
+		text := doc.content[p.start:p.end]
+		if paywallPattern.MatchString(text) {
+			wall.Signals = append(wall.Signals, fmt.Sprintf("notice: %q", strings.Join(strings.Fields(text), " ")))
+			if len(wall.Evidence) < maxEvidence {
+				wall.Evidence = append(wall.Evidence, doc.evidence(p))
+			}
+			login = login || (loginPattern.MatchString(text) && !strings.Contains(strings.ToLower(text), "subscri"))
+		}
+	}
+
+	if crawl := doc.page.Crawl; len(crawl.Redirects) > 0 && crawl.FinalURL != "" {
+		if u, err := url.Parse(crawl.FinalURL); err == nil && loginPathPattern.MatchString(u.Path) {
+			wall.Signals = append(wall.Signals, fmt.Sprintf("redirected to %s", crawl.FinalURL))
+			path := strings.ToLower(u.Path)
+			login = login || !(strings.Contains(path, "subscri") || strings.Contains(path, "paywall"))
+		}
+	}
+
+	if len(wall.Signals) == 0 {
+		return nil
+	}
+	if login && !wall.Marked {
+		wall.Kind = WallLogin
+	}
+	wall.Words = countWords(doc.content)
+	return wall
+}
+
+// paywallIssue describes the wall and what to do about it: serve AI
+// crawlers enough of the content to be cited, and declare the paywall in
+// markup so the preview is not mistaken for cloaking.
+func paywallIssue(wall *Paywall) string {
+	issue := fmt.Sprintf("Content is behind a %s: AI crawlers can read only %d words of it", wallName(wall.Kind), wall.Words)
+	if !wall.Marked && wall.Kind == WallPaywall {
+		return issue + "; declare the paywall with isAccessibleForFree and hasPart markup"
+	}
+	return issue + "; leave a summary of the key points outside the wall"
+}
+
+// wallName returns how reports name a kind of wall.
+func wallName(kind string) string {
+	if kind == WallLogin {
+		return "login wall"
+	}
+	return "paywall"
+}
+
+// Narrative explains what the wall means for the scores, so that a low
+// score is not read as weak content.
+func (w *Paywall) Narrative() string {
+	return fmt.Sprintf("The full content sits behind a %s. Only %d words are readable without an account, so the content scores measure that preview: low scores may reflect inaccessible content rather than weak content.", wallName(w.Kind), w.Words)
+}
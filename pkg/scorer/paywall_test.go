@@ -0,0 +1,58 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestDetectPaywall(t *testing.T) {
+	content := "Rates are rising\n\nThe central bank raised rates again on Tuesday, the third rise this year.\n\nSubscribe to continue reading. Already a subscriber? Log in."
+	page := &webpage.PageData{Content: content}
+	wall := detectPaywall(newDocument(content, page))
+	if wall == nil || wall.Kind != WallPaywall || wall.Marked || len(wall.Evidence) != 1 || !strings.HasPrefix(wall.Evidence[0].Snippet, "Subscribe to continue reading") {
+		t.Fatalf("detectPaywall = %+v, want an unmarked paywall quoting the notice", wall)
+	}
+	if issue := paywallIssue(wall); !strings.Contains(issue, "isAccessibleForFree") {
+		t.Errorf("paywallIssue = %q, want the markup advice", issue)
+	}
+
+	page.StructuredData = []map[string]any{{
+		"@type":   "NewsArticle",
+		"hasPart": map[string]any{"@type": "WebPageElement", "isAccessibleForFree": "False", "cssSelector": ".paywall"},
+	}}
+	if wall := detectPaywall(newDocument(content, page)); wall == nil || !wall.Marked || wall.Signals[0] != "NewsArticle markup declares isAccessibleForFree: false" {
+		t.Errorf("detectPaywall = %+v, want the markup signal", wall)
+	}
+
+	// A login redirect is a login wall
+	free := "Rates are rising\n\nThe central bank raised rates again on Tuesday."
+	page = &webpage.PageData{Content: free, Crawl: webpage.CrawlInfo{
+		FinalURL:  "https://example.com/account/login?return=/rates",
+		Redirects: []webpage.Redirect{{From: "https://example.com/rates", To: "https://example.com/account/login?return=/rates", Status: 302}},
+	}}
+	if wall := detectPaywall(newDocument(free, page)); wall == nil || wall.Kind != WallLogin {
+		t.Errorf("detectPaywall = %+v, want a login wall", wall)
+	}
+
+	page.Crawl = webpage.CrawlInfo{}
+	if wall := detectPaywall(newDocument(free, page)); wall != nil {
+		t.Errorf("detectPaywall = %+v on a free page", wall)
+	}
+}
+
+func TestPaywallDeduction(t *testing.T) {
+	content := "Rates are rising\n\nThe central bank raised rates again on Tuesday, the third rise this year.\n\nThis article is for subscribers only."
+	page := &webpage.PageData{Content: content}
+	ls := NewLocalScorer()
+	walled := ls.AnalyzeContent(content, page)
+	if walled.Paywall == nil || !hasFinding(walled, "accessibility.paywall") {
+		t.Fatalf("paywall %+v, findings %+v, want accessibility.paywall", walled.Paywall, walled.Findings)
+	}
+
+	free := strings.TrimSuffix(content, "\n\nThis article is for subscribers only.")
+	open := ls.AnalyzeContent(free, &webpage.PageData{Content: free})
+	if open.Paywall != nil || walled.Breakdown.Accessibility.Score >= open.Breakdown.Accessibility.Score {
+		t.Errorf("accessibility %d behind the paywall, %d without, want a deduction", walled.Breakdown.Accessibility.Score, open.Breakdown.Accessibility.Score)
+	}
+}
@@ -0,0 +1,56 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+)
+
+// previewDeduction is what a missing, broken, placeholder or undersized
+// og:image costs the Accessibility pillar: assistants and social platforms
+// present links to the page with it.
+const previewDeduction = 5
+
+const (
+	// minPreviewWidth and minPreviewHeight are the smallest preview image
+	// platforms show at all.
+	minPreviewWidth  = 200
+	minPreviewHeight = 200
+
+	// recommendedPreviewWidth and recommendedPreviewHeight fill large link
+	// cards (1.91:1).
+	recommendedPreviewWidth  = 1200
+	recommendedPreviewHeight = 630
+)
+
+// evaluatePreviewImage checks the page's og:image: that it is declared,
+// that it was served as an image (when it was fetched), that it is not a
+// placeholder, that it is at least minPreviewWidth by minPreviewHeight and
+// that og:image:width and og:image:height, when declared, are its actual
+// size. It returns the issue to report, if any, and the problems as
+// evidence.
+func evaluatePreviewImage(page *webpage.PageData) (string, []Evidence) {
+	img := page.PreviewImage
+	if img == nil {
+		if page.HeroMedia != "" {
+			return "Declare an og:image so assistants can show a preview of the page, e.g. its hero image", []Evidence{missingEvidence("no og:image; hero media: %s", page.HeroMedia)}
+		}
+		return fmt.Sprintf("Add a hero image and declare it as the og:image (%dx%d) so assistants can show a preview of the page", recommendedPreviewWidth, recommendedPreviewHeight), []Evidence{missingEvidence("no og:image and no image or video before the first section")}
+	}
+
+	var evidence []Evidence
+	switch {
+	case img.Error != "":
+		evidence = append(evidence, missingEvidence("og:image %s: %s", img.URL, img.Error))
+	case img.Placeholder:
+		evidence = append(evidence, missingEvidence("og:image %s is a placeholder", img.URL))
+	case img.Fetched && (img.Width < minPreviewWidth || img.Height < minPreviewHeight):
+		evidence = append(evidence, missingEvidence("og:image is %dx%d, smaller than the %dx%d minimum", img.Width, img.Height, minPreviewWidth, minPreviewHeight))
+	}
+	if img.Fetched && img.Width > 0 && ((img.DeclaredWidth > 0 && img.DeclaredWidth != img.Width) || (img.DeclaredHeight > 0 && img.DeclaredHeight != img.Height)) {
+		evidence = append(evidence, missingEvidence("og:image:width and og:image:height declare %dx%d, the image is %dx%d", img.DeclaredWidth, img.DeclaredHeight, img.Width, img.Height))
+	}
+	if len(evidence) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("Fix the og:image: it must load as an image of at least %dx%d (%dx%d recommended) that shows the page's content", minPreviewWidth, minPreviewHeight, recommendedPreviewWidth, recommendedPreviewHeight), evidence
+}
@@ -0,0 +1,35 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestEvaluatePreviewImage(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		page *webpage.PageData
+		want string // evidence, or "" for no issue
+	}{
+		{"valid", &webpage.PageData{PreviewImage: &webpage.PreviewImage{URL: "https://example.com/cover.png", Fetched: true, Width: 1200, Height: 630, DeclaredWidth: 1200}}, ""},
+		{"declared only", &webpage.PageData{PreviewImage: &webpage.PreviewImage{URL: "https://example.com/cover.png"}}, ""},
+		{"missing with hero", &webpage.PageData{HeroMedia: "https://example.com/hero.jpg"}, "hero media: https://example.com/hero.jpg"},
+		{"missing", &webpage.PageData{}, "no og:image and no image"},
+		{"broken", &webpage.PageData{PreviewImage: &webpage.PreviewImage{URL: "https://example.com/gone.png", Fetched: true, Status: 404, Error: "HTTP error: 404"}}, "HTTP error: 404"},
+		{"placeholder", &webpage.PageData{PreviewImage: &webpage.PreviewImage{URL: "https://example.com/default-og.png", Placeholder: true}}, "is a placeholder"},
+		{"small", &webpage.PageData{PreviewImage: &webpage.PreviewImage{URL: "https://example.com/logo.png", Fetched: true, Width: 150, Height: 150}}, "150x150, smaller"},
+		{"wrong dimensions", &webpage.PageData{PreviewImage: &webpage.PreviewImage{URL: "https://example.com/cover.png", Fetched: true, Width: 800, Height: 420, DeclaredWidth: 1200, DeclaredHeight: 630}}, "declare 1200x630, the image is 800x420"},
+	} {
+		issue, evidence := evaluatePreviewImage(tc.page)
+		if tc.want == "" {
+			if issue != "" {
+				t.Errorf("%s: issue %q, want none", tc.name, issue)
+			}
+			continue
+		}
+		if issue == "" || len(evidence) == 0 || !strings.Contains(evidence[0].Snippet, tc.want) {
+			t.Errorf("%s: issue %q, evidence %+v, want %q", tc.name, issue, evidence, tc.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"strings"
+)
+
+// productIdentifiers are the schema.org properties that pin a product down
+// beyond its name.
+var productIdentifiers = []string{"sku", "mpn", "gtin", "gtin8", "gtin12", "gtin13", "gtin14"}
+
+// product returns the page's Product markup, or nil.
+func product(pageData *webpage.PageData) map[string]any {
+	if products := pageData.SchemaObjects("Product"); len(products) > 0 {
+		return products[0]
+	}
+	return nil
+}
+
+// schemaObjects returns v as a list of JSON-LD objects; properties may hold
+// one object or several.
+func schemaObjects(v any) []map[string]any {
+	switch v := v.(type) {
+	case map[string]any:
+		return []map[string]any{v}
+	case []any:
+		var objects []map[string]any
+		for _, item := range v {
+			if obj, ok := item.(map[string]any); ok {
+				objects = append(objects, obj)
+			}
+		}
+		return objects
+	}
+	return nil
+}
+
+// schemaText returns a JSON-LD value as text: strings and numbers as they
+// are, objects by their name.
+func schemaText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return fmt.Sprint(v)
+	case map[string]any:
+		return schemaText(v["name"])
+	}
+	return ""
+}
+
+func missingEvidence(format string, args ...any) Evidence {
+	return Evidence{Snippet: fmt.Sprintf(format, args...), Start: -1, End: -1}
+}
+
+// evaluateProductOffer scores the price and offer markup (25 points): a
+// Product (5) with an offer carrying a price (10), its currency (5) and
+// availability (5). It returns the issue to report, if any, and what is
+// missing as evidence.
+func evaluateProductOffer(pageData *webpage.PageData) (int, string, []Evidence) {
+	p := product(pageData)
+	if p == nil {
+		return 0, "Add Product markup with an Offer giving price, currency and availability", nil
+	}
+
+	offers := schemaObjects(p["offers"])
+	if len(offers) == 0 {
+		return 5, "Add an Offer to the Product markup with price, currency and availability", []Evidence{missingEvidence("Product markup has no offers")}
+	}
+
+	score := 5
+	offer := offers[0]
+	var evidence []Evidence
+	if schemaText(offer["price"]) != "" || schemaText(offer["lowPrice"]) != "" {
+		score += 10
+	} else {
+		evidence = append(evidence, missingEvidence("offer has no price"))
+	}
+	if schemaText(offer["priceCurrency"]) != "" {
+		score += 5
+	} else {
+		evidence = append(evidence, missingEvidence("offer has no priceCurrency"))
+	}
+	if schemaText(offer["availability"]) != "" {
+		score += 5
+	} else {
+		evidence = append(evidence, missingEvidence("offer has no availability"))
+	}
+	if len(evidence) > 0 {
+		return score, "Complete the Offer markup with price, currency and availability", evidence
+	}
+	return score, "", nil
+}
+
+// evaluateProductReviews scores review markup (40 points): an
+// AggregateRating with a rating value and count (25) and individual
+// Reviews (15), on the Product or as top-level objects.
+func evaluateProductReviews(pageData *webpage.PageData) int {
+	ratings := pageData.SchemaObjects("AggregateRating")
+	reviews := pageData.SchemaObjects("Review")
+	if p := product(pageData); p != nil {
+		ratings = append(ratings, schemaObjects(p["aggregateRating"])...)
+		reviews = append(reviews, schemaObjects(p["review"])...)
+	}
+
+	score := 0
+	for _, rating := range ratings {
+		if schemaText(rating["ratingValue"]) != "" && (schemaText(rating["reviewCount"]) != "" || schemaText(rating["ratingCount"]) != "") {
+			score += 25
+			break
+		}
+	}
+	if len(reviews) > 0 {
+		score += 15
+	}
+	return score
+}
+
+// evaluateSpecTables scores specifications presented as a table or
+// definition list (20 points): a two-column table or a table headed as
+// specifications with at least three rows, or three definitions.
+func evaluateSpecTables(pageData *webpage.PageData) int {
+	for _, t := range pageData.Tables {
+		if t.Rows < 3 {
+			continue
+		}
+		if t.Columns == 2 || containsWord([]string{"spec", "specification", "feature", "attribute", "detail"}, strings.ToLower(strings.Join(t.Headers, " "))) {
+			return 20
+		}
+	}
+	if len(pageData.Definitions) >= 3 {
+		return 20
+	}
+	return 5
+}
+
+// evaluateProductNaming scores how unambiguously the product is named (30
+// points): a name in the Product markup (10), an H1 that matches it (10)
+// and a brand with a SKU, MPN or GTIN (10). It returns the issue to
+// report, if any, and the mismatches as evidence.
+func evaluateProductNaming(pageData *webpage.PageData) (int, string, []Evidence) {
+	const issue = "Name the product unambiguously: the same full name in the H1 and Product markup, with brand and SKU, MPN or GTIN"
+
+	h1 := ""
+	for _, h := range pageData.Headings {
+		if h.Level == 1 {
+			h1 = h.Text
+			break
+		}
+	}
+
+	p := product(pageData)
+	name := ""
+	if p != nil {
+		name = schemaText(p["name"])
+	}
+	if name == "" {
+		score := 0
+		if h1 != "" {
+			score = 10
+		}
+		return score, issue, []Evidence{missingEvidence("Product markup has no name")}
+	}
+
+	score := 10
+	var evidence []Evidence
+	lowerH1, lowerName := strings.ToLower(h1), strings.ToLower(name)
+	switch {
+	case h1 == "":
+		evidence = append(evidence, missingEvidence("no H1 heading"))
+	case strings.Contains(lowerH1, lowerName) || strings.Contains(lowerName, lowerH1):
+		score += 10
+	default:
+		evidence = append(evidence, missingEvidence("H1 %q differs from the product name %q", h1, name))
+	}
+
+	identified := false
+	for _, prop := range productIdentifiers {
+		identified = identified || schemaText(p[prop]) != ""
+	}
+	switch {
+	case schemaText(p["brand"]) == "":
+		evidence = append(evidence, missingEvidence("Product markup has no brand"))
+	case !identified:
+		evidence = append(evidence, missingEvidence("Product markup has no SKU, MPN or GTIN"))
+	default:
+		score += 10
+	}
+
+	if len(evidence) > 0 {
+		return score, issue, evidence
+	}
+	return score, "", nil
+}
@@ -0,0 +1,61 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestProductProfile(t *testing.T) {
+	content := "Acme Trail Runner 2\n\nLightweight shoe for rocky trails."
+	page := &webpage.PageData{
+		Content:  content,
+		MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 1, Text: "Acme Trail Runner 2"}},
+		Tables:   []webpage.Table{{Rows: 4, Columns: 2}},
+		StructuredData: []map[string]any{{
+			"@type": "Product",
+			"name":  "Trail Runner 2",
+			"brand": map[string]any{"@type": "Brand", "name": "Acme"},
+			"sku":   "TR2-42",
+			"offers": []any{map[string]any{
+				"@type": "Offer", "price": 129.0, "priceCurrency": "EUR",
+			}},
+			"aggregateRating": map[string]any{"ratingValue": "4.6", "reviewCount": 87.0},
+		}},
+	}
+
+	ls := NewLocalScorer()
+	ls.SetProfile(ProfileProduct)
+	score := ls.AnalyzeContent(content, page)
+
+	found := map[string]Finding{}
+	for _, f := range score.Findings {
+		found[f.ID] = f
+		for _, id := range f.Merged {
+			found[id] = f
+		}
+	}
+	for _, id := range []string{"product.naming", "product.specs", "authority.citations", "clarity.definitions"} {
+		if _, ok := found[id]; ok {
+			t.Errorf("unexpected finding %s", id)
+		}
+	}
+	offer, ok := found["product.offer"]
+	if !ok || len(offer.Evidence) != 1 || offer.Evidence[0].Snippet != "offer has no availability" {
+		t.Errorf("offer finding = %+v", offer)
+	}
+	if got := score.Breakdown.AuthoritySignals.Findings; len(got) > 0 && got[0].ID == "product.reviews" {
+		t.Errorf("rating without reviews should pass: %+v", got[0])
+	}
+}
+
+func TestEvaluateProductNaming(t *testing.T) {
+	page := &webpage.PageData{
+		Headings:       []webpage.Heading{{Level: 1, Text: "Our best seller"}},
+		StructuredData: []map[string]any{{"@type": "Product", "name": "Trail Runner 2"}},
+	}
+	score, issue, evidence := evaluateProductNaming(page)
+	if score != 10 || issue == "" || len(evidence) != 2 {
+		t.Errorf("naming = %d, %q, %+v; want 10 with H1 and brand evidence", score, issue, evidence)
+	}
+}
@@ -0,0 +1,67 @@
+package scorer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Profile tunes the local scorer to a kind of page. The general profile
+// applies the article heuristics; the others swap rules that make no sense
+// for their pages for ones that do.
+type Profile string
+
+const (
+	ProfileGeneral Profile = "general"
+	ProfileDocs    Profile = "docs"    // developer documentation
+	ProfileProduct Profile = "product" // e-commerce product pages
+	ProfileNews    Profile = "news"    // news articles
+
+	// ProfileLocalBusiness is for location and local-service pages
+	ProfileLocalBusiness Profile = "local-business"
+
+	// ProfileCategory is for category, hub and landing pages, whose value
+	// is in the listing they curate rather than in prose
+	ProfileCategory Profile = "category"
+
+	// ProfileAuto asks callers to classify each page and score it with the
+	// profile of its type (see ClassifyPage); a scorer set to it applies
+	// the general rules
+	ProfileAuto Profile = "auto"
+)
+
+// Profiles lists the supported profiles.
+var Profiles = []Profile{ProfileGeneral, ProfileDocs, ProfileProduct, ProfileNews, ProfileLocalBusiness, ProfileCategory, ProfileAuto}
+
+// ParseProfile returns the profile called name. An empty name selects the
+// general profile.
+func ParseProfile(name string) (Profile, error) {
+	if name == "" {
+		return ProfileGeneral, nil
+	}
+	for _, p := range Profiles {
+		if strings.EqualFold(name, string(p)) {
+			return p, nil
+		}
+	}
+	names := make([]string, len(Profiles))
+	for i, p := range Profiles {
+		names[i] = string(p)
+	}
+	return "", fmt.Errorf("unknown profile %q (want %s)", name, strings.Join(names, ", "))
+}
+
+// SetProfile switches the scorer to profile p and its pillar weights,
+// keeping the weights a framework overrides.
+func (ls *LocalScorer) SetProfile(p Profile) {
+	ls.profile = p
+	ls.weights = defaultWeights
+	if p == ProfileNews {
+		ls.weights = newsWeights
+	}
+	ls.applyFramework()
+}
+
+// Profile returns the profile the scorer applies.
+func (ls *LocalScorer) Profile() Profile {
+	return ls.profile
+}
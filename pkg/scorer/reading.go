@@ -0,0 +1,93 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// readingLevels are the named reading-level targets, as U.S. school grades.
+var readingLevels = map[string]float64{
+	"elementary": 5,
+	"general":    8,
+	"college":    13,
+	"expert":     16,
+}
+
+// profileReadingLevels are the targets of profiles whose audience differs
+// from the general one.
+var profileReadingLevels = map[Profile]float64{
+	ProfileDocs: 12,
+}
+
+// ParseReadingLevel returns the grade of a reading-level target: a name
+// ("elementary", "general", "college", "expert"), a grade ("grade-8") or a
+// bare number. An empty target returns 0, the profile's default.
+func ParseReadingLevel(target string) (float64, error) {
+	target = strings.ToLower(strings.TrimSpace(target))
+	if target == "" {
+		return 0, nil
+	}
+	if grade, ok := readingLevels[target]; ok {
+		return grade, nil
+	}
+	grade, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimPrefix(target, "grade"), "-"), 64)
+	if err != nil || grade < 1 || grade > 20 {
+		return 0, fmt.Errorf("unknown reading level %q (want elementary, general, college, expert or grade-1 to grade-20)", target)
+	}
+	return grade, nil
+}
+
+// SetReadingLevel sets the reading grade readability is scored against;
+// 0 restores the profile's default.
+func (ls *LocalScorer) SetReadingLevel(grade float64) {
+	ls.readingLevel = grade
+}
+
+// ReadingLevel returns the reading grade readability is scored against.
+func (ls *LocalScorer) ReadingLevel() float64 {
+	if ls.readingLevel > 0 {
+		return ls.readingLevel
+	}
+	if grade, ok := profileReadingLevels[ls.profile]; ok {
+		return grade
+	}
+	return readingLevels["general"]
+}
+
+// readingGrade estimates the U.S. school grade needed to read the prose
+// of doc with the Flesch-Kincaid grade formula.
+func (ls *LocalScorer) readingGrade(doc *document) float64 {
+	var words []string
+	for _, s := range doc.proseSentences() {
+		words = append(words, splitWords(doc.content[s.start:s.end])...)
+	}
+	if len(words) == 0 {
+		return 0
+	}
+	grade := 0.39*ls.calculateAvgWordsPerSentence(doc) + 11.8*ls.calculateAvgSyllablesPerWord(words) - 15.59
+	return math.Max(grade, 0)
+}
+
+// evaluateReadability scores readability against the target grade (40
+// points): full points within 2 grades of it, then 5 points less per grade
+// harder to read and 2.5 per grade easier, down to 10. It returns the
+// issue to report, if any, and the page's grade.
+func (ls *LocalScorer) evaluateReadability(doc *document) (int, string, float64) {
+	if countWords(doc.content) == 0 {
+		return 0, "Add readable prose", 0
+	}
+
+	grade, target := ls.readingGrade(doc), ls.ReadingLevel()
+	distance := math.Abs(grade-target) - 2
+	if distance <= 0 {
+		return 40, "", grade
+	}
+	if grade > target {
+		score := max(40-int(math.Round(5*distance)), 10)
+		return score, fmt.Sprintf("Simplify sentence structure: the text reads at grade %.0f, above the grade %.0f target", grade, target), grade
+	}
+	score := max(40-int(math.Round(2.5*distance)), 10)
+	return score, fmt.Sprintf("The text reads at grade %.0f, below the grade %.0f target: use the precise terms your audience expects", grade, target), grade
+}
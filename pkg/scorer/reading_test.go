@@ -0,0 +1,43 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestParseReadingLevel(t *testing.T) {
+	for in, want := range map[string]float64{"": 0, "general": 8, "Expert": 16, "grade-6": 6, "10": 10} {
+		if got, err := ParseReadingLevel(in); err != nil || got != want {
+			t.Errorf("ParseReadingLevel(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"grade-0", "toddler"} {
+		if _, err := ParseReadingLevel(in); err == nil {
+			t.Errorf("ParseReadingLevel(%q) succeeded", in)
+		}
+	}
+}
+
+func TestReadabilityTarget(t *testing.T) {
+	content := strings.Repeat("The replication protocol provides eventual consistency across distributed regions when every operation is idempotent and retried by the client library. ", 5)
+	technical := newDocument(content, &webpage.PageData{Content: content})
+
+	ls := NewLocalScorer()
+	general, issue, grade := ls.evaluateReadability(technical)
+	if grade < 12 || general >= 30 || !strings.Contains(issue, "above the grade 8 target") {
+		t.Errorf("general audience: score %d, grade %.1f, issue %q", general, grade, issue)
+	}
+
+	ls.SetReadingLevel(20)
+	if specialist, issue, _ := ls.evaluateReadability(technical); specialist != 40 || issue != "" {
+		t.Errorf("grade-20 audience: score %d, issue %q", specialist, issue)
+	}
+
+	// Docs default to a higher target
+	ls = NewLocalScorer()
+	ls.SetProfile(ProfileDocs)
+	if ls.ReadingLevel() != 12 {
+		t.Errorf("docs reading level = %v, want 12", ls.ReadingLevel())
+	}
+}
@@ -0,0 +1,350 @@
+package scorer
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxHeadlineLength is the longest headline Google shows in article rich
+// results.
+const maxHeadlineLength = 110
+
+// SchemaCheck is the validation of one schema.org object against the
+// properties Google and schema.org require and recommend for its type.
+type SchemaCheck struct {
+	Type        string   `json:"type"`
+	Missing     []string `json:"missing,omitempty"`     // required properties
+	Recommended []string `json:"recommended,omitempty"` // recommended properties that are missing
+	Invalid     []string `json:"invalid,omitempty"`     // property: what is wrong with its value
+	Mismatched  []string `json:"mismatched,omitempty"`  // property: how it differs from the page
+}
+
+// Valid reports whether the markup has every required property with a
+// valid value that matches the page.
+func (c SchemaCheck) Valid() bool {
+	return len(c.Missing) == 0 && len(c.Invalid) == 0 && len(c.Mismatched) == 0
+}
+
+// schemaRule lists the properties of a type. Alternatives are separated
+// by "|": any one of them satisfies the requirement.
+type schemaRule struct {
+	required    []string
+	recommended []string
+}
+
+// schemaRules follow Google's structured data documentation for the
+// types that earn rich results and that AI answers draw on.
+var schemaRules = map[string]schemaRule{
+	"Article":        {required: []string{"headline", "datePublished", "author"}, recommended: []string{"image", "dateModified", "publisher"}},
+	"NewsArticle":    {required: []string{"headline", "datePublished", "author"}, recommended: []string{"image", "dateModified", "publisher"}},
+	"BlogPosting":    {required: []string{"headline", "datePublished", "author"}, recommended: []string{"image", "dateModified", "publisher"}},
+	"TechArticle":    {required: []string{"headline", "datePublished", "author"}, recommended: []string{"image", "dateModified", "publisher"}},
+	"FAQPage":        {required: []string{"mainEntity"}},
+	"HowTo":          {required: []string{"name", "step"}, recommended: []string{"image", "totalTime", "supply", "tool"}},
+	"Product":        {required: []string{"name", "offers|review|aggregateRating"}, recommended: []string{"image", "description", "brand", "sku|mpn|gtin|gtin8|gtin12|gtin13|gtin14"}},
+	"Recipe":         {required: []string{"name", "image"}, recommended: []string{"author", "datePublished", "recipeIngredient", "recipeInstructions", "totalTime"}},
+	"Event":          {required: []string{"name", "startDate", "location"}, recommended: []string{"endDate", "eventStatus", "image", "offers", "organizer", "description"}},
+	"Organization":   {required: []string{"name"}, recommended: []string{"url", "logo", "sameAs"}},
+	"LocalBusiness":  {required: []string{"name", "address"}, recommended: []string{"telephone", "openingHoursSpecification|openingHours", "geo", "url"}},
+	"BreadcrumbList": {required: []string{"itemListElement"}},
+	"VideoObject":    {required: []string{"name", "thumbnailUrl", "uploadDate"}, recommended: []string{"description", "duration", "contentUrl|embedUrl"}},
+	"Review":         {required: []string{"itemReviewed", "reviewRating", "author"}},
+}
+
+var (
+	// schemaDateProperties hold ISO 8601 dates.
+	schemaDateProperties = []string{"datePublished", "dateModified", "uploadDate", "startDate", "endDate"}
+
+	// schemaURLProperties hold absolute URLs (or, for images, objects).
+	schemaURLProperties = []string{"url", "image", "logo", "thumbnailUrl", "contentUrl", "embedUrl"}
+
+	// currencyPattern matches ISO 4217 currency codes.
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// schemaRuleType returns the type of obj validation rules exist for, with
+// LocalBusiness subtypes validated as LocalBusiness, or "".
+func schemaRuleType(obj map[string]any) string {
+	for _, t := range schemaTypeNames(obj) {
+		if _, ok := schemaRules[t]; ok {
+			return t
+		}
+		for _, business := range localBusinessTypes {
+			if t == business {
+				return "LocalBusiness"
+			}
+		}
+	}
+	return ""
+}
+
+// validateSchema checks every top-level schema.org object of a known type on
+// the page.
+func validateSchema(doc *document) []SchemaCheck {
+	var checks []SchemaCheck
+	for _, obj := range doc.page.StructuredData {
+		typ := schemaRuleType(obj)
+		if typ == "" {
+			continue
+		}
+		check := SchemaCheck{Type: typ}
+		rule := schemaRules[typ]
+		for _, prop := range rule.required {
+			if !hasSchemaProperty(obj, prop) {
+				check.Missing = append(check.Missing, prop)
+			}
+		}
+		for _, prop := range rule.recommended {
+			if !hasSchemaProperty(obj, prop) {
+				check.Recommended = append(check.Recommended, prop)
+			}
+		}
+		check.Invalid = invalidSchemaValues(typ, obj)
+		check.Mismatched = mismatchedSchemaValues(doc, typ, obj)
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// hasSchemaProperty reports whether obj has a non-empty value for prop or
+// one of its "|"-separated alternatives.
+func hasSchemaProperty(obj map[string]any, prop string) bool {
+	for _, alt := range strings.Split(prop, "|") {
+		switch v := obj[alt].(type) {
+		case nil:
+		case string:
+			if strings.TrimSpace(v) != "" {
+				return true
+			}
+		case []any:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// isNumber reports whether v is a number or a string holding one.
+func isNumber(v any) bool {
+	_, ok := schemaNumber(v)
+	return ok
+}
+
+// parseSchemaDate parses the ISO 8601 forms schema.org dates take.
+func parseSchemaDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02T15:04Z07:00", "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isAbsoluteURL reports whether value is an absolute http(s) URL.
+func isAbsoluteURL(value string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// invalidSchemaValues returns the properties of obj whose values are
+// malformed, each with what is wrong.
+func invalidSchemaValues(typ string, obj map[string]any) []string {
+	var invalid []string
+	for _, prop := range schemaDateProperties {
+		if value, ok := obj[prop].(string); ok {
+			if _, ok := parseSchemaDate(value); !ok {
+				invalid = append(invalid, fmt.Sprintf("%s: %q is not an ISO 8601 date", prop, value))
+			}
+		}
+	}
+	for _, prop := range schemaURLProperties {
+		var values []string
+		switch v := obj[prop].(type) {
+		case string:
+			values = []string{v}
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					values = append(values, s)
+				}
+			}
+		}
+		for _, value := range values {
+			if !isAbsoluteURL(value) {
+				invalid = append(invalid, fmt.Sprintf("%s: %q is not an absolute URL", prop, value))
+				break
+			}
+		}
+	}
+	if headline := schemaText(obj["headline"]); len([]rune(headline)) > maxHeadlineLength {
+		invalid = append(invalid, fmt.Sprintf("headline: %d characters, longer than the %d shown", len([]rune(headline)), maxHeadlineLength))
+	}
+	for _, offer := range schemaObjects(obj["offers"]) {
+		if price, ok := offer["price"]; ok && !isNumber(price) {
+			invalid = append(invalid, fmt.Sprintf("offers.price: %q is not a number", schemaText(price)))
+		}
+		if currency := schemaText(offer["priceCurrency"]); currency != "" && !currencyPattern.MatchString(currency) {
+			invalid = append(invalid, fmt.Sprintf("offers.priceCurrency: %q is not an ISO 4217 code", currency))
+		}
+	}
+	for _, rating := range append(schemaObjects(obj["aggregateRating"]), schemaObjects(obj["reviewRating"])...) {
+		if value, ok := rating["ratingValue"]; ok && !isNumber(value) {
+			invalid = append(invalid, fmt.Sprintf("ratingValue: %q is not a number", schemaText(value)))
+		}
+	}
+	if typ == "FAQPage" {
+		for i, question := range schemaObjects(obj["mainEntity"]) {
+			answers := schemaObjects(question["acceptedAnswer"])
+			if schemaText(question["name"]) == "" || len(answers) == 0 || schemaText(answers[0]["text"]) == "" {
+				invalid = append(invalid, fmt.Sprintf("mainEntity[%d]: a Question needs a name and an acceptedAnswer with text", i))
+			}
+		}
+	}
+	return invalid
+}
+
+// mismatchedSchemaValues returns the properties of obj whose values the
+// visible page contradicts: a headline or name unlike the H1 and title,
+// FAQ questions that are not on the page, and authors, dates, ratings and
+// prices other than those the page shows.
+func mismatchedSchemaValues(doc *document, typ string, obj map[string]any) []string {
+	return append(nameMismatches(doc, typ, obj), doc.visibleMismatches(obj)...)
+}
+
+// nameMismatches checks the headline or name of obj against the H1 and
+// title, and the questions of an FAQPage against the content.
+func nameMismatches(doc *document, typ string, obj map[string]any) []string {
+	var mismatched []string
+	nameProp := "name"
+	switch typ {
+	case "Article", "NewsArticle", "BlogPosting", "TechArticle":
+		nameProp = "headline"
+	case "FAQPage":
+		for _, question := range schemaObjects(obj["mainEntity"]) {
+			if name := schemaText(question["name"]); name != "" && !doc.shows(name) {
+				mismatched = append(mismatched, fmt.Sprintf("mainEntity: question %q is not on the page", name))
+			}
+		}
+		return mismatched
+	case "HowTo", "Product", "Recipe", "Event":
+	default:
+		return nil
+	}
+
+	name := schemaText(obj[nameProp])
+	if name == "" {
+		return nil
+	}
+	titles := []string{doc.page.Title}
+	for _, h := range doc.page.Headings {
+		if h.Level == 1 {
+			titles = append(titles, h.Text)
+		}
+	}
+	for _, title := range titles {
+		if sameTitle(name, title) {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("%s: %q matches neither the H1 nor the title", nameProp, name)}
+}
+
+// schemaWords lowercases text and reduces it to its words.
+func schemaWords(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	}), " ")
+}
+
+// sameTitle reports whether two titles name the same thing: one contains
+// the other once normalized, as titles often append the site name.
+func sameTitle(a, b string) bool {
+	a, b = schemaWords(a), schemaWords(b)
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+// shows reports whether the page's text contains text, ignoring case and
+// punctuation.
+func (d *document) shows(text string) bool {
+	text = schemaWords(text)
+	return text != "" && strings.Contains(schemaWords(d.content), text)
+}
+
+// schemaIssue describes the markup that failed validation, or "" when all
+// of it is valid. For types the profile already scores with its own rule
+// only values the page contradicts count.
+func schemaIssue(checks []SchemaCheck, profile Profile) (string, []Evidence) {
+	var types []string
+	var evidence []Evidence
+	for _, c := range checks {
+		// The profile's own rule scores what is missing or invalid, not
+		// values the page contradicts
+		problems := append(append([]string{}, c.Invalid...), c.Mismatched...)
+		missing := c.Missing
+		if profileScoresSchema(profile, c.Type) {
+			problems, missing = c.Mismatched, nil
+		}
+		if len(missing) == 0 && len(problems) == 0 {
+			continue
+		}
+		types = append(types, c.Type)
+		for _, prop := range missing {
+			evidence = append(evidence, missingEvidence("%s markup has no %s", c.Type, prop))
+		}
+		for _, problem := range problems {
+			evidence = append(evidence, missingEvidence("%s markup %s", c.Type, problem))
+		}
+	}
+	if len(types) == 0 {
+		return "", nil
+	}
+	if len(evidence) > maxEvidence {
+		evidence = evidence[:maxEvidence]
+	}
+	return fmt.Sprintf("Fix the %s markup: add the missing required properties and make values valid and consistent with the page", strings.Join(types, ", ")), evidence
+}
+
+// profileScoresSchema reports whether the profile scores markup of typ
+// with a rule of its own.
+func profileScoresSchema(profile Profile, typ string) bool {
+	switch profile {
+	case ProfileNews:
+		return typ == "NewsArticle"
+	case ProfileLocalBusiness:
+		return typ == "LocalBusiness"
+	case ProfileProduct:
+		return typ == "Product"
+	}
+	return false
+}
+
+// Summary describes a check in one line, e.g. "Article: missing
+// datePublished; invalid image".
+func (c SchemaCheck) Summary() string {
+	var parts []string
+	if len(c.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(c.Missing, ", "))
+	}
+	if len(c.Invalid) > 0 {
+		parts = append(parts, "invalid "+strings.Join(c.Invalid, "; "))
+	}
+	if len(c.Mismatched) > 0 {
+		parts = append(parts, "mismatched "+strings.Join(c.Mismatched, "; "))
+	}
+	if len(c.Recommended) > 0 {
+		parts = append(parts, "recommended "+strings.Join(c.Recommended, ", "))
+	}
+	if len(parts) == 0 {
+		return c.Type + ": valid"
+	}
+	return c.Type + ": " + strings.Join(parts, "; ")
+}
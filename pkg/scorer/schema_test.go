@@ -0,0 +1,118 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	content := "Rates are rising\n\nBy Ann Lee\n\nThe central bank raised rates again on Tuesday.\n\nWhat did the bank decide?\n\nIt raised rates by a quarter point."
+	page := &webpage.PageData{
+		Title:    "Rates are rising | Example News",
+		Content:  content,
+		Headings: []webpage.Heading{{Level: 1, Text: "Rates are rising"}},
+		StructuredData: []map[string]any{
+			{
+				"@type":         "Article",
+				"headline":      "Central bank holds rates",
+				"datePublished": "Tuesday",
+				"image":         "/rates.jpg",
+			},
+			{
+				"@type": "FAQPage",
+				"mainEntity": []any{
+					map[string]any{"@type": "Question", "name": "What did the bank decide?", "acceptedAnswer": map[string]any{"text": "It raised rates."}},
+					map[string]any{"@type": "Question", "name": "Will rates fall next year?"},
+				},
+			},
+			{"@type": "WebSite", "name": "Example News"},
+		},
+	}
+	checks := validateSchema(newDocument(content, page))
+	if len(checks) != 2 {
+		t.Fatalf("validateSchema = %+v, want the Article and FAQPage", checks)
+	}
+
+	article := checks[0]
+	if article.Type != "Article" || !reflect.DeepEqual(article.Missing, []string{"author"}) || !reflect.DeepEqual(article.Recommended, []string{"dateModified", "publisher"}) {
+		t.Errorf("Article check = %+v, want author missing and dateModified, publisher recommended", article)
+	}
+	if len(article.Invalid) != 2 || !strings.HasPrefix(article.Invalid[0], "datePublished:") || !strings.HasPrefix(article.Invalid[1], "image:") {
+		t.Errorf("Article invalid = %q, want datePublished and image", article.Invalid)
+	}
+	if len(article.Mismatched) != 1 || !strings.HasPrefix(article.Mismatched[0], "headline:") {
+		t.Errorf("Article mismatched = %q, want the headline", article.Mismatched)
+	}
+
+	faq := checks[1]
+	if len(faq.Invalid) != 1 || !strings.HasPrefix(faq.Invalid[0], "mainEntity[1]") {
+		t.Errorf("FAQPage invalid = %q, want the unanswered question", faq.Invalid)
+	}
+	if len(faq.Mismatched) != 1 || !strings.Contains(faq.Mismatched[0], "Will rates fall next year?") {
+		t.Errorf("FAQPage mismatched = %q, want the question not on the page", faq.Mismatched)
+	}
+
+	// Complete markup matching the page is valid
+	page.StructuredData = []map[string]any{{
+		"@type":         []any{"NewsArticle"},
+		"headline":      "Rates are rising",
+		"datePublished": "2024-03-05T09:00:00Z",
+		"author":        map[string]any{"@type": "Person", "name": "Ann Lee"},
+	}}
+	checks = validateSchema(newDocument(content, page))
+	if len(checks) != 1 || !checks[0].Valid() {
+		t.Errorf("validateSchema = %+v, want valid NewsArticle markup", checks)
+	}
+}
+
+func TestVisibleMismatches(t *testing.T) {
+	content := "Trail Runner 2\n\nBy Sam Ortiz, March 3, 2024\n\nRated 4.6 out of 5 by 212 runners.\n\nNow $119.99, free shipping."
+	page := &webpage.PageData{Content: content}
+	doc := newDocument(content, page)
+
+	consistent := map[string]any{
+		"author":          []any{map[string]any{"@type": "Person", "name": "Sam Ortiz"}},
+		"datePublished":   "2024-03-03T08:00:00Z",
+		"aggregateRating": map[string]any{"ratingValue": "4.58", "reviewCount": 212},
+		"offers":          map[string]any{"price": 119.99, "priceCurrency": "USD"},
+	}
+	if mismatched := doc.visibleMismatches(consistent); len(mismatched) != 0 {
+		t.Errorf("visibleMismatches = %q, want none for values the page shows", mismatched)
+	}
+
+	contradicted := map[string]any{
+		"author":          "Jo Park",
+		"datePublished":   "2024-02-01",
+		"aggregateRating": map[string]any{"ratingValue": 4.9},
+		"offers":          map[string]any{"price": "99.00"},
+	}
+	mismatched := doc.visibleMismatches(contradicted)
+	want := []string{"author:", "datePublished:", "ratingValue:", "offers.price:"}
+	if len(mismatched) != len(want) {
+		t.Fatalf("visibleMismatches = %q, want %v", mismatched, want)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(mismatched[i], prefix) {
+			t.Errorf("mismatch %d = %q, want %s", i, mismatched[i], prefix)
+		}
+	}
+
+	// A page without dates does not contradict the markup's, but prices
+	// must be shown
+	content = "Trail Runner 2\n\nA light shoe for rocky trails."
+	bare := newDocument(content, &webpage.PageData{Content: content})
+	mismatched = bare.visibleMismatches(map[string]any{"datePublished": "2024-02-01", "offers": map[string]any{"price": "119.99"}})
+	if len(mismatched) != 1 || !strings.Contains(mismatched[0], "shows no price") {
+		t.Errorf("visibleMismatches = %q, want only the unshown price", mismatched)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for s, want := range map[string]float64{"119.99": 119.99, "1,299": 1299, "1,299.50": 1299.5, "19,99": 19.99, "1.299,50": 1299.5, "25.": 25} {
+		if got, ok := parseAmount(s); !ok || got != want {
+			t.Errorf("parseAmount(%q) = %v, %v, want %v", s, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package scorer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sentenceEnd matches candidate sentence boundaries: terminal punctuation,
+// with any closing quotes or brackets, followed by whitespace or the end of
+// the text, or the full-width stops of Chinese and Japanese, which need no
+// space after them. Decimals ("3.5"), versions and URLs never match.
+var sentenceEnd = regexp.MustCompile(`[.!?…]+["'”’)\]]*(\s+|$)|[。！？]+[」』”’）]*\s*`)
+
+// nonTerminalAbbreviations are abbreviations that do not end a sentence
+// even before a capitalized word ("Dr. Smith", "e.g. Python").
+var nonTerminalAbbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "st": true,
+	"sr": true, "jr": true, "vs": true, "e.g": true, "i.e": true, "cf": true,
+	"fig": true, "approx": true, "no": true, "vol": true, "pp": true, "al": true,
+}
+
+// splitSentenceSpans segments text into sentences and returns their
+// offsets into text, trimmed of surrounding whitespace. A period only ends
+// a sentence when it does not close a known abbreviation or an initial and
+// the next word does not start in lower case or with a digit, so "e.g.",
+// "U.S. economy" and "approx. 5" stay within their sentence.
+func splitSentenceSpans(text string) []span {
+	var spans []span
+	start := 0
+	for _, m := range sentenceEnd.FindAllStringIndex(text, -1) {
+		if m[1] < len(text) && !isSentenceBoundary(text, m[0], m[1]) {
+			continue
+		}
+		if s := trimSpan(text, span{start, m[1]}); s.start < s.end {
+			spans = append(spans, s)
+		}
+		start = m[1]
+	}
+	if s := trimSpan(text, span{start, len(text)}); s.start < s.end {
+		spans = append(spans, s)
+	}
+	return spans
+}
+
+// isSentenceBoundary reports whether the punctuation at text[at:next]
+// ends a sentence, next being the start of the following word. Question
+// and exclamation marks always do.
+func isSentenceBoundary(text string, at, next int) bool {
+	if text[at] != '.' {
+		return true
+	}
+
+	// The word the period closes, unless it is an ellipsis
+	if !strings.HasPrefix(text[at:], "..") {
+		word := text[strings.LastIndexAny(text[:at], " \t\n(\"'")+1 : at]
+		if nonTerminalAbbreviations[strings.ToLower(word)] {
+			return false
+		}
+		if r, size := utf8.DecodeRuneInString(word); size == len(word) && unicode.IsUpper(r) {
+			return false // an initial, as in "J. R. R. Tolkien"
+		}
+	}
+
+	r, _ := utf8.DecodeRuneInString(text[next:])
+	return !unicode.IsLower(r) && !unicode.IsDigit(r)
+}
+
+// sentenceSpans segments content into sentences paragraph by paragraph,
+// so headings and list items without final punctuation stand alone.
+func sentenceSpans(content string) []span {
+	var spans []span
+	start := 0
+	for start < len(content) {
+		end := strings.Index(content[start:], "\n\n")
+		if end < 0 {
+			end = len(content)
+		} else {
+			end += start
+		}
+		for _, s := range splitSentenceSpans(content[start:end]) {
+			spans = append(spans, span{start + s.start, start + s.end})
+		}
+		start = end + 2
+	}
+	return spans
+}
+
+// Sentences segments content into sentences the way the scorer counts
+// them, for checks outside the scorer that work sentence by sentence.
+func Sentences(content string) []string {
+	spans := sentenceSpans(content)
+	sentences := make([]string, len(spans))
+	for i, s := range spans {
+		sentences[i] = content[s.start:s.end]
+	}
+	return sentences
+}
+
+// countSentences returns the number of sentences in content.
+func countSentences(content string) int {
+	return len(sentenceSpans(content))
+}
+
+// proseSentences returns the sentences of the document that are not
+// headings, which would otherwise count as one-line sentences.
+func (d *document) proseSentences() []span {
+	var spans []span
+	for _, s := range d.sentences() {
+		if !d.isHeading(s) {
+			spans = append(spans, s)
+		}
+	}
+	return spans
+}
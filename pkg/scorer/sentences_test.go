@@ -0,0 +1,34 @@
+package scorer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentenceSpans(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Use a tool, e.g. Python. It is free.", []string{"Use a tool, e.g. Python.", "It is free."}},
+		{"The U.S. economy grew 2.5% last year. Rates fell.", []string{"The U.S. economy grew 2.5% last year.", "Rates fell."}},
+		{"See https://example.com/docs.html for details. Then install v1.2.3 now!", []string{"See https://example.com/docs.html for details.", "Then install v1.2.3 now!"}},
+		{"Dr. Smith met J. R. Tolkien. Really? Yes.", []string{"Dr. Smith met J. R. Tolkien.", "Really?", "Yes."}},
+		{"Lee et al. (2021) found it. Others did not.", []string{"Lee et al. (2021) found it.", "Others did not."}},
+		{"生成式引擎优化很重要。它帮助内容被引用！", []string{"生成式引擎优化很重要。", "它帮助内容被引用！"}},
+		{"He said \"stop.\" Then he left... and came back. Costs approx. 5 dollars", []string{"He said \"stop.\"", "Then he left... and came back.", "Costs approx. 5 dollars"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, s := range splitSentenceSpans(tt.text) {
+			got = append(got, tt.text[s.start:s.end])
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitSentenceSpans(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	if n := countSentences("Heading\n\nFirst sentence. Second one.\n\n- a list item"); n != 4 {
+		t.Errorf("countSentences = %d, want 4", n)
+	}
+}
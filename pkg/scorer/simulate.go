@@ -0,0 +1,163 @@
+package scorer
+
+import (
+	"fmt"
+	"geo-checker/internal/webpage"
+	"strings"
+)
+
+// Scenario describes hypothetical edits to a page. Applying it never touches
+// the real content; it produces a modified copy to score.
+type Scenario struct {
+	AddH1              bool `json:"add_h1,omitempty"`
+	ShortenParagraphs  bool `json:"shorten_paragraphs,omitempty"`
+	AddCitations       int  `json:"add_citations,omitempty"`
+	AddMetaDescription bool `json:"add_meta_description,omitempty"`
+}
+
+// Simulation compares the score of a page before and after a scenario.
+type Simulation struct {
+	Changes []string     `json:"changes"`
+	Before  *GEOScore    `json:"before"`
+	After   *GEOScore    `json:"after"`
+	Pillars []PillarDiff `json:"pillars"`
+}
+
+// PillarDiff is the projected change of one pillar.
+type PillarDiff struct {
+	Name   string `json:"name"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// maxParagraphWords is the length shortened paragraphs are split to, well
+// inside the 20-150 word range the paragraph rule rewards.
+const maxParagraphWords = 80
+
+// Simulate scores the page as is and with the scenario applied.
+func (ls *LocalScorer) Simulate(content string, pageData *webpage.PageData, scenario Scenario) *Simulation {
+	newContent, newPage, changes := scenario.Apply(content, pageData)
+	sim := &Simulation{
+		Changes: changes,
+		Before:  ls.AnalyzeContent(content, pageData),
+		After:   ls.AnalyzeContent(newContent, newPage),
+	}
+
+	for _, key := range sim.Before.PillarKeys() {
+		before, _ := sim.Before.Pillar(key)
+		after, _ := sim.After.Pillar(key)
+		sim.Pillars = append(sim.Pillars, PillarDiff{before.Name, before.Score, after.Score})
+	}
+	return sim
+}
+
+// Apply returns copies of the content and page data with the scenario's
+// edits, and a description of each edit that changed something.
+func (s Scenario) Apply(content string, pageData *webpage.PageData) (string, *webpage.PageData, []string) {
+	page := *pageData
+	page.Headings = append([]webpage.Heading(nil), pageData.Headings...)
+	page.MetaTags = make(map[string]string, len(pageData.MetaTags))
+	for k, v := range pageData.MetaTags {
+		page.MetaTags[k] = v
+	}
+
+	var changes []string
+
+	if s.AddH1 && !hasH1(page.Headings) {
+		title := strings.TrimSpace(page.Title)
+		if title == "" {
+			title = "Page title"
+		}
+		page.Headings = append([]webpage.Heading{{Level: 1, Text: title}}, page.Headings...)
+		content = title + "\n\n" + content
+		changes = append(changes, fmt.Sprintf("add H1 %q", title))
+	}
+
+	if s.ShortenParagraphs {
+		var split int
+		content, split = shortenParagraphs(content)
+		if split > 0 {
+			changes = append(changes, fmt.Sprintf("split %d paragraph(s) over 150 words", split))
+		}
+	}
+
+	if s.AddCitations > 0 {
+		// Citations are scored on the links of pages that have any, so each
+		// one also links to a primary source on a site of its own
+		var sb strings.Builder
+		sb.WriteString(content)
+		page.Links = append([]webpage.Link(nil), pageData.Links...)
+		for i := 1; i <= s.AddCitations; i++ {
+			text := fmt.Sprintf("supporting study %d", i)
+			fmt.Fprintf(&sb, "\n\nSource: %s.", text)
+			page.Links = append(page.Links, webpage.Link{Text: text, URL: fmt.Sprintf("https://research-%d.example.edu/study", i)})
+		}
+		content = sb.String()
+		changes = append(changes, fmt.Sprintf("add %d citations", s.AddCitations))
+	}
+
+	if s.AddMetaDescription && len(page.MetaTags["description"]) <= 50 {
+		page.MetaTags["description"] = "A concise summary of what this page covers, who it is for and the key takeaways it offers."
+		changes = append(changes, "add meta description")
+	}
+
+	page.Content = content
+	return content, &page, changes
+}
+
+func hasH1(headings []webpage.Heading) bool {
+	for _, h := range headings {
+		if h.Level == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// shortenParagraphs splits paragraphs over 150 words into chunks of about
+// maxParagraphWords words at sentence boundaries.
+func shortenParagraphs(content string) (string, int) {
+	paragraphs := strings.Split(content, "\n\n")
+	split := 0
+	var out []string
+	for _, para := range paragraphs {
+		if len(strings.Fields(para)) <= 150 {
+			out = append(out, para)
+			continue
+		}
+		split++
+
+		var chunk []string
+		words := 0
+		for _, m := range splitSentences(para) {
+			chunk = append(chunk, m)
+			words += len(strings.Fields(m))
+			if words >= maxParagraphWords {
+				out = append(out, strings.Join(chunk, " "))
+				chunk, words = nil, 0
+			}
+		}
+		if len(chunk) > 0 {
+			out = append(out, strings.Join(chunk, " "))
+		}
+	}
+	return strings.Join(out, "\n\n"), split
+}
+
+// splitSentences splits text after sentence punctuation, falling back to
+// fixed word runs for text without any.
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, s := range splitSentenceSpans(text) {
+		sentences = append(sentences, text[s.start:s.end])
+	}
+
+	if len(sentences) == 1 {
+		words := strings.Fields(text)
+		sentences = nil
+		for i := 0; i < len(words); i += maxParagraphWords {
+			sentences = append(sentences, strings.Join(words[i:min(i+maxParagraphWords, len(words))], " "))
+		}
+	}
+	return sentences
+}
@@ -0,0 +1,62 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestScenarioApplyLeavesOriginalUntouched(t *testing.T) {
+	content := "Intro\n\n" + strings.Repeat("One more sentence about the subject. ", 40)
+	page := &webpage.PageData{
+		Title:    "Guide",
+		Content:  content,
+		MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 2, Text: "Intro"}},
+	}
+
+	scenario := Scenario{AddH1: true, ShortenParagraphs: true, AddCitations: 2, AddMetaDescription: true}
+	newContent, newPage, changes := scenario.Apply(content, page)
+
+	if len(changes) != 4 {
+		t.Errorf("changes = %v", changes)
+	}
+	if page.Content != content || len(page.Headings) != 1 || len(page.MetaTags) != 0 {
+		t.Errorf("original page was modified: %+v", page)
+	}
+	if newPage.Headings[0].Level != 1 || !strings.HasPrefix(newContent, "Guide\n\n") {
+		t.Errorf("H1 not added: %+v", newPage.Headings)
+	}
+	for _, para := range strings.Split(newContent, "\n\n") {
+		if n := len(strings.Fields(para)); n > 150 {
+			t.Errorf("paragraph of %d words left unsplit", n)
+		}
+	}
+
+	sim := NewLocalScorer().Simulate(content, page, scenario)
+	if sim.After.Overall <= sim.Before.Overall {
+		t.Errorf("expected projected score to improve: %d -> %d", sim.Before.Overall, sim.After.Overall)
+	}
+}
+
+func TestSimulatedCitationsCountAsLinks(t *testing.T) {
+	content := strings.Repeat("Widgets are small mechanical parts used in many machines. ", 20)
+	page := &webpage.PageData{
+		URL:      "https://widgets.example/guide",
+		Title:    "Widgets",
+		Content:  content,
+		MetaTags: map[string]string{},
+		Links:    []webpage.Link{{Text: "my notes", URL: "https://someone.medium.com/notes"}},
+	}
+
+	sim := NewLocalScorer().Simulate(content, page, Scenario{AddCitations: 3})
+	for _, diff := range sim.Pillars {
+		if diff.Name == sim.Before.Breakdown.AuthoritySignals.Name {
+			if diff.After <= diff.Before {
+				t.Errorf("authority %d -> %d, want a rise from the added citations", diff.Before, diff.After)
+			}
+			return
+		}
+	}
+	t.Fatalf("no authority pillar in %+v", sim.Pillars)
+}
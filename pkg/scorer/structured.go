@@ -0,0 +1,198 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Points of the Structured Data pillar: markup of the type the page calls
+// for (40), required properties with valid values the page agrees with
+// (40) and the recommended properties (20).
+const (
+	schemaTypeMaxPoints        = 40
+	schemaValidityMaxPoints    = 40
+	schemaRecommendedMaxPoints = 20
+
+	// minFAQHeadings is the number of question headings from which a page
+	// reads as an FAQ that FAQPage markup should describe.
+	minFAQHeadings = 3
+)
+
+// pageSchemaTypes are the types that say what a page is, by profile, as
+// opposed to who publishes it or where it sits in the site.
+var pageSchemaTypes = map[Profile][]string{
+	ProfileGeneral:       {"Article", "NewsArticle", "BlogPosting", "TechArticle", "FAQPage", "QAPage", "HowTo", "Product", "Recipe", "Event", "VideoObject", "Review", "LocalBusiness"},
+	ProfileDocs:          {"TechArticle", "Article", "HowTo", "FAQPage"},
+	ProfileProduct:       {"Product"},
+	ProfileNews:          {"NewsArticle", "Article", "BlogPosting"},
+	ProfileLocalBusiness: {"LocalBusiness"},
+	ProfileCategory:      {"CollectionPage", "ItemList", "OfferCatalog"},
+}
+
+func (ls *LocalScorer) analyzeStructuredData(doc *document) ScoreDetail {
+	detail := ScoreDetail{MaxScore: 100, Issues: []string{}, Positives: []string{}, Findings: []Finding{}}
+	score := 0
+
+	// Check the page is marked up as what it is (40 points)
+	typeScore, typeIssue, typeEvidence := evaluateSchemaType(doc, ls.profile)
+	score += typeScore
+	if typeIssue == "" {
+		detail.Positives = append(detail.Positives, "Structured data describes what the page is")
+	} else {
+		detail.addIssue("structured_data", "schema.type", typeIssue, typeScore, schemaTypeMaxPoints, typeEvidence...)
+	}
+
+	// Markup of no known type has nothing to validate or complete, which
+	// the type rule already reports
+	if len(doc.schema) == 0 {
+		detail.Score = score
+		detail.Percentage = float64(score) / float64(detail.MaxScore) * 100
+		return detail
+	}
+
+	// Check required properties, values and consistency with the page (40
+	// points)
+	valid := 0
+	for _, c := range doc.schema {
+		if c.Valid() || (profileScoresSchema(ls.profile, c.Type) && len(c.Mismatched) == 0) {
+			valid++
+		}
+	}
+	validityScore := int(math.Round(float64(schemaValidityMaxPoints*valid) / float64(len(doc.schema))))
+	score += validityScore
+	if issue, evidence := schemaIssue(doc.schema, ls.profile); issue == "" {
+		detail.Positives = append(detail.Positives, "Structured data is valid and matches the visible content")
+	} else {
+		detail.addIssue("structured_data", "schema.validity", issue, validityScore, schemaValidityMaxPoints, evidence...)
+	}
+
+	// Check recommended properties (20 points)
+	recommendedScore, recommendedIssue, recommendedEvidence := evaluateRecommendedSchema(doc.schema)
+	score += recommendedScore
+	if recommendedIssue == "" {
+		detail.Positives = append(detail.Positives, "Structured data has the recommended properties")
+	} else {
+		detail.addIssue("structured_data", "schema.recommended", recommendedIssue, recommendedScore, schemaRecommendedMaxPoints, recommendedEvidence...)
+	}
+
+	detail.Score = score
+	detail.Percentage = float64(score) / float64(detail.MaxScore) * 100
+	return detail
+}
+
+// evaluateSchemaType scores whether the page's markup says what the page
+// is (40 points): none at all scores 0, markup of other types 20. Articles
+// and docs answering questions under their headings lose 10 without
+// FAQPage markup.
+func evaluateSchemaType(doc *document, profile Profile) (int, string, []Evidence) {
+	wanted := pageSchemaTypes[profile]
+	if wanted == nil {
+		wanted = pageSchemaTypes[ProfileGeneral]
+	}
+	if len(doc.page.StructuredData) == 0 {
+		return 0, fmt.Sprintf("Add schema.org markup (JSON-LD) describing the page as %s", orList(wanted)),
+			[]Evidence{missingEvidence("no JSON-LD, microdata or RDFa on the page")}
+	}
+
+	var found []string
+	matched := false
+	for _, obj := range doc.page.StructuredData {
+		types := schemaTypeNames(obj)
+		if rule := schemaRuleType(obj); rule != "" {
+			types = append(types, rule)
+		}
+		for _, t := range types {
+			for _, w := range wanted {
+				matched = matched || t == w
+			}
+		}
+		found = append(found, types...)
+	}
+	if !matched {
+		evidence := []Evidence{missingEvidence("markup describes only %s", strings.Join(uniqueStrings(found), ", "))}
+		return schemaTypeMaxPoints / 2, fmt.Sprintf("Mark the page up as %s, not only as %s", orList(wanted), strings.Join(uniqueStrings(found), ", ")), evidence
+	}
+
+	if profile == ProfileGeneral || profile == ProfileDocs {
+		var questions []Evidence
+		for _, h := range doc.page.Headings {
+			if h.Level >= 2 && strings.HasSuffix(strings.TrimSpace(h.Text), "?") {
+				questions = append(questions, missingEvidence("question heading: %s", h.Text))
+			}
+		}
+		if len(questions) >= minFAQHeadings && len(doc.page.SchemaObjects("FAQPage")) == 0 {
+			if len(questions) > maxEvidence {
+				questions = questions[:maxEvidence]
+			}
+			return schemaTypeMaxPoints - 10, "Add FAQPage markup for the questions the page answers under its headings", questions
+		}
+	}
+	return schemaTypeMaxPoints, "", nil
+}
+
+// evaluateRecommendedSchema scores the recommended properties present in
+// the markup (20 points), in proportion.
+func evaluateRecommendedSchema(checks []SchemaCheck) (int, string, []Evidence) {
+	total, missing := 0, 0
+	var types []string
+	var evidence []Evidence
+	for _, c := range checks {
+		total += len(schemaRules[c.Type].recommended)
+		missing += len(c.Recommended)
+		if len(c.Recommended) > 0 {
+			types = append(types, c.Type)
+		}
+		for _, prop := range c.Recommended {
+			evidence = append(evidence, missingEvidence("%s markup has no %s", c.Type, prop))
+		}
+	}
+	if missing == 0 {
+		return schemaRecommendedMaxPoints, "", nil
+	}
+	if len(evidence) > maxEvidence {
+		evidence = evidence[:maxEvidence]
+	}
+	points := int(math.Round(float64(schemaRecommendedMaxPoints*(total-missing)) / float64(total)))
+	return points, fmt.Sprintf("Complete the %s markup with its recommended properties", strings.Join(uniqueStrings(types), ", ")), evidence
+}
+
+// schemaTypeNames returns the @type of obj, a string or a list, as names.
+func schemaTypeNames(obj map[string]any) []string {
+	switch t := obj["@type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var names []string
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// orList joins types as "A, B or C", naming the first few of long lists.
+func orList(names []string) string {
+	switch {
+	case len(names) == 1:
+		return names[0]
+	case len(names) > 4:
+		return strings.Join(names[:4], ", ") + " or a similar type"
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,57 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"testing"
+)
+
+func TestStructuredDataPillar(t *testing.T) {
+	content := "Rates are rising\n\nBy Ann Lee\n\nThe central bank raised rates again on Tuesday, the third rise this year."
+	page := func(objects ...map[string]any) *webpage.PageData {
+		return &webpage.PageData{Content: content, Headings: []webpage.Heading{{Level: 1, Text: "Rates are rising"}}, StructuredData: objects}
+	}
+	ls := NewLocalScorer()
+
+	none := ls.AnalyzeContent(content, page())
+	if got := none.Breakdown.StructuredData; got.Score != 0 || !hasFinding(none, "schema.type") {
+		t.Errorf("no markup: %+v, want 0 and schema.type", got)
+	}
+
+	publisherOnly := ls.AnalyzeContent(content, page(map[string]any{"@type": "Organization", "name": "Daily Ledger"}))
+	if got := publisherOnly.Breakdown.StructuredData.Score; got <= 0 || got >= 100 || !hasFinding(publisherOnly, "schema.type") {
+		t.Errorf("Organization only: %d, findings %+v, want partial points and schema.type", got, publisherOnly.Findings)
+	}
+
+	broken := ls.AnalyzeContent(content, page(map[string]any{"@type": "Article", "headline": "Rates are rising"}))
+	if !hasFinding(broken, "schema.validity") || hasFinding(broken, "schema.type") {
+		t.Errorf("findings %+v, want schema.validity and not schema.type", broken.Findings)
+	}
+
+	article := map[string]any{"@type": "Article", "headline": "Rates are rising", "datePublished": "2024-03-05", "author": "Ann Lee",
+		"image": "https://example.com/rates.png", "dateModified": "2024-03-06", "publisher": "Daily Ledger"}
+	valid := ls.AnalyzeContent(content, page(article))
+	if got := valid.Breakdown.StructuredData; got.Score != 100 || len(got.Findings) != 0 {
+		t.Errorf("complete markup: %+v, want 100", got)
+	}
+	if broken.Breakdown.StructuredData.Score >= valid.Breakdown.StructuredData.Score || broken.Overall >= valid.Overall {
+		t.Errorf("broken markup scored %d (overall %d), valid %d (overall %d)", broken.Breakdown.StructuredData.Score, broken.Overall,
+			valid.Breakdown.StructuredData.Score, valid.Overall)
+	}
+
+	// An article answering questions under its headings is an FAQ too
+	faqPage := page(article)
+	for _, q := range []string{"Why are rates rising?", "Who decides?", "What happens next?"} {
+		faqPage.Headings = append(faqPage.Headings, webpage.Heading{Level: 2, Text: q})
+	}
+	faq := ls.AnalyzeContent(content, faqPage)
+	if !hasFinding(faq, "schema.type") || faq.Breakdown.StructuredData.Score != 90 {
+		t.Errorf("question headings: %d, findings %+v, want schema.type for FAQPage", faq.Breakdown.StructuredData.Score, faq.Findings)
+	}
+
+	// Product pages are expected to be marked up as products
+	ls.SetProfile(ProfileProduct)
+	product := ls.AnalyzeContent(content, page(article))
+	if !hasFinding(product, "schema.type") {
+		t.Errorf("product page with Article markup: findings %+v, want schema.type", product.Findings)
+	}
+}
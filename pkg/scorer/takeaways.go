@@ -0,0 +1,153 @@
+package scorer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SummaryAdvice is the key-takeaways check: whether the page offers a TL;DR
+// or key-takeaways block near the top, where answer engines and skimming
+// readers look for the gist, and a block drafted from the page's own
+// figures when it does not.
+type SummaryAdvice struct {
+	Found      bool      `json:"found"`
+	Top        bool      `json:"top"` // within the first third of the page
+	Suggestion string    `json:"suggestion,omitempty"`
+	Evidence   *Evidence `json:"evidence,omitempty"`  // the summary block found
+	Takeaways  []string  `json:"takeaways,omitempty"` // drafted bullets
+	Draft      string    `json:"draft,omitempty"`     // Markdown block ready to paste
+}
+
+const (
+	// summaryMaxPoints is what the key-takeaways rule contributes to the
+	// Content Structure pillar.
+	summaryMaxPoints = 10
+
+	// minSummaryWords is the length from which a page needs a summary.
+	minSummaryWords = 300
+
+	// Drafted blocks have between minTakeaways and maxTakeaways bullets.
+	minTakeaways = 3
+	maxTakeaways = 5
+
+	// maxTakeawayWords caps the length of a sentence taken as a bullet.
+	maxTakeawayWords = 30
+)
+
+// summaryPattern matches the labels of summary blocks, as a heading or at
+// the start of a paragraph ("TL;DR: …").
+var summaryPattern = regexp.MustCompile(`(?i)^(tl;? ?dr|key (takeaways|points|facts)|takeaways|summary|at a glance|in brief|in short|the short answer|quick (answer|facts|summary)|highlights|the bottom line|bottom line)\b`)
+
+// keyTakeaways looks for a summary block. Without one near the top it
+// drafts one from sentences carrying figures, one per section first; pages
+// shorter than minSummaryWords need none and get nil.
+func (d *document) keyTakeaways() *SummaryAdvice {
+	if depthWords(d.content) < minSummaryWords {
+		return nil
+	}
+
+	advice := &SummaryAdvice{}
+	for _, p := range d.paragraphs() {
+		// A "Summary" closing the page is the conclusion, credited there
+		if summaryPattern.MatchString(d.content[p.start:p.end]) && !d.closesPage(p) {
+			ev := d.evidence(p)
+			advice.Found, advice.Evidence = true, &ev
+			advice.Top = p.start < len(d.content)/3
+			break
+		}
+	}
+	switch {
+	case advice.Top:
+		return advice
+	case advice.Found:
+		advice.Suggestion = "Move the summary block to the top of the article, under the H1"
+		return advice
+	}
+
+	advice.Takeaways = d.draftTakeaways()
+	advice.Suggestion = "Add a key-takeaways block of 3-5 bullets with the main figures at the top of the article"
+	if len(advice.Takeaways) > 0 {
+		advice.Draft = "**Key takeaways**\n\n- " + strings.Join(advice.Takeaways, "\n- ")
+	}
+	return advice
+}
+
+// closesPage reports whether p is a block the conclusion check credits: a
+// closing heading or paragraph in the last section of the page.
+func (d *document) closesPage(p span) bool {
+	sections := d.sectionHeadings()
+	if len(sections) == 0 || p.start < sections[len(sections)-1].offset {
+		return false
+	}
+	text := d.content[p.start:p.end]
+	return conclusionHeadingPattern.MatchString(text) || closingPattern.MatchString(text)
+}
+
+// draftTakeaways picks up to maxTakeaways sentences for a key-takeaways
+// block: sentences with figures, one per section and then any, topped up
+// with the sentences opening each section. Fewer than minTakeaways give
+// no draft.
+func (d *document) draftTakeaways() []string {
+	var candidates []span
+	for _, s := range d.sentences() {
+		text := d.content[s.start:s.end]
+		if words := wordCount(d.content, s); words >= 6 && words <= maxTakeawayWords &&
+			!d.isHeading(s) && !strings.HasSuffix(text, "?") && !hookPattern.MatchString(text) {
+			candidates = append(candidates, s)
+		}
+	}
+
+	var picked []span
+	taken := make(map[span]bool)
+	sections := make(map[string]bool)
+	take := func(s span) {
+		if len(picked) < maxTakeaways && !taken[s] {
+			taken[s] = true
+			sections[strings.Join(d.headingPath(s.start), "\n")] = true
+			picked = append(picked, s)
+		}
+	}
+
+	// Figures, spread over the sections first
+	for _, s := range candidates {
+		if hasFigure.MatchString(d.content[s.start:s.end]) && !sections[strings.Join(d.headingPath(s.start), "\n")] {
+			take(s)
+		}
+	}
+	for _, s := range candidates {
+		if hasFigure.MatchString(d.content[s.start:s.end]) {
+			take(s)
+		}
+	}
+	// Then the topic sentences of sections without one
+	for _, s := range candidates {
+		if !sections[strings.Join(d.headingPath(s.start), "\n")] {
+			take(s)
+		}
+	}
+
+	if len(picked) < minTakeaways {
+		return nil
+	}
+	// In page order
+	sort.Slice(picked, func(i, j int) bool { return picked[i].start < picked[j].start })
+	takeaways := make([]string, len(picked))
+	for i, s := range picked {
+		takeaways[i] = strings.Join(strings.Fields(d.content[s.start:s.end]), " ")
+	}
+	return takeaways
+}
+
+// evaluateKeyTakeaways scores the key-takeaways check (10 points): 10 for
+// a summary block near the top or a page too short to need one, 6 for one
+// further down and 2 without one.
+func evaluateKeyTakeaways(advice *SummaryAdvice) int {
+	switch {
+	case advice == nil || advice.Top:
+		return summaryMaxPoints
+	case advice.Found:
+		return 6
+	}
+	return 2
+}
@@ -0,0 +1,62 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestKeyTakeaways(t *testing.T) {
+	filler := strings.Repeat("Good bread rewards patience more than any single trick or tool. ", 6)
+	sections := []string{
+		"Flour", "Whole wheat flour absorbs about 10% more water than white flour. " + filler,
+		"Water", "Bakers keep the dough at 24 degrees for a steady rise. " + filler,
+		"Time", "A long cold proof of 12 hours deepens the flavor. " + filler,
+		"Baking", "The loaf bakes for 45 minutes in a covered pot. " + filler,
+	}
+	content := "Sourdough basics\n\n"
+	headings := []webpage.Heading{{Level: 1, Text: "Sourdough basics"}}
+	for i := 0; i < len(sections); i += 2 {
+		content += sections[i] + "\n\n" + sections[i+1] + "\n\n"
+		headings = append(headings, webpage.Heading{Level: 2, Text: sections[i]})
+	}
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{}, Headings: headings}
+
+	score := NewLocalScorer().AnalyzeContent(content, page)
+	advice := score.KeyTakeaways
+	if advice == nil || advice.Found {
+		t.Fatalf("advice = %+v, want a missing summary", advice)
+	}
+	if len(advice.Takeaways) != 4 || !strings.HasPrefix(advice.Takeaways[0], "Whole wheat flour") || !strings.HasPrefix(advice.Takeaways[3], "The loaf bakes") {
+		t.Errorf("takeaways = %q, want one figure per section in page order", advice.Takeaways)
+	}
+	if !strings.HasPrefix(advice.Draft, "**Key takeaways**\n\n- Whole wheat flour") {
+		t.Errorf("draft = %q", advice.Draft)
+	}
+
+	// A TL;DR under the H1 earns full points
+	content = strings.Replace(content, "Sourdough basics\n\n", "Sourdough basics\n\nTL;DR: feed the starter, proof cold, bake covered.\n\n", 1)
+	page.Content = content
+	score = NewLocalScorer().AnalyzeContent(content, page)
+	if advice := score.KeyTakeaways; advice == nil || !advice.Top || advice.Suggestion != "" {
+		t.Errorf("advice = %+v, want a summary at the top", advice)
+	}
+	for _, f := range score.Findings {
+		if f.ID == "structure.takeaways" {
+			t.Errorf("unexpected finding %s", f.ID)
+		}
+	}
+}
+
+func TestClosingSummaryIsAConclusion(t *testing.T) {
+	body := strings.Repeat("Good bread rewards patience more than any single trick or tool. ", 10)
+	content, page := bookendPage("", []string{"Feeding the starter", "Shaping the dough", "Summary"}, body)
+
+	score := NewLocalScorer().AnalyzeContent(content, page)
+	if score.Conclusion == nil || !score.Conclusion.Found {
+		t.Errorf("conclusion = %+v, want the summary section credited", score.Conclusion)
+	}
+	if advice := score.KeyTakeaways; advice == nil || advice.Found || strings.HasPrefix(advice.Suggestion, "Move") {
+		t.Errorf("takeaways = %+v, want the closing summary not taken for a misplaced TL;DR", advice)
+	}
+}
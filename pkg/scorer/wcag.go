@@ -0,0 +1,98 @@
+package scorer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// wcagMaxPoints is what the WCAG-lite checks contribute to the
+// Accessibility pillar.
+const wcagMaxPoints = 10
+
+// languageTag matches BCP 47 tags such as "en", "en-US" or "zh-Hant-TW".
+var languageTag = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// genericTitles are page titles that tell neither readers nor assistive
+// technologies which page they are on.
+var genericTitles = map[string]bool{
+	"home": true, "home page": true, "homepage": true, "untitled": true, "untitled document": true,
+	"document": true, "welcome": true, "index": true, "page": true, "new page": true,
+	"default": true, "title": true, "blog": true, "article": true, "post": true,
+}
+
+// evaluateWCAG runs a few WCAG checks that can be read from the markup: a
+// declared page language (2 points), a descriptive title (3), headings that
+// do not skip levels (2) and links and buttons with an accessible name (3).
+// Content that was not extracted from HTML is only judged on its title and
+// headings. It returns the issue to report, if any, and evidence.
+func evaluateWCAG(doc *document) (int, string, []Evidence) {
+	var fixes []string
+	var evidence []Evidence
+	score := 0
+
+	a11y := doc.page.Accessibility
+	switch {
+	case a11y == nil || languageTag.MatchString(a11y.Lang):
+		score += 2
+	case a11y.Lang == "":
+		fixes = append(fixes, `declare the page language with <html lang="…">`)
+	default:
+		fixes = append(fixes, fmt.Sprintf("replace the invalid page language %q with a BCP 47 tag such as \"en\"", a11y.Lang))
+	}
+
+	title := strings.TrimSpace(doc.page.Title)
+	switch words := len(strings.Fields(title)); {
+	case title == "":
+		fixes = append(fixes, "give the page a <title>")
+	case genericTitles[strings.ToLower(strings.Trim(title, " .!|-"))]:
+		fixes = append(fixes, "replace the generic title with one naming the page topic")
+		evidence = append(evidence, Evidence{Snippet: "title: " + title, Start: -1, End: -1})
+	case words < 3 && len(title) < 20:
+		score++
+		fixes = append(fixes, "make the title say what the page is about")
+		evidence = append(evidence, Evidence{Snippet: "title: " + title, Start: -1, End: -1})
+	default:
+		score += 3
+	}
+
+	skipped := false
+	for i := 1; i < len(doc.headings); i++ {
+		prev, h := doc.headings[i-1], doc.headings[i]
+		if h.level <= prev.level+1 {
+			continue
+		}
+		if !skipped {
+			fixes = append(fixes, "do not skip heading levels")
+			skipped = true
+		}
+		if len(evidence) < maxEvidence {
+			ev := doc.evidence(span{h.offset, h.offset + len(h.text)})
+			ev.Snippet = fmt.Sprintf("H%d → H%d: %s", prev.level, h.level, ev.Snippet)
+			evidence = append(evidence, ev)
+		}
+	}
+	if !skipped {
+		score += 2
+	}
+
+	if a11y == nil || len(a11y.UnlabeledControls) == 0 {
+		score += 3
+	} else {
+		if len(a11y.UnlabeledControls) <= 2 {
+			score++
+		}
+		fixes = append(fixes, fmt.Sprintf("give %d links or buttons a text or aria-label", len(a11y.UnlabeledControls)))
+		for _, control := range a11y.UnlabeledControls {
+			if len(evidence) == maxEvidence {
+				break
+			}
+			evidence = append(evidence, Evidence{Snippet: "no accessible name: " + control, Start: -1, End: -1})
+		}
+	}
+
+	if len(fixes) == 0 {
+		return score, "", nil
+	}
+	return score, "Fix basic accessibility: " + strings.Join(fixes, ", "), evidence
+}
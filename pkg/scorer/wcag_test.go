@@ -0,0 +1,41 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateWCAG(t *testing.T) {
+	headings := []webpage.Heading{{Level: 1, Text: "Trail shoes"}, {Level: 2, Text: "Sizes"}}
+	skipped := []webpage.Heading{{Level: 1, Text: "Trail shoes"}, {Level: 4, Text: "Sizes"}}
+	content := "Trail shoes\n\nGrip matters on rough ground.\n\nSizes\n\nMeasure your foot first."
+
+	tests := []struct {
+		name     string
+		title    string
+		headings []webpage.Heading
+		a11y     *webpage.Accessibility
+		want     int
+		issue    string
+	}{
+		{"all good", "Choosing trail running shoes", headings, &webpage.Accessibility{Lang: "en"}, 10, ""},
+		{"plain text", "Choosing trail running shoes", headings, nil, 10, ""},
+		{"no lang", "Choosing trail running shoes", headings, &webpage.Accessibility{}, 8, "declare the page language"},
+		{"invalid lang", "Choosing trail running shoes", headings, &webpage.Accessibility{Lang: "english please"}, 8, "invalid page language"},
+		{"generic title", "Home", headings, &webpage.Accessibility{Lang: "en"}, 7, "generic title"},
+		{"short title", "Shoes", headings, &webpage.Accessibility{Lang: "en"}, 8, "make the title say"},
+		{"skipped level", "Choosing trail running shoes", skipped, &webpage.Accessibility{Lang: "en"}, 8, "skip heading levels"},
+		{"unlabeled", "Choosing trail running shoes", headings, &webpage.Accessibility{Lang: "en", UnlabeledControls: []string{"<button>"}}, 8, "give 1 links"},
+		{"many unlabeled", "Choosing trail running shoes", headings, &webpage.Accessibility{Lang: "en", UnlabeledControls: []string{"<a>", "<a>", "<a>"}}, 7, "give 3 links"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &webpage.PageData{Title: tt.title, Content: content, Headings: tt.headings, Accessibility: tt.a11y}
+			score, issue, _ := evaluateWCAG(newDocument(content, page))
+			if score != tt.want || !strings.Contains(issue, tt.issue) || (tt.issue == "") != (issue == "") {
+				t.Errorf("score %d (%q), want %d with %q", score, issue, tt.want, tt.issue)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package scorer
+
+import (
+	"fmt"
+	"math"
+)
+
+// weightTolerance is how far pillar weights may sum from 1 before they are
+// normalized.
+const weightTolerance = 0.001
+
+// normalizeWeights scales the pillar weights, custom ones included, to sum
+// to 1 so the overall score stays on a 0-100 scale, and warns about
+// custom pillars that cannot reach 100 as defined.
+func (ls *LocalScorer) normalizeWeights() {
+	ls.customWeights = make(map[string]float64)
+	w := &ls.weights
+	total := w.ContentStructure + w.SemanticClarity + w.ContextRichness + w.AuthoritySignals + w.Accessibility + w.StructuredData
+	for _, p := range ls.framework.Pillars {
+		if isBuiltinPillar(p.ID) {
+			continue
+		}
+		if p.Weight == nil || *p.Weight == 0 {
+			ls.warnings = append(ls.warnings, fmt.Sprintf("pillar %q has no weight and does not count toward the overall score", p.ID))
+		} else {
+			ls.customWeights[p.ID] = *p.Weight
+			total += *p.Weight
+		}
+		if points := p.points(); points != 100 {
+			ls.warnings = append(ls.warnings, fmt.Sprintf("rule points of pillar %q sum to %d, not 100; its score is scaled to 100", p.ID, points))
+		}
+	}
+
+	switch {
+	case total <= 0:
+		ls.warnings = append(ls.warnings, "pillar weights sum to 0; the overall score is always 0")
+	case math.Abs(total-1) > weightTolerance:
+		ls.warnings = append(ls.warnings, fmt.Sprintf("pillar weights sum to %.3g, not 1; they are normalized", total))
+		for _, weight := range []*float64{&w.ContentStructure, &w.SemanticClarity, &w.ContextRichness, &w.AuthoritySignals, &w.Accessibility, &w.StructuredData} {
+			*weight /= total
+		}
+		for id := range ls.customWeights {
+			ls.customWeights[id] /= total
+		}
+	}
+}
+
+// points returns the points of a custom pillar's rules.
+func (p PillarDefinition) points() int {
+	total := 0
+	for _, r := range p.Rules {
+		total += r.Points
+	}
+	return total
+}
+
+// scalePoints scales points out of total to points out of max.
+func scalePoints(points, total, max int) int {
+	if total == 0 || total == max {
+		return points
+	}
+	return int(math.Round(float64(points*max) / float64(total)))
+}
+
+// checkPillarScores caps pillar scores outside 0 and their MaxScore, and
+// warns when the points a pillar scored plus those its findings lost
+// exceed its MaxScore, which means its rules' maximums are inconsistent.
+func (ls *LocalScorer) checkPillarScores(score *GEOScore) []string {
+	details := []*ScoreDetail{&score.Breakdown.ContentStructure, &score.Breakdown.SemanticClarity, &score.Breakdown.ContextRichness,
+		&score.Breakdown.AuthoritySignals, &score.Breakdown.Accessibility, &score.Breakdown.StructuredData}
+	keys := append([]string(nil), builtinPillars...)
+	for i := range score.Breakdown.Custom {
+		details = append(details, &score.Breakdown.Custom[i].ScoreDetail)
+		keys = append(keys, score.Breakdown.Custom[i].ID)
+	}
+
+	var warnings []string
+	for i, detail := range details {
+		name := ls.pillarName(keys[i])
+		lost := 0
+		for _, f := range detail.Findings {
+			lost += f.maxPoints - f.points
+		}
+		if detail.Score+lost > detail.MaxScore {
+			warnings = append(warnings, fmt.Sprintf("%s rules are worth %d points, more than its maximum of %d", name, detail.Score+lost, detail.MaxScore))
+		}
+		if detail.Score > detail.MaxScore || detail.Score < 0 {
+			warnings = append(warnings, fmt.Sprintf("%s scored %d, outside 0-%d; capped", name, detail.Score, detail.MaxScore))
+			detail.Score = max(0, min(detail.Score, detail.MaxScore))
+			detail.Percentage = float64(detail.Score) / float64(detail.MaxScore) * 100
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,82 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeWeights(t *testing.T) {
+	heavy, none := 0.5, 0.0
+	f := &Framework{Pillars: []PillarDefinition{
+		{ID: "accessibility", Weight: &heavy},
+		{ID: "voice", Weight: &heavy, Rules: []CustomRule{{ID: "voice.we", Message: "Say we", Points: 50, Pattern: `\bwe\b`}}},
+		{ID: "tone", Weight: &none, Rules: []CustomRule{{ID: "tone.you", Message: "Say you", Points: 100, Pattern: `\byou\b`}}},
+	}}
+	if err := f.compile(); err != nil {
+		t.Fatal(err)
+	}
+	warnings := strings.Join(f.Warnings(), "\n")
+	for _, want := range []string{`pillar "tone" has no weight`, `pillar "voice" sum to 50`, "weights sum to 1.87"} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings lack %q:\n%s", want, warnings)
+		}
+	}
+
+	ls := NewLocalScorer()
+	ls.SetFramework(f)
+	content := "We ship widgets to every country.\n\nOrders arrive within a week."
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{}, Headings: []webpage.Heading{}}
+	score := ls.AnalyzeContent(content, page)
+	if voice, _ := score.Pillar("voice"); voice.Score != 0 {
+		t.Errorf("voice = %d, want 0 (the rule is case-sensitive)", voice.Score)
+	}
+	if tone, _ := score.Pillar("tone"); tone.Score != 0 || len(tone.Findings) != 1 {
+		t.Errorf("tone = %+v", tone)
+	}
+	var total float64
+	for _, key := range score.PillarKeys() {
+		total += ls.pillarWeight(key)
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("weights sum to %f after normalization", total)
+	}
+
+	content = "we make widgets"
+	score = ls.AnalyzeContent(content, &webpage.PageData{Content: content, MetaTags: map[string]string{}, Headings: []webpage.Heading{}})
+	if voice, _ := score.Pillar("voice"); voice.Score != 100 {
+		t.Errorf("voice = %d, want its 50 points scaled to 100", voice.Score)
+	}
+}
+
+func TestCheckPillarScores(t *testing.T) {
+	score := &GEOScore{}
+	score.Breakdown.SemanticClarity = ScoreDetail{Score: 110, MaxScore: 100}
+	score.Breakdown.Accessibility = ScoreDetail{Score: 80, MaxScore: 100, Findings: []Finding{{ID: "a", maxPoints: 30, points: 0}}}
+	for _, d := range []*ScoreDetail{&score.Breakdown.ContentStructure, &score.Breakdown.ContextRichness, &score.Breakdown.AuthoritySignals} {
+		d.MaxScore = 100
+	}
+
+	warnings := NewLocalScorer().checkPillarScores(score)
+	if len(warnings) != 3 || score.Breakdown.SemanticClarity.Score != 100 || score.Breakdown.SemanticClarity.Percentage != 100 {
+		t.Errorf("warnings %v, semantic clarity %+v", warnings, score.Breakdown.SemanticClarity)
+	}
+	if !strings.Contains(warnings[2], "Accessibility rules are worth 110 points") {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
+
+// TestBuiltinScoresConsistent checks that no profile's rules promise more
+// points than their pillar has.
+func TestBuiltinScoresConsistent(t *testing.T) {
+	content := "Widgets\n\nWe tested " + strings.Repeat("widgets in many homes over a long year of use. ", 30) + "\n\nSizes\n\nMeasure the slot."
+	page := &webpage.PageData{URL: "https://example.com/guides/widgets", Title: "Widgets", Content: content, MetaTags: map[string]string{},
+		Headings: []webpage.Heading{{Level: 1, Text: "Widgets"}, {Level: 3, Text: "Sizes"}}}
+	for _, p := range Profiles {
+		ls := NewLocalScorer()
+		ls.SetProfile(p)
+		if score := ls.AnalyzeContent(content, page); len(score.Warnings) > 0 {
+			t.Errorf("%s: %v", p, score.Warnings)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package scorer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cjkCharsPerWord is the number of Chinese or Japanese characters that
+// carry about as much content as one English word, used to measure the
+// depth of CJK text that has no spaces between words.
+const cjkCharsPerWord = 1.5
+
+// vowels are the vowels syllables are counted by, in Latin scripts with
+// their accented forms, Cyrillic and Greek. CJK characters are one
+// syllable each.
+const vowels = "aeiouyàáâãäåæèéêëìíîïòóôõöøùúûüýÿœаеёиоуыэюяіїєαεηιουωάέήίόύώ"
+
+// isCJK reports whether r is written without spaces between words: Han
+// ideographs and Japanese kana. Korean separates words with spaces.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// splitWords tokenizes text into words in any script: runs separated by
+// whitespace, with every CJK character a word of its own. Tokens without a
+// letter or digit, such as dashes and bullets, are not words.
+func splitWords(text string) []string {
+	var words []string
+	for _, field := range strings.Fields(text) {
+		start := -1
+		for i, r := range field {
+			if isCJK(r) {
+				if start >= 0 {
+					words = appendWord(words, field[start:i])
+					start = -1
+				}
+				words = append(words, string(r))
+			} else if start < 0 {
+				start = i
+			}
+		}
+		if start >= 0 {
+			words = appendWord(words, field[start:])
+		}
+	}
+	return words
+}
+
+// appendWord appends token to words when it has a letter or digit.
+func appendWord(words []string, token string) []string {
+	if strings.IndexFunc(token, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) < 0 {
+		return words
+	}
+	return append(words, token)
+}
+
+// countWords returns the number of words in text, CJK characters
+// included one by one.
+func countWords(text string) int {
+	return len(splitWords(text))
+}
+
+// depthWords returns the length of text in English-word equivalents:
+// CJK characters count cjkCharsPerWord to the word, so a 1,500-character
+// Chinese article weighs as much as a 1,000-word English one.
+func depthWords(text string) int {
+	words, cjk := 0, 0
+	for _, w := range splitWords(text) {
+		if r := []rune(w); len(r) == 1 && isCJK(r[0]) {
+			cjk++
+		} else {
+			words++
+		}
+	}
+	return words + int(float64(cjk)/cjkCharsPerWord)
+}
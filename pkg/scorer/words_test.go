@@ -0,0 +1,62 @@
+package scorer
+
+import (
+	"geo-checker/internal/webpage"
+	"strings"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	for text, want := range map[string]int{
+		"Plain English words — and a dash": 6,
+		"Größe und Straße":                 3,
+		"Генеративная оптимизация движков": 3,
+		"生成式引擎优化":                          7,
+		"GEO 是一种方法":                        6,
+		"• 1,500 users":                    2,
+	} {
+		if got := countWords(text); got != want {
+			t.Errorf("countWords(%q) = %d, want %d (%q)", text, got, want, splitWords(text))
+		}
+	}
+	if got := depthWords(strings.Repeat("优化", 750)); got != 1000 {
+		t.Errorf("depthWords of 1,500 CJK characters = %d, want 1000", got)
+	}
+	if got := NewLocalScorer().countSyllables("оптимизировать"); got != 6 {
+		t.Errorf("countSyllables(оптимизировать) = %d, want 6", got)
+	}
+}
+
+func TestCJKDepth(t *testing.T) {
+	sentence := "生成式引擎优化是让内容更容易被人工智能搜索引擎理解和引用的做法。"
+	content := "什么是生成式引擎优化\n\n" + strings.Repeat(strings.Repeat(sentence, 5)+"\n\n", 8)
+	page := &webpage.PageData{Content: content, MetaTags: map[string]string{}, Headings: []webpage.Heading{{Level: 1, Text: "什么是生成式引擎优化"}}}
+
+	score := NewLocalScorer().AnalyzeContent(content, page)
+	if words := score.Metadata["word_count"].(int); words < 1000 {
+		t.Errorf("word_count = %d, want every character counted", words)
+	}
+	for _, f := range score.Findings {
+		if f.ID == "richness.depth" {
+			t.Errorf("CJK article of %d characters flagged as shallow: %s", len([]rune(content)), f.Message)
+		}
+	}
+	if grade := score.Metadata["reading_grade"].(float64); grade == 0 {
+		t.Error("reading grade of CJK prose is 0")
+	}
+}
+
+func TestCJKLengthThresholds(t *testing.T) {
+	// 186 characters: too long at a word a character, 124 word equivalents
+	sentence := "生成式引擎优化是让内容更容易被人工智能搜索引擎理解和引用的做法。"
+	para := strings.Repeat(sentence, 6)
+	content := para + "\n\n" + para
+	if got := NewLocalScorer().evaluateParagraphStructure(content); got != 15 {
+		t.Errorf("evaluateParagraphStructure of CJK paragraphs = %d, want 15", got)
+	}
+	// 49 words at a word a character, over the limit of 45
+	answer := strings.Repeat("搜索", 20) + "占2024年流量的一半。"
+	if !isDirectAnswer(answer, nil) {
+		t.Errorf("%q is not a direct answer", answer)
+	}
+}
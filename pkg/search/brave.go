@@ -0,0 +1,73 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Brave searches with the Brave Search API.
+type Brave struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewBrave returns a Brave searcher using the BRAVE_SEARCH_API_KEY
+// subscription token.
+func NewBrave() (*Brave, error) {
+	apiKey := os.Getenv("BRAVE_SEARCH_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("Brave search requires BRAVE_SEARCH_API_KEY")
+	}
+	return &Brave{apiKey: apiKey, baseURL: "https://api.search.brave.com/res/v1/web/search", client: newClient()}, nil
+}
+
+func (b *Brave) Name() string {
+	return "brave"
+}
+
+func (b *Brave) Search(ctx context.Context, query string) ([]Result, error) {
+	endpoint := b.baseURL + "?" + url.Values{"q": {query}, "count": {fmt.Sprintf("%d", maxResults)}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", b.apiKey)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Brave search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Brave search response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Brave search API error (status %d)", resp.StatusCode)
+	}
+
+	var payload struct {
+		Web struct {
+			Results []struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse Brave search response: %w", err)
+	}
+	results := make([]Result, 0, len(payload.Web.Results))
+	for _, r := range payload.Web.Results {
+		results = append(results, Result{Title: r.Title, URL: r.URL})
+	}
+	return results, nil
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Google searches with the Google Programmable Search Engine (Custom
+// Search JSON) API.
+type Google struct {
+	apiKey   string
+	engineID string
+	baseURL  string
+	client   *http.Client
+}
+
+// NewGoogle returns a Google searcher using GOOGLE_SEARCH_API_KEY and the
+// search engine in GOOGLE_SEARCH_ENGINE_ID, which should search the entire
+// web.
+func NewGoogle() (*Google, error) {
+	apiKey, engineID := os.Getenv("GOOGLE_SEARCH_API_KEY"), os.Getenv("GOOGLE_SEARCH_ENGINE_ID")
+	if apiKey == "" || engineID == "" {
+		return nil, fmt.Errorf("Google search requires GOOGLE_SEARCH_API_KEY and GOOGLE_SEARCH_ENGINE_ID")
+	}
+	return &Google{apiKey: apiKey, engineID: engineID, baseURL: "https://www.googleapis.com/customsearch/v1", client: newClient()}, nil
+}
+
+func (g *Google) Name() string {
+	return "google"
+}
+
+func (g *Google) Search(ctx context.Context, query string) ([]Result, error) {
+	endpoint := g.baseURL + "?" + url.Values{"key": {g.apiKey}, "cx": {g.engineID}, "q": {query}, "num": {fmt.Sprintf("%d", maxResults)}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		// The request URL carries the API key; keep it out of the error
+		if uerr, ok := err.(*url.Error); ok {
+			err = uerr.Err
+		}
+		return nil, fmt.Errorf("Google search request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Google search response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Google search API error (status %d)", resp.StatusCode)
+	}
+
+	var payload struct {
+		Items []struct {
+			Title string `json:"title"`
+			Link  string `json:"link"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse Google search response: %w", err)
+	}
+	results := make([]Result, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		results = append(results, Result{Title: item.Title, URL: item.Link})
+	}
+	return results, nil
+}
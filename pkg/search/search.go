@@ -0,0 +1,44 @@
+// Package search queries web search APIs, e.g. for pages that contain a
+// given sentence word for word.
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Result is one page a search returned.
+type Result struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// Searcher runs web searches through a search API.
+type Searcher interface {
+	Name() string
+	// Search returns the top results for query, which may quote a phrase
+	// to match it exactly.
+	Search(ctx context.Context, query string) ([]Result, error)
+}
+
+// New returns the searcher for a search API, configured from its
+// environment variables.
+func New(name string) (Searcher, error) {
+	switch name {
+	case "brave":
+		return NewBrave()
+	case "google":
+		return NewGoogle()
+	default:
+		return nil, fmt.Errorf("unsupported search API: %s (use brave or google)", name)
+	}
+}
+
+// maxResults is the number of results asked for per search.
+const maxResults = 10
+
+func newClient() *http.Client {
+	return &http.Client{Timeout: 30 * time.Second}
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBrave(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Subscription-Token") != "token" || r.URL.Query().Get("q") != `"exact phrase"` {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"web":{"results":[{"title":"A","url":"https://a.example/1"},{"title":"B","url":"https://b.example/2"}]}}`))
+	}))
+	defer ts.Close()
+
+	t.Setenv("BRAVE_SEARCH_API_KEY", "token")
+	s, err := New("brave")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.(*Brave).baseURL = ts.URL
+	results, err := s.Search(context.Background(), `"exact phrase"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[1] != (Result{Title: "B", URL: "https://b.example/2"}) {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestGoogle(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("key") != "key" || q.Get("cx") != "engine" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"items":[{"title":"A","link":"https://a.example/1"}]}`))
+	}))
+	defer ts.Close()
+
+	t.Setenv("GOOGLE_SEARCH_API_KEY", "key")
+	t.Setenv("GOOGLE_SEARCH_ENGINE_ID", "engine")
+	s, err := New("google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := s.(*Google)
+	g.baseURL = ts.URL
+	results, err := g.Search(context.Background(), "phrase")
+	if err != nil || len(results) != 1 || results[0].URL != "https://a.example/1" {
+		t.Errorf("results = %+v, err %v", results, err)
+	}
+
+	g.apiKey = "wrong"
+	if _, err := g.Search(context.Background(), "phrase"); err == nil {
+		t.Error("Search succeeded with a rejected key")
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("BRAVE_SEARCH_API_KEY", "")
+	if _, err := New("brave"); err == nil {
+		t.Error("Brave searcher created without an API key")
+	}
+	if _, err := New("bing"); err == nil {
+		t.Error("unknown search API accepted")
+	}
+}
@@ -0,0 +1,187 @@
+// Package searchconsole reads the pages and search queries of a Google
+// Search Console property through the Search Analytics API, for bulk audits
+// to take their URLs and page weights from without exporting CSVs by hand.
+// It signs in with a service account added as a user of the property.
+package searchconsole
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/googleapi"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const scope = "https://www.googleapis.com/auth/webmasters.readonly"
+
+// pageSize is the most rows the API returns per request.
+const pageSize = 25000
+
+// Client queries one Search Console property, e.g. "sc-domain:example.com"
+// or "https://www.example.com/".
+type Client struct {
+	site    string
+	account *googleapi.ServiceAccount
+	baseURL string
+}
+
+// New returns a client of the property site signing in with the service
+// account key at credentialsPath (GOOGLE_APPLICATION_CREDENTIALS if empty).
+func New(site, credentialsPath string) (*Client, error) {
+	if site == "" {
+		return nil, fmt.Errorf("Search Console property is required (e.g. sc-domain:example.com)")
+	}
+	account, err := googleapi.LoadServiceAccount(credentialsPath, scope)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{site: site, account: account, baseURL: "https://www.googleapis.com/webmasters/v3"}, nil
+}
+
+// Page is a page shown in Google Search over a period, with its totals.
+type Page struct {
+	URL         string  `json:"url"`
+	Clicks      float64 `json:"clicks"`
+	Impressions float64 `json:"impressions"`
+	CTR         float64 `json:"ctr"`
+	Position    float64 `json:"position"` // average
+	Queries     []Query `json:"queries,omitempty"`
+}
+
+// Query is a search query a page was shown for.
+type Query struct {
+	Query       string  `json:"query"`
+	Clicks      float64 `json:"clicks"`
+	Impressions float64 `json:"impressions"`
+	Position    float64 `json:"position"`
+}
+
+// row is a row of a Search Analytics response.
+type row struct {
+	Keys        []string `json:"keys"`
+	Clicks      float64  `json:"clicks"`
+	Impressions float64  `json:"impressions"`
+	CTR         float64  `json:"ctr"`
+	Position    float64  `json:"position"`
+}
+
+// Pages returns the pages of the property shown in Google Search in the
+// last days days, most clicked first; at most limit unless it is 0. The API
+// has no list of the indexed pages: pages with impressions are the indexed
+// pages that matter. Search Console data lags, so the period ends
+// yesterday.
+func (c *Client) Pages(ctx context.Context, days, limit int) ([]Page, error) {
+	rows, err := c.query(ctx, []string{"page"}, days, limit)
+	if err != nil {
+		return nil, err
+	}
+	pages := make([]Page, 0, len(rows))
+	for _, r := range rows {
+		if len(r.Keys) < 1 {
+			continue
+		}
+		pages = append(pages, Page{URL: r.Keys[0], Clicks: r.Clicks, Impressions: r.Impressions, CTR: r.CTR, Position: r.Position})
+	}
+	return pages, nil
+}
+
+// AddQueries fills in the top queries of each of pages over the last days
+// days, most clicked first, up to perPage for each page. Only the 25,000
+// most clicked page and query pairs are read.
+func (c *Client) AddQueries(ctx context.Context, pages []Page, days, perPage int) error {
+	rows, err := c.query(ctx, []string{"page", "query"}, days, pageSize)
+	if err != nil {
+		return err
+	}
+	index := make(map[string]int, len(pages))
+	for i, p := range pages {
+		index[p.URL] = i
+	}
+	for _, r := range rows {
+		if len(r.Keys) < 2 {
+			continue
+		}
+		i, ok := index[r.Keys[0]]
+		if !ok {
+			continue
+		}
+		pages[i].Queries = append(pages[i].Queries, Query{Query: r.Keys[1], Clicks: r.Clicks, Impressions: r.Impressions, Position: r.Position})
+	}
+	for i := range pages {
+		queries := pages[i].Queries
+		sort.SliceStable(queries, func(a, b int) bool {
+			if queries[a].Clicks != queries[b].Clicks {
+				return queries[a].Clicks > queries[b].Clicks
+			}
+			return queries[a].Impressions > queries[b].Impressions
+		})
+		if perPage > 0 && len(queries) > perPage {
+			pages[i].Queries = queries[:perPage]
+		}
+	}
+	return nil
+}
+
+// query runs a Search Analytics query over the last days days, reading
+// every page of rows up to limit (0 for all).
+func (c *Client) query(ctx context.Context, dimensions []string, days, limit int) ([]row, error) {
+	if days < 1 {
+		return nil, fmt.Errorf("the Search Console period must be at least 1 day")
+	}
+	end := time.Now().UTC().AddDate(0, 0, -1)
+	start := end.AddDate(0, 0, -(days - 1))
+	endpoint := fmt.Sprintf("%s/sites/%s/searchAnalytics/query", c.baseURL, url.PathEscape(c.site))
+
+	var rows []row
+	for {
+		size := pageSize
+		if limit > 0 && limit-len(rows) < size {
+			size = limit - len(rows)
+		}
+		payload, err := json.Marshal(map[string]any{
+			"startDate":  start.Format("2006-01-02"),
+			"endDate":    end.Format("2006-01-02"),
+			"dimensions": dimensions,
+			"type":       "web",
+			"rowLimit":   size,
+			"startRow":   len(rows),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode query: %w", err)
+		}
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.account.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query Search Console: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Search Console response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Search Console API error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		var result struct {
+			Rows []row `json:"rows"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse Search Console response: %w", err)
+		}
+		rows = append(rows, result.Rows...)
+		if len(result.Rows) < size || (limit > 0 && len(rows) >= limit) {
+			return rows, nil
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package searchconsole
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPagesAndQueries(t *testing.T) {
+	var requests []map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"test-token","expires_in":3600}`))
+	})
+	mux.HandleFunc("/sites/sc-domain:example.com/searchAnalytics/query", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("authorization = %q", r.Header.Get("Authorization"))
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		if dims := body["dimensions"].([]any); len(dims) == 2 {
+			w.Write([]byte(`{"rows":[
+{"keys":["https://example.com/","geo checker"],"clicks":50,"impressions":400,"position":2.1},
+{"keys":["https://example.com/","geo checker free"],"clicks":80,"impressions":300,"position":1.5},
+{"keys":["https://example.com/other","unrelated"],"clicks":5,"impressions":20,"position":9}]}`))
+			return
+		}
+		rows := []row{
+			{Keys: []string{"https://example.com/"}, Clicks: 130, Impressions: 700, CTR: 0.19, Position: 1.8},
+			{Keys: []string{"https://example.com/pricing"}, Clicks: 40, Impressions: 900, CTR: 0.04, Position: 6.2},
+			{Keys: []string{"https://example.com/blog/old"}, Impressions: 35, Position: 41},
+			{Keys: []string{"https://example.com/blog/older"}, Impressions: 3, Position: 70},
+		}
+		start, limit := int(body["startRow"].(float64)), int(body["rowLimit"].(float64))
+		rows = rows[start:min(start+limit, len(rows))]
+		json.NewEncoder(w).Encode(map[string]any{"rows": rows})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := New("sc-domain:example.com", writeKey(t, server.URL+"/token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL
+
+	pages, err := client.Pages(context.Background(), 28, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 3 || pages[0].URL != "https://example.com/" || pages[1].Impressions != 900 || pages[2].URL != "https://example.com/blog/old" {
+		t.Fatalf("pages = %+v", pages)
+	}
+	if len(requests) != 1 || requests[0]["rowLimit"].(float64) != 3 || requests[0]["type"] != "web" {
+		t.Errorf("requests = %v", requests)
+	}
+
+	if err := client.AddQueries(context.Background(), pages, 28, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(pages[0].Queries) != 1 || pages[0].Queries[0].Query != "geo checker free" || len(pages[1].Queries) != 0 {
+		t.Errorf("queries = %+v", pages)
+	}
+}
+
+func TestPagesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Write([]byte(`{"access_token":"test-token","expires_in":3600}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"message":"User does not have sufficient permission for site"}}`))
+	}))
+	defer server.Close()
+
+	client, err := New("https://example.com/", writeKey(t, server.URL+"/token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL
+	if _, err := client.Pages(context.Background(), 28, 0); err == nil {
+		t.Error("permission error not reported")
+	}
+}
+
+// writeKey writes a service account key file using tokenURI and returns
+// its path.
+func writeKey(t *testing.T, tokenURI string) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	data, _ := json.Marshal(map[string]string{
+		"client_email": "audit@example.iam.gserviceaccount.com",
+		"private_key":  string(pemKey),
+		"token_uri":    tokenURI,
+	})
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
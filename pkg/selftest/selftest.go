@@ -0,0 +1,159 @@
+// Package selftest checks extraction and local scoring against golden
+// outputs for HTML fixtures embedded in the binary. The same fixtures back
+// the package's Go tests, which regenerate the golden files with -update.
+package selftest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/textdiff"
+	"geo-checker/internal/webpage"
+	"geo-checker/pkg/scorer"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// FixtureDir is where fixtures and golden files live, relative to this
+// package.
+const FixtureDir = "fixtures"
+
+// fixtureSource is the URL fixtures are parsed as, so relative links resolve
+// the same way everywhere.
+const fixtureSource = "https://fixtures.geo-checker.test/page"
+
+// Snapshot is the stable output recorded for a fixture.
+type Snapshot struct {
+	Title     string            `json:"title"`
+	Blocks    []string          `json:"blocks"` // extracted content, split at blank lines
+	Headings  []webpage.Heading `json:"headings"`
+	Canonical string            `json:"canonical,omitempty"`
+	Overall   int               `json:"overall_score"`
+	Pillars   map[string]int    `json:"pillars"`
+	Findings  []string          `json:"findings"`
+}
+
+// Result is the outcome for one fixture.
+type Result struct {
+	Fixture string `json:"fixture"`
+	OK      bool   `json:"ok"`
+	Diff    string `json:"diff,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Take extracts and scores html.
+func Take(html string) (*Snapshot, error) {
+	page, err := webpage.New().ParseHTML(html, fixtureSource)
+	if err != nil {
+		return nil, err
+	}
+	score := scorer.NewLocalScorer().AnalyzeContent(page.Content, page)
+
+	snap := &Snapshot{
+		Title:     page.Title,
+		Blocks:    strings.Split(page.Content, "\n\n"),
+		Headings:  page.Headings,
+		Canonical: page.Crawl.Canonical,
+		Overall:   score.Overall,
+		Pillars:   make(map[string]int),
+	}
+	for _, name := range []string{"content_structure", "semantic_clarity", "context_richness", "authority_signals", "accessibility", "structured_data"} {
+		detail, _ := score.Pillar(name)
+		snap.Pillars[name] = detail.Score
+	}
+	for _, f := range score.Findings {
+		snap.Findings = append(snap.Findings, f.ID)
+	}
+	sort.Strings(snap.Findings)
+	return snap, nil
+}
+
+// Marshal renders a snapshot the way golden files store it.
+func (s *Snapshot) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Fixtures lists the embedded fixture names, e.g. "overview.html".
+func Fixtures() ([]string, error) {
+	return fixtureNames(fixtures)
+}
+
+func fixtureNames(fsys fs.FS) ([]string, error) {
+	names, err := fs.Glob(fsys, path.Join(FixtureDir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		names[i] = path.Base(name)
+	}
+	return names, nil
+}
+
+// GoldenName is the golden file recorded for a fixture.
+func GoldenName(fixture string) string {
+	return strings.TrimSuffix(fixture, ".html") + ".golden.json"
+}
+
+// Run checks every embedded fixture against its golden file.
+func Run() ([]Result, error) {
+	return run(fixtures)
+}
+
+func run(fsys fs.FS) ([]Result, error) {
+	names, err := fixtureNames(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no fixtures found")
+	}
+
+	var results []Result
+	for _, name := range names {
+		results = append(results, check(fsys, name))
+	}
+	return results, nil
+}
+
+func check(fsys fs.FS, name string) Result {
+	result := Result{Fixture: name}
+
+	html, err := fs.ReadFile(fsys, path.Join(FixtureDir, name))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	golden, err := fs.ReadFile(fsys, path.Join(FixtureDir, GoldenName(name)))
+	if err != nil {
+		result.Error = fmt.Sprintf("missing golden file: %v", err)
+		return result
+	}
+
+	snap, err := Take(string(html))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	got, err := snap.Marshal()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if string(got) == string(golden) {
+		result.OK = true
+		return result
+	}
+	lines := textdiff.Lines(strings.Split(string(golden), "\n"), strings.Split(string(got), "\n"))
+	result.Diff = textdiff.Unified(lines, 1)
+	return result
+}
@@ -0,0 +1,47 @@
+package selftest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestGolden(t *testing.T) {
+	if *update {
+		names, err := Fixtures()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range names {
+			html, err := os.ReadFile(filepath.Join(FixtureDir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			snap, err := Take(string(html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := snap.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(FixtureDir, GoldenName(name)), data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Read from disk so freshly updated golden files are checked too
+	results, err := run(os.DirFS("."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if !r.OK {
+			t.Errorf("%s: %s%s", r.Fixture, r.Error, r.Diff)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKey describes a client allowed to call the service. Zero limits fall
+// back to the defaults passed to NewAuthenticator.
+type APIKey struct {
+	Key           string `json:"key"`
+	Name          string `json:"name"`
+	RatePerMinute int    `json:"rate_per_minute"`
+	DailyQuota    int    `json:"daily_quota"`
+}
+
+// Authenticator enforces API keys, per-key rate limits (token bucket) and
+// per-key daily quotas for both the HTTP and gRPC APIs. Rate limits count
+// requests; quotas count the URLs analyzed, so one bulk request of 50 URLs
+// uses 50 units (content analyses use one).
+type Authenticator struct {
+	keys  []*APIKey
+	mu    sync.Mutex
+	state map[string]*keyState
+	now   func() time.Time
+}
+
+type keyState struct {
+	tokens     float64
+	lastRefill time.Time
+	day        string
+	used       int
+}
+
+// LoadAPIKeys reads keys from a JSON file (an array of APIKey) and/or the
+// comma-separated GEO_API_KEYS environment variable.
+func LoadAPIKeys(path string) ([]*APIKey, error) {
+	var keys []*APIKey
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read API keys file: %w", err)
+		}
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return nil, fmt.Errorf("failed to parse API keys file: %w", err)
+		}
+	}
+
+	for i, k := range strings.Split(os.Getenv("GEO_API_KEYS"), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, &APIKey{Key: k, Name: fmt.Sprintf("env-%d", i+1)})
+		}
+	}
+
+	for _, k := range keys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("API key entry %q has an empty key", k.Name)
+		}
+	}
+	return keys, nil
+}
+
+func NewAuthenticator(keys []*APIKey, defaultRate, defaultQuota int) *Authenticator {
+	for _, k := range keys {
+		if k.RatePerMinute == 0 {
+			k.RatePerMinute = defaultRate
+		}
+		if k.DailyQuota == 0 {
+			k.DailyQuota = defaultQuota
+		}
+	}
+	return &Authenticator{
+		keys:  keys,
+		state: make(map[string]*keyState),
+		now:   time.Now,
+	}
+}
+
+// Enabled reports whether any keys are configured. Without keys the service
+// is open, which is only appropriate on localhost.
+func (a *Authenticator) Enabled() bool {
+	return a != nil && len(a.keys) > 0
+}
+
+type authError struct {
+	status     int
+	message    string
+	retryAfter time.Duration
+}
+
+func (e *authError) Error() string {
+	return e.message
+}
+
+// check validates the presented key and consumes one request from its rate
+// limit and units from its quota.
+func (a *Authenticator) check(presented string, units int) (*APIKey, *authError) {
+	key, err := a.authenticate(presented)
+	if err != nil {
+		return nil, err
+	}
+	return key, a.consume(key, units)
+}
+
+// authenticate returns the key presented, consuming nothing.
+func (a *Authenticator) authenticate(presented string) (*APIKey, *authError) {
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(k.Key), []byte(presented)) == 1 {
+			return k, nil
+		}
+	}
+	return nil, &authError{status: http.StatusUnauthorized, message: "missing or invalid API key"}
+}
+
+// consume takes one request from the key's rate limit and units from its
+// quota. Requests whose URLs are only known once their body is read take no
+// units here; their handlers charge them with chargeURLs.
+func (a *Authenticator) consume(key *APIKey, units int) *authError {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	st := a.stateFor(key, now)
+	if err := checkQuota(st, key, now, units); err != nil {
+		return err
+	}
+
+	if key.RatePerMinute > 0 {
+		rate := float64(key.RatePerMinute) / 60
+		st.tokens = math.Min(float64(key.RatePerMinute), st.tokens+now.Sub(st.lastRefill).Seconds()*rate)
+		st.lastRefill = now
+		if st.tokens < 1 {
+			wait := time.Duration((1 - st.tokens) / rate * float64(time.Second))
+			return &authError{
+				status:     http.StatusTooManyRequests,
+				message:    fmt.Sprintf("rate limit of %d requests per minute exceeded", key.RatePerMinute),
+				retryAfter: wait,
+			}
+		}
+		st.tokens--
+	}
+
+	st.used += units
+	return nil
+}
+
+// chargeURLs takes n units from the quota of the key ctx was authenticated
+// with, refusing the request when fewer than n remain.
+func (a *Authenticator) chargeURLs(ctx context.Context, n int) *authError {
+	if !a.Enabled() {
+		return nil
+	}
+	var key *APIKey
+	for _, k := range a.keys {
+		if k.Key == caller(ctx) {
+			key = k
+		}
+	}
+	if key == nil {
+		return &authError{status: http.StatusUnauthorized, message: "missing or invalid API key"}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	st := a.stateFor(key, now)
+	if err := checkQuota(st, key, now, n); err != nil {
+		return err
+	}
+	st.used += n
+	return nil
+}
+
+// stateFor returns the key's limit state; a.mu must be held.
+func (a *Authenticator) stateFor(key *APIKey, now time.Time) *keyState {
+	st, ok := a.state[key.Key]
+	if !ok {
+		st = &keyState{tokens: float64(key.RatePerMinute), lastRefill: now}
+		a.state[key.Key] = st
+	}
+	return st
+}
+
+// checkQuota refuses units more than the key has left today.
+func checkQuota(st *keyState, key *APIKey, now time.Time, units int) *authError {
+	if key.DailyQuota <= 0 {
+		return nil
+	}
+	day := now.UTC().Format("2006-01-02")
+	if st.day != day {
+		st.day = day
+		st.used = 0
+	}
+	if remaining := key.DailyQuota - st.used; remaining < units {
+		midnight := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		message := fmt.Sprintf("daily quota of %d URLs exhausted", key.DailyQuota)
+		if remaining > 0 {
+			message = fmt.Sprintf("daily quota of %d URLs: %d remaining, %d requested", key.DailyQuota, remaining, units)
+		}
+		return &authError{
+			status:     http.StatusTooManyRequests,
+			message:    message,
+			retryAfter: midnight.Sub(now),
+		}
+	}
+	return nil
+}
+
+func bearerToken(header string) string {
+	if strings.HasPrefix(strings.ToLower(header), "bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+type callerKey struct{}
+
+// caller returns the API key a request was made with; empty when the
+// service is open.
+func caller(ctx context.Context) string {
+	key, _ := ctx.Value(callerKey{}).(string)
+	return key
+}
+
+// isMeteredByURL reports whether r lists its URLs in the body, so that its
+// handler rather than the middleware charges the quota.
+func isMeteredByURL(r *http.Request) bool {
+	return r.Method == http.MethodPost && (r.URL.Path == "/v1/bulk" || r.URL.Path == "/v1/jobs")
+}
+
+// isJobPolling reports whether r only reads jobs, as the dashboard does
+// every few seconds.
+func isJobPolling(r *http.Request) bool {
+	return r.Method == http.MethodGet && (r.URL.Path == "/v1/jobs" || strings.HasPrefix(r.URL.Path, "/v1/jobs/"))
+}
+
+// Middleware protects every HTTP route except /healthz and the dashboard's
+// static files; the dashboard itself sends the key with its API calls.
+// Reading jobs needs a key but counts against neither its rate limit nor
+// its quota, so a dashboard left open does not use them up.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	if !a.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" || r.URL.Path == "/" || strings.HasPrefix(r.URL.Path, "/ui/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		presented := r.Header.Get("X-API-Key")
+		if presented == "" {
+			presented = bearerToken(r.Header.Get("Authorization"))
+		}
+
+		key, err := a.authenticate(presented)
+		if err == nil && !isJobPolling(r) {
+			units := 1
+			if isMeteredByURL(r) {
+				units = 0
+			}
+			err = a.consume(key, units)
+		}
+		if err != nil {
+			writeAuthError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerKey{}, key.Key)))
+	})
+}
+
+func writeAuthError(w http.ResponseWriter, err *authError) {
+	if err.retryAfter > 0 {
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", int(math.Ceil(err.retryAfter.Seconds()))))
+	}
+	writeError(w, err.status, err.message)
+}
+
+// chargeURLs answers the request with an error and returns false when the
+// caller's quota has fewer than n units left.
+func (s *Server) chargeURLs(w http.ResponseWriter, r *http.Request, n int) bool {
+	if err := s.auth.chargeURLs(r.Context(), n); err != nil {
+		writeAuthError(w, err)
+		return false
+	}
+	return true
+}
+
+// checkGRPC authenticates a call, consuming units from the key's quota, and
+// returns ctx carrying the caller's key.
+func (a *Authenticator) checkGRPC(ctx context.Context, units int) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	presented := ""
+	if v := md.Get("x-api-key"); len(v) > 0 {
+		presented = v[0]
+	} else if v := md.Get("authorization"); len(v) > 0 {
+		presented = bearerToken(v[0])
+	}
+
+	key, err := a.check(presented, units)
+	if err != nil {
+		return nil, grpcAuthError(err)
+	}
+	return context.WithValue(ctx, callerKey{}, key.Key), nil
+}
+
+func grpcAuthError(err *authError) error {
+	code := codes.Unauthenticated
+	if err.status == http.StatusTooManyRequests {
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, err.message)
+}
+
+// callerStream hands stream handlers the context carrying the caller's key.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// GRPCOptions returns interceptors enforcing the same keys and limits on gRPC.
+func (a *Authenticator) GRPCOptions() []grpc.ServerOption {
+	if !a.Enabled() {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := a.checkGRPC(ctx, 1)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		// StreamBulk charges its URLs once it has read the request
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := a.checkGRPC(ss.Context(), 0)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &callerStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"geo-checker/pkg/config"
+)
+
+func TestAuthenticator_Check(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	auth := NewAuthenticator([]*APIKey{
+		{Key: "limited", RatePerMinute: 2},
+		{Key: "quota", DailyQuota: 1},
+	}, 0, 0)
+	auth.now = func() time.Time { return now }
+
+	tests := []struct {
+		name       string
+		key        string
+		advance    time.Duration
+		wantStatus int
+	}{
+		{name: "unknown key", key: "nope", wantStatus: http.StatusUnauthorized},
+		{name: "first request", key: "limited", wantStatus: 0},
+		{name: "second request", key: "limited", wantStatus: 0},
+		{name: "burst exhausted", key: "limited", wantStatus: http.StatusTooManyRequests},
+		{name: "refilled after 30s", key: "limited", advance: 30 * time.Second, wantStatus: 0},
+		{name: "quota available", key: "quota", wantStatus: 0},
+		{name: "quota exhausted", key: "quota", wantStatus: http.StatusTooManyRequests},
+		{name: "quota resets next day", key: "quota", advance: 24 * time.Hour, wantStatus: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			_, err := auth.check(tt.key, 1)
+			got := 0
+			if err != nil {
+				got = err.status
+			}
+			if got != tt.wantStatus {
+				t.Errorf("check(%q) status = %d, want %d", tt.key, got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestQuotaChargesPerURL(t *testing.T) {
+	s := New(&config.Config{Mode: "local"})
+	s.UseAuthenticator(NewAuthenticator([]*APIKey{{Key: "team-a", DailyQuota: 3}}, 0, 0))
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/v1/jobs", strings.NewReader(body))
+		req.Header.Set("X-API-Key", "team-a")
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post(`{"urls":["https://a.example","https://b.example","https://c.example","https://d.example"]}`); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("4 URLs on a quota of 3: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := post(`{"urls":["https://a.example","https://b.example"]}`); rec.Code != http.StatusAccepted {
+		t.Fatalf("2 URLs on a quota of 3: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := post(`{"urls":["https://a.example","https://b.example"]}`); rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("2 URLs with 1 left: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := post(`{"url":"https://a.example"}`); rec.Code != http.StatusAccepted {
+		t.Fatalf("1 URL with 1 left: %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"embed"
+	"geo-checker/pkg/formatter"
+	"io/fs"
+	"net/http"
+	"sort"
+)
+
+//go:embed ui
+var uiFiles embed.FS
+
+// registerDashboard serves the embedded web UI at / and its assets under /ui/.
+func (s *Server) registerDashboard() {
+	assets, _ := fs.Sub(uiFiles, "ui")
+	s.mux.Handle("GET /ui/", http.StripPrefix("/ui/", http.FileServerFS(assets)))
+	s.mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFileFS(w, r, assets, "index.html")
+	})
+}
+
+// handleListJobs lists the jobs submitted with the caller's API key.
+func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.jobs.list(caller(r.Context())))
+}
+
+// handleJobReport renders a job's results with the CLI formatters so the
+// downloaded report matches `bulk --output`.
+func (s *Server) handleJobReport(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.jobs.snapshotFor(r.PathValue("id"), caller(r.Context()))
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	contentType, ext := "application/json", "json"
+	switch format {
+	case "", "json":
+		format = "json"
+	case "markdown":
+		contentType, ext = "text/markdown; charset=utf-8", "md"
+	case "text":
+		contentType, ext = "text/plain; charset=utf-8", "txt"
+	default:
+		writeError(w, http.StatusBadRequest, "format must be json, markdown or text")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+job.ID+"."+ext+`"`)
+	w.Write([]byte(formatter.New(format).FormatBulkResults(job.Results)))
+}
+
+// list returns snapshots of the retained jobs owner submitted, newest
+// first.
+func (js *jobStore) list(owner string) []*Job {
+	js.mu.Lock()
+	ids := make([]string, 0, len(js.jobs))
+	for id, job := range js.jobs {
+		if job.owner == owner {
+			ids = append(ids, id)
+		}
+	}
+	js.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(ids))
+	for _, id := range ids {
+		if job, ok := js.snapshot(id); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	return jobs
+}
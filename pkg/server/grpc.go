@@ -0,0 +1,348 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/analyzer"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// The gRPC service is described at runtime from the same schema as
+// api/geochecker/v1/geochecker.proto, which avoids a protoc code-generation
+// step in the build. Messages are handled as dynamicpb messages and are
+// wire-compatible with clients generated from the published proto file.
+
+const grpcServiceName = "geochecker.v1.GeoChecker"
+
+type grpcSchema struct {
+	analyzeURLRequest     protoreflect.MessageDescriptor
+	analyzeContentRequest protoreflect.MessageDescriptor
+	streamBulkRequest     protoreflect.MessageDescriptor
+	factorScore           protoreflect.MessageDescriptor
+	analysisResult        protoreflect.MessageDescriptor
+	bulkItem              protoreflect.MessageDescriptor
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, repeated bool, typeName string) *descriptorpb.FieldDescriptorProto {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(jsonName(name)),
+		Number:   proto.Int32(number),
+		Type:     typ.Enum(),
+		Label:    label.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func jsonName(name string) string {
+	out := []byte{}
+	upper := false
+	for i := 0; i < len(name); i++ {
+		if name[i] == '_' {
+			upper = true
+			continue
+		}
+		c := name[i]
+		if upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		out = append(out, c)
+	}
+	return string(out)
+}
+
+func buildGRPCSchema() (*grpcSchema, error) {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	i32 := descriptorpb.FieldDescriptorProto_TYPE_INT32
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+
+	message := func(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+	}
+	method := func(name, in, out string, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(name),
+			InputType:       proto.String(".geochecker.v1." + in),
+			OutputType:      proto.String(".geochecker.v1." + out),
+			ServerStreaming: proto.Bool(serverStreaming),
+		}
+	}
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("geochecker/v1/geochecker.proto"),
+		Package: proto.String("geochecker.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("AnalyzeUrlRequest", field("url", 1, str, false, "")),
+			message("AnalyzeContentRequest", field("title", 1, str, false, ""), field("content", 2, str, false, "")),
+			message("StreamBulkRequest", field("urls", 1, str, true, ""), field("concurrency", 2, i32, false, "")),
+			message("FactorScore", field("name", 1, str, false, ""), field("score", 2, i32, false, "")),
+			message("AnalysisResult",
+				field("url", 1, str, false, ""),
+				field("title", 2, str, false, ""),
+				field("score", 3, i32, false, ""),
+				field("mode", 4, str, false, ""),
+				field("suggestions", 5, str, true, ""),
+				field("factors", 6, msg, true, ".geochecker.v1.FactorScore"),
+				field("analysis", 7, str, false, ""),
+				field("tokens_used", 8, i32, false, ""),
+				field("result_json", 9, str, false, ""),
+			),
+			message("BulkItem",
+				field("url", 1, str, false, ""),
+				field("result", 2, msg, false, ".geochecker.v1.AnalysisResult"),
+				field("error", 3, str, false, ""),
+			),
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("GeoChecker"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("AnalyzeUrl", "AnalyzeUrlRequest", "AnalysisResult", false),
+				method("AnalyzeContent", "AnalyzeContentRequest", "AnalysisResult", false),
+				method("StreamBulk", "StreamBulkRequest", "BulkItem", true),
+			},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gRPC schema: %w", err)
+	}
+
+	msgs := fd.Messages()
+	return &grpcSchema{
+		analyzeURLRequest:     msgs.ByName("AnalyzeUrlRequest"),
+		analyzeContentRequest: msgs.ByName("AnalyzeContentRequest"),
+		streamBulkRequest:     msgs.ByName("StreamBulkRequest"),
+		factorScore:           msgs.ByName("FactorScore"),
+		analysisResult:        msgs.ByName("AnalysisResult"),
+		bulkItem:              msgs.ByName("BulkItem"),
+	}, nil
+}
+
+// toProto converts an analyzer result to an AnalysisResult message.
+func (g *grpcSchema) toProto(result *analyzer.Result) *dynamicpb.Message {
+	m := dynamicpb.NewMessage(g.analysisResult)
+	fields := g.analysisResult.Fields()
+
+	m.Set(fields.ByName("url"), protoreflect.ValueOfString(result.URL))
+	m.Set(fields.ByName("title"), protoreflect.ValueOfString(result.Title))
+	m.Set(fields.ByName("score"), protoreflect.ValueOfInt32(int32(result.Score)))
+	m.Set(fields.ByName("mode"), protoreflect.ValueOfString(result.Mode))
+	m.Set(fields.ByName("analysis"), protoreflect.ValueOfString(result.Analysis))
+	m.Set(fields.ByName("tokens_used"), protoreflect.ValueOfInt32(int32(result.TokensUsed)))
+
+	suggestions := m.Mutable(fields.ByName("suggestions")).List()
+	for _, s := range result.Suggestions {
+		suggestions.Append(protoreflect.ValueOfString(s))
+	}
+
+	if ls := result.LocalScore; ls != nil {
+		factors := m.Mutable(fields.ByName("factors")).List()
+		for _, key := range ls.PillarKeys() {
+			detail, _ := ls.Pillar(key)
+			fm := dynamicpb.NewMessage(g.factorScore)
+			fm.Set(g.factorScore.Fields().ByName("name"), protoreflect.ValueOfString(key))
+			fm.Set(g.factorScore.Fields().ByName("score"), protoreflect.ValueOfInt32(int32(detail.Score)))
+			factors.Append(protoreflect.ValueOfMessage(fm))
+		}
+	}
+
+	if data, err := json.Marshal(result); err == nil {
+		m.Set(fields.ByName("result_json"), protoreflect.ValueOfString(string(data)))
+	}
+	return m
+}
+
+func (g *grpcSchema) bulkItemToProto(item *bulk.BulkResult) *dynamicpb.Message {
+	m := dynamicpb.NewMessage(g.bulkItem)
+	fields := g.bulkItem.Fields()
+	m.Set(fields.ByName("url"), protoreflect.ValueOfString(item.URL))
+	m.Set(fields.ByName("error"), protoreflect.ValueOfString(item.ErrorMessage()))
+	if item.Result != nil {
+		m.Set(fields.ByName("result"), protoreflect.ValueOfMessage(g.toProto(item.Result)))
+	}
+	return m
+}
+
+func stringValue(m *dynamicpb.Message, name string) string {
+	return m.Get(m.Descriptor().Fields().ByName(protoreflect.Name(name))).String()
+}
+
+// grpcService adapts Server to the gRPC service description.
+type grpcService struct {
+	server *Server
+	schema *grpcSchema
+}
+
+func (s *grpcService) serviceDesc() *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: grpcServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "AnalyzeUrl", Handler: s.analyzeURL},
+			{MethodName: "AnalyzeContent", Handler: s.analyzeContent},
+		},
+		Streams: []grpc.StreamDesc{
+			{StreamName: "StreamBulk", Handler: s.streamBulk, ServerStreams: true},
+		},
+		Metadata: "geochecker/v1/geochecker.proto",
+	}
+}
+
+func (s *grpcService) analyzeURL(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := dynamicpb.NewMessage(s.schema.analyzeURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		url := stringValue(req.(*dynamicpb.Message), "url")
+		if url == "" {
+			return nil, status.Error(codes.InvalidArgument, "url is required")
+		}
+		if err := s.server.checkTargets(ctx, []string{url}); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		result, err := s.server.analyzer.AnalyzeURL(ctx, url)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return s.schema.toProto(result), nil
+	}
+
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: s, FullMethod: "/" + grpcServiceName + "/AnalyzeUrl"}
+	return interceptor(ctx, in, info, handler)
+}
+
+func (s *grpcService) analyzeContent(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := dynamicpb.NewMessage(s.schema.analyzeContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		m := req.(*dynamicpb.Message)
+		content := stringValue(m, "content")
+		if content == "" {
+			return nil, status.Error(codes.InvalidArgument, "content is required")
+		}
+		result, err := s.server.analyzer.AnalyzeContent(ctx, content, stringValue(m, "title"))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return s.schema.toProto(result), nil
+	}
+
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: s, FullMethod: "/" + grpcServiceName + "/AnalyzeContent"}
+	return interceptor(ctx, in, info, handler)
+}
+
+func (s *grpcService) streamBulk(_ any, stream grpc.ServerStream) error {
+	in := dynamicpb.NewMessage(s.schema.streamBulkRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+
+	fields := s.schema.streamBulkRequest.Fields()
+	list := in.Get(fields.ByName("urls")).List()
+	urls := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		urls = append(urls, list.Get(i).String())
+	}
+	if len(urls) == 0 {
+		return status.Error(codes.InvalidArgument, "urls is required")
+	}
+	if err := s.server.checkTargets(stream.Context(), urls); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.server.auth.chargeURLs(stream.Context(), len(urls)); err != nil {
+		return grpcAuthError(err)
+	}
+	concurrency := int(in.Get(fields.ByName("concurrency")).Int())
+
+	for item := range s.server.streamBulk(stream.Context(), urls, concurrency) {
+		if err := stream.SendMsg(s.schema.bulkItemToProto(item)); err != nil {
+			return err
+		}
+	}
+	return stream.Context().Err()
+}
+
+// NewGRPCServer returns a gRPC server with the GeoChecker service registered.
+func (s *Server) NewGRPCServer(opts ...grpc.ServerOption) (*grpc.Server, error) {
+	schema, err := buildGRPCSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	gs := grpc.NewServer(opts...)
+	svc := &grpcService{server: s, schema: schema}
+	gs.RegisterService(svc.serviceDesc(), svc)
+	return gs, nil
+}
+
+// ServeGRPC runs the gRPC API until ctx is cancelled.
+func (s *Server) ServeGRPC(ctx context.Context, addr string) error {
+	gs, err := s.NewGRPCServer(s.auth.GRPCOptions()...)
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- gs.Serve(lis)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("gRPC server failed: %w", err)
+	case <-ctx.Done():
+		stopped := make(chan struct{})
+		go func() {
+			gs.GracefulStop()
+			close(stopped)
+		}()
+		if s.ShutdownTimeout > 0 {
+			select {
+			case <-stopped:
+			case <-time.After(s.ShutdownTimeout):
+				gs.Stop()
+			}
+		} else {
+			<-stopped
+		}
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+
+	"geo-checker/pkg/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	protoComment = regexp.MustCompile(`//.*`)
+	protoMessage = regexp.MustCompile(`(?s)message (\w+) \{(.*?)\n\}`)
+	protoField   = regexp.MustCompile(`(?m)^\s*(repeated )?(\w+) (\w+) = (\d+);`)
+	protoRPC     = regexp.MustCompile(`rpc (\w+)\((\w+)\) returns \((stream )?(\w+)\);`)
+)
+
+// The runtime schema must describe exactly what the published proto file
+// does, or clients generated from it break.
+func TestGRPCSchemaMatchesProtoFile(t *testing.T) {
+	data, err := os.ReadFile("../../api/geochecker/v1/geochecker.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := protoComment.ReplaceAllString(string(data), "")
+
+	schema, err := buildGRPCSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := schema.analysisResult.ParentFile()
+
+	messages := protoMessage.FindAllStringSubmatch(src, -1)
+	if len(messages) != file.Messages().Len() {
+		t.Errorf("the proto file has %d messages, the schema %d", len(messages), file.Messages().Len())
+	}
+	for _, m := range messages {
+		desc := file.Messages().ByName(protoreflect.Name(m[1]))
+		if desc == nil {
+			t.Errorf("message %s is missing from the schema", m[1])
+			continue
+		}
+		fields := protoField.FindAllStringSubmatch(m[2], -1)
+		if len(fields) != desc.Fields().Len() {
+			t.Errorf("%s: the proto file has %d fields, the schema %d", m[1], len(fields), desc.Fields().Len())
+		}
+		for _, f := range fields {
+			fd := desc.Fields().ByName(protoreflect.Name(f[3]))
+			if fd == nil {
+				t.Errorf("%s.%s is missing from the schema", m[1], f[3])
+				continue
+			}
+			typ := fd.Kind().String()
+			if fd.Kind() == protoreflect.MessageKind {
+				typ = string(fd.Message().Name())
+			}
+			got := fmt.Sprintf("%v %s %d", fd.IsList(), typ, fd.Number())
+			if want := fmt.Sprintf("%v %s %s", f[1] != "", f[2], f[4]); got != want {
+				t.Errorf("%s.%s: the schema has (repeated, type, number) %s, the proto file %s", m[1], f[3], got, want)
+			}
+		}
+	}
+
+	service := file.Services().ByName("GeoChecker")
+	rpcs := protoRPC.FindAllStringSubmatch(src, -1)
+	if service == nil || len(rpcs) != service.Methods().Len() {
+		t.Fatalf("the proto file has %d RPCs, the schema service %v", len(rpcs), service)
+	}
+	for _, r := range rpcs {
+		md := service.Methods().ByName(protoreflect.Name(r[1]))
+		if md == nil {
+			t.Errorf("RPC %s is missing from the schema", r[1])
+			continue
+		}
+		if string(md.Input().Name()) != r[2] || string(md.Output().Name()) != r[4] || md.IsStreamingServer() != (r[3] != "") {
+			t.Errorf("RPC %s: the schema has (%s) returns (%s), streaming %v", r[1], md.Input().Name(), md.Output().Name(), md.IsStreamingServer())
+		}
+	}
+}
+
+// grpcClient serves s over an in-memory listener and returns a connection
+// to it with the schema to build messages from.
+func grpcClient(t *testing.T, s *Server) (*grpc.ClientConn, *grpcSchema) {
+	t.Helper()
+	gs, err := s.NewGRPCServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := bufconn.Listen(1 << 20)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	schema, err := buildGRPCSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn, schema
+}
+
+func TestGRPCRoundTrips(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<html><head><title>Page %s</title></head><body><main><h1>Widgets</h1><p>Widgets are small mechanical parts.</p></main></body></html>`, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	conn, schema := grpcClient(t, New(&config.Config{Mode: "local", Timeout: 10, AllowedHosts: []string{"127.0.0.1"}}))
+	ctx := context.Background()
+	method := func(name string) string { return "/" + grpcServiceName + "/" + name }
+	set := func(m *dynamicpb.Message, field string, v protoreflect.Value) {
+		m.Set(m.Descriptor().Fields().ByName(protoreflect.Name(field)), v)
+	}
+
+	t.Run("AnalyzeUrl", func(t *testing.T) {
+		in := dynamicpb.NewMessage(schema.analyzeURLRequest)
+		set(in, "url", protoreflect.ValueOfString(ts.URL+"/one"))
+		out := dynamicpb.NewMessage(schema.analysisResult)
+		if err := conn.Invoke(ctx, method("AnalyzeUrl"), in, out); err != nil {
+			t.Fatal(err)
+		}
+		if title := stringValue(out, "title"); title != "Page /one" {
+			t.Errorf("title = %q", title)
+		}
+		if factors := out.Get(schema.analysisResult.Fields().ByName("factors")).List(); factors.Len() == 0 {
+			t.Error("no factor scores")
+		}
+
+		set(in, "url", protoreflect.ValueOfString("http://169.254.169.254/latest/meta-data/"))
+		if err := conn.Invoke(ctx, method("AnalyzeUrl"), in, out); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("link-local target: %v, want InvalidArgument", err)
+		}
+	})
+
+	t.Run("AnalyzeContent", func(t *testing.T) {
+		in := dynamicpb.NewMessage(schema.analyzeContentRequest)
+		set(in, "title", protoreflect.ValueOfString("Widgets"))
+		set(in, "content", protoreflect.ValueOfString("Widgets are small mechanical parts."))
+		out := dynamicpb.NewMessage(schema.analysisResult)
+		if err := conn.Invoke(ctx, method("AnalyzeContent"), in, out); err != nil {
+			t.Fatal(err)
+		}
+		if stringValue(out, "title") != "Widgets" || stringValue(out, "result_json") == "" {
+			t.Errorf("result = %v", out)
+		}
+	})
+
+	t.Run("StreamBulk", func(t *testing.T) {
+		in := dynamicpb.NewMessage(schema.streamBulkRequest)
+		urls := in.Mutable(schema.streamBulkRequest.Fields().ByName("urls")).List()
+		for _, path := range []string{"/a", "/b", "/c"} {
+			urls.Append(protoreflect.ValueOfString(ts.URL + path))
+		}
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, method("StreamBulk"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := stream.SendMsg(in); err != nil {
+			t.Fatal(err)
+		}
+		stream.CloseSend()
+
+		seen := make(map[string]bool)
+		for {
+			item := dynamicpb.NewMessage(schema.bulkItem)
+			if err := stream.RecvMsg(item); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if e := stringValue(item, "error"); e != "" {
+				t.Errorf("%s: %s", stringValue(item, "url"), e)
+			}
+			seen[stringValue(item, "url")] = true
+		}
+		if len(seen) != 3 {
+			t.Errorf("streamed %d results, want 3: %v", len(seen), seen)
+		}
+	})
+}
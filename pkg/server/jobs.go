@@ -0,0 +1,274 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/internal/netguard"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+)
+
+// jobRetention is how long finished jobs stay retrievable.
+const jobRetention = 24 * time.Hour
+
+// Job is a long-running analysis submitted through POST /v1/jobs.
+type Job struct {
+	ID          string             `json:"id"`
+	Status      string             `json:"status"`
+	URLs        []string           `json:"urls"`
+	Completed   int                `json:"completed"`
+	Total       int                `json:"total"`
+	CallbackURL string             `json:"callback_url,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	FinishedAt  *time.Time         `json:"finished_at,omitempty"`
+	Results     []*bulk.BulkResult `json:"results,omitempty"`
+	Error       string             `json:"error,omitempty"`
+
+	owner string // API key that submitted the job; empty on an open service
+}
+
+type jobRequest struct {
+	URL         string   `json:"url"`
+	URLs        []string `json:"urls"`
+	Concurrency int      `json:"concurrency"`
+	CallbackURL string   `json:"callback_url"`
+}
+
+// jobStore keeps jobs in memory; they do not survive a restart.
+type jobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+func newJobStore() *jobStore {
+	return &jobStore{jobs: make(map[string]*Job)}
+}
+
+func (js *jobStore) add(job *Job) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	for id, j := range js.jobs {
+		if j.FinishedAt != nil && time.Since(*j.FinishedAt) > jobRetention {
+			delete(js.jobs, id)
+		}
+	}
+	js.jobs[job.ID] = job
+}
+
+// snapshot returns a copy of the job that is safe to encode while the job
+// is still running.
+func (js *jobStore) snapshot(id string) (*Job, bool) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	job, ok := js.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	cp := *job
+	cp.Results = append([]*bulk.BulkResult(nil), job.Results...)
+	return &cp, true
+}
+
+// snapshotFor returns the job like snapshot when owner submitted it; other
+// keys' jobs are not found.
+func (js *jobStore) snapshotFor(id, owner string) (*Job, bool) {
+	job, ok := js.snapshot(id)
+	if !ok || job.owner != owner {
+		return nil, false
+	}
+	return job, true
+}
+
+func (js *jobStore) update(id string, fn func(*Job)) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	if job, ok := js.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "job_" + hex.EncodeToString(b)
+}
+
+func (s *Server) handleCreateJob(w http.ResponseWriter, r *http.Request) {
+	var req jobRequest
+	if err := decodeJSON(w, r, maxRequestBytes, &req); err != nil {
+		writeDecodeError(w, err, "request body must be JSON")
+		return
+	}
+	urls := req.URLs
+	if req.URL != "" {
+		urls = append([]string{req.URL}, urls...)
+	}
+	if len(urls) == 0 {
+		writeError(w, http.StatusBadRequest, "request body must contain a \"url\" or a non-empty \"urls\" array")
+		return
+	}
+	if err := s.checkTargets(r.Context(), urls); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.CallbackURL != "" {
+		u, err := url.Parse(req.CallbackURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			writeError(w, http.StatusBadRequest, "callback_url must be an absolute http(s) URL")
+			return
+		}
+		if !netguard.Allowed(u.Hostname(), webhookAllowedHosts()) {
+			ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+			err := netguard.CheckPublic(ctx, u.Hostname())
+			cancel()
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "callback_url must point to a public address: "+err.Error())
+				return
+			}
+		}
+	}
+	if !s.chargeURLs(w, r, len(urls)) {
+		return
+	}
+
+	job := &Job{
+		ID:          newJobID(),
+		Status:      JobQueued,
+		URLs:        urls,
+		Total:       len(urls),
+		CallbackURL: req.CallbackURL,
+		CreatedAt:   time.Now().UTC(),
+		owner:       caller(r.Context()),
+	}
+	s.jobs.add(job)
+
+	go s.runJob(job.ID, urls, req.Concurrency)
+
+	w.Header().Set("Location", "/v1/jobs/"+job.ID)
+	snap, _ := s.jobs.snapshot(job.ID)
+	writeJSON(w, http.StatusAccepted, snap)
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.jobs.snapshotFor(r.PathValue("id"), caller(r.Context()))
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// runJob executes the job detached from the submitting request and fires
+// the callback webhook once it has finished.
+func (s *Server) runJob(id string, urls []string, concurrency int) {
+	s.jobs.update(id, func(j *Job) { j.Status = JobRunning })
+
+	for item := range s.streamBulk(s.jobsCtx, urls, concurrency) {
+		s.jobs.update(id, func(j *Job) {
+			j.Results = append(j.Results, item)
+			j.Completed++
+		})
+	}
+
+	s.jobs.update(id, func(j *Job) {
+		now := time.Now().UTC()
+		j.FinishedAt = &now
+		j.Status = JobCompleted
+		failed := 0
+		for _, r := range j.Results {
+			if r.Error != nil {
+				failed++
+			}
+		}
+		if failed == len(j.Results) {
+			j.Status = JobFailed
+			j.Error = "all URLs failed to analyze"
+		}
+	})
+
+	job, _ := s.jobs.snapshot(id)
+	if job.CallbackURL != "" {
+		if err := deliverWebhook(job); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: webhook for %s failed: %v\n", job.ID, err)
+		}
+	}
+}
+
+// webhookAllowedHosts are the hosts callbacks may go to although they are
+// not public, from the comma-separated GEO_WEBHOOK_ALLOWED_HOSTS, for
+// receivers on the internal network.
+func webhookAllowedHosts() []string {
+	return strings.Split(os.Getenv("GEO_WEBHOOK_ALLOWED_HOSTS"), ",")
+}
+
+// deliverWebhook POSTs the finished job to its callback URL, retrying with
+// backoff. Only public addresses and GEO_WEBHOOK_ALLOWED_HOSTS are
+// dialed. When GEO_WEBHOOK_SECRET is set the body is signed with
+// HMAC-SHA256 in the X-Geo-Signature header.
+func deliverWebhook(job *Job) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+
+	signature := ""
+	if secret := os.Getenv("GEO_WEBHOOK_SECRET"); secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	// Callbacks go to public addresses only, checked as they are dialed
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = netguard.Guard(netguard.PublicOnly(10*time.Second, webhookAllowedHosts()))
+	client := &http.Client{Timeout: 15 * time.Second, Transport: transport}
+	var lastErr error
+	for attempt := 0; attempt < 3; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt*attempt) * 2 * time.Second)
+		}
+
+		req, err := http.NewRequest("POST", job.CallbackURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Geo-Job-ID", job.ID)
+		if signature != "" {
+			req.Header.Set("X-Geo-Signature", signature)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return lastErr
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"geo-checker/pkg/config"
+)
+
+func TestCallbackURLMustBePublic(t *testing.T) {
+	s := New(&config.Config{Mode: "local"})
+	for _, callback := range []string{"http://127.0.0.1:9000/hook", "http://169.254.169.254/latest", "http://[::1]/hook", "http://10.0.0.8/hook", "ftp://example.com/hook"} {
+		req := httptest.NewRequest("POST", "/v1/jobs", strings.NewReader(`{"url":"https://example.com","callback_url":"`+callback+`"}`))
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("callback %s: %d", callback, rec.Code)
+		}
+	}
+}
+
+func TestJobsPerKey(t *testing.T) {
+	s := New(&config.Config{Mode: "local"})
+	auth := NewAuthenticator([]*APIKey{{Key: "team-a", RatePerMinute: 1}, {Key: "team-b"}}, 0, 0)
+	s.UseAuthenticator(auth)
+	s.jobs.add(&Job{ID: "job_a", Status: JobQueued, owner: "team-a"})
+	s.jobs.add(&Job{ID: "job_b", Status: JobQueued, owner: "team-b"})
+	handler := s.Handler()
+
+	get := func(path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	// Polling more often than the rate limit allows is fine
+	for i := 0; i < 3; i++ {
+		rec := get("/v1/jobs", "team-a")
+		var jobs []*Job
+		json.Unmarshal(rec.Body.Bytes(), &jobs)
+		if rec.Code != http.StatusOK || len(jobs) != 1 || jobs[0].ID != "job_a" {
+			t.Fatalf("poll %d: %d %s", i, rec.Code, rec.Body.String())
+		}
+	}
+	if rec := get("/v1/jobs/job_b", "team-a"); rec.Code != http.StatusNotFound {
+		t.Errorf("another key's job: %d", rec.Code)
+	}
+	if rec := get("/v1/jobs/job_b/report", "team-a"); rec.Code != http.StatusNotFound {
+		t.Errorf("another key's report: %d", rec.Code)
+	}
+	if rec := get("/v1/jobs", "nope"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("unknown key: %d", rec.Code)
+	}
+}
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/internal/netguard"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/config"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Limits on what a single request may ask of the server.
+const (
+	maxRequestBytes    = 1 << 20  // JSON bodies listing URLs
+	maxContentBytes    = 10 << 20 // bodies of /v1/analyze-content
+	maxRequestURLs     = 1000
+	maxConcurrency     = 20
+	defaultConcurrency = 5
+)
+
+// Server exposes the analyzer over HTTP (and, optionally, gRPC).
+type Server struct {
+	config   *config.Config
+	analyzer *analyzer.Analyzer
+	mux      *http.ServeMux
+	auth     *Authenticator
+	jobs     *jobStore
+
+	// jobsCtx scopes background jobs to the server's lifetime
+	jobsCtx  context.Context
+	stopJobs context.CancelFunc
+
+	// ShutdownTimeout bounds how long in-flight requests may run after the
+	// serve context is cancelled. Zero waits indefinitely.
+	ShutdownTimeout time.Duration
+}
+
+type analyzeURLRequest struct {
+	URL string `json:"url"`
+}
+
+type analyzeContentRequest struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+type bulkRequest struct {
+	URLs        []string `json:"urls"`
+	Concurrency int      `json:"concurrency"`
+}
+
+// New returns a server analyzing with cfg. Pages are only fetched from
+// public addresses, and from the hosts in cfg.AllowedHosts.
+func New(cfg *config.Config) *Server {
+	// Spinners and banners make no sense in a server process
+	cfg.OutputFormat = "json"
+	// Clients must not be able to make the server fetch internal services
+	cfg.PublicOnly = true
+
+	s := &Server{
+		config:   cfg,
+		analyzer: analyzer.New(cfg),
+		mux:      http.NewServeMux(),
+		jobs:     newJobStore(),
+	}
+	s.jobsCtx, s.stopJobs = context.WithCancel(context.Background())
+
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("POST /v1/analyze", s.handleAnalyzeURL)
+	s.mux.HandleFunc("POST /v1/analyze-content", s.handleAnalyzeContent)
+	s.mux.HandleFunc("POST /v1/bulk", s.handleBulk)
+	s.mux.HandleFunc("POST /v1/jobs", s.handleCreateJob)
+	s.mux.HandleFunc("GET /v1/jobs", s.handleListJobs)
+	s.mux.HandleFunc("GET /v1/jobs/{id}", s.handleGetJob)
+	s.mux.HandleFunc("GET /v1/jobs/{id}/report", s.handleJobReport)
+	s.registerDashboard()
+
+	return s
+}
+
+// Mode returns the analysis mode the server resolved at startup.
+func (s *Server) Mode() string {
+	return s.analyzer.Mode()
+}
+
+// UseAuthenticator enables API-key checks and rate limits on both APIs.
+func (s *Server) UseAuthenticator(auth *Authenticator) {
+	s.auth = auth
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.auth.Middleware(s.mux)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "mode": s.analyzer.Mode()})
+}
+
+func (s *Server) handleAnalyzeURL(w http.ResponseWriter, r *http.Request) {
+	var req analyzeURLRequest
+	if err := decodeJSON(w, r, maxRequestBytes, &req); err != nil || req.URL == "" {
+		writeDecodeError(w, err, "request body must be JSON with a \"url\" field")
+		return
+	}
+	if err := s.checkTargets(r.Context(), []string{req.URL}); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := s.analyzer.AnalyzeURL(r.Context(), req.URL)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleAnalyzeContent(w http.ResponseWriter, r *http.Request) {
+	var req analyzeContentRequest
+	if err := decodeJSON(w, r, maxContentBytes, &req); err != nil || req.Content == "" {
+		writeDecodeError(w, err, "request body must be JSON with a \"content\" field")
+		return
+	}
+
+	result, err := s.analyzer.AnalyzeContent(r.Context(), req.Content, req.Title)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleBulk streams one NDJSON line per URL as each analysis completes.
+func (s *Server) handleBulk(w http.ResponseWriter, r *http.Request) {
+	var req bulkRequest
+	if err := decodeJSON(w, r, maxRequestBytes, &req); err != nil || len(req.URLs) == 0 {
+		writeDecodeError(w, err, "request body must be JSON with a non-empty \"urls\" array")
+		return
+	}
+	if err := s.checkTargets(r.Context(), req.URLs); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !s.chargeURLs(w, r, len(req.URLs)) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+
+	for item := range s.streamBulk(r.Context(), req.URLs, req.Concurrency) {
+		if err := enc.Encode(item); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// streamBulk analyzes urls with bounded concurrency and delivers results in
+// completion order. The channel is closed once all work is done or ctx ends.
+// Concurrency is capped at maxConcurrency.
+func (s *Server) streamBulk(ctx context.Context, urls []string, concurrency int) <-chan *bulk.BulkResult {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	concurrency = min(concurrency, maxConcurrency)
+
+	out := make(chan *bulk.BulkResult)
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	go func() {
+		defer close(out)
+		for _, u := range urls {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			}
+
+			wg.Add(1)
+			go func(u string) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				item := &bulk.BulkResult{URL: u}
+				result, err := s.analyzer.AnalyzeURL(ctx, u)
+				if err != nil {
+					item.Error = analyzer.Classify(err)
+				} else {
+					item.Result = result
+				}
+
+				select {
+				case out <- item:
+				case <-ctx.Done():
+				}
+			}(u)
+		}
+		wg.Wait()
+	}()
+
+	return out
+}
+
+// checkTargets refuses more than maxRequestURLs URLs, URLs that are not
+// absolute http(s) URLs and URLs whose host is not public, unless the host is
+// allowed. Hosts that fail to resolve are left for the fetch to report; the
+// scraper checks addresses again as it dials them.
+func (s *Server) checkTargets(ctx context.Context, urls []string) error {
+	if len(urls) > maxRequestURLs {
+		return fmt.Errorf("at most %d URLs may be analyzed per request", maxRequestURLs)
+	}
+	checked := make(map[string]bool)
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%q is not an absolute http(s) URL", raw)
+		}
+		host := u.Hostname()
+		if checked[host] || netguard.Allowed(host, s.config.AllowedHosts) {
+			continue
+		}
+		checked[host] = true
+
+		lookupCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err = netguard.CheckPublic(lookupCtx, host)
+		cancel()
+		if errors.Is(err, netguard.ErrNotPublic) {
+			return fmt.Errorf("%s: %w", raw, err)
+		}
+	}
+	return nil
+}
+
+// decodeJSON decodes the request body into v, reading at most limit bytes.
+func decodeJSON(w http.ResponseWriter, r *http.Request, limit int64, v any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// writeDecodeError answers a request decodeJSON refused: 413 when the body
+// was too large, 400 with message otherwise.
+func writeDecodeError(w http.ResponseWriter, err error, message string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+		return
+	}
+	writeError(w, http.StatusBadRequest, message)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// ListenAndServe runs the HTTP API until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{Addr: addr, Handler: s.Handler()}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("HTTP server failed: %w", err)
+	case <-ctx.Done():
+		// Background jobs stop with the server; requests in flight drain
+		s.stopJobs()
+		shutdownCtx := context.Background()
+		if s.ShutdownTimeout > 0 {
+			var cancel context.CancelFunc
+			shutdownCtx, cancel = context.WithTimeout(shutdownCtx, s.ShutdownTimeout)
+			defer cancel()
+		}
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// Probe checks /healthz of a server listening on addr and returns an error
+// unless it reports healthy. Hosts omitted from addr (":8080") mean localhost.
+func Probe(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + net.JoinHostPort(host, port) + "/healthz")
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"geo-checker/pkg/config"
+)
+
+func TestPrivateTargetsRefused(t *testing.T) {
+	s := New(&config.Config{Mode: "local"})
+	targets := []string{"http://127.0.0.1:8080/", "http://169.254.169.254/latest/meta-data/", "http://[::1]/", "http://10.0.0.8/", "http://localhost/", "file:///etc/passwd"}
+	for _, target := range targets {
+		for path, body := range map[string]string{
+			"/v1/analyze": `{"url":"` + target + `"}`,
+			"/v1/bulk":    `{"urls":["` + target + `"]}`,
+			"/v1/jobs":    `{"urls":["` + target + `"]}`,
+		} {
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, httptest.NewRequest("POST", path, strings.NewReader(body)))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s %s: %d %s", path, target, rec.Code, rec.Body.String())
+			}
+		}
+	}
+}
+
+func TestAllowedHostIsFetched(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head><title>Staging</title></head><body><main><p>Internal staging page.</p></main></body></html>"))
+	}))
+	defer ts.Close()
+
+	s := New(&config.Config{Mode: "local", Timeout: 10, AllowedHosts: []string{"127.0.0.1"}})
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest("POST", "/v1/analyze", strings.NewReader(`{"url":"`+ts.URL+`"}`)))
+	if rec.Code != http.StatusOK {
+		t.Errorf("allowed host: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRequestLimits(t *testing.T) {
+	s := New(&config.Config{Mode: "local"})
+	post := func(path, body string) int {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest("POST", path, strings.NewReader(body)))
+		return rec.Code
+	}
+
+	urls := make([]string, maxRequestURLs+1)
+	for i := range urls {
+		urls[i] = fmt.Sprintf(`"https://example.com/%d"`, i)
+	}
+	tooMany := `{"urls":[` + strings.Join(urls, ",") + `]}`
+	for _, path := range []string{"/v1/bulk", "/v1/jobs"} {
+		if code := post(path, tooMany); code != http.StatusBadRequest {
+			t.Errorf("%s with %d URLs: %d", path, len(urls), code)
+		}
+	}
+
+	huge := `{"url":"https://example.com/` + strings.Repeat("a", maxRequestBytes) + `"}`
+	if code := post("/v1/analyze", huge); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized body: %d", code)
+	}
+}
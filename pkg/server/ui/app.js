@@ -0,0 +1,158 @@
+// Minimal dashboard on top of the /v1/jobs API. Jobs live in server memory,
+// so history covers the jobs submitted since the server started.
+(function () {
+  const keyInput = document.getElementById("api-key");
+  keyInput.value = localStorage.getItem("geo-api-key") || "";
+  keyInput.addEventListener("change", () => {
+    localStorage.setItem("geo-api-key", keyInput.value);
+    refresh();
+  });
+
+  function api(path, options) {
+    options = options || {};
+    options.headers = Object.assign({ "Content-Type": "application/json" }, options.headers);
+    if (keyInput.value) {
+      options.headers["Authorization"] = "Bearer " + keyInput.value;
+    }
+    return fetch(path, options).then((resp) =>
+      resp.json().then((body) => {
+        if (!resp.ok) throw new Error(body.error || resp.statusText);
+        return body;
+      })
+    );
+  }
+
+  function el(tag, attrs, children) {
+    const node = document.createElement(tag);
+    Object.entries(attrs || {}).forEach(([k, v]) => (k === "text" ? (node.textContent = v) : node.setAttribute(k, v)));
+    (children || []).forEach((c) => node.appendChild(c));
+    return node;
+  }
+
+  function average(results) {
+    const scores = (results || []).filter((r) => r.result).map((r) => r.result.score);
+    return scores.length ? Math.round(scores.reduce((a, b) => a + b, 0) / scores.length) : null;
+  }
+
+  function sparkline(points) {
+    const w = 120, h = 24;
+    const ns = "http://www.w3.org/2000/svg";
+    const svg = document.createElementNS(ns, "svg");
+    svg.setAttribute("width", w);
+    svg.setAttribute("height", h);
+    if (points.length < 2) return svg;
+    const step = w / (points.length - 1);
+    const line = document.createElementNS(ns, "polyline");
+    line.setAttribute("points", points.map((p, i) => `${i * step},${h - (p / 100) * h}`).join(" "));
+    line.setAttribute("fill", "none");
+    line.setAttribute("stroke", "#2e6bd1");
+    line.setAttribute("stroke-width", "1.5");
+    svg.appendChild(line);
+    return svg;
+  }
+
+  function downloadReport(job, format) {
+    const headers = keyInput.value ? { Authorization: "Bearer " + keyInput.value } : {};
+    fetch(`/v1/jobs/${job.id}/report?format=${format}`, { headers })
+      .then((resp) => resp.blob())
+      .then((blob) => {
+        const ext = format === "markdown" ? "md" : format;
+        const a = el("a", { href: URL.createObjectURL(blob), download: `${job.id}.${ext}` });
+        a.click();
+        URL.revokeObjectURL(a.href);
+      });
+  }
+
+  function renderJobs(jobs) {
+    const body = document.getElementById("jobs");
+    body.replaceChildren();
+    jobs.forEach((job) => {
+      const avg = average(job.results);
+      const reports = el("td");
+      if (job.status === "completed" || job.status === "failed") {
+        ["markdown", "json"].forEach((format) => {
+          const link = el("a", { href: "#", text: format });
+          link.addEventListener("click", (e) => {
+            e.preventDefault();
+            downloadReport(job, format);
+          });
+          reports.appendChild(link);
+          reports.appendChild(document.createTextNode(" "));
+        });
+      }
+      body.appendChild(
+        el("tr", {}, [
+          el("td", { text: job.id }),
+          el("td", { text: new Date(job.created_at).toLocaleString() }),
+          el("td", { class: "status-" + job.status, text: job.status }),
+          el("td", {}, [el("progress", { max: job.total, value: job.completed }), document.createTextNode(` ${job.completed}/${job.total}`)]),
+          el("td", { text: avg === null ? "-" : avg }),
+          reports,
+        ])
+      );
+    });
+  }
+
+  function renderTrends(jobs) {
+    const byURL = {};
+    jobs
+      .slice()
+      .reverse()
+      .forEach((job) =>
+        (job.results || []).forEach((r) => {
+          if (r.result) (byURL[r.url] = byURL[r.url] || []).push(r.result.score);
+        })
+      );
+
+    const body = document.getElementById("trends");
+    body.replaceChildren();
+    Object.keys(byURL)
+      .sort()
+      .forEach((url) => {
+        const scores = byURL[url];
+        const latest = scores[scores.length - 1];
+        const delta = scores.length > 1 ? latest - scores[scores.length - 2] : 0;
+        body.appendChild(
+          el("tr", {}, [
+            el("td", { class: "url", title: url, text: url }),
+            el("td", { text: latest }),
+            el("td", { class: delta > 0 ? "up" : delta < 0 ? "down" : "", text: delta > 0 ? "+" + delta : String(delta) }),
+            el("td", {}, [sparkline(scores)]),
+            el("td", { text: scores.length }),
+          ])
+        );
+      });
+  }
+
+  let timer;
+  function refresh() {
+    clearTimeout(timer);
+    api("/v1/jobs")
+      .then((jobs) => {
+        renderJobs(jobs);
+        renderTrends(jobs);
+        const active = jobs.some((j) => j.status === "queued" || j.status === "running");
+        timer = setTimeout(refresh, active ? 2000 : 15000);
+      })
+      .catch((err) => {
+        document.getElementById("submit-error").textContent = err.message;
+        timer = setTimeout(refresh, 15000);
+      });
+  }
+
+  document.getElementById("submit-form").addEventListener("submit", (e) => {
+    e.preventDefault();
+    const urls = document.getElementById("urls").value.split("\n").map((u) => u.trim()).filter(Boolean);
+    const concurrency = parseInt(document.getElementById("concurrency").value, 10) || 5;
+    const errorBox = document.getElementById("submit-error");
+    errorBox.textContent = "";
+    api("/v1/jobs", { method: "POST", body: JSON.stringify({ urls, concurrency }) })
+      .then(() => {
+        document.getElementById("urls").value = "";
+        refresh();
+      })
+      .catch((err) => (errorBox.textContent = err.message));
+  });
+
+  refresh();
+})();
@@ -0,0 +1,184 @@
+package sitemap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"geo-checker/internal/webpage"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Freshness statuses of a sitemap entry, from worst to best.
+const (
+	StatusError   = "error"   // the page could not be fetched
+	StatusMissing = "missing" // no <lastmod>
+	StatusInvalid = "invalid" // <lastmod> is not a W3C datetime
+	StatusFuture  = "future"  // <lastmod> lies in the future
+	StatusStale   = "stale"   // the page declares a later modification than <lastmod>
+	StatusChanged = "changed" // the content changed since the last audit but <lastmod> did not
+	StatusOK      = "ok"
+)
+
+// Check is the freshness verdict for one sitemap entry.
+type Check struct {
+	URL            string `json:"url"`
+	LastMod        string `json:"lastmod,omitempty"`
+	Modified       string `json:"modified,omitempty"`        // modification date the page itself declares
+	ModifiedSource string `json:"modified_source,omitempty"` // where Modified was read from
+	Hash           string `json:"hash,omitempty"`            // hash of the extracted content
+	Status         string `json:"status"`
+	Detail         string `json:"detail,omitempty"`
+}
+
+// Report is the result of a freshness audit.
+type Report struct {
+	Checked int            `json:"checked"`
+	Counts  map[string]int `json:"counts"`
+	Checks  []Check        `json:"checks"`
+}
+
+// Issues returns the number of entries whose status is not ok.
+func (r *Report) Issues() int {
+	return r.Checked - r.Counts[StatusOK]
+}
+
+// Auditor compares sitemap entries with the pages they point to.
+type Auditor struct {
+	// Concurrency is the number of pages fetched at once.
+	Concurrency int
+
+	// Tolerance absorbs clock skew and date-only lastmod values; dates
+	// closer than this are treated as equal.
+	Tolerance time.Duration
+
+	// History, when set, holds content hashes from earlier audits so
+	// content changes that never reached <lastmod> are caught. Successful
+	// checks are recorded in it.
+	History *HashStore
+
+	scraper *webpage.Scraper
+}
+
+func NewAuditor() *Auditor {
+	return &Auditor{
+		Concurrency: 5,
+		Tolerance:   24 * time.Hour,
+		scraper:     webpage.New(),
+	}
+}
+
+// Audit fetches every entry's page and checks its <lastmod>. Checks are
+// returned in entry order.
+func (a *Auditor) Audit(ctx context.Context, entries []Entry) *Report {
+	now := time.Now()
+	checks := make([]Check, len(entries))
+
+	semaphore := make(chan struct{}, max(a.Concurrency, 1))
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			checks[i] = Check{URL: entry.Loc, LastMod: entry.LastMod, Status: StatusError, Detail: ctx.Err().Error()}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, entry Entry) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			checks[i] = a.check(ctx, entry, now)
+		}(i, entry)
+	}
+	wg.Wait()
+
+	report := &Report{Checked: len(checks), Counts: make(map[string]int), Checks: checks}
+	for _, c := range checks {
+		report.Counts[c.Status]++
+	}
+	return report
+}
+
+func (a *Auditor) check(ctx context.Context, entry Entry, now time.Time) Check {
+	c := Check{URL: entry.Loc, LastMod: entry.LastMod}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	page, err := a.scraper.ScrapeURL(ctx, entry.Loc)
+	if err != nil {
+		c.Status, c.Detail = StatusError, err.Error()
+		return c
+	}
+
+	modified, source := pageModified(page)
+	if !modified.IsZero() {
+		c.Modified, c.ModifiedSource = modified.UTC().Format(time.RFC3339), source
+	}
+	c.Hash = contentHash(page.Content)
+
+	var changedAt time.Time
+	if a.History != nil {
+		var previous *HashRecord
+		if rec, ok := a.History.Get(entry.Loc); ok {
+			previous = &rec
+		}
+		rec := previous.next(c.Hash, now)
+		a.History.Put(entry.Loc, rec)
+		changedAt = rec.ChangedAt
+	}
+
+	c.Status, c.Detail = evaluate(entry.LastMod, modified, changedAt, now, a.Tolerance)
+	return c
+}
+
+// evaluate decides the status of a <lastmod> value given the page's declared
+// modification time (zero if unknown) and the audit after which its content
+// last changed (zero if no change has been seen).
+func evaluate(lastmod string, modified, changedAt time.Time, now time.Time, tolerance time.Duration) (string, string) {
+	if lastmod == "" {
+		return StatusMissing, "no <lastmod>; answer engines cannot tell when the page changed"
+	}
+	declared, err := ParseLastMod(lastmod)
+	if err != nil {
+		return StatusInvalid, err.Error()
+	}
+
+	if declared.After(now.Add(tolerance)) {
+		return StatusFuture, fmt.Sprintf("<lastmod> %s is in the future", lastmod)
+	}
+	if !modified.IsZero() && modified.After(declared.Add(tolerance)) {
+		return StatusStale, fmt.Sprintf("page was modified %s but <lastmod> says %s", modified.UTC().Format("2006-01-02"), lastmod)
+	}
+	if !changedAt.IsZero() && declared.Add(tolerance).Before(changedAt) {
+		return StatusChanged, fmt.Sprintf("content changed since the audit on %s but <lastmod> still says %s", changedAt.UTC().Format("2006-01-02"), lastmod)
+	}
+	return StatusOK, ""
+}
+
+// pageModified returns when the page says it was last modified and where
+// that was read from. The Last-Modified header is only a fallback: dynamic
+// sites often send the time of the request.
+func pageModified(page *webpage.PageData) (time.Time, string) {
+	for _, name := range webpage.ModifiedMetaTags {
+		if value := page.MetaTags[name]; value != "" {
+			if t, err := webpage.ParseDate(value); err == nil {
+				return t, name
+			}
+		}
+	}
+	if value := page.Crawl.LastModified; value != "" {
+		if t, err := http.ParseTime(value); err == nil {
+			return t, "Last-Modified header"
+		}
+	}
+	return time.Time{}, ""
+}
+
+// contentHash fingerprints extracted text, ignoring whitespace changes.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(content), " ")))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,104 @@
+package sitemap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"geo-checker/pkg/config"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// HashRecord is what a freshness audit remembers about a page.
+type HashRecord struct {
+	Hash      string    `json:"hash"`
+	CheckedAt time.Time `json:"checked_at"`
+	// ChangedAt is the last audit that still saw the previous content: the
+	// page changed at some point after it. Zero if no change has been seen.
+	ChangedAt time.Time `json:"changed_at,omitempty"`
+}
+
+// next returns the record to store after an audit at now found hash.
+func (r *HashRecord) next(hash string, now time.Time) HashRecord {
+	rec := HashRecord{Hash: hash, CheckedAt: now}
+	if r != nil {
+		rec.ChangedAt = r.ChangedAt
+		if r.Hash != hash {
+			rec.ChangedAt = r.CheckedAt
+		}
+	}
+	return rec
+}
+
+// HashStore keeps the content hash of every audited page in a JSON file, so
+// the next audit can tell which pages changed.
+type HashStore struct {
+	path string
+
+	mu      sync.Mutex
+	records map[string]HashRecord
+}
+
+// DefaultHashStorePath returns the hash store location in the history
+// directory.
+func DefaultHashStorePath() (string, error) {
+	dir, err := config.HistoryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sitemap-hashes.json"), nil
+}
+
+// OpenHashStore loads the store at path. A missing file is an empty store.
+func OpenHashStore(path string) (*HashStore, error) {
+	s := &HashStore{path: path, records: make(map[string]HashRecord)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hash store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.records); err != nil {
+		return nil, fmt.Errorf("failed to parse hash store %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *HashStore) Get(url string) (HashRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[url]
+	return rec, ok
+}
+
+func (s *HashStore) Put(url string, rec HashRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[url] = rec
+}
+
+// Save writes the store back to its file, replacing it atomically.
+func (s *HashStore) Save() error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.records, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode hash store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write hash store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write hash store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Package sitemap reads XML sitemaps and audits the freshness metadata they
+// declare against the pages they list.
+package sitemap
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"geo-checker/internal/webpage"
+)
+
+const (
+	// maxSitemapSize is the protocol's limit for an uncompressed sitemap.
+	maxSitemapSize = 50 << 20
+
+	// maxSitemaps bounds how many child sitemaps of an index are read.
+	maxSitemaps = 100
+)
+
+// Entry is one <url> of a sitemap.
+type Entry struct {
+	Loc        string  `json:"loc"`
+	LastMod    string  `json:"lastmod,omitempty"`
+	ChangeFreq string  `json:"changefreq,omitempty"`
+	Priority   float64 `json:"priority,omitempty"`
+}
+
+type urlSet struct {
+	URLs []struct {
+		Loc        string `xml:"loc"`
+		LastMod    string `xml:"lastmod"`
+		ChangeFreq string `xml:"changefreq"`
+		Priority   string `xml:"priority"`
+	} `xml:"url"`
+}
+
+type sitemapIndex struct {
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// Fetch downloads the sitemap at url and returns its entries. Sitemap
+// indexes are followed one level deep, as the protocol allows, and gzipped
+// sitemaps are decompressed.
+func Fetch(ctx context.Context, url string) ([]Entry, error) {
+	client := &http.Client{Timeout: 60 * time.Second}
+	data, err := download(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sitemap %s: %w", url, err)
+	}
+	if root != "sitemapindex" {
+		return Parse(data)
+	}
+
+	var index sitemapIndex
+	if err := xml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse sitemap index %s: %w", url, err)
+	}
+	if len(index.Sitemaps) > maxSitemaps {
+		return nil, fmt.Errorf("sitemap index %s lists %d sitemaps, more than the %d supported", url, len(index.Sitemaps), maxSitemaps)
+	}
+
+	var entries []Entry
+	for _, child := range index.Sitemaps {
+		loc := strings.TrimSpace(child.Loc)
+		data, err := download(ctx, client, loc)
+		if err != nil {
+			return nil, err
+		}
+		childEntries, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sitemap %s: %w", loc, err)
+		}
+		entries = append(entries, childEntries...)
+	}
+	return entries, nil
+}
+
+// Parse reads the entries of a <urlset> sitemap.
+func Parse(data []byte) ([]Entry, error) {
+	var set urlSet
+	if err := xml.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(set.URLs))
+	for _, u := range set.URLs {
+		loc := strings.TrimSpace(u.Loc)
+		if loc == "" {
+			continue
+		}
+		entry := Entry{
+			Loc:        loc,
+			LastMod:    strings.TrimSpace(u.LastMod),
+			ChangeFreq: strings.TrimSpace(u.ChangeFreq),
+		}
+		if p, err := strconv.ParseFloat(strings.TrimSpace(u.Priority), 64); err == nil {
+			entry.Priority = p
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ParseLastMod parses a W3C datetime as used by <lastmod>: a date, or a date
+// and time with a timezone, optionally with fractional seconds.
+func ParseLastMod(value string) (time.Time, error) {
+	t, err := webpage.ParseDate(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid lastmod %q", value)
+	}
+	return t, nil
+}
+
+func download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sitemap %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch sitemap %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	body := bufio.NewReader(resp.Body)
+	// Sniff gzip rather than trusting the extension or Content-Type
+	var r io.Reader = body
+	if magic, _ := body.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap %s: %w", url, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxSitemapSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sitemap %s: %w", url, err)
+	}
+	if len(data) > maxSitemapSize {
+		return nil, fmt.Errorf("sitemap %s exceeds %d bytes", url, maxSitemapSize)
+	}
+	return data, nil
+}
+
+// rootElement returns the local name of the document's root element.
+func rootElement(data []byte) (string, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package sitemap
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFetchFollowsIndexAndGzip(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			fmt.Fprintf(w, `<?xml version="1.0"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<sitemap><loc>%s/a.xml</loc></sitemap><sitemap><loc>%s/b.xml.gz</loc></sitemap></sitemapindex>`, ts.URL, ts.URL)
+		case "/a.xml":
+			fmt.Fprint(w, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc> https://example.com/ </loc><lastmod>2024-05-01</lastmod><priority>1.0</priority></url></urlset>`)
+		case "/b.xml.gz":
+			gz := gzip.NewWriter(w)
+			fmt.Fprint(gz, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://example.com/blog</loc></url></urlset>`)
+			gz.Close()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	entries, err := Fetch(context.Background(), ts.URL+"/sitemap.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{Loc: "https://example.com/", LastMod: "2024-05-01", Priority: 1},
+		{Loc: "https://example.com/blog"},
+	}
+	if fmt.Sprint(entries) != fmt.Sprint(want) {
+		t.Errorf("entries = %+v, want %+v", entries, want)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	may10 := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	may20 := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		lastmod   string
+		modified  time.Time
+		changedAt time.Time
+		want      string
+	}{
+		{"missing", "", time.Time{}, time.Time{}, StatusMissing},
+		{"invalid", "May 1st", time.Time{}, time.Time{}, StatusInvalid},
+		{"future", "2024-07-01", time.Time{}, time.Time{}, StatusFuture},
+		{"stale", "2024-05-01", may10, time.Time{}, StatusStale},
+		{"same day", "2024-05-10", may10, time.Time{}, StatusOK},
+		{"changed since audit", "2024-05-01", time.Time{}, may20, StatusChanged},
+		{"changed and lastmod updated", "2024-05-25T08:00:00+02:00", time.Time{}, may20, StatusOK},
+		{"never changed", "2024-05-01", time.Time{}, time.Time{}, StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, detail := evaluate(tt.lastmod, tt.modified, tt.changedAt, now, 24*time.Hour)
+			if got != tt.want {
+				t.Errorf("evaluate() = %s (%s), want %s", got, detail, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditRecordsHashes(t *testing.T) {
+	body := "first version"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<html><head><meta property="article:modified_time" content="2024-05-01T10:00:00Z"></head><body><main><p>%s of a page with enough words to be extracted as content.</p></main></body></html>`, body)
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "hashes.json")
+	entries := []Entry{{Loc: ts.URL + "/page", LastMod: "2024-05-01"}}
+
+	audit := func() Check {
+		store, err := OpenHashStore(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		auditor := NewAuditor()
+		auditor.History = store
+		report := auditor.Audit(context.Background(), entries)
+		if err := store.Save(); err != nil {
+			t.Fatal(err)
+		}
+		return report.Checks[0]
+	}
+
+	if c := audit(); c.Status != StatusOK || c.ModifiedSource != "article:modified_time" {
+		t.Fatalf("first audit = %+v", c)
+	}
+	body = "second version"
+	if c := audit(); c.Status != StatusChanged {
+		t.Errorf("audit after content change = %+v, want %s", c, StatusChanged)
+	}
+	if c := audit(); c.Status != StatusChanged {
+		t.Errorf("second audit after content change = %+v, want %s", c, StatusChanged)
+	}
+}
@@ -0,0 +1,182 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Contentful reads entries through the Content Delivery API, or the Content
+// Preview API when drafts are requested. The two APIs take different
+// tokens.
+type Contentful struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewContentful(cfg Config) (*Contentful, error) {
+	tokenEnv := "CONTENTFUL_ACCESS_TOKEN"
+	if cfg.Preview {
+		tokenEnv = "CONTENTFUL_PREVIEW_TOKEN"
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv(tokenEnv)
+	}
+	if cfg.Project == "" || cfg.Token == "" {
+		return nil, fmt.Errorf("Contentful requires a space ID and access token (%s)", tokenEnv)
+	}
+	if cfg.ContentType == "" {
+		return nil, fmt.Errorf("Contentful requires a content type")
+	}
+	if cfg.Dataset == "" {
+		cfg.Dataset = "master"
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://cdn.contentful.com"
+		if cfg.Preview {
+			cfg.BaseURL = "https://preview.contentful.com"
+		}
+	}
+	return &Contentful{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+func (c *Contentful) Name() string {
+	return "contentful"
+}
+
+// contentfulMaxPageSize is the largest limit the entries endpoint accepts.
+const contentfulMaxPageSize = 1000
+
+// contentfulItem is an entry as the entries endpoint returns it.
+type contentfulItem struct {
+	Sys struct {
+		ID string `json:"id"`
+	} `json:"sys"`
+	Fields map[string]any `json:"fields"`
+}
+
+// Fetch reads up to Limit entries, paging with skip until the total is
+// reached.
+func (c *Contentful) Fetch(ctx context.Context) ([]Document, error) {
+	pageSize := c.cfg.Limit
+	if pageSize > contentfulMaxPageSize {
+		pageSize = contentfulMaxPageSize
+	}
+
+	var docs []Document
+	for skip := 0; len(docs) < c.cfg.Limit; {
+		items, total, err := c.fetchPage(ctx, skip, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if len(docs) == c.cfg.Limit {
+				break
+			}
+			docs = append(docs, c.document(item))
+		}
+		skip += len(items)
+		if len(items) == 0 || skip >= total {
+			break
+		}
+	}
+	return docs, nil
+}
+
+// fetchPage reads the entries from skip on and the total there are.
+func (c *Contentful) fetchPage(ctx context.Context, skip, pageSize int) ([]contentfulItem, int, error) {
+	query := url.Values{
+		"content_type": {c.cfg.ContentType},
+		"limit":        {fmt.Sprintf("%d", pageSize)},
+		"skip":         {fmt.Sprintf("%d", skip)},
+		"order":        {"sys.id"}, // a stable order, so pages neither overlap nor skip entries
+	}
+	endpoint := fmt.Sprintf("%s/spaces/%s/environments/%s/entries?%s", strings.TrimRight(c.cfg.BaseURL, "/"),
+		url.PathEscape(c.cfg.Project), url.PathEscape(c.cfg.Dataset), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Contentful request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read Contentful response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("Contentful API error (status %d)", resp.StatusCode)
+	}
+
+	var payload struct {
+		Items []contentfulItem `json:"items"`
+		Total int              `json:"total"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse Contentful response: %w", err)
+	}
+	return payload.Items, payload.Total, nil
+}
+
+// document converts an entry, rendering a Rich Text or markdown body.
+func (c *Contentful) document(item contentfulItem) Document {
+	var body string
+	switch v := item.Fields[c.cfg.BodyField].(type) {
+	case string:
+		body = MarkdownToHTML(v)
+	case map[string]any:
+		body = richTextToHTML(v)
+	}
+	ref := fmt.Sprintf("contentful://%s/%s", c.cfg.Project, item.Sys.ID)
+	return document(item.Sys.ID, stringField(item.Fields, c.cfg.TitleField), ref, body)
+}
+
+// richTextToHTML renders a Contentful Rich Text node tree.
+func richTextToHTML(node map[string]any) string {
+	nodeType, _ := node["nodeType"].(string)
+	if nodeType == "text" {
+		value, _ := node["value"].(string)
+		return html.EscapeString(value)
+	}
+
+	var inner strings.Builder
+	if children, ok := node["content"].([]any); ok {
+		for _, child := range children {
+			if m, ok := child.(map[string]any); ok {
+				inner.WriteString(richTextToHTML(m))
+			}
+		}
+	}
+
+	tags := map[string]string{
+		"paragraph": "p", "heading-1": "h1", "heading-2": "h2", "heading-3": "h3",
+		"heading-4": "h4", "heading-5": "h5", "heading-6": "h6",
+		"unordered-list": "ul", "ordered-list": "ol", "list-item": "li",
+		"blockquote": "blockquote", "table": "table", "table-row": "tr",
+		"table-cell": "td", "table-header-cell": "th",
+	}
+	if nodeType == "hyperlink" {
+		href := ""
+		if data, ok := node["data"].(map[string]any); ok {
+			href, _ = data["uri"].(string)
+		}
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(href), inner.String())
+	}
+	if tag, ok := tags[nodeType]; ok {
+		return "<" + tag + ">" + inner.String() + "</" + tag + ">"
+	}
+	return inner.String()
+}
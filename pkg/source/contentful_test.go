@@ -0,0 +1,69 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestContentfulPreviewPages(t *testing.T) {
+	const total = 2500
+	var skips []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/spaces/space1/environments/master/entries" {
+			t.Errorf("path %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer preview-token" {
+			t.Errorf("authorization %q, want the preview token", auth)
+		}
+		q := r.URL.Query()
+		skips = append(skips, q.Get("skip"))
+		skip, _ := strconv.Atoi(q.Get("skip"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		if limit != 1000 {
+			t.Errorf("limit %d", limit)
+		}
+		var items []string
+		for i := skip; i < min(skip+limit, total); i++ {
+			items = append(items, fmt.Sprintf(`{"sys":{"id":"e%d"},"fields":{"title":"Entry %d","body":"Text."}}`, i, i))
+		}
+		fmt.Fprintf(w, `{"items":[%s],"skip":%d,"limit":%d,"total":%d}`, strings.Join(items, ","), skip, limit, total)
+	}))
+	defer server.Close()
+
+	t.Setenv("CONTENTFUL_ACCESS_TOKEN", "delivery-token")
+	t.Setenv("CONTENTFUL_PREVIEW_TOKEN", "preview-token")
+	src, err := New("contentful", Config{BaseURL: server.URL, Project: "space1", ContentType: "blogPost", Preview: true, Limit: 1200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := src.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1200 || strings.Join(skips, ",") != "0,1000" || docs[1199].ID != "e1199" {
+		t.Errorf("%d documents from skips %v", len(docs), skips)
+	}
+
+	// Paging stops at the total even when the limit is higher
+	skips = nil
+	src, _ = New("contentful", Config{BaseURL: server.URL, Project: "space1", ContentType: "blogPost", Preview: true, Limit: 5000})
+	if docs, err = src.Fetch(context.Background()); err != nil || len(docs) != total || strings.Join(skips, ",") != "0,1000,2000" {
+		t.Errorf("%d documents from skips %v: %v", len(docs), skips, err)
+	}
+}
+
+func TestContentfulPreviewNeedsPreviewToken(t *testing.T) {
+	t.Setenv("CONTENTFUL_ACCESS_TOKEN", "delivery-token")
+	t.Setenv("CONTENTFUL_PREVIEW_TOKEN", "")
+	if _, err := New("contentful", Config{Project: "space1", ContentType: "blogPost", Preview: true}); err == nil || !strings.Contains(err.Error(), "CONTENTFUL_PREVIEW_TOKEN") {
+		t.Errorf("err = %v, want the preview token asked for", err)
+	}
+	if _, err := New("contentful", Config{Project: "space1", ContentType: "blogPost"}); err != nil {
+		t.Errorf("delivery: %v", err)
+	}
+}
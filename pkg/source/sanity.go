@@ -0,0 +1,166 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Sanity reads documents through the GROQ query API. Portable Text bodies
+// are rendered to HTML.
+type Sanity struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewSanity(cfg Config) (*Sanity, error) {
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("SANITY_TOKEN")
+	}
+	if cfg.Project == "" {
+		return nil, fmt.Errorf("Sanity requires a project ID")
+	}
+	if cfg.ContentType == "" {
+		return nil, fmt.Errorf("Sanity requires a document type")
+	}
+	if cfg.Preview && cfg.Token == "" {
+		return nil, fmt.Errorf("Sanity drafts require a token (SANITY_TOKEN)")
+	}
+	if cfg.Dataset == "" {
+		cfg.Dataset = "production"
+	}
+	return &Sanity{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+func (s *Sanity) Name() string {
+	return "sanity"
+}
+
+func (s *Sanity) Fetch(ctx context.Context) ([]Document, error) {
+	groq := fmt.Sprintf(`*[_type == $type][0...%d]{_id, "title": %s, "body": %s, "slug": slug.current}`,
+		s.cfg.Limit, s.cfg.TitleField, s.cfg.BodyField)
+	query := url.Values{
+		"query": {groq},
+		"$type": {fmt.Sprintf("%q", s.cfg.ContentType)},
+	}
+	if s.cfg.Preview {
+		query.Set("perspective", "previewDrafts")
+	}
+	endpoint := fmt.Sprintf("https://%s.api.sanity.io/v2021-10-21/data/query/%s?%s",
+		url.PathEscape(s.cfg.Project), url.PathEscape(s.cfg.Dataset), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if s.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Sanity request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Sanity response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Sanity API error (status %d)", resp.StatusCode)
+	}
+
+	var payload struct {
+		Result []struct {
+			ID    string `json:"_id"`
+			Title string `json:"title"`
+			Slug  string `json:"slug"`
+			Body  any    `json:"body"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse Sanity response: %w", err)
+	}
+
+	docs := make([]Document, 0, len(payload.Result))
+	for _, item := range payload.Result {
+		var body string
+		switch v := item.Body.(type) {
+		case string:
+			body = MarkdownToHTML(v)
+		case []any:
+			body = portableTextToHTML(v)
+		}
+		ref := fmt.Sprintf("sanity://%s/%s", s.cfg.Project, item.ID)
+		if item.Slug != "" {
+			ref = fmt.Sprintf("sanity://%s/%s", s.cfg.Project, item.Slug)
+		}
+		docs = append(docs, document(item.ID, item.Title, ref, body))
+	}
+	return docs, nil
+}
+
+// portableTextToHTML renders Portable Text blocks, grouping consecutive list
+// items into lists.
+func portableTextToHTML(blocks []any) string {
+	var out strings.Builder
+	listTag := ""
+
+	for _, raw := range blocks {
+		block, ok := raw.(map[string]any)
+		if !ok || block["_type"] != "block" {
+			continue
+		}
+
+		var text strings.Builder
+		if children, ok := block["children"].([]any); ok {
+			for _, child := range children {
+				if span, ok := child.(map[string]any); ok {
+					t, _ := span["text"].(string)
+					text.WriteString(html.EscapeString(t))
+				}
+			}
+		}
+
+		listItem, _ := block["listItem"].(string)
+		wantList := ""
+		switch listItem {
+		case "bullet":
+			wantList = "ul"
+		case "number":
+			wantList = "ol"
+		}
+		if listTag != wantList {
+			if listTag != "" {
+				out.WriteString("</" + listTag + ">")
+			}
+			if wantList != "" {
+				out.WriteString("<" + wantList + ">")
+			}
+			listTag = wantList
+		}
+		if listTag != "" {
+			out.WriteString("<li>" + text.String() + "</li>")
+			continue
+		}
+
+		tag := "p"
+		if style, _ := block["style"].(string); style != "" && style != "normal" {
+			tag = style
+		}
+		out.WriteString("<" + tag + ">" + text.String() + "</" + tag + ">")
+	}
+	if listTag != "" {
+		out.WriteString("</" + listTag + ">")
+	}
+
+	return out.String()
+}
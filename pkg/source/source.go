@@ -0,0 +1,206 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Document is a single piece of content pulled from a content source,
+// normalized to HTML so it can go through the regular extraction pipeline.
+type Document struct {
+	ID    string
+	Title string
+	URL   string
+	HTML  string
+}
+
+// Source is implemented by content-source adapters (headless CMSs, etc.)
+// that deliver structured entries instead of rendered pages.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context) ([]Document, error)
+}
+
+// Config holds the settings shared by all adapters. Fields that do not apply
+// to an adapter are ignored.
+type Config struct {
+	BaseURL     string // Strapi/WordPress base URL, or another Contentful API host
+	Project     string // Contentful space or Sanity project ID
+	Dataset     string // Contentful environment or Sanity dataset
+	Token       string
+	ContentType string // Contentful content type, Sanity _type or Strapi collection
+	TitleField  string
+	BodyField   string
+	Preview     bool // include unpublished drafts
+	Limit       int
+}
+
+func New(name string, cfg Config) (Source, error) {
+	if cfg.TitleField == "" {
+		cfg.TitleField = "title"
+	}
+	if cfg.BodyField == "" {
+		cfg.BodyField = "body"
+	}
+	if cfg.Limit <= 0 {
+		cfg.Limit = 100
+	}
+
+	switch name {
+	case "contentful":
+		return NewContentful(cfg)
+	case "sanity":
+		return NewSanity(cfg)
+	case "strapi":
+		return NewStrapi(cfg)
+	case "wordpress":
+		return NewWordPress(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported content source: %s (use contentful, sanity, strapi or wordpress)", name)
+	}
+}
+
+// document wraps a title and HTML body into a full document.
+func document(id, title, url, body string) Document {
+	return Document{
+		ID:    id,
+		Title: title,
+		URL:   url,
+		HTML: fmt.Sprintf("<html><head><title>%s</title></head><body><article><h1>%s</h1>%s</article></body></html>",
+			html.EscapeString(title), html.EscapeString(title), body),
+	}
+}
+
+// MarkdownToHTML converts the markdown subset commonly stored in CMS text
+// fields (headings, lists, quotes, paragraphs) into HTML.
+func MarkdownToHTML(md string) string {
+	var out strings.Builder
+	var para []string
+	listTag := ""
+
+	flushPara := func() {
+		if len(para) > 0 {
+			out.WriteString("<p>" + html.EscapeString(strings.Join(para, " ")) + "</p>")
+			para = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			out.WriteString("</" + listTag + ">")
+			listTag = ""
+		}
+	}
+
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flushPara()
+			closeList()
+		case strings.HasPrefix(trimmed, "#"):
+			flushPara()
+			closeList()
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			if level > 6 {
+				level = 6
+			}
+			out.WriteString(fmt.Sprintf("<h%d>%s</h%d>", level, html.EscapeString(strings.TrimSpace(trimmed[level:])), level))
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			flushPara()
+			if listTag != "ul" {
+				closeList()
+				out.WriteString("<ul>")
+				listTag = "ul"
+			}
+			out.WriteString("<li>" + html.EscapeString(trimmed[2:]) + "</li>")
+		case isOrderedItem(trimmed):
+			flushPara()
+			if listTag != "ol" {
+				closeList()
+				out.WriteString("<ol>")
+				listTag = "ol"
+			}
+			out.WriteString("<li>" + html.EscapeString(strings.TrimSpace(trimmed[strings.Index(trimmed, ".")+1:])) + "</li>")
+		case strings.HasPrefix(trimmed, "> "):
+			flushPara()
+			closeList()
+			out.WriteString("<blockquote>" + html.EscapeString(trimmed[2:]) + "</blockquote>")
+		default:
+			closeList()
+			para = append(para, trimmed)
+		}
+	}
+	flushPara()
+	closeList()
+
+	return out.String()
+}
+
+// MarkdownPage renders a markdown document as the HTML page it would be
+// published as, with the title, description and language of its front
+// matter.
+func MarkdownPage(md string) string {
+	meta, body := frontMatter(md)
+	title := meta["title"]
+	if title == "" {
+		for _, line := range strings.Split(body, "\n") {
+			if strings.HasPrefix(line, "# ") {
+				title = strings.TrimSpace(line[2:])
+				break
+			}
+		}
+	}
+	var head strings.Builder
+	head.WriteString("<title>" + html.EscapeString(title) + "</title>")
+	if desc := meta["description"]; desc != "" {
+		head.WriteString(`<meta name="description" content="` + html.EscapeString(desc) + `">`)
+	}
+	root := "<html>"
+	if lang := meta["lang"]; lang != "" {
+		root = `<html lang="` + html.EscapeString(lang) + `">`
+	}
+	return root + "<head>" + head.String() + "</head><body><article>" + MarkdownToHTML(body) + "</article></body></html>"
+}
+
+// frontMatter returns the scalar fields of a YAML front matter block and
+// the markdown after it.
+func frontMatter(md string) (map[string]string, string) {
+	meta := make(map[string]string)
+	lines := strings.SplitAfter(md, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return meta, md
+	}
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "---" {
+			return meta, strings.Join(lines[i+1:], "")
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			meta[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return make(map[string]string), md
+}
+
+func isOrderedItem(line string) bool {
+	idx := strings.Index(line, ". ")
+	if idx <= 0 || idx > 3 {
+		return false
+	}
+	for _, r := range line[:idx] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// stringField returns a field as a string, or "" when it is missing or not a string.
+func stringField(fields map[string]any, key string) string {
+	if v, ok := fields[key].(string); ok {
+		return v
+	}
+	return ""
+}
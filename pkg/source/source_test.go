@@ -0,0 +1,15 @@
+package source
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownPage(t *testing.T) {
+	page := MarkdownPage("---\ntitle: \"Widgets\"\nlang: en\ndescription: All about widgets\n---\n# Widget guide\n\nText.\n")
+	for _, want := range []string{`<html lang="en">`, "<title>Widgets</title>", `<meta name="description" content="All about widgets">`, "<h1>Widget guide</h1>"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page lacks %s: %s", want, page)
+		}
+	}
+}
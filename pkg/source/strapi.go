@@ -0,0 +1,198 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Strapi reads a collection through the Strapi REST API. Both the v4
+// (data[].attributes) and v5 (flattened) response shapes are supported.
+type Strapi struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewStrapi(cfg Config) (*Strapi, error) {
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("STRAPI_TOKEN")
+	}
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("Strapi requires a base URL")
+	}
+	if cfg.ContentType == "" {
+		return nil, fmt.Errorf("Strapi requires a collection name (e.g. articles)")
+	}
+	return &Strapi{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+func (s *Strapi) Name() string {
+	return "strapi"
+}
+
+// strapiMaxPageSize is the largest page Strapi serves by default
+// (api.rest.maxLimit).
+const strapiMaxPageSize = 100
+
+// Fetch reads up to Limit entries, following meta.pagination across pages.
+func (s *Strapi) Fetch(ctx context.Context) ([]Document, error) {
+	pageSize := s.cfg.Limit
+	if pageSize > strapiMaxPageSize {
+		pageSize = strapiMaxPageSize
+	}
+
+	var docs []Document
+	for page := 1; len(docs) < s.cfg.Limit; page++ {
+		items, pageCount, err := s.fetchPage(ctx, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if len(docs) == s.cfg.Limit {
+				break
+			}
+			docs = append(docs, s.document(item))
+		}
+		if len(items) == 0 || page >= pageCount {
+			break
+		}
+	}
+	return docs, nil
+}
+
+// fetchPage reads one page of entries and the number of pages there are.
+func (s *Strapi) fetchPage(ctx context.Context, page, pageSize int) ([]map[string]any, int, error) {
+	query := url.Values{
+		"pagination[page]":     {fmt.Sprintf("%d", page)},
+		"pagination[pageSize]": {fmt.Sprintf("%d", pageSize)},
+	}
+	if s.cfg.Preview {
+		query.Set("publicationState", "preview") // v4
+		query.Set("status", "draft")             // v5
+	}
+	endpoint := fmt.Sprintf("%s/api/%s?%s", strings.TrimRight(s.cfg.BaseURL, "/"), url.PathEscape(s.cfg.ContentType), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if s.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Strapi request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read Strapi response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("Strapi API error (status %d)", resp.StatusCode)
+	}
+
+	var payload struct {
+		Data []map[string]any `json:"data"`
+		Meta struct {
+			Pagination struct {
+				PageCount int `json:"pageCount"`
+			} `json:"pagination"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse Strapi response: %w", err)
+	}
+	return payload.Data, payload.Meta.Pagination.PageCount, nil
+}
+
+// document converts an entry in either response shape.
+func (s *Strapi) document(item map[string]any) Document {
+	fields := item
+	if attrs, ok := item["attributes"].(map[string]any); ok {
+		fields = attrs
+	}
+
+	id := fmt.Sprintf("%v", item["id"])
+	if docID := stringField(item, "documentId"); docID != "" {
+		id = docID
+	}
+
+	var body string
+	switch v := fields[s.cfg.BodyField].(type) {
+	case string:
+		body = MarkdownToHTML(v)
+	case []any:
+		body = strapiBlocksToHTML(v)
+	}
+	ref := fmt.Sprintf("%s/api/%s/%s", strings.TrimRight(s.cfg.BaseURL, "/"), s.cfg.ContentType, id)
+	return document(id, stringField(fields, s.cfg.TitleField), ref, body)
+}
+
+// strapiBlocksToHTML renders the Strapi v5 blocks editor format.
+func strapiBlocksToHTML(blocks []any) string {
+	var out strings.Builder
+	for _, raw := range blocks {
+		block, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		text := strapiChildrenText(block)
+
+		switch block["type"] {
+		case "heading":
+			level := 2
+			if l, ok := block["level"].(float64); ok {
+				level = min(max(int(l), 1), 6)
+			}
+			out.WriteString(fmt.Sprintf("<h%d>%s</h%d>", level, text, level))
+		case "list":
+			tag := "ul"
+			if block["format"] == "ordered" {
+				tag = "ol"
+			}
+			out.WriteString("<" + tag + ">")
+			if items, ok := block["children"].([]any); ok {
+				for _, item := range items {
+					if m, ok := item.(map[string]any); ok {
+						out.WriteString("<li>" + strapiChildrenText(m) + "</li>")
+					}
+				}
+			}
+			out.WriteString("</" + tag + ">")
+		case "quote":
+			out.WriteString("<blockquote>" + text + "</blockquote>")
+		case "code":
+			out.WriteString("<pre>" + text + "</pre>")
+		default:
+			out.WriteString("<p>" + text + "</p>")
+		}
+	}
+	return out.String()
+}
+
+func strapiChildrenText(node map[string]any) string {
+	var text strings.Builder
+	children, _ := node["children"].([]any)
+	for _, child := range children {
+		m, ok := child.(map[string]any)
+		if !ok {
+			continue
+		}
+		if t, ok := m["text"].(string); ok {
+			text.WriteString(html.EscapeString(t))
+		} else {
+			text.WriteString(strapiChildrenText(m))
+		}
+	}
+	return text.String()
+}
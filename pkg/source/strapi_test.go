@@ -0,0 +1,55 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStrapiBlocksEscaped(t *testing.T) {
+	var blocks []any
+	json.Unmarshal([]byte(`[
+{"type":"heading","level":9,"children":[{"type":"text","text":"Fish & <Chips>"}]},
+{"type":"heading","level":0,"children":[{"type":"text","text":"Intro"}]},
+{"type":"paragraph","children":[{"type":"text","text":"<script>alert(1)</script>"}]},
+{"type":"list","format":"unordered","children":[{"type":"list-item","children":[{"type":"text","text":"a < b"}]}]}
+]`), &blocks)
+	got := strapiBlocksToHTML(blocks)
+	want := "<h6>Fish &amp; &lt;Chips&gt;</h6><h1>Intro</h1><p>&lt;script&gt;alert(1)&lt;/script&gt;</p><ul><li>a &lt; b</li></ul>"
+	if got != want {
+		t.Errorf("blocks = %s\nwant     %s", got, want)
+	}
+}
+
+func TestStrapiFetchPages(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("pagination[page]")
+		pages = append(pages, page)
+		if size := r.URL.Query().Get("pagination[pageSize]"); size != "100" {
+			t.Errorf("page size %s", size)
+		}
+		var data []string
+		for i := 0; i < 100; i++ {
+			data = append(data, fmt.Sprintf(`{"id":%s%02d,"title":"Entry","body":"Text."}`, page, i))
+		}
+		fmt.Fprintf(w, `{"data":[%s],"meta":{"pagination":{"page":%s,"pageSize":100,"pageCount":3,"total":300}}}`, strings.Join(data, ","), page)
+	}))
+	defer server.Close()
+
+	src, err := New("strapi", Config{BaseURL: server.URL, ContentType: "articles", Limit: 250})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := src.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 250 || strings.Join(pages, ",") != "1,2,3" || docs[249].ID != "349" {
+		t.Errorf("%d documents from pages %v, last %s", len(docs), pages, docs[len(docs)-1].ID)
+	}
+}
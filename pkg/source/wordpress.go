@@ -0,0 +1,51 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"geo-checker/pkg/wordpress"
+)
+
+// WordPress adapts the WordPress REST client to the Source interface.
+type WordPress struct {
+	cfg    Config
+	client *wordpress.Client
+}
+
+func NewWordPress(cfg Config) (*WordPress, error) {
+	client, err := wordpress.NewClient(cfg.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ContentType == "" {
+		cfg.ContentType = "posts"
+	}
+	return &WordPress{cfg: cfg, client: client}, nil
+}
+
+func (w *WordPress) Name() string {
+	return "wordpress"
+}
+
+func (w *WordPress) Fetch(ctx context.Context) ([]Document, error) {
+	status := "publish"
+	if w.cfg.Preview {
+		status = "any"
+	}
+
+	posts, err := w.client.ListPosts(ctx, w.cfg.ContentType, status, w.cfg.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]Document, 0, len(posts))
+	for i := range posts {
+		docs = append(docs, Document{
+			ID:    fmt.Sprintf("%d", posts[i].ID),
+			Title: posts[i].Title.Rendered,
+			URL:   posts[i].Link,
+			HTML:  posts[i].HTML(),
+		})
+	}
+	return docs, nil
+}
@@ -0,0 +1,136 @@
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// JiraTracker files issues through the Jira Cloud REST API v2. The suggestion
+// ID is stored as a label, which makes deduplication a single JQL query.
+type JiraTracker struct {
+	baseURL string
+	email   string
+	token   string
+	opts    Options
+	client  *http.Client
+}
+
+func NewJiraTracker(opts Options) (*JiraTracker, error) {
+	baseURL := strings.TrimRight(os.Getenv("JIRA_BASE_URL"), "/")
+	email := os.Getenv("JIRA_EMAIL")
+	token := os.Getenv("JIRA_API_TOKEN")
+
+	if baseURL == "" || email == "" || token == "" {
+		return nil, fmt.Errorf("Jira requires JIRA_BASE_URL, JIRA_EMAIL and JIRA_API_TOKEN environment variables")
+	}
+	if opts.Project == "" {
+		return nil, fmt.Errorf("Jira project key is required")
+	}
+
+	return &JiraTracker{
+		baseURL: baseURL,
+		email:   email,
+		token:   token,
+		opts:    opts,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (j *JiraTracker) Name() string {
+	return "jira"
+}
+
+func (j *JiraTracker) Exists(ctx context.Context, id string) (bool, error) {
+	payload := map[string]any{
+		"jql":        fmt.Sprintf(`project = %s AND labels = %s`, jqlString(j.opts.Project), jqlString(id)),
+		"maxResults": 1,
+		"fields":     []string{"key"},
+	}
+
+	var resp struct {
+		Total int `json:"total"`
+	}
+	if err := j.do(ctx, "/rest/api/2/search", payload, &resp); err != nil {
+		return false, err
+	}
+	return resp.Total > 0, nil
+}
+
+func (j *JiraTracker) Create(ctx context.Context, issue Issue) (string, error) {
+	payload := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": j.opts.Project},
+			"summary":     truncate(issue.Title, 250),
+			"description": issue.Description,
+			"issuetype":   map[string]string{"name": "Task"},
+			"labels":      append(issue.Labels, issue.ID),
+		},
+	}
+
+	var resp struct {
+		Key string `json:"key"`
+	}
+	if err := j.do(ctx, "/rest/api/2/issue", payload, &resp); err != nil {
+		return "", err
+	}
+	return resp.Key, nil
+}
+
+func (j *JiraTracker) do(ctx context.Context, path string, payload any, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", j.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(j.email, j.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Jira request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Jira response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Jira API error (status %d): %s", resp.StatusCode, truncate(string(data), 200))
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse Jira response: %w", err)
+	}
+	return nil
+}
+
+// jqlString quotes s as a JQL string literal.
+func jqlString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// truncate cuts s to at most maxLength bytes, on a rune boundary.
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	cut := maxLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
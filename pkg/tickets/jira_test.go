@@ -0,0 +1,50 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestJiraExistsQuotesProject(t *testing.T) {
+	var jql string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			JQL string `json:"jql"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		jql = payload.JQL
+		w.Write([]byte(`{"total":1}`))
+	}))
+	defer ts.Close()
+
+	t.Setenv("JIRA_BASE_URL", ts.URL)
+	t.Setenv("JIRA_EMAIL", "dev@example.com")
+	t.Setenv("JIRA_API_TOKEN", "token")
+	j, err := NewJiraTracker(Options{Project: `DOC" OR project = "OPS\`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exists, err := j.Exists(context.Background(), "geo-abc")
+	if err != nil || !exists {
+		t.Fatalf("Exists() = %v, %v", exists, err)
+	}
+	if want := `project = "DOC\" OR project = \"OPS\\" AND labels = "geo-abc"`; jql != want {
+		t.Errorf("jql = %s, want %s", jql, want)
+	}
+}
+
+func TestTruncateOnRuneBoundary(t *testing.T) {
+	title := strings.Repeat("é", 200) // 400 bytes
+	got := truncate(title, 251)
+	if !utf8.ValidString(got) || len(got) != 250+len("...") {
+		t.Errorf("truncate() = %q (%d bytes)", got, len(got))
+	}
+	if got := truncate("short", 250); got != "short" {
+		t.Errorf("truncate(short) = %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// LinearTracker files issues through Linear's GraphQL API. Linear has no
+// free-form labels, so the suggestion ID marker embedded in the description
+// is used for deduplication.
+type LinearTracker struct {
+	apiKey   string
+	opts     Options
+	client   *http.Client
+	labelIDs []string
+}
+
+func NewLinearTracker(opts Options) (*LinearTracker, error) {
+	apiKey := os.Getenv("LINEAR_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("Linear requires the LINEAR_API_KEY environment variable")
+	}
+	if opts.Project == "" {
+		return nil, fmt.Errorf("Linear team ID is required")
+	}
+
+	return &LinearTracker{
+		apiKey: apiKey,
+		opts:   opts,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (l *LinearTracker) Name() string {
+	return "linear"
+}
+
+func (l *LinearTracker) Exists(ctx context.Context, id string) (bool, error) {
+	query := `query($team: ID!, $marker: String!) {
+  issues(first: 1, filter: {team: {id: {eq: $team}}, description: {contains: $marker}}) {
+    nodes { id }
+  }
+}`
+	var resp struct {
+		Issues struct {
+			Nodes []struct {
+				ID string `json:"id"`
+			} `json:"nodes"`
+		} `json:"issues"`
+	}
+	vars := map[string]any{"team": l.opts.Project, "marker": "geo-checker-id: " + id}
+	if err := l.do(ctx, query, vars, &resp); err != nil {
+		return false, err
+	}
+	return len(resp.Issues.Nodes) > 0, nil
+}
+
+func (l *LinearTracker) Create(ctx context.Context, issue Issue) (string, error) {
+	labelIDs, err := l.resolveLabels(ctx, issue.Labels)
+	if err != nil {
+		return "", err
+	}
+
+	query := `mutation($input: IssueCreateInput!) {
+  issueCreate(input: $input) {
+    success
+    issue { identifier url }
+  }
+}`
+	input := map[string]any{
+		"teamId":      l.opts.Project,
+		"title":       truncate(issue.Title, 250),
+		"description": issue.Description,
+	}
+	if len(labelIDs) > 0 {
+		input["labelIds"] = labelIDs
+	}
+
+	var resp struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				Identifier string `json:"identifier"`
+				URL        string `json:"url"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	if err := l.do(ctx, query, map[string]any{"input": input}, &resp); err != nil {
+		return "", err
+	}
+	if !resp.IssueCreate.Success {
+		return "", fmt.Errorf("Linear rejected issue creation")
+	}
+	return resp.IssueCreate.Issue.Identifier, nil
+}
+
+// resolveLabels maps label names to Linear label IDs once per run. Names
+// without a matching label in the workspace are ignored.
+func (l *LinearTracker) resolveLabels(ctx context.Context, names []string) ([]string, error) {
+	if l.labelIDs != nil || len(names) == 0 {
+		return l.labelIDs, nil
+	}
+
+	query := `query($names: [String!]) {
+  issueLabels(filter: {name: {in: $names}}) {
+    nodes { id }
+  }
+}`
+	var resp struct {
+		IssueLabels struct {
+			Nodes []struct {
+				ID string `json:"id"`
+			} `json:"nodes"`
+		} `json:"issueLabels"`
+	}
+	if err := l.do(ctx, query, map[string]any{"names": names}, &resp); err != nil {
+		return nil, err
+	}
+
+	l.labelIDs = []string{}
+	for _, node := range resp.IssueLabels.Nodes {
+		l.labelIDs = append(l.labelIDs, node.ID)
+	}
+	return l.labelIDs, nil
+}
+
+func (l *LinearTracker) do(ctx context.Context, query string, vars map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.linear.app/graphql", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", l.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Linear request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Linear response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Linear API error (status %d): %s", resp.StatusCode, truncate(string(data), 200))
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("failed to parse Linear response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		return fmt.Errorf("Linear API error: %s", envelope.Errors[0].Message)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
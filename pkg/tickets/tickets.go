@@ -0,0 +1,156 @@
+package tickets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/scorer"
+	"regexp"
+	"strings"
+)
+
+// Issue is a tracker-agnostic description of a ticket to create.
+type Issue struct {
+	ID          string // stable suggestion ID used for deduplication
+	Title       string
+	Description string
+	Labels      []string
+}
+
+// Tracker creates issues in an external issue tracker.
+type Tracker interface {
+	Name() string
+	// Exists reports whether an issue for the given suggestion ID was already filed.
+	Exists(ctx context.Context, id string) (bool, error)
+	// Create files the issue and returns the tracker's key or URL for it.
+	Create(ctx context.Context, issue Issue) (string, error)
+}
+
+type Options struct {
+	Project string   // Jira project key or Linear team ID
+	Labels  []string // extra labels applied to every issue
+	All     bool     // ticket every suggestion, not only high-severity weaknesses
+}
+
+// Report summarizes a ticket sync run.
+type Report struct {
+	Created []string
+	Skipped int
+	Errors  []error
+}
+
+func NewTracker(kind string, opts Options) (Tracker, error) {
+	switch kind {
+	case "jira":
+		return NewJiraTracker(opts)
+	case "linear":
+		return NewLinearTracker(opts)
+	default:
+		return nil, fmt.Errorf("unsupported ticket tracker: %s (use jira or linear)", kind)
+	}
+}
+
+// SuggestionID derives a stable identifier for a finding on a page so the
+// same finding is never filed twice across runs. key is the finding's rule
+// ID, which stays the same when figures in its message (a page's age, a
+// word count) change between runs.
+func SuggestionID(pageURL, key string) string {
+	sum := sha256.Sum256([]byte(pageURL + "\n" + key))
+	return "geo-" + hex.EncodeToString(sum[:])[:12]
+}
+
+var figures = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+
+// suggestionKey keys a suggestion no rule raised on its text, with figures
+// and case taken out so that it too stays the same across runs.
+func suggestionKey(suggestion string) string {
+	return "text:" + strings.ToLower(strings.Join(strings.Fields(figures.ReplaceAllString(suggestion, "#")), " "))
+}
+
+// BuildIssues converts bulk results into issues. By default only
+// high-severity findings (issues from factors scoring below 50%) are ticketed.
+func BuildIssues(results []*bulk.BulkResult, opts Options) []Issue {
+	var issues []Issue
+	for _, r := range results {
+		if r.Result == nil || r.Result.LocalScore == nil {
+			continue
+		}
+
+		type item struct{ key, text string }
+		var suggestions []item
+		if opts.All {
+			ruleIDs := make(map[string]string)
+			for _, f := range r.Result.LocalScore.Findings {
+				ruleIDs[f.Message] = f.ID
+			}
+			for _, text := range r.Result.Suggestions {
+				key, ok := ruleIDs[text]
+				if !ok || key == "" {
+					key = suggestionKey(text)
+				}
+				suggestions = append(suggestions, item{key, text})
+			}
+		} else {
+			for _, f := range r.Result.LocalScore.Findings {
+				if f.Severity == scorer.SeverityHigh {
+					suggestions = append(suggestions, item{f.ID, f.Message})
+				}
+			}
+		}
+
+		for _, suggestion := range suggestions {
+			id := SuggestionID(r.URL, suggestion.key)
+			var desc strings.Builder
+			desc.WriteString(fmt.Sprintf("GEO audit finding for %s\n\n", r.URL))
+			desc.WriteString(fmt.Sprintf("Suggestion: %s\n", suggestion.text))
+			desc.WriteString(fmt.Sprintf("Current GEO score: %d/100\n", r.Result.Score))
+			if r.Result.Title != "" {
+				desc.WriteString(fmt.Sprintf("Page title: %s\n", r.Result.Title))
+			}
+			desc.WriteString(fmt.Sprintf("\ngeo-checker-id: %s\n", id))
+
+			issues = append(issues, Issue{
+				ID:          id,
+				Title:       fmt.Sprintf("[GEO] %s (%s)", suggestion.text, r.URL),
+				Description: desc.String(),
+				Labels:      append([]string{"geo-checker"}, opts.Labels...),
+			})
+		}
+	}
+	return issues
+}
+
+// Sync files every issue not already present in the tracker.
+func Sync(ctx context.Context, tracker Tracker, issues []Issue) *Report {
+	report := &Report{}
+	seen := make(map[string]bool)
+
+	for _, issue := range issues {
+		if seen[issue.ID] {
+			report.Skipped++
+			continue
+		}
+		seen[issue.ID] = true
+
+		exists, err := tracker.Exists(ctx, issue.ID)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("%s: %w", issue.ID, err))
+			continue
+		}
+		if exists {
+			report.Skipped++
+			continue
+		}
+
+		key, err := tracker.Create(ctx, issue)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("%s: %w", issue.ID, err))
+			continue
+		}
+		report.Created = append(report.Created, key)
+	}
+
+	return report
+}
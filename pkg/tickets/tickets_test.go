@@ -0,0 +1,38 @@
+package tickets
+
+import (
+	"testing"
+
+	"geo-checker/internal/bulk"
+	"geo-checker/pkg/analyzer"
+	"geo-checker/pkg/scorer"
+)
+
+func TestIssueIDsStableAcrossRuns(t *testing.T) {
+	run := func(days string) *bulk.BulkResult {
+		stale := "Review the page: it was last updated " + days + " days ago"
+		return &bulk.BulkResult{URL: "https://example.com/guide", Result: &analyzer.Result{
+			Suggestions: []string{stale, "Add " + days + " more examples"},
+			LocalScore: &scorer.GEOScore{Findings: []scorer.Finding{
+				{ID: "authority.freshness", Message: stale, Severity: scorer.SeverityHigh},
+			}},
+		}}
+	}
+
+	for _, opts := range []Options{{}, {All: true}} {
+		first := BuildIssues([]*bulk.BulkResult{run("400")}, opts)
+		second := BuildIssues([]*bulk.BulkResult{run("431")}, opts)
+		if len(first) == 0 || len(first) != len(second) {
+			t.Fatalf("all=%v: %d and %d issues", opts.All, len(first), len(second))
+		}
+		for i := range first {
+			if first[i].ID != second[i].ID {
+				t.Errorf("all=%v: %q and %q got IDs %s and %s", opts.All, first[i].Title, second[i].Title, first[i].ID, second[i].ID)
+			}
+		}
+	}
+
+	if other := BuildIssues([]*bulk.BulkResult{{URL: "https://example.com/other", Result: run("400").Result}}, Options{}); other[0].ID == BuildIssues([]*bulk.BulkResult{run("400")}, Options{})[0].ID {
+		t.Error("the same finding on another page got the same ID")
+	}
+}
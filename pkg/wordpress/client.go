@@ -0,0 +1,318 @@
+package wordpress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Client talks to the WordPress REST API (wp-json/wp/v2). Authentication
+// uses application passwords, which are required for drafts and write-back.
+type Client struct {
+	baseURL  string
+	username string
+	password string
+	client   *http.Client
+}
+
+// Post is the subset of a WordPress post or page used for analysis.
+type Post struct {
+	ID       int    `json:"id"`
+	Type     string `json:"type"`
+	Link     string `json:"link"`
+	Status   string `json:"status"`
+	Modified string `json:"modified_gmt"`
+	Title    struct {
+		Rendered string `json:"rendered"`
+	} `json:"title"`
+	Content struct {
+		Rendered string `json:"rendered"`
+	} `json:"content"`
+	Excerpt struct {
+		Rendered string `json:"rendered"`
+	} `json:"excerpt"`
+	YoastHead *struct {
+		Description string `json:"description"`
+	} `json:"yoast_head_json,omitempty"`
+	AIOSEOHead *struct {
+		Description string `json:"description"`
+	} `json:"aioseo_head_json,omitempty"`
+	// Meta is the registered post meta; WordPress sends [] when there is none
+	Meta json.RawMessage `json:"meta,omitempty"`
+}
+
+// SEO plugins whose meta description is read and written.
+const (
+	PluginYoast    = "yoast"
+	PluginRankMath = "rankmath"
+	PluginAIOSEO   = "aioseo"
+)
+
+// descriptionMeta are the post meta keys the SEO plugins keep the meta
+// description in.
+var descriptionMeta = map[string]string{
+	PluginYoast:    "_yoast_wpseo_metadesc",
+	PluginRankMath: "rank_math_description",
+	PluginAIOSEO:   "_aioseo_description",
+}
+
+// NewClient creates a client for the site at siteURL. Credentials are read
+// from WP_USERNAME and WP_APP_PASSWORD when present.
+func NewClient(siteURL string) (*Client, error) {
+	parsed, err := url.Parse(siteURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid WordPress site URL: %s", siteURL)
+	}
+
+	return &Client{
+		baseURL:  strings.TrimRight(siteURL, "/") + "/wp-json/wp/v2",
+		username: os.Getenv("WP_USERNAME"),
+		password: os.Getenv("WP_APP_PASSWORD"),
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (c *Client) Authenticated() bool {
+	return c.username != "" && c.password != ""
+}
+
+// ListPosts fetches up to limit items of the given type ("posts" or "pages")
+// with the given status, following pagination.
+func (c *Client) ListPosts(ctx context.Context, postType, status string, limit int) ([]Post, error) {
+	if status != "publish" && !c.Authenticated() {
+		return nil, fmt.Errorf("fetching %s with status %q requires WP_USERNAME and WP_APP_PASSWORD", postType, status)
+	}
+
+	var posts []Post
+	for page := 1; limit <= 0 || len(posts) < limit; page++ {
+		query := url.Values{
+			"per_page": {"100"},
+			"page":     {fmt.Sprintf("%d", page)},
+			"status":   {status},
+		}
+		if c.Authenticated() {
+			query.Set("context", "edit")
+		}
+
+		var batch []Post
+		more, err := c.get(ctx, fmt.Sprintf("/%s?%s", postType, query.Encode()), &batch)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, batch...)
+		if !more || len(batch) == 0 {
+			break
+		}
+	}
+
+	if limit > 0 && len(posts) > limit {
+		posts = posts[:limit]
+	}
+	return posts, nil
+}
+
+// HTML assembles a minimal document from the post so it can be run through
+// the regular extraction pipeline.
+func (p *Post) HTML() string {
+	var doc strings.Builder
+	doc.WriteString("<html><head><title>")
+	doc.WriteString(p.Title.Rendered)
+	doc.WriteString("</title>")
+	if desc := p.MetaDescription(); desc != "" {
+		doc.WriteString(`<meta name="description" content="`)
+		doc.WriteString(html.EscapeString(desc))
+		doc.WriteString(`">`)
+	}
+	doc.WriteString("</head><body><article><h1>")
+	doc.WriteString(p.Title.Rendered)
+	doc.WriteString("</h1>")
+	doc.WriteString(p.Content.Rendered)
+	doc.WriteString("</article></body></html>")
+	return doc.String()
+}
+
+// SEOPlugin returns the SEO plugin the post shows signs of: Yoast and All
+// in One SEO add their head to the REST response, Rank Math registers its
+// meta. Empty when none is detected.
+func (p *Post) SEOPlugin() string {
+	switch {
+	case p.YoastHead != nil:
+		return PluginYoast
+	case p.AIOSEOHead != nil:
+		return PluginAIOSEO
+	}
+	if _, ok := p.meta()[descriptionMeta[PluginRankMath]]; ok {
+		return PluginRankMath
+	}
+	return ""
+}
+
+// MetaDescription returns the SEO plugin description when one is exposed.
+func (p *Post) MetaDescription() string {
+	switch p.SEOPlugin() {
+	case PluginYoast:
+		return p.YoastHead.Description
+	case PluginAIOSEO:
+		return p.AIOSEOHead.Description
+	case PluginRankMath:
+		desc, _ := p.meta()[descriptionMeta[PluginRankMath]].(string)
+		return desc
+	}
+	return ""
+}
+
+// DescriptionField returns the post meta key the detected SEO plugin keeps
+// the meta description in; empty without one.
+func (p *Post) DescriptionField() string {
+	return descriptionMeta[p.SEOPlugin()]
+}
+
+func (p *Post) meta() map[string]any {
+	var meta map[string]any
+	json.Unmarshal(p.Meta, &meta) // [] when the post has none
+	return meta
+}
+
+// SaveDraftMetaDescription stores a suggested meta description in the post
+// meta key field as an autosave revision, leaving the published post
+// untouched until an editor reviews and publishes it. The key must be
+// registered for the REST API, as the SEO plugins do for their own.
+func (c *Client) SaveDraftMetaDescription(ctx context.Context, post *Post, field, description string) error {
+	if !c.Authenticated() {
+		return fmt.Errorf("writing drafts requires WP_USERNAME and WP_APP_PASSWORD")
+	}
+	if field == "" {
+		return fmt.Errorf("no meta field to write the description to")
+	}
+
+	body, err := json.Marshal(map[string]any{"meta": map[string]string{field: description}})
+	if err != nil {
+		return fmt.Errorf("failed to encode draft: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/%d/autosaves", c.baseURL, restBase(post.Type), post.ID)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("WordPress request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("WordPress API error (status %d): %s", resp.StatusCode, truncate(string(data), 200))
+	}
+	return nil
+}
+
+// get performs a GET request and reports whether more pages are available.
+func (c *Client) get(ctx context.Context, path string, out any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.Authenticated() {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("WordPress request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read WordPress response: %w", err)
+	}
+
+	// WordPress answers requests past the last page with 400 rest_post_invalid_page_number
+	if resp.StatusCode == http.StatusBadRequest && strings.Contains(string(data), "invalid_page_number") {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("WordPress API error (status %d): %s", resp.StatusCode, truncate(string(data), 200))
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("failed to parse WordPress response: %w", err)
+	}
+
+	totalPages := resp.Header.Get("X-WP-TotalPages")
+	currentPage := 1
+	if u, err := url.Parse(path); err == nil {
+		fmt.Sscanf(u.Query().Get("page"), "%d", &currentPage)
+	}
+	var total int
+	fmt.Sscanf(totalPages, "%d", &total)
+	return currentPage < total, nil
+}
+
+// restBase maps a post type to its REST collection.
+func restBase(postType string) string {
+	switch postType {
+	case "page":
+		return "pages"
+	case "post", "":
+		return "posts"
+	default:
+		return postType
+	}
+}
+
+// truncate cuts s to at most maxLength bytes, on a rune boundary and, when
+// one is near, after the last whole word.
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	cut := maxLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	if idx := strings.LastIndex(s[:cut], " "); s[cut] != ' ' && idx > cut/2 {
+		cut = idx
+	}
+	return s[:cut] + "..."
+}
+
+// maxMetaDescription is the length in characters search engines show of a
+// meta description.
+const maxMetaDescription = 155
+
+// SuggestMetaDescription drafts a meta description from the opening of the
+// extracted content, cut at a sentence or word boundary to stay under 155
+// characters.
+func SuggestMetaDescription(content string) string {
+	text := strings.Join(strings.Fields(content), " ")
+	runes := []rune(text)
+	if len(runes) <= maxMetaDescription {
+		return text
+	}
+
+	cut := string(runes[:maxMetaDescription])
+	for _, stop := range []string{". ", "。"} {
+		if idx := strings.LastIndex(cut, stop); idx >= 0 && utf8.RuneCountInString(cut[:idx]) > 80 {
+			return cut[:idx+len(strings.TrimSpace(stop))]
+		}
+	}
+	if idx := strings.LastIndex(cut, " "); idx > 0 {
+		cut = cut[:idx]
+	}
+	return strings.TrimRight(cut, ",;:、，") + "…"
+}
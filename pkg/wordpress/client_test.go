@@ -0,0 +1,86 @@
+package wordpress
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSEOPlugin(t *testing.T) {
+	for name, tc := range map[string]struct {
+		post        string
+		plugin      string
+		description string
+	}{
+		"none":      {`{"id":1,"meta":[]}`, "", ""},
+		"yoast":     {`{"id":1,"yoast_head_json":{"description":"Yoast text"}}`, PluginYoast, "Yoast text"},
+		"aioseo":    {`{"id":1,"aioseo_head_json":{"description":""}}`, PluginAIOSEO, ""},
+		"rank math": {`{"id":1,"meta":{"rank_math_description":"Rank Math text"}}`, PluginRankMath, "Rank Math text"},
+	} {
+		var post Post
+		if err := json.Unmarshal([]byte(tc.post), &post); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := post.SEOPlugin(); got != tc.plugin {
+			t.Errorf("%s: plugin %q, want %q", name, got, tc.plugin)
+		}
+		if got := post.MetaDescription(); got != tc.description {
+			t.Errorf("%s: description %q, want %q", name, got, tc.description)
+		}
+	}
+}
+
+func TestSaveDraftMetaDescription(t *testing.T) {
+	var path string
+	var body map[string]map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	t.Setenv("WP_USERNAME", "editor")
+	t.Setenv("WP_APP_PASSWORD", "secret")
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := &Post{ID: 7, Type: "post", YoastHead: &struct {
+		Description string `json:"description"`
+	}{}}
+	if err := client.SaveDraftMetaDescription(context.Background(), post, post.DescriptionField(), "A summary."); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/wp-json/wp/v2/posts/7/autosaves" || body["meta"]["_yoast_wpseo_metadesc"] != "A summary." {
+		t.Errorf("wrote %v to %s", body, path)
+	}
+	if err := client.SaveDraftMetaDescription(context.Background(), &Post{ID: 8}, "", "A summary."); err == nil {
+		t.Error("wrote without a meta field")
+	}
+}
+
+func TestSuggestMetaDescriptionNonASCII(t *testing.T) {
+	german := strings.Repeat("Größere Fenster lassen mehr Tageslicht in die Wohnräume übergroßer Häuser ", 3)
+	got := SuggestMetaDescription(german)
+	prefix := strings.TrimSuffix(got, "…")
+	if !utf8.ValidString(got) || utf8.RuneCountInString(prefix) > 155 || !strings.HasPrefix(german, prefix+" ") {
+		t.Errorf("SuggestMetaDescription(german) = %q, want whole words within 155 characters", got)
+	}
+
+	chinese := strings.Repeat("生成式引擎优化帮助内容被人工智能助手引用和推荐。", 10)
+	if got := SuggestMetaDescription(chinese); !utf8.ValidString(got) || !strings.HasSuffix(got, "。") || utf8.RuneCountInString(got) > 155 {
+		t.Errorf("SuggestMetaDescription(chinese) = %q, want whole sentences within 155 characters", got)
+	}
+
+	if got := truncate("Zugriff verweigert für Benutzer ä", 32); got != "Zugriff verweigert für Benutzer..." {
+		t.Errorf("truncate = %q", got)
+	}
+	if got := truncate("Ungültige Anfrage", 4); got != "Ung..." {
+		t.Errorf("truncate = %q, want the cut moved off the ü", got)
+	}
+}